@@ -51,6 +51,17 @@ var OrgRolePermissionEnum = &struct {
 	UpdateColonIntegrationsettings postgres.StringExpression
 	GetColonMessagetemplates       postgres.StringExpression
 	GetColonPhonenumbers           postgres.StringExpression
+	GetColonCannedresponse         postgres.StringExpression
+	CreateColonCannedresponse      postgres.StringExpression
+	UpdateColonCannedresponse      postgres.StringExpression
+	DeleteColonCannedresponse      postgres.StringExpression
+	GetColonChatbotflow            postgres.StringExpression
+	CreateColonChatbotflow         postgres.StringExpression
+	UpdateColonChatbotflow         postgres.StringExpression
+	DeleteColonChatbotflow         postgres.StringExpression
+	CreateColonMessagetemplates    postgres.StringExpression
+	UpdateColonMessagetemplates    postgres.StringExpression
+	DeleteColonMessagetemplates    postgres.StringExpression
 }{
 	GetColonOrganizationmember:     postgres.NewEnumValue("Get:OrganizationMember"),
 	CreateColonOrganizationmember:  postgres.NewEnumValue("Create:OrganizationMember"),
@@ -93,4 +104,15 @@ var OrgRolePermissionEnum = &struct {
 	UpdateColonIntegrationsettings: postgres.NewEnumValue("Update:IntegrationSettings"),
 	GetColonMessagetemplates:       postgres.NewEnumValue("Get:MessageTemplates"),
 	GetColonPhonenumbers:           postgres.NewEnumValue("Get:PhoneNumbers"),
+	GetColonCannedresponse:         postgres.NewEnumValue("Get:CannedResponse"),
+	CreateColonCannedresponse:      postgres.NewEnumValue("Create:CannedResponse"),
+	UpdateColonCannedresponse:      postgres.NewEnumValue("Update:CannedResponse"),
+	DeleteColonCannedresponse:      postgres.NewEnumValue("Delete:CannedResponse"),
+	GetColonChatbotflow:            postgres.NewEnumValue("Get:ChatbotFlow"),
+	CreateColonChatbotflow:         postgres.NewEnumValue("Create:ChatbotFlow"),
+	UpdateColonChatbotflow:         postgres.NewEnumValue("Update:ChatbotFlow"),
+	DeleteColonChatbotflow:         postgres.NewEnumValue("Delete:ChatbotFlow"),
+	CreateColonMessagetemplates:    postgres.NewEnumValue("Create:MessageTemplates"),
+	UpdateColonMessagetemplates:    postgres.NewEnumValue("Update:MessageTemplates"),
+	DeleteColonMessagetemplates:    postgres.NewEnumValue("Delete:MessageTemplates"),
 }
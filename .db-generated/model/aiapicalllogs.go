@@ -13,13 +13,18 @@ import (
 )
 
 type AiApiCallLogs struct {
-	UniqueId        uuid.UUID `sql:"primary_key"`
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
-	Request         string
-	Response        string
-	InputTokenUsed  int32
-	OutputTokenUsed int32
-	OrganizationId  uuid.UUID
-	Model           AiModelEnum
+	UniqueId             uuid.UUID `sql:"primary_key"`
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	Request              string
+	Response             string
+	InputTokenUsed       int32
+	OutputTokenUsed      int32
+	OrganizationId       uuid.UUID
+	Model                AiModelEnum
+	IsCacheHit           bool
+	CacheSimilarity      *float64
+	Feature              string
+	OrganizationMemberId *uuid.UUID
+	CostInUsd            float64
 }
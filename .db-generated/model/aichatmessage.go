@@ -21,4 +21,5 @@ type AiChatMessage struct {
 	OrganizationId       uuid.UUID
 	OrganizationMemberId uuid.UUID
 	Role                 AiChatMessageRoleEnum
+	Citations            *string
 }
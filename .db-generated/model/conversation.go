@@ -13,13 +13,19 @@ import (
 )
 
 type Conversation struct {
-	UniqueId              uuid.UUID `sql:"primary_key"`
-	CreatedAt             time.Time
-	UpdatedAt             time.Time
-	ContactId             uuid.UUID
-	OrganizationId        uuid.UUID
-	Status                ConversationStatusEnum
-	PhoneNumberUsed       string
-	InitiatedBy           ConversationInitiatedEnum
-	InitiatedByCampaignId *uuid.UUID
+	UniqueId                   uuid.UUID `sql:"primary_key"`
+	CreatedAt                  time.Time
+	UpdatedAt                  time.Time
+	ContactId                  uuid.UUID
+	OrganizationId             uuid.UUID
+	Status                     ConversationStatusEnum
+	PhoneNumberUsed            string
+	InitiatedBy                ConversationInitiatedEnum
+	InitiatedByCampaignId      *uuid.UUID
+	SnoozedUntil               *time.Time
+	IsSnoozedUntilContactReply bool
+	Sentiment                  *MessageSentimentEnum
+	Intent                     *MessageIntentEnum
+	Language                   *string
+	Priority                   ConversationPriorityEnum
 }
@@ -16,6 +16,7 @@ const (
 	ConversationStatusEnum_Closed   ConversationStatusEnum = "Closed"
 	ConversationStatusEnum_Deleted  ConversationStatusEnum = "Deleted"
 	ConversationStatusEnum_Resolved ConversationStatusEnum = "Resolved"
+	ConversationStatusEnum_Snoozed  ConversationStatusEnum = "Snoozed"
 )
 
 func (e *ConversationStatusEnum) Scan(value interface{}) error {
@@ -38,6 +39,8 @@ func (e *ConversationStatusEnum) Scan(value interface{}) error {
 		*e = ConversationStatusEnum_Deleted
 	case "Resolved":
 		*e = ConversationStatusEnum_Resolved
+	case "Snoozed":
+		*e = ConversationStatusEnum_Snoozed
 	default:
 		return errors.New("jet: Invalid scan value '" + enumValue + "' for ConversationStatusEnum enum")
 	}
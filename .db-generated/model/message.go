@@ -28,4 +28,10 @@ type Message struct {
 	Status                    MessageStatusEnum
 	MessageType               MessageTypeEnum
 	RepliedTo                 *uuid.UUID
+	Reactions                 *string
+	IsAiGenerated             bool
+	Sentiment                 *MessageSentimentEnum
+	Intent                    *MessageIntentEnum
+	Language                  *string
+	ClassifiedAt              *time.Time
 }
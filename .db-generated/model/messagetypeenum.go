@@ -12,18 +12,21 @@ import "errors"
 type MessageTypeEnum string
 
 const (
-	MessageTypeEnum_Text        MessageTypeEnum = "Text"
-	MessageTypeEnum_Image       MessageTypeEnum = "Image"
-	MessageTypeEnum_Video       MessageTypeEnum = "Video"
-	MessageTypeEnum_Audio       MessageTypeEnum = "Audio"
-	MessageTypeEnum_Document    MessageTypeEnum = "Document"
-	MessageTypeEnum_Sticker     MessageTypeEnum = "Sticker"
-	MessageTypeEnum_Location    MessageTypeEnum = "Location"
-	MessageTypeEnum_Contacts    MessageTypeEnum = "Contacts"
-	MessageTypeEnum_Reaction    MessageTypeEnum = "Reaction"
-	MessageTypeEnum_Address     MessageTypeEnum = "Address"
-	MessageTypeEnum_Interactive MessageTypeEnum = "Interactive"
-	MessageTypeEnum_Template    MessageTypeEnum = "Template"
+	MessageTypeEnum_Text             MessageTypeEnum = "Text"
+	MessageTypeEnum_Image            MessageTypeEnum = "Image"
+	MessageTypeEnum_Video            MessageTypeEnum = "Video"
+	MessageTypeEnum_Audio            MessageTypeEnum = "Audio"
+	MessageTypeEnum_Document         MessageTypeEnum = "Document"
+	MessageTypeEnum_Sticker          MessageTypeEnum = "Sticker"
+	MessageTypeEnum_Location         MessageTypeEnum = "Location"
+	MessageTypeEnum_Contacts         MessageTypeEnum = "Contacts"
+	MessageTypeEnum_Reaction         MessageTypeEnum = "Reaction"
+	MessageTypeEnum_Address          MessageTypeEnum = "Address"
+	MessageTypeEnum_Interactive      MessageTypeEnum = "Interactive"
+	MessageTypeEnum_Template         MessageTypeEnum = "Template"
+	MessageTypeEnum_InteractiveReply MessageTypeEnum = "InteractiveReply"
+	MessageTypeEnum_Order            MessageTypeEnum = "Order"
+	MessageTypeEnum_ProductInquiry   MessageTypeEnum = "ProductInquiry"
 )
 
 func (e *MessageTypeEnum) Scan(value interface{}) error {
@@ -62,6 +65,12 @@ func (e *MessageTypeEnum) Scan(value interface{}) error {
 		*e = MessageTypeEnum_Interactive
 	case "Template":
 		*e = MessageTypeEnum_Template
+	case "InteractiveReply":
+		*e = MessageTypeEnum_InteractiveReply
+	case "Order":
+		*e = MessageTypeEnum_Order
+	case "ProductInquiry":
+		*e = MessageTypeEnum_ProductInquiry
 	default:
 		return errors.New("jet: Invalid scan value '" + enumValue + "' for MessageTypeEnum enum")
 	}
@@ -53,6 +53,17 @@ const (
 	OrgRolePermissionEnum_UpdateColonIntegrationsettings OrgRolePermissionEnum = "Update:IntegrationSettings"
 	OrgRolePermissionEnum_GetColonMessagetemplates       OrgRolePermissionEnum = "Get:MessageTemplates"
 	OrgRolePermissionEnum_GetColonPhonenumbers           OrgRolePermissionEnum = "Get:PhoneNumbers"
+	OrgRolePermissionEnum_GetColonCannedresponse         OrgRolePermissionEnum = "Get:CannedResponse"
+	OrgRolePermissionEnum_CreateColonCannedresponse      OrgRolePermissionEnum = "Create:CannedResponse"
+	OrgRolePermissionEnum_UpdateColonCannedresponse      OrgRolePermissionEnum = "Update:CannedResponse"
+	OrgRolePermissionEnum_DeleteColonCannedresponse      OrgRolePermissionEnum = "Delete:CannedResponse"
+	OrgRolePermissionEnum_GetColonChatbotflow            OrgRolePermissionEnum = "Get:ChatbotFlow"
+	OrgRolePermissionEnum_CreateColonChatbotflow         OrgRolePermissionEnum = "Create:ChatbotFlow"
+	OrgRolePermissionEnum_UpdateColonChatbotflow         OrgRolePermissionEnum = "Update:ChatbotFlow"
+	OrgRolePermissionEnum_DeleteColonChatbotflow         OrgRolePermissionEnum = "Delete:ChatbotFlow"
+	OrgRolePermissionEnum_CreateColonMessagetemplates    OrgRolePermissionEnum = "Create:MessageTemplates"
+	OrgRolePermissionEnum_UpdateColonMessagetemplates    OrgRolePermissionEnum = "Update:MessageTemplates"
+	OrgRolePermissionEnum_DeleteColonMessagetemplates    OrgRolePermissionEnum = "Delete:MessageTemplates"
 )
 
 func (e *OrgRolePermissionEnum) Scan(value interface{}) error {
@@ -149,6 +160,28 @@ func (e *OrgRolePermissionEnum) Scan(value interface{}) error {
 		*e = OrgRolePermissionEnum_GetColonMessagetemplates
 	case "Get:PhoneNumbers":
 		*e = OrgRolePermissionEnum_GetColonPhonenumbers
+	case "Get:CannedResponse":
+		*e = OrgRolePermissionEnum_GetColonCannedresponse
+	case "Create:CannedResponse":
+		*e = OrgRolePermissionEnum_CreateColonCannedresponse
+	case "Update:CannedResponse":
+		*e = OrgRolePermissionEnum_UpdateColonCannedresponse
+	case "Delete:CannedResponse":
+		*e = OrgRolePermissionEnum_DeleteColonCannedresponse
+	case "Get:ChatbotFlow":
+		*e = OrgRolePermissionEnum_GetColonChatbotflow
+	case "Create:ChatbotFlow":
+		*e = OrgRolePermissionEnum_CreateColonChatbotflow
+	case "Update:ChatbotFlow":
+		*e = OrgRolePermissionEnum_UpdateColonChatbotflow
+	case "Delete:ChatbotFlow":
+		*e = OrgRolePermissionEnum_DeleteColonChatbotflow
+	case "Create:MessageTemplates":
+		*e = OrgRolePermissionEnum_CreateColonMessagetemplates
+	case "Update:MessageTemplates":
+		*e = OrgRolePermissionEnum_UpdateColonMessagetemplates
+	case "Delete:MessageTemplates":
+		*e = OrgRolePermissionEnum_DeleteColonMessagetemplates
 	default:
 		return errors.New("jet: Invalid scan value '" + enumValue + "' for OrgRolePermissionEnum enum")
 	}
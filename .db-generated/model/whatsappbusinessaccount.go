@@ -22,4 +22,5 @@ type WhatsappBusinessAccount struct {
 	OrganizationId uuid.UUID
 	PhoneNumberId  string
 	Status         WhatsAppBusinessAccountVerificationStatus
+	Name           *string
 }
@@ -17,15 +17,20 @@ type aiApiCallLogsTable struct {
 	postgres.Table
 
 	// Columns
-	UniqueId        postgres.ColumnString
-	CreatedAt       postgres.ColumnTimestampz
-	UpdatedAt       postgres.ColumnTimestampz
-	Request         postgres.ColumnString
-	Response        postgres.ColumnString
-	InputTokenUsed  postgres.ColumnInteger
-	OutputTokenUsed postgres.ColumnInteger
-	OrganizationId  postgres.ColumnString
-	Model           postgres.ColumnString
+	UniqueId             postgres.ColumnString
+	CreatedAt            postgres.ColumnTimestampz
+	UpdatedAt            postgres.ColumnTimestampz
+	Request              postgres.ColumnString
+	Response             postgres.ColumnString
+	InputTokenUsed       postgres.ColumnInteger
+	OutputTokenUsed      postgres.ColumnInteger
+	OrganizationId       postgres.ColumnString
+	Model                postgres.ColumnString
+	IsCacheHit           postgres.ColumnBool
+	CacheSimilarity      postgres.ColumnFloat
+	Feature              postgres.ColumnString
+	OrganizationMemberId postgres.ColumnString
+	CostInUsd            postgres.ColumnFloat
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -66,32 +71,42 @@ func newAiApiCallLogsTable(schemaName, tableName, alias string) *AiApiCallLogsTa
 
 func newAiApiCallLogsTableImpl(schemaName, tableName, alias string) aiApiCallLogsTable {
 	var (
-		UniqueIdColumn        = postgres.StringColumn("UniqueId")
-		CreatedAtColumn       = postgres.TimestampzColumn("CreatedAt")
-		UpdatedAtColumn       = postgres.TimestampzColumn("UpdatedAt")
-		RequestColumn         = postgres.StringColumn("Request")
-		ResponseColumn        = postgres.StringColumn("Response")
-		InputTokenUsedColumn  = postgres.IntegerColumn("InputTokenUsed")
-		OutputTokenUsedColumn = postgres.IntegerColumn("OutputTokenUsed")
-		OrganizationIdColumn  = postgres.StringColumn("OrganizationId")
-		ModelColumn           = postgres.StringColumn("Model")
-		allColumns            = postgres.ColumnList{UniqueIdColumn, CreatedAtColumn, UpdatedAtColumn, RequestColumn, ResponseColumn, InputTokenUsedColumn, OutputTokenUsedColumn, OrganizationIdColumn, ModelColumn}
-		mutableColumns        = postgres.ColumnList{CreatedAtColumn, UpdatedAtColumn, RequestColumn, ResponseColumn, InputTokenUsedColumn, OutputTokenUsedColumn, OrganizationIdColumn, ModelColumn}
+		UniqueIdColumn             = postgres.StringColumn("UniqueId")
+		CreatedAtColumn            = postgres.TimestampzColumn("CreatedAt")
+		UpdatedAtColumn            = postgres.TimestampzColumn("UpdatedAt")
+		RequestColumn              = postgres.StringColumn("Request")
+		ResponseColumn             = postgres.StringColumn("Response")
+		InputTokenUsedColumn       = postgres.IntegerColumn("InputTokenUsed")
+		OutputTokenUsedColumn      = postgres.IntegerColumn("OutputTokenUsed")
+		OrganizationIdColumn       = postgres.StringColumn("OrganizationId")
+		ModelColumn                = postgres.StringColumn("Model")
+		IsCacheHitColumn           = postgres.BoolColumn("IsCacheHit")
+		CacheSimilarityColumn      = postgres.FloatColumn("CacheSimilarity")
+		FeatureColumn              = postgres.StringColumn("Feature")
+		OrganizationMemberIdColumn = postgres.StringColumn("OrganizationMemberId")
+		CostInUsdColumn            = postgres.FloatColumn("CostInUsd")
+		allColumns                 = postgres.ColumnList{UniqueIdColumn, CreatedAtColumn, UpdatedAtColumn, RequestColumn, ResponseColumn, InputTokenUsedColumn, OutputTokenUsedColumn, OrganizationIdColumn, ModelColumn, IsCacheHitColumn, CacheSimilarityColumn, FeatureColumn, OrganizationMemberIdColumn, CostInUsdColumn}
+		mutableColumns             = postgres.ColumnList{CreatedAtColumn, UpdatedAtColumn, RequestColumn, ResponseColumn, InputTokenUsedColumn, OutputTokenUsedColumn, OrganizationIdColumn, ModelColumn, IsCacheHitColumn, CacheSimilarityColumn, FeatureColumn, OrganizationMemberIdColumn, CostInUsdColumn}
 	)
 
 	return aiApiCallLogsTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		UniqueId:        UniqueIdColumn,
-		CreatedAt:       CreatedAtColumn,
-		UpdatedAt:       UpdatedAtColumn,
-		Request:         RequestColumn,
-		Response:        ResponseColumn,
-		InputTokenUsed:  InputTokenUsedColumn,
-		OutputTokenUsed: OutputTokenUsedColumn,
-		OrganizationId:  OrganizationIdColumn,
-		Model:           ModelColumn,
+		UniqueId:             UniqueIdColumn,
+		CreatedAt:            CreatedAtColumn,
+		UpdatedAt:            UpdatedAtColumn,
+		Request:              RequestColumn,
+		Response:             ResponseColumn,
+		InputTokenUsed:       InputTokenUsedColumn,
+		OutputTokenUsed:      OutputTokenUsedColumn,
+		OrganizationId:       OrganizationIdColumn,
+		Model:                ModelColumn,
+		IsCacheHit:           IsCacheHitColumn,
+		CacheSimilarity:      CacheSimilarityColumn,
+		Feature:              FeatureColumn,
+		OrganizationMemberId: OrganizationMemberIdColumn,
+		CostInUsd:            CostInUsdColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
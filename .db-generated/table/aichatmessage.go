@@ -25,6 +25,7 @@ type aiChatMessageTable struct {
 	OrganizationId       postgres.ColumnString
 	OrganizationMemberId postgres.ColumnString
 	Role                 postgres.ColumnString
+	Citations            postgres.ColumnString
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -73,8 +74,9 @@ func newAiChatMessageTableImpl(schemaName, tableName, alias string) aiChatMessag
 		OrganizationIdColumn       = postgres.StringColumn("OrganizationId")
 		OrganizationMemberIdColumn = postgres.StringColumn("OrganizationMemberId")
 		RoleColumn                 = postgres.StringColumn("Role")
-		allColumns                 = postgres.ColumnList{UniqueIdColumn, CreatedAtColumn, UpdatedAtColumn, ContentColumn, AiChatIdColumn, OrganizationIdColumn, OrganizationMemberIdColumn, RoleColumn}
-		mutableColumns             = postgres.ColumnList{CreatedAtColumn, UpdatedAtColumn, ContentColumn, AiChatIdColumn, OrganizationIdColumn, OrganizationMemberIdColumn, RoleColumn}
+		CitationsColumn            = postgres.StringColumn("Citations")
+		allColumns                 = postgres.ColumnList{UniqueIdColumn, CreatedAtColumn, UpdatedAtColumn, ContentColumn, AiChatIdColumn, OrganizationIdColumn, OrganizationMemberIdColumn, RoleColumn, CitationsColumn}
+		mutableColumns             = postgres.ColumnList{CreatedAtColumn, UpdatedAtColumn, ContentColumn, AiChatIdColumn, OrganizationIdColumn, OrganizationMemberIdColumn, RoleColumn, CitationsColumn}
 	)
 
 	return aiChatMessageTable{
@@ -89,6 +91,7 @@ func newAiChatMessageTableImpl(schemaName, tableName, alias string) aiChatMessag
 		OrganizationId:       OrganizationIdColumn,
 		OrganizationMemberId: OrganizationMemberIdColumn,
 		Role:                 RoleColumn,
+		Citations:            CitationsColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
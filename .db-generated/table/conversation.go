@@ -17,15 +17,21 @@ type conversationTable struct {
 	postgres.Table
 
 	// Columns
-	UniqueId              postgres.ColumnString
-	CreatedAt             postgres.ColumnTimestampz
-	UpdatedAt             postgres.ColumnTimestampz
-	ContactId             postgres.ColumnString
-	OrganizationId        postgres.ColumnString
-	Status                postgres.ColumnString
-	PhoneNumberUsed       postgres.ColumnString
-	InitiatedBy           postgres.ColumnString
-	InitiatedByCampaignId postgres.ColumnString
+	UniqueId                   postgres.ColumnString
+	CreatedAt                  postgres.ColumnTimestampz
+	UpdatedAt                  postgres.ColumnTimestampz
+	ContactId                  postgres.ColumnString
+	OrganizationId             postgres.ColumnString
+	Status                     postgres.ColumnString
+	PhoneNumberUsed            postgres.ColumnString
+	InitiatedBy                postgres.ColumnString
+	InitiatedByCampaignId      postgres.ColumnString
+	SnoozedUntil               postgres.ColumnTimestampz
+	IsSnoozedUntilContactReply postgres.ColumnBool
+	Sentiment                  postgres.ColumnString
+	Intent                     postgres.ColumnString
+	Language                   postgres.ColumnString
+	Priority                   postgres.ColumnString
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -66,32 +72,44 @@ func newConversationTable(schemaName, tableName, alias string) *ConversationTabl
 
 func newConversationTableImpl(schemaName, tableName, alias string) conversationTable {
 	var (
-		UniqueIdColumn              = postgres.StringColumn("UniqueId")
-		CreatedAtColumn             = postgres.TimestampzColumn("CreatedAt")
-		UpdatedAtColumn             = postgres.TimestampzColumn("UpdatedAt")
-		ContactIdColumn             = postgres.StringColumn("ContactId")
-		OrganizationIdColumn        = postgres.StringColumn("OrganizationId")
-		StatusColumn                = postgres.StringColumn("Status")
-		PhoneNumberUsedColumn       = postgres.StringColumn("PhoneNumberUsed")
-		InitiatedByColumn           = postgres.StringColumn("InitiatedBy")
-		InitiatedByCampaignIdColumn = postgres.StringColumn("InitiatedByCampaignId")
-		allColumns                  = postgres.ColumnList{UniqueIdColumn, CreatedAtColumn, UpdatedAtColumn, ContactIdColumn, OrganizationIdColumn, StatusColumn, PhoneNumberUsedColumn, InitiatedByColumn, InitiatedByCampaignIdColumn}
-		mutableColumns              = postgres.ColumnList{CreatedAtColumn, UpdatedAtColumn, ContactIdColumn, OrganizationIdColumn, StatusColumn, PhoneNumberUsedColumn, InitiatedByColumn, InitiatedByCampaignIdColumn}
+		UniqueIdColumn                   = postgres.StringColumn("UniqueId")
+		CreatedAtColumn                  = postgres.TimestampzColumn("CreatedAt")
+		UpdatedAtColumn                  = postgres.TimestampzColumn("UpdatedAt")
+		ContactIdColumn                  = postgres.StringColumn("ContactId")
+		OrganizationIdColumn             = postgres.StringColumn("OrganizationId")
+		StatusColumn                     = postgres.StringColumn("Status")
+		PhoneNumberUsedColumn            = postgres.StringColumn("PhoneNumberUsed")
+		InitiatedByColumn                = postgres.StringColumn("InitiatedBy")
+		InitiatedByCampaignIdColumn      = postgres.StringColumn("InitiatedByCampaignId")
+		SnoozedUntilColumn               = postgres.TimestampzColumn("SnoozedUntil")
+		IsSnoozedUntilContactReplyColumn = postgres.BoolColumn("IsSnoozedUntilContactReply")
+		SentimentColumn                  = postgres.StringColumn("Sentiment")
+		IntentColumn                     = postgres.StringColumn("Intent")
+		LanguageColumn                   = postgres.StringColumn("Language")
+		PriorityColumn                   = postgres.StringColumn("Priority")
+		allColumns                       = postgres.ColumnList{UniqueIdColumn, CreatedAtColumn, UpdatedAtColumn, ContactIdColumn, OrganizationIdColumn, StatusColumn, PhoneNumberUsedColumn, InitiatedByColumn, InitiatedByCampaignIdColumn, SnoozedUntilColumn, IsSnoozedUntilContactReplyColumn, SentimentColumn, IntentColumn, LanguageColumn, PriorityColumn}
+		mutableColumns                   = postgres.ColumnList{CreatedAtColumn, UpdatedAtColumn, ContactIdColumn, OrganizationIdColumn, StatusColumn, PhoneNumberUsedColumn, InitiatedByColumn, InitiatedByCampaignIdColumn, SnoozedUntilColumn, IsSnoozedUntilContactReplyColumn, SentimentColumn, IntentColumn, LanguageColumn, PriorityColumn}
 	)
 
 	return conversationTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		UniqueId:              UniqueIdColumn,
-		CreatedAt:             CreatedAtColumn,
-		UpdatedAt:             UpdatedAtColumn,
-		ContactId:             ContactIdColumn,
-		OrganizationId:        OrganizationIdColumn,
-		Status:                StatusColumn,
-		PhoneNumberUsed:       PhoneNumberUsedColumn,
-		InitiatedBy:           InitiatedByColumn,
-		InitiatedByCampaignId: InitiatedByCampaignIdColumn,
+		UniqueId:                   UniqueIdColumn,
+		CreatedAt:                  CreatedAtColumn,
+		UpdatedAt:                  UpdatedAtColumn,
+		ContactId:                  ContactIdColumn,
+		OrganizationId:             OrganizationIdColumn,
+		Status:                     StatusColumn,
+		PhoneNumberUsed:            PhoneNumberUsedColumn,
+		InitiatedBy:                InitiatedByColumn,
+		InitiatedByCampaignId:      InitiatedByCampaignIdColumn,
+		SnoozedUntil:               SnoozedUntilColumn,
+		IsSnoozedUntilContactReply: IsSnoozedUntilContactReplyColumn,
+		Sentiment:                  SentimentColumn,
+		Intent:                     IntentColumn,
+		Language:                   LanguageColumn,
+		Priority:                   PriorityColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
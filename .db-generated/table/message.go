@@ -32,6 +32,12 @@ type messageTable struct {
 	Status                    postgres.ColumnString
 	MessageType               postgres.ColumnString
 	RepliedTo                 postgres.ColumnString
+	Reactions                 postgres.ColumnString
+	IsAiGenerated             postgres.ColumnBool
+	Sentiment                 postgres.ColumnString
+	Intent                    postgres.ColumnString
+	Language                  postgres.ColumnString
+	ClassifiedAt              postgres.ColumnTimestampz
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -87,8 +93,14 @@ func newMessageTableImpl(schemaName, tableName, alias string) messageTable {
 		StatusColumn                    = postgres.StringColumn("Status")
 		MessageTypeColumn               = postgres.StringColumn("MessageType")
 		RepliedToColumn                 = postgres.StringColumn("RepliedTo")
-		allColumns                      = postgres.ColumnList{UniqueIdColumn, WhatsAppMessageIdColumn, WhatsappBusinessAccountIdColumn, CreatedAtColumn, UpdatedAtColumn, ConversationIdColumn, CampaignIdColumn, ContactIdColumn, PhoneNumberUsedColumn, DirectionColumn, MessageDataColumn, OrganizationIdColumn, StatusColumn, MessageTypeColumn, RepliedToColumn}
-		mutableColumns                  = postgres.ColumnList{WhatsAppMessageIdColumn, WhatsappBusinessAccountIdColumn, CreatedAtColumn, UpdatedAtColumn, ConversationIdColumn, CampaignIdColumn, ContactIdColumn, PhoneNumberUsedColumn, DirectionColumn, MessageDataColumn, OrganizationIdColumn, StatusColumn, MessageTypeColumn, RepliedToColumn}
+		ReactionsColumn                 = postgres.StringColumn("Reactions")
+		IsAiGeneratedColumn             = postgres.BoolColumn("IsAiGenerated")
+		SentimentColumn                 = postgres.StringColumn("Sentiment")
+		IntentColumn                    = postgres.StringColumn("Intent")
+		LanguageColumn                  = postgres.StringColumn("Language")
+		ClassifiedAtColumn              = postgres.TimestampzColumn("ClassifiedAt")
+		allColumns                      = postgres.ColumnList{UniqueIdColumn, WhatsAppMessageIdColumn, WhatsappBusinessAccountIdColumn, CreatedAtColumn, UpdatedAtColumn, ConversationIdColumn, CampaignIdColumn, ContactIdColumn, PhoneNumberUsedColumn, DirectionColumn, MessageDataColumn, OrganizationIdColumn, StatusColumn, MessageTypeColumn, RepliedToColumn, ReactionsColumn, IsAiGeneratedColumn, SentimentColumn, IntentColumn, LanguageColumn, ClassifiedAtColumn}
+		mutableColumns                  = postgres.ColumnList{WhatsAppMessageIdColumn, WhatsappBusinessAccountIdColumn, CreatedAtColumn, UpdatedAtColumn, ConversationIdColumn, CampaignIdColumn, ContactIdColumn, PhoneNumberUsedColumn, DirectionColumn, MessageDataColumn, OrganizationIdColumn, StatusColumn, MessageTypeColumn, RepliedToColumn, ReactionsColumn, IsAiGeneratedColumn, SentimentColumn, IntentColumn, LanguageColumn, ClassifiedAtColumn}
 	)
 
 	return messageTable{
@@ -110,6 +122,12 @@ func newMessageTableImpl(schemaName, tableName, alias string) messageTable {
 		Status:                    StatusColumn,
 		MessageType:               MessageTypeColumn,
 		RepliedTo:                 RepliedToColumn,
+		Reactions:                 ReactionsColumn,
+		IsAiGenerated:             IsAiGeneratedColumn,
+		Sentiment:                 SentimentColumn,
+		Intent:                    IntentColumn,
+		Language:                  LanguageColumn,
+		ClassifiedAt:              ClassifiedAtColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -17,23 +17,25 @@ type organizationTable struct {
 	postgres.Table
 
 	// Columns
-	UniqueId           postgres.ColumnString
-	CreatedAt          postgres.ColumnTimestampz
-	UpdatedAt          postgres.ColumnTimestampz
-	Name               postgres.ColumnString
-	Description        postgres.ColumnString
-	WebsiteUrl         postgres.ColumnString
-	LogoUrl            postgres.ColumnString
-	FaviconUrl         postgres.ColumnString
-	SlackWebhookUrl    postgres.ColumnString
-	SlackChannel       postgres.ColumnString
-	SmtpClientHost     postgres.ColumnString
-	SmtpClientUsername postgres.ColumnString
-	SmtpClientPassword postgres.ColumnString
-	SmtpClientPort     postgres.ColumnString
-	IsAiEnabled        postgres.ColumnBool
-	AiModel            postgres.ColumnString
-	AiApiKey           postgres.ColumnString
+	UniqueId                            postgres.ColumnString
+	CreatedAt                           postgres.ColumnTimestampz
+	UpdatedAt                           postgres.ColumnTimestampz
+	Name                                postgres.ColumnString
+	Description                         postgres.ColumnString
+	WebsiteUrl                          postgres.ColumnString
+	LogoUrl                             postgres.ColumnString
+	FaviconUrl                          postgres.ColumnString
+	SlackWebhookUrl                     postgres.ColumnString
+	SlackChannel                        postgres.ColumnString
+	SmtpClientHost                      postgres.ColumnString
+	SmtpClientUsername                  postgres.ColumnString
+	SmtpClientPassword                  postgres.ColumnString
+	SmtpClientPort                      postgres.ColumnString
+	IsAiEnabled                         postgres.ColumnBool
+	AiModel                             postgres.ColumnString
+	AiApiKey                            postgres.ColumnString
+	IsConversationSummaryOnCloseEnabled postgres.ColumnBool
+	IsAiCrossProviderFallbackEnabled    postgres.ColumnBool
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -74,48 +76,52 @@ func newOrganizationTable(schemaName, tableName, alias string) *OrganizationTabl
 
 func newOrganizationTableImpl(schemaName, tableName, alias string) organizationTable {
 	var (
-		UniqueIdColumn           = postgres.StringColumn("UniqueId")
-		CreatedAtColumn          = postgres.TimestampzColumn("CreatedAt")
-		UpdatedAtColumn          = postgres.TimestampzColumn("UpdatedAt")
-		NameColumn               = postgres.StringColumn("Name")
-		DescriptionColumn        = postgres.StringColumn("Description")
-		WebsiteUrlColumn         = postgres.StringColumn("WebsiteUrl")
-		LogoUrlColumn            = postgres.StringColumn("LogoUrl")
-		FaviconUrlColumn         = postgres.StringColumn("FaviconUrl")
-		SlackWebhookUrlColumn    = postgres.StringColumn("SlackWebhookUrl")
-		SlackChannelColumn       = postgres.StringColumn("SlackChannel")
-		SmtpClientHostColumn     = postgres.StringColumn("SmtpClientHost")
-		SmtpClientUsernameColumn = postgres.StringColumn("SmtpClientUsername")
-		SmtpClientPasswordColumn = postgres.StringColumn("SmtpClientPassword")
-		SmtpClientPortColumn     = postgres.StringColumn("SmtpClientPort")
-		IsAiEnabledColumn        = postgres.BoolColumn("IsAiEnabled")
-		AiModelColumn            = postgres.StringColumn("AiModel")
-		AiApiKeyColumn           = postgres.StringColumn("AiApiKey")
-		allColumns               = postgres.ColumnList{UniqueIdColumn, CreatedAtColumn, UpdatedAtColumn, NameColumn, DescriptionColumn, WebsiteUrlColumn, LogoUrlColumn, FaviconUrlColumn, SlackWebhookUrlColumn, SlackChannelColumn, SmtpClientHostColumn, SmtpClientUsernameColumn, SmtpClientPasswordColumn, SmtpClientPortColumn, IsAiEnabledColumn, AiModelColumn, AiApiKeyColumn}
-		mutableColumns           = postgres.ColumnList{CreatedAtColumn, UpdatedAtColumn, NameColumn, DescriptionColumn, WebsiteUrlColumn, LogoUrlColumn, FaviconUrlColumn, SlackWebhookUrlColumn, SlackChannelColumn, SmtpClientHostColumn, SmtpClientUsernameColumn, SmtpClientPasswordColumn, SmtpClientPortColumn, IsAiEnabledColumn, AiModelColumn, AiApiKeyColumn}
+		UniqueIdColumn                            = postgres.StringColumn("UniqueId")
+		CreatedAtColumn                           = postgres.TimestampzColumn("CreatedAt")
+		UpdatedAtColumn                           = postgres.TimestampzColumn("UpdatedAt")
+		NameColumn                                = postgres.StringColumn("Name")
+		DescriptionColumn                         = postgres.StringColumn("Description")
+		WebsiteUrlColumn                          = postgres.StringColumn("WebsiteUrl")
+		LogoUrlColumn                             = postgres.StringColumn("LogoUrl")
+		FaviconUrlColumn                          = postgres.StringColumn("FaviconUrl")
+		SlackWebhookUrlColumn                     = postgres.StringColumn("SlackWebhookUrl")
+		SlackChannelColumn                        = postgres.StringColumn("SlackChannel")
+		SmtpClientHostColumn                      = postgres.StringColumn("SmtpClientHost")
+		SmtpClientUsernameColumn                  = postgres.StringColumn("SmtpClientUsername")
+		SmtpClientPasswordColumn                  = postgres.StringColumn("SmtpClientPassword")
+		SmtpClientPortColumn                      = postgres.StringColumn("SmtpClientPort")
+		IsAiEnabledColumn                         = postgres.BoolColumn("IsAiEnabled")
+		AiModelColumn                             = postgres.StringColumn("AiModel")
+		AiApiKeyColumn                            = postgres.StringColumn("AiApiKey")
+		IsConversationSummaryOnCloseEnabledColumn = postgres.BoolColumn("IsConversationSummaryOnCloseEnabled")
+		IsAiCrossProviderFallbackEnabledColumn    = postgres.BoolColumn("IsAiCrossProviderFallbackEnabled")
+		allColumns                                = postgres.ColumnList{UniqueIdColumn, CreatedAtColumn, UpdatedAtColumn, NameColumn, DescriptionColumn, WebsiteUrlColumn, LogoUrlColumn, FaviconUrlColumn, SlackWebhookUrlColumn, SlackChannelColumn, SmtpClientHostColumn, SmtpClientUsernameColumn, SmtpClientPasswordColumn, SmtpClientPortColumn, IsAiEnabledColumn, AiModelColumn, AiApiKeyColumn, IsConversationSummaryOnCloseEnabledColumn, IsAiCrossProviderFallbackEnabledColumn}
+		mutableColumns                            = postgres.ColumnList{CreatedAtColumn, UpdatedAtColumn, NameColumn, DescriptionColumn, WebsiteUrlColumn, LogoUrlColumn, FaviconUrlColumn, SlackWebhookUrlColumn, SlackChannelColumn, SmtpClientHostColumn, SmtpClientUsernameColumn, SmtpClientPasswordColumn, SmtpClientPortColumn, IsAiEnabledColumn, AiModelColumn, AiApiKeyColumn, IsConversationSummaryOnCloseEnabledColumn, IsAiCrossProviderFallbackEnabledColumn}
 	)
 
 	return organizationTable{
 		Table: postgres.NewTable(schemaName, tableName, alias, allColumns...),
 
 		//Columns
-		UniqueId:           UniqueIdColumn,
-		CreatedAt:          CreatedAtColumn,
-		UpdatedAt:          UpdatedAtColumn,
-		Name:               NameColumn,
-		Description:        DescriptionColumn,
-		WebsiteUrl:         WebsiteUrlColumn,
-		LogoUrl:            LogoUrlColumn,
-		FaviconUrl:         FaviconUrlColumn,
-		SlackWebhookUrl:    SlackWebhookUrlColumn,
-		SlackChannel:       SlackChannelColumn,
-		SmtpClientHost:     SmtpClientHostColumn,
-		SmtpClientUsername: SmtpClientUsernameColumn,
-		SmtpClientPassword: SmtpClientPasswordColumn,
-		SmtpClientPort:     SmtpClientPortColumn,
-		IsAiEnabled:        IsAiEnabledColumn,
-		AiModel:            AiModelColumn,
-		AiApiKey:           AiApiKeyColumn,
+		UniqueId:                            UniqueIdColumn,
+		CreatedAt:                           CreatedAtColumn,
+		UpdatedAt:                           UpdatedAtColumn,
+		Name:                                NameColumn,
+		Description:                         DescriptionColumn,
+		WebsiteUrl:                          WebsiteUrlColumn,
+		LogoUrl:                             LogoUrlColumn,
+		FaviconUrl:                          FaviconUrlColumn,
+		SlackWebhookUrl:                     SlackWebhookUrlColumn,
+		SlackChannel:                        SlackChannelColumn,
+		SmtpClientHost:                      SmtpClientHostColumn,
+		SmtpClientUsername:                  SmtpClientUsernameColumn,
+		SmtpClientPassword:                  SmtpClientPasswordColumn,
+		SmtpClientPort:                      SmtpClientPortColumn,
+		IsAiEnabled:                         IsAiEnabledColumn,
+		AiModel:                             AiModelColumn,
+		AiApiKey:                            AiApiKeyColumn,
+		IsConversationSummaryOnCloseEnabled: IsConversationSummaryOnCloseEnabledColumn,
+		IsAiCrossProviderFallbackEnabled:    IsAiCrossProviderFallbackEnabledColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
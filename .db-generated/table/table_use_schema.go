@@ -11,23 +11,44 @@ package table
 // this method only once at the beginning of the program.
 func UseSchema(schema string) {
 	AiApiCallLogs = AiApiCallLogs.FromSchema(schema)
+	AiAutoResponderConfiguration = AiAutoResponderConfiguration.FromSchema(schema)
+	AiAutoResponderHandoff = AiAutoResponderHandoff.FromSchema(schema)
+	AiBudgetConfiguration = AiBudgetConfiguration.FromSchema(schema)
 	AiChat = AiChat.FromSchema(schema)
 	AiChatMessage = AiChatMessage.FromSchema(schema)
 	AiChatMessageVote = AiChatMessageVote.FromSchema(schema)
 	AiChatSuggestions = AiChatSuggestions.FromSchema(schema)
+	AiPiiRedactionConfiguration = AiPiiRedactionConfiguration.FromSchema(schema)
 	ApiKey = ApiKey.FromSchema(schema)
 	Campaign = Campaign.FromSchema(schema)
 	CampaignList = CampaignList.FromSchema(schema)
 	CampaignTag = CampaignTag.FromSchema(schema)
+	CannedResponse = CannedResponse.FromSchema(schema)
+	CannedResponseFolder = CannedResponseFolder.FromSchema(schema)
+	CannedResponseUsageLog = CannedResponseUsageLog.FromSchema(schema)
+	ChatbotFlow = ChatbotFlow.FromSchema(schema)
+	ChatbotFlowSession = ChatbotFlowSession.FromSchema(schema)
 	Contact = Contact.FromSchema(schema)
 	ContactList = ContactList.FromSchema(schema)
 	ContactListContact = ContactListContact.FromSchema(schema)
 	ContactListTag = ContactListTag.FromSchema(schema)
+	ContactTag = ContactTag.FromSchema(schema)
 	Conversation = Conversation.FromSchema(schema)
 	ConversationAssignment = ConversationAssignment.FromSchema(schema)
+	ConversationBulkActionJob = ConversationBulkActionJob.FromSchema(schema)
+	ConversationReminder = ConversationReminder.FromSchema(schema)
+	ConversationSummary = ConversationSummary.FromSchema(schema)
 	ConversationTag = ConversationTag.FromSchema(schema)
+	ConversationTaggingRule = ConversationTaggingRule.FromSchema(schema)
+	CsatSurveyConfiguration = CsatSurveyConfiguration.FromSchema(schema)
+	CsatSurveyResponse = CsatSurveyResponse.FromSchema(schema)
 	Integration = Integration.FromSchema(schema)
+	KnowledgeBaseChunk = KnowledgeBaseChunk.FromSchema(schema)
+	KnowledgeBaseDocument = KnowledgeBaseDocument.FromSchema(schema)
+	MediaFile = MediaFile.FromSchema(schema)
 	Message = Message.FromSchema(schema)
+	MessageClassificationConfiguration = MessageClassificationConfiguration.FromSchema(schema)
+	MessageTemplate = MessageTemplate.FromSchema(schema)
 	Notification = Notification.FromSchema(schema)
 	NotificationReadLog = NotificationReadLog.FromSchema(schema)
 	Organization = Organization.FromSchema(schema)
@@ -35,10 +56,14 @@ func UseSchema(schema string) {
 	OrganizationMember = OrganizationMember.FromSchema(schema)
 	OrganizationMemberInvite = OrganizationMemberInvite.FromSchema(schema)
 	OrganizationRole = OrganizationRole.FromSchema(schema)
+	PhoneNumberHealthConfiguration = PhoneNumberHealthConfiguration.FromSchema(schema)
+	PhoneNumberHealthEvent = PhoneNumberHealthEvent.FromSchema(schema)
 	RoleAssignment = RoleAssignment.FromSchema(schema)
+	SegmentRecommendationJob = SegmentRecommendationJob.FromSchema(schema)
 	Tag = Tag.FromSchema(schema)
 	TrackLink = TrackLink.FromSchema(schema)
 	TrackLinkClick = TrackLinkClick.FromSchema(schema)
 	User = User.FromSchema(schema)
 	WhatsappBusinessAccount = WhatsappBusinessAccount.FromSchema(schema)
+	WhatsappBusinessAccountPhoneNumber = WhatsappBusinessAccountPhoneNumber.FromSchema(schema)
 }
@@ -26,6 +26,7 @@ type whatsappBusinessAccountTable struct {
 	OrganizationId postgres.ColumnString
 	PhoneNumberId  postgres.ColumnString
 	Status         postgres.ColumnString
+	Name           postgres.ColumnString
 
 	AllColumns     postgres.ColumnList
 	MutableColumns postgres.ColumnList
@@ -75,8 +76,9 @@ func newWhatsappBusinessAccountTableImpl(schemaName, tableName, alias string) wh
 		OrganizationIdColumn = postgres.StringColumn("OrganizationId")
 		PhoneNumberIdColumn  = postgres.StringColumn("PhoneNumberId")
 		StatusColumn         = postgres.StringColumn("Status")
-		allColumns           = postgres.ColumnList{UniqueIdColumn, CreatedAtColumn, UpdatedAtColumn, AccountIdColumn, AccessTokenColumn, WebhookSecretColumn, OrganizationIdColumn, PhoneNumberIdColumn, StatusColumn}
-		mutableColumns       = postgres.ColumnList{CreatedAtColumn, UpdatedAtColumn, AccountIdColumn, AccessTokenColumn, WebhookSecretColumn, OrganizationIdColumn, PhoneNumberIdColumn, StatusColumn}
+		NameColumn           = postgres.StringColumn("Name")
+		allColumns           = postgres.ColumnList{UniqueIdColumn, CreatedAtColumn, UpdatedAtColumn, AccountIdColumn, AccessTokenColumn, WebhookSecretColumn, OrganizationIdColumn, PhoneNumberIdColumn, StatusColumn, NameColumn}
+		mutableColumns       = postgres.ColumnList{CreatedAtColumn, UpdatedAtColumn, AccountIdColumn, AccessTokenColumn, WebhookSecretColumn, OrganizationIdColumn, PhoneNumberIdColumn, StatusColumn, NameColumn}
 	)
 
 	return whatsappBusinessAccountTable{
@@ -92,6 +94,7 @@ func newWhatsappBusinessAccountTableImpl(schemaName, tableName, alias string) wh
 		OrganizationId: OrganizationIdColumn,
 		PhoneNumberId:  PhoneNumberIdColumn,
 		Status:         StatusColumn,
+		Name:           NameColumn,
 
 		AllColumns:     allColumns,
 		MutableColumns: mutableColumns,
@@ -66,6 +66,32 @@ const (
 	Scheduled CampaignStatusEnum = "Scheduled"
 )
 
+// Defines values for CannedResponseVisibilityEnum.
+const (
+	Organization CannedResponseVisibilityEnum = "Organization"
+	Personal     CannedResponseVisibilityEnum = "Personal"
+	Team         CannedResponseVisibilityEnum = "Team"
+)
+
+// Defines values for ChatbotFlowBranchMatchTypeEnum.
+const (
+	ButtonId     ChatbotFlowBranchMatchTypeEnum = "ButtonId"
+	KeywordMatch ChatbotFlowBranchMatchTypeEnum = "KeywordMatch"
+	RegexMatch   ChatbotFlowBranchMatchTypeEnum = "RegexMatch"
+)
+
+// Defines values for ChatbotFlowStepTypeEnum.
+const (
+	AddTag       ChatbotFlowStepTypeEnum = "AddTag"
+	AssignTeam   ChatbotFlowStepTypeEnum = "AssignTeam"
+	Branch       ChatbotFlowStepTypeEnum = "Branch"
+	CallWebhook  ChatbotFlowStepTypeEnum = "CallWebhook"
+	HandOff      ChatbotFlowStepTypeEnum = "HandOff"
+	SendMessage  ChatbotFlowStepTypeEnum = "SendMessage"
+	SetAttribute ChatbotFlowStepTypeEnum = "SetAttribute"
+	WaitForReply ChatbotFlowStepTypeEnum = "WaitForReply"
+)
+
 // Defines values for ContactStatusEnum.
 const (
 	ContactStatusEnumActive   ContactStatusEnum = "Active"
@@ -73,17 +99,67 @@ const (
 	ContactStatusEnumInactive ContactStatusEnum = "Inactive"
 )
 
+// Defines values for ContactsMessageMessageType.
+const (
+	Contacts ContactsMessageMessageType = "Contacts"
+)
+
+// Defines values for ConversationBulkActionEnum.
+const (
+	Assign   ConversationBulkActionEnum = "Assign"
+	Close    ConversationBulkActionEnum = "Close"
+	MarkRead ConversationBulkActionEnum = "MarkRead"
+	Tag      ConversationBulkActionEnum = "Tag"
+	Untag    ConversationBulkActionEnum = "Untag"
+)
+
+// Defines values for ConversationBulkActionJobStatusEnum.
+const (
+	Completed  ConversationBulkActionJobStatusEnum = "Completed"
+	Errored    ConversationBulkActionJobStatusEnum = "Errored"
+	InProgress ConversationBulkActionJobStatusEnum = "InProgress"
+	Queued     ConversationBulkActionJobStatusEnum = "Queued"
+)
+
 // Defines values for ConversationInitiatedByEnum.
 const (
 	Campaign ConversationInitiatedByEnum = "Campaign"
 	Contact  ConversationInitiatedByEnum = "Contact"
 )
 
+// Defines values for ConversationPriorityEnum.
+const (
+	High   ConversationPriorityEnum = "High"
+	Low    ConversationPriorityEnum = "Low"
+	Normal ConversationPriorityEnum = "Normal"
+	Urgent ConversationPriorityEnum = "Urgent"
+)
+
+// Defines values for ConversationSearchMatchTypeEnum.
+const (
+	ContactDetails ConversationSearchMatchTypeEnum = "ContactDetails"
+	MessageContent ConversationSearchMatchTypeEnum = "MessageContent"
+)
+
 // Defines values for ConversationStatusEnum.
 const (
 	ConversationStatusEnumActive  ConversationStatusEnum = "Active"
 	ConversationStatusEnumClosed  ConversationStatusEnum = "Closed"
 	ConversationStatusEnumDeleted ConversationStatusEnum = "Deleted"
+	ConversationStatusEnumSnoozed ConversationStatusEnum = "Snoozed"
+)
+
+// Defines values for ConversationTaggingRuleTypeEnum.
+const (
+	ContactAttribute    ConversationTaggingRuleTypeEnum = "ContactAttribute"
+	Keyword             ConversationTaggingRuleTypeEnum = "Keyword"
+	OriginatingCampaign ConversationTaggingRuleTypeEnum = "OriginatingCampaign"
+)
+
+// Defines values for ConversationTranscriptFormatEnum.
+const (
+	Html ConversationTranscriptFormatEnum = "Html"
+	Pdf  ConversationTranscriptFormatEnum = "Pdf"
 )
 
 // Defines values for DocumentMessageMessageType.
@@ -102,12 +178,50 @@ const (
 	IntegrationStatusEnumInactive IntegrationStatusEnum = "Inactive"
 )
 
+// Defines values for InteractiveMessageMessageType.
+const (
+	Interactive InteractiveMessageMessageType = "Interactive"
+)
+
+// Defines values for InteractiveMessageTypeEnum.
+const (
+	CtaUrl       InteractiveMessageTypeEnum = "CtaUrl"
+	List         InteractiveMessageTypeEnum = "List"
+	ReplyButtons InteractiveMessageTypeEnum = "ReplyButtons"
+)
+
+// Defines values for InteractiveReplyMessageMessageType.
+const (
+	InteractiveReply InteractiveReplyMessageMessageType = "InteractiveReply"
+)
+
+// Defines values for InteractiveReplyTypeEnum.
+const (
+	ButtonReply InteractiveReplyTypeEnum = "ButtonReply"
+	ListReply   InteractiveReplyTypeEnum = "ListReply"
+	QuickReply  InteractiveReplyTypeEnum = "QuickReply"
+)
+
 // Defines values for InviteStatusEnum.
 const (
 	Pending  InviteStatusEnum = "Pending"
 	Redeemed InviteStatusEnum = "Redeemed"
 )
 
+// Defines values for KnowledgeBaseDocumentStatusEnum.
+const (
+	Indexed        KnowledgeBaseDocumentStatusEnum = "Indexed"
+	Indexing       KnowledgeBaseDocumentStatusEnum = "Indexing"
+	IndexingFailed KnowledgeBaseDocumentStatusEnum = "IndexingFailed"
+)
+
+// Defines values for KnowledgeBaseSourceTypeEnum.
+const (
+	FaqList     KnowledgeBaseSourceTypeEnum = "FaqList"
+	PdfDocument KnowledgeBaseSourceTypeEnum = "PdfDocument"
+	WebPage     KnowledgeBaseSourceTypeEnum = "WebPage"
+)
+
 // Defines values for LocationMessageMessageType.
 const (
 	Location LocationMessageMessageType = "Location"
@@ -119,6 +233,24 @@ const (
 	OutBound MessageDirectionEnum = "OutBound"
 )
 
+// Defines values for MessageIntentEnum.
+const (
+	Complaint MessageIntentEnum = "Complaint"
+	Feedback  MessageIntentEnum = "Feedback"
+	Greeting  MessageIntentEnum = "Greeting"
+	Inquiry   MessageIntentEnum = "Inquiry"
+	Other     MessageIntentEnum = "Other"
+	Purchase  MessageIntentEnum = "Purchase"
+	Support   MessageIntentEnum = "Support"
+)
+
+// Defines values for MessageSentimentEnum.
+const (
+	Negative MessageSentimentEnum = "Negative"
+	Neutral  MessageSentimentEnum = "Neutral"
+	Positive MessageSentimentEnum = "Positive"
+)
+
 // Defines values for MessageStatusEnum.
 const (
 	MessageStatusEnumDelivered   MessageStatusEnum = "Delivered"
@@ -156,11 +288,26 @@ const (
 	LIMITEDTIMEOFFER MessageTemplateComponentType = "LIMITED_TIME_OFFER"
 )
 
+// Defines values for MessageTemplateQualityRating.
+const (
+	GREEN   MessageTemplateQualityRating = "GREEN"
+	RED     MessageTemplateQualityRating = "RED"
+	UNKNOWN MessageTemplateQualityRating = "UNKNOWN"
+	YELLOW  MessageTemplateQualityRating = "YELLOW"
+)
+
 // Defines values for MessageTemplateStatus.
 const (
-	APPROVED MessageTemplateStatus = "APPROVED"
-	PENDING  MessageTemplateStatus = "PENDING"
-	REJECTED MessageTemplateStatus = "REJECTED"
+	APPROVED        MessageTemplateStatus = "APPROVED"
+	ARCHIVED        MessageTemplateStatus = "ARCHIVED"
+	DELETED         MessageTemplateStatus = "DELETED"
+	DISABLED        MessageTemplateStatus = "DISABLED"
+	INAPPEAL        MessageTemplateStatus = "IN_APPEAL"
+	LIMITEXCEEDED   MessageTemplateStatus = "LIMIT_EXCEEDED"
+	PAUSED          MessageTemplateStatus = "PAUSED"
+	PENDING         MessageTemplateStatus = "PENDING"
+	PENDINGDELETION MessageTemplateStatus = "PENDING_DELETION"
+	REJECTED        MessageTemplateStatus = "REJECTED"
 )
 
 // Defines values for OrderEnum.
@@ -169,6 +316,56 @@ const (
 	Desc OrderEnum = "desc"
 )
 
+// Defines values for OrderMessageMessageType.
+const (
+	Order OrderMessageMessageType = "Order"
+)
+
+// Defines values for PhoneNumberHealthEventTypeEnum.
+const (
+	AccountAlert       PhoneNumberHealthEventTypeEnum = "AccountAlert"
+	AccountUpdate      PhoneNumberHealthEventTypeEnum = "AccountUpdate"
+	MessagingLimitTier PhoneNumberHealthEventTypeEnum = "MessagingLimitTier"
+	NameStatus         PhoneNumberHealthEventTypeEnum = "NameStatus"
+	QualityRating      PhoneNumberHealthEventTypeEnum = "QualityRating"
+)
+
+// Defines values for PhoneNumberMessagingLimitTierEnum.
+const (
+	PhoneNumberMessagingLimitTierEnumTier100K      PhoneNumberMessagingLimitTierEnum = "Tier100K"
+	PhoneNumberMessagingLimitTierEnumTier10K       PhoneNumberMessagingLimitTierEnum = "Tier10K"
+	PhoneNumberMessagingLimitTierEnumTier1K        PhoneNumberMessagingLimitTierEnum = "Tier1K"
+	PhoneNumberMessagingLimitTierEnumTier250       PhoneNumberMessagingLimitTierEnum = "Tier250"
+	PhoneNumberMessagingLimitTierEnumTier2K        PhoneNumberMessagingLimitTierEnum = "Tier2K"
+	PhoneNumberMessagingLimitTierEnumTier50        PhoneNumberMessagingLimitTierEnum = "Tier50"
+	PhoneNumberMessagingLimitTierEnumTierUnlimited PhoneNumberMessagingLimitTierEnum = "TierUnlimited"
+	PhoneNumberMessagingLimitTierEnumUnknown       PhoneNumberMessagingLimitTierEnum = "Unknown"
+)
+
+// Defines values for PhoneNumberNameStatusEnum.
+const (
+	PhoneNumberNameStatusEnumApproved               PhoneNumberNameStatusEnum = "Approved"
+	PhoneNumberNameStatusEnumAvailableWithoutReview PhoneNumberNameStatusEnum = "AvailableWithoutReview"
+	PhoneNumberNameStatusEnumDeclined               PhoneNumberNameStatusEnum = "Declined"
+	PhoneNumberNameStatusEnumExpired                PhoneNumberNameStatusEnum = "Expired"
+	PhoneNumberNameStatusEnumNone                   PhoneNumberNameStatusEnum = "None"
+	PhoneNumberNameStatusEnumPendingReview          PhoneNumberNameStatusEnum = "PendingReview"
+	PhoneNumberNameStatusEnumUnknown                PhoneNumberNameStatusEnum = "Unknown"
+)
+
+// Defines values for PhoneNumberQualityRatingEnum.
+const (
+	Green   PhoneNumberQualityRatingEnum = "Green"
+	Red     PhoneNumberQualityRatingEnum = "Red"
+	Unknown PhoneNumberQualityRatingEnum = "Unknown"
+	Yellow  PhoneNumberQualityRatingEnum = "Yellow"
+)
+
+// Defines values for ProductInquiryMessageMessageType.
+const (
+	ProductInquiry ProductInquiryMessageMessageType = "ProductInquiry"
+)
+
 // Defines values for ReactionMessageMessageType.
 const (
 	Reaction ReactionMessageMessageType = "Reaction"
@@ -179,15 +376,21 @@ const (
 	AssignConversation        RolePermissionEnum = "Assign:Conversation"
 	BulkImportContacts        RolePermissionEnum = "BulkImport:Contacts"
 	CreateCampaign            RolePermissionEnum = "Create:Campaign"
+	CreateCannedResponse      RolePermissionEnum = "Create:CannedResponse"
+	CreateChatbotFlow         RolePermissionEnum = "Create:ChatbotFlow"
 	CreateContact             RolePermissionEnum = "Create:Contact"
 	CreateList                RolePermissionEnum = "Create:List"
+	CreateMessageTemplates    RolePermissionEnum = "Create:MessageTemplates"
 	CreateOrganizationMember  RolePermissionEnum = "Create:OrganizationMember"
 	CreateOrganizationRole    RolePermissionEnum = "Create:OrganizationRole"
 	CreateTag                 RolePermissionEnum = "Create:Tag"
 	DeleteCampaign            RolePermissionEnum = "Delete:Campaign"
+	DeleteCannedResponse      RolePermissionEnum = "Delete:CannedResponse"
+	DeleteChatbotFlow         RolePermissionEnum = "Delete:ChatbotFlow"
 	DeleteContact             RolePermissionEnum = "Delete:Contact"
 	DeleteConversation        RolePermissionEnum = "Delete:Conversation"
 	DeleteList                RolePermissionEnum = "Delete:List"
+	DeleteMessageTemplates    RolePermissionEnum = "Delete:MessageTemplates"
 	DeleteOrganizationMember  RolePermissionEnum = "Delete:OrganizationMember"
 	DeleteOrganizationRole    RolePermissionEnum = "Delete:OrganizationRole"
 	DeleteTag                 RolePermissionEnum = "Delete:Tag"
@@ -195,6 +398,8 @@ const (
 	GetAppSettings            RolePermissionEnum = "Get:AppSettings"
 	GetCampaign               RolePermissionEnum = "Get:Campaign"
 	GetCampaignAnalytics      RolePermissionEnum = "Get:CampaignAnalytics"
+	GetCannedResponse         RolePermissionEnum = "Get:CannedResponse"
+	GetChatbotFlow            RolePermissionEnum = "Get:ChatbotFlow"
 	GetContact                RolePermissionEnum = "Get:Contact"
 	GetConversation           RolePermissionEnum = "Get:Conversation"
 	GetList                   RolePermissionEnum = "Get:List"
@@ -209,10 +414,13 @@ const (
 	UnassignConversation      RolePermissionEnum = "Unassign:Conversation"
 	UpdateAppSettings         RolePermissionEnum = "Update:AppSettings"
 	UpdateCampaign            RolePermissionEnum = "Update:Campaign"
+	UpdateCannedResponse      RolePermissionEnum = "Update:CannedResponse"
+	UpdateChatbotFlow         RolePermissionEnum = "Update:ChatbotFlow"
 	UpdateContact             RolePermissionEnum = "Update:Contact"
 	UpdateConversation        RolePermissionEnum = "Update:Conversation"
 	UpdateIntegrationSettings RolePermissionEnum = "Update:IntegrationSettings"
 	UpdateList                RolePermissionEnum = "Update:List"
+	UpdateMessageTemplates    RolePermissionEnum = "Update:MessageTemplates"
 	UpdateOrganization        RolePermissionEnum = "Update:Organization"
 	UpdateOrganizationMember  RolePermissionEnum = "Update:OrganizationMember"
 	UpdateOrganizationRole    RolePermissionEnum = "Update:OrganizationRole"
@@ -238,6 +446,12 @@ const (
 	Static  TemplateParameterInputParameterType = "static"
 )
 
+// Defines values for TemplateRuleIssueSeverityEnum.
+const (
+	Error   TemplateRuleIssueSeverityEnum = "error"
+	Warning TemplateRuleIssueSeverityEnum = "warning"
+)
+
 // Defines values for TextMessageMessageType.
 const (
 	Text TextMessageMessageType = "Text"
@@ -267,6 +481,20 @@ type AcceptOrganizationInviteResponseSchema struct {
 	Token string `json:"token"`
 }
 
+// AcceptSegmentRecommendationsResponseSchema defines model for AcceptSegmentRecommendationsResponseSchema.
+type AcceptSegmentRecommendationsResponseSchema struct {
+	Tags []TagSchema `json:"tags"`
+}
+
+// AcceptSegmentRecommendationsSchema defines model for AcceptSegmentRecommendationsSchema.
+type AcceptSegmentRecommendationsSchema struct {
+	ContactId      *string `json:"contactId,omitempty"`
+	ConversationId *string `json:"conversationId,omitempty"`
+
+	// Labels The labels of the recommendations to accept, at most five.
+	Labels []string `json:"labels"`
+}
+
 // AggregateAnalyticsSchema defines model for AggregateAnalyticsSchema.
 type AggregateAnalyticsSchema struct {
 	CampaignStats     AggregateCampaignStatsDataPointsSchema     `json:"campaignStats"`
@@ -312,15 +540,69 @@ type AggregateMessageStatsDataPointsSchema struct {
 	TotalMessages       int `json:"totalMessages"`
 }
 
+// AiAutoResponderConfigurationSchema defines model for AiAutoResponderConfigurationSchema.
+type AiAutoResponderConfigurationSchema struct {
+	// ForbiddenTopics Topics the AI must never answer about, the conversation is handed off when the contact brings one up.
+	ForbiddenTopics []string `json:"forbiddenTopics"`
+
+	// HandoffMessage (Optional) Message sent to the contact when the conversation is handed off.
+	HandoffMessage *string `json:"handoffMessage,omitempty"`
+
+	// HandoffOrganizationRoleId Organization role whose least busy member gets the conversations handed off.
+	HandoffOrganizationRoleId *string `json:"handoffOrganizationRoleId,omitempty"`
+
+	// Instructions (Optional) What the AI must know about the business to answer the contacts, e.g. opening hours, policies and tone of voice.
+	Instructions *string `json:"instructions,omitempty"`
+	IsEnabled    bool    `json:"isEnabled"`
+
+	// MaxAiTurns Number of AI replies in a conversation after which it is handed off.
+	MaxAiTurns int `json:"maxAiTurns"`
+
+	// MinConfidence Replies the AI is less confident about, in percent, are not sent and the conversation is handed off instead.
+	MinConfidence int `json:"minConfidence"`
+}
+
+// AiBudgetSchema defines model for AiBudgetSchema.
+type AiBudgetSchema struct {
+	// IsHardLimitEnabled Block the AI features until the next month when a limit is reached.
+	IsHardLimitEnabled bool `json:"isHardLimitEnabled"`
+
+	// MonthlyCostLimitInUsd No cost limit when absent.
+	MonthlyCostLimitInUsd *float64 `json:"monthlyCostLimitInUsd,omitempty"`
+
+	// MonthlyTokenLimit No token limit when absent.
+	MonthlyTokenLimit *int64 `json:"monthlyTokenLimit,omitempty"`
+
+	// SoftLimitPercentage The owners are warned once a month when the usage reaches this percentage of a limit.
+	SoftLimitPercentage int                 `json:"softLimitPercentage"`
+	Usage               AiBudgetUsageSchema `json:"usage"`
+}
+
+// AiBudgetUsageSchema defines model for AiBudgetUsageSchema.
+type AiBudgetUsageSchema struct {
+	CostInUsd          float64 `json:"costInUsd"`
+	IsHardLimitReached bool    `json:"isHardLimitReached"`
+	IsSoftLimitReached bool    `json:"isSoftLimitReached"`
+
+	// PeriodStart Start of the current month, the budget is reset every month.
+	PeriodStart time.Time `json:"periodStart"`
+	TokensUsed  int64     `json:"tokensUsed"`
+
+	// UsagePercentage Usage against the tightest limit, absent when the budget has no limit.
+	UsagePercentage *float64 `json:"usagePercentage,omitempty"`
+}
+
 // AiChatMessageRoleEnum defines model for AiChatMessageRoleEnum.
 type AiChatMessageRoleEnum string
 
 // AiChatMessageSchema defines model for AiChatMessageSchema.
 type AiChatMessageSchema struct {
-	Content   string                `json:"content"`
-	CreatedAt time.Time             `json:"createdAt"`
-	Role      AiChatMessageRoleEnum `json:"role"`
-	UniqueId  string                `json:"uniqueId"`
+	// Citations The knowledge base passages the answer is grounded on, only for the assistant messages.
+	Citations *[]KnowledgeBaseCitationSchema `json:"citations,omitempty"`
+	Content   string                         `json:"content"`
+	CreatedAt time.Time                      `json:"createdAt"`
+	Role      AiChatMessageRoleEnum          `json:"role"`
+	UniqueId  string                         `json:"uniqueId"`
 }
 
 // AiChatMessageVoteCreateSchema defines model for AiChatMessageVoteCreateSchema.
@@ -361,13 +643,62 @@ type AiChatVisibilityEnum string
 
 // AiConfigurationDetailsSchema defines model for AiConfigurationDetailsSchema.
 type AiConfigurationDetailsSchema struct {
-	IsEnabled *bool       `json:"isEnabled,omitempty"`
-	Model     AiModelEnum `json:"model"`
+	// AllowCrossProviderFallback Let the AI calls fall back to the models of other providers, billed to the platform, when the model of the organization fails. Without it an organization bringing its own API key only ever uses its provider.
+	AllowCrossProviderFallback *bool       `json:"allowCrossProviderFallback,omitempty"`
+	IsEnabled                  *bool       `json:"isEnabled,omitempty"`
+	Model                      AiModelEnum `json:"model"`
+
+	// StoreConversationSummaryOnClose Summarize the conversations when they are closed, to search them later.
+	StoreConversationSummaryOnClose *bool `json:"storeConversationSummaryOnClose,omitempty"`
+}
+
+// AiFeatureUsageSchema defines model for AiFeatureUsageSchema.
+type AiFeatureUsageSchema struct {
+	// Feature The AI feature, e.g. chat, summary or response_suggestions.
+	Feature string        `json:"feature"`
+	Usage   AiUsageSchema `json:"usage"`
+}
+
+// AiMemberUsageSchema defines model for AiMemberUsageSchema.
+type AiMemberUsageSchema struct {
+	Name *string `json:"name,omitempty"`
+
+	// OrganizationMemberId Absent for the calls made in the background, e.g. by the auto responder.
+	OrganizationMemberId *string       `json:"organizationMemberId,omitempty"`
+	Usage                AiUsageSchema `json:"usage"`
 }
 
 // AiModelEnum defines model for AiModelEnum.
 type AiModelEnum string
 
+// AiPiiRedactionConfigurationSchema defines model for AiPiiRedactionConfigurationSchema.
+type AiPiiRedactionConfigurationSchema struct {
+	AvailableDetectors []PiiDetectorSchema       `json:"availableDetectors"`
+	CustomDetectors    []PiiCustomDetectorSchema `json:"customDetectors"`
+	EnabledDetectors   []string                  `json:"enabledDetectors"`
+	IsEnabled          bool                      `json:"isEnabled"`
+}
+
+// AiUsageReportSchema defines model for AiUsageReportSchema.
+type AiUsageReportSchema struct {
+	Budget    AiBudgetUsageSchema    `json:"budget"`
+	ByFeature []AiFeatureUsageSchema `json:"byFeature"`
+	ByMember  []AiMemberUsageSchema  `json:"byMember"`
+	From      time.Time              `json:"from"`
+	To        time.Time              `json:"to"`
+	Total     AiUsageSchema          `json:"total"`
+}
+
+// AiUsageSchema defines model for AiUsageSchema.
+type AiUsageSchema struct {
+	// CacheHitCount Calls answered from the semantic cache, they use no tokens.
+	CacheHitCount int64   `json:"cacheHitCount"`
+	CallCount     int64   `json:"callCount"`
+	CostInUsd     float64 `json:"costInUsd"`
+	InputTokens   int64   `json:"inputTokens"`
+	OutputTokens  int64   `json:"outputTokens"`
+}
+
 // ApiKeySchema defines model for ApiKeySchema.
 type ApiKeySchema struct {
 	CreatedAt time.Time `json:"createdAt"`
@@ -391,11 +722,20 @@ type AudioMessage struct {
 	ConversationId string `json:"conversationId"`
 
 	// CreatedAt Time when the message was created.
-	CreatedAt   time.Time               `json:"createdAt"`
-	Direction   MessageDirectionEnum    `json:"direction"`
-	MessageData AudioMessageData        `json:"messageData"`
-	MessageType AudioMessageMessageType `json:"messageType"`
-	Status      MessageStatusEnum       `json:"status"`
+	CreatedAt time.Time            `json:"createdAt"`
+	Direction MessageDirectionEnum `json:"direction"`
+
+	// IsAiGenerated (Optional) The message was written by the AI auto responder.
+	IsAiGenerated *bool                   `json:"isAiGenerated,omitempty"`
+	MessageData   AudioMessageData        `json:"messageData"`
+	MessageType   AudioMessageMessageType `json:"messageType"`
+
+	// Reactions Reactions on this message.
+	Reactions *[]MessageReactionSchema `json:"reactions,omitempty"`
+
+	// RepliedTo (Optional) ID of the message this message is a reply to.
+	RepliedTo *string           `json:"repliedTo,omitempty"`
+	Status    MessageStatusEnum `json:"status"`
 
 	// UniqueId Unique identifier of the message.
 	UniqueId string `json:"uniqueId"`
@@ -427,9 +767,18 @@ type BaseMessage struct {
 	CreatedAt time.Time            `json:"createdAt"`
 	Direction MessageDirectionEnum `json:"direction"`
 
+	// IsAiGenerated (Optional) The message was written by the AI auto responder.
+	IsAiGenerated *bool `json:"isAiGenerated,omitempty"`
+
 	// MessageType Discriminator field used to determine the concrete message type.
-	MessageType string            `json:"messageType"`
-	Status      MessageStatusEnum `json:"status"`
+	MessageType string `json:"messageType"`
+
+	// Reactions Reactions on this message.
+	Reactions *[]MessageReactionSchema `json:"reactions,omitempty"`
+
+	// RepliedTo (Optional) ID of the message this message is a reply to.
+	RepliedTo *string           `json:"repliedTo,omitempty"`
+	Status    MessageStatusEnum `json:"status"`
 
 	// UniqueId Unique identifier of the message.
 	UniqueId string `json:"uniqueId"`
@@ -446,6 +795,26 @@ type BulkImportSchema struct {
 	ListIds   *[]string `json:"listIds,omitempty"`
 }
 
+// BusinessAccountSchema defines model for BusinessAccountSchema.
+type BusinessAccountSchema struct {
+	BusinessAccountId string                      `json:"businessAccountId"`
+	CreatedAt         time.Time                   `json:"createdAt"`
+	Name              *string                     `json:"name,omitempty"`
+	PhoneNumbers      []BusinessPhoneNumberSchema `json:"phoneNumbers"`
+	Status            string                      `json:"status"`
+	UniqueId          string                      `json:"uniqueId"`
+	WebhookSecret     string                      `json:"webhookSecret"`
+}
+
+// BusinessPhoneNumberSchema defines model for BusinessPhoneNumberSchema.
+type BusinessPhoneNumberSchema struct {
+	BusinessAccountId  string  `json:"businessAccountId"`
+	DisplayPhoneNumber *string `json:"displayPhoneNumber,omitempty"`
+	IsDefault          bool    `json:"isDefault"`
+	PhoneNumberId      string  `json:"phoneNumberId"`
+	VerifiedName       *string `json:"verifiedName,omitempty"`
+}
+
 // CampaignAnalyticsResponseSchema defines model for CampaignAnalyticsResponseSchema.
 type CampaignAnalyticsResponseSchema struct {
 	ConversationInitiated int                                   `json:"conversationInitiated"`
@@ -491,6 +860,175 @@ type CampaignSchema struct {
 // CampaignStatusEnum defines model for CampaignStatusEnum.
 type CampaignStatusEnum string
 
+// CannedResponseFolderSchema defines model for CannedResponseFolderSchema.
+type CannedResponseFolderSchema struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Name      string    `json:"name"`
+	UniqueId  string    `json:"uniqueId"`
+}
+
+// CannedResponseSchema defines model for CannedResponseSchema.
+type CannedResponseSchema struct {
+	// Content Content of the response, supports {{contact.name}}, {{contact.phoneNumber}}, {{agent.name}}, {{organization.name}} and {{contact.attributes.<key>}} variables.
+	Content    string                      `json:"content"`
+	CreatedAt  time.Time                   `json:"createdAt"`
+	Folder     *CannedResponseFolderSchema `json:"folder,omitempty"`
+	LastUsedAt *time.Time                  `json:"lastUsedAt,omitempty"`
+
+	// OrganizationRoleId The role whose members can use the response when the visibility is Team.
+	OrganizationRoleId *string                      `json:"organizationRoleId,omitempty"`
+	Shortcut           string                       `json:"shortcut"`
+	Title              string                       `json:"title"`
+	UniqueId           string                       `json:"uniqueId"`
+	UsageCount         int                          `json:"usageCount"`
+	Visibility         CannedResponseVisibilityEnum `json:"visibility"`
+}
+
+// CannedResponseUsageByMemberSchema defines model for CannedResponseUsageByMemberSchema.
+type CannedResponseUsageByMemberSchema struct {
+	Name                 string `json:"name"`
+	OrganizationMemberId string `json:"organizationMemberId"`
+	UsageCount           int    `json:"usageCount"`
+}
+
+// CannedResponseUsageByResponseSchema defines model for CannedResponseUsageByResponseSchema.
+type CannedResponseUsageByResponseSchema struct {
+	CannedResponseId string `json:"cannedResponseId"`
+	Shortcut         string `json:"shortcut"`
+	Title            string `json:"title"`
+	UsageCount       int    `json:"usageCount"`
+}
+
+// CannedResponseVisibilityEnum defines model for CannedResponseVisibilityEnum.
+type CannedResponseVisibilityEnum string
+
+// ChatbotFlowBranchConditionSchema defines model for ChatbotFlowBranchConditionSchema.
+type ChatbotFlowBranchConditionSchema struct {
+	MatchType ChatbotFlowBranchMatchTypeEnum `json:"matchType"`
+
+	// Next Id of the step to go to when the condition matches.
+	Next string `json:"next"`
+
+	// Value Id of the button, comma separated keywords or regular expression, matched against the last reply of the contact.
+	Value string `json:"value"`
+
+	// Variable (Optional) Name of a flow variable to match instead of the last reply.
+	Variable *string `json:"variable,omitempty"`
+}
+
+// ChatbotFlowBranchMatchTypeEnum defines model for ChatbotFlowBranchMatchTypeEnum.
+type ChatbotFlowBranchMatchTypeEnum string
+
+// ChatbotFlowDefinitionSchema defines model for ChatbotFlowDefinitionSchema.
+type ChatbotFlowDefinitionSchema struct {
+	StartStepId string                  `json:"startStepId"`
+	Steps       []ChatbotFlowStepSchema `json:"steps"`
+}
+
+// ChatbotFlowInputSchema defines model for ChatbotFlowInputSchema.
+type ChatbotFlowInputSchema struct {
+	Definition              ChatbotFlowDefinitionSchema `json:"definition"`
+	Description             *string                     `json:"description,omitempty"`
+	IsEnabled               bool                        `json:"isEnabled"`
+	Name                    string                      `json:"name"`
+	Priority                *int                        `json:"priority,omitempty"`
+	SessionTimeoutInMinutes *int                        `json:"sessionTimeoutInMinutes,omitempty"`
+
+	// TriggerKeywords The flow starts when the message of a contact is one of these keywords, case insensitive.
+	TriggerKeywords *[]string `json:"triggerKeywords,omitempty"`
+
+	// TriggerOnNewConversation The flow starts on the first message of a new conversation.
+	TriggerOnNewConversation *bool `json:"triggerOnNewConversation,omitempty"`
+}
+
+// ChatbotFlowSchema defines model for ChatbotFlowSchema.
+type ChatbotFlowSchema struct {
+	CreatedAt                time.Time                   `json:"createdAt"`
+	Definition               ChatbotFlowDefinitionSchema `json:"definition"`
+	Description              *string                     `json:"description,omitempty"`
+	IsEnabled                bool                        `json:"isEnabled"`
+	Name                     string                      `json:"name"`
+	Priority                 int                         `json:"priority"`
+	SessionTimeoutInMinutes  int                         `json:"sessionTimeoutInMinutes"`
+	TriggerKeywords          []string                    `json:"triggerKeywords"`
+	TriggerOnNewConversation bool                        `json:"triggerOnNewConversation"`
+	UniqueId                 string                      `json:"uniqueId"`
+	UpdatedAt                time.Time                   `json:"updatedAt"`
+}
+
+// ChatbotFlowSimulatedMessageSchema defines model for ChatbotFlowSimulatedMessageSchema.
+type ChatbotFlowSimulatedMessageSchema struct {
+	// ButtonId Id of the reply button the contact taps, instead of a text message.
+	ButtonId    *string `json:"buttonId,omitempty"`
+	ButtonTitle *string `json:"buttonTitle,omitempty"`
+	Text        *string `json:"text,omitempty"`
+}
+
+// ChatbotFlowSimulationEventSchema defines model for ChatbotFlowSimulationEventSchema.
+type ChatbotFlowSimulationEventSchema struct {
+	Buttons     *[]InteractiveMessageButtonSchema `json:"buttons,omitempty"`
+	Description string                            `json:"description"`
+
+	// MessageIndex Index of the simulated message that led to the step, -1 for a timeout.
+	MessageIndex int                     `json:"messageIndex"`
+	StepId       string                  `json:"stepId"`
+	StepType     ChatbotFlowStepTypeEnum `json:"stepType"`
+
+	// Text Text of the message the step would send.
+	Text *string `json:"text,omitempty"`
+}
+
+// ChatbotFlowStepSchema defines model for ChatbotFlowStepSchema.
+type ChatbotFlowStepSchema struct {
+	AttributeKey *string `json:"attributeKey,omitempty"`
+
+	// AttributeValue Value of a SetAttribute step, can contain the same placeholders as the text.
+	AttributeValue *string `json:"attributeValue,omitempty"`
+
+	// Buttons (Optional) Reply buttons of a SendMessage step, max 3 buttons.
+	Buttons    *[]InteractiveMessageButtonSchema   `json:"buttons,omitempty"`
+	Conditions *[]ChatbotFlowBranchConditionSchema `json:"conditions,omitempty"`
+
+	// DefaultNext Step a Branch step goes to when no condition matches.
+	DefaultNext *string `json:"defaultNext,omitempty"`
+
+	// ErrorNext Step to go to when the webhook call fails, the session fails when not set.
+	ErrorNext *string `json:"errorNext,omitempty"`
+	Id        string  `json:"id"`
+
+	// Next Id of the step to go to after this one, the flow ends when there is none.
+	Next *string `json:"next,omitempty"`
+
+	// RoleId Organization role whose members form the team of an AssignTeam or HandOff step.
+	RoleId *string `json:"roleId,omitempty"`
+
+	// SaveAs Variable the reply of a WaitForReply step or the response of a CallWebhook step is saved to.
+	SaveAs *string `json:"saveAs,omitempty"`
+	TagId  *string `json:"tagId,omitempty"`
+
+	// Text Message sent by a SendMessage step, or sent before handing off by a HandOff step. Can contain {{reply}}, {{contact.name}}, {{contact.phone}} and {{variables.<name>}}.
+	Text *string `json:"text,omitempty"`
+
+	// TimeoutInMinutes How long a WaitForReply step waits, the session timeout of the flow is used when not set.
+	TimeoutInMinutes *int `json:"timeoutInMinutes,omitempty"`
+
+	// TimeoutNext Step to go to when a WaitForReply step times out, the session ends when not set.
+	TimeoutNext *string                 `json:"timeoutNext,omitempty"`
+	Type        ChatbotFlowStepTypeEnum `json:"type"`
+
+	// Url Url a CallWebhook step posts the session details to.
+	Url *string `json:"url,omitempty"`
+}
+
+// ChatbotFlowStepTypeEnum defines model for ChatbotFlowStepTypeEnum.
+type ChatbotFlowStepTypeEnum string
+
+// ClassificationDistributionDataPointSchema defines model for ClassificationDistributionDataPointSchema.
+type ClassificationDistributionDataPointSchema struct {
+	Count int    `json:"count"`
+	Label string `json:"label"`
+}
+
 // ContactListSchema defines model for ContactListSchema.
 type ContactListSchema struct {
 	CreatedAt             time.Time   `json:"createdAt"`
@@ -511,6 +1049,7 @@ type ContactSchema struct {
 	Name          string                              `json:"name"`
 	Phone         string                              `json:"phone"`
 	Status        ContactStatusEnum                   `json:"status"`
+	Tags          []TagSchema                         `json:"tags"`
 	UniqueId      string                              `json:"uniqueId"`
 }
 
@@ -527,17 +1066,60 @@ type ContactWithoutConversationSchema struct {
 	UniqueId   string                 `json:"uniqueId"`
 }
 
+// ContactsMessage defines model for ContactsMessage.
+type ContactsMessage struct {
+	// ConversationId ID of the conversation.
+	ConversationId string `json:"conversationId"`
+
+	// CreatedAt Time when the message was created.
+	CreatedAt time.Time            `json:"createdAt"`
+	Direction MessageDirectionEnum `json:"direction"`
+
+	// IsAiGenerated (Optional) The message was written by the AI auto responder.
+	IsAiGenerated *bool                      `json:"isAiGenerated,omitempty"`
+	MessageData   ContactsMessageData        `json:"messageData"`
+	MessageType   ContactsMessageMessageType `json:"messageType"`
+
+	// Reactions Reactions on this message.
+	Reactions *[]MessageReactionSchema `json:"reactions,omitempty"`
+
+	// RepliedTo (Optional) ID of the message this message is a reply to.
+	RepliedTo *string           `json:"repliedTo,omitempty"`
+	Status    MessageStatusEnum `json:"status"`
+
+	// UniqueId Unique identifier of the message.
+	UniqueId string `json:"uniqueId"`
+}
+
+// ContactsMessageMessageType defines model for ContactsMessage.MessageType.
+type ContactsMessageMessageType string
+
+// ContactsMessageData defines model for ContactsMessageData.
+type ContactsMessageData struct {
+	// Contacts Contact cards shared in the message.
+	Contacts []SharedContactSchema `json:"contacts"`
+}
+
 // ConversationAggregateAnalytics defines model for ConversationAggregateAnalytics.
 type ConversationAggregateAnalytics struct {
-	AvgResponseTimeInMinutes                float64                                       `json:"avgResponseTimeInMinutes"`
-	ConversationsActive                     int                                           `json:"conversationsActive"`
-	ConversationsAnalytics                  []ConversationAnalyticsDataPointSchema        `json:"conversationsAnalytics"`
-	ConversationsClosed                     int                                           `json:"conversationsClosed"`
-	ConversationsPending                    int                                           `json:"conversationsPending"`
-	InboundToOutboundRatio                  float64                                       `json:"inboundToOutboundRatio"`
+	AvgResponseTimeInMinutes float64                                `json:"avgResponseTimeInMinutes"`
+	ConversationsActive      int                                    `json:"conversationsActive"`
+	ConversationsAnalytics   []ConversationAnalyticsDataPointSchema `json:"conversationsAnalytics"`
+	ConversationsClosed      int                                    `json:"conversationsClosed"`
+	ConversationsPending     int                                    `json:"conversationsPending"`
+	InboundToOutboundRatio   float64                                `json:"inboundToOutboundRatio"`
+
+	// IntentDistribution Number of the classified inbound messages of the period per intent.
+	IntentDistribution *[]ClassificationDistributionDataPointSchema `json:"intentDistribution,omitempty"`
+
+	// LanguageDistribution Number of the classified inbound messages of the period per language.
+	LanguageDistribution                    *[]ClassificationDistributionDataPointSchema  `json:"languageDistribution,omitempty"`
 	MessageTypeTrafficDistributionAnalytics []MessageTypeDistributionGraphDataPointSchema `json:"messageTypeTrafficDistributionAnalytics"`
-	ServiceConversations                    int                                           `json:"serviceConversations"`
-	TotalConversations                      int                                           `json:"totalConversations"`
+
+	// SentimentDistribution Number of the classified inbound messages of the period per sentiment.
+	SentimentDistribution *[]ClassificationDistributionDataPointSchema `json:"sentimentDistribution,omitempty"`
+	ServiceConversations  int                                          `json:"serviceConversations"`
+	TotalConversations    int                                          `json:"totalConversations"`
 }
 
 // ConversationAnalyticsDataPointSchema defines model for ConversationAnalyticsDataPointSchema.
@@ -548,29 +1130,147 @@ type ConversationAnalyticsDataPointSchema struct {
 	NumberOfNewConversationOpened int       `json:"numberOfNewConversationOpened"`
 }
 
+// ConversationBulkActionEnum defines model for ConversationBulkActionEnum.
+type ConversationBulkActionEnum string
+
+// ConversationBulkActionFilterSchema defines model for ConversationBulkActionFilterSchema.
+type ConversationBulkActionFilterSchema struct {
+	CampaignId      *string                 `json:"campaignId,omitempty"`
+	ContactId       *string                 `json:"contactId,omitempty"`
+	ListId          *string                 `json:"listId,omitempty"`
+	PhoneNumberUsed *string                 `json:"phoneNumberUsed,omitempty"`
+	Status          *ConversationStatusEnum `json:"status,omitempty"`
+	TagId           *string                 `json:"tagId,omitempty"`
+}
+
+// ConversationBulkActionJobSchema defines model for ConversationBulkActionJobSchema.
+type ConversationBulkActionJobSchema struct {
+	Action         ConversationBulkActionEnum          `json:"action"`
+	CompletedAt    *time.Time                          `json:"completedAt,omitempty"`
+	CreatedAt      time.Time                           `json:"createdAt"`
+	Error          *string                             `json:"error,omitempty"`
+	FailedCount    int                                 `json:"failedCount"`
+	ProcessedCount int                                 `json:"processedCount"`
+	StartedAt      *time.Time                          `json:"startedAt,omitempty"`
+	Status         ConversationBulkActionJobStatusEnum `json:"status"`
+	TotalCount     int                                 `json:"totalCount"`
+	UniqueId       string                              `json:"uniqueId"`
+}
+
+// ConversationBulkActionJobStatusEnum defines model for ConversationBulkActionJobStatusEnum.
+type ConversationBulkActionJobStatusEnum string
+
 // ConversationInitiatedByEnum defines model for ConversationInitiatedByEnum.
 type ConversationInitiatedByEnum string
 
+// ConversationPriorityEnum defines model for ConversationPriorityEnum.
+type ConversationPriorityEnum string
+
+// ConversationReminderSchema defines model for ConversationReminderSchema.
+type ConversationReminderSchema struct {
+	ConversationId       string     `json:"conversationId"`
+	CreatedAt            time.Time  `json:"createdAt"`
+	FiredAt              *time.Time `json:"firedAt,omitempty"`
+	Note                 *string    `json:"note,omitempty"`
+	OrganizationMemberId string     `json:"organizationMemberId"`
+	RemindAt             time.Time  `json:"remindAt"`
+	UniqueId             string     `json:"uniqueId"`
+}
+
 // ConversationSchema defines model for ConversationSchema.
 type ConversationSchema struct {
-	AssignedTo             *OrganizationMemberSchema        `json:"assignedTo,omitempty"`
-	CampaignId             *string                          `json:"campaignId,omitempty"`
-	Contact                ContactWithoutConversationSchema `json:"contact"`
-	ContactId              string                           `json:"contactId"`
-	CreatedAt              time.Time                        `json:"createdAt"`
-	InitiatedBy            ConversationInitiatedByEnum      `json:"initiatedBy"`
-	Messages               []MessageSchema                  `json:"messages"`
-	NumberOfUnreadMessages int                              `json:"numberOfUnreadMessages"`
-	OrganizationId         string                           `json:"organizationId"`
-	Status                 ConversationStatusEnum           `json:"status"`
-	Tags                   []TagSchema                      `json:"tags"`
-	TotalMessages          *int                             `json:"totalMessages,omitempty"`
-	UniqueId               string                           `json:"uniqueId"`
+	AssignedTo                 *OrganizationMemberSchema        `json:"assignedTo,omitempty"`
+	CampaignId                 *string                          `json:"campaignId,omitempty"`
+	Contact                    ContactWithoutConversationSchema `json:"contact"`
+	ContactId                  string                           `json:"contactId"`
+	CreatedAt                  time.Time                        `json:"createdAt"`
+	InitiatedBy                ConversationInitiatedByEnum      `json:"initiatedBy"`
+	Intent                     *MessageIntentEnum               `json:"intent,omitempty"`
+	IsSnoozedUntilContactReply *bool                            `json:"isSnoozedUntilContactReply,omitempty"`
+
+	// Language ISO 639-1 code of the language of the latest classified message of the contact.
+	Language               *string                   `json:"language,omitempty"`
+	Messages               []MessageSchema           `json:"messages"`
+	NumberOfUnreadMessages int                       `json:"numberOfUnreadMessages"`
+	OrganizationId         string                    `json:"organizationId"`
+	PhoneNumberUsed        *string                   `json:"phoneNumberUsed,omitempty"`
+	Priority               *ConversationPriorityEnum `json:"priority,omitempty"`
+	Sentiment              *MessageSentimentEnum     `json:"sentiment,omitempty"`
+	SnoozedUntil           *time.Time                `json:"snoozedUntil,omitempty"`
+	Status                 ConversationStatusEnum    `json:"status"`
+	Tags                   []TagSchema               `json:"tags"`
+	TotalMessages          *int                      `json:"totalMessages,omitempty"`
+	UniqueId               string                    `json:"uniqueId"`
+}
+
+// ConversationSearchMatchTypeEnum defines model for ConversationSearchMatchTypeEnum.
+type ConversationSearchMatchTypeEnum string
+
+// ConversationSearchResultSchema defines model for ConversationSearchResultSchema.
+type ConversationSearchResultSchema struct {
+	ContactId      string `json:"contactId"`
+	ContactName    string `json:"contactName"`
+	ContactPhone   string `json:"contactPhone"`
+	ConversationId string `json:"conversationId"`
+
+	// Highlight Matched fragment of the text, the matched terms are wrapped in <mark></mark> tags.
+	Highlight string                          `json:"highlight"`
+	MatchType ConversationSearchMatchTypeEnum `json:"matchType"`
+	MatchedAt time.Time                       `json:"matchedAt"`
+
+	// MessageId Id of the matched message, only present when the match is in a message.
+	MessageId *string `json:"messageId,omitempty"`
+	Rank      float32 `json:"rank"`
 }
 
 // ConversationStatusEnum defines model for ConversationStatusEnum.
 type ConversationStatusEnum string
 
+// ConversationSummarySchema defines model for ConversationSummarySchema.
+type ConversationSummarySchema struct {
+	ContactId      string    `json:"contactId"`
+	Content        string    `json:"content"`
+	ConversationId string    `json:"conversationId"`
+	CreatedAt      time.Time `json:"createdAt"`
+
+	// LastMessageId Id of the last message of the conversation when it was summarized.
+	LastMessageId string    `json:"lastMessageId"`
+	UniqueId      string    `json:"uniqueId"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// ConversationTaggingRuleInputSchema defines model for ConversationTaggingRuleInputSchema.
+type ConversationTaggingRuleInputSchema struct {
+	AttributeKey   *string                         `json:"attributeKey,omitempty"`
+	AttributeValue *string                         `json:"attributeValue,omitempty"`
+	CampaignId     *string                         `json:"campaignId,omitempty"`
+	IsEnabled      *bool                           `json:"isEnabled,omitempty"`
+	Keywords       *[]string                       `json:"keywords,omitempty"`
+	Name           string                          `json:"name"`
+	TagId          string                          `json:"tagId"`
+	Type           ConversationTaggingRuleTypeEnum `json:"type"`
+}
+
+// ConversationTaggingRuleSchema defines model for ConversationTaggingRuleSchema.
+type ConversationTaggingRuleSchema struct {
+	AttributeKey   *string                         `json:"attributeKey,omitempty"`
+	AttributeValue *string                         `json:"attributeValue,omitempty"`
+	CampaignId     *string                         `json:"campaignId,omitempty"`
+	CreatedAt      time.Time                       `json:"createdAt"`
+	IsEnabled      bool                            `json:"isEnabled"`
+	Keywords       []string                        `json:"keywords"`
+	Name           string                          `json:"name"`
+	Tag            TagSchema                       `json:"tag"`
+	Type           ConversationTaggingRuleTypeEnum `json:"type"`
+	UniqueId       string                          `json:"uniqueId"`
+}
+
+// ConversationTaggingRuleTypeEnum defines model for ConversationTaggingRuleTypeEnum.
+type ConversationTaggingRuleTypeEnum string
+
+// ConversationTranscriptFormatEnum defines model for ConversationTranscriptFormatEnum.
+type ConversationTranscriptFormatEnum string
+
 // ConversationWithoutContactSchema defines model for ConversationWithoutContactSchema.
 type ConversationWithoutContactSchema struct {
 	CampaignId     *string                     `json:"campaignId,omitempty"`
@@ -588,11 +1288,102 @@ type CreateAiChatMessageVoteResponseSchema struct {
 	Vote AiChatMessageVoteSchema `json:"vote"`
 }
 
+// CreateBusinessAccountResponseSchema defines model for CreateBusinessAccountResponseSchema.
+type CreateBusinessAccountResponseSchema struct {
+	BusinessAccount BusinessAccountSchema `json:"businessAccount"`
+}
+
+// CreateBusinessAccountSchema defines model for CreateBusinessAccountSchema.
+type CreateBusinessAccountSchema struct {
+	AccessToken       string  `json:"accessToken"`
+	BusinessAccountId string  `json:"businessAccountId"`
+	Name              *string `json:"name,omitempty"`
+}
+
+// CreateCannedResponseFolderResponseSchema defines model for CreateCannedResponseFolderResponseSchema.
+type CreateCannedResponseFolderResponseSchema struct {
+	Folder CannedResponseFolderSchema `json:"folder"`
+}
+
+// CreateCannedResponseResponseSchema defines model for CreateCannedResponseResponseSchema.
+type CreateCannedResponseResponseSchema struct {
+	CannedResponse CannedResponseSchema `json:"cannedResponse"`
+}
+
+// CreateChatbotFlowResponseSchema defines model for CreateChatbotFlowResponseSchema.
+type CreateChatbotFlowResponseSchema struct {
+	Flow ChatbotFlowSchema `json:"flow"`
+}
+
+// CreateConversationBulkActionResponseSchema defines model for CreateConversationBulkActionResponseSchema.
+type CreateConversationBulkActionResponseSchema struct {
+	Job ConversationBulkActionJobSchema `json:"job"`
+}
+
+// CreateConversationBulkActionSchema defines model for CreateConversationBulkActionSchema.
+type CreateConversationBulkActionSchema struct {
+	Action               ConversationBulkActionEnum          `json:"action"`
+	ConversationIds      *[]string                           `json:"conversationIds,omitempty"`
+	Filter               *ConversationBulkActionFilterSchema `json:"filter,omitempty"`
+	OrganizationMemberId *string                             `json:"organizationMemberId,omitempty"`
+	TagIds               *[]string                           `json:"tagIds,omitempty"`
+}
+
+// CreateConversationReminderResponseSchema defines model for CreateConversationReminderResponseSchema.
+type CreateConversationReminderResponseSchema struct {
+	Reminder ConversationReminderSchema `json:"reminder"`
+}
+
+// CreateConversationTaggingRuleResponseSchema defines model for CreateConversationTaggingRuleResponseSchema.
+type CreateConversationTaggingRuleResponseSchema struct {
+	Rule ConversationTaggingRuleSchema `json:"rule"`
+}
+
 // CreateInviteResponseSchema defines model for CreateInviteResponseSchema.
 type CreateInviteResponseSchema struct {
 	Invite OrganizationMemberInviteSchema `json:"invite"`
 }
 
+// CreateKnowledgeBaseDocumentResponseSchema defines model for CreateKnowledgeBaseDocumentResponseSchema.
+type CreateKnowledgeBaseDocumentResponseSchema struct {
+	Document KnowledgeBaseDocumentSchema `json:"document"`
+}
+
+// CreateKnowledgeBaseFaqDocumentSchema defines model for CreateKnowledgeBaseFaqDocumentSchema.
+type CreateKnowledgeBaseFaqDocumentSchema struct {
+	Faqs  []KnowledgeBaseFaqSchema `json:"faqs"`
+	Title string                   `json:"title"`
+}
+
+// CreateKnowledgeBaseWebPageDocumentSchema defines model for CreateKnowledgeBaseWebPageDocumentSchema.
+type CreateKnowledgeBaseWebPageDocumentSchema struct {
+	// Title (Optional) Title of the document, the title of the page by default.
+	Title *string `json:"title,omitempty"`
+
+	// Url Public http or https url of the page.
+	Url string `json:"url"`
+}
+
+// CreateMessageTemplateResponseSchema defines model for CreateMessageTemplateResponseSchema.
+type CreateMessageTemplateResponseSchema struct {
+	Template MessageTemplateSchema `json:"template"`
+}
+
+// CreateMessageTemplateSchema defines model for CreateMessageTemplateSchema.
+type CreateMessageTemplateSchema struct {
+	// AllowCategoryChange lets whatsapp recategorize the template instead of rejecting it when the category does not match its content
+	AllowCategoryChange *bool `json:"allowCategoryChange,omitempty"`
+
+	// BusinessAccountId the business account to create the template in, defaults to the business account of the default phone number
+	BusinessAccountId *string                                   `json:"businessAccountId,omitempty"`
+	Category          MessageTemplateCategory                   `json:"category"`
+	Components        []WhatsAppBusinessHSMWhatsAppHSMComponent `json:"components"`
+
+	// Language the code of the language of the template, e.g. en_US
+	Language string `json:"language"`
+	Name     string `json:"name"`
+}
+
 // CreateNewCampaignResponseSchema defines model for CreateNewCampaignResponseSchema.
 type CreateNewCampaignResponseSchema struct {
 	Campaign CampaignSchema `json:"campaign"`
@@ -629,6 +1420,73 @@ type CreateNewRoleResponseSchema struct {
 	Role OrganizationRoleSchema `json:"role"`
 }
 
+// CreateSegmentRecommendationJobResponseSchema defines model for CreateSegmentRecommendationJobResponseSchema.
+type CreateSegmentRecommendationJobResponseSchema struct {
+	Job SegmentRecommendationJobSchema `json:"job"`
+}
+
+// CreateSegmentRecommendationJobSchema defines model for CreateSegmentRecommendationJobSchema.
+type CreateSegmentRecommendationJobSchema struct {
+	ContactListId string `json:"contactListId"`
+
+	// TagIds The tags the contacts are classified into, the AI proposes its own labels when empty.
+	TagIds *[]string `json:"tagIds,omitempty"`
+}
+
+// CsatBreakdownSchema defines model for CsatBreakdownSchema.
+type CsatBreakdownSchema struct {
+	Name    string            `json:"name"`
+	Summary CsatSummarySchema `json:"summary"`
+
+	// UniqueId Id of the organization member or the role of the team.
+	UniqueId string `json:"uniqueId"`
+}
+
+// CsatCommentSchema defines model for CsatCommentSchema.
+type CsatCommentSchema struct {
+	AssignedToName *string   `json:"assignedToName,omitempty"`
+	Comment        string    `json:"comment"`
+	CommentedAt    time.Time `json:"commentedAt"`
+	ConversationId string    `json:"conversationId"`
+	Rating         int       `json:"rating"`
+}
+
+// CsatDataPointSchema defines model for CsatDataPointSchema.
+type CsatDataPointSchema struct {
+	Date    openapi_types.Date `json:"date"`
+	Summary CsatSummarySchema  `json:"summary"`
+}
+
+// CsatSummarySchema defines model for CsatSummarySchema.
+type CsatSummarySchema struct {
+	// AverageRating Average rating, from 1 (poor) to 3 (great).
+	AverageRating float32 `json:"averageRating"`
+
+	// CsatScore Percentage of the ratings that are great.
+	CsatScore float32 `json:"csatScore"`
+
+	// ResponseRate Percentage of the sent surveys that were rated.
+	ResponseRate float32 `json:"responseRate"`
+	Responses    int     `json:"responses"`
+	SurveysSent  int     `json:"surveysSent"`
+}
+
+// CsatSurveyConfigurationSchema defines model for CsatSurveyConfigurationSchema.
+type CsatSurveyConfigurationSchema struct {
+	// CommentPrompt (Optional) Message asking the contact for a comment after the rating, no comment is asked when empty. Only a reply to this message is recorded as the comment.
+	CommentPrompt *string `json:"commentPrompt,omitempty"`
+	IsEnabled     bool    `json:"isEnabled"`
+
+	// Question Body of the survey message, the contact answers it with the Poor, Okay and Great buttons.
+	Question string `json:"question"`
+
+	// ResponseWindowInHours Hours the contact has to answer the survey.
+	ResponseWindowInHours int `json:"responseWindowInHours"`
+
+	// ThankYouMessage (Optional) Message sent once the survey is answered.
+	ThankYouMessage *string `json:"thankYouMessage,omitempty"`
+}
+
 // DashboardAggregateCountResponseSchema defines model for DashboardAggregateCountResponseSchema.
 type DashboardAggregateCountResponseSchema struct {
 	AggregateAnalytics AggregateAnalyticsSchema `json:"aggregateAnalytics"`
@@ -641,6 +1499,21 @@ type DateToCountGraphDataPointSchema struct {
 	Label string    `json:"label"`
 }
 
+// DeleteCannedResponseByIdResponseSchema defines model for DeleteCannedResponseByIdResponseSchema.
+type DeleteCannedResponseByIdResponseSchema struct {
+	Data bool `json:"data"`
+}
+
+// DeleteCannedResponseFolderByIdResponseSchema defines model for DeleteCannedResponseFolderByIdResponseSchema.
+type DeleteCannedResponseFolderByIdResponseSchema struct {
+	Data bool `json:"data"`
+}
+
+// DeleteChatbotFlowByIdResponseSchema defines model for DeleteChatbotFlowByIdResponseSchema.
+type DeleteChatbotFlowByIdResponseSchema struct {
+	Data bool `json:"data"`
+}
+
 // DeleteContactByIdResponseSchema defines model for DeleteContactByIdResponseSchema.
 type DeleteContactByIdResponseSchema struct {
 	Data bool `json:"data"`
@@ -651,6 +1524,26 @@ type DeleteConversationByIdResponseSchema struct {
 	Data bool `json:"data"`
 }
 
+// DeleteConversationReminderByIdResponseSchema defines model for DeleteConversationReminderByIdResponseSchema.
+type DeleteConversationReminderByIdResponseSchema struct {
+	Data bool `json:"data"`
+}
+
+// DeleteConversationTaggingRuleByIdResponseSchema defines model for DeleteConversationTaggingRuleByIdResponseSchema.
+type DeleteConversationTaggingRuleByIdResponseSchema struct {
+	Data bool `json:"data"`
+}
+
+// DeleteKnowledgeBaseDocumentByIdResponseSchema defines model for DeleteKnowledgeBaseDocumentByIdResponseSchema.
+type DeleteKnowledgeBaseDocumentByIdResponseSchema struct {
+	Data bool `json:"data"`
+}
+
+// DeleteMessageTemplateResponseSchema defines model for DeleteMessageTemplateResponseSchema.
+type DeleteMessageTemplateResponseSchema struct {
+	IsDeleted bool `json:"isDeleted"`
+}
+
 // DeleteOrganizationMemberByIdResponseSchema defines model for DeleteOrganizationMemberByIdResponseSchema.
 type DeleteOrganizationMemberByIdResponseSchema struct {
 	Data bool `json:"data"`
@@ -667,11 +1560,20 @@ type DocumentMessage struct {
 	ConversationId string `json:"conversationId"`
 
 	// CreatedAt Time when the message was created.
-	CreatedAt   time.Time                  `json:"createdAt"`
-	Direction   MessageDirectionEnum       `json:"direction"`
-	MessageData DocumentMessageData        `json:"messageData"`
-	MessageType DocumentMessageMessageType `json:"messageType"`
-	Status      MessageStatusEnum          `json:"status"`
+	CreatedAt time.Time            `json:"createdAt"`
+	Direction MessageDirectionEnum `json:"direction"`
+
+	// IsAiGenerated (Optional) The message was written by the AI auto responder.
+	IsAiGenerated *bool                      `json:"isAiGenerated,omitempty"`
+	MessageData   DocumentMessageData        `json:"messageData"`
+	MessageType   DocumentMessageMessageType `json:"messageType"`
+
+	// Reactions Reactions on this message.
+	Reactions *[]MessageReactionSchema `json:"reactions,omitempty"`
+
+	// RepliedTo (Optional) ID of the message this message is a reply to.
+	RepliedTo *string           `json:"repliedTo,omitempty"`
+	Status    MessageStatusEnum `json:"status"`
 
 	// UniqueId Unique identifier of the message.
 	UniqueId string `json:"uniqueId"`
@@ -695,6 +1597,22 @@ type DocumentMessageData struct {
 	Link *string `json:"link,omitempty"`
 }
 
+// EmailConversationTranscriptResponseSchema defines model for EmailConversationTranscriptResponseSchema.
+type EmailConversationTranscriptResponseSchema struct {
+	Data bool `json:"data"`
+}
+
+// EmailConversationTranscriptSchema defines model for EmailConversationTranscriptSchema.
+type EmailConversationTranscriptSchema struct {
+	// Email email address to send the transcript to, defaults to the email attribute of the contact. Any other address must be the email of a member of the organization.
+	Email  *openapi_types.Email              `json:"email,omitempty"`
+	Format *ConversationTranscriptFormatEnum `json:"format,omitempty"`
+
+	// Message message shown above the transcript in the email.
+	Message *string `json:"message,omitempty"`
+	Subject *string `json:"subject,omitempty"`
+}
+
 // EmailNotificationConfigurationSchema defines model for EmailNotificationConfigurationSchema.
 type EmailNotificationConfigurationSchema struct {
 	SmtpHost     string `json:"smtpHost"`
@@ -710,9 +1628,35 @@ type FeatureFlags struct {
 
 // FullAiConfiguration defines model for FullAiConfiguration.
 type FullAiConfiguration struct {
-	ApiKey    string      `json:"apiKey"`
-	IsEnabled bool        `json:"isEnabled"`
-	Model     AiModelEnum `json:"model"`
+	// AllowCrossProviderFallback Let the AI calls fall back to the models of other providers, billed to the platform, when the model of the organization fails. Without it an organization bringing its own API key only ever uses its provider.
+	AllowCrossProviderFallback *bool       `json:"allowCrossProviderFallback,omitempty"`
+	ApiKey                     string      `json:"apiKey"`
+	IsEnabled                  bool        `json:"isEnabled"`
+	Model                      AiModelEnum `json:"model"`
+
+	// StoreConversationSummaryOnClose Summarize the conversations when they are closed, to search them later.
+	StoreConversationSummaryOnClose *bool `json:"storeConversationSummaryOnClose,omitempty"`
+}
+
+// GenerateTemplateDraftsResponseSchema defines model for GenerateTemplateDraftsResponseSchema.
+type GenerateTemplateDraftsResponseSchema struct {
+	Drafts []TemplateDraftSchema `json:"drafts"`
+}
+
+// GenerateTemplateDraftsSchema defines model for GenerateTemplateDraftsSchema.
+type GenerateTemplateDraftsSchema struct {
+	// Brief what the template is about, e.g. the offer, the audience and the call to action
+	Brief    string                  `json:"brief"`
+	Category MessageTemplateCategory `json:"category"`
+
+	// Language the code of the language of the template, defaults to en_US
+	Language *string `json:"language,omitempty"`
+
+	// Tone e.g. friendly, formal
+	Tone *string `json:"tone,omitempty"`
+
+	// VariantCount the number of variants to draft, defaults to 2
+	VariantCount *int `json:"variantCount,omitempty"`
 }
 
 // GetAggregateCampaignAnalyticsResponseSchema defines model for GetAggregateCampaignAnalyticsResponseSchema.
@@ -720,6 +1664,16 @@ type GetAggregateCampaignAnalyticsResponseSchema struct {
 	Analytics CampaignAnalyticsResponseSchema `json:"analytics"`
 }
 
+// GetAiAutoResponderConfigurationResponseSchema defines model for GetAiAutoResponderConfigurationResponseSchema.
+type GetAiAutoResponderConfigurationResponseSchema struct {
+	Configuration AiAutoResponderConfigurationSchema `json:"configuration"`
+}
+
+// GetAiBudgetResponseSchema defines model for GetAiBudgetResponseSchema.
+type GetAiBudgetResponseSchema struct {
+	Budget AiBudgetSchema `json:"budget"`
+}
+
 // GetAiChatByIdResponseSchema defines model for GetAiChatByIdResponseSchema.
 type GetAiChatByIdResponseSchema struct {
 	Chat AiChatSchema `json:"chat"`
@@ -748,6 +1702,16 @@ type GetAiConfigurationResponseSchema struct {
 	AiConfiguration FullAiConfiguration `json:"aiConfiguration"`
 }
 
+// GetAiPiiRedactionConfigurationResponseSchema defines model for GetAiPiiRedactionConfigurationResponseSchema.
+type GetAiPiiRedactionConfigurationResponseSchema struct {
+	Configuration AiPiiRedactionConfigurationSchema `json:"configuration"`
+}
+
+// GetAiUsageReportResponseSchema defines model for GetAiUsageReportResponseSchema.
+type GetAiUsageReportResponseSchema struct {
+	Report AiUsageReportSchema `json:"report"`
+}
+
 // GetAllMessageTemplatesResponseSchema defines model for GetAllMessageTemplatesResponseSchema.
 type GetAllMessageTemplatesResponseSchema = []MessageTemplateSchema
 
@@ -759,6 +1723,11 @@ type GetApiKeysResponseSchema struct {
 	ApiKey ApiKeySchema `json:"apiKey"`
 }
 
+// GetBusinessAccountsResponseSchema defines model for GetBusinessAccountsResponseSchema.
+type GetBusinessAccountsResponseSchema struct {
+	BusinessAccounts []BusinessAccountSchema `json:"businessAccounts"`
+}
+
 // GetCampaignByIdResponseSchema defines model for GetCampaignByIdResponseSchema.
 type GetCampaignByIdResponseSchema struct {
 	Campaign CampaignSchema `json:"campaign"`
@@ -770,6 +1739,39 @@ type GetCampaignResponseSchema struct {
 	PaginationMeta PaginationMeta   `json:"paginationMeta"`
 }
 
+// GetCannedResponseAnalyticsResponseSchema defines model for GetCannedResponseAnalyticsResponseSchema.
+type GetCannedResponseAnalyticsResponseSchema struct {
+	ByMember   []CannedResponseUsageByMemberSchema   `json:"byMember"`
+	ByResponse []CannedResponseUsageByResponseSchema `json:"byResponse"`
+	TotalUsage int                                   `json:"totalUsage"`
+}
+
+// GetCannedResponseByIdResponseSchema defines model for GetCannedResponseByIdResponseSchema.
+type GetCannedResponseByIdResponseSchema struct {
+	CannedResponse CannedResponseSchema `json:"cannedResponse"`
+}
+
+// GetCannedResponseFoldersResponseSchema defines model for GetCannedResponseFoldersResponseSchema.
+type GetCannedResponseFoldersResponseSchema struct {
+	Folders []CannedResponseFolderSchema `json:"folders"`
+}
+
+// GetCannedResponsesResponseSchema defines model for GetCannedResponsesResponseSchema.
+type GetCannedResponsesResponseSchema struct {
+	CannedResponses []CannedResponseSchema `json:"cannedResponses"`
+	PaginationMeta  PaginationMeta         `json:"paginationMeta"`
+}
+
+// GetChatbotFlowByIdResponseSchema defines model for GetChatbotFlowByIdResponseSchema.
+type GetChatbotFlowByIdResponseSchema struct {
+	Flow ChatbotFlowSchema `json:"flow"`
+}
+
+// GetChatbotFlowsResponseSchema defines model for GetChatbotFlowsResponseSchema.
+type GetChatbotFlowsResponseSchema struct {
+	Flows []ChatbotFlowSchema `json:"flows"`
+}
+
 // GetContactByIdResponseSchema defines model for GetContactByIdResponseSchema.
 type GetContactByIdResponseSchema struct {
 	Contact ContactSchema `json:"contact"`
@@ -797,6 +1799,11 @@ type GetConversationAnalyticsResponseSchema struct {
 	Analytics ConversationAggregateAnalytics `json:"analytics"`
 }
 
+// GetConversationBulkActionByIdResponseSchema defines model for GetConversationBulkActionByIdResponseSchema.
+type GetConversationBulkActionByIdResponseSchema struct {
+	Job ConversationBulkActionJobSchema `json:"job"`
+}
+
 // GetConversationByIdResponseSchema defines model for GetConversationByIdResponseSchema.
 type GetConversationByIdResponseSchema struct {
 	Conversation ConversationSchema `json:"conversation"`
@@ -808,12 +1815,42 @@ type GetConversationMessagesResponseSchema struct {
 	PaginationMeta PaginationMeta  `json:"paginationMeta"`
 }
 
+// GetConversationRemindersResponseSchema defines model for GetConversationRemindersResponseSchema.
+type GetConversationRemindersResponseSchema struct {
+	Reminders []ConversationReminderSchema `json:"reminders"`
+}
+
+// GetConversationSummariesResponseSchema defines model for GetConversationSummariesResponseSchema.
+type GetConversationSummariesResponseSchema struct {
+	PaginationMeta PaginationMeta              `json:"paginationMeta"`
+	Summaries      []ConversationSummarySchema `json:"summaries"`
+}
+
+// GetConversationTaggingRulesResponseSchema defines model for GetConversationTaggingRulesResponseSchema.
+type GetConversationTaggingRulesResponseSchema struct {
+	Rules []ConversationTaggingRuleSchema `json:"rules"`
+}
+
 // GetConversationsResponseSchema defines model for GetConversationsResponseSchema.
 type GetConversationsResponseSchema struct {
 	Conversations  []ConversationSchema `json:"conversations"`
 	PaginationMeta PaginationMeta       `json:"paginationMeta"`
 }
 
+// GetCsatAnalyticsResponseSchema defines model for GetCsatAnalyticsResponseSchema.
+type GetCsatAnalyticsResponseSchema struct {
+	ByAgent        []CsatBreakdownSchema `json:"byAgent"`
+	ByTeam         []CsatBreakdownSchema `json:"byTeam"`
+	OverTime       []CsatDataPointSchema `json:"overTime"`
+	RecentComments []CsatCommentSchema   `json:"recentComments"`
+	Summary        CsatSummarySchema     `json:"summary"`
+}
+
+// GetCsatSurveyConfigurationResponseSchema defines model for GetCsatSurveyConfigurationResponseSchema.
+type GetCsatSurveyConfigurationResponseSchema struct {
+	Configuration CsatSurveyConfigurationSchema `json:"configuration"`
+}
+
 // GetFeatureFlagsResponseSchema defines model for GetFeatureFlagsResponseSchema.
 type GetFeatureFlagsResponseSchema struct {
 	FeatureFlags FeatureFlags `json:"featureFlags"`
@@ -825,6 +1862,23 @@ type GetIntegrationResponseSchema struct {
 	PaginationMeta PaginationMeta      `json:"paginationMeta"`
 }
 
+// GetKnowledgeBaseDocumentByIdResponseSchema defines model for GetKnowledgeBaseDocumentByIdResponseSchema.
+type GetKnowledgeBaseDocumentByIdResponseSchema struct {
+	Chunks   []KnowledgeBaseChunkSchema  `json:"chunks"`
+	Document KnowledgeBaseDocumentSchema `json:"document"`
+}
+
+// GetKnowledgeBaseDocumentsResponseSchema defines model for GetKnowledgeBaseDocumentsResponseSchema.
+type GetKnowledgeBaseDocumentsResponseSchema struct {
+	Documents      []KnowledgeBaseDocumentSchema `json:"documents"`
+	PaginationMeta PaginationMeta                `json:"paginationMeta"`
+}
+
+// GetMessageClassificationConfigurationResponseSchema defines model for GetMessageClassificationConfigurationResponseSchema.
+type GetMessageClassificationConfigurationResponseSchema struct {
+	Configuration MessageClassificationConfigurationSchema `json:"configuration"`
+}
+
 // GetMetaDataResponseSchema defines model for GetMetaDataResponseSchema.
 type GetMetaDataResponseSchema struct {
 	FaviconUrl      *string `json:"faviconUrl,omitempty"`
@@ -880,9 +1934,16 @@ type GetOrganizationsResponseSchema struct {
 // GetPhoneNumberByIdResponseSchema defines model for GetPhoneNumberByIdResponseSchema.
 type GetPhoneNumberByIdResponseSchema = PhoneNumberSchema
 
+// GetPhoneNumberHealthConfigurationResponseSchema defines model for GetPhoneNumberHealthConfigurationResponseSchema.
+type GetPhoneNumberHealthConfigurationResponseSchema struct {
+	Configuration PhoneNumberHealthConfigurationSchema `json:"configuration"`
+}
+
 // GetResponseSuggestionsResponse defines model for GetResponseSuggestionsResponse.
 type GetResponseSuggestionsResponse struct {
-	Suggestions []string `json:"suggestions"`
+	// Citations The knowledge base passages the suggestions are grounded on.
+	Citations   *[]KnowledgeBaseCitationSchema `json:"citations,omitempty"`
+	Suggestions []string                       `json:"suggestions"`
 }
 
 // GetRoleByIdResponseSchema defines model for GetRoleByIdResponseSchema.
@@ -890,6 +1951,11 @@ type GetRoleByIdResponseSchema struct {
 	Role OrganizationRoleSchema `json:"role"`
 }
 
+// GetSegmentRecommendationJobByIdResponseSchema defines model for GetSegmentRecommendationJobByIdResponseSchema.
+type GetSegmentRecommendationJobByIdResponseSchema struct {
+	Job SegmentRecommendationJobSchema `json:"job"`
+}
+
 // GetSegmentationRecommendationsResponse defines model for GetSegmentationRecommendationsResponse.
 type GetSegmentationRecommendationsResponse struct {
 	Recommendations []SegmentationRecommendation `json:"recommendations"`
@@ -918,11 +1984,20 @@ type ImageMessage struct {
 	ConversationId string `json:"conversationId"`
 
 	// CreatedAt Time when the message was created.
-	CreatedAt   time.Time               `json:"createdAt"`
-	Direction   MessageDirectionEnum    `json:"direction"`
-	MessageData ImageMessageData        `json:"messageData"`
-	MessageType ImageMessageMessageType `json:"messageType"`
-	Status      MessageStatusEnum       `json:"status"`
+	CreatedAt time.Time            `json:"createdAt"`
+	Direction MessageDirectionEnum `json:"direction"`
+
+	// IsAiGenerated (Optional) The message was written by the AI auto responder.
+	IsAiGenerated *bool                   `json:"isAiGenerated,omitempty"`
+	MessageData   ImageMessageData        `json:"messageData"`
+	MessageType   ImageMessageMessageType `json:"messageType"`
+
+	// Reactions Reactions on this message.
+	Reactions *[]MessageReactionSchema `json:"reactions,omitempty"`
+
+	// RepliedTo (Optional) ID of the message this message is a reply to.
+	RepliedTo *string           `json:"repliedTo,omitempty"`
+	Status    MessageStatusEnum `json:"status"`
 
 	// UniqueId Unique identifier of the message.
 	UniqueId string `json:"uniqueId"`
@@ -958,6 +2033,140 @@ type IntegrationSchema struct {
 // IntegrationStatusEnum defines model for IntegrationStatusEnum.
 type IntegrationStatusEnum string
 
+// InteractiveMessage defines model for InteractiveMessage.
+type InteractiveMessage struct {
+	// ConversationId ID of the conversation.
+	ConversationId string `json:"conversationId"`
+
+	// CreatedAt Time when the message was created.
+	CreatedAt time.Time            `json:"createdAt"`
+	Direction MessageDirectionEnum `json:"direction"`
+
+	// IsAiGenerated (Optional) The message was written by the AI auto responder.
+	IsAiGenerated *bool                         `json:"isAiGenerated,omitempty"`
+	MessageData   InteractiveMessageData        `json:"messageData"`
+	MessageType   InteractiveMessageMessageType `json:"messageType"`
+
+	// Reactions Reactions on this message.
+	Reactions *[]MessageReactionSchema `json:"reactions,omitempty"`
+
+	// RepliedTo (Optional) ID of the message this message is a reply to.
+	RepliedTo *string           `json:"repliedTo,omitempty"`
+	Status    MessageStatusEnum `json:"status"`
+
+	// UniqueId Unique identifier of the message.
+	UniqueId string `json:"uniqueId"`
+}
+
+// InteractiveMessageMessageType defines model for InteractiveMessage.MessageType.
+type InteractiveMessageMessageType string
+
+// InteractiveMessageButtonSchema defines model for InteractiveMessageButtonSchema.
+type InteractiveMessageButtonSchema struct {
+	// Id Unique identifier of the button, sent back in the reply of the contact.
+	Id string `json:"id"`
+
+	// Title Button label, max 20 characters.
+	Title string `json:"title"`
+}
+
+// InteractiveMessageCtaUrlSchema defines model for InteractiveMessageCtaUrlSchema.
+type InteractiveMessageCtaUrlSchema struct {
+	DisplayText string `json:"displayText"`
+	Url         string `json:"url"`
+}
+
+// InteractiveMessageData defines model for InteractiveMessageData.
+type InteractiveMessageData struct {
+	// Body Body text of the message.
+	Body string `json:"body"`
+
+	// Buttons Reply buttons, required for ReplyButtons messages, max 3 buttons.
+	Buttons *[]InteractiveMessageButtonSchema `json:"buttons,omitempty"`
+	CtaUrl  *InteractiveMessageCtaUrlSchema   `json:"ctaUrl,omitempty"`
+
+	// Footer (Optional) Footer text of the message.
+	Footer *string `json:"footer,omitempty"`
+
+	// Header (Optional) Text header of the message.
+	Header          *string                    `json:"header,omitempty"`
+	InteractiveType InteractiveMessageTypeEnum `json:"interactiveType"`
+
+	// ListButtonText Label of the button opening the list, required for List messages.
+	ListButtonText *string `json:"listButtonText,omitempty"`
+
+	// Sections List sections, required for List messages, max 10 rows in total.
+	Sections *[]InteractiveMessageListSectionSchema `json:"sections,omitempty"`
+}
+
+// InteractiveMessageListRowSchema defines model for InteractiveMessageListRowSchema.
+type InteractiveMessageListRowSchema struct {
+	// Description Row description, max 72 characters.
+	Description *string `json:"description,omitempty"`
+
+	// Id Unique identifier of the row, sent back in the reply of the contact.
+	Id string `json:"id"`
+
+	// Title Row title, max 24 characters.
+	Title string `json:"title"`
+}
+
+// InteractiveMessageListSectionSchema defines model for InteractiveMessageListSectionSchema.
+type InteractiveMessageListSectionSchema struct {
+	Rows  []InteractiveMessageListRowSchema `json:"rows"`
+	Title string                            `json:"title"`
+}
+
+// InteractiveMessageTypeEnum defines model for InteractiveMessageTypeEnum.
+type InteractiveMessageTypeEnum string
+
+// InteractiveReplyMessage defines model for InteractiveReplyMessage.
+type InteractiveReplyMessage struct {
+	// ConversationId ID of the conversation.
+	ConversationId string `json:"conversationId"`
+
+	// CreatedAt Time when the message was created.
+	CreatedAt time.Time            `json:"createdAt"`
+	Direction MessageDirectionEnum `json:"direction"`
+
+	// IsAiGenerated (Optional) The message was written by the AI auto responder.
+	IsAiGenerated *bool                              `json:"isAiGenerated,omitempty"`
+	MessageData   InteractiveReplyMessageData        `json:"messageData"`
+	MessageType   InteractiveReplyMessageMessageType `json:"messageType"`
+
+	// Reactions Reactions on this message.
+	Reactions *[]MessageReactionSchema `json:"reactions,omitempty"`
+
+	// RepliedTo (Optional) ID of the message this message is a reply to.
+	RepliedTo *string           `json:"repliedTo,omitempty"`
+	Status    MessageStatusEnum `json:"status"`
+
+	// UniqueId Unique identifier of the message.
+	UniqueId string `json:"uniqueId"`
+}
+
+// InteractiveReplyMessageMessageType defines model for InteractiveReplyMessage.MessageType.
+type InteractiveReplyMessageMessageType string
+
+// InteractiveReplyMessageData defines model for InteractiveReplyMessageData.
+type InteractiveReplyMessageData struct {
+	// Description Description of the selected list row.
+	Description *string `json:"description,omitempty"`
+
+	// Id Id of the selected button or list row, payload of the button for quick replies.
+	Id string `json:"id"`
+
+	// RepliedToMessageId Id of the interactive message the contact replied to.
+	RepliedToMessageId *string                  `json:"repliedToMessageId,omitempty"`
+	ReplyType          InteractiveReplyTypeEnum `json:"replyType"`
+
+	// Title Title of the selected button or list row.
+	Title string `json:"title"`
+}
+
+// InteractiveReplyTypeEnum defines model for InteractiveReplyTypeEnum.
+type InteractiveReplyTypeEnum string
+
 // InviteStatusEnum defines model for InviteStatusEnum.
 type InviteStatusEnum string
 
@@ -971,17 +2180,76 @@ type JoinOrganizationResponseBodySchema struct {
 	Token string `json:"token"`
 }
 
+// KnowledgeBaseChunkSchema defines model for KnowledgeBaseChunkSchema.
+type KnowledgeBaseChunkSchema struct {
+	ChunkIndex int    `json:"chunkIndex"`
+	Content    string `json:"content"`
+	UniqueId   string `json:"uniqueId"`
+}
+
+// KnowledgeBaseCitationSchema A knowledge base passage an AI answer is grounded on.
+type KnowledgeBaseCitationSchema struct {
+	ChunkId    string `json:"chunkId"`
+	DocumentId string `json:"documentId"`
+	Excerpt    string `json:"excerpt"`
+
+	// Index Number the AI refers to the passage with in its answer, e.g. [1].
+	Index int `json:"index"`
+
+	// Score Relevance of the passage, higher is more relevant.
+	Score      float64                     `json:"score"`
+	SourceType KnowledgeBaseSourceTypeEnum `json:"sourceType"`
+	SourceUrl  *string                     `json:"sourceUrl,omitempty"`
+	Title      string                      `json:"title"`
+}
+
+// KnowledgeBaseDocumentSchema defines model for KnowledgeBaseDocumentSchema.
+type KnowledgeBaseDocumentSchema struct {
+	ChunkCount int       `json:"chunkCount"`
+	CreatedAt  time.Time `json:"createdAt"`
+
+	// Error Why the indexing failed, only for the IndexingFailed documents.
+	Error      *string                         `json:"error,omitempty"`
+	SourceType KnowledgeBaseSourceTypeEnum     `json:"sourceType"`
+	SourceUrl  *string                         `json:"sourceUrl,omitempty"`
+	Status     KnowledgeBaseDocumentStatusEnum `json:"status"`
+	Title      string                          `json:"title"`
+	UniqueId   string                          `json:"uniqueId"`
+	UpdatedAt  time.Time                       `json:"updatedAt"`
+}
+
+// KnowledgeBaseDocumentStatusEnum defines model for KnowledgeBaseDocumentStatusEnum.
+type KnowledgeBaseDocumentStatusEnum string
+
+// KnowledgeBaseFaqSchema defines model for KnowledgeBaseFaqSchema.
+type KnowledgeBaseFaqSchema struct {
+	Answer   string `json:"answer"`
+	Question string `json:"question"`
+}
+
+// KnowledgeBaseSourceTypeEnum defines model for KnowledgeBaseSourceTypeEnum.
+type KnowledgeBaseSourceTypeEnum string
+
 // LocationMessage defines model for LocationMessage.
 type LocationMessage struct {
 	// ConversationId ID of the conversation.
 	ConversationId string `json:"conversationId"`
 
 	// CreatedAt Time when the message was created.
-	CreatedAt   time.Time                  `json:"createdAt"`
-	Direction   MessageDirectionEnum       `json:"direction"`
-	MessageData LocationMessageData        `json:"messageData"`
-	MessageType LocationMessageMessageType `json:"messageType"`
-	Status      MessageStatusEnum          `json:"status"`
+	CreatedAt time.Time            `json:"createdAt"`
+	Direction MessageDirectionEnum `json:"direction"`
+
+	// IsAiGenerated (Optional) The message was written by the AI auto responder.
+	IsAiGenerated *bool                      `json:"isAiGenerated,omitempty"`
+	MessageData   LocationMessageData        `json:"messageData"`
+	MessageType   LocationMessageMessageType `json:"messageType"`
+
+	// Reactions Reactions on this message.
+	Reactions *[]MessageReactionSchema `json:"reactions,omitempty"`
+
+	// RepliedTo (Optional) ID of the message this message is a reply to.
+	RepliedTo *string           `json:"repliedTo,omitempty"`
+	Status    MessageStatusEnum `json:"status"`
 
 	// UniqueId Unique identifier of the message.
 	UniqueId string `json:"uniqueId"`
@@ -1032,14 +2300,40 @@ type MessageAnalyticGraphDataPointSchema struct {
 	Sent      int       `json:"sent"`
 }
 
+// MessageClassificationConfigurationSchema defines model for MessageClassificationConfigurationSchema.
+type MessageClassificationConfigurationSchema struct {
+	EscalationPriority ConversationPriorityEnum `json:"escalationPriority"`
+
+	// EscalationRoleId (Optional) Team the unassigned conversations reaching the escalation priority are assigned to.
+	EscalationRoleId *string `json:"escalationRoleId,omitempty"`
+
+	// IsEnabled Classifies the sentiment, the intent and the language of every inbound message with the AI model of the organization.
+	IsEnabled bool `json:"isEnabled"`
+}
+
 // MessageDirectionEnum defines model for MessageDirectionEnum.
 type MessageDirectionEnum string
 
+// MessageIntentEnum defines model for MessageIntentEnum.
+type MessageIntentEnum string
+
+// MessageReactionSchema defines model for MessageReactionSchema.
+type MessageReactionSchema struct {
+	Direction MessageDirectionEnum `json:"direction"`
+	ReactedAt time.Time            `json:"reactedAt"`
+
+	// Reaction Reaction emoji.
+	Reaction string `json:"reaction"`
+}
+
 // MessageSchema The message object returned from the API (or retrieved from the database) with a discriminator to determine the concrete type.
 type MessageSchema struct {
 	union json.RawMessage
 }
 
+// MessageSentimentEnum defines model for MessageSentimentEnum.
+type MessageSentimentEnum string
+
 // MessageStatusEnum defines model for MessageStatusEnum.
 type MessageStatusEnum string
 
@@ -1052,21 +2346,31 @@ type MessageTemplateComponentFormat string
 // MessageTemplateComponentType defines model for MessageTemplateComponentType.
 type MessageTemplateComponentType string
 
+// MessageTemplateQualityRating defines model for MessageTemplateQualityRating.
+type MessageTemplateQualityRating string
+
 // MessageTemplateSchema defines model for MessageTemplateSchema.
 type MessageTemplateSchema struct {
+	// BusinessAccountId the id of the whatsapp business account the template belongs to
+	BusinessAccountId          *string                                    `json:"business_account_id,omitempty"`
 	Category                   MessageTemplateCategory                    `json:"category"`
 	Components                 *[]WhatsAppBusinessHSMWhatsAppHSMComponent `json:"components,omitempty"`
 	CorrectCategory            *string                                    `json:"correct_category,omitempty"`
 	CtaUrlLinkTrackingOptedOut *bool                                      `json:"cta_url_link_tracking_opted_out,omitempty"`
 	Id                         string                                     `json:"id"`
 	Language                   string                                     `json:"language"`
+	LastSyncedAt               *time.Time                                 `json:"last_synced_at,omitempty"`
 	LibraryTemplateName        *string                                    `json:"library_template_name,omitempty"`
 	MessageSendTtlSeconds      *int                                       `json:"message_send_ttl_seconds,omitempty"`
 	Name                       string                                     `json:"name"`
 	PreviousCategory           *string                                    `json:"previous_category,omitempty"`
+	QualityRating              *MessageTemplateQualityRating              `json:"quality_rating,omitempty"`
 	QualityScore               *TemplateMessageQualityScore               `json:"quality_score,omitempty"`
 	RejectedReason             *string                                    `json:"rejected_reason,omitempty"`
 	Status                     MessageTemplateStatus                      `json:"status"`
+
+	// StatusReason why the template has been rejected, paused or disabled
+	StatusReason *string `json:"status_reason,omitempty"`
 }
 
 // MessageTemplateStatus defines model for MessageTemplateStatus.
@@ -1091,6 +2395,21 @@ type NewCampaignSchema struct {
 	TemplateMessageId     string     `json:"templateMessageId"`
 }
 
+// NewCannedResponseFolderSchema defines model for NewCannedResponseFolderSchema.
+type NewCannedResponseFolderSchema struct {
+	Name string `json:"name"`
+}
+
+// NewCannedResponseSchema defines model for NewCannedResponseSchema.
+type NewCannedResponseSchema struct {
+	Content            string                       `json:"content"`
+	FolderId           *string                      `json:"folderId,omitempty"`
+	OrganizationRoleId *string                      `json:"organizationRoleId,omitempty"`
+	Shortcut           string                       `json:"shortcut"`
+	Title              string                       `json:"title"`
+	Visibility         CannedResponseVisibilityEnum `json:"visibility"`
+}
+
 // NewContactListSchema defines model for NewContactListSchema.
 type NewContactListSchema struct {
 	ContactIds  *[]string `json:"contactIds,omitempty"`
@@ -1108,6 +2427,12 @@ type NewContactSchema struct {
 	Status     ContactStatusEnum      `json:"status"`
 }
 
+// NewConversationReminderSchema defines model for NewConversationReminderSchema.
+type NewConversationReminderSchema struct {
+	Note     *string   `json:"note,omitempty"`
+	RemindAt time.Time `json:"remindAt"`
+}
+
 // NewMessageDataSchema OneOf-based union for new message data, distinguished by `messageType`.
 type NewMessageDataSchema struct {
 	// MessageType The type for this new message data.
@@ -1117,7 +2442,9 @@ type NewMessageDataSchema struct {
 
 // NewMessageSchema Request payload for sending a new message. The payload includes the messageType, createdAt date, and a messageData field whose structure depends on the messageType.
 type NewMessageSchema struct {
-	CreatedAt time.Time `json:"createdAt"`
+	// CannedResponseId (Optional) Id of the canned response used to compose this message, the content of the response is rendered with the conversation variables and the usage is recorded.
+	CannedResponseId *string   `json:"cannedResponseId,omitempty"`
+	CreatedAt        time.Time `json:"createdAt"`
 
 	// MessageData OneOf-based union for new message data, distinguished by `messageType`.
 	MessageData NewMessageDataSchema `json:"messageData"`
@@ -1161,6 +2488,55 @@ type NotificationSchema struct {
 // OrderEnum defines model for OrderEnum.
 type OrderEnum string
 
+// OrderMessage defines model for OrderMessage.
+type OrderMessage struct {
+	// ConversationId ID of the conversation.
+	ConversationId string `json:"conversationId"`
+
+	// CreatedAt Time when the message was created.
+	CreatedAt time.Time            `json:"createdAt"`
+	Direction MessageDirectionEnum `json:"direction"`
+
+	// IsAiGenerated (Optional) The message was written by the AI auto responder.
+	IsAiGenerated *bool                   `json:"isAiGenerated,omitempty"`
+	MessageData   OrderMessageData        `json:"messageData"`
+	MessageType   OrderMessageMessageType `json:"messageType"`
+
+	// Reactions Reactions on this message.
+	Reactions *[]MessageReactionSchema `json:"reactions,omitempty"`
+
+	// RepliedTo (Optional) ID of the message this message is a reply to.
+	RepliedTo *string           `json:"repliedTo,omitempty"`
+	Status    MessageStatusEnum `json:"status"`
+
+	// UniqueId Unique identifier of the message.
+	UniqueId string `json:"uniqueId"`
+}
+
+// OrderMessageMessageType defines model for OrderMessage.MessageType.
+type OrderMessageMessageType string
+
+// OrderMessageData defines model for OrderMessageData.
+type OrderMessageData struct {
+	CatalogId    string                   `json:"catalogId"`
+	ProductItems []OrderProductItemSchema `json:"productItems"`
+
+	// Text (Optional) Text sent by the contact along with the order.
+	Text *string `json:"text,omitempty"`
+}
+
+// OrderProductItemSchema defines model for OrderProductItemSchema.
+type OrderProductItemSchema struct {
+	Currency string `json:"currency"`
+
+	// ItemPrice Price of a single unit of the product.
+	ItemPrice float64 `json:"itemPrice"`
+
+	// ProductRetailerId Retailer id of the product in the catalog.
+	ProductRetailerId string `json:"productRetailerId"`
+	Quantity          int    `json:"quantity"`
+}
+
 // OrganizationMemberInviteSchema defines model for OrganizationMemberInviteSchema.
 type OrganizationMemberInviteSchema struct {
 	AccessLevel      UserPermissionLevelEnum `json:"accessLevel"`
@@ -1212,16 +2588,140 @@ type PaginationMeta struct {
 	Total   int   `json:"total"`
 }
 
+// PhoneNumberHealthConfigurationSchema defines model for PhoneNumberHealthConfigurationSchema.
+type PhoneNumberHealthConfigurationSchema struct {
+	// IsCampaignAutoPauseEnabled Pauses the running campaigns of a phone number when its quality rating drops to red.
+	IsCampaignAutoPauseEnabled bool `json:"isCampaignAutoPauseEnabled"`
+
+	// IsEmailNotificationEnabled Emails the owners and admins of the organization on a downgrade.
+	IsEmailNotificationEnabled bool `json:"isEmailNotificationEnabled"`
+
+	// IsSlackNotificationEnabled Posts the downgrades to the slack channel of the organization, when one is configured.
+	IsSlackNotificationEnabled bool `json:"isSlackNotificationEnabled"`
+}
+
+// PhoneNumberHealthEventSchema defines model for PhoneNumberHealthEventSchema.
+type PhoneNumberHealthEventSchema struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Description (Optional) The reason or the details whatsapp gave along with the change.
+	Description *string `json:"description,omitempty"`
+
+	// IsAccountEvent The event is about the business account of the phone number rather than the phone number itself.
+	IsAccountEvent bool                           `json:"isAccountEvent"`
+	IsDowngrade    bool                           `json:"isDowngrade"`
+	NewValue       *string                        `json:"newValue,omitempty"`
+	PreviousValue  *string                        `json:"previousValue,omitempty"`
+	Type           PhoneNumberHealthEventTypeEnum `json:"type"`
+	UniqueId       string                         `json:"uniqueId"`
+}
+
+// PhoneNumberHealthEventTypeEnum defines model for PhoneNumberHealthEventTypeEnum.
+type PhoneNumberHealthEventTypeEnum string
+
+// PhoneNumberHealthSchema defines model for PhoneNumberHealthSchema.
+type PhoneNumberHealthSchema struct {
+	MessagingLimitTier PhoneNumberMessagingLimitTierEnum `json:"messagingLimitTier"`
+	NameStatus         PhoneNumberNameStatusEnum         `json:"nameStatus"`
+	QualityRating      PhoneNumberQualityRatingEnum      `json:"qualityRating"`
+
+	// UpdatedAt (Optional) When whatsapp last told the health of the phone number.
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// PhoneNumberMessagingLimitTierEnum defines model for PhoneNumberMessagingLimitTierEnum.
+type PhoneNumberMessagingLimitTierEnum string
+
+// PhoneNumberNameStatusEnum defines model for PhoneNumberNameStatusEnum.
+type PhoneNumberNameStatusEnum string
+
+// PhoneNumberQualityRatingEnum defines model for PhoneNumberQualityRatingEnum.
+type PhoneNumberQualityRatingEnum string
+
 // PhoneNumberSchema defines model for PhoneNumberSchema.
 type PhoneNumberSchema struct {
 	CodeVerificationStatus struct {
 		Status *string `json:"status,omitempty"`
 	} `json:"code_verification_status"`
-	DisplayPhoneNumber string `json:"display_phone_number"`
-	Id                 string `json:"id"`
-	PlatformType       string `json:"platform_type"`
-	QualityRating      string `json:"quality_rating"`
-	VerifiedName       string `json:"verified_name"`
+	DisplayPhoneNumber string                   `json:"display_phone_number"`
+	Health             *PhoneNumberHealthSchema `json:"health,omitempty"`
+
+	// HealthHistory (Optional) The latest changes of the health of the phone number and of its business account, the latest first.
+	HealthHistory *[]PhoneNumberHealthEventSchema `json:"healthHistory,omitempty"`
+	Id            string                          `json:"id"`
+	PlatformType  string                          `json:"platform_type"`
+	QualityRating string                          `json:"quality_rating"`
+	VerifiedName  string                          `json:"verified_name"`
+}
+
+// PiiCustomDetectorSchema defines model for PiiCustomDetectorSchema.
+type PiiCustomDetectorSchema struct {
+	// Name the name of the placeholders, e.g. ORDER_ID for <ORDER_ID_1>
+	Name string `json:"name"`
+
+	// Pattern a regular expression in the RE2 syntax
+	Pattern string `json:"pattern"`
+}
+
+// PiiDetectorSchema defines model for PiiDetectorSchema.
+type PiiDetectorSchema struct {
+	Description string `json:"description"`
+	Name        string `json:"name"`
+}
+
+// PreviewAiPiiRedactionResponseSchema defines model for PreviewAiPiiRedactionResponseSchema.
+type PreviewAiPiiRedactionResponseSchema struct {
+	RedactedText string `json:"redactedText"`
+}
+
+// PreviewAiPiiRedactionSchema defines model for PreviewAiPiiRedactionSchema.
+type PreviewAiPiiRedactionSchema struct {
+	Configuration *UpdateAiPiiRedactionConfigurationSchema `json:"configuration,omitempty"`
+	Text          string                                   `json:"text"`
+}
+
+// PreviewCannedResponseResponseSchema defines model for PreviewCannedResponseResponseSchema.
+type PreviewCannedResponseResponseSchema struct {
+	Content string `json:"content"`
+}
+
+// ProductInquiryMessage defines model for ProductInquiryMessage.
+type ProductInquiryMessage struct {
+	// ConversationId ID of the conversation.
+	ConversationId string `json:"conversationId"`
+
+	// CreatedAt Time when the message was created.
+	CreatedAt time.Time            `json:"createdAt"`
+	Direction MessageDirectionEnum `json:"direction"`
+
+	// IsAiGenerated (Optional) The message was written by the AI auto responder.
+	IsAiGenerated *bool                            `json:"isAiGenerated,omitempty"`
+	MessageData   ProductInquiryMessageData        `json:"messageData"`
+	MessageType   ProductInquiryMessageMessageType `json:"messageType"`
+
+	// Reactions Reactions on this message.
+	Reactions *[]MessageReactionSchema `json:"reactions,omitempty"`
+
+	// RepliedTo (Optional) ID of the message this message is a reply to.
+	RepliedTo *string           `json:"repliedTo,omitempty"`
+	Status    MessageStatusEnum `json:"status"`
+
+	// UniqueId Unique identifier of the message.
+	UniqueId string `json:"uniqueId"`
+}
+
+// ProductInquiryMessageMessageType defines model for ProductInquiryMessage.MessageType.
+type ProductInquiryMessageMessageType string
+
+// ProductInquiryMessageData defines model for ProductInquiryMessageData.
+type ProductInquiryMessageData struct {
+	CatalogId string `json:"catalogId"`
+
+	// ProductRetailerId Retailer id of the product the contact is enquiring about.
+	ProductRetailerId string `json:"productRetailerId"`
+
+	// Text Question of the contact about the product.
+	Text string `json:"text"`
 }
 
 // RateLimitErrorResponseSchema defines model for RateLimitErrorResponseSchema.
@@ -1237,11 +2737,20 @@ type ReactionMessage struct {
 	ConversationId string `json:"conversationId"`
 
 	// CreatedAt Time when the message was created.
-	CreatedAt   time.Time                  `json:"createdAt"`
-	Direction   MessageDirectionEnum       `json:"direction"`
-	MessageData ReactionMessageData        `json:"messageData"`
-	MessageType ReactionMessageMessageType `json:"messageType"`
-	Status      MessageStatusEnum          `json:"status"`
+	CreatedAt time.Time            `json:"createdAt"`
+	Direction MessageDirectionEnum `json:"direction"`
+
+	// IsAiGenerated (Optional) The message was written by the AI auto responder.
+	IsAiGenerated *bool                      `json:"isAiGenerated,omitempty"`
+	MessageData   ReactionMessageData        `json:"messageData"`
+	MessageType   ReactionMessageMessageType `json:"messageType"`
+
+	// Reactions Reactions on this message.
+	Reactions *[]MessageReactionSchema `json:"reactions,omitempty"`
+
+	// RepliedTo (Optional) ID of the message this message is a reply to.
+	RepliedTo *string           `json:"repliedTo,omitempty"`
+	Status    MessageStatusEnum `json:"status"`
 
 	// UniqueId Unique identifier of the message.
 	UniqueId string `json:"uniqueId"`
@@ -1303,10 +2812,43 @@ type RoleUpdateSchema struct {
 	Permissions []RolePermissionEnum `json:"permissions"`
 }
 
+// SearchConversationsResponseSchema defines model for SearchConversationsResponseSchema.
+type SearchConversationsResponseSchema struct {
+	PaginationMeta PaginationMeta                   `json:"paginationMeta"`
+	Results        []ConversationSearchResultSchema `json:"results"`
+}
+
+// SearchKnowledgeBaseResponseSchema defines model for SearchKnowledgeBaseResponseSchema.
+type SearchKnowledgeBaseResponseSchema struct {
+	Results []KnowledgeBaseCitationSchema `json:"results"`
+}
+
+// SearchKnowledgeBaseSchema defines model for SearchKnowledgeBaseSchema.
+type SearchKnowledgeBaseSchema struct {
+	// Limit (Optional) Number of passages to return, 5 by default.
+	Limit *int   `json:"limit,omitempty"`
+	Query string `json:"query"`
+}
+
+// SegmentRecommendationJobSchema defines model for SegmentRecommendationJobSchema.
+type SegmentRecommendationJobSchema struct {
+	CompletedAt    *time.Time                          `json:"completedAt,omitempty"`
+	ContactListId  string                              `json:"contactListId"`
+	CreatedAt      time.Time                           `json:"createdAt"`
+	Error          *string                             `json:"error,omitempty"`
+	FailedCount    int                                 `json:"failedCount"`
+	ProcessedCount int                                 `json:"processedCount"`
+	StartedAt      *time.Time                          `json:"startedAt,omitempty"`
+	Status         ConversationBulkActionJobStatusEnum `json:"status"`
+	TotalCount     int                                 `json:"totalCount"`
+	UniqueId       string                              `json:"uniqueId"`
+}
+
 // SegmentationRecommendation defines model for SegmentationRecommendation.
 type SegmentationRecommendation struct {
-	Lists []ContactListSchema `json:"lists"`
-	Tags  []TagSchema         `json:"tags"`
+	// Label The label of the recommended tag.
+	Label string     `json:"label"`
+	Tag   *TagSchema `json:"tag,omitempty"`
 }
 
 // SendMessageInConversationResponseSchema defines model for SendMessageInConversationResponseSchema.
@@ -1315,23 +2857,114 @@ type SendMessageInConversationResponseSchema struct {
 	Message MessageSchema `json:"message"`
 }
 
+// SendTypingIndicatorResponseSchema defines model for SendTypingIndicatorResponseSchema.
+type SendTypingIndicatorResponseSchema struct {
+	Data bool `json:"data"`
+}
+
+// SetDefaultPhoneNumberResponseSchema defines model for SetDefaultPhoneNumberResponseSchema.
+type SetDefaultPhoneNumberResponseSchema struct {
+	IsDefault bool `json:"isDefault"`
+}
+
+// SharedContactEmailSchema defines model for SharedContactEmailSchema.
+type SharedContactEmailSchema struct {
+	Email string `json:"email"`
+
+	// Type Type of the email address, e.g. HOME, WORK.
+	Type *string `json:"type,omitempty"`
+}
+
+// SharedContactPhoneSchema defines model for SharedContactPhoneSchema.
+type SharedContactPhoneSchema struct {
+	Phone string `json:"phone"`
+
+	// Type Type of the phone number, e.g. CELL, HOME, WORK.
+	Type *string `json:"type,omitempty"`
+
+	// WaId WhatsApp id of the phone number, present only if the number is on WhatsApp.
+	WaId *string `json:"waId,omitempty"`
+}
+
+// SharedContactSchema defines model for SharedContactSchema.
+type SharedContactSchema struct {
+	Birthday  *string                    `json:"birthday,omitempty"`
+	Company   *string                    `json:"company,omitempty"`
+	Emails    []SharedContactEmailSchema `json:"emails"`
+	FirstName *string                    `json:"firstName,omitempty"`
+
+	// FormattedName Full name of the shared contact as displayed on WhatsApp.
+	FormattedName string                     `json:"formattedName"`
+	LastName      *string                    `json:"lastName,omitempty"`
+	Phones        []SharedContactPhoneSchema `json:"phones"`
+	Title         *string                    `json:"title,omitempty"`
+	Urls          *[]string                  `json:"urls,omitempty"`
+}
+
+// SimulateChatbotFlowResponseSchema defines model for SimulateChatbotFlowResponseSchema.
+type SimulateChatbotFlowResponseSchema struct {
+	CurrentStepId     string                             `json:"currentStepId"`
+	Error             *string                            `json:"error,omitempty"`
+	Events            []ChatbotFlowSimulationEventSchema `json:"events"`
+	IsFinished        bool                               `json:"isFinished"`
+	IsHandedOff       bool                               `json:"isHandedOff"`
+	IsWaitingForReply bool                               `json:"isWaitingForReply"`
+	Variables         map[string]string                  `json:"variables"`
+}
+
+// SimulateChatbotFlowSchema defines model for SimulateChatbotFlowSchema.
+type SimulateChatbotFlowSchema struct {
+	ContactAttributes *map[string]interface{}      `json:"contactAttributes,omitempty"`
+	ContactName       *string                      `json:"contactName,omitempty"`
+	Definition        *ChatbotFlowDefinitionSchema `json:"definition,omitempty"`
+
+	// Messages Messages of the contact, the first one starts the flow and the next ones answer its waiting steps.
+	Messages []ChatbotFlowSimulatedMessageSchema `json:"messages"`
+
+	// TimeoutLastWait Simulates the timeout of the step waiting for a reply after the last message.
+	TimeoutLastWait *bool `json:"timeoutLastWait,omitempty"`
+}
+
 // SlackNotificationConfigurationSchema defines model for SlackNotificationConfigurationSchema.
 type SlackNotificationConfigurationSchema struct {
 	SlackChannel    string `json:"slackChannel"`
 	SlackWebhookUrl string `json:"slackWebhookUrl"`
 }
 
+// SnoozeConversationResponseSchema defines model for SnoozeConversationResponseSchema.
+type SnoozeConversationResponseSchema struct {
+	Data bool `json:"data"`
+}
+
+// SnoozeConversationSchema defines model for SnoozeConversationSchema.
+type SnoozeConversationSchema struct {
+	// SnoozedUntil Time until which the conversation stays snoozed, required unless untilContactReplies is true.
+	SnoozedUntil *time.Time `json:"snoozedUntil,omitempty"`
+
+	// UntilContactReplies Keep the conversation snoozed until the contact sends a new message.
+	UntilContactReplies bool `json:"untilContactReplies"`
+}
+
 // StickerMessage defines model for StickerMessage.
 type StickerMessage struct {
 	// ConversationId ID of the conversation.
 	ConversationId string `json:"conversationId"`
 
 	// CreatedAt Time when the message was created.
-	CreatedAt   time.Time                 `json:"createdAt"`
-	Direction   MessageDirectionEnum      `json:"direction"`
-	MessageData StickerMessageData        `json:"messageData"`
-	MessageType StickerMessageMessageType `json:"messageType"`
-	Status      MessageStatusEnum         `json:"status"`
+	CreatedAt time.Time            `json:"createdAt"`
+	Direction MessageDirectionEnum `json:"direction"`
+
+	// IsAiGenerated (Optional) The message was written by the AI auto responder.
+	IsAiGenerated *bool                     `json:"isAiGenerated,omitempty"`
+	MessageData   StickerMessageData        `json:"messageData"`
+	MessageType   StickerMessageMessageType `json:"messageType"`
+
+	// Reactions Reactions on this message.
+	Reactions *[]MessageReactionSchema `json:"reactions,omitempty"`
+
+	// RepliedTo (Optional) ID of the message this message is a reply to.
+	RepliedTo *string           `json:"repliedTo,omitempty"`
+	Status    MessageStatusEnum `json:"status"`
 
 	// UniqueId Unique identifier of the message.
 	UniqueId string `json:"uniqueId"`
@@ -1354,6 +2987,16 @@ type SwitchOrganizationResponseSchema struct {
 	Token string `json:"token"`
 }
 
+// SyncBusinessAccountPhoneNumbersResponseSchema defines model for SyncBusinessAccountPhoneNumbersResponseSchema.
+type SyncBusinessAccountPhoneNumbersResponseSchema struct {
+	PhoneNumbers []BusinessPhoneNumberSchema `json:"phoneNumbers"`
+}
+
+// SyncMessageTemplatesResponseSchema defines model for SyncMessageTemplatesResponseSchema.
+type SyncMessageTemplatesResponseSchema struct {
+	SyncedCount int `json:"syncedCount"`
+}
+
 // SystemFeatureFlags defines model for SystemFeatureFlags.
 type SystemFeatureFlags struct {
 	IsAiIntegrationEnabled                bool `json:"isAiIntegrationEnabled"`
@@ -1383,6 +3026,20 @@ type TemplateComponentParameters struct {
 	Header []TemplateParameterInput `json:"header"`
 }
 
+// TemplateDraftSchema defines model for TemplateDraftSchema.
+type TemplateDraftSchema struct {
+	// Angle how the variant differs from the other variants
+	Angle      string                                    `json:"angle"`
+	Category   MessageTemplateCategory                   `json:"category"`
+	Components []WhatsAppBusinessHSMWhatsAppHSMComponent `json:"components"`
+
+	// IsValid false when Meta would reject the template for one of the issues
+	IsValid  bool                      `json:"isValid"`
+	Issues   []TemplateRuleIssueSchema `json:"issues"`
+	Language string                    `json:"language"`
+	Name     string                    `json:"name"`
+}
+
 // TemplateMessageButtonType defines model for TemplateMessageButtonType.
 type TemplateMessageButtonType string
 
@@ -1459,17 +3116,36 @@ type TemplateParameterInput struct {
 // TemplateParameterInputParameterType Specifies whether the parameter is static or dynamic.
 type TemplateParameterInputParameterType string
 
+// TemplateRuleIssueSchema defines model for TemplateRuleIssueSchema.
+type TemplateRuleIssueSchema struct {
+	Component *MessageTemplateComponentType `json:"component,omitempty"`
+	Message   string                        `json:"message"`
+	Severity  TemplateRuleIssueSeverityEnum `json:"severity"`
+}
+
+// TemplateRuleIssueSeverityEnum defines model for TemplateRuleIssueSeverityEnum.
+type TemplateRuleIssueSeverityEnum string
+
 // TextMessage defines model for TextMessage.
 type TextMessage struct {
 	// ConversationId ID of the conversation.
 	ConversationId string `json:"conversationId"`
 
 	// CreatedAt Time when the message was created.
-	CreatedAt   time.Time              `json:"createdAt"`
-	Direction   MessageDirectionEnum   `json:"direction"`
-	MessageData TextMessageData        `json:"messageData"`
-	MessageType TextMessageMessageType `json:"messageType"`
-	Status      MessageStatusEnum      `json:"status"`
+	CreatedAt time.Time            `json:"createdAt"`
+	Direction MessageDirectionEnum `json:"direction"`
+
+	// IsAiGenerated (Optional) The message was written by the AI auto responder.
+	IsAiGenerated *bool                  `json:"isAiGenerated,omitempty"`
+	MessageData   TextMessageData        `json:"messageData"`
+	MessageType   TextMessageMessageType `json:"messageType"`
+
+	// Reactions Reactions on this message.
+	Reactions *[]MessageReactionSchema `json:"reactions,omitempty"`
+
+	// RepliedTo (Optional) ID of the message this message is a reply to.
+	RepliedTo *string           `json:"repliedTo,omitempty"`
+	Status    MessageStatusEnum `json:"status"`
 
 	// UniqueId Unique identifier of the message.
 	UniqueId string `json:"uniqueId"`
@@ -1509,11 +3185,75 @@ type UnauthorizedErrorResponseSchema struct {
 	Message string `json:"message"`
 }
 
+// UnsnoozeConversationResponseSchema defines model for UnsnoozeConversationResponseSchema.
+type UnsnoozeConversationResponseSchema struct {
+	Data bool `json:"data"`
+}
+
 // UpdateAIConfigurationDetailsSchema defines model for UpdateAIConfigurationDetailsSchema.
 type UpdateAIConfigurationDetailsSchema struct {
-	ApiKey    string      `json:"apiKey"`
-	IsEnabled *bool       `json:"isEnabled,omitempty"`
-	Model     AiModelEnum `json:"model"`
+	// AllowCrossProviderFallback Let the AI calls fall back to the models of other providers, billed to the platform, when the model of the organization fails. Without it an organization bringing its own API key only ever uses its provider.
+	AllowCrossProviderFallback *bool       `json:"allowCrossProviderFallback,omitempty"`
+	ApiKey                     string      `json:"apiKey"`
+	IsEnabled                  *bool       `json:"isEnabled,omitempty"`
+	Model                      AiModelEnum `json:"model"`
+
+	// StoreConversationSummaryOnClose Summarize the conversations when they are closed, to search them later.
+	StoreConversationSummaryOnClose *bool `json:"storeConversationSummaryOnClose,omitempty"`
+}
+
+// UpdateAiAutoResponderConfigurationResponseSchema defines model for UpdateAiAutoResponderConfigurationResponseSchema.
+type UpdateAiAutoResponderConfigurationResponseSchema struct {
+	Configuration AiAutoResponderConfigurationSchema `json:"configuration"`
+}
+
+// UpdateAiAutoResponderConfigurationSchema defines model for UpdateAiAutoResponderConfigurationSchema.
+type UpdateAiAutoResponderConfigurationSchema struct {
+	ForbiddenTopics *[]string `json:"forbiddenTopics,omitempty"`
+	HandoffMessage  *string   `json:"handoffMessage,omitempty"`
+
+	// HandoffOrganizationRoleId Required to enable the auto responder.
+	HandoffOrganizationRoleId *string `json:"handoffOrganizationRoleId,omitempty"`
+	Instructions              *string `json:"instructions,omitempty"`
+	IsEnabled                 bool    `json:"isEnabled"`
+	MaxAiTurns                int     `json:"maxAiTurns"`
+	MinConfidence             int     `json:"minConfidence"`
+}
+
+// UpdateAiBudgetResponseSchema defines model for UpdateAiBudgetResponseSchema.
+type UpdateAiBudgetResponseSchema struct {
+	Budget AiBudgetSchema `json:"budget"`
+}
+
+// UpdateAiBudgetSchema defines model for UpdateAiBudgetSchema.
+type UpdateAiBudgetSchema struct {
+	IsHardLimitEnabled    bool     `json:"isHardLimitEnabled"`
+	MonthlyCostLimitInUsd *float64 `json:"monthlyCostLimitInUsd,omitempty"`
+	MonthlyTokenLimit     *int64   `json:"monthlyTokenLimit,omitempty"`
+	SoftLimitPercentage   int      `json:"softLimitPercentage"`
+}
+
+// UpdateAiPiiRedactionConfigurationResponseSchema defines model for UpdateAiPiiRedactionConfigurationResponseSchema.
+type UpdateAiPiiRedactionConfigurationResponseSchema struct {
+	Configuration AiPiiRedactionConfigurationSchema `json:"configuration"`
+}
+
+// UpdateAiPiiRedactionConfigurationSchema defines model for UpdateAiPiiRedactionConfigurationSchema.
+type UpdateAiPiiRedactionConfigurationSchema struct {
+	CustomDetectors  *[]PiiCustomDetectorSchema `json:"customDetectors,omitempty"`
+	EnabledDetectors []string                   `json:"enabledDetectors"`
+	IsEnabled        bool                       `json:"isEnabled"`
+}
+
+// UpdateBusinessAccountResponseSchema defines model for UpdateBusinessAccountResponseSchema.
+type UpdateBusinessAccountResponseSchema struct {
+	BusinessAccount BusinessAccountSchema `json:"businessAccount"`
+}
+
+// UpdateBusinessAccountSchema defines model for UpdateBusinessAccountSchema.
+type UpdateBusinessAccountSchema struct {
+	AccessToken *string `json:"accessToken,omitempty"`
+	Name        *string `json:"name,omitempty"`
 }
 
 // UpdateCampaignByIdResponseSchema defines model for UpdateCampaignByIdResponseSchema.
@@ -1537,6 +3277,16 @@ type UpdateCampaignSchema struct {
 	TemplateMessageId           *string                      `json:"templateMessageId,omitempty"`
 }
 
+// UpdateCannedResponseByIdResponseSchema defines model for UpdateCannedResponseByIdResponseSchema.
+type UpdateCannedResponseByIdResponseSchema struct {
+	CannedResponse CannedResponseSchema `json:"cannedResponse"`
+}
+
+// UpdateChatbotFlowByIdResponseSchema defines model for UpdateChatbotFlowByIdResponseSchema.
+type UpdateChatbotFlowByIdResponseSchema struct {
+	Flow ChatbotFlowSchema `json:"flow"`
+}
+
 // UpdateContactByIdResponseSchema defines model for UpdateContactByIdResponseSchema.
 type UpdateContactByIdResponseSchema struct {
 	Contact ContactSchema `json:"contact"`
@@ -1563,16 +3313,68 @@ type UpdateConversationByIdResponseSchema struct {
 	Conversation ConversationSchema `json:"conversation"`
 }
 
+// UpdateConversationPhoneNumberResponseSchema defines model for UpdateConversationPhoneNumberResponseSchema.
+type UpdateConversationPhoneNumberResponseSchema struct {
+	IsUpdated bool `json:"isUpdated"`
+}
+
+// UpdateConversationPhoneNumberSchema defines model for UpdateConversationPhoneNumberSchema.
+type UpdateConversationPhoneNumberSchema struct {
+	PhoneNumberId string `json:"phoneNumberId"`
+}
+
 // UpdateConversationSchema defines model for UpdateConversationSchema.
 type UpdateConversationSchema struct {
 	Status ConversationStatusEnum `json:"status"`
 }
 
+// UpdateConversationTaggingRuleByIdResponseSchema defines model for UpdateConversationTaggingRuleByIdResponseSchema.
+type UpdateConversationTaggingRuleByIdResponseSchema struct {
+	Rule ConversationTaggingRuleSchema `json:"rule"`
+}
+
+// UpdateCsatSurveyConfigurationResponseSchema defines model for UpdateCsatSurveyConfigurationResponseSchema.
+type UpdateCsatSurveyConfigurationResponseSchema struct {
+	Configuration CsatSurveyConfigurationSchema `json:"configuration"`
+}
+
+// UpdateCsatSurveyConfigurationSchema defines model for UpdateCsatSurveyConfigurationSchema.
+type UpdateCsatSurveyConfigurationSchema struct {
+	CommentPrompt         *string `json:"commentPrompt,omitempty"`
+	IsEnabled             bool    `json:"isEnabled"`
+	Question              string  `json:"question"`
+	ResponseWindowInHours *int    `json:"responseWindowInHours,omitempty"`
+	ThankYouMessage       *string `json:"thankYouMessage,omitempty"`
+}
+
 // UpdateListByIdResponseSchema defines model for UpdateListByIdResponseSchema.
 type UpdateListByIdResponseSchema struct {
 	List ContactListSchema `json:"list"`
 }
 
+// UpdateMessageClassificationConfigurationResponseSchema defines model for UpdateMessageClassificationConfigurationResponseSchema.
+type UpdateMessageClassificationConfigurationResponseSchema struct {
+	Configuration MessageClassificationConfigurationSchema `json:"configuration"`
+}
+
+// UpdateMessageClassificationConfigurationSchema defines model for UpdateMessageClassificationConfigurationSchema.
+type UpdateMessageClassificationConfigurationSchema struct {
+	EscalationPriority *ConversationPriorityEnum `json:"escalationPriority,omitempty"`
+	EscalationRoleId   *string                   `json:"escalationRoleId,omitempty"`
+	IsEnabled          bool                      `json:"isEnabled"`
+}
+
+// UpdateMessageTemplateResponseSchema defines model for UpdateMessageTemplateResponseSchema.
+type UpdateMessageTemplateResponseSchema struct {
+	Template MessageTemplateSchema `json:"template"`
+}
+
+// UpdateMessageTemplateSchema defines model for UpdateMessageTemplateSchema.
+type UpdateMessageTemplateSchema struct {
+	Category   *MessageTemplateCategory                  `json:"category,omitempty"`
+	Components []WhatsAppBusinessHSMWhatsAppHSMComponent `json:"components"`
+}
+
 // UpdateOrganizationByIdResponseSchema defines model for UpdateOrganizationByIdResponseSchema.
 type UpdateOrganizationByIdResponseSchema struct {
 	IsUpdated bool `json:"isUpdated"`
@@ -1607,6 +3409,18 @@ type UpdateOrganizationSchema struct {
 	SlackNotificationConfiguration *SlackNotificationConfigurationSchema `json:"slackNotificationConfiguration,omitempty"`
 }
 
+// UpdatePhoneNumberHealthConfigurationResponseSchema defines model for UpdatePhoneNumberHealthConfigurationResponseSchema.
+type UpdatePhoneNumberHealthConfigurationResponseSchema struct {
+	Configuration PhoneNumberHealthConfigurationSchema `json:"configuration"`
+}
+
+// UpdatePhoneNumberHealthConfigurationSchema defines model for UpdatePhoneNumberHealthConfigurationSchema.
+type UpdatePhoneNumberHealthConfigurationSchema struct {
+	IsCampaignAutoPauseEnabled bool  `json:"isCampaignAutoPauseEnabled"`
+	IsEmailNotificationEnabled *bool `json:"isEmailNotificationEnabled,omitempty"`
+	IsSlackNotificationEnabled *bool `json:"isSlackNotificationEnabled,omitempty"`
+}
+
 // UpdateRoleByIdResponseSchema defines model for UpdateRoleByIdResponseSchema.
 type UpdateRoleByIdResponseSchema struct {
 	Role OrganizationRoleSchema `json:"role"`
@@ -1635,6 +3449,12 @@ type UploadFileInConversationResponseSchema struct {
 	MediaUrl string `json:"mediaUrl"`
 }
 
+// UploadTemplateHeaderMediaResponseSchema defines model for UploadTemplateHeaderMediaResponseSchema.
+type UploadTemplateHeaderMediaResponseSchema struct {
+	Handle      string `json:"handle"`
+	MediaFileId string `json:"mediaFileId"`
+}
+
 // UserPermissionLevelEnum defines model for UserPermissionLevelEnum.
 type UserPermissionLevelEnum string
 
@@ -1652,6 +3472,19 @@ type UserSchema struct {
 	Username                       string                   `json:"username"`
 }
 
+// ValidateTemplateDraftResponseSchema defines model for ValidateTemplateDraftResponseSchema.
+type ValidateTemplateDraftResponseSchema struct {
+	IsValid bool                      `json:"isValid"`
+	Issues  []TemplateRuleIssueSchema `json:"issues"`
+}
+
+// ValidateTemplateDraftSchema defines model for ValidateTemplateDraftSchema.
+type ValidateTemplateDraftSchema struct {
+	Category   MessageTemplateCategory                   `json:"category"`
+	Components []WhatsAppBusinessHSMWhatsAppHSMComponent `json:"components"`
+	Name       string                                    `json:"name"`
+}
+
 // VerifyOtpRequestBodySchema defines model for VerifyOtpRequestBodySchema.
 type VerifyOtpRequestBodySchema struct {
 	Email                  string  `json:"email"`
@@ -1673,11 +3506,20 @@ type VideoMessage struct {
 	ConversationId string `json:"conversationId"`
 
 	// CreatedAt Time when the message was created.
-	CreatedAt   time.Time               `json:"createdAt"`
-	Direction   MessageDirectionEnum    `json:"direction"`
-	MessageData VideoMessageData        `json:"messageData"`
-	MessageType VideoMessageMessageType `json:"messageType"`
-	Status      MessageStatusEnum       `json:"status"`
+	CreatedAt time.Time            `json:"createdAt"`
+	Direction MessageDirectionEnum `json:"direction"`
+
+	// IsAiGenerated (Optional) The message was written by the AI auto responder.
+	IsAiGenerated *bool                   `json:"isAiGenerated,omitempty"`
+	MessageData   VideoMessageData        `json:"messageData"`
+	MessageType   VideoMessageMessageType `json:"messageType"`
+
+	// Reactions Reactions on this message.
+	Reactions *[]MessageReactionSchema `json:"reactions,omitempty"`
+
+	// RepliedTo (Optional) ID of the message this message is a reply to.
+	RepliedTo *string           `json:"repliedTo,omitempty"`
+	Status    MessageStatusEnum `json:"status"`
 
 	// UniqueId Unique identifier of the message.
 	UniqueId string `json:"uniqueId"`
@@ -1755,6 +3597,42 @@ type GetAiChatsParams struct {
 	Visibility *AiChatVisibilityEnum `form:"visibility,omitempty" json:"visibility,omitempty"`
 }
 
+// GetConversationSummariesParams defines parameters for GetConversationSummaries.
+type GetConversationSummariesParams struct {
+	// Query (Optional) words to search in the summaries
+	Query *string `form:"query,omitempty" json:"query,omitempty"`
+
+	// Page number of records to skip
+	Page *int64 `form:"page,omitempty" json:"page,omitempty"`
+
+	// PerPage max number of records to return per page
+	PerPage *int64 `form:"per_page,omitempty" json:"per_page,omitempty"`
+}
+
+// GetConversationSummaryParams defines parameters for GetConversationSummary.
+type GetConversationSummaryParams struct {
+	// ConversationId id of the conversation to summarize
+	ConversationId string `form:"conversationId" json:"conversationId"`
+}
+
+// GetKnowledgeBaseDocumentsParams defines parameters for GetKnowledgeBaseDocuments.
+type GetKnowledgeBaseDocumentsParams struct {
+	// Page number of records to skip
+	Page *int64 `form:"page,omitempty" json:"page,omitempty"`
+
+	// PerPage max number of records to return per page
+	PerPage *int64 `form:"per_page,omitempty" json:"per_page,omitempty"`
+}
+
+// CreateKnowledgeBasePdfDocumentMultipartBody defines parameters for CreateKnowledgeBasePdfDocument.
+type CreateKnowledgeBasePdfDocumentMultipartBody struct {
+	// File The PDF file to be added
+	File *openapi_types.File `json:"file,omitempty"`
+
+	// Title (Optional) Title of the document, the file name by default.
+	Title *string `json:"title,omitempty"`
+}
+
 // GetConversationResponseSuggestionsParams defines parameters for GetConversationResponseSuggestions.
 type GetConversationResponseSuggestionsParams struct {
 	// ConversationId number of records to skip
@@ -1763,11 +3641,20 @@ type GetConversationResponseSuggestionsParams struct {
 
 // GetAiChatSegmentRecommendationsParams defines parameters for GetAiChatSegmentRecommendations.
 type GetAiChatSegmentRecommendationsParams struct {
-	// ConversationId number of records to skip
-	ConversationId *int64 `form:"conversationId,omitempty" json:"conversationId,omitempty"`
+	// ConversationId the conversation to recommend tags for
+	ConversationId *string `form:"conversationId,omitempty" json:"conversationId,omitempty"`
+
+	// ContactId the contact to recommend tags for, all the conversations of the contact are analysed
+	ContactId *string `form:"contactId,omitempty" json:"contactId,omitempty"`
+}
+
+// GetAiUsageReportParams defines parameters for GetAiUsageReport.
+type GetAiUsageReportParams struct {
+	// From (Optional) start of the report window, the start of the current month by default
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
 
-	// ContactId max number of records to return per page
-	ContactId *int64 `form:"contactId,omitempty" json:"contactId,omitempty"`
+	// To (Optional) end of the report window, now by default
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
 }
 
 // GetAggregateCountsParams defines parameters for GetAggregateCounts.
@@ -1797,6 +3684,15 @@ type GetConversationAnalyticsParams struct {
 	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
 }
 
+// GetCsatAnalyticsParams defines parameters for GetCsatAnalytics.
+type GetCsatAnalyticsParams struct {
+	// From starting range of time span to get analytics for
+	From time.Time `form:"from" json:"from"`
+
+	// To ending range of time span to get analytics for
+	To time.Time `form:"to" json:"to"`
+}
+
 // ResetPasswordCompleteJSONBody defines parameters for ResetPasswordComplete.
 type ResetPasswordCompleteJSONBody struct {
 	Email    string `json:"email"`
@@ -1834,6 +3730,36 @@ type GetCampaignsParams struct {
 	Status *CampaignStatusEnum `form:"status,omitempty" json:"status,omitempty"`
 }
 
+// GetCannedResponsesParams defines parameters for GetCannedResponses.
+type GetCannedResponsesParams struct {
+	// Page number of records to skip
+	Page int64 `form:"page" json:"page"`
+
+	// PerPage max number of records to return per page
+	PerPage int64 `form:"per_page" json:"per_page"`
+
+	// FolderId query canned responses in a folder.
+	FolderId *string `form:"folder_id,omitempty" json:"folder_id,omitempty"`
+
+	// Query search canned responses by title, shortcut or content.
+	Query *string `form:"query,omitempty" json:"query,omitempty"`
+}
+
+// GetCannedResponseAnalyticsParams defines parameters for GetCannedResponseAnalytics.
+type GetCannedResponseAnalyticsParams struct {
+	// From start date of the analytics window
+	From time.Time `form:"from" json:"from"`
+
+	// To end date of the analytics window
+	To time.Time `form:"to" json:"to"`
+}
+
+// PreviewCannedResponseParams defines parameters for PreviewCannedResponse.
+type PreviewCannedResponseParams struct {
+	// ConversationId The id of the conversation used to resolve the variables.
+	ConversationId string `form:"conversation_id" json:"conversation_id"`
+}
+
 // DeleteContactsByListParams defines parameters for DeleteContactsByList.
 type DeleteContactsByListParams struct {
 	// Id contact id/s to be deleted
@@ -1879,6 +3805,15 @@ type GetConversationMessagesParams struct {
 	Order *OrderEnum `form:"order,omitempty" json:"order,omitempty"`
 }
 
+// GetConversationTranscriptParams defines parameters for GetConversationTranscript.
+type GetConversationTranscriptParams struct {
+	// Format format of the transcript, defaults to pdf
+	Format *ConversationTranscriptFormatEnum `form:"format,omitempty" json:"format,omitempty"`
+
+	// IncludeNotes include the internal notes of the agents, defaults to true
+	IncludeNotes *bool `form:"includeNotes,omitempty" json:"includeNotes,omitempty"`
+}
+
 // UploadFileInConversationMultipartBody defines parameters for UploadFileInConversation.
 type UploadFileInConversationMultipartBody struct {
 	// File The file to be uploaded
@@ -1910,6 +3845,30 @@ type GetConversationsParams struct {
 
 	// MessageId query conversations with a message id.
 	MessageId *string `form:"message_id,omitempty" json:"message_id,omitempty"`
+
+	// Sentiment query conversations by the sentiment of the latest classified message of the contact.
+	Sentiment *MessageSentimentEnum `form:"sentiment,omitempty" json:"sentiment,omitempty"`
+
+	// Intent query conversations by the intent of the latest classified message of the contact.
+	Intent *MessageIntentEnum `form:"intent,omitempty" json:"intent,omitempty"`
+
+	// Language query conversations by the ISO 639-1 code of the language of the contact.
+	Language *string `form:"language,omitempty" json:"language,omitempty"`
+
+	// Priority query conversations by priority.
+	Priority *ConversationPriorityEnum `form:"priority,omitempty" json:"priority,omitempty"`
+}
+
+// SearchConversationsParams defines parameters for SearchConversations.
+type SearchConversationsParams struct {
+	// Page number of records to skip
+	Page int64 `form:"page" json:"page"`
+
+	// PerPage max number of records to return per page
+	PerPage int64 `form:"per_page" json:"per_page"`
+
+	// Query search query, supports quoted phrases, OR and -exclusions.
+	Query string `form:"query" json:"query"`
 }
 
 // GetIntegrationsParams defines parameters for GetIntegrations.
@@ -2020,6 +3979,15 @@ type GetOrganizationTagsParams struct {
 	SortBy *OrderEnum `form:"sortBy,omitempty" json:"sortBy,omitempty"`
 }
 
+// UploadTemplateHeaderMediaMultipartBody defines parameters for UploadTemplateHeaderMedia.
+type UploadTemplateHeaderMediaMultipartBody struct {
+	// BusinessAccountId the business account the template will be created in, defaults to the business account of the default phone number
+	BusinessAccountId *string `json:"businessAccountId,omitempty"`
+
+	// File a jpeg or png image, a mp4 video or a pdf document
+	File *openapi_types.File `json:"file,omitempty"`
+}
+
 // GetOrganizationRolesParams defines parameters for GetOrganizationRoles.
 type GetOrganizationRolesParams struct {
 	// Page number of records to skip
@@ -2050,6 +4018,30 @@ type SendMessageInAiChatJSONRequestBody = AiChatQuerySchema
 // VoteOnAiChatMessageJSONRequestBody defines body for VoteOnAiChatMessage for application/json ContentType.
 type VoteOnAiChatMessageJSONRequestBody = AiChatMessageVoteCreateSchema
 
+// CreateKnowledgeBaseFaqDocumentJSONRequestBody defines body for CreateKnowledgeBaseFaqDocument for application/json ContentType.
+type CreateKnowledgeBaseFaqDocumentJSONRequestBody = CreateKnowledgeBaseFaqDocumentSchema
+
+// CreateKnowledgeBasePdfDocumentMultipartRequestBody defines body for CreateKnowledgeBasePdfDocument for multipart/form-data ContentType.
+type CreateKnowledgeBasePdfDocumentMultipartRequestBody CreateKnowledgeBasePdfDocumentMultipartBody
+
+// CreateKnowledgeBaseWebPageDocumentJSONRequestBody defines body for CreateKnowledgeBaseWebPageDocument for application/json ContentType.
+type CreateKnowledgeBaseWebPageDocumentJSONRequestBody = CreateKnowledgeBaseWebPageDocumentSchema
+
+// SearchKnowledgeBaseJSONRequestBody defines body for SearchKnowledgeBase for application/json ContentType.
+type SearchKnowledgeBaseJSONRequestBody = SearchKnowledgeBaseSchema
+
+// AcceptAiSegmentRecommendationsJSONRequestBody defines body for AcceptAiSegmentRecommendations for application/json ContentType.
+type AcceptAiSegmentRecommendationsJSONRequestBody = AcceptSegmentRecommendationsSchema
+
+// CreateSegmentRecommendationJobJSONRequestBody defines body for CreateSegmentRecommendationJob for application/json ContentType.
+type CreateSegmentRecommendationJobJSONRequestBody = CreateSegmentRecommendationJobSchema
+
+// GenerateTemplateDraftsJSONRequestBody defines body for GenerateTemplateDrafts for application/json ContentType.
+type GenerateTemplateDraftsJSONRequestBody = GenerateTemplateDraftsSchema
+
+// ValidateTemplateDraftJSONRequestBody defines body for ValidateTemplateDraft for application/json ContentType.
+type ValidateTemplateDraftJSONRequestBody = ValidateTemplateDraftSchema
+
 // JoinOrganizationJSONRequestBody defines body for JoinOrganization for application/json ContentType.
 type JoinOrganizationJSONRequestBody = JoinOrganizationRequestBodySchema
 
@@ -2080,6 +4072,24 @@ type CreateCampaignJSONRequestBody = NewCampaignSchema
 // UpdateCampaignByIdJSONRequestBody defines body for UpdateCampaignById for application/json ContentType.
 type UpdateCampaignByIdJSONRequestBody = UpdateCampaignSchema
 
+// CreateCannedResponseJSONRequestBody defines body for CreateCannedResponse for application/json ContentType.
+type CreateCannedResponseJSONRequestBody = NewCannedResponseSchema
+
+// CreateCannedResponseFolderJSONRequestBody defines body for CreateCannedResponseFolder for application/json ContentType.
+type CreateCannedResponseFolderJSONRequestBody = NewCannedResponseFolderSchema
+
+// UpdateCannedResponseByIdJSONRequestBody defines body for UpdateCannedResponseById for application/json ContentType.
+type UpdateCannedResponseByIdJSONRequestBody = NewCannedResponseSchema
+
+// CreateChatbotFlowJSONRequestBody defines body for CreateChatbotFlow for application/json ContentType.
+type CreateChatbotFlowJSONRequestBody = ChatbotFlowInputSchema
+
+// UpdateChatbotFlowByIdJSONRequestBody defines body for UpdateChatbotFlowById for application/json ContentType.
+type UpdateChatbotFlowByIdJSONRequestBody = ChatbotFlowInputSchema
+
+// SimulateChatbotFlowJSONRequestBody defines body for SimulateChatbotFlow for application/json ContentType.
+type SimulateChatbotFlowJSONRequestBody = SimulateChatbotFlowSchema
+
 // CreateContactsJSONRequestBody defines body for CreateContacts for application/json ContentType.
 type CreateContactsJSONRequestBody = CreateContactsJSONBody
 
@@ -2101,12 +4111,33 @@ type AssignConversationJSONRequestBody = AssignConversationSchema
 // SendMessageInConversationJSONRequestBody defines body for SendMessageInConversation for application/json ContentType.
 type SendMessageInConversationJSONRequestBody = NewMessageSchema
 
+// UpdateConversationPhoneNumberJSONRequestBody defines body for UpdateConversationPhoneNumber for application/json ContentType.
+type UpdateConversationPhoneNumberJSONRequestBody = UpdateConversationPhoneNumberSchema
+
+// CreateConversationReminderJSONRequestBody defines body for CreateConversationReminder for application/json ContentType.
+type CreateConversationReminderJSONRequestBody = NewConversationReminderSchema
+
+// SnoozeConversationJSONRequestBody defines body for SnoozeConversation for application/json ContentType.
+type SnoozeConversationJSONRequestBody = SnoozeConversationSchema
+
+// EmailConversationTranscriptJSONRequestBody defines body for EmailConversationTranscript for application/json ContentType.
+type EmailConversationTranscriptJSONRequestBody = EmailConversationTranscriptSchema
+
 // UnassignConversationJSONRequestBody defines body for UnassignConversation for application/json ContentType.
 type UnassignConversationJSONRequestBody = UnassignConversationSchema
 
 // UploadFileInConversationMultipartRequestBody defines body for UploadFileInConversation for multipart/form-data ContentType.
 type UploadFileInConversationMultipartRequestBody UploadFileInConversationMultipartBody
 
+// CreateConversationBulkActionJSONRequestBody defines body for CreateConversationBulkAction for application/json ContentType.
+type CreateConversationBulkActionJSONRequestBody = CreateConversationBulkActionSchema
+
+// CreateConversationTaggingRuleJSONRequestBody defines body for CreateConversationTaggingRule for application/json ContentType.
+type CreateConversationTaggingRuleJSONRequestBody = ConversationTaggingRuleInputSchema
+
+// UpdateConversationTaggingRuleByIdJSONRequestBody defines body for UpdateConversationTaggingRuleById for application/json ContentType.
+type UpdateConversationTaggingRuleByIdJSONRequestBody = ConversationTaggingRuleInputSchema
+
 // CreateListJSONRequestBody defines body for CreateList for application/json ContentType.
 type CreateListJSONRequestBody = NewContactListSchema
 
@@ -2116,6 +4147,27 @@ type UpdateListByIdJSONRequestBody = UpdateContactListSchema
 // CreateOrganizationJSONRequestBody defines body for CreateOrganization for application/json ContentType.
 type CreateOrganizationJSONRequestBody = NewOrganizationSchema
 
+// UpdateAiAutoResponderConfigurationJSONRequestBody defines body for UpdateAiAutoResponderConfiguration for application/json ContentType.
+type UpdateAiAutoResponderConfigurationJSONRequestBody = UpdateAiAutoResponderConfigurationSchema
+
+// UpdateAiBudgetJSONRequestBody defines body for UpdateAiBudget for application/json ContentType.
+type UpdateAiBudgetJSONRequestBody = UpdateAiBudgetSchema
+
+// UpdateAiPiiRedactionConfigurationJSONRequestBody defines body for UpdateAiPiiRedactionConfiguration for application/json ContentType.
+type UpdateAiPiiRedactionConfigurationJSONRequestBody = UpdateAiPiiRedactionConfigurationSchema
+
+// PreviewAiPiiRedactionJSONRequestBody defines body for PreviewAiPiiRedaction for application/json ContentType.
+type PreviewAiPiiRedactionJSONRequestBody = PreviewAiPiiRedactionSchema
+
+// CreateBusinessAccountJSONRequestBody defines body for CreateBusinessAccount for application/json ContentType.
+type CreateBusinessAccountJSONRequestBody = CreateBusinessAccountSchema
+
+// UpdateBusinessAccountByIdJSONRequestBody defines body for UpdateBusinessAccountById for application/json ContentType.
+type UpdateBusinessAccountByIdJSONRequestBody = UpdateBusinessAccountSchema
+
+// UpdateCsatSurveyConfigurationJSONRequestBody defines body for UpdateCsatSurveyConfiguration for application/json ContentType.
+type UpdateCsatSurveyConfigurationJSONRequestBody = UpdateCsatSurveyConfigurationSchema
+
 // CreateOrganizationInviteJSONRequestBody defines body for CreateOrganizationInvite for application/json ContentType.
 type CreateOrganizationInviteJSONRequestBody = CreateNewOrganizationInviteSchema
 
@@ -2125,9 +4177,24 @@ type UpdateOrganizationMemberByIdJSONRequestBody = UpdateOrganizationMemberSchem
 // UpdateOrganizationMemberRoleByIdJSONRequestBody defines body for UpdateOrganizationMemberRoleById for application/json ContentType.
 type UpdateOrganizationMemberRoleByIdJSONRequestBody = UpdateOrganizationMemberRoleSchema
 
+// UpdateMessageClassificationConfigurationJSONRequestBody defines body for UpdateMessageClassificationConfiguration for application/json ContentType.
+type UpdateMessageClassificationConfigurationJSONRequestBody = UpdateMessageClassificationConfigurationSchema
+
+// UpdatePhoneNumberHealthConfigurationJSONRequestBody defines body for UpdatePhoneNumberHealthConfiguration for application/json ContentType.
+type UpdatePhoneNumberHealthConfigurationJSONRequestBody = UpdatePhoneNumberHealthConfigurationSchema
+
 // CreateOrganizationTagJSONRequestBody defines body for CreateOrganizationTag for application/json ContentType.
 type CreateOrganizationTagJSONRequestBody = NewOrganizationTagSchema
 
+// CreateTemplateJSONRequestBody defines body for CreateTemplate for application/json ContentType.
+type CreateTemplateJSONRequestBody = CreateMessageTemplateSchema
+
+// UploadTemplateHeaderMediaMultipartRequestBody defines body for UploadTemplateHeaderMedia for multipart/form-data ContentType.
+type UploadTemplateHeaderMediaMultipartRequestBody UploadTemplateHeaderMediaMultipartBody
+
+// UpdateTemplateByIdJSONRequestBody defines body for UpdateTemplateById for application/json ContentType.
+type UpdateTemplateByIdJSONRequestBody = UpdateMessageTemplateSchema
+
 // UpdateWhatsappBusinessAccountDetailsJSONRequestBody defines body for UpdateWhatsappBusinessAccountDetails for application/json ContentType.
 type UpdateWhatsappBusinessAccountDetailsJSONRequestBody = UpdateWhatsAppBusinessAccountDetailsSchema
 
@@ -2370,6 +4437,146 @@ func (t *MessageSchema) MergeReactionMessage(v ReactionMessage) error {
 	return err
 }
 
+// AsInteractiveMessage returns the union data inside the MessageSchema as a InteractiveMessage
+func (t MessageSchema) AsInteractiveMessage() (InteractiveMessage, error) {
+	var body InteractiveMessage
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromInteractiveMessage overwrites any union data inside the MessageSchema as the provided InteractiveMessage
+func (t *MessageSchema) FromInteractiveMessage(v InteractiveMessage) error {
+	v.MessageType = "Interactive"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeInteractiveMessage performs a merge with any union data inside the MessageSchema, using the provided InteractiveMessage
+func (t *MessageSchema) MergeInteractiveMessage(v InteractiveMessage) error {
+	v.MessageType = "Interactive"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsInteractiveReplyMessage returns the union data inside the MessageSchema as a InteractiveReplyMessage
+func (t MessageSchema) AsInteractiveReplyMessage() (InteractiveReplyMessage, error) {
+	var body InteractiveReplyMessage
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromInteractiveReplyMessage overwrites any union data inside the MessageSchema as the provided InteractiveReplyMessage
+func (t *MessageSchema) FromInteractiveReplyMessage(v InteractiveReplyMessage) error {
+	v.MessageType = "InteractiveReply"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeInteractiveReplyMessage performs a merge with any union data inside the MessageSchema, using the provided InteractiveReplyMessage
+func (t *MessageSchema) MergeInteractiveReplyMessage(v InteractiveReplyMessage) error {
+	v.MessageType = "InteractiveReply"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsContactsMessage returns the union data inside the MessageSchema as a ContactsMessage
+func (t MessageSchema) AsContactsMessage() (ContactsMessage, error) {
+	var body ContactsMessage
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromContactsMessage overwrites any union data inside the MessageSchema as the provided ContactsMessage
+func (t *MessageSchema) FromContactsMessage(v ContactsMessage) error {
+	v.MessageType = "Contacts"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeContactsMessage performs a merge with any union data inside the MessageSchema, using the provided ContactsMessage
+func (t *MessageSchema) MergeContactsMessage(v ContactsMessage) error {
+	v.MessageType = "Contacts"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsOrderMessage returns the union data inside the MessageSchema as a OrderMessage
+func (t MessageSchema) AsOrderMessage() (OrderMessage, error) {
+	var body OrderMessage
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromOrderMessage overwrites any union data inside the MessageSchema as the provided OrderMessage
+func (t *MessageSchema) FromOrderMessage(v OrderMessage) error {
+	v.MessageType = "Order"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeOrderMessage performs a merge with any union data inside the MessageSchema, using the provided OrderMessage
+func (t *MessageSchema) MergeOrderMessage(v OrderMessage) error {
+	v.MessageType = "Order"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsProductInquiryMessage returns the union data inside the MessageSchema as a ProductInquiryMessage
+func (t MessageSchema) AsProductInquiryMessage() (ProductInquiryMessage, error) {
+	var body ProductInquiryMessage
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromProductInquiryMessage overwrites any union data inside the MessageSchema as the provided ProductInquiryMessage
+func (t *MessageSchema) FromProductInquiryMessage(v ProductInquiryMessage) error {
+	v.MessageType = "ProductInquiry"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeProductInquiryMessage performs a merge with any union data inside the MessageSchema, using the provided ProductInquiryMessage
+func (t *MessageSchema) MergeProductInquiryMessage(v ProductInquiryMessage) error {
+	v.MessageType = "ProductInquiry"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t MessageSchema) Discriminator() (string, error) {
 	var discriminator struct {
 		Discriminator string `json:"messageType"`
@@ -2386,12 +4593,22 @@ func (t MessageSchema) ValueByDiscriminator() (interface{}, error) {
 	switch discriminator {
 	case "Audio":
 		return t.AsAudioMessage()
+	case "Contacts":
+		return t.AsContactsMessage()
 	case "Document":
 		return t.AsDocumentMessage()
 	case "Image":
 		return t.AsImageMessage()
+	case "Interactive":
+		return t.AsInteractiveMessage()
+	case "InteractiveReply":
+		return t.AsInteractiveReplyMessage()
 	case "Location":
 		return t.AsLocationMessage()
+	case "Order":
+		return t.AsOrderMessage()
+	case "ProductInquiry":
+		return t.AsProductInquiryMessage()
 	case "Reaction":
 		return t.AsReactionMessage()
 	case "Sticker":
@@ -2655,6 +4872,36 @@ func (t *NewMessageDataSchema) MergeLocationMessageData(v LocationMessageData) e
 	return err
 }
 
+// AsInteractiveMessageData returns the union data inside the NewMessageDataSchema as a InteractiveMessageData
+func (t NewMessageDataSchema) AsInteractiveMessageData() (InteractiveMessageData, error) {
+	var body InteractiveMessageData
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromInteractiveMessageData overwrites any union data inside the NewMessageDataSchema as the provided InteractiveMessageData
+func (t *NewMessageDataSchema) FromInteractiveMessageData(v InteractiveMessageData) error {
+	t.MessageType = "Interactive"
+
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeInteractiveMessageData performs a merge with any union data inside the NewMessageDataSchema, using the provided InteractiveMessageData
+func (t *NewMessageDataSchema) MergeInteractiveMessageData(v InteractiveMessageData) error {
+	t.MessageType = "Interactive"
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JsonMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t NewMessageDataSchema) Discriminator() (string, error) {
 	var discriminator struct {
 		Discriminator string `json:"messageType"`
@@ -2675,6 +4922,8 @@ func (t NewMessageDataSchema) ValueByDiscriminator() (interface{}, error) {
 		return t.AsDocumentMessageData()
 	case "Image":
 		return t.AsImageMessageData()
+	case "Interactive":
+		return t.AsInteractiveMessageData()
 	case "Location":
 		return t.AsLocationMessageData()
 	case "Reaction":
@@ -9,11 +9,15 @@ import (
 	"github.com/wapikit/wapikit/api/controllers/analytics_controller"
 	"github.com/wapikit/wapikit/api/controllers/auth_controller"
 	"github.com/wapikit/wapikit/api/controllers/campaign_controller"
+	"github.com/wapikit/wapikit/api/controllers/canned_response_controller"
+	"github.com/wapikit/wapikit/api/controllers/chatbot_controller"
 	"github.com/wapikit/wapikit/api/controllers/contact_controller"
 	"github.com/wapikit/wapikit/api/controllers/contact_list_controller"
 	"github.com/wapikit/wapikit/api/controllers/conversation_controller"
 	"github.com/wapikit/wapikit/api/controllers/event_controller"
 	"github.com/wapikit/wapikit/api/controllers/integration_controller"
+	"github.com/wapikit/wapikit/api/controllers/knowledge_base_controller"
+	"github.com/wapikit/wapikit/api/controllers/media_controller"
 	"github.com/wapikit/wapikit/api/controllers/next_files_controller"
 	"github.com/wapikit/wapikit/api/controllers/organization_controller"
 	"github.com/wapikit/wapikit/api/controllers/rbac_controller"
@@ -45,6 +49,10 @@ func mountHandlerServices(e *echo.Echo, app *interfaces.App) {
 	whatsappWebhookController := webhook_controller.NewWhatsappWebhookWebhookController(app.WapiClient)
 	aiController := ai_controller.NewAiController()
 	eventController := event_controller.NewEventController()
+	cannedResponseController := canned_response_controller.NewCannedResponseController()
+	chatbotController := chatbot_controller.NewChatbotController()
+	knowledgeBaseController := knowledge_base_controller.NewKnowledgeBaseController()
+	mediaController := media_controller.NewMediaController()
 
 	controllersToRegister = append(
 		controllersToRegister,
@@ -62,6 +70,10 @@ func mountHandlerServices(e *echo.Echo, app *interfaces.App) {
 		whatsappWebhookController,
 		aiController,
 		eventController,
+		cannedResponseController,
+		chatbotController,
+		knowledgeBaseController,
+		mediaController,
 	)
 
 	if !isFrontendHostedSeparately {
@@ -5,15 +5,21 @@ package ai_controller
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/tmc/langchaingo/llms"
 	"github.com/wapikit/wapikit/.db-generated/model"
 	"github.com/wapikit/wapikit/api/api_types"
 	controller "github.com/wapikit/wapikit/api/controllers"
 	"github.com/wapikit/wapikit/interfaces"
+	"github.com/wapikit/wapikit/services/ai_service"
+	"github.com/wapikit/wapikit/services/conversation_service"
 	"github.com/wapikit/wapikit/utils"
 
 	. "github.com/go-jet/jet/v2/postgres"
@@ -123,6 +129,52 @@ func NewAiController() *AiController {
 						},
 					},
 				},
+				{
+					Path:                    "/api/ai/segment-recommendations",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleAcceptSegmentRecommendations),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60,
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.CreateTag,
+						},
+					},
+				},
+				{
+					Path:                    "/api/ai/segment-recommendations/jobs",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleCreateSegmentRecommendationJob),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: time.Hour.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.CreateTag,
+							api_types.UpdateContact,
+						},
+					},
+				},
+				{
+					Path:                    "/api/ai/segment-recommendations/jobs/:id",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetSegmentRecommendationJobById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    600,
+							WindowTimeInMs: time.Hour.Milliseconds(),
+						},
+					},
+				},
 				{
 					Path:                    "/api/ai/get-chat-summary",
 					Method:                  http.MethodGet,
@@ -136,6 +188,35 @@ func NewAiController() *AiController {
 						},
 					},
 				},
+				{
+					Path:                    "/api/ai/conversation-summaries",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetConversationSummaries),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60,
+						},
+					},
+				},
+				{
+					Path:                    "/api/ai/usage",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetAiUsageReport),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetSecondaryAnalytics,
+						},
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60,
+						},
+					},
+				},
 				{
 					Path:                    "/api/ai/response-suggestions",
 					Method:                  http.MethodGet,
@@ -149,11 +230,83 @@ func NewAiController() *AiController {
 						},
 					},
 				},
+				{
+					Path:                    "/api/ai/template-drafts",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleGenerateTemplateDrafts),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    20,
+							WindowTimeInMs: 1000 * 60 * 60,
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetMessageTemplates,
+						},
+					},
+				},
+				{
+					Path:                    "/api/ai/template-drafts/validate",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleValidateTemplateDraft),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60,
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetMessageTemplates,
+						},
+					},
+				},
 			},
 		},
 	}
 }
 
+func handleGetAiUsageReport(context interfaces.ContextWithSession) error {
+	params := new(api_types.GetAiUsageReportParams)
+	if err := utils.BindQueryParams(context, params); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	to := time.Now()
+	if params.To != nil {
+		to = *params.To
+	}
+	from := ai_service.AiBudgetPeriodStart(to)
+	if params.From != nil {
+		from = *params.From
+	}
+	if !from.Before(to) {
+		return context.JSON(http.StatusBadRequest, "from must be before to")
+	}
+
+	report, err := ai_service.GetAiUsageReport(context.Request().Context(), context.App.Db, orgUuid, from, to)
+	if err != nil {
+		context.App.Logger.Error("error getting ai usage report", err.Error(), nil)
+		return context.JSON(http.StatusInternalServerError, "Error getting AI usage report")
+	}
+
+	status, err := ai_service.GetAiBudgetStatus(context.Request().Context(), context.App.Db, orgUuid)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+	report.Budget = ai_service.ParseAiBudgetStatusToApiSchema(*status)
+
+	return context.JSON(http.StatusOK, api_types.GetAiUsageReportResponseSchema{
+		Report: *report,
+	})
+}
+
 func handleGetResponseSuggestions(context interfaces.ContextWithSession) error {
 
 	params := new(api_types.GetConversationResponseSuggestionsParams)
@@ -219,14 +372,27 @@ func handleGetResponseSuggestions(context interfaces.ContextWithSession) error {
 	}
 
 	// * get the response suggestions from the AI model
-	aiService := context.App.AiService
-	suggestions, err := aiService.GetResponseSuggestions(
+	aiService, err := organizationAiService(context, orgUuid)
+	if err != nil {
+		return respondWithAiServiceError(context, err)
+	}
+
+	suggestions, citations, err := aiService.GetResponseSuggestions(
 		context.Request().Context(),
+		orgUuid,
 		dest.Messages,
 	)
+	if err != nil {
+		context.App.Logger.Error("error getting response suggestions", err.Error(), nil)
+		return context.JSON(http.StatusInternalServerError, "Error getting response suggestions")
+	}
+	if citations == nil {
+		citations = []api_types.KnowledgeBaseCitationSchema{}
+	}
 
 	return context.JSON(http.StatusOK, api_types.GetResponseSuggestionsResponse{
 		Suggestions: suggestions,
+		Citations:   &citations,
 	})
 }
 
@@ -424,12 +590,19 @@ func handleGetChatMessages(context interfaces.ContextWithSession) error {
 
 	for _, message := range dest {
 		role := api_types.AiChatMessageRoleEnum(message.Role)
-		messagesToReturn = append(messagesToReturn, api_types.AiChatMessageSchema{
+		messageToReturn := api_types.AiChatMessageSchema{
 			UniqueId:  message.UniqueId.String(),
 			CreatedAt: message.CreatedAt,
 			Content:   message.Content,
 			Role:      role,
-		})
+		}
+		if message.Citations != nil {
+			var citations []api_types.KnowledgeBaseCitationSchema
+			if err := json.Unmarshal([]byte(*message.Citations), &citations); err == nil {
+				messageToReturn.Citations = &citations
+			}
+		}
+		messagesToReturn = append(messagesToReturn, messageToReturn)
 	}
 
 	return context.JSON(http.StatusOK, api_types.GetAiChatMessagesResponseSchema{
@@ -593,7 +766,6 @@ func handleReplyToChat(context interfaces.ContextWithSession) error {
 	}
 
 	logger := context.App.Logger
-	aiService := context.App.AiService
 	// * read the users query from here
 	chatId := context.Param("id")
 	if chatId == "" {
@@ -652,6 +824,12 @@ func handleReplyToChat(context interfaces.ContextWithSession) error {
 		}
 	}
 
+	aiService, err := ai_service.NewOrganizationAiService(context.Request().Context(), &context.App.Logger, context.App.Redis, context.App.Db, dest.OrganizationId, context.App.AiServiceConfig)
+	if err != nil {
+		return respondWithAiServiceError(context, err)
+	}
+	aiService.OrganizationMemberId = &dest.OrganizationMemberId
+
 	payload := new(api_types.AiChatQuerySchema)
 	if err := context.Bind(payload); err != nil {
 		return context.JSON(http.StatusBadRequest, err.Error())
@@ -698,17 +876,40 @@ func handleReplyToChat(context interfaces.ContextWithSession) error {
 		})
 	}
 
-	// * query the AI model
-	inputPrompt := aiService.BuildChatBoxQueryInputPrompt(
-		payload.Query,
-		contextMessages,
-		dest.OrganizationId,
-	)
-
-	streamingResponse, err := aiService.QueryAiModelWithStreaming(context.Request().Context(), inputPrompt)
-
-	if err != nil {
-		return context.JSON(http.StatusInternalServerError, "Error querying AI model")
+	// * the first question of a chat does not depend on earlier messages, the answer to a question of the same meaning
+	// * asked recently in the organization is reused
+	var cacheLookup *ai_service.SemanticCacheLookup
+	if len(contextMessages) == 0 {
+		cacheLookup = aiService.LookupSemanticCache(context.Request().Context(), dest.OrganizationId, ai_service.SemanticCacheScopeAiChat, payload.Query)
+	}
+	isCacheHit := cacheLookup != nil && cacheLookup.Entry != nil
+
+	var responseChannel <-chan string
+	var streamingResponse *ai_service.StreamingResult
+	var citations []api_types.KnowledgeBaseCitationSchema
+
+	if isCacheHit {
+		cachedResponse := make(chan string, 1)
+		cachedResponse <- cacheLookup.Entry.Response
+		close(cachedResponse)
+		responseChannel = cachedResponse
+		citations = cacheLookup.Entry.Citations
+	} else {
+		// * query the AI model
+		var inputPrompt []llms.MessageContent
+		inputPrompt, citations = aiService.BuildChatBoxQueryInputPrompt(
+			context.Request().Context(),
+			payload.Query,
+			contextMessages,
+			dest.OrganizationId,
+		)
+
+		streamingResponse, err = aiService.QueryAiModelWithStreaming(context.Request().Context(), ai_service.AiTaskChat, inputPrompt)
+
+		if err != nil {
+			return context.JSON(http.StatusInternalServerError, "Error querying AI model")
+		}
+		responseChannel = streamingResponse.StreamChannel
 	}
 
 	bufferedResponse := ""
@@ -770,64 +971,844 @@ func handleReplyToChat(context interfaces.ContextWithSession) error {
 		context.Response().Flush()
 	}
 
-	for response := range streamingResponse.StreamChannel {
-		delta := map[string]string{
-			"type":    "text-delta",
-			"content": response,
+	isClientGone := false
+	var streamErr error
+	if isCacheHit {
+		for response := range responseChannel {
+			delta := map[string]string{
+				"type":    "text-delta",
+				"content": response,
+			}
+			bufferedResponse += response
+			if err := enc.Encode(delta); err != nil {
+				return err
+			}
+			context.Response().Flush()
 		}
-		bufferedResponse += response
-		if err := enc.Encode(delta); err != nil {
+	} else {
+		bufferedResponse, isClientGone, streamErr = forwardStream(context, enc, streamingResponse)
+
+		// * the tokens of an answer stopped midway are billed by the provider too
+		aiService.LogApiCall(
+			dest.OrganizationId,
+			context.App.Db,
+			ai_service.AiTaskChat,
+			payload.Query,
+			bufferedResponse,
+			model.AiModelEnum(streamingResponse.ModelUsed),
+			streamingResponse.InputTokensUsed,
+			streamingResponse.OutputTokensUsed,
+		)
+	}
+
+	// * the knowledge base passages the answer may cite with their [n] numbers
+	if !isClientGone && streamErr == nil && len(citations) > 0 {
+		citationsMessage := map[string]interface{}{
+			"type":      "citations",
+			"citations": citations,
+		}
+		if err := enc.Encode(citationsMessage); err != nil {
 			return err
 		}
 		context.Response().Flush()
 	}
 
-	// we need to get the usage.promptTokens and usage.completionTokens from the response and send in the finished response too
-
-	// After the loop, send a `finish` signal
-	finishMessage := map[string]string{
-		"type":         "finish",
-		"finishReason": "done",
+	if !isClientGone {
+		if err := enc.Encode(streamFinishMessage(streamErr)); err != nil {
+			return err
+		}
+		context.Response().Flush()
 	}
 
-	// * update the message content in the db
-
-	if err := enc.Encode(finishMessage); err != nil {
-		return err
+	var citationsToStore *string
+	if len(citations) > 0 {
+		jsonCitations, _ := json.Marshal(citations)
+		stringCitations := string(jsonCitations)
+		citationsToStore = &stringCitations
 	}
 
 	updateQuery := table.AiChatMessage.UPDATE(
 		table.AiChatMessage.Content,
-	).SET(
-		bufferedResponse,
+		table.AiChatMessage.Citations,
+	).MODEL(
+		model.AiChatMessage{
+			Content:   bufferedResponse,
+			Citations: citationsToStore,
+		},
 	).WHERE(
 		table.AiChatMessage.UniqueId.EQ(UUID(insertedAiMessage.UniqueId)),
 	)
-	_, err = updateQuery.ExecContext(context.Request().Context(), context.App.Db)
+	// * the part of the answer streamed before the client went away is kept too, the request context is cancelled by then
+	_, err = updateQuery.Exec(context.App.Db)
 
 	if err != nil {
 		logger.Error("Error updating ai message: %v", err.Error(), nil)
-		return context.JSON(http.StatusInternalServerError, "Error updating ai message")
+		return nil
 	}
 
-	aiService.LogApiCall(
-		dest.OrganizationId,
-		context.App.Db,
-		payload.Query,
-		bufferedResponse,
-		model.AiModelEnum(streamingResponse.ModelUsed),
-		streamingResponse.InputTokensUsed,
-		streamingResponse.OutputTokensUsed,
-	)
+	if isCacheHit {
+		aiService.LogSemanticCacheHit(dest.OrganizationId, payload.Query, cacheLookup)
+		return nil
+	}
+
+	if isClientGone || streamErr != nil {
+		return nil
+	}
+
+	aiService.StoreInSemanticCache(context.Request().Context(), dest.OrganizationId, ai_service.SemanticCacheScopeAiChat, cacheLookup, bufferedResponse, citations)
 
 	return nil
 }
 
+// segmentRecommendationTarget is the conversation or the contact the tags are recommended for, the contact is the one of the conversation
+type segmentRecommendationTarget struct {
+	Contact      model.Contact
+	Conversation *model.Conversation
+}
+
+// parseSegmentRecommendationTargetIds validates that exactly one of the conversation and the contact is given
+func parseSegmentRecommendationTargetIds(conversationId, contactId *string) (*uuid.UUID, *uuid.UUID, error) {
+	hasConversationId := conversationId != nil && *conversationId != ""
+	hasContactId := contactId != nil && *contactId != ""
+
+	if hasConversationId == hasContactId {
+		return nil, nil, errors.New("either a conversation id or a contact id is required")
+	}
+
+	if hasConversationId {
+		conversationUuid, err := uuid.Parse(*conversationId)
+		if err != nil {
+			return nil, nil, errors.New("invalid conversation id")
+		}
+		return &conversationUuid, nil, nil
+	}
+
+	contactUuid, err := uuid.Parse(*contactId)
+	if err != nil {
+		return nil, nil, errors.New("invalid contact id")
+	}
+	return nil, &contactUuid, nil
+}
+
+func fetchSegmentRecommendationTarget(context interfaces.ContextWithSession, orgUuid uuid.UUID, conversationUuid, contactUuid *uuid.UUID) (*segmentRecommendationTarget, error) {
+	target := segmentRecommendationTarget{}
+
+	if conversationUuid != nil {
+		var conversation model.Conversation
+		err := SELECT(table.Conversation.AllColumns).
+			FROM(table.Conversation).
+			WHERE(
+				table.Conversation.UniqueId.EQ(UUID(*conversationUuid)).
+					AND(table.Conversation.OrganizationId.EQ(UUID(orgUuid))),
+			).
+			LIMIT(1).
+			QueryContext(context.Request().Context(), context.App.Db, &conversation)
+
+		if err != nil {
+			return nil, err
+		}
+
+		target.Conversation = &conversation
+		contactUuid = &conversation.ContactId
+	}
+
+	err := SELECT(table.Contact.AllColumns).
+		FROM(table.Contact).
+		WHERE(
+			table.Contact.UniqueId.EQ(UUID(*contactUuid)).
+				AND(table.Contact.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &target.Contact)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &target, nil
+}
+
 func handleGetSegmentRecommendation(context interfaces.ContextWithSession) error {
-	return nil
+	isCloudEdition := context.App.Constants.IsCloudEdition
+	if isCloudEdition {
+		isLimitReached := context.IsAiLimitReached()
+		if isLimitReached {
+			return context.JSON(http.StatusPaymentRequired, "You need to upgrade your plan to use more AI features")
+		}
+	}
+
+	params := new(api_types.GetAiChatSegmentRecommendationsParams)
+	if err := utils.BindQueryParams(context, params); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	conversationUuid, contactUuid, err := parseSegmentRecommendationTargetIds(params.ConversationId, params.ContactId)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization Id")
+	}
+
+	target, err := fetchSegmentRecommendationTarget(context, orgUuid, conversationUuid, contactUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "Conversation or contact not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	aiService, err := organizationAiService(context, orgUuid)
+	if err != nil {
+		return respondWithAiServiceError(context, err)
+	}
+
+	messages, err := aiService.FetchMessagesForSegmentRecommendation(context.Request().Context(), target.Contact.UniqueId, conversationUuid)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Error fetching messages")
+	}
+
+	labels, err := aiService.RecommendSegments(context.Request().Context(), ai_service.SegmentRecommendationInput{
+		OrganizationId: orgUuid,
+		Contact:        target.Contact,
+		Messages:       messages,
+	})
+	if err != nil {
+		context.App.Logger.Error("error recommending segments", err.Error(), nil)
+		return context.JSON(http.StatusInternalServerError, "Error querying AI model")
+	}
+
+	// * the recommendations matching a tag of the organization are accepted without creating a new tag
+	existingTags, err := ai_service.FindOrganizationTagsByLabels(context.Request().Context(), context.App.Db, orgUuid, labels)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	recommendations := []api_types.SegmentationRecommendation{}
+	for _, label := range labels {
+		recommendation := api_types.SegmentationRecommendation{
+			Label: label,
+		}
+		if tag, exists := existingTags[strings.ToLower(label)]; exists {
+			recommendation.Tag = &api_types.TagSchema{
+				UniqueId: tag.UniqueId.String(),
+				Label:    tag.Label,
+			}
+		}
+		recommendations = append(recommendations, recommendation)
+	}
+
+	return context.JSON(http.StatusOK, api_types.GetSegmentationRecommendationsResponse{
+		Recommendations: recommendations,
+	})
 }
 
-// ! this streams response to the client
-func handleGetChatSummary(context interfaces.ContextWithSession) error {
-	return nil
+func handleAcceptSegmentRecommendations(context interfaces.ContextWithSession) error {
+	payload := new(api_types.AcceptSegmentRecommendationsSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	if len(payload.Labels) > ai_service.MaxSegmentRecommendations {
+		return context.JSON(http.StatusBadRequest, fmt.Sprintf("at most %d recommendations can be accepted at once", ai_service.MaxSegmentRecommendations))
+	}
+
+	labels := ai_service.NormalizeSegmentLabels(payload.Labels, nil)
+	if len(labels) == 0 {
+		return context.JSON(http.StatusBadRequest, "at least one label is required")
+	}
+
+	conversationUuid, contactUuid, err := parseSegmentRecommendationTargetIds(payload.ConversationId, payload.ContactId)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization Id")
+	}
+
+	target, err := fetchSegmentRecommendationTarget(context, orgUuid, conversationUuid, contactUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "Conversation or contact not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	tags, err := ai_service.EnsureOrganizationTags(context.Request().Context(), context.App.Db, orgUuid, labels)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	if target.Conversation != nil {
+		err = ai_service.TagConversation(context.Request().Context(), context.App.Db, target.Conversation.UniqueId, tags)
+	} else {
+		err = ai_service.TagContact(context.Request().Context(), context.App.Db, target.Contact.UniqueId, tags)
+	}
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	tagsToReturn := []api_types.TagSchema{}
+	for _, tag := range tags {
+		tagsToReturn = append(tagsToReturn, api_types.TagSchema{
+			UniqueId: tag.UniqueId.String(),
+			Label:    tag.Label,
+		})
+	}
+
+	return context.JSON(http.StatusOK, api_types.AcceptSegmentRecommendationsResponseSchema{
+		Tags: tagsToReturn,
+	})
+}
+
+func handleCreateSegmentRecommendationJob(context interfaces.ContextWithSession) error {
+	isCloudEdition := context.App.Constants.IsCloudEdition
+	if isCloudEdition {
+		isLimitReached := context.IsAiLimitReached()
+		if isLimitReached {
+			return context.JSON(http.StatusPaymentRequired, "You need to upgrade your plan to use more AI features")
+		}
+	}
+
+	payload := new(api_types.CreateSegmentRecommendationJobSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	contactListUuid, err := uuid.Parse(payload.ContactListId)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "invalid contact list id")
+	}
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization Id")
+	}
+
+	var contactList struct {
+		model.ContactList
+		NumberOfContacts int `json:"numberOfContacts"`
+	}
+	err = SELECT(
+		table.ContactList.AllColumns,
+		COUNT(table.ContactListContact.ContactId).AS("numberOfContacts"),
+	).
+		FROM(table.ContactList.
+			LEFT_JOIN(table.ContactListContact, table.ContactListContact.ContactListId.EQ(table.ContactList.UniqueId)),
+		).
+		WHERE(
+			table.ContactList.UniqueId.EQ(UUID(contactListUuid)).
+				AND(table.ContactList.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		GROUP_BY(table.ContactList.UniqueId).
+		QueryContext(context.Request().Context(), context.App.Db, &contactList)
+
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "contact list not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	if contactList.NumberOfContacts > conversation_service.MaxSegmentRecommendationContacts {
+		return context.JSON(http.StatusBadRequest, fmt.Sprintf("a list of at most %d contacts can be classified at once", conversation_service.MaxSegmentRecommendationContacts))
+	}
+
+	parameters := conversation_service.SegmentRecommendationJobParameters{}
+	if payload.TagIds != nil && len(*payload.TagIds) > 0 {
+		tagUuidExpressions := make([]Expression, 0, len(*payload.TagIds))
+		for _, tagId := range *payload.TagIds {
+			tagUuid, err := uuid.Parse(tagId)
+			if err != nil {
+				return context.JSON(http.StatusBadRequest, fmt.Sprintf("invalid tag id %s", tagId))
+			}
+			tagUuidExpressions = append(tagUuidExpressions, UUID(tagUuid))
+		}
+
+		var tags []model.Tag
+		err = SELECT(table.Tag.UniqueId).
+			FROM(table.Tag).
+			WHERE(
+				table.Tag.UniqueId.IN(tagUuidExpressions...).
+					AND(table.Tag.OrganizationId.EQ(UUID(orgUuid))),
+			).
+			QueryContext(context.Request().Context(), context.App.Db, &tags)
+
+		if err != nil {
+			return context.JSON(http.StatusInternalServerError, err.Error())
+		}
+
+		if len(tags) != len(tagUuidExpressions) {
+			return context.JSON(http.StatusBadRequest, "one or more tags do not belong to the organization")
+		}
+
+		parameters.TagIds = *payload.TagIds
+	}
+
+	if _, err := ai_service.NewOrganizationAiService(context.Request().Context(), &context.App.Logger, context.App.Redis, context.App.Db, orgUuid, context.App.AiServiceConfig); err != nil {
+		return respondWithAiServiceError(context, err)
+	}
+
+	orgMember, err := fetchCurrentOrganizationMember(context, orgUuid)
+	if err != nil {
+		return context.JSON(http.StatusNotFound, "organization member not found")
+	}
+
+	job, err := context.App.ConversationService.CreateSegmentRecommendationJob(context.Request().Context(), conversation_service.CreateSegmentRecommendationJobParams{
+		OrganizationId:                orgUuid,
+		CreatedByOrganizationMemberId: orgMember.UniqueId,
+		ContactListId:                 contactListUuid,
+		Parameters:                    parameters,
+	})
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	context.App.ConversationService.StartSegmentRecommendationJob(job.UniqueId)
+
+	return context.JSON(http.StatusOK, api_types.CreateSegmentRecommendationJobResponseSchema{
+		Job: context.App.ConversationService.ParseSegmentRecommendationJobToApiSchema(*job),
+	})
+}
+
+func handleGetSegmentRecommendationJobById(context interfaces.ContextWithSession) error {
+	jobUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "invalid segment recommendation job id")
+	}
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization Id")
+	}
+
+	var job model.SegmentRecommendationJob
+	err = SELECT(table.SegmentRecommendationJob.AllColumns).
+		FROM(table.SegmentRecommendationJob).
+		WHERE(
+			table.SegmentRecommendationJob.UniqueId.EQ(UUID(jobUuid)).
+				AND(table.SegmentRecommendationJob.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &job)
+
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "segment recommendation job not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.GetSegmentRecommendationJobByIdResponseSchema{
+		Job: context.App.ConversationService.ParseSegmentRecommendationJobToApiSchema(job),
+	})
+}
+
+func handleGenerateTemplateDrafts(context interfaces.ContextWithSession) error {
+	isCloudEdition := context.App.Constants.IsCloudEdition
+	if isCloudEdition {
+		isLimitReached := context.IsAiLimitReached()
+		if isLimitReached {
+			return context.JSON(http.StatusPaymentRequired, "You need to upgrade your plan to use more AI features")
+		}
+	}
+
+	payload := new(api_types.GenerateTemplateDraftsSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization Id")
+	}
+
+	draftParams := ai_service.TemplateDraftParams{
+		OrganizationId: orgUuid,
+		Brief:          payload.Brief,
+		Category:       payload.Category,
+	}
+	if payload.Language != nil {
+		draftParams.Language = *payload.Language
+	}
+	if payload.VariantCount != nil {
+		draftParams.VariantCount = *payload.VariantCount
+	}
+	if payload.Tone != nil {
+		draftParams.Tone = *payload.Tone
+	}
+
+	// * the brief is checked before building the AI service, a bad request must not be reported as a failure of the model
+	draftParams, err = ai_service.NormalizeTemplateDraftParams(draftParams)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	aiService, err := organizationAiService(context, orgUuid)
+	if err != nil {
+		return respondWithAiServiceError(context, err)
+	}
+
+	drafts, err := aiService.DraftTemplates(context.Request().Context(), draftParams)
+	if err != nil {
+		context.App.Logger.Error("error drafting templates", err.Error(), nil)
+		return respondWithAiServiceError(context, err)
+	}
+
+	response := api_types.GenerateTemplateDraftsResponseSchema{
+		Drafts: []api_types.TemplateDraftSchema{},
+	}
+	for _, draft := range drafts {
+		response.Drafts = append(response.Drafts, api_types.TemplateDraftSchema{
+			Name:       draft.Name,
+			Angle:      draft.Angle,
+			Category:   draftParams.Category,
+			Language:   draftParams.Language,
+			Components: draft.Components,
+			Issues:     parseTemplateRuleIssuesToApiSchema(draft.Issues),
+			IsValid:    !ai_service.HasTemplateRuleErrors(draft.Issues),
+		})
+	}
+
+	return context.JSON(http.StatusOK, response)
+}
+
+func handleValidateTemplateDraft(context interfaces.ContextWithSession) error {
+	payload := new(api_types.ValidateTemplateDraftSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	issues := ai_service.ValidateTemplateComponents(payload.Name, payload.Category, payload.Components)
+
+	return context.JSON(http.StatusOK, api_types.ValidateTemplateDraftResponseSchema{
+		Issues:  parseTemplateRuleIssuesToApiSchema(issues),
+		IsValid: !ai_service.HasTemplateRuleErrors(issues),
+	})
+}
+
+func parseTemplateRuleIssuesToApiSchema(issues []ai_service.TemplateRuleIssue) []api_types.TemplateRuleIssueSchema {
+	issuesToReturn := []api_types.TemplateRuleIssueSchema{}
+	for _, issue := range issues {
+		issueToReturn := api_types.TemplateRuleIssueSchema{
+			Severity: api_types.TemplateRuleIssueSeverityEnum(issue.Severity),
+			Message:  issue.Message,
+		}
+		if issue.Component != "" {
+			component := issue.Component
+			issueToReturn.Component = &component
+		}
+		issuesToReturn = append(issuesToReturn, issueToReturn)
+	}
+	return issuesToReturn
+}
+
+// organizationAiService builds the AI service with the model and the key of the organization, the AI calls are accounted to the member of the session
+func organizationAiService(context interfaces.ContextWithSession, orgUuid uuid.UUID) (*ai_service.AiService, error) {
+	aiService, err := ai_service.NewOrganizationAiService(context.Request().Context(), &context.App.Logger, context.App.Redis, context.App.Db, orgUuid, context.App.AiServiceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if orgMember, err := fetchCurrentOrganizationMember(context, orgUuid); err == nil {
+		aiService.OrganizationMemberId = &orgMember.UniqueId
+	}
+
+	return aiService, nil
+}
+
+func respondWithAiServiceError(context interfaces.ContextWithSession, err error) error {
+	if errors.Is(err, ai_service.ErrAiNotEnabled) {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, ai_service.ErrAiBudgetExceeded) {
+		return context.JSON(http.StatusPaymentRequired, err.Error())
+	}
+	return context.JSON(http.StatusInternalServerError, err.Error())
+}
+
+// forwardStream writes the answer of the model to the client as text deltas, the generation is stopped when the client is gone.
+// It returns the text streamed so far once the generation ended along with the error it ended with
+func forwardStream(context interfaces.ContextWithSession, enc *json.Encoder, stream *ai_service.StreamingResult) (string, bool, error) {
+	bufferedResponse := ""
+	isClientGone := false
+
+	for response := range stream.StreamChannel {
+		if isClientGone {
+			continue
+		}
+
+		delta := map[string]string{
+			"type":    "text-delta",
+			"content": response,
+		}
+		if err := enc.Encode(delta); err != nil {
+			isClientGone = true
+			stream.Cancel()
+			continue
+		}
+		bufferedResponse += response
+		context.Response().Flush()
+	}
+
+	err := stream.Wait()
+	if context.Request().Context().Err() != nil {
+		isClientGone = true
+	}
+
+	return bufferedResponse, isClientGone, err
+}
+
+// streamFinishMessage is the last event of a streamed answer, an answer stopped by an error of the provider finishes with
+// the error for the client to show instead of a truncated answer
+func streamFinishMessage(streamErr error) map[string]string {
+	if streamErr == nil {
+		return map[string]string{
+			"type":         "finish",
+			"finishReason": "done",
+		}
+	}
+
+	message := "The AI model failed to answer, please try again"
+	if errors.Is(streamErr, ai_service.ErrAiModelTimedOut) {
+		message = "The AI model took too long to answer, please try again"
+	}
+
+	return map[string]string{
+		"type":         "finish",
+		"finishReason": "error",
+		"error":        message,
+	}
+}
+
+func fetchCurrentOrganizationMember(context interfaces.ContextWithSession, orgUuid uuid.UUID) (*model.OrganizationMember, error) {
+	userUuid, err := uuid.Parse(context.Session.User.UniqueId)
+	if err != nil {
+		return nil, err
+	}
+
+	var orgMember model.OrganizationMember
+	err = SELECT(table.OrganizationMember.AllColumns).
+		FROM(table.OrganizationMember).
+		WHERE(
+			table.OrganizationMember.OrganizationId.EQ(UUID(orgUuid)).
+				AND(table.OrganizationMember.UserId.EQ(UUID(userUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &orgMember)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &orgMember, nil
+}
+
+// ! this streams response to the client
+func handleGetChatSummary(context interfaces.ContextWithSession) error {
+	isCloudEdition := context.App.Constants.IsCloudEdition
+	if isCloudEdition {
+		isLimitReached := context.IsAiLimitReached()
+		if isLimitReached {
+			return context.JSON(http.StatusPaymentRequired, "You need to upgrade your plan to use more AI features")
+		}
+	}
+
+	params := new(api_types.GetConversationSummaryParams)
+	if err := utils.BindQueryParams(context, params); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	conversationUuid, err := uuid.Parse(params.ConversationId)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "Invalid conversation Id")
+	}
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization Id")
+	}
+
+	var conversation model.Conversation
+	err = SELECT(table.Conversation.AllColumns).
+		FROM(table.Conversation).
+		WHERE(
+			table.Conversation.UniqueId.EQ(UUID(conversationUuid)).
+				AND(table.Conversation.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &conversation)
+
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "Conversation not found")
+		}
+		return context.JSON(http.StatusInternalServerError, "Error fetching conversation")
+	}
+
+	aiService, err := organizationAiService(context, orgUuid)
+	if err != nil {
+		return respondWithAiServiceError(context, err)
+	}
+
+	messages, err := aiService.FetchConversationMessagesForSummary(context.Request().Context(), conversationUuid)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Error fetching messages")
+	}
+
+	if len(messages) == 0 {
+		return context.JSON(http.StatusBadRequest, "The conversation has no messages to summarize")
+	}
+
+	// * the summary is cached until the conversation gets a new message
+	lastMessageId := messages[len(messages)-1].UniqueId
+	cachedSummary, isCached := aiService.GetCachedConversationSummary(context.Request().Context(), conversationUuid, lastMessageId)
+
+	var streamingResponse *ai_service.StreamingResult
+	if !isCached {
+		streamingResponse, err = aiService.QueryAiModelWithStreaming(context.Request().Context(), ai_service.AiTaskSummary, aiService.ConversationSummaryPrompt(messages))
+		if err != nil {
+			return context.JSON(http.StatusInternalServerError, "Error querying AI model")
+		}
+	}
+
+	context.Response().Header().Set(echo.HeaderContentType, echo.MIMETextPlain)
+	context.Response().WriteHeader(http.StatusOK)
+	enc := json.NewEncoder(context.Response())
+
+	summaryDetails := map[string]interface{}{
+		"type":           "summaryDetails",
+		"conversationId": conversationUuid.String(),
+		"lastMessageId":  lastMessageId.String(),
+		"isCached":       isCached,
+	}
+	if err := enc.Encode(summaryDetails); err != nil {
+		return err
+	}
+	context.Response().Flush()
+
+	if isCached {
+		if err := enc.Encode(map[string]string{"type": "text-delta", "content": cachedSummary}); err != nil {
+			return err
+		}
+		context.Response().Flush()
+		if err := enc.Encode(streamFinishMessage(nil)); err != nil {
+			return err
+		}
+		context.Response().Flush()
+		return nil
+	}
+
+	bufferedResponse, isClientGone, streamErr := forwardStream(context, enc, streamingResponse)
+	if !isClientGone {
+		if err := enc.Encode(streamFinishMessage(streamErr)); err != nil {
+			return err
+		}
+		context.Response().Flush()
+	}
+
+	aiService.LogApiCall(
+		orgUuid,
+		context.App.Db,
+		ai_service.AiTaskSummary,
+		params.ConversationId,
+		bufferedResponse,
+		model.AiModelEnum(streamingResponse.ModelUsed),
+		streamingResponse.InputTokensUsed,
+		streamingResponse.OutputTokensUsed,
+	)
+
+	if isClientGone || streamErr != nil {
+		return nil
+	}
+
+	if summary := strings.TrimSpace(bufferedResponse); summary != "" {
+		aiService.CacheConversationSummary(conversationUuid, lastMessageId, summary)
+	}
+
+	return nil
+}
+
+func handleGetConversationSummaries(context interfaces.ContextWithSession) error {
+	params := new(api_types.GetConversationSummariesParams)
+	if err := utils.BindQueryParams(context, params); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	pageNumber := int64(1)
+	if params.Page != nil && *params.Page > 0 {
+		pageNumber = *params.Page
+	}
+	pageSize := int64(20)
+	if params.PerPage != nil && *params.PerPage > 0 {
+		pageSize = min(*params.PerPage, 100)
+	}
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization Id")
+	}
+
+	whereCondition := table.ConversationSummary.OrganizationId.EQ(UUID(orgUuid))
+	if params.Query != nil && strings.TrimSpace(*params.Query) != "" {
+		whereCondition = whereCondition.AND(RawBool(
+			`to_tsvector('simple'::regconfig, "ConversationSummary"."Content") @@ plainto_tsquery('simple'::regconfig, #query)`,
+			RawArgs{"#query": strings.TrimSpace(*params.Query)},
+		))
+	}
+
+	var dest []struct {
+		TotalSummaries int `json:"totalSummaries"`
+		model.ConversationSummary
+		Conversation model.Conversation
+	}
+
+	err = SELECT(
+		table.ConversationSummary.AllColumns,
+		table.Conversation.ContactId,
+		COUNT(table.ConversationSummary.UniqueId).OVER().AS("totalSummaries"),
+	).
+		FROM(table.ConversationSummary.
+			INNER_JOIN(table.Conversation, table.Conversation.UniqueId.EQ(table.ConversationSummary.ConversationId)),
+		).
+		WHERE(whereCondition).
+		ORDER_BY(table.ConversationSummary.UpdatedAt.DESC()).
+		LIMIT(pageSize).
+		OFFSET((pageNumber-1)*pageSize).
+		QueryContext(context.Request().Context(), context.App.Db, &dest)
+
+	if err != nil && err.Error() != qrm.ErrNoRows.Error() {
+		return context.JSON(http.StatusInternalServerError, "Error fetching conversation summaries")
+	}
+
+	response := api_types.GetConversationSummariesResponseSchema{
+		Summaries: []api_types.ConversationSummarySchema{},
+		PaginationMeta: api_types.PaginationMeta{
+			Page:    pageNumber,
+			PerPage: pageSize,
+			Total:   0,
+		},
+	}
+
+	for _, summary := range dest {
+		response.Summaries = append(response.Summaries, api_types.ConversationSummarySchema{
+			UniqueId:       summary.UniqueId.String(),
+			ConversationId: summary.ConversationId.String(),
+			ContactId:      summary.Conversation.ContactId.String(),
+			Content:        summary.Content,
+			LastMessageId:  summary.LastMessageId.String(),
+			CreatedAt:      summary.CreatedAt,
+			UpdatedAt:      summary.UpdatedAt,
+		})
+	}
+	if len(dest) > 0 {
+		response.PaginationMeta.Total = dest[0].TotalSummaries
+	}
+
+	return context.JSON(http.StatusOK, response)
 }
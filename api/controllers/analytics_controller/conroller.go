@@ -2,17 +2,20 @@ package analytics_controller
 
 import (
 	"database/sql"
+	"fmt"
 	"math"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 	"github.com/wapikit/wapikit/.db-generated/model"
 	"github.com/wapikit/wapikit/.db-generated/table"
 	"github.com/wapikit/wapikit/api/api_types"
 	controller "github.com/wapikit/wapikit/api/controllers"
 	"github.com/wapikit/wapikit/interfaces"
+	"github.com/wapikit/wapikit/services/conversation_service"
 	"github.com/wapikit/wapikit/utils"
 
 	"github.com/go-jet/jet/qrm"
@@ -65,6 +68,18 @@ func NewAnalyticsController() *AnalyticsController {
 						},
 					},
 				},
+				{
+					Path:                    "/api/analytics/csat",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetCsatAnalytics),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetSecondaryAnalytics,
+						},
+					},
+				},
 				{
 					Path:                    "/api/analytics/campaign/:campaignId",
 					Method:                  http.MethodGet,
@@ -460,6 +475,7 @@ func handleAggregateConversationAnalytics(context interfaces.ContextWithSession)
 	// - Total service conversations (initiated by "Contact")
 	// - Inbound/outbound message ratio
 	// - Message type distribution
+	// - Sentiment, intent and language distribution of the classified inbound messages
 
 	logger := context.App.Logger
 	params := new(api_types.GetConversationAnalyticsParams)
@@ -470,7 +486,7 @@ func handleAggregateConversationAnalytics(context interfaces.ContextWithSession)
 
 	minDateRange := params.From
 	maxDateRange := params.To
-	if minDateRange.IsZero() || maxDateRange.IsZero() {
+	if minDateRange == nil || maxDateRange == nil || minDateRange.IsZero() || maxDateRange.IsZero() {
 		return context.JSON(http.StatusBadRequest, "Invalid date range")
 	}
 
@@ -604,6 +620,24 @@ FROM (
 		return context.JSON(http.StatusInternalServerError, "Error getting message type distribution")
 	}
 
+	sentimentDistribution, err := classificationDistribution(context, orgUuid, *minDateRange, *maxDateRange, "Sentiment")
+	if err != nil {
+		logger.Error("Error getting sentiment distribution", err.Error(), nil)
+		return context.JSON(http.StatusInternalServerError, "Error getting sentiment distribution")
+	}
+
+	intentDistribution, err := classificationDistribution(context, orgUuid, *minDateRange, *maxDateRange, "Intent")
+	if err != nil {
+		logger.Error("Error getting intent distribution", err.Error(), nil)
+		return context.JSON(http.StatusInternalServerError, "Error getting intent distribution")
+	}
+
+	languageDistribution, err := classificationDistribution(context, orgUuid, *minDateRange, *maxDateRange, "Language")
+	if err != nil {
+		logger.Error("Error getting language distribution", err.Error(), nil)
+		return context.JSON(http.StatusInternalServerError, "Error getting language distribution")
+	}
+
 	responseToReturn = &api_types.GetConversationAnalyticsResponseSchema{
 		Analytics: api_types.ConversationAggregateAnalytics{
 			ConversationsActive:                     activeCountDest.ActiveCount,
@@ -615,6 +649,9 @@ FROM (
 			AvgResponseTimeInMinutes:                avgResponseTime.Float64,
 			ConversationsAnalytics:                  []api_types.ConversationAnalyticsDataPointSchema{},
 			MessageTypeTrafficDistributionAnalytics: messageTypeDistribution,
+			SentimentDistribution:                   &sentimentDistribution,
+			IntentDistribution:                      &intentDistribution,
+			LanguageDistribution:                    &languageDistribution,
 		},
 	}
 
@@ -624,6 +661,39 @@ FROM (
 	return context.JSON(http.StatusOK, responseToReturn)
 }
 
+// classificationDistribution counts the classified inbound messages of the organization received in the date range per value
+// of the classification column, the column must be one of Sentiment, Intent or Language
+func classificationDistribution(context interfaces.ContextWithSession, orgUuid uuid.UUID, from, to time.Time, column string) ([]api_types.ClassificationDistributionDataPointSchema, error) {
+	distributionQuery := fmt.Sprintf(`
+SELECT "%[1]s"::text, COUNT(*)
+FROM "Message"
+WHERE "OrganizationId" = $1
+      AND "Direction" = 'InBound'
+      AND "%[1]s" IS NOT NULL
+      AND "CreatedAt" BETWEEN $2 AND $3
+GROUP BY "%[1]s"
+ORDER BY COUNT(*) DESC
+LIMIT 20;
+`, column)
+
+	rows, err := context.App.Db.QueryContext(context.Request().Context(), distributionQuery, orgUuid, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	distribution := []api_types.ClassificationDistributionDataPointSchema{}
+	for rows.Next() {
+		var dataPoint api_types.ClassificationDistributionDataPointSchema
+		if err := rows.Scan(&dataPoint.Label, &dataPoint.Count); err != nil {
+			return nil, err
+		}
+		distribution = append(distribution, dataPoint)
+	}
+
+	return distribution, rows.Err()
+}
+
 func handleGetCampaignAnalyticsById(context interfaces.ContextWithSession) error {
 	var campaignAnalyticsData struct {
 		MessagesDelivered     int                                         `json:"messagesDelivered"`
@@ -760,3 +830,218 @@ func handleGetCampaignAnalyticsById(context interfaces.ContextWithSession) error
 
 	return context.JSON(http.StatusOK, responseToReturn)
 }
+
+// csatAggregate is a group of CSAT surveys as counted by the csat analytics queries
+type csatAggregate struct {
+	SurveysSent   int
+	Responses     int
+	Satisfied     int
+	AverageRating float64
+}
+
+func (aggregate csatAggregate) toApiSchema() api_types.CsatSummarySchema {
+	summary := api_types.CsatSummarySchema{
+		SurveysSent:   aggregate.SurveysSent,
+		Responses:     aggregate.Responses,
+		AverageRating: float32(math.Round(aggregate.AverageRating*10) / 10),
+	}
+
+	if aggregate.SurveysSent > 0 {
+		summary.ResponseRate = float32(math.Round(float64(aggregate.Responses)/float64(aggregate.SurveysSent)*1000) / 10)
+	}
+	if aggregate.Responses > 0 {
+		summary.CsatScore = float32(math.Round(float64(aggregate.Satisfied)/float64(aggregate.Responses)*1000) / 10)
+	}
+
+	return summary
+}
+
+// * the aggregated columns shared by all the csat analytics queries, the surveys are counted in the range they were sent in
+const csatAggregateColumns = `
+    COUNT(s."UniqueId") AS surveys_sent,
+    COUNT(s."Rating") AS responses,
+    COUNT(s."UniqueId") FILTER (WHERE s."Rating" = $4) AS satisfied,
+    COALESCE(AVG(s."Rating"), 0) AS average_rating`
+
+const csatSurveyRangeCondition = `s."OrganizationId" = $1 AND s."SentAt" >= $2 AND s."SentAt" <= $3`
+
+func handleGetCsatAnalytics(context interfaces.ContextWithSession) error {
+	logger := context.App.Logger
+	params := new(api_types.GetCsatAnalyticsParams)
+	err := utils.BindQueryParams(context, params)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	if params.From.IsZero() || params.To.IsZero() || params.To.Before(params.From) {
+		return context.JSON(http.StatusBadRequest, "Invalid date range")
+	}
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	cacheKey := context.App.Redis.ComputeCacheKey(
+		"handleGetCsatAnalytics",
+		strings.Join([]string{orgUuid.String(), params.From.String(), params.To.String()}, ":"),
+		"csat_analytics",
+	)
+
+	var responseToReturn *api_types.GetCsatAnalyticsResponseSchema
+	ok, _ := context.App.Redis.GetCachedData(cacheKey, &responseToReturn)
+	if ok {
+		return context.JSON(http.StatusOK, responseToReturn)
+	}
+
+	ctx := context.Request().Context()
+	queryArgs := []interface{}{orgUuid, params.From, params.To, conversation_service.CsatSatisfiedRating}
+
+	responseToReturn = &api_types.GetCsatAnalyticsResponseSchema{
+		ByAgent:        []api_types.CsatBreakdownSchema{},
+		ByTeam:         []api_types.CsatBreakdownSchema{},
+		OverTime:       []api_types.CsatDataPointSchema{},
+		RecentComments: []api_types.CsatCommentSchema{},
+	}
+
+	var summary csatAggregate
+	summaryQuery := `SELECT` + csatAggregateColumns + `
+FROM "CsatSurveyResponse" s
+WHERE ` + csatSurveyRangeCondition
+	err = context.App.Db.QueryRowContext(ctx, summaryQuery, queryArgs...).Scan(&summary.SurveysSent, &summary.Responses, &summary.Satisfied, &summary.AverageRating)
+	if err != nil && err != sql.ErrNoRows {
+		logger.Error("Error computing csat summary", err.Error(), nil)
+		return context.JSON(http.StatusInternalServerError, "Error computing csat summary")
+	}
+	responseToReturn.Summary = summary.toApiSchema()
+
+	// * the surveys of the conversations nobody was assigned to are only part of the summary
+	byAgentQuery := `SELECT om."UniqueId", u."Name",` + csatAggregateColumns + `
+FROM "CsatSurveyResponse" s
+JOIN "OrganizationMember" om ON om."UniqueId" = s."AssignedToOrganizationMemberId"
+JOIN "User" u ON u."UniqueId" = om."UserId"
+WHERE ` + csatSurveyRangeCondition + `
+GROUP BY om."UniqueId", u."Name"
+ORDER BY responses DESC, u."Name" ASC`
+	responseToReturn.ByAgent, err = queryCsatBreakdown(context, byAgentQuery, queryArgs)
+	if err != nil {
+		logger.Error("Error computing csat by agent", err.Error(), nil)
+		return context.JSON(http.StatusInternalServerError, "Error computing csat by agent")
+	}
+
+	// * a team is the set of members having a role, a member with multiple roles counts towards each of their teams
+	byTeamQuery := `SELECT r."UniqueId", r."Name",` + csatAggregateColumns + `
+FROM "CsatSurveyResponse" s
+JOIN "RoleAssignment" ra ON ra."OrganizationMemberId" = s."AssignedToOrganizationMemberId"
+JOIN "OrganizationRole" r ON r."UniqueId" = ra."OrganizationRoleId"
+WHERE ` + csatSurveyRangeCondition + `
+GROUP BY r."UniqueId", r."Name"
+ORDER BY responses DESC, r."Name" ASC`
+	responseToReturn.ByTeam, err = queryCsatBreakdown(context, byTeamQuery, queryArgs)
+	if err != nil {
+		logger.Error("Error computing csat by team", err.Error(), nil)
+		return context.JSON(http.StatusInternalServerError, "Error computing csat by team")
+	}
+
+	overTimeQuery := `SELECT DATE_TRUNC('day', s."SentAt") AS day,` + csatAggregateColumns + `
+FROM "CsatSurveyResponse" s
+WHERE ` + csatSurveyRangeCondition + `
+GROUP BY day
+ORDER BY day ASC`
+	overTimeRows, err := context.App.Db.QueryContext(ctx, overTimeQuery, queryArgs...)
+	if err != nil {
+		logger.Error("Error computing csat over time", err.Error(), nil)
+		return context.JSON(http.StatusInternalServerError, "Error computing csat over time")
+	}
+	defer overTimeRows.Close()
+
+	for overTimeRows.Next() {
+		var day time.Time
+		var aggregate csatAggregate
+		if err := overTimeRows.Scan(&day, &aggregate.SurveysSent, &aggregate.Responses, &aggregate.Satisfied, &aggregate.AverageRating); err != nil {
+			logger.Error("Error reading csat over time", err.Error(), nil)
+			return context.JSON(http.StatusInternalServerError, "Error computing csat over time")
+		}
+		responseToReturn.OverTime = append(responseToReturn.OverTime, api_types.CsatDataPointSchema{
+			Date:    openapi_types.Date{Time: day},
+			Summary: aggregate.toApiSchema(),
+		})
+	}
+
+	var recentComments []struct {
+		model.CsatSurveyResponse
+		User model.User
+	}
+
+	err = SELECT(
+		table.CsatSurveyResponse.AllColumns,
+		table.User.AllColumns,
+	).
+		FROM(
+			table.CsatSurveyResponse.
+				LEFT_JOIN(table.OrganizationMember, table.OrganizationMember.UniqueId.EQ(table.CsatSurveyResponse.AssignedToOrganizationMemberId)).
+				LEFT_JOIN(table.User, table.User.UniqueId.EQ(table.OrganizationMember.UserId)),
+		).
+		WHERE(
+			table.CsatSurveyResponse.OrganizationId.EQ(UUID(orgUuid)).
+				AND(table.CsatSurveyResponse.SentAt.BETWEEN(TimestampzT(params.From), TimestampzT(params.To))).
+				AND(table.CsatSurveyResponse.Comment.IS_NOT_NULL()),
+		).
+		ORDER_BY(table.CsatSurveyResponse.CommentedAt.DESC()).
+		LIMIT(20).
+		QueryContext(ctx, context.App.Db, &recentComments)
+
+	if err != nil && err.Error() != qrm.ErrNoRows.Error() {
+		logger.Error("Error fetching csat comments", err.Error(), nil)
+		return context.JSON(http.StatusInternalServerError, "Error fetching csat comments")
+	}
+
+	for _, survey := range recentComments {
+		if survey.Rating == nil || survey.Comment == nil || survey.CommentedAt == nil {
+			continue
+		}
+
+		comment := api_types.CsatCommentSchema{
+			ConversationId: survey.ConversationId.String(),
+			Rating:         int(*survey.Rating),
+			Comment:        *survey.Comment,
+			CommentedAt:    *survey.CommentedAt,
+		}
+		if survey.User.Name != "" {
+			assignedToName := survey.User.Name
+			comment.AssignedToName = &assignedToName
+		}
+		responseToReturn.RecentComments = append(responseToReturn.RecentComments, comment)
+	}
+
+	// cache the data for 10 minutes
+	context.App.Redis.CacheData(cacheKey, responseToReturn, 10*time.Minute)
+
+	return context.JSON(http.StatusOK, responseToReturn)
+}
+
+// queryCsatBreakdown runs a csat query selecting the id and the name of a group followed by the aggregated columns
+func queryCsatBreakdown(context interfaces.ContextWithSession, query string, queryArgs []interface{}) ([]api_types.CsatBreakdownSchema, error) {
+	rows, err := context.App.Db.QueryContext(context.Request().Context(), query, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	breakdown := []api_types.CsatBreakdownSchema{}
+	for rows.Next() {
+		var uniqueId uuid.UUID
+		var name string
+		var aggregate csatAggregate
+		if err := rows.Scan(&uniqueId, &name, &aggregate.SurveysSent, &aggregate.Responses, &aggregate.Satisfied, &aggregate.AverageRating); err != nil {
+			return nil, err
+		}
+		breakdown = append(breakdown, api_types.CsatBreakdownSchema{
+			UniqueId: uniqueId.String(),
+			Name:     name,
+			Summary:  aggregate.toApiSchema(),
+		})
+	}
+
+	return breakdown, rows.Err()
+}
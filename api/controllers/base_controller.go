@@ -112,6 +112,20 @@ func authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 				return ctx.JSON(http.StatusUnauthorized, "Unauthorized access")
 			}
 
+			// * an organization can have several business accounts, the client of the app is the one of the business account
+			// * of the default phone number, or the oldest business account when no phone number is synced yet
+			defaultBusinessAccount := table.WhatsappBusinessAccount.AS("DefaultBusinessAccount")
+			defaultPhoneNumber := table.WhatsappBusinessAccountPhoneNumber.AS("DefaultPhoneNumber")
+			defaultBusinessAccountQuery := SELECT(defaultBusinessAccount.UniqueId).
+				FROM(
+					defaultBusinessAccount.
+						LEFT_JOIN(defaultPhoneNumber, defaultPhoneNumber.WhatsappBusinessAccountId.EQ(defaultBusinessAccount.UniqueId).
+							AND(defaultPhoneNumber.IsDefault.IS_TRUE())),
+				).
+				WHERE(defaultBusinessAccount.OrganizationId.EQ(table.Organization.UniqueId)).
+				ORDER_BY(defaultPhoneNumber.IsDefault.IS_TRUE().DESC(), defaultBusinessAccount.CreatedAt.ASC()).
+				LIMIT(1)
+
 			user := UserWithOrgDetails{}
 			userQuery := SELECT(
 				table.User.AllColumns,
@@ -124,7 +138,7 @@ func authMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 				table.User.
 					LEFT_JOIN(table.OrganizationMember, table.User.UniqueId.EQ(table.OrganizationMember.UserId)).
 					LEFT_JOIN(table.Organization, table.Organization.UniqueId.EQ(table.OrganizationMember.OrganizationId)).
-					LEFT_JOIN(table.WhatsappBusinessAccount, table.WhatsappBusinessAccount.OrganizationId.EQ(table.Organization.UniqueId)).
+					LEFT_JOIN(table.WhatsappBusinessAccount, table.WhatsappBusinessAccount.UniqueId.IN(defaultBusinessAccountQuery)).
 					LEFT_JOIN(table.RoleAssignment, table.OrganizationMember.UniqueId.EQ(table.RoleAssignment.OrganizationMemberId)).
 					LEFT_JOIN(table.OrganizationRole, table.RoleAssignment.OrganizationRoleId.EQ(table.OrganizationRole.UniqueId)),
 			).WHERE(
@@ -324,6 +338,12 @@ func rateLimiter(next echo.HandlerFunc) echo.HandlerFunc {
 	}
 }
 
+// EnforceRateLimit rate limits an action of a handler by a key of its own, e.g. per resource, on top of the rate limit of the route
+func EnforceRateLimit(redisClient *cache_service.RedisClient, key string, limit int, window time.Duration) (bool, error) {
+	allowed, _, _, err := enforceRateLimit(redisClient, key, limit, window)
+	return allowed, err
+}
+
 // enforceRateLimit checks and updates the rate limit state in Redis
 func enforceRateLimit(redisClient *cache_service.RedisClient, key string, limit int, window time.Duration) (bool, int, int64, error) {
 	ctx := context.Background()
@@ -2,6 +2,7 @@ package campaign_controller
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -11,6 +12,8 @@ import (
 	controller "github.com/wapikit/wapikit/api/controllers"
 	"github.com/wapikit/wapikit/interfaces"
 	"github.com/wapikit/wapikit/internal/campaign_manager"
+	"github.com/wapikit/wapikit/services/business_account_service"
+	"github.com/wapikit/wapikit/services/template_service"
 	"github.com/wapikit/wapikit/utils"
 
 	"github.com/go-jet/jet/qrm"
@@ -303,6 +306,24 @@ func createNewCampaign(context interfaces.ContextWithSession) error {
 		return context.JSON(http.StatusInternalServerError, err.Error())
 	}
 
+	// * the campaign is sent from the chosen phone number, which must belong to one of the business accounts of the organization
+	if _, err := context.App.BusinessAccountService.GetPhoneNumber(context.Request().Context(), organizationUuid, payload.PhoneNumberToUse); err != nil {
+		if errors.Is(err, business_account_service.ErrPhoneNumberNotFound) {
+			return context.JSON(http.StatusBadRequest, err.Error())
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	// * a scheduled campaign starts on its own, its template must be one whatsapp sends
+	if payload.ScheduledAt != nil {
+		if err := context.App.TemplateService.CheckTemplateSendable(context.Request().Context(), organizationUuid, payload.TemplateMessageId); err != nil {
+			if errors.Is(err, template_service.ErrTemplateNotSendable) {
+				return context.JSON(http.StatusBadRequest, err.Error())
+			}
+			return context.JSON(http.StatusInternalServerError, err.Error())
+		}
+	}
+
 	var newCampaign model.Campaign
 	tx, err := context.App.Db.BeginTx(context.Request().Context(), nil)
 	if err != nil {
@@ -568,6 +589,22 @@ func updateCampaignById(context interfaces.ContextWithSession) error {
 		return context.JSON(http.StatusInternalServerError, err.Error())
 	}
 
+	if *payload.Status == api_types.Running || *payload.Status == api_types.Scheduled {
+		templateId := campaign.MessageTemplateId
+		if payload.TemplateMessageId != nil {
+			templateId = payload.TemplateMessageId
+		}
+
+		if templateId != nil {
+			if err := context.App.TemplateService.CheckTemplateSendable(context.Request().Context(), orgUuid, *templateId); err != nil {
+				if errors.Is(err, template_service.ErrTemplateNotSendable) {
+					return context.JSON(http.StatusBadRequest, err.Error())
+				}
+				return context.JSON(http.StatusInternalServerError, err.Error())
+			}
+		}
+	}
+
 	// ! if this is a status update, handle it first and return
 	if campaign.Status != model.CampaignStatusEnum(*payload.Status) {
 		// * this is a status update
@@ -633,6 +670,17 @@ func updateCampaignById(context interfaces.ContextWithSession) error {
 		return context.JSON(http.StatusBadRequest, "Cannot update a running campaign, pause the campaign first to update")
 	}
 
+	if payload.PhoneNumber == nil || *payload.PhoneNumber == "" {
+		return context.JSON(http.StatusBadRequest, "phone number is required")
+	}
+
+	if _, err := context.App.BusinessAccountService.GetPhoneNumber(context.Request().Context(), orgUuid, *payload.PhoneNumber); err != nil {
+		if errors.Is(err, business_account_service.ErrPhoneNumberNotFound) {
+			return context.JSON(http.StatusBadRequest, err.Error())
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
 	// * ====== SYNC TAGS FOR THIS CAMPAIGN ======
 
 	oldTagsUuids := make([]uuid.UUID, 0)
@@ -918,6 +966,14 @@ func deleteCampaignById(context interfaces.ContextWithSession) error {
 		return context.JSON(http.StatusBadRequest, "Cannot delete a running campaign, pause the campaign first to delete")
 	}
 
+	// * the tagging rules scoped to the campaign have nothing to match anymore
+	_, err = table.ConversationTaggingRule.DELETE().
+		WHERE(table.ConversationTaggingRule.CampaignId.EQ(UUID(campaignUuid))).
+		ExecContext(context.Request().Context(), context.App.Db)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
 	result, err := table.Campaign.DELETE().WHERE(table.Campaign.UniqueId.EQ(String(campaignId))).ExecContext(context.Request().Context(), context.App.Db)
 	if err != nil {
 		return context.JSON(http.StatusInternalServerError, err.Error())
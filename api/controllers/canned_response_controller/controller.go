@@ -0,0 +1,908 @@
+package canned_response_controller
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	"github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	controller "github.com/wapikit/wapikit/api/controllers"
+	"github.com/wapikit/wapikit/interfaces"
+	"github.com/wapikit/wapikit/services/conversation_service"
+	"github.com/wapikit/wapikit/utils"
+
+	"github.com/go-jet/jet/qrm"
+	. "github.com/go-jet/jet/v2/postgres"
+)
+
+type CannedResponseController struct {
+	controller.BaseController `json:"-,inline"`
+}
+
+func NewCannedResponseController() *CannedResponseController {
+	return &CannedResponseController{
+		BaseController: controller.BaseController{
+			Name:        "Canned Response Controller",
+			RestApiPath: "/api/canned-responses",
+			Routes: []interfaces.Route{
+				{
+					Path:                    "/api/canned-responses",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetCannedResponses),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    120,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetCannedResponse,
+						},
+					},
+				},
+				{
+					Path:                    "/api/canned-responses",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleCreateCannedResponse),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.CreateCannedResponse,
+						},
+					},
+				},
+				{
+					Path:                    "/api/canned-responses/folders",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetCannedResponseFolders),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetCannedResponse,
+						},
+					},
+				},
+				{
+					Path:                    "/api/canned-responses/folders",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleCreateCannedResponseFolder),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.CreateCannedResponse,
+						},
+					},
+				},
+				{
+					Path:                    "/api/canned-responses/folders/:id",
+					Method:                  http.MethodDelete,
+					Handler:                 interfaces.HandlerWithSession(handleDeleteCannedResponseFolderById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.DeleteCannedResponse,
+						},
+					},
+				},
+				{
+					Path:                    "/api/canned-responses/analytics",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetCannedResponseAnalytics),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetSecondaryAnalytics,
+						},
+					},
+				},
+				{
+					Path:                    "/api/canned-responses/:id",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetCannedResponseById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetCannedResponse,
+						},
+					},
+				},
+				{
+					Path:                    "/api/canned-responses/:id",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleUpdateCannedResponseById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateCannedResponse,
+						},
+					},
+				},
+				{
+					Path:                    "/api/canned-responses/:id",
+					Method:                  http.MethodDelete,
+					Handler:                 interfaces.HandlerWithSession(handleDeleteCannedResponseById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.DeleteCannedResponse,
+						},
+					},
+				},
+				{
+					Path:                    "/api/canned-responses/:id/preview",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handlePreviewCannedResponse),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    120,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetCannedResponse,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type cannedResponseWithFolder struct {
+	model.CannedResponse
+	Folder *model.CannedResponseFolder
+}
+
+func parseCannedResponseToApiSchema(cannedResponse cannedResponseWithFolder) api_types.CannedResponseSchema {
+	responseToReturn := api_types.CannedResponseSchema{
+		UniqueId:   cannedResponse.UniqueId.String(),
+		Title:      cannedResponse.Title,
+		Shortcut:   cannedResponse.Shortcut,
+		Content:    cannedResponse.Content,
+		Visibility: api_types.CannedResponseVisibilityEnum(cannedResponse.Visibility.String()),
+		UsageCount: int(cannedResponse.UsageCount),
+		LastUsedAt: cannedResponse.LastUsedAt,
+		CreatedAt:  cannedResponse.CreatedAt,
+	}
+
+	if cannedResponse.OrganizationRoleId != nil {
+		roleId := cannedResponse.OrganizationRoleId.String()
+		responseToReturn.OrganizationRoleId = &roleId
+	}
+
+	if cannedResponse.Folder != nil && cannedResponse.Folder.UniqueId != uuid.Nil {
+		responseToReturn.Folder = &api_types.CannedResponseFolderSchema{
+			UniqueId:  cannedResponse.Folder.UniqueId.String(),
+			Name:      cannedResponse.Folder.Name,
+			CreatedAt: cannedResponse.Folder.CreatedAt,
+		}
+	}
+
+	return responseToReturn
+}
+
+// isDuplicateShortcutError tells if the error is a violation of the unique shortcut of the shared canned responses or of the personal ones of a member
+func isDuplicateShortcutError(err error) bool {
+	return strings.Contains(err.Error(), "CannedResponseShortcutOrganizationIdUniqueIndex") || strings.Contains(err.Error(), "CannedResponseShortcutOrganizationMemberIdUniqueIndex")
+}
+
+func fetchCurrentOrganizationMember(context interfaces.ContextWithSession) (*model.OrganizationMember, error) {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	userUuid, err := uuid.Parse(context.Session.User.UniqueId)
+	if err != nil {
+		return nil, err
+	}
+
+	var orgMember model.OrganizationMember
+	err = SELECT(table.OrganizationMember.AllColumns).
+		FROM(table.OrganizationMember).
+		WHERE(
+			table.OrganizationMember.OrganizationId.EQ(UUID(orgUuid)).
+				AND(table.OrganizationMember.UserId.EQ(UUID(userUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &orgMember)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &orgMember, nil
+}
+
+// validateCannedResponsePayload validates the payload and resolves the folder and role ids of the canned response
+func validateCannedResponsePayload(context interfaces.ContextWithSession, orgUuid uuid.UUID, payload *api_types.NewCannedResponseSchema) (folderId *uuid.UUID, roleId *uuid.UUID, errMessage string) {
+	payload.Shortcut = strings.TrimSpace(payload.Shortcut)
+	if strings.TrimSpace(payload.Title) == "" || payload.Shortcut == "" || strings.TrimSpace(payload.Content) == "" {
+		return nil, nil, "title, shortcut and content are required"
+	}
+
+	if strings.ContainsAny(payload.Shortcut, " \t\n") {
+		return nil, nil, "shortcut must not contain whitespaces"
+	}
+
+	switch payload.Visibility {
+	case api_types.Organization, api_types.Personal:
+	case api_types.Team:
+		if payload.OrganizationRoleId == nil || *payload.OrganizationRoleId == "" {
+			return nil, nil, "organization role id is required for team visibility"
+		}
+		roleUuid, err := uuid.Parse(*payload.OrganizationRoleId)
+		if err != nil {
+			return nil, nil, "invalid organization role id"
+		}
+
+		var role model.OrganizationRole
+		err = SELECT(table.OrganizationRole.AllColumns).
+			FROM(table.OrganizationRole).
+			WHERE(
+				table.OrganizationRole.UniqueId.EQ(UUID(roleUuid)).
+					AND(table.OrganizationRole.OrganizationId.EQ(UUID(orgUuid))),
+			).
+			LIMIT(1).
+			QueryContext(context.Request().Context(), context.App.Db, &role)
+		if err != nil {
+			return nil, nil, "organization role not found"
+		}
+		roleId = &roleUuid
+	default:
+		return nil, nil, "invalid visibility"
+	}
+
+	if payload.FolderId != nil && *payload.FolderId != "" {
+		folderUuid, err := uuid.Parse(*payload.FolderId)
+		if err != nil {
+			return nil, nil, "invalid folder id"
+		}
+
+		var folder model.CannedResponseFolder
+		err = SELECT(table.CannedResponseFolder.AllColumns).
+			FROM(table.CannedResponseFolder).
+			WHERE(
+				table.CannedResponseFolder.UniqueId.EQ(UUID(folderUuid)).
+					AND(table.CannedResponseFolder.OrganizationId.EQ(UUID(orgUuid))),
+			).
+			LIMIT(1).
+			QueryContext(context.Request().Context(), context.App.Db, &folder)
+		if err != nil {
+			return nil, nil, "folder not found"
+		}
+		folderId = &folderUuid
+	}
+
+	return folderId, roleId, ""
+}
+
+func handleGetCannedResponses(context interfaces.ContextWithSession) error {
+	params := new(api_types.GetCannedResponsesParams)
+	if err := utils.BindQueryParams(context, params); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	pageNumber := params.Page
+	pageSize := params.PerPage
+
+	if pageNumber == 0 || pageSize > 100 {
+		return context.JSON(http.StatusBadRequest, "Invalid page or perPage value")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	orgMember, err := fetchCurrentOrganizationMember(context)
+	if err != nil {
+		return context.JSON(http.StatusNotFound, "Organization member not found")
+	}
+
+	whereCondition := context.App.ConversationService.CannedResponseVisibilityCondition(orgUuid, orgMember.UniqueId)
+
+	if params.FolderId != nil && *params.FolderId != "" {
+		folderUuid, err := uuid.Parse(*params.FolderId)
+		if err != nil {
+			return context.JSON(http.StatusBadRequest, "Invalid folder id")
+		}
+		whereCondition = whereCondition.AND(table.CannedResponse.FolderId.EQ(UUID(folderUuid)))
+	}
+
+	if params.Query != nil && strings.TrimSpace(*params.Query) != "" {
+		searchPattern := String("%" + strings.ToLower(strings.TrimSpace(*params.Query)) + "%")
+		whereCondition = whereCondition.AND(
+			LOWER(table.CannedResponse.Title).LIKE(searchPattern).
+				OR(LOWER(table.CannedResponse.Shortcut).LIKE(searchPattern)).
+				OR(LOWER(table.CannedResponse.Content).LIKE(searchPattern)),
+		)
+	}
+
+	cannedResponsesQuery := SELECT(
+		table.CannedResponse.AllColumns,
+		table.CannedResponseFolder.AllColumns,
+		COUNT(table.CannedResponse.UniqueId).OVER().AS("totalCannedResponses"),
+	).
+		FROM(
+			table.CannedResponse.
+				LEFT_JOIN(table.CannedResponseFolder, table.CannedResponseFolder.UniqueId.EQ(table.CannedResponse.FolderId)),
+		).
+		WHERE(whereCondition).
+		ORDER_BY(table.CannedResponse.UsageCount.DESC(), table.CannedResponse.CreatedAt.DESC()).
+		LIMIT(pageSize).
+		OFFSET((pageNumber - 1) * pageSize)
+
+	var dest []struct {
+		cannedResponseWithFolder
+		TotalCannedResponses int `json:"totalCannedResponses"`
+	}
+
+	err = cannedResponsesQuery.QueryContext(context.Request().Context(), context.App.Db, &dest)
+	if err != nil && err.Error() != qrm.ErrNoRows.Error() {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	cannedResponsesToReturn := []api_types.CannedResponseSchema{}
+	total := 0
+
+	for _, cannedResponse := range dest {
+		total = cannedResponse.TotalCannedResponses
+		cannedResponsesToReturn = append(cannedResponsesToReturn, parseCannedResponseToApiSchema(cannedResponse.cannedResponseWithFolder))
+	}
+
+	return context.JSON(http.StatusOK, api_types.GetCannedResponsesResponseSchema{
+		CannedResponses: cannedResponsesToReturn,
+		PaginationMeta: api_types.PaginationMeta{
+			Page:    pageNumber,
+			PerPage: pageSize,
+			Total:   total,
+		},
+	})
+}
+
+func handleCreateCannedResponse(context interfaces.ContextWithSession) error {
+	payload := new(api_types.NewCannedResponseSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	orgMember, err := fetchCurrentOrganizationMember(context)
+	if err != nil {
+		return context.JSON(http.StatusNotFound, "Organization member not found")
+	}
+
+	folderId, roleId, errMessage := validateCannedResponsePayload(context, orgUuid, payload)
+	if errMessage != "" {
+		return context.JSON(http.StatusBadRequest, errMessage)
+	}
+
+	cannedResponseToInsert := model.CannedResponse{
+		Title:                         strings.TrimSpace(payload.Title),
+		Shortcut:                      payload.Shortcut,
+		Content:                       payload.Content,
+		Visibility:                    model.CannedResponseVisibilityEnum(payload.Visibility),
+		FolderId:                      folderId,
+		OrganizationRoleId:            roleId,
+		CreatedByOrganizationMemberId: orgMember.UniqueId,
+		OrganizationId:                orgUuid,
+		CreatedAt:                     time.Now(),
+		UpdatedAt:                     time.Now(),
+	}
+
+	var insertedCannedResponse model.CannedResponse
+	err = table.CannedResponse.
+		INSERT(table.CannedResponse.MutableColumns).
+		MODEL(cannedResponseToInsert).
+		RETURNING(table.CannedResponse.AllColumns).
+		QueryContext(context.Request().Context(), context.App.Db, &insertedCannedResponse)
+
+	if err != nil {
+		if isDuplicateShortcutError(err) {
+			return context.JSON(http.StatusBadRequest, "A canned response with this shortcut already exists")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	cannedResponse, err := fetchCannedResponseById(context, insertedCannedResponse.UniqueId, orgUuid, orgMember.UniqueId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.CreateCannedResponseResponseSchema{
+		CannedResponse: parseCannedResponseToApiSchema(*cannedResponse),
+	})
+}
+
+func fetchCannedResponseById(context interfaces.ContextWithSession, cannedResponseUuid, orgUuid, orgMemberUuid uuid.UUID) (*cannedResponseWithFolder, error) {
+	var dest cannedResponseWithFolder
+
+	err := SELECT(
+		table.CannedResponse.AllColumns,
+		table.CannedResponseFolder.AllColumns,
+	).
+		FROM(
+			table.CannedResponse.
+				LEFT_JOIN(table.CannedResponseFolder, table.CannedResponseFolder.UniqueId.EQ(table.CannedResponse.FolderId)),
+		).
+		WHERE(
+			table.CannedResponse.UniqueId.EQ(UUID(cannedResponseUuid)).
+				AND(context.App.ConversationService.CannedResponseVisibilityCondition(orgUuid, orgMemberUuid)),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &dest)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dest, nil
+}
+
+func handleGetCannedResponseById(context interfaces.ContextWithSession) error {
+	cannedResponseUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "Invalid canned response id")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	orgMember, err := fetchCurrentOrganizationMember(context)
+	if err != nil {
+		return context.JSON(http.StatusNotFound, "Organization member not found")
+	}
+
+	cannedResponse, err := fetchCannedResponseById(context, cannedResponseUuid, orgUuid, orgMember.UniqueId)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "Canned response not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.GetCannedResponseByIdResponseSchema{
+		CannedResponse: parseCannedResponseToApiSchema(*cannedResponse),
+	})
+}
+
+func handleUpdateCannedResponseById(context interfaces.ContextWithSession) error {
+	cannedResponseUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "Invalid canned response id")
+	}
+
+	payload := new(api_types.NewCannedResponseSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	orgMember, err := fetchCurrentOrganizationMember(context)
+	if err != nil {
+		return context.JSON(http.StatusNotFound, "Organization member not found")
+	}
+
+	existingCannedResponse, err := fetchCannedResponseById(context, cannedResponseUuid, orgUuid, orgMember.UniqueId)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "Canned response not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	// * personal responses can only be updated by their creator
+	if existingCannedResponse.Visibility == model.CannedResponseVisibilityEnum_Personal && existingCannedResponse.CreatedByOrganizationMemberId != orgMember.UniqueId {
+		return context.JSON(http.StatusForbidden, "You are not allowed to update this canned response")
+	}
+
+	folderId, roleId, errMessage := validateCannedResponsePayload(context, orgUuid, payload)
+	if errMessage != "" {
+		return context.JSON(http.StatusBadRequest, errMessage)
+	}
+
+	var folderIdExpression, roleIdExpression Expression = NULL, NULL
+	if folderId != nil {
+		folderIdExpression = UUID(*folderId)
+	}
+	if roleId != nil {
+		roleIdExpression = UUID(*roleId)
+	}
+
+	_, err = table.CannedResponse.
+		UPDATE(
+			table.CannedResponse.Title,
+			table.CannedResponse.Shortcut,
+			table.CannedResponse.Content,
+			table.CannedResponse.Visibility,
+			table.CannedResponse.FolderId,
+			table.CannedResponse.OrganizationRoleId,
+			table.CannedResponse.UpdatedAt,
+		).
+		SET(
+			strings.TrimSpace(payload.Title),
+			payload.Shortcut,
+			payload.Content,
+			utils.EnumExpression(string(payload.Visibility)),
+			folderIdExpression,
+			roleIdExpression,
+			time.Now(),
+		).
+		WHERE(
+			table.CannedResponse.UniqueId.EQ(UUID(cannedResponseUuid)).
+				AND(table.CannedResponse.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		ExecContext(context.Request().Context(), context.App.Db)
+
+	if err != nil {
+		if isDuplicateShortcutError(err) {
+			return context.JSON(http.StatusBadRequest, "A canned response with this shortcut already exists")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	updatedCannedResponse, err := fetchCannedResponseById(context, cannedResponseUuid, orgUuid, orgMember.UniqueId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.UpdateCannedResponseByIdResponseSchema{
+		CannedResponse: parseCannedResponseToApiSchema(*updatedCannedResponse),
+	})
+}
+
+func handleDeleteCannedResponseById(context interfaces.ContextWithSession) error {
+	cannedResponseUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "Invalid canned response id")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	orgMember, err := fetchCurrentOrganizationMember(context)
+	if err != nil {
+		return context.JSON(http.StatusNotFound, "Organization member not found")
+	}
+
+	existingCannedResponse, err := fetchCannedResponseById(context, cannedResponseUuid, orgUuid, orgMember.UniqueId)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "Canned response not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	if existingCannedResponse.Visibility == model.CannedResponseVisibilityEnum_Personal && existingCannedResponse.CreatedByOrganizationMemberId != orgMember.UniqueId {
+		return context.JSON(http.StatusForbidden, "You are not allowed to delete this canned response")
+	}
+
+	// * usage logs are removed along with the response, the usage count history is not needed anymore
+	_, err = table.CannedResponseUsageLog.
+		DELETE().
+		WHERE(table.CannedResponseUsageLog.CannedResponseId.EQ(UUID(cannedResponseUuid))).
+		ExecContext(context.Request().Context(), context.App.Db)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	_, err = table.CannedResponse.
+		DELETE().
+		WHERE(
+			table.CannedResponse.UniqueId.EQ(UUID(cannedResponseUuid)).
+				AND(table.CannedResponse.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		ExecContext(context.Request().Context(), context.App.Db)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.DeleteCannedResponseByIdResponseSchema{
+		Data: true,
+	})
+}
+
+func handlePreviewCannedResponse(context interfaces.ContextWithSession) error {
+	cannedResponseUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "Invalid canned response id")
+	}
+
+	params := new(api_types.PreviewCannedResponseParams)
+	if err := utils.BindQueryParams(context, params); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	conversationUuid, err := uuid.Parse(params.ConversationId)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "Invalid conversation id")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	orgMember, err := fetchCurrentOrganizationMember(context)
+	if err != nil {
+		return context.JSON(http.StatusNotFound, "Organization member not found")
+	}
+
+	cannedResponse, err := fetchCannedResponseById(context, cannedResponseUuid, orgUuid, orgMember.UniqueId)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "Canned response not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	var conversation struct {
+		model.Conversation
+		Contact      model.Contact
+		Organization model.Organization
+	}
+
+	err = SELECT(
+		table.Conversation.AllColumns,
+		table.Contact.AllColumns,
+		table.Organization.AllColumns,
+	).
+		FROM(
+			table.Conversation.
+				LEFT_JOIN(table.Contact, table.Contact.UniqueId.EQ(table.Conversation.ContactId)).
+				LEFT_JOIN(table.Organization, table.Organization.UniqueId.EQ(table.Conversation.OrganizationId)),
+		).
+		WHERE(
+			table.Conversation.UniqueId.EQ(UUID(conversationUuid)).
+				AND(table.Conversation.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &conversation)
+
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "Conversation not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	renderedContent := context.App.ConversationService.RenderCannedResponse(cannedResponse.Content, conversation_service.CannedResponseVariables{
+		Contact:          conversation.Contact,
+		AgentName:        context.Session.User.Name,
+		OrganizationName: conversation.Organization.Name,
+	})
+
+	return context.JSON(http.StatusOK, api_types.PreviewCannedResponseResponseSchema{
+		Content: renderedContent,
+	})
+}
+
+func handleGetCannedResponseFolders(context interfaces.ContextWithSession) error {
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+
+	var folders []model.CannedResponseFolder
+	err := SELECT(table.CannedResponseFolder.AllColumns).
+		FROM(table.CannedResponseFolder).
+		WHERE(table.CannedResponseFolder.OrganizationId.EQ(UUID(orgUuid))).
+		ORDER_BY(table.CannedResponseFolder.Name.ASC()).
+		QueryContext(context.Request().Context(), context.App.Db, &folders)
+
+	if err != nil && err.Error() != qrm.ErrNoRows.Error() {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	foldersToReturn := []api_types.CannedResponseFolderSchema{}
+	for _, folder := range folders {
+		foldersToReturn = append(foldersToReturn, api_types.CannedResponseFolderSchema{
+			UniqueId:  folder.UniqueId.String(),
+			Name:      folder.Name,
+			CreatedAt: folder.CreatedAt,
+		})
+	}
+
+	return context.JSON(http.StatusOK, api_types.GetCannedResponseFoldersResponseSchema{
+		Folders: foldersToReturn,
+	})
+}
+
+func handleCreateCannedResponseFolder(context interfaces.ContextWithSession) error {
+	payload := new(api_types.NewCannedResponseFolderSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	folderName := strings.TrimSpace(payload.Name)
+	if folderName == "" {
+		return context.JSON(http.StatusBadRequest, "Folder name is required")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+
+	var insertedFolder model.CannedResponseFolder
+	err := table.CannedResponseFolder.
+		INSERT(table.CannedResponseFolder.MutableColumns).
+		MODEL(model.CannedResponseFolder{
+			Name:           folderName,
+			OrganizationId: orgUuid,
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		}).
+		RETURNING(table.CannedResponseFolder.AllColumns).
+		QueryContext(context.Request().Context(), context.App.Db, &insertedFolder)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "CannedResponseFolderNameOrganizationIdUniqueIndex") {
+			return context.JSON(http.StatusBadRequest, "A folder with this name already exists")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.CreateCannedResponseFolderResponseSchema{
+		Folder: api_types.CannedResponseFolderSchema{
+			UniqueId:  insertedFolder.UniqueId.String(),
+			Name:      insertedFolder.Name,
+			CreatedAt: insertedFolder.CreatedAt,
+		},
+	})
+}
+
+func handleDeleteCannedResponseFolderById(context interfaces.ContextWithSession) error {
+	folderUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "Invalid folder id")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+
+	// * responses in the folder are kept, they are just moved out of the folder
+	_, err = table.CannedResponse.
+		UPDATE(table.CannedResponse.FolderId).
+		SET(NULL).
+		WHERE(
+			table.CannedResponse.FolderId.EQ(UUID(folderUuid)).
+				AND(table.CannedResponse.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		ExecContext(context.Request().Context(), context.App.Db)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	result, err := table.CannedResponseFolder.
+		DELETE().
+		WHERE(
+			table.CannedResponseFolder.UniqueId.EQ(UUID(folderUuid)).
+				AND(table.CannedResponseFolder.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		ExecContext(context.Request().Context(), context.App.Db)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	if res, _ := result.RowsAffected(); res == 0 {
+		return context.JSON(http.StatusNotFound, "Folder not found")
+	}
+
+	return context.JSON(http.StatusOK, api_types.DeleteCannedResponseFolderByIdResponseSchema{
+		Data: true,
+	})
+}
+
+func handleGetCannedResponseAnalytics(context interfaces.ContextWithSession) error {
+	logger := context.App.Logger
+	params := new(api_types.GetCannedResponseAnalyticsParams)
+	if err := utils.BindQueryParams(context, params); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	if params.From.After(params.To) {
+		return context.JSON(http.StatusBadRequest, "Invalid date range")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	orgMember, err := fetchCurrentOrganizationMember(context)
+	if err != nil {
+		return context.JSON(http.StatusNotFound, "Organization member not found")
+	}
+
+	// * only the usage of the canned responses visible to the member is counted, the personal ones of other members stay hidden
+	usageWhereCondition := table.CannedResponseUsageLog.OrganizationId.EQ(UUID(orgUuid)).
+		AND(
+			table.CannedResponseUsageLog.CreatedAt.BETWEEN(
+				TimestampzExp(TimestampzT(params.From)),
+				TimestampzExp(TimestampzT(params.To)),
+			),
+		).
+		AND(context.App.ConversationService.CannedResponseVisibilityCondition(orgUuid, orgMember.UniqueId))
+
+	usageCount := COUNT(table.CannedResponseUsageLog.UniqueId)
+
+	usageByResponseQuery := SELECT(
+		table.CannedResponse.UniqueId.AS("CannedResponseUsageByResponseSchema.cannedResponseId"),
+		table.CannedResponse.Title.AS("CannedResponseUsageByResponseSchema.title"),
+		table.CannedResponse.Shortcut.AS("CannedResponseUsageByResponseSchema.shortcut"),
+		usageCount.AS("CannedResponseUsageByResponseSchema.usageCount"),
+	).
+		FROM(
+			table.CannedResponseUsageLog.
+				INNER_JOIN(table.CannedResponse, table.CannedResponse.UniqueId.EQ(table.CannedResponseUsageLog.CannedResponseId)),
+		).
+		WHERE(usageWhereCondition).
+		GROUP_BY(table.CannedResponse.UniqueId).
+		ORDER_BY(usageCount.DESC()).
+		LIMIT(50)
+
+	usageByMemberQuery := SELECT(
+		table.OrganizationMember.UniqueId.AS("CannedResponseUsageByMemberSchema.organizationMemberId"),
+		table.User.Name.AS("CannedResponseUsageByMemberSchema.name"),
+		usageCount.AS("CannedResponseUsageByMemberSchema.usageCount"),
+	).
+		FROM(
+			table.CannedResponseUsageLog.
+				INNER_JOIN(table.CannedResponse, table.CannedResponse.UniqueId.EQ(table.CannedResponseUsageLog.CannedResponseId)).
+				LEFT_JOIN(table.OrganizationMember, table.OrganizationMember.UniqueId.EQ(table.CannedResponseUsageLog.OrganizationMemberId)).
+				LEFT_JOIN(table.User, table.User.UniqueId.EQ(table.OrganizationMember.UserId)),
+		).
+		WHERE(usageWhereCondition).
+		GROUP_BY(table.OrganizationMember.UniqueId, table.User.Name).
+		ORDER_BY(usageCount.DESC())
+
+	usageByResponse := []api_types.CannedResponseUsageByResponseSchema{}
+	err = usageByResponseQuery.QueryContext(context.Request().Context(), context.App.Db, &usageByResponse)
+	if err != nil && err != sql.ErrNoRows && err.Error() != qrm.ErrNoRows.Error() {
+		logger.Error("error fetching canned response usage by response", err.Error(), nil)
+		return context.JSON(http.StatusInternalServerError, "Error getting canned response analytics")
+	}
+
+	usageByMember := []api_types.CannedResponseUsageByMemberSchema{}
+	err = usageByMemberQuery.QueryContext(context.Request().Context(), context.App.Db, &usageByMember)
+	if err != nil && err != sql.ErrNoRows && err.Error() != qrm.ErrNoRows.Error() {
+		logger.Error("error fetching canned response usage by member", err.Error(), nil)
+		return context.JSON(http.StatusInternalServerError, "Error getting canned response analytics")
+	}
+
+	totalUsage := 0
+	for _, usage := range usageByMember {
+		totalUsage += usage.UsageCount
+	}
+
+	return context.JSON(http.StatusOK, api_types.GetCannedResponseAnalyticsResponseSchema{
+		TotalUsage: totalUsage,
+		ByResponse: usageByResponse,
+		ByMember:   usageByMember,
+	})
+}
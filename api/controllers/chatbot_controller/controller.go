@@ -0,0 +1,515 @@
+package chatbot_controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	"github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	controller "github.com/wapikit/wapikit/api/controllers"
+	"github.com/wapikit/wapikit/interfaces"
+	"github.com/wapikit/wapikit/services/chatbot_service"
+
+	"github.com/go-jet/jet/qrm"
+	. "github.com/go-jet/jet/v2/postgres"
+)
+
+const (
+	maxSessionTimeoutInMinutes = 1440
+	maxSimulatedMessages       = 50
+)
+
+type ChatbotController struct {
+	controller.BaseController `json:"-,inline"`
+}
+
+func NewChatbotController() *ChatbotController {
+	return &ChatbotController{
+		BaseController: controller.BaseController{
+			Name:        "Chatbot Controller",
+			RestApiPath: "/api/chatbot-flows",
+			Routes: []interfaces.Route{
+				{
+					Path:                    "/api/chatbot-flows",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetChatbotFlows),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    120,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetChatbotFlow,
+						},
+					},
+				},
+				{
+					Path:                    "/api/chatbot-flows",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleCreateChatbotFlow),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.CreateChatbotFlow,
+						},
+					},
+				},
+				{
+					Path:                    "/api/chatbot-flows/:id",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetChatbotFlowById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetChatbotFlow,
+						},
+					},
+				},
+				{
+					Path:                    "/api/chatbot-flows/:id",
+					Method:                  http.MethodPut,
+					Handler:                 interfaces.HandlerWithSession(handleUpdateChatbotFlowById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateChatbotFlow,
+						},
+					},
+				},
+				{
+					Path:                    "/api/chatbot-flows/:id",
+					Method:                  http.MethodDelete,
+					Handler:                 interfaces.HandlerWithSession(handleDeleteChatbotFlowById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.DeleteChatbotFlow,
+						},
+					},
+				},
+				{
+					Path:                    "/api/chatbot-flows/:id/simulate",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleSimulateChatbotFlow),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetChatbotFlow,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func fetchCurrentOrganizationMember(context interfaces.ContextWithSession) (*model.OrganizationMember, error) {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	userUuid, err := uuid.Parse(context.Session.User.UniqueId)
+	if err != nil {
+		return nil, err
+	}
+
+	var orgMember model.OrganizationMember
+	err = SELECT(table.OrganizationMember.AllColumns).
+		FROM(table.OrganizationMember).
+		WHERE(
+			table.OrganizationMember.OrganizationId.EQ(UUID(orgUuid)).
+				AND(table.OrganizationMember.UserId.EQ(UUID(userUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &orgMember)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &orgMember, nil
+}
+
+func fetchChatbotFlow(context interfaces.ContextWithSession, flowUuid, orgUuid uuid.UUID) (*model.ChatbotFlow, error) {
+	var flow model.ChatbotFlow
+	err := SELECT(table.ChatbotFlow.AllColumns).
+		FROM(table.ChatbotFlow).
+		WHERE(
+			table.ChatbotFlow.UniqueId.EQ(UUID(flowUuid)).
+				AND(table.ChatbotFlow.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &flow)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &flow, nil
+}
+
+// validateDefinitionReferences checks the tags and roles the steps of the flow use belong to the organization
+func validateDefinitionReferences(context interfaces.ContextWithSession, orgUuid uuid.UUID, definition api_types.ChatbotFlowDefinitionSchema) string {
+	tagIds, roleIds := chatbot_service.DefinitionReferences(definition)
+
+	if len(tagIds) > 0 {
+		tagIdExpressions := make([]Expression, 0, len(tagIds))
+		uniqueTagIds := map[string]bool{}
+		for _, tagId := range tagIds {
+			tagIdExpressions = append(tagIdExpressions, UUID(uuid.MustParse(tagId)))
+			uniqueTagIds[tagId] = true
+		}
+
+		var tags []model.Tag
+		err := SELECT(table.Tag.UniqueId).
+			FROM(table.Tag).
+			WHERE(
+				table.Tag.UniqueId.IN(tagIdExpressions...).
+					AND(table.Tag.OrganizationId.EQ(UUID(orgUuid))),
+			).
+			QueryContext(context.Request().Context(), context.App.Db, &tags)
+
+		if err != nil || len(tags) != len(uniqueTagIds) {
+			return "a step uses a tag that does not exist"
+		}
+	}
+
+	if len(roleIds) > 0 {
+		roleIdExpressions := make([]Expression, 0, len(roleIds))
+		uniqueRoleIds := map[string]bool{}
+		for _, roleId := range roleIds {
+			roleIdExpressions = append(roleIdExpressions, UUID(uuid.MustParse(roleId)))
+			uniqueRoleIds[roleId] = true
+		}
+
+		var roles []model.OrganizationRole
+		err := SELECT(table.OrganizationRole.UniqueId).
+			FROM(table.OrganizationRole).
+			WHERE(
+				table.OrganizationRole.UniqueId.IN(roleIdExpressions...).
+					AND(table.OrganizationRole.OrganizationId.EQ(UUID(orgUuid))),
+			).
+			QueryContext(context.Request().Context(), context.App.Db, &roles)
+
+		if err != nil || len(roles) != len(uniqueRoleIds) {
+			return "a step uses an organization role that does not exist"
+		}
+	}
+
+	return ""
+}
+
+// buildChatbotFlow validates the payload and maps it to the columns of the flow
+func buildChatbotFlow(context interfaces.ContextWithSession, orgUuid uuid.UUID, payload api_types.ChatbotFlowInputSchema) (*model.ChatbotFlow, string) {
+	if strings.TrimSpace(payload.Name) == "" {
+		return nil, "name is required"
+	}
+
+	if err := chatbot_service.ValidateFlowDefinition(payload.Definition); err != nil {
+		return nil, err.Error()
+	}
+
+	if errMessage := validateDefinitionReferences(context, orgUuid, payload.Definition); errMessage != "" {
+		return nil, errMessage
+	}
+
+	flow := model.ChatbotFlow{
+		OrganizationId:          orgUuid,
+		Name:                    strings.TrimSpace(payload.Name),
+		Description:             payload.Description,
+		IsEnabled:               payload.IsEnabled,
+		SessionTimeoutInMinutes: 60,
+		UpdatedAt:               time.Now(),
+	}
+
+	if payload.Priority != nil {
+		flow.Priority = int32(*payload.Priority)
+	}
+
+	if payload.SessionTimeoutInMinutes != nil {
+		if *payload.SessionTimeoutInMinutes < 1 || *payload.SessionTimeoutInMinutes > maxSessionTimeoutInMinutes {
+			return nil, "session timeout must be between 1 and 1440 minutes"
+		}
+		flow.SessionTimeoutInMinutes = int32(*payload.SessionTimeoutInMinutes)
+	}
+
+	if payload.TriggerOnNewConversation != nil {
+		flow.TriggerOnNewConversation = *payload.TriggerOnNewConversation
+	}
+
+	keywords := []string{}
+	if payload.TriggerKeywords != nil {
+		for _, keyword := range *payload.TriggerKeywords {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				keywords = append(keywords, keyword)
+			}
+		}
+	}
+	if len(keywords) > 0 {
+		jsonKeywords, _ := json.Marshal(keywords)
+		stringKeywords := string(jsonKeywords)
+		flow.TriggerKeywords = &stringKeywords
+	}
+
+	if payload.IsEnabled && len(keywords) == 0 && !flow.TriggerOnNewConversation {
+		return nil, "an enabled flow needs trigger keywords or to be triggered on new conversations"
+	}
+
+	definition, err := json.Marshal(payload.Definition)
+	if err != nil {
+		return nil, err.Error()
+	}
+	flow.Definition = string(definition)
+
+	return &flow, ""
+}
+
+func handleGetChatbotFlows(context interfaces.ContextWithSession) error {
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+
+	var flows []model.ChatbotFlow
+	err := SELECT(table.ChatbotFlow.AllColumns).
+		FROM(table.ChatbotFlow).
+		WHERE(table.ChatbotFlow.OrganizationId.EQ(UUID(orgUuid))).
+		ORDER_BY(table.ChatbotFlow.Priority.DESC(), table.ChatbotFlow.CreatedAt.DESC()).
+		QueryContext(context.Request().Context(), context.App.Db, &flows)
+
+	if err != nil && err.Error() != qrm.ErrNoRows.Error() {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	response := api_types.GetChatbotFlowsResponseSchema{
+		Flows: []api_types.ChatbotFlowSchema{},
+	}
+	for _, flow := range flows {
+		response.Flows = append(response.Flows, context.App.ChatbotService.ParseChatbotFlowToApiSchema(flow))
+	}
+
+	return context.JSON(http.StatusOK, response)
+}
+
+func handleCreateChatbotFlow(context interfaces.ContextWithSession) error {
+	payload := new(api_types.ChatbotFlowInputSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	orgMember, err := fetchCurrentOrganizationMember(context)
+	if err != nil {
+		return context.JSON(http.StatusNotFound, "Organization member not found")
+	}
+
+	flowToInsert, errMessage := buildChatbotFlow(context, orgUuid, *payload)
+	if errMessage != "" {
+		return context.JSON(http.StatusBadRequest, errMessage)
+	}
+	flowToInsert.CreatedByOrganizationMemberId = orgMember.UniqueId
+	flowToInsert.CreatedAt = time.Now()
+
+	var insertedFlow model.ChatbotFlow
+	err = table.ChatbotFlow.
+		INSERT(table.ChatbotFlow.MutableColumns).
+		MODEL(flowToInsert).
+		RETURNING(table.ChatbotFlow.AllColumns).
+		QueryContext(context.Request().Context(), context.App.Db, &insertedFlow)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.CreateChatbotFlowResponseSchema{
+		Flow: context.App.ChatbotService.ParseChatbotFlowToApiSchema(insertedFlow),
+	})
+}
+
+func handleGetChatbotFlowById(context interfaces.ContextWithSession) error {
+	flowUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "Invalid chatbot flow id")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	flow, err := fetchChatbotFlow(context, flowUuid, orgUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "Chatbot flow not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.GetChatbotFlowByIdResponseSchema{
+		Flow: context.App.ChatbotService.ParseChatbotFlowToApiSchema(*flow),
+	})
+}
+
+func handleUpdateChatbotFlowById(context interfaces.ContextWithSession) error {
+	flowUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "Invalid chatbot flow id")
+	}
+
+	payload := new(api_types.ChatbotFlowInputSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	_, err = fetchChatbotFlow(context, flowUuid, orgUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "Chatbot flow not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	flowToUpdate, errMessage := buildChatbotFlow(context, orgUuid, *payload)
+	if errMessage != "" {
+		return context.JSON(http.StatusBadRequest, errMessage)
+	}
+
+	// * the sessions already running keep going with the new definition, the steps are looked up by id on every reply
+	var updatedFlow model.ChatbotFlow
+	err = table.ChatbotFlow.
+		UPDATE(
+			table.ChatbotFlow.Name,
+			table.ChatbotFlow.Description,
+			table.ChatbotFlow.IsEnabled,
+			table.ChatbotFlow.Definition,
+			table.ChatbotFlow.TriggerKeywords,
+			table.ChatbotFlow.TriggerOnNewConversation,
+			table.ChatbotFlow.Priority,
+			table.ChatbotFlow.SessionTimeoutInMinutes,
+			table.ChatbotFlow.UpdatedAt,
+		).
+		MODEL(flowToUpdate).
+		WHERE(
+			table.ChatbotFlow.UniqueId.EQ(UUID(flowUuid)).
+				AND(table.ChatbotFlow.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		RETURNING(table.ChatbotFlow.AllColumns).
+		QueryContext(context.Request().Context(), context.App.Db, &updatedFlow)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.UpdateChatbotFlowByIdResponseSchema{
+		Flow: context.App.ChatbotService.ParseChatbotFlowToApiSchema(updatedFlow),
+	})
+}
+
+func handleDeleteChatbotFlowById(context interfaces.ContextWithSession) error {
+	flowUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "Invalid chatbot flow id")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	_, err = fetchChatbotFlow(context, flowUuid, orgUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "Chatbot flow not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	// * the sessions are removed along with the flow, the conversations they ran in keep their messages
+	_, err = table.ChatbotFlowSession.
+		DELETE().
+		WHERE(table.ChatbotFlowSession.ChatbotFlowId.EQ(UUID(flowUuid))).
+		ExecContext(context.Request().Context(), context.App.Db)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	_, err = table.ChatbotFlow.
+		DELETE().
+		WHERE(
+			table.ChatbotFlow.UniqueId.EQ(UUID(flowUuid)).
+				AND(table.ChatbotFlow.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		ExecContext(context.Request().Context(), context.App.Db)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.DeleteChatbotFlowByIdResponseSchema{
+		Data: true,
+	})
+}
+
+func handleSimulateChatbotFlow(context interfaces.ContextWithSession) error {
+	flowUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "Invalid chatbot flow id")
+	}
+
+	payload := new(api_types.SimulateChatbotFlowSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	if len(payload.Messages) == 0 {
+		return context.JSON(http.StatusBadRequest, "at least one message is required")
+	}
+	if len(payload.Messages) > maxSimulatedMessages {
+		return context.JSON(http.StatusBadRequest, "too many messages to simulate")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	flow, err := fetchChatbotFlow(context, flowUuid, orgUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "Chatbot flow not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	// * an unsaved definition can be simulated, so that the flow is tried out before the changes are saved
+	var definition api_types.ChatbotFlowDefinitionSchema
+	if payload.Definition != nil {
+		definition = *payload.Definition
+	} else if err := json.Unmarshal([]byte(flow.Definition), &definition); err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	if err := chatbot_service.ValidateFlowDefinition(definition); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	response := context.App.ChatbotService.Simulate(definition, int(flow.SessionTimeoutInMinutes), *payload)
+	return context.JSON(http.StatusOK, response)
+}
@@ -281,6 +281,16 @@ func getContacts(context interfaces.ContextWithSession) error {
 		}
 	}
 
+	contactIds := make([]uuid.UUID, 0, len(dest))
+	for _, contact := range dest {
+		contactIds = append(contactIds, contact.UniqueId)
+	}
+
+	contactTags, err := fetchContactTags(context, contactIds)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
 	contactsToReturn := []api_types.ContactSchema{}
 	totalContacts := 0
 	if len(dest) > 0 {
@@ -337,6 +347,7 @@ func getContacts(context interfaces.ContextWithSession) error {
 				Attributes:    attr,
 				Status:        api_types.ContactStatusEnum(contact.Status),
 				Conversations: &conversations,
+				Tags:          contactTags[contact.UniqueId],
 			}
 			contactsToReturn = append(contactsToReturn, cntct)
 		}
@@ -545,6 +556,11 @@ func getContactById(context interfaces.ContextWithSession) error {
 		conversations = append(conversations, conversationToAppend)
 	}
 
+	contactTags, err := fetchContactTags(context, []uuid.UUID{dest.UniqueId})
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
 	contactIdString := dest.UniqueId.String()
 	attr := map[string]interface{}{}
 	json.Unmarshal([]byte(*dest.Attributes), &attr)
@@ -559,6 +575,7 @@ func getContactById(context interfaces.ContextWithSession) error {
 			Attributes:    attr,
 			Status:        api_types.ContactStatusEnum(dest.Status),
 			Conversations: &conversations,
+			Tags:          contactTags[dest.UniqueId],
 		},
 	})
 }
@@ -778,6 +795,11 @@ func updateContactById(context interfaces.ContextWithSession) error {
 		listToReturn = append(listToReturn, listToAppend)
 	}
 
+	contactTags, err := fetchContactTags(context, []uuid.UUID{updatedContact.UniqueId})
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
 	return context.JSON(http.StatusOK, api_types.UpdateContactByIdResponseSchema{
 		Contact: api_types.ContactSchema{
 			UniqueId:   updatedContact.UniqueId.String(),
@@ -786,6 +808,7 @@ func updateContactById(context interfaces.ContextWithSession) error {
 			Attributes: payload.Attributes,
 			Phone:      updatedContact.PhoneNumber,
 			Lists:      listToReturn,
+			Tags:       contactTags[updatedContact.UniqueId],
 		},
 	})
 }
@@ -1038,8 +1061,22 @@ func deleteContactById(context interfaces.ContextWithSession) error {
 	// ! TODO: check if there is any conversation associated with this contact
 	// ! TODO: also before deleting the contact, remove the contact from all the lists and delete all their messages
 
+	tx, err := context.App.Db.BeginTx(context.Request().Context(), nil)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+	defer tx.Rollback()
+
+	_, err = table.ContactTag.DELETE().
+		WHERE(table.ContactTag.ContactId.EQ(UUID(contactUuid))).
+		ExecContext(context.Request().Context(), tx)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
 	contactQuery := table.Contact.DELETE().WHERE(table.Contact.UniqueId.EQ(UUID(contactUuid)))
-	result, err := contactQuery.ExecContext(context.Request().Context(), context.App.Db)
+	result, err := contactQuery.ExecContext(context.Request().Context(), tx)
 
 	if err != nil {
 		return context.JSON(http.StatusInternalServerError, err.Error())
@@ -1049,9 +1086,53 @@ func deleteContactById(context interfaces.ContextWithSession) error {
 		return context.JSON(http.StatusNotFound, "Contact not found")
 	}
 
+	if err := tx.Commit(); err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
 	response := api_types.DeleteContactByIdResponseSchema{
 		Data: true,
 	}
 
 	return context.JSON(http.StatusOK, response)
 }
+
+// fetchContactTags returns the tags of the contacts, every contact has an entry even without tags
+func fetchContactTags(context interfaces.ContextWithSession, contactIds []uuid.UUID) (map[uuid.UUID][]api_types.TagSchema, error) {
+	contactTags := map[uuid.UUID][]api_types.TagSchema{}
+	if len(contactIds) == 0 {
+		return contactTags, nil
+	}
+
+	contactIdExpressions := make([]Expression, 0, len(contactIds))
+	for _, contactId := range contactIds {
+		contactTags[contactId] = []api_types.TagSchema{}
+		contactIdExpressions = append(contactIdExpressions, UUID(contactId))
+	}
+
+	var dest []struct {
+		model.ContactTag
+		Tag model.Tag
+	}
+
+	err := SELECT(table.ContactTag.AllColumns, table.Tag.AllColumns).
+		FROM(table.ContactTag.
+			INNER_JOIN(table.Tag, table.Tag.UniqueId.EQ(table.ContactTag.TagId)),
+		).
+		WHERE(table.ContactTag.ContactId.IN(contactIdExpressions...)).
+		ORDER_BY(table.Tag.Label.ASC()).
+		QueryContext(context.Request().Context(), context.App.Db, &dest)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, contactTag := range dest {
+		contactTags[contactTag.ContactId] = append(contactTags[contactTag.ContactId], api_types.TagSchema{
+			UniqueId: contactTag.Tag.UniqueId.String(),
+			Label:    contactTag.Tag.Label,
+		})
+	}
+
+	return contactTags, nil
+}
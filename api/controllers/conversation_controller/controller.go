@@ -2,7 +2,9 @@ package conversation_controller
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"io"
 	"net/http"
 	"path/filepath"
@@ -15,7 +17,11 @@ import (
 	"github.com/wapikit/wapikit/api/api_types"
 	controller "github.com/wapikit/wapikit/api/controllers"
 	"github.com/wapikit/wapikit/interfaces"
+	"github.com/wapikit/wapikit/services/business_account_service"
+	"github.com/wapikit/wapikit/services/conversation_service"
 	"github.com/wapikit/wapikit/services/event_service"
+	"github.com/wapikit/wapikit/services/media_service"
+	"github.com/wapikit/wapikit/services/notification_service"
 	"github.com/wapikit/wapikit/utils"
 
 	"github.com/go-jet/jet/qrm"
@@ -48,6 +54,118 @@ func NewConversationController() *ConversationController {
 						},
 					},
 				},
+				{
+					Path:                    "/api/conversations/search",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleSearchConversations),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: time.Minute.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetConversation,
+						},
+					},
+				},
+				{
+					Path:                    "/api/conversations/bulk-actions",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleCreateConversationBulkAction),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    30,
+							WindowTimeInMs: time.Hour.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateConversation,
+						},
+					},
+				},
+				{
+					Path:                    "/api/conversations/bulk-actions/:id",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetConversationBulkActionById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    600,
+							WindowTimeInMs: time.Hour.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetConversation,
+						},
+					},
+				},
+				{
+					Path:                    "/api/conversations/tagging-rules",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetConversationTaggingRules),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    600,
+							WindowTimeInMs: time.Hour.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetTag,
+						},
+					},
+				},
+				{
+					Path:                    "/api/conversations/tagging-rules",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleCreateConversationTaggingRule),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: time.Hour.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateTag,
+						},
+					},
+				},
+				{
+					Path:                    "/api/conversations/tagging-rules/:id",
+					Method:                  http.MethodPut,
+					Handler:                 interfaces.HandlerWithSession(handleUpdateConversationTaggingRuleById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: time.Hour.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateTag,
+						},
+					},
+				},
+				{
+					Path:                    "/api/conversations/tagging-rules/:id",
+					Method:                  http.MethodDelete,
+					Handler:                 interfaces.HandlerWithSession(handleDeleteConversationTaggingRuleById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: time.Hour.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateTag,
+						},
+					},
+				},
 				{
 					Path:                    "/api/conversation/:id",
 					Method:                  http.MethodGet,
@@ -188,6 +306,150 @@ func NewConversationController() *ConversationController {
 					Handler:                 interfaces.HandlerWithSession(markConversationAsRead),
 					IsAuthorizationRequired: true,
 				},
+				{
+					Path:                    "/api/conversation/:id/typing",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleSendTypingIndicator),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    120,
+							WindowTimeInMs: time.Minute.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateConversation,
+						},
+					},
+				},
+				{
+					Path:                    "/api/conversation/:id/phone-number",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleUpdateConversationPhoneNumber),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: time.Hour.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateConversation,
+						},
+					},
+				},
+				{
+					Path:                    "/api/conversation/:id/snooze",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleSnoozeConversation),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    600,
+							WindowTimeInMs: time.Hour.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateConversation,
+						},
+					},
+				},
+				{
+					Path:                    "/api/conversation/:id/unsnooze",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleUnsnoozeConversation),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    600,
+							WindowTimeInMs: time.Hour.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateConversation,
+						},
+					},
+				},
+				{
+					Path:                    "/api/conversation/:id/reminders",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetConversationReminders),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    600,
+							WindowTimeInMs: time.Hour.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetConversation,
+						},
+					},
+				},
+				{
+					Path:                    "/api/conversation/:id/reminders",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleCreateConversationReminder),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    600,
+							WindowTimeInMs: time.Hour.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateConversation,
+						},
+					},
+				},
+				{
+					Path:                    "/api/conversation/:id/reminders/:reminderId",
+					Method:                  http.MethodDelete,
+					Handler:                 interfaces.HandlerWithSession(handleDeleteConversationReminderById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    600,
+							WindowTimeInMs: time.Hour.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateConversation,
+						},
+					},
+				},
+				{
+					Path:                    "/api/conversation/:id/transcript",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetConversationTranscript),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: time.Hour.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetConversation,
+						},
+					},
+				},
+				{
+					Path:                    "/api/conversation/:id/transcript/email",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleEmailConversationTranscript),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    20,
+							WindowTimeInMs: time.Hour.Milliseconds(),
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetConversation,
+						},
+					},
+				},
 			},
 		},
 	}
@@ -244,6 +506,8 @@ func handleGetConversations(context interfaces.ContextWithSession) error {
 			table.Conversation.Status.NOT_IN(
 				utils.EnumExpression(model.ConversationStatusEnum_Deleted.String()),
 				utils.EnumExpression(model.ConversationStatusEnum_Closed.String()),
+				// * snoozed conversations are out of the inbox until they are reopened
+				utils.EnumExpression(model.ConversationStatusEnum_Snoozed.String()),
 			),
 		)
 	}
@@ -252,6 +516,37 @@ func handleGetConversations(context interfaces.ContextWithSession) error {
 		conversationWhereQuery = conversationWhereQuery.AND(table.Conversation.InitiatedByCampaignId.EQ(UUID(uuid.MustParse(*campaignId))))
 	}
 
+	if queryParams.Sentiment != nil {
+		switch *queryParams.Sentiment {
+		case api_types.Positive, api_types.Neutral, api_types.Negative:
+		default:
+			return context.JSON(http.StatusBadRequest, "Invalid sentiment value")
+		}
+		conversationWhereQuery = conversationWhereQuery.AND(table.Conversation.Sentiment.EQ(utils.EnumExpression(string(*queryParams.Sentiment))))
+	}
+
+	if queryParams.Intent != nil {
+		switch *queryParams.Intent {
+		case api_types.Inquiry, api_types.Complaint, api_types.Purchase, api_types.Support, api_types.Feedback, api_types.Greeting, api_types.Other:
+		default:
+			return context.JSON(http.StatusBadRequest, "Invalid intent value")
+		}
+		conversationWhereQuery = conversationWhereQuery.AND(table.Conversation.Intent.EQ(utils.EnumExpression(string(*queryParams.Intent))))
+	}
+
+	if queryParams.Language != nil && *queryParams.Language != "" {
+		conversationWhereQuery = conversationWhereQuery.AND(table.Conversation.Language.EQ(String(strings.ToLower(strings.TrimSpace(*queryParams.Language)))))
+	}
+
+	if queryParams.Priority != nil {
+		switch *queryParams.Priority {
+		case api_types.Low, api_types.Normal, api_types.High, api_types.Urgent:
+		default:
+			return context.JSON(http.StatusBadRequest, "Invalid priority value")
+		}
+		conversationWhereQuery = conversationWhereQuery.AND(table.Conversation.Priority.EQ(utils.EnumExpression(string(*queryParams.Priority))))
+	}
+
 	conversationCte := CTE("conversations")
 	unreadCountCte := CTE("numberOfUnreadMessages")
 	paginationMetaCte := CTE("paginationMeta")
@@ -323,6 +618,8 @@ func handleGetConversations(context interfaces.ContextWithSession) error {
 				LEFT_JOIN(table.Tag, table.ConversationTag.TagId.EQ(table.Tag.UniqueId)),
 			).
 				WHERE(conversationWhereQuery).
+				// * the most urgent conversations come first, the latest ones first within a priority
+				ORDER_BY(table.Conversation.Priority.DESC(), table.Conversation.UpdatedAt.DESC()).
 				LIMIT(limit).
 				OFFSET((page-1)*limit),
 		),
@@ -365,6 +662,9 @@ func handleGetConversations(context interfaces.ContextWithSession) error {
 				).LEFT_JOIN(
 				paginationMetaCte, conversationIdColumn.EQ(table.Message.ConversationId.From(paginationMetaCte)),
 			),
+		).ORDER_BY(
+			table.Conversation.Priority.From(conversationCte).DESC(),
+			table.Conversation.UpdatedAt.From(conversationCte).DESC(),
 		),
 	)
 
@@ -403,16 +703,19 @@ func handleGetConversations(context interfaces.ContextWithSession) error {
 		}
 
 		conversationToAppend := api_types.ConversationSchema{
-			UniqueId:               conversation.UniqueId.String(),
-			ContactId:              conversation.ContactId.String(),
-			OrganizationId:         conversation.OrganizationId.String(),
-			InitiatedBy:            api_types.ConversationInitiatedByEnum(conversation.InitiatedBy.String()),
-			CampaignId:             &campaignId,
-			CreatedAt:              conversation.CreatedAt,
-			Status:                 api_types.ConversationStatusEnum(conversation.Status.String()),
-			Messages:               []api_types.MessageSchema{},
-			NumberOfUnreadMessages: conversation.NumberOfUnreadMessages,
-			TotalMessages:          &conversation.TotalMessages,
+			UniqueId:                   conversation.UniqueId.String(),
+			ContactId:                  conversation.ContactId.String(),
+			OrganizationId:             conversation.OrganizationId.String(),
+			InitiatedBy:                api_types.ConversationInitiatedByEnum(conversation.InitiatedBy.String()),
+			PhoneNumberUsed:            &conversation.PhoneNumberUsed,
+			CampaignId:                 &campaignId,
+			CreatedAt:                  conversation.CreatedAt,
+			Status:                     api_types.ConversationStatusEnum(conversation.Status.String()),
+			SnoozedUntil:               conversation.SnoozedUntil,
+			IsSnoozedUntilContactReply: &conversation.IsSnoozedUntilContactReply,
+			Messages:                   []api_types.MessageSchema{},
+			NumberOfUnreadMessages:     conversation.NumberOfUnreadMessages,
+			TotalMessages:              &conversation.TotalMessages,
 			Contact: api_types.ContactWithoutConversationSchema{
 				UniqueId:   conversation.Contact.UniqueId.String(),
 				Name:       conversation.Contact.Name,
@@ -423,6 +726,7 @@ func handleGetConversations(context interfaces.ContextWithSession) error {
 			},
 			Tags: []api_types.TagSchema{},
 		}
+		context.App.ConversationService.SetConversationClassificationOfApiSchema(&conversationToAppend, conversation.Conversation)
 
 		context.App.Logger.Info("conversation: %v", conversation.AssignedTo)
 
@@ -460,6 +764,69 @@ func handleGetConversations(context interfaces.ContextWithSession) error {
 	return context.JSON(http.StatusOK, response)
 }
 
+func handleSearchConversations(context interfaces.ContextWithSession) error {
+	queryParams := new(api_types.SearchConversationsParams)
+	if err := utils.BindQueryParams(context, queryParams); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	page := queryParams.Page
+	limit := queryParams.PerPage
+	searchQuery := strings.TrimSpace(queryParams.Query)
+
+	if page == 0 || limit > 50 {
+		return context.JSON(http.StatusBadRequest, "Invalid page or perPage value")
+	}
+
+	if searchQuery == "" {
+		return context.JSON(http.StatusBadRequest, "search query is required")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	userUuid, _ := uuid.Parse(context.Session.User.UniqueId)
+
+	var orgMember model.OrganizationMember
+	err := SELECT(table.OrganizationMember.AllColumns).
+		FROM(table.OrganizationMember).
+		WHERE(
+			table.OrganizationMember.OrganizationId.EQ(UUID(orgUuid)).
+				AND(table.OrganizationMember.UserId.EQ(UUID(userUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &orgMember)
+	if err != nil {
+		return context.JSON(http.StatusNotFound, "organization member not found")
+	}
+
+	visibilityCondition := context.App.ConversationService.ConversationVisibilityCondition(
+		orgUuid,
+		orgMember.UniqueId,
+		context.Session.User.Role == api_types.Owner,
+	)
+
+	results, err := context.App.ConversationService.SearchConversations(context.Request().Context(), searchQuery, visibilityCondition, page, limit)
+	if err != nil && err.Error() != qrm.ErrNoRows.Error() {
+		context.App.Logger.Error("error searching conversations", "error", err.Error())
+		return context.JSON(http.StatusInternalServerError, "Error searching conversations")
+	}
+
+	resultsToReturn := []api_types.ConversationSearchResultSchema{}
+	totalResults := 0
+	for _, result := range results {
+		totalResults = result.TotalResults
+		resultsToReturn = append(resultsToReturn, result.ConversationSearchResultSchema)
+	}
+
+	return context.JSON(http.StatusOK, api_types.SearchConversationsResponseSchema{
+		Results: resultsToReturn,
+		PaginationMeta: api_types.PaginationMeta{
+			Page:    page,
+			PerPage: limit,
+			Total:   totalResults,
+		},
+	})
+}
+
 func handleGetConversationById(context interfaces.ContextWithSession) error {
 	conversationId := context.Param("id")
 
@@ -551,15 +918,18 @@ func handleGetConversationById(context interfaces.ContextWithSession) error {
 	}
 
 	response.Conversation = api_types.ConversationSchema{
-		UniqueId:               conversation.UniqueId.String(),
-		ContactId:              conversation.ContactId.String(),
-		OrganizationId:         conversation.OrganizationId.String(),
-		InitiatedBy:            api_types.ConversationInitiatedByEnum(conversation.InitiatedBy.String()),
-		CampaignId:             &campaignId,
-		CreatedAt:              conversation.CreatedAt,
-		Status:                 api_types.ConversationStatusEnum(conversation.Status.String()),
-		Messages:               []api_types.MessageSchema{},
-		NumberOfUnreadMessages: conversation.NumberOfUnreadMessages,
+		UniqueId:                   conversation.UniqueId.String(),
+		ContactId:                  conversation.ContactId.String(),
+		OrganizationId:             conversation.OrganizationId.String(),
+		InitiatedBy:                api_types.ConversationInitiatedByEnum(conversation.InitiatedBy.String()),
+		PhoneNumberUsed:            &conversation.PhoneNumberUsed,
+		CampaignId:                 &campaignId,
+		CreatedAt:                  conversation.CreatedAt,
+		Status:                     api_types.ConversationStatusEnum(conversation.Status.String()),
+		SnoozedUntil:               conversation.SnoozedUntil,
+		IsSnoozedUntilContactReply: &conversation.IsSnoozedUntilContactReply,
+		Messages:                   []api_types.MessageSchema{},
+		NumberOfUnreadMessages:     conversation.NumberOfUnreadMessages,
 		Contact: api_types.ContactWithoutConversationSchema{
 			UniqueId:   conversation.Contact.UniqueId.String(),
 			Name:       conversation.Contact.Name,
@@ -570,6 +940,7 @@ func handleGetConversationById(context interfaces.ContextWithSession) error {
 		},
 		Tags: []api_types.TagSchema{},
 	}
+	context.App.ConversationService.SetConversationClassificationOfApiSchema(&response.Conversation, conversation.Conversation)
 
 	if conversation.AssignedTo.UniqueId != uuid.Nil {
 		member := conversation.AssignedTo
@@ -612,26 +983,111 @@ func handleUpdateConversationById(context interfaces.ContextWithSession) error {
 		return context.JSON(http.StatusBadRequest, "invalid conversation id")
 	}
 
-	type FetchedConversation struct {
-		model.Conversation
-		Contact struct {
-			model.Contact
-			ContactLists []struct {
-				model.ContactList
-			} `json:"contactLists"`
-		} `json:"contact"`
-		Tags       []model.Tag     `json:"tags"`
-		Messages   []model.Message `json:"messages"`
-		AssignedTo struct {
-			model.OrganizationMember
-			User model.User `json:"user"`
-		} `json:"assignedTo"`
-		NumberOfUnreadMessages int `json:"numberOfUnreadMessages"`
+	payload := new(api_types.UpdateConversationSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
 	}
 
-	context.App.Logger.Info("conversation id: %v", conversationUuid)
+	conversation, err := fetchOrganizationConversation(context, conversationUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "conversation not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
 
-	return nil
+	switch payload.Status {
+	case api_types.ConversationStatusEnumClosed:
+		if conversation.Status == model.ConversationStatusEnum_Closed {
+			break
+		}
+		if conversation.Status == model.ConversationStatusEnum_Deleted {
+			return context.JSON(http.StatusBadRequest, "a deleted conversation can not be closed")
+		}
+
+		// * closing the conversation also sends the CSAT survey to the contact, when the organization enabled it
+		userId := context.Session.User.UniqueId
+		closedConversation, err := context.App.ConversationService.CloseConversation(context.Request().Context(), conversationUuid, &userId)
+		if err != nil {
+			return context.JSON(http.StatusInternalServerError, err.Error())
+		}
+		conversation = closedConversation
+
+	case api_types.ConversationStatusEnumActive:
+		if conversation.Status == model.ConversationStatusEnum_Active {
+			break
+		}
+		if conversation.Status != model.ConversationStatusEnum_Closed {
+			return context.JSON(http.StatusBadRequest, "only a closed conversation can be reopened, use the unsnooze endpoint for a snoozed conversation")
+		}
+
+		var reopenedConversation model.Conversation
+		err = table.Conversation.
+			UPDATE(table.Conversation.Status, table.Conversation.UpdatedAt).
+			SET(utils.EnumExpression(model.ConversationStatusEnum_Active.String()), TimestampzT(time.Now())).
+			WHERE(table.Conversation.UniqueId.EQ(UUID(conversationUuid))).
+			RETURNING(table.Conversation.AllColumns).
+			QueryContext(context.Request().Context(), context.App.Db, &reopenedConversation)
+		if err != nil {
+			return context.JSON(http.StatusInternalServerError, err.Error())
+		}
+		conversation = &reopenedConversation
+
+	case api_types.ConversationStatusEnumSnoozed:
+		return context.JSON(http.StatusBadRequest, "use the snooze endpoint to snooze a conversation")
+
+	default:
+		return context.JSON(http.StatusBadRequest, "invalid conversation status")
+	}
+
+	var contact model.Contact
+	err = SELECT(table.Contact.AllColumns).
+		FROM(table.Contact).
+		WHERE(table.Contact.UniqueId.EQ(UUID(conversation.ContactId))).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &contact)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	attributes := map[string]interface{}{}
+	if contact.Attributes != nil {
+		json.Unmarshal([]byte(*contact.Attributes), &attributes)
+	}
+
+	var campaignId *string
+	if conversation.InitiatedByCampaignId != nil {
+		stringCampaignId := conversation.InitiatedByCampaignId.String()
+		campaignId = &stringCampaignId
+	}
+
+	response := api_types.UpdateConversationByIdResponseSchema{
+		Conversation: api_types.ConversationSchema{
+			UniqueId:                   conversation.UniqueId.String(),
+			ContactId:                  conversation.ContactId.String(),
+			OrganizationId:             conversation.OrganizationId.String(),
+			InitiatedBy:                api_types.ConversationInitiatedByEnum(conversation.InitiatedBy.String()),
+			PhoneNumberUsed:            &conversation.PhoneNumberUsed,
+			CampaignId:                 campaignId,
+			CreatedAt:                  conversation.CreatedAt,
+			Status:                     api_types.ConversationStatusEnum(conversation.Status.String()),
+			SnoozedUntil:               conversation.SnoozedUntil,
+			IsSnoozedUntilContactReply: &conversation.IsSnoozedUntilContactReply,
+			Messages:                   []api_types.MessageSchema{},
+			Contact: api_types.ContactWithoutConversationSchema{
+				UniqueId:   contact.UniqueId.String(),
+				Name:       contact.Name,
+				Phone:      contact.PhoneNumber,
+				Attributes: attributes,
+				CreatedAt:  contact.CreatedAt,
+				Status:     api_types.ContactStatusEnum(contact.Status.String()),
+			},
+			Tags: []api_types.TagSchema{},
+		},
+	}
+	context.App.ConversationService.SetConversationClassificationOfApiSchema(&response.Conversation, *conversation)
+
+	return context.JSON(http.StatusOK, response)
 }
 
 func handleDeleteConversationById(context interfaces.ContextWithSession) error {
@@ -776,18 +1232,15 @@ func handleSendMessage(context interfaces.ContextWithSession) error {
 	// 3. Fetch conversation + contact + business account
 	var convoData struct {
 		model.Conversation
-		Contact                 model.Contact
-		WhatsappBusinessAccount model.WhatsappBusinessAccount
+		Contact model.Contact
 	}
 	err = SELECT(
 		table.Conversation.AllColumns,
 		table.Contact.AllColumns,
-		table.WhatsappBusinessAccount.AllColumns,
 	).
 		FROM(
 			table.Conversation.
-				LEFT_JOIN(table.Contact, table.Conversation.ContactId.EQ(table.Contact.UniqueId)).
-				LEFT_JOIN(table.WhatsappBusinessAccount, table.WhatsappBusinessAccount.OrganizationId.EQ(table.Conversation.OrganizationId)),
+				LEFT_JOIN(table.Contact, table.Conversation.ContactId.EQ(table.Contact.UniqueId)),
 		).
 		WHERE(table.Conversation.UniqueId.EQ(UUID(conversationUuid))).
 		LIMIT(1).
@@ -800,6 +1253,74 @@ func handleSendMessage(context interfaces.ContextWithSession) error {
 		return context.JSON(http.StatusInternalServerError, err.Error())
 	}
 
+	// * the message is sent from the phone number of the conversation, with the business account that phone number belongs to
+	phoneNumber, err := context.App.BusinessAccountService.GetPhoneNumber(context.Request().Context(), convoData.OrganizationId, convoData.PhoneNumberUsed)
+	if err != nil {
+		if errors.Is(err, business_account_service.ErrPhoneNumberNotFound) {
+			return context.JSON(http.StatusBadRequest, err.Error())
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	// * when a canned response is referenced, its rendered content is sent as a text message
+	var cannedResponse *model.CannedResponse
+	var cannedResponseUsedBy uuid.UUID
+	if payload.CannedResponseId != nil && *payload.CannedResponseId != "" {
+		cannedResponseUuid, err := uuid.Parse(*payload.CannedResponseId)
+		if err != nil {
+			return context.JSON(http.StatusBadRequest, "invalid canned response id")
+		}
+
+		userUuid, _ := uuid.Parse(context.Session.User.UniqueId)
+		var orgMember model.OrganizationMember
+		err = SELECT(table.OrganizationMember.AllColumns).
+			FROM(table.OrganizationMember).
+			WHERE(
+				table.OrganizationMember.OrganizationId.EQ(UUID(convoData.OrganizationId)).
+					AND(table.OrganizationMember.UserId.EQ(UUID(userUuid))),
+			).
+			LIMIT(1).
+			QueryContext(context.Request().Context(), context.App.Db, &orgMember)
+		if err != nil {
+			return context.JSON(http.StatusNotFound, "organization member not found")
+		}
+
+		var cannedResponseData struct {
+			model.CannedResponse
+			Organization model.Organization
+		}
+		err = SELECT(table.CannedResponse.AllColumns, table.Organization.AllColumns).
+			FROM(
+				table.CannedResponse.
+					LEFT_JOIN(table.Organization, table.Organization.UniqueId.EQ(table.CannedResponse.OrganizationId)),
+			).
+			WHERE(
+				table.CannedResponse.UniqueId.EQ(UUID(cannedResponseUuid)).
+					AND(context.App.ConversationService.CannedResponseVisibilityCondition(convoData.OrganizationId, orgMember.UniqueId)),
+			).
+			LIMIT(1).
+			QueryContext(context.Request().Context(), context.App.Db, &cannedResponseData)
+		if err != nil {
+			if err.Error() == qrm.ErrNoRows.Error() {
+				return context.JSON(http.StatusNotFound, "canned response not found")
+			}
+			return context.JSON(http.StatusInternalServerError, err.Error())
+		}
+
+		renderedContent := context.App.ConversationService.RenderCannedResponse(cannedResponseData.Content, conversation_service.CannedResponseVariables{
+			Contact:          convoData.Contact,
+			AgentName:        context.Session.User.Name,
+			OrganizationName: cannedResponseData.Organization.Name,
+		})
+
+		if err := payload.MessageData.FromTextMessageData(api_types.TextMessageData{Text: renderedContent}); err != nil {
+			return context.JSON(http.StatusInternalServerError, err.Error())
+		}
+
+		cannedResponse = &cannedResponseData.CannedResponse
+		cannedResponseUsedBy = orgMember.UniqueId
+	}
+
 	// 4. Build & send the message
 	discriminator, err := payload.MessageData.Discriminator()
 	if err != nil {
@@ -810,10 +1331,10 @@ func handleSendMessage(context interfaces.ContextWithSession) error {
 
 	messageComponent, err := context.App.ConversationService.BuildSendMessagePayload(discriminator, payload.MessageData)
 	if err != nil {
-		return context.JSON(http.StatusInternalServerError, err.Error())
+		return context.JSON(http.StatusBadRequest, err.Error())
 	}
 
-	messagingClient := context.App.WapiClient.NewMessagingClient(convoData.PhoneNumberUsed)
+	messagingClient := context.App.BusinessAccountService.NewClient(phoneNumber.WhatsappBusinessAccount).NewMessagingClient(convoData.PhoneNumberUsed)
 	resp, err := messagingClient.Message.Send(messageComponent, convoData.Contact.PhoneNumber)
 	if err != nil {
 		return context.JSON(http.StatusInternalServerError, err.Error())
@@ -836,7 +1357,7 @@ func handleSendMessage(context interfaces.ContextWithSession) error {
 		MessageType:               model.MessageTypeEnum(discriminator), // override if needed
 		OrganizationId:            convoData.OrganizationId,
 		WhatsAppMessageId:         &whatsappMessageId,
-		WhatsappBusinessAccountId: &convoData.WhatsappBusinessAccount.AccountId,
+		WhatsappBusinessAccountId: &phoneNumber.WhatsappBusinessAccount.AccountId,
 		PhoneNumberUsed:           convoData.PhoneNumberUsed,
 		MessageData:               &stringMessageData,
 		CreatedAt:                 time.Now(),
@@ -852,6 +1373,19 @@ func handleSendMessage(context interfaces.ContextWithSession) error {
 		return context.JSON(http.StatusInternalServerError, err.Error())
 	}
 
+	if cannedResponse != nil {
+		err = context.App.ConversationService.RecordCannedResponseUsage(context.Request().Context(), *cannedResponse, cannedResponseUsedBy, convoData.UniqueId, &insertedMessage.UniqueId)
+		if err != nil {
+			// * the message is already sent, failing to record the usage must not fail the request
+			logger.Error("error recording canned response usage", "error", err.Error())
+		}
+	}
+
+	// * an agent replying takes the conversation over from the chatbot flow waiting for the contact
+	if err := context.App.ChatbotService.StopConversationSessions(context.Request().Context(), convoData.UniqueId); err != nil {
+		logger.Error("error stopping chatbot flow sessions", "error", err.Error())
+	}
+
 	// 6. Return the new message
 	response := api_types.SendMessageInConversationResponseSchema{
 		Message: context.App.ConversationService.ParseDbMessageToApiMessage(insertedMessage),
@@ -1092,12 +1626,15 @@ func handleMediaUpload(context interfaces.ContextWithSession) error {
 		return context.JSON(http.StatusBadRequest, "invalid conversation id")
 	}
 
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+
 	conversationQuery := SELECT(
 		table.Conversation.AllColumns,
 	).FROM(
 		table.Conversation,
 	).WHERE(
-		table.Conversation.UniqueId.EQ(UUID(conversationUuid)),
+		table.Conversation.UniqueId.EQ(UUID(conversationUuid)).
+			AND(table.Conversation.OrganizationId.EQ(UUID(orgUuid))),
 	).LIMIT(1)
 
 	var conversation model.Conversation
@@ -1142,19 +1679,48 @@ func handleMediaUpload(context interfaces.ContextWithSession) error {
 		return context.JSON(http.StatusInternalServerError, "phone number id not configured")
 	}
 
+	phoneNumber, err := context.App.BusinessAccountService.GetPhoneNumber(context.Request().Context(), conversation.OrganizationId, phoneNumberId)
+	if err != nil {
+		if errors.Is(err, business_account_service.ErrPhoneNumberNotFound) {
+			return context.JSON(http.StatusBadRequest, err.Error())
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	// * keep our own copy of the media, the media urls of whatsapp expire, this also validates the content and size of the file
+	mediaFile, err := context.App.MediaService.StoreMedia(context.Request().Context(), media_service.StoreMediaParams{
+		OrganizationId: conversation.OrganizationId,
+		Content:        file,
+		FileName:       &filename,
+	})
+	if err != nil {
+		if errors.Is(err, media_service.ErrMediaTooLarge) || errors.Is(err, media_service.ErrUnsupportedMediaType) || errors.Is(err, media_service.ErrEmptyMedia) {
+			return context.JSON(http.StatusBadRequest, err.Error())
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
 	// Use MediaManager to upload the media file to WhatsApp.
-	messagingClient := context.App.WapiClient.NewMessagingClient(phoneNumberId)
+	messagingClient := context.App.BusinessAccountService.NewClient(phoneNumber.WhatsappBusinessAccount).NewMessagingClient(phoneNumberId)
 	mediaManager := messagingClient.Media
 	mediaID, err := mediaManager.UploadMedia(phoneNumberId, file, filename, mimeType)
 	if err != nil {
 		return context.JSON(http.StatusInternalServerError, fmt.Sprintf("upload failed: %v", err))
 	}
 
-	// Optionally, retrieve a temporary media URL.
-	mediaUrl, err := mediaManager.GetMediaUrlById(mediaID)
+	mediaFile, err = context.App.MediaService.LinkWhatsAppMediaId(context.Request().Context(), mediaFile.UniqueId, mediaID)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	mediaUrl, err := context.App.MediaService.GetSignedUrl(*mediaFile, false)
 	if err != nil {
 		// Log the error but we can still proceed with mediaID.
-		context.App.Logger.Error("failed to retrieve media URL", err.Error(), nil)
+		context.App.Logger.Error("failed to sign media URL", err.Error(), nil)
 	}
 
 	// Return the media ID and URL to the frontend.
@@ -1165,9 +1731,6 @@ func handleMediaUpload(context interfaces.ContextWithSession) error {
 }
 
 func handleProxyWhatsAppMedia(context interfaces.ContextWithSession) error {
-	redis := context.App.Redis
-	// logger := context.App.Logger
-
 	// 0. Validate conversationId
 	conversationId := context.Param("id")
 	if conversationId == "" {
@@ -1179,24 +1742,23 @@ func handleProxyWhatsAppMedia(context interfaces.ContextWithSession) error {
 		return context.JSON(http.StatusBadRequest, "invalid conversation id")
 	}
 
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+
 	conversationQuery := SELECT(
 		table.Conversation.AllColumns,
 		table.Organization.AllColumns,
-		table.WhatsappBusinessAccount.AllColumns,
 	).FROM(
 		table.Conversation.LEFT_JOIN(
 			table.Organization, table.Conversation.OrganizationId.EQ(table.Organization.UniqueId),
-		).LEFT_JOIN(
-			table.WhatsappBusinessAccount, table.WhatsappBusinessAccount.OrganizationId.EQ(table.Organization.UniqueId),
 		),
 	).WHERE(
-		table.Conversation.UniqueId.EQ(UUID(conversationUuid)),
+		table.Conversation.UniqueId.EQ(UUID(conversationUuid)).
+			AND(table.Conversation.OrganizationId.EQ(UUID(orgUuid))),
 	).LIMIT(1)
 
 	var conversation struct {
 		model.Conversation
-		Organization            model.Organization
-		WhatsappBusinessAccount model.WhatsappBusinessAccount
+		Organization model.Organization
 	}
 
 	err = conversationQuery.QueryContext(context.Request().Context(), context.App.Db, &conversation)
@@ -1213,74 +1775,78 @@ func handleProxyWhatsAppMedia(context interfaces.ContextWithSession) error {
 		return context.JSON(http.StatusBadRequest, "Missing mediaId")
 	}
 
-	// Use MediaManager to upload the media file to WhatsApp.
-	messagingClient := context.App.WapiClient.NewMessagingClient(conversation.PhoneNumberUsed)
-	mediaManager := messagingClient.Media
+	isThumbnail := context.QueryParam("thumbnail") == "true"
+
+	mediaFile, err := context.App.MediaService.GetMediaFileByWhatsAppMediaId(context.Request().Context(), conversation.OrganizationId, mediaId)
 	if err != nil {
-		return context.JSON(http.StatusInternalServerError, fmt.Sprintf("upload failed: %v", err))
-	}
+		if err.Error() != qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusInternalServerError, err.Error())
+		}
 
-	cacheKey := redis.ComputeCacheKey("media", mediaId, "url")
-	var mediaUrl string
-	ok, err := redis.GetCachedData(cacheKey, &mediaUrl)
+		// * the media has not been stored yet (received before the media storage existed, or the download on receipt failed),
+		// * fetch it from whatsapp once while the whatsapp media url is still valid
+		phoneNumber, err := context.App.BusinessAccountService.GetPhoneNumber(context.Request().Context(), conversation.OrganizationId, conversation.PhoneNumberUsed)
+		if err != nil {
+			if errors.Is(err, business_account_service.ErrPhoneNumberNotFound) {
+				return context.JSON(http.StatusBadRequest, err.Error())
+			}
+			return context.JSON(http.StatusInternalServerError, err.Error())
+		}
 
-	if !ok || err != nil {
-		mediaUrl, err = mediaManager.GetMediaUrlById(mediaId)
+		messagingClient := context.App.BusinessAccountService.NewClient(phoneNumber.WhatsappBusinessAccount).NewMessagingClient(conversation.PhoneNumberUsed)
+		mediaUrl, err := messagingClient.Media.GetMediaUrlById(mediaId)
 		if err != nil {
-			return context.JSON(http.StatusInternalServerError, fmt.Sprintf("failed to get media url: %v", err))
+			return context.JSON(http.StatusNotFound, fmt.Sprintf("failed to get media url: %v", err))
 		}
-		redis.CacheData(cacheKey, mediaUrl, time.Minute*4)
-	}
 
-	if mediaUrl == "" {
-		return context.JSON(http.StatusNotFound, "media url is empty or not found")
-	}
+		if mediaUrl == "" {
+			return context.JSON(http.StatusNotFound, "media url is empty or not found")
+		}
 
-	// 3. Build an HTTP GET request to that ephemeral URL, adding your bearer token if needed.
-	req, err := http.NewRequest(http.MethodGet, mediaUrl, nil)
-	if err != nil {
-		return context.JSON(http.StatusInternalServerError, fmt.Sprintf("failed to create request: %v", err))
+		mediaFile, err = context.App.MediaService.StoreWhatsAppMedia(
+			context.Request().Context(),
+			conversation.OrganizationId,
+			mediaId,
+			mediaUrl,
+			phoneNumber.WhatsappBusinessAccount.AccessToken,
+			nil,
+		)
+		if err != nil {
+			return context.JSON(http.StatusBadGateway, fmt.Sprintf("failed to fetch media from WhatsApp: %v", err))
+		}
 	}
 
-	// Possibly set an Authorization header, if the ephemeral URL requires your token.
-	// For example:
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", conversation.WhatsappBusinessAccount.AccessToken))
-
-	// 4. Execute the request.
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	signedUrl, err := context.App.MediaService.GetSignedUrl(*mediaFile, isThumbnail)
 	if err != nil {
-		return context.JSON(http.StatusBadGateway, fmt.Sprintf("failed to fetch media from WhatsApp: %v", err))
+		return context.JSON(http.StatusInternalServerError, err.Error())
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return context.JSON(resp.StatusCode, fmt.Sprintf("upstream error: %s", string(bodyBytes)))
-	}
+	return context.Redirect(http.StatusFound, signedUrl)
+}
 
-	// 5. Copy relevant headers to the response (e.g. Content-Type, Content-Length).
-	contentType := resp.Header.Get("Content-Type")
-	if contentType == "" {
-		contentType = "application/octet-stream"
+func markConversationAsRead(context interfaces.ContextWithSession) error {
+	conversationId := context.Param("id")
+	if conversationId == "" {
+		return context.JSON(http.StatusBadRequest, "conversation id is required")
+	}
+	conversationUuid, err := uuid.Parse(conversationId)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "invalid conversation id")
 	}
-	context.Response().Header().Set("Content-Type", contentType)
-
-	// 6. Stream the body directly to the client.
-	// If your framework supports returning a "stream" response, you can do that.
-	// Otherwise, manually copy the bytes to the Context's writer.
 
-	// Option A: Manually copy the stream:
-	_, copyErr := io.Copy(context.Response().Writer, resp.Body)
-	if copyErr != nil && !strings.Contains(copyErr.Error(), "broken pipe") {
-		// Log or handle partial writes if needed
-		context.App.Logger.Error("error streaming media to client", copyErr.Error(), nil)
+	if err := markInboundMessagesAsRead(context, conversationUuid, false); err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "conversation not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
 	}
 
-	return nil
+	return context.JSON(http.StatusOK, api_types.MarkConversationAsReadResponseSchema{
+		IsRead: true,
+	})
 }
 
-func markConversationAsRead(context interfaces.ContextWithSession) error {
+func handleSendTypingIndicator(context interfaces.ContextWithSession) error {
 	conversationId := context.Param("id")
 	if conversationId == "" {
 		return context.JSON(http.StatusBadRequest, "conversation id is required")
@@ -1290,7 +1856,40 @@ func markConversationAsRead(context interfaces.ContextWithSession) error {
 		return context.JSON(http.StatusBadRequest, "invalid conversation id")
 	}
 
-	updateQuery := table.Message.UPDATE(table.Message.Status).
+	if err := markInboundMessagesAsRead(context, conversationUuid, true); err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "conversation not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.SendTypingIndicatorResponseSchema{
+		Data: true,
+	})
+}
+
+// markInboundMessagesAsRead marks the unread inbound messages of the conversation as read and queues the read receipt,
+// and optionally the typing indicator, for the latest inbound message to be sent to whatsapp
+func markInboundMessagesAsRead(context interfaces.ContextWithSession, conversationUuid uuid.UUID, showTypingIndicator bool) error {
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+
+	var conversation model.Conversation
+
+	err := SELECT(
+		table.Conversation.AllColumns,
+	).FROM(
+		table.Conversation,
+	).WHERE(
+		table.Conversation.UniqueId.EQ(UUID(conversationUuid)).
+			AND(table.Conversation.OrganizationId.EQ(UUID(orgUuid))),
+	).LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &conversation)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = table.Message.UPDATE(table.Message.Status).
 		SET(utils.EnumExpression(model.MessageStatusEnum_Read.String())).
 		WHERE(
 			table.Message.ConversationId.EQ(UUID(conversationUuid)).AND(
@@ -1298,15 +1897,1047 @@ func markConversationAsRead(context interfaces.ContextWithSession) error {
 			).AND(
 				table.Message.Status.EQ(utils.EnumExpression(model.MessageStatusEnum_Sent.String())),
 			),
-		)
+		).
+		ExecContext(context.Request().Context(), context.App.Db)
+
+	if err != nil {
+		return err
+	}
+
+	// * whatsapp marks the earlier messages of the thread as read along with the given one, so only the latest inbound message is needed
+	var latestInboundMessage model.Message
+	err = SELECT(table.Message.AllColumns).
+		FROM(table.Message).
+		WHERE(
+			table.Message.ConversationId.EQ(UUID(conversationUuid)).
+				AND(table.Message.Direction.EQ(utils.EnumExpression(model.MessageDirectionEnum_InBound.String()))).
+				AND(table.Message.WhatsAppMessageId.IS_NOT_NULL()),
+		).
+		ORDER_BY(table.Message.CreatedAt.DESC()).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &latestInboundMessage)
+
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			// * nothing has been received in this conversation yet
+			return nil
+		}
+		return err
+	}
+
+	phoneNumber, err := context.App.BusinessAccountService.GetPhoneNumber(context.Request().Context(), conversation.OrganizationId, conversation.PhoneNumberUsed)
+	if err != nil {
+		if errors.Is(err, business_account_service.ErrPhoneNumberNotFound) {
+			// * the messages are marked as read locally, the read receipt can not be sent without the business account of the phone number
+			context.App.Logger.Warn("read receipt not sent", "conversation_id", conversationUuid.String(), "error", err.Error())
+			return nil
+		}
+		return err
+	}
+
+	context.App.ConversationService.QueueStatusUpdate(conversation_service.StatusUpdateParams{
+		PhoneNumberId:       conversation.PhoneNumberUsed,
+		AccessToken:         phoneNumber.WhatsappBusinessAccount.AccessToken,
+		ConversationId:      conversationUuid.String(),
+		WhatsAppMessageId:   *latestInboundMessage.WhatsAppMessageId,
+		ShowTypingIndicator: showTypingIndicator,
+	})
+
+	return nil
+}
+
+func fetchOrganizationConversation(context interfaces.ContextWithSession, conversationUuid uuid.UUID) (*model.Conversation, error) {
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+
+	var conversation model.Conversation
+	err := SELECT(table.Conversation.AllColumns).
+		FROM(table.Conversation).
+		WHERE(
+			table.Conversation.UniqueId.EQ(UUID(conversationUuid)).
+				AND(table.Conversation.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &conversation)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &conversation, nil
+}
+
+// handleUpdateConversationPhoneNumber changes the phone number the replies of the conversation are sent from,
+// e.g. to move a contact to the number of its country, the contact sees the replies in the chat of the new number
+func handleUpdateConversationPhoneNumber(context interfaces.ContextWithSession) error {
+	conversationId := context.Param("id")
+	if conversationId == "" {
+		return context.JSON(http.StatusBadRequest, "conversation id is required")
+	}
+	conversationUuid, err := uuid.Parse(conversationId)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "invalid conversation id")
+	}
+
+	payload := new(api_types.UpdateConversationPhoneNumberSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	if payload.PhoneNumberId == "" {
+		return context.JSON(http.StatusBadRequest, "phone number id is required")
+	}
+
+	conversation, err := fetchOrganizationConversation(context, conversationUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "conversation not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
 
-	_, err = updateQuery.ExecContext(context.Request().Context(), context.App.Db)
+	if conversation.Status == model.ConversationStatusEnum_Closed || conversation.Status == model.ConversationStatusEnum_Deleted {
+		return context.JSON(http.StatusBadRequest, "the phone number of a closed conversation can not be changed")
+	}
+
+	if _, err := context.App.BusinessAccountService.GetPhoneNumber(context.Request().Context(), conversation.OrganizationId, payload.PhoneNumberId); err != nil {
+		if errors.Is(err, business_account_service.ErrPhoneNumberNotFound) {
+			return context.JSON(http.StatusBadRequest, err.Error())
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	_, err = table.Conversation.UPDATE(table.Conversation.PhoneNumberUsed, table.Conversation.UpdatedAt).
+		SET(payload.PhoneNumberId, time.Now()).
+		WHERE(table.Conversation.UniqueId.EQ(UUID(conversation.UniqueId))).
+		ExecContext(context.Request().Context(), context.App.Db)
 
 	if err != nil {
 		return context.JSON(http.StatusInternalServerError, err.Error())
 	}
 
-	return context.JSON(http.StatusOK, api_types.MarkConversationAsReadResponseSchema{
-		IsRead: true,
+	return context.JSON(http.StatusOK, api_types.UpdateConversationPhoneNumberResponseSchema{
+		IsUpdated: true,
+	})
+}
+
+func handleSnoozeConversation(context interfaces.ContextWithSession) error {
+	conversationId := context.Param("id")
+	if conversationId == "" {
+		return context.JSON(http.StatusBadRequest, "conversation id is required")
+	}
+	conversationUuid, err := uuid.Parse(conversationId)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "invalid conversation id")
+	}
+
+	payload := new(api_types.SnoozeConversationSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	conversation, err := fetchOrganizationConversation(context, conversationUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "conversation not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	if conversation.Status != model.ConversationStatusEnum_Active && conversation.Status != model.ConversationStatusEnum_Snoozed {
+		return context.JSON(http.StatusBadRequest, "only active conversations can be snoozed")
+	}
+
+	err = context.App.ConversationService.SnoozeConversation(context.Request().Context(), conversationUuid, payload.SnoozedUntil, payload.UntilContactReplies)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.SnoozeConversationResponseSchema{
+		Data: true,
+	})
+}
+
+func handleUnsnoozeConversation(context interfaces.ContextWithSession) error {
+	conversationId := context.Param("id")
+	if conversationId == "" {
+		return context.JSON(http.StatusBadRequest, "conversation id is required")
+	}
+	conversationUuid, err := uuid.Parse(conversationId)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "invalid conversation id")
+	}
+
+	conversation, err := fetchOrganizationConversation(context, conversationUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "conversation not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	if conversation.Status != model.ConversationStatusEnum_Snoozed {
+		return context.JSON(http.StatusBadRequest, "conversation is not snoozed")
+	}
+
+	err = context.App.ConversationService.ReopenSnoozedConversation(context.Request().Context(), conversationUuid, fmt.Sprintf("reopened by %s", context.Session.User.Name))
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.UnsnoozeConversationResponseSchema{
+		Data: true,
+	})
+}
+
+func parseConversationReminderToApiSchema(reminder model.ConversationReminder) api_types.ConversationReminderSchema {
+	return api_types.ConversationReminderSchema{
+		UniqueId:             reminder.UniqueId.String(),
+		ConversationId:       reminder.ConversationId.String(),
+		OrganizationMemberId: reminder.OrganizationMemberId.String(),
+		RemindAt:             reminder.RemindAt,
+		Note:                 reminder.Note,
+		FiredAt:              reminder.FiredAt,
+		CreatedAt:            reminder.CreatedAt,
+	}
+}
+
+func handleGetConversationReminders(context interfaces.ContextWithSession) error {
+	conversationId := context.Param("id")
+	if conversationId == "" {
+		return context.JSON(http.StatusBadRequest, "conversation id is required")
+	}
+	conversationUuid, err := uuid.Parse(conversationId)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "invalid conversation id")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+
+	var reminders []model.ConversationReminder
+	err = SELECT(table.ConversationReminder.AllColumns).
+		FROM(table.ConversationReminder).
+		WHERE(
+			table.ConversationReminder.ConversationId.EQ(UUID(conversationUuid)).
+				AND(table.ConversationReminder.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		ORDER_BY(table.ConversationReminder.RemindAt.ASC()).
+		QueryContext(context.Request().Context(), context.App.Db, &reminders)
+
+	if err != nil && err.Error() != qrm.ErrNoRows.Error() {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	remindersToReturn := []api_types.ConversationReminderSchema{}
+	for _, reminder := range reminders {
+		remindersToReturn = append(remindersToReturn, parseConversationReminderToApiSchema(reminder))
+	}
+
+	return context.JSON(http.StatusOK, api_types.GetConversationRemindersResponseSchema{
+		Reminders: remindersToReturn,
+	})
+}
+
+func handleCreateConversationReminder(context interfaces.ContextWithSession) error {
+	conversationId := context.Param("id")
+	if conversationId == "" {
+		return context.JSON(http.StatusBadRequest, "conversation id is required")
+	}
+	conversationUuid, err := uuid.Parse(conversationId)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "invalid conversation id")
+	}
+
+	payload := new(api_types.NewConversationReminderSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	if payload.RemindAt.Before(time.Now()) {
+		return context.JSON(http.StatusBadRequest, "remind at time must be in the future")
+	}
+
+	conversation, err := fetchOrganizationConversation(context, conversationUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "conversation not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	userUuid, _ := uuid.Parse(context.Session.User.UniqueId)
+	var orgMember model.OrganizationMember
+	err = SELECT(table.OrganizationMember.AllColumns).
+		FROM(table.OrganizationMember).
+		WHERE(
+			table.OrganizationMember.OrganizationId.EQ(UUID(conversation.OrganizationId)).
+				AND(table.OrganizationMember.UserId.EQ(UUID(userUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &orgMember)
+	if err != nil {
+		return context.JSON(http.StatusNotFound, "organization member not found")
+	}
+
+	reminderToInsert := model.ConversationReminder{
+		ConversationId:       conversationUuid,
+		OrganizationMemberId: orgMember.UniqueId,
+		OrganizationId:       conversation.OrganizationId,
+		RemindAt:             payload.RemindAt,
+		Note:                 payload.Note,
+		CreatedAt:            time.Now(),
+		UpdatedAt:            time.Now(),
+	}
+
+	var insertedReminder model.ConversationReminder
+	err = table.ConversationReminder.
+		INSERT(table.ConversationReminder.MutableColumns).
+		MODEL(reminderToInsert).
+		RETURNING(table.ConversationReminder.AllColumns).
+		QueryContext(context.Request().Context(), context.App.Db, &insertedReminder)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.CreateConversationReminderResponseSchema{
+		Reminder: parseConversationReminderToApiSchema(insertedReminder),
+	})
+}
+
+func handleDeleteConversationReminderById(context interfaces.ContextWithSession) error {
+	conversationUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "invalid conversation id")
+	}
+
+	reminderUuid, err := uuid.Parse(context.Param("reminderId"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "invalid reminder id")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+
+	result, err := table.ConversationReminder.
+		DELETE().
+		WHERE(
+			table.ConversationReminder.UniqueId.EQ(UUID(reminderUuid)).
+				AND(table.ConversationReminder.ConversationId.EQ(UUID(conversationUuid))).
+				AND(table.ConversationReminder.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		ExecContext(context.Request().Context(), context.App.Db)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	if res, _ := result.RowsAffected(); res == 0 {
+		return context.JSON(http.StatusNotFound, "reminder not found")
+	}
+
+	return context.JSON(http.StatusOK, api_types.DeleteConversationReminderByIdResponseSchema{
+		Data: true,
+	})
+}
+
+// * transcripts are downloaded and emailed, so the media links in them must outlive the usual signed url expiry
+const transcriptMediaUrlExpiry = 7 * 24 * time.Hour
+
+func transcriptMediaUrlResolver(context interfaces.ContextWithSession, orgUuid uuid.UUID) func(string) *string {
+	baseUrl := fmt.Sprintf("%s://%s", context.Scheme(), context.Request().Host)
+
+	return func(whatsAppMediaId string) *string {
+		mediaFile, err := context.App.MediaService.GetMediaFileByWhatsAppMediaId(context.Request().Context(), orgUuid, whatsAppMediaId)
+		if err != nil {
+			return nil
+		}
+
+		signedUrl, err := context.App.MediaService.GetSignedUrlWithExpiry(*mediaFile, false, transcriptMediaUrlExpiry)
+		if err != nil {
+			return nil
+		}
+
+		if strings.HasPrefix(signedUrl, "/") {
+			signedUrl = baseUrl + signedUrl
+		}
+
+		return &signedUrl
+	}
+}
+
+func renderConversationTranscript(context interfaces.ContextWithSession, transcript conversation_service.ConversationTranscript, format api_types.ConversationTranscriptFormatEnum) ([]byte, string, string, error) {
+	fileName := fmt.Sprintf("conversation-%s-%s", transcript.ConversationId, transcript.GeneratedAt.UTC().Format("20060102"))
+
+	if format == api_types.Html {
+		document, err := context.App.ConversationService.RenderTranscriptHtml(transcript)
+		if err != nil {
+			return nil, "", "", err
+		}
+		return document, "text/html; charset=utf-8", fileName + ".html", nil
+	}
+
+	document, err := context.App.ConversationService.RenderTranscriptPdf(transcript)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return document, "application/pdf", fileName + ".pdf", nil
+}
+
+func handleGetConversationTranscript(context interfaces.ContextWithSession) error {
+	conversationId := context.Param("id")
+	if conversationId == "" {
+		return context.JSON(http.StatusBadRequest, "conversation id is required")
+	}
+	conversationUuid, err := uuid.Parse(conversationId)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "invalid conversation id")
+	}
+
+	queryParams := new(api_types.GetConversationTranscriptParams)
+	if err := utils.BindQueryParams(context, queryParams); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	format := api_types.Pdf
+	if queryParams.Format != nil {
+		format = *queryParams.Format
+	}
+	if format != api_types.Pdf && format != api_types.Html {
+		return context.JSON(http.StatusBadRequest, "invalid transcript format")
+	}
+
+	includeNotes := true
+	if queryParams.IncludeNotes != nil {
+		includeNotes = *queryParams.IncludeNotes
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+
+	transcript, err := context.App.ConversationService.BuildConversationTranscript(context.Request().Context(), conversation_service.BuildTranscriptParams{
+		OrganizationId:  orgUuid,
+		ConversationId:  conversationUuid,
+		IncludeNotes:    includeNotes,
+		ResolveMediaUrl: transcriptMediaUrlResolver(context, orgUuid),
+	})
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "conversation not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	document, contentType, fileName, err := renderConversationTranscript(context, *transcript, format)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	context.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	return context.Blob(http.StatusOK, contentType, document)
+}
+
+// transcriptEmailsPerConversationLimit is the number of transcripts of a conversation that can be emailed in an hour
+const transcriptEmailsPerConversationLimit = 3
+
+func handleEmailConversationTranscript(context interfaces.ContextWithSession) error {
+	conversationId := context.Param("id")
+	if conversationId == "" {
+		return context.JSON(http.StatusBadRequest, "conversation id is required")
+	}
+	conversationUuid, err := uuid.Parse(conversationId)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "invalid conversation id")
+	}
+
+	payload := new(api_types.EmailConversationTranscriptSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	format := api_types.Pdf
+	if payload.Format != nil {
+		format = *payload.Format
+	}
+	if format != api_types.Pdf && format != api_types.Html {
+		return context.JSON(http.StatusBadRequest, "invalid transcript format")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+
+	var organization model.Organization
+	err = SELECT(table.Organization.AllColumns).
+		FROM(table.Organization).
+		WHERE(table.Organization.UniqueId.EQ(UUID(orgUuid))).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &organization)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	if organization.SmtpClientHost == nil || organization.SmtpClientPassword == nil || organization.SmtpClientPort == nil || organization.SmtpClientUsername == nil {
+		return context.JSON(http.StatusBadRequest, "smtp settings of the organization are not configured")
+	}
+
+	conversation, err := fetchOrganizationConversation(context, conversationUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "conversation not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	contactEmail := ""
+	var contact model.Contact
+	err = SELECT(table.Contact.AllColumns).
+		FROM(table.Contact).
+		WHERE(table.Contact.UniqueId.EQ(UUID(conversation.ContactId))).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &contact)
+
+	if err == nil && contact.Attributes != nil {
+		var attributes map[string]interface{}
+		if json.Unmarshal([]byte(*contact.Attributes), &attributes) == nil {
+			if email, ok := attributes["email"].(string); ok {
+				contactEmail = strings.TrimSpace(email)
+			}
+		}
+	}
+
+	recipientEmail := contactEmail
+	if payload.Email != nil {
+		recipientEmail = strings.TrimSpace(string(*payload.Email))
+	}
+
+	if recipientEmail == "" {
+		return context.JSON(http.StatusBadRequest, "email is required, the contact does not have an email attribute")
+	}
+
+	// * the transcript holds the personal data of the contact, it is only ever sent to the contact or to a member of the organization
+	if !strings.EqualFold(recipientEmail, contactEmail) {
+		var member model.User
+		err = SELECT(table.User.UniqueId).
+			FROM(table.OrganizationMember.
+				INNER_JOIN(table.User, table.User.UniqueId.EQ(table.OrganizationMember.UserId)),
+			).
+			WHERE(
+				table.OrganizationMember.OrganizationId.EQ(UUID(orgUuid)).
+					AND(LOWER(table.User.Email).EQ(LOWER(String(recipientEmail)))).
+					AND(table.User.Status.EQ(utils.EnumExpression(model.UserAccountStatusEnum_Active.String()))),
+			).
+			LIMIT(1).
+			QueryContext(context.Request().Context(), context.App.Db, &member)
+
+		if err != nil {
+			if err.Error() == qrm.ErrNoRows.Error() {
+				return context.JSON(http.StatusBadRequest, "the transcript can only be emailed to the contact or to a member of the organization")
+			}
+			return context.JSON(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	isAllowed, err := controller.EnforceRateLimit(context.App.Redis, fmt.Sprintf("conversation-transcript-email:%s", conversationUuid.String()), transcriptEmailsPerConversationLimit, time.Hour)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+	if !isAllowed {
+		return context.JSON(http.StatusTooManyRequests, "too many transcripts of this conversation were emailed, try again later")
+	}
+
+	// * the notes are internal to the organization, they are never shared over email
+	transcript, err := context.App.ConversationService.BuildConversationTranscript(context.Request().Context(), conversation_service.BuildTranscriptParams{
+		OrganizationId:  orgUuid,
+		ConversationId:  conversationUuid,
+		IncludeNotes:    false,
+		ResolveMediaUrl: transcriptMediaUrlResolver(context, orgUuid),
+	})
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	document, contentType, fileName, err := renderConversationTranscript(context, *transcript, format)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	subject := fmt.Sprintf("Transcript of your conversation with %s", organization.Name)
+	if payload.Subject != nil && strings.TrimSpace(*payload.Subject) != "" {
+		subject = strings.TrimSpace(*payload.Subject)
+	}
+
+	message := fmt.Sprintf("Please find attached the transcript of your conversation with %s.", organization.Name)
+	if payload.Message != nil && strings.TrimSpace(*payload.Message) != "" {
+		message = strings.TrimSpace(*payload.Message)
+	}
+
+	err = context.App.NotificationService.SendEmailWithAttachments(notification_service.EmailWithAttachmentsParams{
+		SmtpConfig: &notification_service.EmailConfig{
+			Host:     *organization.SmtpClientHost,
+			Port:     *organization.SmtpClientPort,
+			Username: *organization.SmtpClientUsername,
+			Password: *organization.SmtpClientPassword,
+		},
+		To:       recipientEmail,
+		Subject:  subject,
+		HtmlBody: fmt.Sprintf("<p>%s</p>", html.EscapeString(message)),
+		Attachments: []notification_service.EmailAttachment{
+			{
+				FileName:    fileName,
+				ContentType: contentType,
+				Content:     document,
+			},
+		},
+	})
+
+	if err != nil {
+		context.App.Logger.Error("error sending conversation transcript email", "error", err.Error())
+		return context.JSON(http.StatusInternalServerError, "failed to send the transcript email")
+	}
+
+	return context.JSON(http.StatusOK, api_types.EmailConversationTranscriptResponseSchema{
+		Data: true,
+	})
+}
+
+func fetchCurrentOrganizationMember(context interfaces.ContextWithSession) (*model.OrganizationMember, error) {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	userUuid, err := uuid.Parse(context.Session.User.UniqueId)
+	if err != nil {
+		return nil, err
+	}
+
+	var orgMember model.OrganizationMember
+	err = SELECT(table.OrganizationMember.AllColumns).
+		FROM(table.OrganizationMember).
+		WHERE(
+			table.OrganizationMember.OrganizationId.EQ(UUID(orgUuid)).
+				AND(table.OrganizationMember.UserId.EQ(UUID(userUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &orgMember)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &orgMember, nil
+}
+
+// validateOrganizationTagIds checks all the given tags belong to the organization and returns them parsed
+func validateOrganizationTagIds(context interfaces.ContextWithSession, orgUuid uuid.UUID, tagIds []string) ([]uuid.UUID, error) {
+	tagUuids := make([]uuid.UUID, 0, len(tagIds))
+	tagIdExpressions := make([]Expression, 0, len(tagIds))
+	for _, tagId := range tagIds {
+		tagUuid, err := uuid.Parse(tagId)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag id %s", tagId)
+		}
+		tagUuids = append(tagUuids, tagUuid)
+		tagIdExpressions = append(tagIdExpressions, UUID(tagUuid))
+	}
+
+	var tags []model.Tag
+	err := SELECT(table.Tag.UniqueId).
+		FROM(table.Tag).
+		WHERE(
+			table.Tag.UniqueId.IN(tagIdExpressions...).
+				AND(table.Tag.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		QueryContext(context.Request().Context(), context.App.Db, &tags)
+
+	if err != nil && err.Error() != qrm.ErrNoRows.Error() {
+		return nil, err
+	}
+
+	foundTagIds := map[uuid.UUID]bool{}
+	for _, tag := range tags {
+		foundTagIds[tag.UniqueId] = true
+	}
+
+	for _, tagUuid := range tagUuids {
+		if !foundTagIds[tagUuid] {
+			return nil, fmt.Errorf("tag %s not found", tagUuid.String())
+		}
+	}
+
+	return tagUuids, nil
+}
+
+func handleCreateConversationBulkAction(context interfaces.ContextWithSession) error {
+	orgUuid := uuid.MustParse(context.Session.User.OrganizationId)
+
+	payload := new(api_types.CreateConversationBulkActionSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	selection := conversation_service.BulkActionSelection{
+		Filter:  payload.Filter,
+		IsOwner: context.Session.User.Role == api_types.Owner,
+	}
+
+	if payload.ConversationIds != nil && len(*payload.ConversationIds) > 0 {
+		if len(*payload.ConversationIds) > conversation_service.MaxBulkActionConversations {
+			return context.JSON(http.StatusBadRequest, fmt.Sprintf("a bulk action can run on at most %d conversations", conversation_service.MaxBulkActionConversations))
+		}
+		for _, conversationId := range *payload.ConversationIds {
+			if _, err := uuid.Parse(conversationId); err != nil {
+				return context.JSON(http.StatusBadRequest, fmt.Sprintf("invalid conversation id %s", conversationId))
+			}
+		}
+		selection.ConversationIds = *payload.ConversationIds
+		selection.Filter = nil
+	} else if payload.Filter == nil {
+		return context.JSON(http.StatusBadRequest, "either conversation ids or a filter is required")
+	} else if payload.Filter.Status != nil {
+		switch model.ConversationStatusEnum(*payload.Filter.Status) {
+		case model.ConversationStatusEnum_Active, model.ConversationStatusEnum_Closed, model.ConversationStatusEnum_Resolved, model.ConversationStatusEnum_Snoozed, model.ConversationStatusEnum_Deleted:
+		default:
+			return context.JSON(http.StatusBadRequest, "invalid status in the filter")
+		}
+	}
+
+	parameters := conversation_service.BulkActionParameters{}
+
+	switch payload.Action {
+	case api_types.Assign:
+		if payload.OrganizationMemberId == nil {
+			return context.JSON(http.StatusBadRequest, "organization member id is required to assign conversations")
+		}
+		memberUuid, err := uuid.Parse(*payload.OrganizationMemberId)
+		if err != nil {
+			return context.JSON(http.StatusBadRequest, "invalid organization member id")
+		}
+
+		var assignee model.OrganizationMember
+		err = SELECT(table.OrganizationMember.UniqueId).
+			FROM(table.OrganizationMember).
+			WHERE(
+				table.OrganizationMember.UniqueId.EQ(UUID(memberUuid)).
+					AND(table.OrganizationMember.OrganizationId.EQ(UUID(orgUuid))),
+			).
+			LIMIT(1).
+			QueryContext(context.Request().Context(), context.App.Db, &assignee)
+
+		if err != nil {
+			if err.Error() == qrm.ErrNoRows.Error() {
+				return context.JSON(http.StatusNotFound, "organization member not found")
+			}
+			return context.JSON(http.StatusInternalServerError, err.Error())
+		}
+		parameters.OrganizationMemberId = payload.OrganizationMemberId
+
+	case api_types.Tag, api_types.Untag:
+		if payload.TagIds == nil || len(*payload.TagIds) == 0 {
+			return context.JSON(http.StatusBadRequest, "tag ids are required to tag or untag conversations")
+		}
+		if _, err := validateOrganizationTagIds(context, orgUuid, *payload.TagIds); err != nil {
+			return context.JSON(http.StatusBadRequest, err.Error())
+		}
+		parameters.TagIds = *payload.TagIds
+
+	case api_types.Close, api_types.MarkRead:
+	default:
+		return context.JSON(http.StatusBadRequest, "invalid bulk action")
+	}
+
+	orgMember, err := fetchCurrentOrganizationMember(context)
+	if err != nil {
+		return context.JSON(http.StatusNotFound, "organization member not found")
+	}
+
+	job, err := context.App.ConversationService.CreateBulkActionJob(context.Request().Context(), conversation_service.CreateBulkActionJobParams{
+		OrganizationId:                orgUuid,
+		CreatedByOrganizationMemberId: orgMember.UniqueId,
+		Action:                        model.ConversationBulkActionEnum(payload.Action),
+		Selection:                     selection,
+		Parameters:                    parameters,
+	})
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	context.App.ConversationService.StartBulkActionJob(job.UniqueId)
+
+	return context.JSON(http.StatusOK, api_types.CreateConversationBulkActionResponseSchema{
+		Job: context.App.ConversationService.ParseBulkActionJobToApiSchema(*job),
+	})
+}
+
+func handleGetConversationBulkActionById(context interfaces.ContextWithSession) error {
+	jobUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "invalid bulk action id")
+	}
+
+	orgUuid := uuid.MustParse(context.Session.User.OrganizationId)
+
+	var job model.ConversationBulkActionJob
+	err = SELECT(table.ConversationBulkActionJob.AllColumns).
+		FROM(table.ConversationBulkActionJob).
+		WHERE(
+			table.ConversationBulkActionJob.UniqueId.EQ(UUID(jobUuid)).
+				AND(table.ConversationBulkActionJob.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &job)
+
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "bulk action not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.GetConversationBulkActionByIdResponseSchema{
+		Job: context.App.ConversationService.ParseBulkActionJobToApiSchema(job),
+	})
+}
+
+// buildConversationTaggingRule validates the payload and builds the rule to save, the tag and the campaign must belong to the organization
+func buildConversationTaggingRule(context interfaces.ContextWithSession, orgUuid uuid.UUID, payload api_types.ConversationTaggingRuleInputSchema) (*model.ConversationTaggingRule, error) {
+	if err := conversation_service.ValidateTaggingRule(payload); err != nil {
+		return nil, err
+	}
+
+	tagUuids, err := validateOrganizationTagIds(context, orgUuid, []string{payload.TagId})
+	if err != nil {
+		return nil, err
+	}
+
+	rule := model.ConversationTaggingRule{
+		OrganizationId: orgUuid,
+		Name:           strings.TrimSpace(payload.Name),
+		Type:           model.ConversationTaggingRuleTypeEnum(payload.Type),
+		IsEnabled:      true,
+		TagId:          tagUuids[0],
+		UpdatedAt:      time.Now(),
+	}
+
+	if payload.IsEnabled != nil {
+		rule.IsEnabled = *payload.IsEnabled
+	}
+
+	switch payload.Type {
+	case api_types.Keyword:
+		keywords := []string{}
+		for _, keyword := range *payload.Keywords {
+			if keyword = strings.TrimSpace(keyword); keyword != "" {
+				keywords = append(keywords, keyword)
+			}
+		}
+		keywordsJson, _ := json.Marshal(keywords)
+		stringKeywords := string(keywordsJson)
+		rule.Keywords = &stringKeywords
+
+	case api_types.ContactAttribute:
+		attributeKey := strings.TrimSpace(*payload.AttributeKey)
+		rule.AttributeKey = &attributeKey
+		rule.AttributeValue = payload.AttributeValue
+
+	case api_types.OriginatingCampaign:
+		if payload.CampaignId != nil && *payload.CampaignId != "" {
+			campaignUuid, err := uuid.Parse(*payload.CampaignId)
+			if err != nil {
+				return nil, fmt.Errorf("invalid campaign id")
+			}
+
+			var campaign model.Campaign
+			err = SELECT(table.Campaign.UniqueId).
+				FROM(table.Campaign).
+				WHERE(
+					table.Campaign.UniqueId.EQ(UUID(campaignUuid)).
+						AND(table.Campaign.OrganizationId.EQ(UUID(orgUuid))),
+				).
+				LIMIT(1).
+				QueryContext(context.Request().Context(), context.App.Db, &campaign)
+
+			if err != nil {
+				return nil, fmt.Errorf("campaign not found")
+			}
+			rule.CampaignId = &campaignUuid
+		}
+	}
+
+	return &rule, nil
+}
+
+func fetchConversationTaggingRule(context interfaces.ContextWithSession, ruleUuid uuid.UUID) (*conversation_service.TaggingRuleWithTag, error) {
+	orgUuid := uuid.MustParse(context.Session.User.OrganizationId)
+
+	var rule conversation_service.TaggingRuleWithTag
+	err := SELECT(table.ConversationTaggingRule.AllColumns, table.Tag.AllColumns).
+		FROM(table.ConversationTaggingRule.LEFT_JOIN(table.Tag, table.Tag.UniqueId.EQ(table.ConversationTaggingRule.TagId))).
+		WHERE(
+			table.ConversationTaggingRule.UniqueId.EQ(UUID(ruleUuid)).
+				AND(table.ConversationTaggingRule.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &rule)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+func handleGetConversationTaggingRules(context interfaces.ContextWithSession) error {
+	orgUuid := uuid.MustParse(context.Session.User.OrganizationId)
+
+	var rules []conversation_service.TaggingRuleWithTag
+	err := SELECT(table.ConversationTaggingRule.AllColumns, table.Tag.AllColumns).
+		FROM(table.ConversationTaggingRule.LEFT_JOIN(table.Tag, table.Tag.UniqueId.EQ(table.ConversationTaggingRule.TagId))).
+		WHERE(table.ConversationTaggingRule.OrganizationId.EQ(UUID(orgUuid))).
+		ORDER_BY(table.ConversationTaggingRule.CreatedAt.DESC()).
+		QueryContext(context.Request().Context(), context.App.Db, &rules)
+
+	if err != nil && err.Error() != qrm.ErrNoRows.Error() {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	response := api_types.GetConversationTaggingRulesResponseSchema{
+		Rules: []api_types.ConversationTaggingRuleSchema{},
+	}
+
+	for _, rule := range rules {
+		response.Rules = append(response.Rules, context.App.ConversationService.ParseTaggingRuleToApiSchema(rule))
+	}
+
+	return context.JSON(http.StatusOK, response)
+}
+
+func handleCreateConversationTaggingRule(context interfaces.ContextWithSession) error {
+	orgUuid := uuid.MustParse(context.Session.User.OrganizationId)
+
+	payload := new(api_types.ConversationTaggingRuleInputSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	ruleToInsert, err := buildConversationTaggingRule(context, orgUuid, *payload)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	orgMember, err := fetchCurrentOrganizationMember(context)
+	if err != nil {
+		return context.JSON(http.StatusNotFound, "organization member not found")
+	}
+
+	ruleToInsert.CreatedAt = time.Now()
+	ruleToInsert.CreatedByOrganizationMemberId = orgMember.UniqueId
+
+	var insertedRule model.ConversationTaggingRule
+	err = table.ConversationTaggingRule.
+		INSERT(table.ConversationTaggingRule.MutableColumns).
+		MODEL(*ruleToInsert).
+		RETURNING(table.ConversationTaggingRule.AllColumns).
+		QueryContext(context.Request().Context(), context.App.Db, &insertedRule)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	rule, err := fetchConversationTaggingRule(context, insertedRule.UniqueId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.CreateConversationTaggingRuleResponseSchema{
+		Rule: context.App.ConversationService.ParseTaggingRuleToApiSchema(*rule),
+	})
+}
+
+func handleUpdateConversationTaggingRuleById(context interfaces.ContextWithSession) error {
+	ruleUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "invalid tagging rule id")
+	}
+
+	orgUuid := uuid.MustParse(context.Session.User.OrganizationId)
+
+	payload := new(api_types.ConversationTaggingRuleInputSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	existingRule, err := fetchConversationTaggingRule(context, ruleUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "tagging rule not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	ruleToUpdate, err := buildConversationTaggingRule(context, orgUuid, *payload)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	// * the rule keeps its enabled state unless it is explicitly changed
+	if payload.IsEnabled == nil {
+		ruleToUpdate.IsEnabled = existingRule.IsEnabled
+	}
+
+	_, err = table.ConversationTaggingRule.
+		UPDATE(
+			table.ConversationTaggingRule.Name,
+			table.ConversationTaggingRule.Type,
+			table.ConversationTaggingRule.IsEnabled,
+			table.ConversationTaggingRule.Keywords,
+			table.ConversationTaggingRule.AttributeKey,
+			table.ConversationTaggingRule.AttributeValue,
+			table.ConversationTaggingRule.CampaignId,
+			table.ConversationTaggingRule.TagId,
+			table.ConversationTaggingRule.UpdatedAt,
+		).
+		MODEL(*ruleToUpdate).
+		WHERE(table.ConversationTaggingRule.UniqueId.EQ(UUID(existingRule.UniqueId))).
+		ExecContext(context.Request().Context(), context.App.Db)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	rule, err := fetchConversationTaggingRule(context, existingRule.UniqueId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.UpdateConversationTaggingRuleByIdResponseSchema{
+		Rule: context.App.ConversationService.ParseTaggingRuleToApiSchema(*rule),
+	})
+}
+
+func handleDeleteConversationTaggingRuleById(context interfaces.ContextWithSession) error {
+	ruleUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "invalid tagging rule id")
+	}
+
+	orgUuid := uuid.MustParse(context.Session.User.OrganizationId)
+
+	result, err := table.ConversationTaggingRule.
+		DELETE().
+		WHERE(
+			table.ConversationTaggingRule.UniqueId.EQ(UUID(ruleUuid)).
+				AND(table.ConversationTaggingRule.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		ExecContext(context.Request().Context(), context.App.Db)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return context.JSON(http.StatusNotFound, "tagging rule not found")
+	}
+
+	return context.JSON(http.StatusOK, api_types.DeleteConversationTaggingRuleByIdResponseSchema{
+		Data: true,
 	})
 }
@@ -0,0 +1,586 @@
+package knowledge_base_controller
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	"github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	controller "github.com/wapikit/wapikit/api/controllers"
+	"github.com/wapikit/wapikit/interfaces"
+	"github.com/wapikit/wapikit/services/ai_service"
+	"github.com/wapikit/wapikit/utils"
+
+	"github.com/go-jet/jet/qrm"
+	. "github.com/go-jet/jet/v2/postgres"
+)
+
+const (
+	maxPdfFileSize       = 10 << 20 // 10 MB
+	maxFaqsPerDocument   = 500
+	maxSearchResults     = 20
+	defaultDocumentsPage = 20
+	maxDocumentsPerPage  = 100
+)
+
+type KnowledgeBaseController struct {
+	controller.BaseController `json:"-,inline"`
+}
+
+func NewKnowledgeBaseController() *KnowledgeBaseController {
+	return &KnowledgeBaseController{
+		BaseController: controller.BaseController{
+			Name:        "Knowledge Base Controller",
+			RestApiPath: "/api/ai/knowledge-base",
+			Routes: []interfaces.Route{
+				{
+					Path:                    "/api/ai/knowledge-base/documents",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetKnowledgeBaseDocuments),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+					},
+				},
+				{
+					Path:                    "/api/ai/knowledge-base/documents/faq",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleCreateKnowledgeBaseFaqDocument),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateOrganization,
+						},
+					},
+				},
+				{
+					Path:                    "/api/ai/knowledge-base/documents/pdf",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleCreateKnowledgeBasePdfDocument),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateOrganization,
+						},
+					},
+				},
+				{
+					Path:                    "/api/ai/knowledge-base/documents/web-page",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleCreateKnowledgeBaseWebPageDocument),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateOrganization,
+						},
+					},
+				},
+				{
+					Path:                    "/api/ai/knowledge-base/documents/:id",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetKnowledgeBaseDocumentById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+					},
+				},
+				{
+					Path:                    "/api/ai/knowledge-base/documents/:id",
+					Method:                  http.MethodDelete,
+					Handler:                 interfaces.HandlerWithSession(handleDeleteKnowledgeBaseDocumentById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateOrganization,
+						},
+					},
+				},
+				{
+					Path:                    "/api/ai/knowledge-base/documents/:id/reindex",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleReindexKnowledgeBaseDocument),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateOrganization,
+						},
+					},
+				},
+				{
+					Path:                    "/api/ai/knowledge-base/search",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleSearchKnowledgeBase),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// organizationAiService builds the AI service with the model and the key of the organization of the session,
+// the documents are embedded with the same model the answers are generated with
+func organizationAiService(context interfaces.ContextWithSession, orgUuid uuid.UUID) (*ai_service.AiService, error) {
+	return ai_service.NewOrganizationAiService(context.Request().Context(), &context.App.Logger, context.App.Redis, context.App.Db, orgUuid, context.App.AiServiceConfig)
+}
+
+func respondWithAiServiceError(context interfaces.ContextWithSession, err error) error {
+	if errors.Is(err, ai_service.ErrAiNotEnabled) {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, ai_service.ErrAiBudgetExceeded) {
+		return context.JSON(http.StatusPaymentRequired, err.Error())
+	}
+	return context.JSON(http.StatusInternalServerError, err.Error())
+}
+
+func fetchKnowledgeBaseDocument(context interfaces.ContextWithSession, documentUuid, orgUuid uuid.UUID) (*model.KnowledgeBaseDocument, error) {
+	var document model.KnowledgeBaseDocument
+	err := SELECT(table.KnowledgeBaseDocument.AllColumns).
+		FROM(table.KnowledgeBaseDocument).
+		WHERE(
+			table.KnowledgeBaseDocument.UniqueId.EQ(UUID(documentUuid)).
+				AND(table.KnowledgeBaseDocument.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &document)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &document, nil
+}
+
+func fetchCurrentOrganizationMember(context interfaces.ContextWithSession, orgUuid uuid.UUID) (*model.OrganizationMember, error) {
+	userUuid, err := uuid.Parse(context.Session.User.UniqueId)
+	if err != nil {
+		return nil, err
+	}
+
+	var orgMember model.OrganizationMember
+	err = SELECT(table.OrganizationMember.AllColumns).
+		FROM(table.OrganizationMember).
+		WHERE(
+			table.OrganizationMember.OrganizationId.EQ(UUID(orgUuid)).
+				AND(table.OrganizationMember.UserId.EQ(UUID(userUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &orgMember)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &orgMember, nil
+}
+
+// createKnowledgeBaseDocument stores the document and starts indexing it, the document is returned while it is still being indexed
+func createKnowledgeBaseDocument(context interfaces.ContextWithSession, title string, sourceType model.KnowledgeBaseSourceTypeEnum, sourceUrl *string, content string) error {
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+
+	aiService, err := organizationAiService(context, orgUuid)
+	if err != nil {
+		return respondWithAiServiceError(context, err)
+	}
+
+	orgMember, err := fetchCurrentOrganizationMember(context, orgUuid)
+	if err != nil {
+		return context.JSON(http.StatusNotFound, "Organization member not found")
+	}
+
+	if strings.TrimSpace(content) == "" {
+		return context.JSON(http.StatusBadRequest, "The document has no text")
+	}
+
+	var insertedDocument model.KnowledgeBaseDocument
+	err = table.KnowledgeBaseDocument.
+		INSERT(table.KnowledgeBaseDocument.MutableColumns).
+		MODEL(model.KnowledgeBaseDocument{
+			OrganizationId:                orgUuid,
+			Title:                         title,
+			SourceType:                    sourceType,
+			SourceUrl:                     sourceUrl,
+			Content:                       content,
+			Status:                        model.KnowledgeBaseDocumentStatusEnum_Indexing,
+			CreatedByOrganizationMemberId: &orgMember.UniqueId,
+			CreatedAt:                     time.Now(),
+			UpdatedAt:                     time.Now(),
+		}).
+		RETURNING(table.KnowledgeBaseDocument.AllColumns).
+		QueryContext(context.Request().Context(), context.App.Db, &insertedDocument)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	aiService.IndexKnowledgeBaseDocumentInBackground(insertedDocument.UniqueId)
+
+	return context.JSON(http.StatusOK, api_types.CreateKnowledgeBaseDocumentResponseSchema{
+		Document: ai_service.ParseKnowledgeBaseDocumentToApiSchema(insertedDocument),
+	})
+}
+
+func handleGetKnowledgeBaseDocuments(context interfaces.ContextWithSession) error {
+	params := new(api_types.GetKnowledgeBaseDocumentsParams)
+	if err := utils.BindQueryParams(context, params); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	pageNumber := int64(1)
+	if params.Page != nil && *params.Page > 0 {
+		pageNumber = *params.Page
+	}
+	pageSize := int64(defaultDocumentsPage)
+	if params.PerPage != nil && *params.PerPage > 0 {
+		pageSize = min(*params.PerPage, maxDocumentsPerPage)
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+
+	// * the content of the documents can be large, the list only needs their metadata
+	var dest []struct {
+		TotalDocuments int `json:"totalDocuments"`
+		model.KnowledgeBaseDocument
+	}
+	err := SELECT(
+		table.KnowledgeBaseDocument.AllColumns.Except(table.KnowledgeBaseDocument.Content),
+		COUNT(table.KnowledgeBaseDocument.UniqueId).OVER().AS("totalDocuments"),
+	).
+		FROM(table.KnowledgeBaseDocument).
+		WHERE(table.KnowledgeBaseDocument.OrganizationId.EQ(UUID(orgUuid))).
+		ORDER_BY(table.KnowledgeBaseDocument.CreatedAt.DESC()).
+		LIMIT(pageSize).
+		OFFSET((pageNumber-1)*pageSize).
+		QueryContext(context.Request().Context(), context.App.Db, &dest)
+
+	if err != nil && err.Error() != qrm.ErrNoRows.Error() {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	response := api_types.GetKnowledgeBaseDocumentsResponseSchema{
+		Documents: []api_types.KnowledgeBaseDocumentSchema{},
+		PaginationMeta: api_types.PaginationMeta{
+			Page:    pageNumber,
+			PerPage: pageSize,
+			Total:   0,
+		},
+	}
+
+	for _, document := range dest {
+		response.Documents = append(response.Documents, ai_service.ParseKnowledgeBaseDocumentToApiSchema(document.KnowledgeBaseDocument))
+	}
+	if len(dest) > 0 {
+		response.PaginationMeta.Total = dest[0].TotalDocuments
+	}
+
+	return context.JSON(http.StatusOK, response)
+}
+
+func handleCreateKnowledgeBaseFaqDocument(context interfaces.ContextWithSession) error {
+	payload := new(api_types.CreateKnowledgeBaseFaqDocumentSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	title := strings.TrimSpace(payload.Title)
+	if title == "" {
+		return context.JSON(http.StatusBadRequest, "title is required")
+	}
+	if len(payload.Faqs) == 0 || len(payload.Faqs) > maxFaqsPerDocument {
+		return context.JSON(http.StatusBadRequest, "a FAQ document needs between 1 and 500 questions")
+	}
+
+	content, err := ai_service.FaqListContent(payload.Faqs)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	return createKnowledgeBaseDocument(context, title, model.KnowledgeBaseSourceTypeEnum_FaqList, nil, content)
+}
+
+func handleCreateKnowledgeBasePdfDocument(context interfaces.ContextWithSession) error {
+	if err := context.Request().ParseMultipartForm(maxPdfFileSize); err != nil {
+		return context.JSON(http.StatusBadRequest, "Invalid form data")
+	}
+
+	fileHeader, err := context.FormFile("file")
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "file is required")
+	}
+	if fileHeader.Size > maxPdfFileSize {
+		return context.JSON(http.StatusBadRequest, "The file is larger than 10 MB")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxPdfFileSize))
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	text, err := ai_service.ExtractPdfText(data)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	title := strings.TrimSpace(context.FormValue("title"))
+	if title == "" {
+		title = strings.TrimSuffix(fileHeader.Filename, filepath.Ext(fileHeader.Filename))
+	}
+
+	return createKnowledgeBaseDocument(context, title, model.KnowledgeBaseSourceTypeEnum_PdfDocument, nil, text)
+}
+
+func handleCreateKnowledgeBaseWebPageDocument(context interfaces.ContextWithSession) error {
+	payload := new(api_types.CreateKnowledgeBaseWebPageDocumentSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	pageUrl := strings.TrimSpace(payload.Url)
+	if pageUrl == "" {
+		return context.JSON(http.StatusBadRequest, "url is required")
+	}
+
+	pageTitle, text, err := ai_service.FetchWebPage(context.Request().Context(), pageUrl)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	title := pageUrl
+	if payload.Title != nil && strings.TrimSpace(*payload.Title) != "" {
+		title = strings.TrimSpace(*payload.Title)
+	} else if pageTitle != "" {
+		title = pageTitle
+	}
+
+	return createKnowledgeBaseDocument(context, title, model.KnowledgeBaseSourceTypeEnum_WebPage, &pageUrl, text)
+}
+
+func handleGetKnowledgeBaseDocumentById(context interfaces.ContextWithSession) error {
+	documentUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "Invalid document id")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	document, err := fetchKnowledgeBaseDocument(context, documentUuid, orgUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "Document not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	var chunks []model.KnowledgeBaseChunk
+	err = SELECT(table.KnowledgeBaseChunk.UniqueId, table.KnowledgeBaseChunk.ChunkIndex, table.KnowledgeBaseChunk.Content).
+		FROM(table.KnowledgeBaseChunk).
+		WHERE(table.KnowledgeBaseChunk.KnowledgeBaseDocumentId.EQ(UUID(documentUuid))).
+		ORDER_BY(table.KnowledgeBaseChunk.ChunkIndex.ASC()).
+		QueryContext(context.Request().Context(), context.App.Db, &chunks)
+
+	if err != nil && err.Error() != qrm.ErrNoRows.Error() {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	response := api_types.GetKnowledgeBaseDocumentByIdResponseSchema{
+		Document: ai_service.ParseKnowledgeBaseDocumentToApiSchema(*document),
+		Chunks:   []api_types.KnowledgeBaseChunkSchema{},
+	}
+	for _, chunk := range chunks {
+		response.Chunks = append(response.Chunks, api_types.KnowledgeBaseChunkSchema{
+			UniqueId:   chunk.UniqueId.String(),
+			ChunkIndex: int(chunk.ChunkIndex),
+			Content:    chunk.Content,
+		})
+	}
+
+	return context.JSON(http.StatusOK, response)
+}
+
+func handleDeleteKnowledgeBaseDocumentById(context interfaces.ContextWithSession) error {
+	documentUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "Invalid document id")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	_, err = fetchKnowledgeBaseDocument(context, documentUuid, orgUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "Document not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	tx, err := context.App.Db.BeginTx(context.Request().Context(), nil)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+	defer tx.Rollback()
+
+	_, err = table.KnowledgeBaseChunk.
+		DELETE().
+		WHERE(table.KnowledgeBaseChunk.KnowledgeBaseDocumentId.EQ(UUID(documentUuid))).
+		ExecContext(context.Request().Context(), tx)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	_, err = table.KnowledgeBaseDocument.
+		DELETE().
+		WHERE(
+			table.KnowledgeBaseDocument.UniqueId.EQ(UUID(documentUuid)).
+				AND(table.KnowledgeBaseDocument.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		ExecContext(context.Request().Context(), tx)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	if err := tx.Commit(); err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	// * the cached AI answers may quote the deleted document
+	if err := ai_service.ClearSemanticCache(context.Request().Context(), context.App.Redis, context.App.AiServiceConfig.SemanticCache, orgUuid, ai_service.SemanticCacheScopeAiChat); err != nil {
+		context.App.Logger.Error("error clearing the semantic cache", err.Error(), nil)
+	}
+
+	return context.JSON(http.StatusOK, api_types.DeleteKnowledgeBaseDocumentByIdResponseSchema{
+		Data: true,
+	})
+}
+
+// handleReindexKnowledgeBaseDocument indexes the document again, e.g. after the AI model of the organization changed
+func handleReindexKnowledgeBaseDocument(context interfaces.ContextWithSession) error {
+	documentUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "Invalid document id")
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	_, err = fetchKnowledgeBaseDocument(context, documentUuid, orgUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "Document not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	aiService, err := organizationAiService(context, orgUuid)
+	if err != nil {
+		return respondWithAiServiceError(context, err)
+	}
+
+	var updatedDocument model.KnowledgeBaseDocument
+	err = table.KnowledgeBaseDocument.
+		UPDATE(table.KnowledgeBaseDocument.Status, table.KnowledgeBaseDocument.Error, table.KnowledgeBaseDocument.UpdatedAt).
+		MODEL(model.KnowledgeBaseDocument{
+			Status:    model.KnowledgeBaseDocumentStatusEnum_Indexing,
+			Error:     nil,
+			UpdatedAt: time.Now(),
+		}).
+		WHERE(
+			table.KnowledgeBaseDocument.UniqueId.EQ(UUID(documentUuid)).
+				AND(table.KnowledgeBaseDocument.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		RETURNING(table.KnowledgeBaseDocument.AllColumns).
+		QueryContext(context.Request().Context(), context.App.Db, &updatedDocument)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	aiService.IndexKnowledgeBaseDocumentInBackground(updatedDocument.UniqueId)
+
+	return context.JSON(http.StatusOK, api_types.CreateKnowledgeBaseDocumentResponseSchema{
+		Document: ai_service.ParseKnowledgeBaseDocumentToApiSchema(updatedDocument),
+	})
+}
+
+func handleSearchKnowledgeBase(context interfaces.ContextWithSession) error {
+	payload := new(api_types.SearchKnowledgeBaseSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	if strings.TrimSpace(payload.Query) == "" {
+		return context.JSON(http.StatusBadRequest, "query is required")
+	}
+
+	limit := ai_service.KnowledgeBaseDefaultMatches
+	if payload.Limit != nil {
+		if *payload.Limit < 1 || *payload.Limit > maxSearchResults {
+			return context.JSON(http.StatusBadRequest, "limit must be between 1 and 20")
+		}
+		limit = *payload.Limit
+	}
+
+	orgUuid, _ := uuid.Parse(context.Session.User.OrganizationId)
+	aiService, err := organizationAiService(context, orgUuid)
+	if err != nil {
+		return respondWithAiServiceError(context, err)
+	}
+
+	matches, err := aiService.SearchKnowledgeBase(context.Request().Context(), orgUuid, payload.Query, limit)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.SearchKnowledgeBaseResponseSchema{
+		Results: ai_service.KnowledgeBaseCitations(matches),
+	})
+}
@@ -0,0 +1,87 @@
+package media_controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	controller "github.com/wapikit/wapikit/api/controllers"
+	"github.com/wapikit/wapikit/interfaces"
+	"github.com/wapikit/wapikit/services/media_service"
+
+	"github.com/go-jet/jet/qrm"
+)
+
+type MediaController struct {
+	controller.BaseController `json:"-,inline"`
+}
+
+func NewMediaController() *MediaController {
+	return &MediaController{
+		BaseController: controller.BaseController{
+			Name:        "Media Controller",
+			RestApiPath: "/api/media",
+			Routes: []interfaces.Route{
+				{
+					// * the signature in the url authorizes the request, so the media can be used in img and video tags
+					Path:                    "/api/media/:id",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithoutSession(handleGetSignedMedia),
+					IsAuthorizationRequired: false,
+					MetaData: interfaces.RouteMetaData{
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    600,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func handleGetSignedMedia(context interfaces.ContextWithoutSession) error {
+	mediaFileId := context.Param("id")
+	mediaFileUuid, err := uuid.Parse(mediaFileId)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "invalid media id")
+	}
+
+	isThumbnail := context.QueryParam("thumbnail") == "true"
+
+	err = context.App.MediaService.VerifySignedUrl(
+		mediaFileId,
+		isThumbnail,
+		context.QueryParam("expires"),
+		context.QueryParam("signature"),
+	)
+	if err != nil {
+		return context.JSON(http.StatusForbidden, err.Error())
+	}
+
+	mediaFile, err := context.App.MediaService.GetMediaFileById(context.Request().Context(), mediaFileUuid)
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() {
+			return context.JSON(http.StatusNotFound, "media not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	content, mimeType, err := context.App.MediaService.OpenMediaFile(context.Request().Context(), *mediaFile, isThumbnail)
+	if err != nil {
+		if errors.Is(err, media_service.ErrMediaObjectNotFound) {
+			return context.JSON(http.StatusNotFound, "media not found")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+	defer content.Close()
+
+	// * the stored objects are content addressed and never change, so the clients can cache them for the lifetime of the url
+	context.Response().Header().Set("Cache-Control", "private, max-age=3600")
+	if mediaFile.FileName != nil && *mediaFile.FileName != "" {
+		context.Response().Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", *mediaFile.FileName))
+	}
+
+	return context.Stream(http.StatusOK, mimeType, content)
+}
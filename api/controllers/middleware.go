@@ -11,8 +11,9 @@ import (
 )
 
 func mountServices(app *interfaces.App, org *model.Organization) {
-	if org.IsAiEnabled && !app.Constants.IsCloudEdition {
-		aiService := ai_service.NewAiService(&app.Logger, app.Redis, app.Db, org.AiApiKey, api_types.AiModelEnum(*org.AiModel))
+	if org.IsAiEnabled && org.AiModel != nil && !app.Constants.IsCloudEdition {
+		aiService := ai_service.NewAiService(&app.Logger, app.Redis, app.Db, org.AiApiKey, api_types.AiModelEnum(*org.AiModel), app.AiServiceConfig)
+		aiService.AllowCrossProviderFallback = org.IsAiCrossProviderFallbackEnabled
 		app.AiService = aiService
 	}
 }
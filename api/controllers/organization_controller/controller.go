@@ -1,18 +1,23 @@
 package organization_controller
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
 	gonanoid "github.com/matoous/go-nanoid/v2"
-	wapi "github.com/wapikit/wapi.go/pkg/client"
+	"github.com/wapikit/wapi.go/manager"
 	"github.com/wapikit/wapikit/api/api_types"
 	controller "github.com/wapikit/wapikit/api/controllers"
 	"github.com/wapikit/wapikit/interfaces"
+	"github.com/wapikit/wapikit/services/ai_service"
+	"github.com/wapikit/wapikit/services/business_account_service"
+	"github.com/wapikit/wapikit/services/media_service"
+	"github.com/wapikit/wapikit/services/template_service"
 	"github.com/wapikit/wapikit/utils"
 
 	"github.com/go-jet/jet/qrm"
@@ -71,6 +76,170 @@ func NewOrganizationController() *OrganizationController {
 						},
 					},
 				},
+				{
+					Path:                    "/api/organization/csat-survey",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetCsatSurveyConfiguration),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RequiredPermission:  []api_types.RolePermissionEnum{},
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/csat-survey",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleUpdateCsatSurveyConfiguration),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Owner,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateOrganization,
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/message-classification",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetMessageClassificationConfiguration),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RequiredPermission:  []api_types.RolePermissionEnum{},
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/message-classification",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleUpdateMessageClassificationConfiguration),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Owner,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateOrganization,
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/ai-pii-redaction",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetAiPiiRedactionConfiguration),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RequiredPermission:  []api_types.RolePermissionEnum{},
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/ai-pii-redaction",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleUpdateAiPiiRedactionConfiguration),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Owner,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateOrganization,
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/ai-pii-redaction/preview",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handlePreviewAiPiiRedaction),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RequiredPermission:  []api_types.RolePermissionEnum{},
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    30,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/ai-budget",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetAiBudget),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RequiredPermission:  []api_types.RolePermissionEnum{},
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/ai-budget",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleUpdateAiBudget),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Owner,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateOrganization,
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/ai-auto-responder",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetAiAutoResponderConfiguration),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RequiredPermission:  []api_types.RolePermissionEnum{},
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/ai-auto-responder",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleUpdateAiAutoResponderConfiguration),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Owner,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateOrganization,
+						},
+					},
+				},
 				{
 					Path:                    "/api/organization/:id",
 					Method:                  http.MethodPost,
@@ -317,6 +486,116 @@ func NewOrganizationController() *OrganizationController {
 						},
 					},
 				},
+				{
+					Path:                    "/api/organization/templates",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleCreateMessageTemplate),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    30,
+							WindowTimeInMs: 1000 * 60 * 60, // 1 hour,
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.CreateMessageTemplates,
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/templates/sync",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleSyncMessageTemplates),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: 1000 * 60 * 60, // 1 hour,
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.GetMessageTemplates,
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/templates/media",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleUploadTemplateHeaderMedia),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    30,
+							WindowTimeInMs: 1000 * 60 * 60, // 1 hour,
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.CreateMessageTemplates,
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/templates/:id",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleUpdateMessageTemplateById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    30,
+							WindowTimeInMs: 1000 * 60, // 1 minute,
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateMessageTemplates,
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/templates/:id",
+					Method:                  http.MethodDelete,
+					Handler:                 interfaces.HandlerWithSession(handleDeleteMessageTemplateById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    30,
+							WindowTimeInMs: 1000 * 60, // 1 minute,
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.DeleteMessageTemplates,
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/phone-number-health",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(handleGetPhoneNumberHealthConfiguration),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Member,
+						RequiredPermission:  []api_types.RolePermissionEnum{},
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/phone-number-health",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleUpdatePhoneNumberHealthConfiguration),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Owner,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+						RequiredPermission: []api_types.RolePermissionEnum{
+							api_types.UpdateOrganization,
+						},
+					},
+				},
 				{
 					Path:                    "/api/organization/phone-numbers",
 					Method:                  http.MethodGet,
@@ -350,9 +629,22 @@ func NewOrganizationController() *OrganizationController {
 					},
 				},
 				{
-					Path:                    "/api/organization/whatsappBusinessAccount",
+					Path:                    "/api/organization/phone-numbers/:id/default",
 					Method:                  http.MethodPost,
-					Handler:                 interfaces.HandlerWithSession(handleUpdateWhatsappBusinessAccountDetails),
+					Handler:                 interfaces.HandlerWithSession(handleSetDefaultPhoneNumber),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Owner,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/business-accounts",
+					Method:                  http.MethodGet,
+					Handler:                 interfaces.HandlerWithSession(getBusinessAccounts),
 					IsAuthorizationRequired: true,
 					MetaData: interfaces.RouteMetaData{
 						PermissionRoleLevel: api_types.Owner,
@@ -362,30 +654,82 @@ func NewOrganizationController() *OrganizationController {
 						},
 					},
 				},
-			},
-		},
-	}
-}
-
-func createNewOrganization(context interfaces.ContextWithSession) error {
-	payload := new(api_types.NewOrganizationSchema)
-	if err := context.Bind(payload); err != nil {
-		return context.JSON(http.StatusBadRequest, err.Error())
-	}
-
-	var newOrg model.Organization
-	var member model.OrganizationMember
-
-	tx, err := context.App.Db.BeginTx(context.Request().Context(), nil)
-	if err != nil {
-		return context.JSON(http.StatusInternalServerError, err.Error())
-	}
-	defer tx.Rollback()
-
-	// 1. Insert Organization
-	err = table.Organization.INSERT(table.Organization.MutableColumns).
-		MODEL(model.Organization{
-			CreatedAt:   time.Now(),
+				{
+					Path:                    "/api/organization/business-accounts",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleCreateBusinessAccount),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Owner,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/business-accounts/:id",
+					Method:                  http.MethodPut,
+					Handler:                 interfaces.HandlerWithSession(handleUpdateBusinessAccountById),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Owner,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/business-accounts/:id/sync",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleSyncBusinessAccountPhoneNumbers),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Owner,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    10,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+					},
+				},
+				{
+					Path:                    "/api/organization/whatsappBusinessAccount",
+					Method:                  http.MethodPost,
+					Handler:                 interfaces.HandlerWithSession(handleUpdateWhatsappBusinessAccountDetails),
+					IsAuthorizationRequired: true,
+					MetaData: interfaces.RouteMetaData{
+						PermissionRoleLevel: api_types.Owner,
+						RateLimitConfig: interfaces.RateLimitConfig{
+							MaxRequests:    60,
+							WindowTimeInMs: 1000 * 60, // 1 minute
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func createNewOrganization(context interfaces.ContextWithSession) error {
+	payload := new(api_types.NewOrganizationSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	var newOrg model.Organization
+	var member model.OrganizationMember
+
+	tx, err := context.App.Db.BeginTx(context.Request().Context(), nil)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+	defer tx.Rollback()
+
+	// 1. Insert Organization
+	err = table.Organization.INSERT(table.Organization.MutableColumns).
+		MODEL(model.Organization{
+			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
 			Name:        payload.Name,
 			Description: payload.Description,
@@ -578,8 +922,10 @@ func getOrganizations(context interfaces.ContextWithSession) error {
 		if org.IsAiEnabled {
 			model := api_types.AiModelEnum(*org.AiModel)
 			organization.AiConfiguration = &api_types.AiConfigurationDetailsSchema{
-				IsEnabled: &org.IsAiEnabled,
-				Model:     model,
+				IsEnabled:                       &org.IsAiEnabled,
+				Model:                           model,
+				StoreConversationSummaryOnClose: &org.IsConversationSummaryOnCloseEnabled,
+				AllowCrossProviderFallback:      &org.IsAiCrossProviderFallbackEnabled,
 			}
 		}
 
@@ -666,8 +1012,10 @@ func getOrganizationById(context interfaces.ContextWithSession) error {
 	if dest.IsAiEnabled {
 		model := api_types.AiModelEnum(*dest.AiModel)
 		orgToReturn.AiConfiguration = &api_types.AiConfigurationDetailsSchema{
-			IsEnabled: &dest.IsAiEnabled,
-			Model:     model,
+			IsEnabled:                       &dest.IsAiEnabled,
+			Model:                           model,
+			StoreConversationSummaryOnClose: &dest.IsConversationSummaryOnCloseEnabled,
+			AllowCrossProviderFallback:      &dest.IsAiCrossProviderFallbackEnabled,
 		}
 	}
 
@@ -758,6 +1106,12 @@ func updateOrganizationById(context interfaces.ContextWithSession) error {
 		orgUpdates.IsAiEnabled = *payload.AiConfiguration.IsEnabled
 		orgUpdates.AiModel = (*model.AiModelEnum)(&payload.AiConfiguration.Model)
 		orgUpdates.AiApiKey = payload.AiConfiguration.ApiKey
+		if payload.AiConfiguration.StoreConversationSummaryOnClose != nil {
+			orgUpdates.IsConversationSummaryOnCloseEnabled = *payload.AiConfiguration.StoreConversationSummaryOnClose
+		}
+		if payload.AiConfiguration.AllowCrossProviderFallback != nil {
+			orgUpdates.IsAiCrossProviderFallbackEnabled = *payload.AiConfiguration.AllowCrossProviderFallback
+		}
 	}
 
 	var updatedOrg model.Organization
@@ -1784,7 +2138,6 @@ func acceptOrganizationInvite(context interfaces.ContextWithSession) error {
 	})
 }
 
-// ! cache the response here and return the cached response, revalidate the cache for this endpoint on template update webhook
 func getMessageTemplateById(context interfaces.ContextWithSession) error {
 	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
 
@@ -1798,179 +2151,692 @@ func getMessageTemplateById(context interfaces.ContextWithSession) error {
 		return context.JSON(http.StatusBadRequest, "Invalid template id")
 	}
 
-	businessAccountDetails := SELECT(table.WhatsappBusinessAccount.AllColumns).
-		FROM(table.WhatsappBusinessAccount).
-		WHERE(table.WhatsappBusinessAccount.OrganizationId.EQ(UUID(orgUuid))).
-		LIMIT(1)
+	template, err := context.App.TemplateService.GetTemplate(context.Request().Context(), orgUuid, templateId)
 
-	var businessAccount model.WhatsappBusinessAccount
+	if err != nil {
+		if errors.Is(err, template_service.ErrTemplateNotFound) {
+			return context.JSON(http.StatusNotFound, err.Error())
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.GetTemplateByIdResponseSchema{
+		Template: template_service.ParseTemplateToApiSchema(*template),
+	})
+}
+
+// * the templates are read from the local table, the template webhooks and the sync scheduler keep it current
+func getAllMessageTemplates(context interfaces.ContextWithSession) error {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
 
-	err = businessAccountDetails.QueryContext(context.Request().Context(), context.App.Db, &businessAccount)
+	templates, err := context.App.TemplateService.GetTemplates(context.Request().Context(), orgUuid)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	// * the templates of an organization are synced the first time they are listed
+	if len(templates) == 0 {
+		syncedCount, err := context.App.TemplateService.SyncOrganizationTemplates(context.Request().Context(), orgUuid)
+		if err != nil {
+			return context.JSON(templateServiceErrorStatus(err), err.Error())
+		}
+
+		if syncedCount > 0 {
+			templates, err = context.App.TemplateService.GetTemplates(context.Request().Context(), orgUuid)
+			if err != nil {
+				return context.JSON(http.StatusInternalServerError, err.Error())
+			}
+		}
+	}
+
+	templatesToReturn := api_types.GetAllMessageTemplatesResponseSchema{}
+	for _, template := range templates {
+		templatesToReturn = append(templatesToReturn, template_service.ParseTemplateToApiSchema(template))
+	}
+
+	return context.JSON(http.StatusOK, templatesToReturn)
+}
+
+func handleCreateMessageTemplate(context interfaces.ContextWithSession) error {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	payload := new(api_types.CreateMessageTemplateSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	payload.Name = strings.TrimSpace(payload.Name)
+	payload.Language = strings.TrimSpace(payload.Language)
+	if payload.Name == "" || payload.Language == "" {
+		return context.JSON(http.StatusBadRequest, "Name and language are required")
+	}
+
+	// * the rules of meta are checked before submitting, a rejected template can only be edited a limited number of times
+	issues := ai_service.ValidateTemplateComponents(payload.Name, payload.Category, payload.Components)
+	if ai_service.HasTemplateRuleErrors(issues) {
+		return context.JSON(http.StatusBadRequest, templateRuleIssuesMessage(issues))
+	}
+
+	businessAccountId := ""
+	if payload.BusinessAccountId != nil {
+		businessAccountId = *payload.BusinessAccountId
+	}
+
+	businessAccount, err := context.App.TemplateService.ResolveBusinessAccount(context.Request().Context(), orgUuid, businessAccountId)
+	if err != nil {
+		if errors.Is(err, business_account_service.ErrBusinessAccountNotFound) {
+			return context.JSON(http.StatusBadRequest, "Please update your business account details in the settings first.")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	allowCategoryChange := false
+	if payload.AllowCategoryChange != nil {
+		allowCategoryChange = *payload.AllowCategoryChange
+	}
+
+	template, err := context.App.TemplateService.CreateTemplate(context.Request().Context(), template_service.CreateTemplateParams{
+		OrganizationId:      orgUuid,
+		BusinessAccount:     *businessAccount,
+		Name:                payload.Name,
+		Language:            payload.Language,
+		Category:            payload.Category,
+		Components:          payload.Components,
+		AllowCategoryChange: allowCategoryChange,
+	})
+
+	if err != nil {
+		return context.JSON(templateServiceErrorStatus(err), err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.CreateMessageTemplateResponseSchema{
+		Template: template_service.ParseTemplateToApiSchema(*template),
+	})
+}
+
+func handleUpdateMessageTemplateById(context interfaces.ContextWithSession) error {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	templateId := context.Param("id")
+
+	if templateId == "" {
+		return context.JSON(http.StatusBadRequest, "Invalid template id")
+	}
+
+	payload := new(api_types.UpdateMessageTemplateSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	existingTemplate, err := context.App.TemplateService.GetTemplate(context.Request().Context(), orgUuid, templateId)
+	if err != nil {
+		if errors.Is(err, template_service.ErrTemplateNotFound) {
+			return context.JSON(http.StatusNotFound, err.Error())
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	category := template_service.ParseTemplateToApiSchema(*existingTemplate).Category
+	if payload.Category != nil {
+		category = *payload.Category
+	}
+
+	issues := ai_service.ValidateTemplateComponents(existingTemplate.Name, category, payload.Components)
+	if ai_service.HasTemplateRuleErrors(issues) {
+		return context.JSON(http.StatusBadRequest, templateRuleIssuesMessage(issues))
+	}
+
+	template, err := context.App.TemplateService.UpdateTemplate(context.Request().Context(), orgUuid, templateId, payload.Category, payload.Components)
+
+	if err != nil {
+		return context.JSON(templateServiceErrorStatus(err), err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.UpdateMessageTemplateResponseSchema{
+		Template: template_service.ParseTemplateToApiSchema(*template),
+	})
+}
+
+func handleDeleteMessageTemplateById(context interfaces.ContextWithSession) error {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	templateId := context.Param("id")
+
+	if templateId == "" {
+		return context.JSON(http.StatusBadRequest, "Invalid template id")
+	}
+
+	err = context.App.TemplateService.DeleteTemplate(context.Request().Context(), orgUuid, templateId)
+
+	if err != nil {
+		return context.JSON(templateServiceErrorStatus(err), err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.DeleteMessageTemplateResponseSchema{
+		IsDeleted: true,
+	})
+}
+
+func handleSyncMessageTemplates(context interfaces.ContextWithSession) error {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	syncedCount, err := context.App.TemplateService.SyncOrganizationTemplates(context.Request().Context(), orgUuid)
+
+	if err != nil {
+		return context.JSON(templateServiceErrorStatus(err), err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.SyncMessageTemplatesResponseSchema{
+		SyncedCount: syncedCount,
+	})
+}
+
+func handleUploadTemplateHeaderMedia(context interfaces.ContextWithSession) error {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	if err := context.Request().ParseMultipartForm(32 << 20); err != nil {
+		return context.JSON(http.StatusBadRequest, "failed to parse multipart form")
+	}
+
+	file, fileHeader, err := context.Request().FormFile("file")
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, "file is required")
+	}
+	defer file.Close()
+
+	businessAccount, err := context.App.TemplateService.ResolveBusinessAccount(context.Request().Context(), orgUuid, strings.TrimSpace(context.Request().FormValue("businessAccountId")))
+	if err != nil {
+		if errors.Is(err, business_account_service.ErrBusinessAccountNotFound) {
+			return context.JSON(http.StatusBadRequest, "Please update your business account details in the settings first.")
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	filename := filepath.Base(fileHeader.Filename)
+
+	mediaFile, err := context.App.MediaService.StoreMedia(context.Request().Context(), media_service.StoreMediaParams{
+		OrganizationId: orgUuid,
+		Content:        file,
+		FileName:       &filename,
+	})
+	if err != nil {
+		if errors.Is(err, media_service.ErrMediaTooLarge) || errors.Is(err, media_service.ErrUnsupportedMediaType) || errors.Is(err, media_service.ErrEmptyMedia) {
+			return context.JSON(http.StatusBadRequest, err.Error())
+		}
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	handle, err := context.App.TemplateService.UploadHeaderMedia(context.Request().Context(), *businessAccount, *mediaFile)
+	if err != nil {
+		if errors.Is(err, template_service.ErrUnsupportedHeaderMedia) {
+			return context.JSON(http.StatusBadRequest, err.Error())
+		}
+		return context.JSON(templateServiceErrorStatus(err), err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.UploadTemplateHeaderMediaResponseSchema{
+		Handle:      handle,
+		MediaFileId: mediaFile.UniqueId.String(),
+	})
+}
+
+// templateServiceErrorStatus maps the errors of the template service to a status, whatsapp answers a 4xx for a template it refuses, e.g. a name already in use
+func templateServiceErrorStatus(err error) int {
+	var graphApiError *template_service.GraphApiError
+	switch {
+	case errors.Is(err, template_service.ErrTemplateNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, template_service.ErrTemplateInUse):
+		return http.StatusBadRequest
+	case errors.As(err, &graphApiError) && graphApiError.StatusCode < http.StatusInternalServerError:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func templateRuleIssuesMessage(issues []ai_service.TemplateRuleIssue) string {
+	messages := []string{}
+	for _, issue := range issues {
+		if issue.Severity == ai_service.TemplateRuleIssueSeverityError {
+			messages = append(messages, strings.ToLower(string(issue.Component))+" "+issue.Message)
+		}
+	}
+	return strings.Join(messages, ", ")
+}
+
+func getAllPhoneNumbers(context interfaces.ContextWithSession) error {
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	businessAccounts, err := context.App.BusinessAccountService.ListBusinessAccounts(context.Request().Context(), orgUuid)
 
 	if err != nil {
 		return context.JSON(http.StatusInternalServerError, "Error fetching business account details")
 	}
 
-	if businessAccount.UniqueId.String() == "" || businessAccount.AccessToken == "" || businessAccount.AccountId == "" {
-		return context.JSON(http.StatusBadRequest, "Please update your business account details in the settings first.")
+	phoneNumbers := []manager.WhatsappBusinessAccountPhoneNumber{}
+
+	for _, businessAccount := range businessAccounts {
+		if businessAccount.AccessToken == "" || businessAccount.AccountId == "" {
+			continue
+		}
+
+		phoneNumbersResponse, err := context.App.BusinessAccountService.NewClient(businessAccount).Business.PhoneNumber.FetchAll(true)
+
+		if err != nil {
+			return context.JSON(http.StatusInternalServerError, err.Error())
+		}
+
+		phoneNumbers = append(phoneNumbers, phoneNumbersResponse.Data...)
+	}
+
+	return context.JSON(http.StatusOK, phoneNumbers)
+}
+
+func getPhoneNumberById(context interfaces.ContextWithSession) error {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	phoneNumberId := context.Param("id")
+
+	if phoneNumberId == "" {
+		return context.JSON(http.StatusBadRequest, "Invalid phone number id")
+	}
+
+	phoneNumber, err := context.App.BusinessAccountService.GetPhoneNumber(context.Request().Context(), orgUuid, phoneNumberId)
+
+	if err != nil {
+		if errors.Is(err, business_account_service.ErrPhoneNumberNotFound) {
+			return context.JSON(http.StatusNotFound, err.Error())
+		}
+		return context.JSON(http.StatusInternalServerError, "Error fetching business account details")
+	}
+
+	responseToReturn := api_types.GetPhoneNumberByIdResponseSchema{
+		Id: phoneNumber.PhoneNumberId,
+	}
+
+	whatsAppPhoneNumber, err := context.App.BusinessAccountService.FetchWhatsAppPhoneNumber(context.Request().Context(), phoneNumber.WhatsappBusinessAccount, phoneNumberId)
+
+	if err != nil {
+		// * the stored details and health are returned when whatsapp can not be reached
+		context.App.Logger.Error("error fetching the phone number from whatsapp", "phone_number_id", phoneNumberId, "error", err.Error())
+		if phoneNumber.DisplayPhoneNumber != nil {
+			responseToReturn.DisplayPhoneNumber = *phoneNumber.DisplayPhoneNumber
+		}
+		if phoneNumber.VerifiedName != nil {
+			responseToReturn.VerifiedName = *phoneNumber.VerifiedName
+		}
+		responseToReturn.QualityRating = phoneNumber.QualityRating.String()
+	} else {
+		responseToReturn.DisplayPhoneNumber = whatsAppPhoneNumber.DisplayPhoneNumber
+		responseToReturn.VerifiedName = whatsAppPhoneNumber.VerifiedName
+		responseToReturn.QualityRating = whatsAppPhoneNumber.QualityRating
+		responseToReturn.PlatformType = whatsAppPhoneNumber.PlatformType
+		if whatsAppPhoneNumber.CodeVerificationStatus != "" {
+			responseToReturn.CodeVerificationStatus.Status = &whatsAppPhoneNumber.CodeVerificationStatus
+		}
+
+		// * the health of a phone number is otherwise only stored on the webhooks, the first fetch stores its baseline
+		if phoneNumber.HealthUpdatedAt == nil {
+			health := whatsAppPhoneNumber.Health()
+			_, err := context.App.BusinessAccountService.UpdatePhoneNumberHealth(context.Request().Context(), *phoneNumber, health)
+			if err != nil {
+				context.App.Logger.Error("error storing the health of the phone number", "phone_number_id", phoneNumberId, "error", err.Error())
+			} else {
+				now := time.Now()
+				phoneNumber.HealthUpdatedAt = &now
+				if health.QualityRating != nil {
+					phoneNumber.QualityRating = *health.QualityRating
+				}
+				if health.MessagingLimitTier != nil {
+					phoneNumber.MessagingLimitTier = *health.MessagingLimitTier
+				}
+				if health.NameStatus != nil {
+					phoneNumber.NameStatus = *health.NameStatus
+				}
+			}
+		}
+	}
+
+	responseToReturn.Health = &api_types.PhoneNumberHealthSchema{
+		QualityRating:      api_types.PhoneNumberQualityRatingEnum(phoneNumber.QualityRating.String()),
+		MessagingLimitTier: api_types.PhoneNumberMessagingLimitTierEnum(phoneNumber.MessagingLimitTier.String()),
+		NameStatus:         api_types.PhoneNumberNameStatusEnum(phoneNumber.NameStatus.String()),
+		UpdatedAt:          phoneNumber.HealthUpdatedAt,
+	}
+
+	healthEvents, err := context.App.BusinessAccountService.ListPhoneNumberHealthEvents(context.Request().Context(), phoneNumber.WhatsappBusinessAccountPhoneNumber)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	healthHistory := []api_types.PhoneNumberHealthEventSchema{}
+	for _, healthEvent := range healthEvents {
+		healthHistory = append(healthHistory, api_types.PhoneNumberHealthEventSchema{
+			UniqueId:       healthEvent.UniqueId.String(),
+			CreatedAt:      healthEvent.CreatedAt,
+			Type:           api_types.PhoneNumberHealthEventTypeEnum(healthEvent.Type.String()),
+			PreviousValue:  healthEvent.PreviousValue,
+			NewValue:       healthEvent.NewValue,
+			Description:    healthEvent.Description,
+			IsDowngrade:    healthEvent.IsDowngrade,
+			IsAccountEvent: healthEvent.PhoneNumberId == nil,
+		})
+	}
+	responseToReturn.HealthHistory = &healthHistory
+
+	return context.JSON(http.StatusOK, responseToReturn)
+}
+
+// syncBusinessAccountPhoneNumbers registers the phone numbers of the business account, a failure is only logged
+// because the phone numbers are registered on the first webhook event received on them as well
+func syncBusinessAccountPhoneNumbers(context interfaces.ContextWithSession, businessAccount model.WhatsappBusinessAccount) []business_account_service.PhoneNumberWithBusinessAccount {
+	phoneNumbers, err := context.App.BusinessAccountService.SyncPhoneNumbers(context.Request().Context(), businessAccount)
+	if err != nil {
+		context.App.Logger.Error("error syncing phone numbers of the business account", "business_account_id", businessAccount.AccountId, "error", err.Error())
+		return []business_account_service.PhoneNumberWithBusinessAccount{}
 	}
+	return phoneNumbers
+}
 
-	// initialize a wapi client and fetch the templates
+func parseBusinessPhoneNumberToApiSchema(phoneNumber business_account_service.PhoneNumberWithBusinessAccount) api_types.BusinessPhoneNumberSchema {
+	return api_types.BusinessPhoneNumberSchema{
+		PhoneNumberId:      phoneNumber.PhoneNumberId,
+		DisplayPhoneNumber: phoneNumber.DisplayPhoneNumber,
+		VerifiedName:       phoneNumber.VerifiedName,
+		IsDefault:          phoneNumber.IsDefault,
+		BusinessAccountId:  phoneNumber.WhatsappBusinessAccount.AccountId,
+	}
+}
 
-	wapiClient := wapi.New(&wapi.ClientConfig{
+func parseBusinessAccountToApiSchema(businessAccount model.WhatsappBusinessAccount, phoneNumbers []business_account_service.PhoneNumberWithBusinessAccount) api_types.BusinessAccountSchema {
+	businessAccountToReturn := api_types.BusinessAccountSchema{
+		UniqueId:          businessAccount.UniqueId.String(),
+		Name:              businessAccount.Name,
 		BusinessAccountId: businessAccount.AccountId,
-		ApiAccessToken:    businessAccount.AccessToken,
 		WebhookSecret:     businessAccount.WebhookSecret,
+		Status:            businessAccount.Status.String(),
+		CreatedAt:         businessAccount.CreatedAt,
+		PhoneNumbers:      []api_types.BusinessPhoneNumberSchema{},
+	}
+
+	for _, phoneNumber := range phoneNumbers {
+		if phoneNumber.WhatsappBusinessAccountId != businessAccount.UniqueId {
+			continue
+		}
+		phoneNumber.WhatsappBusinessAccount = businessAccount
+		businessAccountToReturn.PhoneNumbers = append(businessAccountToReturn.PhoneNumbers, parseBusinessPhoneNumberToApiSchema(phoneNumber))
+	}
+
+	return businessAccountToReturn
+}
+
+func fetchOrganizationBusinessAccount(context interfaces.ContextWithSession, orgUuid uuid.UUID) (*model.WhatsappBusinessAccount, error) {
+	businessAccountUuid, err := uuid.Parse(context.Param("id"))
+	if err != nil {
+		return nil, err
+	}
+
+	var businessAccount model.WhatsappBusinessAccount
+	err = SELECT(table.WhatsappBusinessAccount.AllColumns).
+		FROM(table.WhatsappBusinessAccount).
+		WHERE(
+			table.WhatsappBusinessAccount.UniqueId.EQ(UUID(businessAccountUuid)).
+				AND(table.WhatsappBusinessAccount.OrganizationId.EQ(UUID(orgUuid))),
+		).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &businessAccount)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &businessAccount, nil
+}
+
+func getBusinessAccounts(context interfaces.ContextWithSession) error {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	businessAccounts, err := context.App.BusinessAccountService.ListBusinessAccounts(context.Request().Context(), orgUuid)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	phoneNumbers, err := context.App.BusinessAccountService.ListPhoneNumbers(context.Request().Context(), orgUuid)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	responseToReturn := api_types.GetBusinessAccountsResponseSchema{
+		BusinessAccounts: []api_types.BusinessAccountSchema{},
+	}
+
+	for _, businessAccount := range businessAccounts {
+		responseToReturn.BusinessAccounts = append(responseToReturn.BusinessAccounts, parseBusinessAccountToApiSchema(businessAccount, phoneNumbers))
+	}
+
+	return context.JSON(http.StatusOK, responseToReturn)
+}
+
+func handleCreateBusinessAccount(context interfaces.ContextWithSession) error {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	payload := new(api_types.CreateBusinessAccountSchema)
+
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	payload.BusinessAccountId = strings.TrimSpace(payload.BusinessAccountId)
+	payload.AccessToken = strings.TrimSpace(payload.AccessToken)
+
+	if payload.BusinessAccountId == "" || payload.AccessToken == "" {
+		return context.JSON(http.StatusBadRequest, "business account id and access token are required")
+	}
+
+	var existingBusinessAccount model.WhatsappBusinessAccount
+	err = SELECT(table.WhatsappBusinessAccount.AllColumns).
+		FROM(table.WhatsappBusinessAccount).
+		WHERE(table.WhatsappBusinessAccount.AccountId.EQ(String(payload.BusinessAccountId))).
+		LIMIT(1).
+		QueryContext(context.Request().Context(), context.App.Db, &existingBusinessAccount)
+
+	if err == nil {
+		return context.JSON(http.StatusBadRequest, "Business account is already connected")
+	}
+
+	if err.Error() != qrm.ErrNoRows.Error() {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	// * every business account has its own webhook secret, the webhook events are routed by the business account id
+	webhookSecret, err := context.App.EncryptionService.EncryptData(utils.WebhookSecretData{
+		WhatsappBusinessAccountId: payload.BusinessAccountId,
+		OrganizationId:            orgUuid.String(),
 	})
 
-	templateResponse, err := wapiClient.Business.Template.Fetch(templateId)
+	if err != nil {
+		context.App.Logger.Error("Error encrypting webhook secret", err.Error(), nil)
+		return context.JSON(http.StatusInternalServerError, "Something went wrong")
+	}
+
+	var businessAccount model.WhatsappBusinessAccount
+	err = table.WhatsappBusinessAccount.
+		INSERT(table.WhatsappBusinessAccount.MutableColumns).
+		MODEL(model.WhatsappBusinessAccount{
+			OrganizationId: orgUuid,
+			Name:           payload.Name,
+			AccountId:      payload.BusinessAccountId,
+			AccessToken:    payload.AccessToken,
+			WebhookSecret:  webhookSecret,
+			CreatedAt:      time.Now(),
+			Status:         model.WhatsAppBusinessAccountVerificationStatus_Unverified,
+			UpdatedAt:      time.Now(),
+		}).
+		RETURNING(table.WhatsappBusinessAccount.AllColumns).
+		QueryContext(context.Request().Context(), context.App.Db, &businessAccount)
 
 	if err != nil {
 		return context.JSON(http.StatusInternalServerError, err.Error())
 	}
 
-	return context.JSON(http.StatusOK, templateResponse)
+	phoneNumbers := syncBusinessAccountPhoneNumbers(context, businessAccount)
+
+	return context.JSON(http.StatusOK, api_types.CreateBusinessAccountResponseSchema{
+		BusinessAccount: parseBusinessAccountToApiSchema(businessAccount, phoneNumbers),
+	})
 }
 
-func getAllMessageTemplates(context interfaces.ContextWithSession) error {
+func handleUpdateBusinessAccountById(context interfaces.ContextWithSession) error {
 	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
 
 	if err != nil {
 		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
 	}
 
-	businessAccountDetails := SELECT(table.WhatsappBusinessAccount.AllColumns).
-		FROM(table.WhatsappBusinessAccount).
-		WHERE(table.WhatsappBusinessAccount.OrganizationId.EQ(UUID(orgUuid))).
-		LIMIT(1)
-
-	var businessAccount model.WhatsappBusinessAccount
+	payload := new(api_types.UpdateBusinessAccountSchema)
 
-	err = businessAccountDetails.QueryContext(context.Request().Context(), context.App.Db, &businessAccount)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
 
+	businessAccount, err := fetchOrganizationBusinessAccount(context, orgUuid)
 	if err != nil {
 		if err.Error() == qrm.ErrNoRows.Error() {
-			return context.JSON(http.StatusOK, []api_types.MessageTemplateSchema{})
+			return context.JSON(http.StatusNotFound, "Business account not found")
 		}
-		return context.JSON(http.StatusInternalServerError, "Error fetching business account details")
+		return context.JSON(http.StatusBadRequest, "Invalid business account id")
 	}
 
-	if businessAccount.UniqueId.String() == "" || businessAccount.AccessToken == "" || businessAccount.AccountId == "" {
-		// return empty response
-		return context.JSON(http.StatusOK, []api_types.MessageTemplateSchema{})
+	if payload.Name != nil {
+		businessAccount.Name = payload.Name
 	}
 
-	// initialize a wapi client and fetch the templates
+	if payload.AccessToken != nil && strings.TrimSpace(*payload.AccessToken) != "" {
+		businessAccount.AccessToken = strings.TrimSpace(*payload.AccessToken)
+	}
 
-	wapiClient := wapi.New(&wapi.ClientConfig{
-		BusinessAccountId: businessAccount.AccountId,
-		ApiAccessToken:    businessAccount.AccessToken,
-		WebhookSecret:     businessAccount.WebhookSecret,
-	})
+	businessAccount.UpdatedAt = time.Now()
 
-	templateResponse, err := wapiClient.Business.Template.FetchAll()
+	var updatedBusinessAccount model.WhatsappBusinessAccount
+	err = table.WhatsappBusinessAccount.UPDATE(
+		table.WhatsappBusinessAccount.Name,
+		table.WhatsappBusinessAccount.AccessToken,
+		table.WhatsappBusinessAccount.UpdatedAt,
+	).
+		MODEL(*businessAccount).
+		WHERE(table.WhatsappBusinessAccount.UniqueId.EQ(UUID(businessAccount.UniqueId))).
+		RETURNING(table.WhatsappBusinessAccount.AllColumns).
+		QueryContext(context.Request().Context(), context.App.Db, &updatedBusinessAccount)
 
 	if err != nil {
 		return context.JSON(http.StatusInternalServerError, err.Error())
 	}
 
-	// return the templates to the user
+	phoneNumbers := syncBusinessAccountPhoneNumbers(context, updatedBusinessAccount)
 
-	return context.JSON(http.StatusOK, templateResponse.Data)
+	return context.JSON(http.StatusOK, api_types.UpdateBusinessAccountResponseSchema{
+		BusinessAccount: parseBusinessAccountToApiSchema(updatedBusinessAccount, phoneNumbers),
+	})
 }
 
-func getAllPhoneNumbers(context interfaces.ContextWithSession) error {
-
+func handleSyncBusinessAccountPhoneNumbers(context interfaces.ContextWithSession) error {
 	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
 
 	if err != nil {
 		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
 	}
 
-	businessAccountDetails := SELECT(table.WhatsappBusinessAccount.AllColumns).
-		FROM(table.WhatsappBusinessAccount).
-		WHERE(table.WhatsappBusinessAccount.OrganizationId.EQ(UUID(orgUuid))).
-		LIMIT(1)
-
-	var businessAccount model.WhatsappBusinessAccount
-
-	err = businessAccountDetails.QueryContext(context.Request().Context(), context.App.Db, &businessAccount)
-
+	businessAccount, err := fetchOrganizationBusinessAccount(context, orgUuid)
 	if err != nil {
 		if err.Error() == qrm.ErrNoRows.Error() {
-			return context.JSON(http.StatusOK, []api_types.PhoneNumberSchema{})
+			return context.JSON(http.StatusNotFound, "Business account not found")
 		}
-		return context.JSON(http.StatusInternalServerError, "Error fetching business account details")
+		return context.JSON(http.StatusBadRequest, "Invalid business account id")
 	}
 
-	if businessAccount.UniqueId.String() == "" || businessAccount.AccessToken == "" || businessAccount.AccountId == "" {
-		return context.JSON(http.StatusOK, []api_types.PhoneNumberSchema{})
+	phoneNumbers, err := context.App.BusinessAccountService.SyncPhoneNumbers(context.Request().Context(), *businessAccount)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
 	}
 
-	// initialize a wapi client and fetch the templates
-
-	wapiClient := wapi.New(&wapi.ClientConfig{
-		BusinessAccountId: businessAccount.AccountId,
-		ApiAccessToken:    businessAccount.AccessToken,
-		WebhookSecret:     businessAccount.WebhookSecret,
-	})
-
-	phoneNumbersResponse, err := wapiClient.Business.PhoneNumber.FetchAll(true)
-
-	fmt.Println("phoneNumbersResponse", phoneNumbersResponse)
+	responseToReturn := api_types.SyncBusinessAccountPhoneNumbersResponseSchema{
+		PhoneNumbers: []api_types.BusinessPhoneNumberSchema{},
+	}
 
-	if err != nil {
-		return context.JSON(http.StatusInternalServerError, err.Error())
+	for _, phoneNumber := range phoneNumbers {
+		responseToReturn.PhoneNumbers = append(responseToReturn.PhoneNumbers, parseBusinessPhoneNumberToApiSchema(phoneNumber))
 	}
 
-	return context.JSON(http.StatusOK, phoneNumbersResponse.Data)
+	return context.JSON(http.StatusOK, responseToReturn)
 }
 
-func getPhoneNumberById(context interfaces.ContextWithSession) error {
+func handleSetDefaultPhoneNumber(context interfaces.ContextWithSession) error {
 	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
 
 	if err != nil {
 		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
 	}
 
-	businessAccountDetails := SELECT(table.WhatsappBusinessAccount.AllColumns).
-		FROM(table.WhatsappBusinessAccount).
-		WHERE(table.WhatsappBusinessAccount.OrganizationId.EQ(UUID(orgUuid))).
-		LIMIT(1)
-
-	var businessAccount model.WhatsappBusinessAccount
-
-	err = businessAccountDetails.QueryContext(context.Request().Context(), context.App.Db, &businessAccount)
-
-	if err != nil {
-		return context.JSON(http.StatusInternalServerError, "Error fetching business account details")
-	}
-
-	if businessAccount.UniqueId.String() == "" || businessAccount.AccessToken == "" || businessAccount.AccountId == "" {
-		return context.JSON(http.StatusOK, nil)
-	}
-
 	phoneNumberId := context.Param("id")
 
 	if phoneNumberId == "" {
 		return context.JSON(http.StatusBadRequest, "Invalid phone number id")
 	}
 
-	// initialize a wapi client and fetch the templates
-
-	wapiClient := wapi.New(&wapi.ClientConfig{
-		BusinessAccountId: businessAccount.AccountId,
-		ApiAccessToken:    businessAccount.AccessToken,
-		WebhookSecret:     businessAccount.WebhookSecret,
-	})
-
-	phoneNumberResponse, err := wapiClient.Business.PhoneNumber.Fetch(phoneNumberId)
-
+	err = context.App.BusinessAccountService.SetDefaultPhoneNumber(context.Request().Context(), orgUuid, phoneNumberId)
 	if err != nil {
+		if errors.Is(err, business_account_service.ErrPhoneNumberNotFound) {
+			return context.JSON(http.StatusNotFound, err.Error())
+		}
 		return context.JSON(http.StatusInternalServerError, err.Error())
 	}
 
-	return context.JSON(http.StatusOK, phoneNumberResponse)
+	return context.JSON(http.StatusOK, api_types.SetDefaultPhoneNumberResponseSchema{
+		IsDefault: true,
+	})
 }
 
 func transferOwnershipOfOrganization(context interfaces.ContextWithSession) error {
@@ -2133,6 +2999,8 @@ func handleUpdateWhatsappBusinessAccountDetails(context interfaces.ContextWithSe
 				return context.JSON(http.StatusInternalServerError, err.Error())
 			}
 
+			syncBusinessAccountPhoneNumbers(context, businessAccount)
+
 			responseToReturn := api_types.WhatsAppBusinessAccountDetailsSchema{
 				BusinessAccountId: businessAccount.AccountId,
 				AccessToken:       businessAccount.AccessToken,
@@ -2185,6 +3053,8 @@ func handleUpdateWhatsappBusinessAccountDetails(context interfaces.ContextWithSe
 		return context.JSON(http.StatusInternalServerError, err.Error())
 	}
 
+	syncBusinessAccountPhoneNumbers(context, updatedBusinessAccount)
+
 	responseToReturn := api_types.WhatsAppBusinessAccountDetailsSchema{
 		BusinessAccountId: updatedBusinessAccount.AccountId,
 		AccessToken:       updatedBusinessAccount.AccessToken,
@@ -2221,15 +3091,265 @@ func getFullAiConfiguration(context interfaces.ContextWithSession) error {
 
 	responseToReturn := api_types.GetAiConfigurationResponseSchema{
 		AiConfiguration: api_types.FullAiConfiguration{
-			IsEnabled: organization.IsAiEnabled,
-			Model:     model,
-			ApiKey:    organization.AiApiKey,
+			IsEnabled:                       organization.IsAiEnabled,
+			Model:                           model,
+			ApiKey:                          organization.AiApiKey,
+			StoreConversationSummaryOnClose: &organization.IsConversationSummaryOnCloseEnabled,
+			AllowCrossProviderFallback:      &organization.IsAiCrossProviderFallbackEnabled,
 		},
 	}
 
 	return context.JSON(http.StatusOK, responseToReturn)
 }
 
+func handleGetCsatSurveyConfiguration(context interfaces.ContextWithSession) error {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	configuration, err := context.App.ConversationService.GetCsatSurveyConfiguration(context.Request().Context(), orgUuid)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.GetCsatSurveyConfigurationResponseSchema{
+		Configuration: context.App.ConversationService.ParseCsatSurveyConfigurationToApiSchema(*configuration),
+	})
+}
+
+func handleUpdateCsatSurveyConfiguration(context interfaces.ContextWithSession) error {
+	payload := new(api_types.UpdateCsatSurveyConfigurationSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	configuration, err := context.App.ConversationService.UpdateCsatSurveyConfiguration(context.Request().Context(), orgUuid, *payload)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.UpdateCsatSurveyConfigurationResponseSchema{
+		Configuration: context.App.ConversationService.ParseCsatSurveyConfigurationToApiSchema(*configuration),
+	})
+}
+
+func handleGetMessageClassificationConfiguration(context interfaces.ContextWithSession) error {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	configuration, err := context.App.ConversationService.GetMessageClassificationConfiguration(context.Request().Context(), orgUuid)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.GetMessageClassificationConfigurationResponseSchema{
+		Configuration: context.App.ConversationService.ParseMessageClassificationConfigurationToApiSchema(*configuration),
+	})
+}
+
+func handleUpdateMessageClassificationConfiguration(context interfaces.ContextWithSession) error {
+	payload := new(api_types.UpdateMessageClassificationConfigurationSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	configuration, err := context.App.ConversationService.UpdateMessageClassificationConfiguration(context.Request().Context(), orgUuid, *payload)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.UpdateMessageClassificationConfigurationResponseSchema{
+		Configuration: context.App.ConversationService.ParseMessageClassificationConfigurationToApiSchema(*configuration),
+	})
+}
+
+func handleGetPhoneNumberHealthConfiguration(context interfaces.ContextWithSession) error {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	configuration, err := context.App.BusinessAccountService.GetPhoneNumberHealthConfiguration(context.Request().Context(), orgUuid)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.GetPhoneNumberHealthConfigurationResponseSchema{
+		Configuration: business_account_service.ParsePhoneNumberHealthConfigurationToApiSchema(*configuration),
+	})
+}
+
+func handleUpdatePhoneNumberHealthConfiguration(context interfaces.ContextWithSession) error {
+	payload := new(api_types.UpdatePhoneNumberHealthConfigurationSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	configuration, err := context.App.BusinessAccountService.UpdatePhoneNumberHealthConfiguration(context.Request().Context(), orgUuid, *payload)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.UpdatePhoneNumberHealthConfigurationResponseSchema{
+		Configuration: business_account_service.ParsePhoneNumberHealthConfigurationToApiSchema(*configuration),
+	})
+}
+
+func handleGetAiAutoResponderConfiguration(context interfaces.ContextWithSession) error {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	configuration, err := context.App.ChatbotService.GetAiAutoResponderConfiguration(context.Request().Context(), orgUuid)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.GetAiAutoResponderConfigurationResponseSchema{
+		Configuration: context.App.ChatbotService.ParseAiAutoResponderConfigurationToApiSchema(*configuration),
+	})
+}
+
+func handleUpdateAiAutoResponderConfiguration(context interfaces.ContextWithSession) error {
+	payload := new(api_types.UpdateAiAutoResponderConfigurationSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	configuration, err := context.App.ChatbotService.UpdateAiAutoResponderConfiguration(context.Request().Context(), orgUuid, *payload)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.UpdateAiAutoResponderConfigurationResponseSchema{
+		Configuration: context.App.ChatbotService.ParseAiAutoResponderConfigurationToApiSchema(*configuration),
+	})
+}
+
+func handleGetAiPiiRedactionConfiguration(context interfaces.ContextWithSession) error {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	configuration, err := ai_service.GetAiPiiRedactionConfiguration(context.Request().Context(), context.App.Db, orgUuid)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.GetAiPiiRedactionConfigurationResponseSchema{
+		Configuration: ai_service.ParseAiPiiRedactionConfigurationToApiSchema(*configuration),
+	})
+}
+
+func handleUpdateAiPiiRedactionConfiguration(context interfaces.ContextWithSession) error {
+	payload := new(api_types.UpdateAiPiiRedactionConfigurationSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	configuration, err := ai_service.UpdateAiPiiRedactionConfiguration(context.Request().Context(), context.App.Db, orgUuid, *payload)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.UpdateAiPiiRedactionConfigurationResponseSchema{
+		Configuration: ai_service.ParseAiPiiRedactionConfigurationToApiSchema(*configuration),
+	})
+}
+
+func handlePreviewAiPiiRedaction(context interfaces.ContextWithSession) error {
+	payload := new(api_types.PreviewAiPiiRedactionSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	redactedText, err := ai_service.PreviewAiPiiRedaction(context.Request().Context(), context.App.Db, orgUuid, *payload)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.PreviewAiPiiRedactionResponseSchema{
+		RedactedText: redactedText,
+	})
+}
+
+func handleGetAiBudget(context interfaces.ContextWithSession) error {
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	status, err := ai_service.GetAiBudgetStatus(context.Request().Context(), context.App.Db, orgUuid)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.GetAiBudgetResponseSchema{
+		Budget: ai_service.ParseAiBudgetToApiSchema(*status),
+	})
+}
+
+func handleUpdateAiBudget(context interfaces.ContextWithSession) error {
+	payload := new(api_types.UpdateAiBudgetSchema)
+	if err := context.Bind(payload); err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	orgUuid, err := uuid.Parse(context.Session.User.OrganizationId)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, "Invalid organization id")
+	}
+
+	_, err = ai_service.UpdateAiBudgetConfiguration(context.Request().Context(), context.App.Db, orgUuid, *payload)
+	if err != nil {
+		return context.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	status, err := ai_service.GetAiBudgetStatus(context.Request().Context(), context.App.Db, orgUuid)
+	if err != nil {
+		return context.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return context.JSON(http.StatusOK, api_types.UpdateAiBudgetResponseSchema{
+		Budget: ai_service.ParseAiBudgetToApiSchema(*status),
+	})
+}
+
 func _verifyAccessToOrganization(context interfaces.ContextWithSession, userId, organizationId uuid.UUID) bool {
 
 	orgQuery := SELECT(table.OrganizationMember.AllColumns, table.Organization.AllColumns).
@@ -2,11 +2,17 @@ package webhook_controller
 
 import (
 	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,8 +21,12 @@ import (
 	"github.com/wapikit/wapikit/api/api_types"
 	controller "github.com/wapikit/wapikit/api/controllers"
 	"github.com/wapikit/wapikit/interfaces"
+	"github.com/wapikit/wapikit/internal/campaign_manager"
+	"github.com/wapikit/wapikit/services/business_account_service"
+	"github.com/wapikit/wapikit/services/conversation_service"
 	"github.com/wapikit/wapikit/services/event_service"
 	"github.com/wapikit/wapikit/services/notification_service"
+	"github.com/wapikit/wapikit/services/template_service"
 	"github.com/wapikit/wapikit/utils"
 
 	. "github.com/go-jet/jet/v2/postgres"
@@ -28,6 +38,9 @@ import (
 type WebhookController struct {
 	controller.BaseController `json:"-,inline"`
 	handlerMap                map[events.EventType]func(events.BaseEvent, interfaces.App) error
+	// accountHandlerMap handles the changes of the business account itself, e.g. the status of a template, by the webhook field
+	// they come in, these changes are decoded here as wapi.go does not parse their payload
+	accountHandlerMap map[string]func(model.WhatsappBusinessAccount, json.RawMessage, interfaces.App) error
 }
 
 func NewWhatsappWebhookWebhookController(wapiClient *wapi.Client) *WebhookController {
@@ -55,40 +68,43 @@ func NewWhatsappWebhookWebhookController(wapiClient *wapi.Client) *WebhookContro
 	}
 
 	service.handlerMap = map[events.EventType]func(event events.BaseEvent, app interfaces.App) error{
-		events.TextMessageEventType:                  handleTextMessage,
-		events.VideoMessageEventType:                 handleVideoMessageEvent,
-		events.ImageMessageEventType:                 handleImageMessageEvent,
-		events.AccountAlertsEventType:                handleAccountAlerts,
-		events.DocumentMessageEventType:              handleDocumentMessageEvent,
-		events.AudioMessageEventType:                 handleAudioMessageEvent,
-		events.MessageReadEventType:                  handleMessageReadEvent,
-		events.SecurityEventType:                     handleSecurityEvent,
-		events.ErrorEventType:                        handleErrorEvent,
-		events.AdInteractionEventType:                handleAdInteractionEvent,
-		events.CustomerNumberChangedEventType:        handlePhoneNumberChangeEvent,
-		events.AccountReviewUpdateEventType:          handleAccountReviewUpdateEvent,
-		events.AccountUpdateEventType:                handleAccountUpdateEvent,
-		events.TemplateMessageEventType:              handleTemplateMessageEvent,
-		events.ContactMessageEventType:               handleContactMessageEvent,
-		events.ListInteractionMessageEventType:       handleListInteractionMessageEvent,
-		events.LocationMessageEventType:              handleLocationMessageEvent,
-		events.MessageDeliveredEventType:             handleMessageDeliveredEvent,
-		events.MessageFailedEventType:                handleMessageFailedEvent,
-		events.QuickReplyMessageEventType:            handleQuickReplyMessageEvent,
-		events.ReplyButtonInteractionEventType:       handleReplyButtonInteractionEvent,
-		events.ReactionMessageEventType:              handleReactionMessageEvent,
-		events.BusinessCapabilityUpdateEventType:     handleBusinessCapabilityUpdateEvent,
-		events.ProductInquiryEventType:               handleProductInquiryEvent,
-		events.OrderReceivedEventType:                handleOrderReceivedEvent,
-		events.StickerMessageEventType:               handleStickerMessageEvent,
-		events.MessageUndeliveredEventType:           handleMessageUndeliveredEvent,
-		events.CustomerIdentityChangedEventType:      handleCustomerIdentityChangedEvent,
-		events.UnknownEventType:                      handleUnknownEvent,
-		events.WarnEventType:                         handleWarnEvent,
-		events.MessageTemplateStatusUpdateEventType:  handleMessageTemplateUpdateEvent,
-		events.MessageTemplateQualityUpdateEventType: handleMessageTemplateQualityUpdateEvent,
-		events.PhoneNumberNameUpdateEventType:        handlePhoneNumberNameUpdateEvent,
-		events.PhoneNumberQualityUpdateEventType:     handlePhoneNumberQualityUpdateEvent,
+		events.TextMessageEventType:              handleTextMessage,
+		events.VideoMessageEventType:             handleVideoMessageEvent,
+		events.ImageMessageEventType:             handleImageMessageEvent,
+		events.DocumentMessageEventType:          handleDocumentMessageEvent,
+		events.AudioMessageEventType:             handleAudioMessageEvent,
+		events.MessageReadEventType:              handleMessageReadEvent,
+		events.SecurityEventType:                 handleSecurityEvent,
+		events.ErrorEventType:                    handleErrorEvent,
+		events.AdInteractionEventType:            handleAdInteractionEvent,
+		events.CustomerNumberChangedEventType:    handlePhoneNumberChangeEvent,
+		events.AccountReviewUpdateEventType:      handleAccountReviewUpdateEvent,
+		events.TemplateMessageEventType:          handleTemplateMessageEvent,
+		events.ContactMessageEventType:           handleContactMessageEvent,
+		events.ListInteractionMessageEventType:   handleListInteractionMessageEvent,
+		events.LocationMessageEventType:          handleLocationMessageEvent,
+		events.MessageDeliveredEventType:         handleMessageDeliveredEvent,
+		events.MessageFailedEventType:            handleMessageFailedEvent,
+		events.QuickReplyMessageEventType:        handleQuickReplyMessageEvent,
+		events.ReplyButtonInteractionEventType:   handleReplyButtonInteractionEvent,
+		events.ReactionMessageEventType:          handleReactionMessageEvent,
+		events.BusinessCapabilityUpdateEventType: handleBusinessCapabilityUpdateEvent,
+		events.ProductInquiryEventType:           handleProductInquiryEvent,
+		events.OrderReceivedEventType:            handleOrderReceivedEvent,
+		events.StickerMessageEventType:           handleStickerMessageEvent,
+		events.MessageUndeliveredEventType:       handleMessageUndeliveredEvent,
+		events.CustomerIdentityChangedEventType:  handleCustomerIdentityChangedEvent,
+		events.UnknownEventType:                  handleUnknownEvent,
+		events.WarnEventType:                     handleWarnEvent,
+	}
+
+	service.accountHandlerMap = map[string]func(businessAccount model.WhatsappBusinessAccount, value json.RawMessage, app interfaces.App) error{
+		"message_template_status_update":  handleMessageTemplateUpdateEvent,
+		"message_template_quality_update": handleMessageTemplateQualityUpdateEvent,
+		"phone_number_quality_update":     handlePhoneNumberQualityUpdateEvent,
+		"phone_number_name_update":        handlePhoneNumberNameUpdateEvent,
+		"account_alerts":                  handleAccountAlerts,
+		"account_update":                  handleAccountUpdateEvent,
 	}
 
 	return service
@@ -263,7 +279,7 @@ func fetchContact(sentByContactNumber, businessAccountId string, app interfaces.
 	return &contactWithAllDetails, nil
 }
 
-func fetchConversation(businessAccountId, sentByContactNumber string, app interfaces.App) (*event_service.ConversationWithAllDetails, error) {
+func fetchConversation(businessAccountId, phoneNumberId, sentByContactNumber string, app interfaces.App) (*event_service.ConversationWithAllDetails, error) {
 	var conversation struct {
 		model.Conversation
 		Contact struct {
@@ -302,8 +318,12 @@ func fetchConversation(businessAccountId, sentByContactNumber string, app interf
 			LEFT_JOIN(table.OrganizationMember, table.OrganizationMember.UniqueId.EQ(table.ConversationAssignment.AssignedToOrganizationMemberId)).
 			LEFT_JOIN(table.User, table.User.UniqueId.EQ(table.OrganizationMember.UserId)),
 	).WHERE(
-		table.Conversation.Status.EQ(utils.EnumExpression(model.ConversationStatusEnum_Active.String())).
+		table.Conversation.Status.IN(
+			utils.EnumExpression(model.ConversationStatusEnum_Active.String()),
+			utils.EnumExpression(model.ConversationStatusEnum_Snoozed.String()),
+		).
 			AND(table.WhatsappBusinessAccount.AccountId.EQ(String(businessAccountId))).
+			AND(table.Conversation.PhoneNumberUsed.EQ(String(phoneNumberId))).
 			AND(table.Contact.PhoneNumber.EQ(String(sentByContactNumber))),
 	).LIMIT(1)
 
@@ -338,6 +358,7 @@ func fetchConversation(businessAccountId, sentByContactNumber string, app interf
 			ContactId:              conversation.ContactId.String(),
 			OrganizationId:         conversation.OrganizationId.String(),
 			InitiatedBy:            api_types.ConversationInitiatedByEnum(conversation.InitiatedBy.String()),
+			PhoneNumberUsed:        &conversation.PhoneNumberUsed,
 			CampaignId:             &campaignId,
 			CreatedAt:              conversation.CreatedAt,
 			Status:                 api_types.ConversationStatusEnum(conversation.Status.String()),
@@ -472,6 +493,17 @@ func (service *WebhookController) handleWebhookPostRequest(context interfaces.Co
 		return context.JSON(http.StatusInternalServerError, "Internal server error")
 	}
 
+	// * whatsapp signs the payload with the secret of the meta app, it must be verified before anything is dispatched
+	appSecret := context.App.Koa.String("whatsapp.app_secret")
+	if appSecret == "" {
+		missingAppSecretWarningOnce.Do(func() {
+			logger.Warn("whatsapp.app_secret is not configured, the signature of the webhooks is not verified")
+		})
+	} else if !isValidWebhookSignature(bodyBytes, context.Request().Header.Get("X-Hub-Signature-256"), appSecret) {
+		logger.Error("invalid webhook signature", "business_account_id", businessAccountId)
+		return context.JSON(http.StatusUnauthorized, "Invalid signature")
+	}
+
 	// 3) Reset the body so the wapiClient can read it again.
 	context.Request().Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 
@@ -496,9 +528,62 @@ func (service *WebhookController) handleWebhookPostRequest(context interfaces.Co
 		return context.JSON(http.StatusInternalServerError, "Internal server error")
 	}
 
+	service.handleAccountChanges(bodyBytes, businessAccount, context.App)
+
 	return context.JSON(http.StatusOK, "Success")
 }
 
+// missingAppSecretWarningOnce warns only once about the webhooks accepted without a signature check
+var missingAppSecretWarningOnce sync.Once
+
+// isValidWebhookSignature reports whether the X-Hub-Signature-256 header is the HMAC-SHA256 of the raw body with the app secret
+func isValidWebhookSignature(bodyBytes []byte, signatureHeader string, appSecret string) bool {
+	signature, found := strings.CutPrefix(signatureHeader, "sha256=")
+	if !found {
+		return false
+	}
+
+	expectedSignature, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(appSecret))
+	mac.Write(bodyBytes)
+	return hmac.Equal(mac.Sum(nil), expectedSignature)
+}
+
+// handleAccountChanges dispatches the changes of the webhook payload to the account handlers by their field, a failing handler
+// is only logged as whatsapp would send the whole payload again
+func (service *WebhookController) handleAccountChanges(bodyBytes []byte, businessAccount model.WhatsappBusinessAccount, app interfaces.App) {
+	var payload struct {
+		Entry []struct {
+			Changes []struct {
+				Field string          `json:"field"`
+				Value json.RawMessage `json:"value"`
+			} `json:"changes"`
+		} `json:"entry"`
+	}
+
+	if err := json.Unmarshal(bodyBytes, &payload); err != nil {
+		app.Logger.Error("error parsing the changes of the webhook payload", "error", err.Error())
+		return
+	}
+
+	for _, entry := range payload.Entry {
+		for _, change := range entry.Changes {
+			handler, ok := service.accountHandlerMap[change.Field]
+			if !ok {
+				continue
+			}
+
+			if err := handler(businessAccount, change.Value, app); err != nil {
+				app.Logger.Error("error handling the webhook change", "field", change.Field, "business_account_id", businessAccount.AccountId, "error", err.Error())
+			}
+		}
+	}
+}
+
 func _preHandlerHook(app interfaces.App, businessAccountId string, phoneNumber events.BusinessPhoneNumber, sentByContactNumber, sentByName string) (*event_service.ConversationWithAllDetails, error) {
 	conversationDetailsToReturn := &event_service.ConversationWithAllDetails{}
 	businessAccount, err := fetchBusinessAccountDetails(businessAccountId, app)
@@ -517,6 +602,12 @@ func _preHandlerHook(app interfaces.App, businessAccountId string, phoneNumber e
 		conversationDetailsToReturn.BusinessAccountId = businessAccountId
 	}
 
+	// * a business account can have multiple phone numbers, the ones added on whatsapp after the last sync are registered here
+	err = app.BusinessAccountService.EnsurePhoneNumber(context.Background(), businessAccountId, phoneNumber.Id, phoneNumber.DisplayPhoneNumber)
+	if err != nil {
+		app.Logger.Error("error registering the phone number of the business account", err.Error(), nil)
+	}
+
 	var contactWithAllDetails ContactWithAllDetails
 
 	contact, err := fetchContact(sentByContactNumber, businessAccountId, app)
@@ -572,7 +663,7 @@ func _preHandlerHook(app interfaces.App, businessAccountId string, phoneNumber e
 		contactWithAllDetails = *contact
 	}
 
-	fetchedConversation, err := fetchConversation(businessAccountId, sentByContactNumber, app)
+	fetchedConversation, err := fetchConversation(businessAccountId, phoneNumber.Id, sentByContactNumber, app)
 
 	if err != nil {
 		if err.Error() == qrm.ErrNoRows.Error() {
@@ -590,6 +681,7 @@ func _preHandlerHook(app interfaces.App, businessAccountId string, phoneNumber e
 				InitiatedByCampaignId: initiatedByCampaignId,
 				InitiatedBy:           model.ConversationInitiatedEnum_Contact,
 				Status:                model.ConversationStatusEnum_Active,
+				Priority:              model.ConversationPriorityEnum_Normal,
 			}
 
 			var insertedConversation model.Conversation
@@ -611,6 +703,7 @@ func _preHandlerHook(app interfaces.App, businessAccountId string, phoneNumber e
 				CreatedAt:              insertedConversation.CreatedAt,
 				ContactId:              insertedConversation.ContactId.String(),
 				OrganizationId:         insertedConversation.OrganizationId.String(),
+				PhoneNumberUsed:        &insertedConversation.PhoneNumberUsed,
 				Status:                 api_types.ConversationStatusEnum(insertedConversation.Status.String()),
 				InitiatedBy:            api_types.ConversationInitiatedByEnum(insertedConversation.InitiatedBy.String()),
 				CampaignId:             &campaignId,
@@ -655,6 +748,31 @@ func _processIncomingMessage(
 	sentByContactNumber := baseEvent.From
 	sentByName := baseEvent.SenderName
 
+	// * replies to a CSAT survey are recorded against the closed conversation they rate, instead of opening a new conversation
+	businessAccount, err := fetchBusinessAccountDetails(businessAccountId, app)
+	if err != nil {
+		app.Logger.Error("error fetching business account details", err.Error(), nil)
+		return err
+	}
+
+	isCsatSurveyReply, err := app.ConversationService.HandleCsatSurveyReply(context.Background(), conversation_service.CsatSurveyReplyParams{
+		OrganizationId:             uuid.MustParse(businessAccount.OrganizationId),
+		PhoneNumberId:              phoneNumber.Id,
+		WhatsappBusinessAccountId:  businessAccountId,
+		ContactPhoneNumber:         sentByContactNumber,
+		WhatsAppMessageId:          messageId,
+		MessageType:                msgType,
+		MessageData:                messageData,
+		SentAt:                     sentAtTime,
+		RepliedToWhatsAppMessageId: baseEvent.Context.RepliedToMessageId,
+	})
+	if err != nil {
+		app.Logger.Error("error handling csat survey reply", err.Error(), nil)
+	}
+	if isCsatSurveyReply {
+		return nil
+	}
+
 	// 2. Get conversation details via preHandlerHook.
 	conversationDetails, err := _preHandlerHook(app, businessAccountId, phoneNumber, sentByContactNumber, sentByName)
 	if err != nil {
@@ -662,6 +780,16 @@ func _processIncomingMessage(
 		return err
 	}
 
+	// * a reply from the contact always wakes up a snoozed conversation
+	if conversationDetails.Status == api_types.ConversationStatusEnumSnoozed {
+		err = app.ConversationService.ReopenSnoozedConversation(context.Background(), uuid.MustParse(conversationDetails.UniqueId), "the contact replied")
+		if err != nil {
+			app.Logger.Error("error reopening snoozed conversation", err.Error(), nil)
+		} else {
+			conversationDetails.Status = api_types.ConversationStatusEnumActive
+		}
+	}
+
 	// 3. Marshal the message-specific data into JSON.
 	jsonMessageData, err := json.Marshal(messageData)
 	if err != nil {
@@ -689,6 +817,23 @@ func _processIncomingMessage(
 		UpdatedAt:                 time.Now(),
 	}
 
+	// * link the message to the one the contact replied to, if we have it in the database
+	if baseEvent.Context.RepliedToMessageId != "" {
+		var repliedToMessage model.Message
+		err = SELECT(table.Message.UniqueId).
+			FROM(table.Message).
+			WHERE(
+				table.Message.WhatsAppMessageId.EQ(String(baseEvent.Context.RepliedToMessageId)).
+					AND(table.Message.OrganizationId.EQ(UUID(messageToInsert.OrganizationId))),
+			).
+			LIMIT(1).
+			Query(app.Db, &repliedToMessage)
+
+		if err == nil {
+			messageToInsert.RepliedTo = &repliedToMessage.UniqueId
+		}
+	}
+
 	var insertedMessage model.Message
 	insertQuery := table.Message.
 		INSERT(table.Message.MutableColumns).
@@ -700,6 +845,14 @@ func _processIncomingMessage(
 		return err
 	}
 
+	// * auto tagging failures must not drop the message, the conversation can still be tagged manually
+	if _, err := app.ConversationService.ApplyTaggingRules(context.Background(), insertedMessage); err != nil {
+		app.Logger.Error("error applying conversation tagging rules", err.Error(), nil)
+	}
+
+	// * the sentiment, the intent and the language are classified by the AI in the background, off the webhook response
+	app.ConversationService.QueueMessageClassification(insertedMessage)
+
 	// 5. Parse DB record to API message and publish event.
 	messageParsed := app.ConversationService.ParseDbMessageToApiMessage(insertedMessage)
 	messageEvent := event_service.NewNewMessageEvent(*conversationDetails, messageParsed, nil, &conversationDetails.OrganizationId)
@@ -709,9 +862,52 @@ func _processIncomingMessage(
 		return err
 	}
 
+	// * the chatbot flows reply in the background, whatsapp expects the webhook to be acknowledged quickly
+	_queueChatbotInboundMessage(app, insertedMessage)
+
 	return nil
 }
 
+const (
+	chatbotInboundMessageWorkerCount = 4
+	chatbotInboundMessageQueueSize   = 500
+)
+
+var (
+	// chatbotInboundMessageQueue bounds the chatbot flows run for the inbound messages, their webhook and AI calls wait here
+	// for one of the chatbotInboundMessageWorkerCount workers instead of starting a go routine each
+	chatbotInboundMessageQueue       = make(chan func(), chatbotInboundMessageQueueSize)
+	chatbotInboundMessageWorkersOnce sync.Once
+)
+
+func runChatbotInboundMessageWorker() {
+	for handleMessage := range chatbotInboundMessageQueue {
+		handleMessage()
+	}
+}
+
+// _queueChatbotInboundMessage runs the chatbot flows for the inbound message in the background
+func _queueChatbotInboundMessage(app interfaces.App, message model.Message) {
+	chatbotInboundMessageWorkersOnce.Do(func() {
+		for i := 0; i < chatbotInboundMessageWorkerCount; i++ {
+			go runChatbotInboundMessageWorker()
+		}
+	})
+
+	handleMessage := func() {
+		if err := app.ChatbotService.HandleInboundMessage(context.Background(), message); err != nil {
+			app.Logger.Error("error running chatbot flow", err.Error(), nil)
+		}
+	}
+
+	select {
+	case chatbotInboundMessageQueue <- handleMessage:
+	default:
+		// * the message is in the inbox already, an agent replies to it instead of the chatbot
+		app.Logger.Error("chatbot inbound message queue is full, the message is not handled by the chatbot", "message_id", message.UniqueId.String())
+	}
+}
+
 // _processMessageStatusUpdate unifies the logic for updating message status
 // - newStatus: The new status to set (e.g., model.MessageStatusEnum_Read, Delivered, Failed).
 func _processMessageStatusUpdate(messageId string, app interfaces.App, newStatus model.MessageStatusEnum) error {
@@ -789,6 +985,79 @@ func _processMessageStatusUpdate(messageId string, app interfaces.App, newStatus
 	return nil
 }
 
+const (
+	inboundMediaWorkerCount = 4
+	inboundMediaQueueSize   = 500
+)
+
+var (
+	// inboundMediaQueue bounds the downloads of the inbound media, a burst of media messages waits here for one of
+	// the inboundMediaWorkerCount workers instead of starting a download each
+	inboundMediaQueue       = make(chan func(), inboundMediaQueueSize)
+	inboundMediaWorkersOnce sync.Once
+)
+
+func runInboundMediaWorker() {
+	for storeMedia := range inboundMediaQueue {
+		storeMedia()
+	}
+}
+
+// _storeInboundMedia downloads the media of an inbound message into the media storage in the background,
+// as the whatsapp media urls expire and the media would not be viewable afterwards
+func _storeInboundMedia(app interfaces.App, businessAccountId string, phoneNumber events.BusinessPhoneNumber, mediaId string, fileName *string) {
+	if mediaId == "" {
+		return
+	}
+
+	inboundMediaWorkersOnce.Do(func() {
+		for i := 0; i < inboundMediaWorkerCount; i++ {
+			go runInboundMediaWorker()
+		}
+	})
+
+	storeMedia := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		businessAccount, err := fetchBusinessAccountDetails(businessAccountId, app)
+		if err != nil {
+			app.Logger.Error("error fetching business account details for media download", err.Error(), nil)
+			return
+		}
+
+		organizationUuid, err := uuid.Parse(businessAccount.OrganizationId)
+		if err != nil {
+			app.Logger.Error("error parsing organization id for media download", err.Error(), nil)
+			return
+		}
+
+		wapiClient := wapi.New(&wapi.ClientConfig{
+			BusinessAccountId: businessAccount.BusinessAccountId,
+			ApiAccessToken:    businessAccount.AccessToken,
+			WebhookSecret:     businessAccount.WebhookSecret,
+		})
+
+		mediaUrl, err := wapiClient.NewMessagingClient(phoneNumber.Id).Media.GetMediaUrlById(mediaId)
+		if err != nil {
+			app.Logger.Error("error fetching media url from whatsapp", err.Error(), nil)
+			return
+		}
+
+		_, err = app.MediaService.StoreWhatsAppMedia(ctx, organizationUuid, mediaId, mediaUrl, businessAccount.AccessToken, fileName)
+		if err != nil {
+			app.Logger.Error("error storing inbound media", err.Error(), nil)
+		}
+	}
+
+	select {
+	case inboundMediaQueue <- storeMedia:
+	default:
+		// * the media stays viewable from whatsapp until its url expires
+		app.Logger.Error("inbound media queue is full, the media is not stored", "media_id", mediaId)
+	}
+}
+
 func handleTextMessage(event events.BaseEvent, app interfaces.App) error {
 	textMessageEvent := event.(*events.TextMessageEvent)
 
@@ -835,6 +1104,8 @@ func handleVideoMessageEvent(event events.BaseEvent, app interfaces.App) error {
 		return err
 	}
 
+	_storeInboundMedia(app, videoMessageEvent.BusinessAccountId, videoMessageEvent.PhoneNumber, videoMessageEvent.Video.Id, nil)
+
 	return nil
 }
 
@@ -860,6 +1131,8 @@ func handleImageMessageEvent(event events.BaseEvent, app interfaces.App) error {
 		return err
 	}
 
+	_storeInboundMedia(app, imageMessageEvent.BusinessAccountId, imageMessageEvent.PhoneNumber, imageMessageEvent.Image.Id, nil)
+
 	return nil
 }
 
@@ -871,7 +1144,12 @@ func handleDocumentMessageEvent(event events.BaseEvent, app interfaces.App) erro
 		documentMessageEvent.PhoneNumber,
 		documentMessageEvent.MessageId,
 		documentMessageEvent.BaseMessageEvent,
-		api_types.DocumentMessageData{},
+		api_types.DocumentMessageData{
+			Id:       documentMessageEvent.Document.Id,
+			Link:     &documentMessageEvent.Document.Link,
+			Caption:  &documentMessageEvent.Document.Caption,
+			FileName: documentMessageEvent.Document.Filename,
+		},
 		model.MessageTypeEnum_Document,
 	)
 
@@ -880,6 +1158,8 @@ func handleDocumentMessageEvent(event events.BaseEvent, app interfaces.App) erro
 		return err
 	}
 
+	_storeInboundMedia(app, documentMessageEvent.BusinessAccountId, documentMessageEvent.PhoneNumber, documentMessageEvent.Document.Id, &documentMessageEvent.Document.Filename)
+
 	return nil
 }
 
@@ -903,6 +1183,8 @@ func handleAudioMessageEvent(event events.BaseEvent, app interfaces.App) error {
 		return err
 	}
 
+	_storeInboundMedia(app, audioMessageEvent.BusinessAccountId, audioMessageEvent.PhoneNumber, audioMessageEvent.Audio.Id, nil)
+
 	return nil
 }
 
@@ -927,6 +1209,8 @@ func handleStickerMessageEvent(event events.BaseEvent, app interfaces.App) error
 		return err
 	}
 
+	_storeInboundMedia(app, stickerMessageEvent.BusinessAccountId, stickerMessageEvent.PhoneNumber, stickerMessageEvent.MediaId, nil)
+
 	return nil
 }
 
@@ -944,7 +1228,7 @@ func handleLocationMessageEvent(event events.BaseEvent, app interfaces.App) erro
 			Longitude: locationMessageEvent.Location.Longitude,
 			Name:      &locationMessageEvent.Location.Name,
 		},
-		model.MessageTypeEnum_Sticker,
+		model.MessageTypeEnum_Location,
 	)
 
 	if err != nil {
@@ -957,21 +1241,47 @@ func handleLocationMessageEvent(event events.BaseEvent, app interfaces.App) erro
 
 func handleReactionMessageEvent(event events.BaseEvent, app interfaces.App) error {
 	reactionMessageEvent := event.(*events.ReactionMessageEvent)
-	err := _processIncomingMessage(
-		app,
-		reactionMessageEvent.BusinessAccountId,
-		reactionMessageEvent.PhoneNumber,
-		reactionMessageEvent.MessageId,
-		reactionMessageEvent.BaseMessageEvent,
-		api_types.ReactionMessageData{
-			Reaction:  reactionMessageEvent.Reaction.Emoji,
-			MessageId: &reactionMessageEvent.Reaction.MessageId,
-		},
-		model.MessageTypeEnum_Sticker,
+
+	unixTimestamp, err := strconv.ParseInt(reactionMessageEvent.Timestamp, 10, 64)
+	if err != nil {
+		app.Logger.Error("error parsing timestamp", err.Error(), nil)
+		return err
+	}
+
+	conversationDetails, err := _preHandlerHook(app, reactionMessageEvent.BusinessAccountId, reactionMessageEvent.PhoneNumber, reactionMessageEvent.From, reactionMessageEvent.SenderName)
+	if err != nil {
+		app.Logger.Error("error fetching conversation details", err.Error(), nil)
+		return err
+	}
+
+	// * reactions are attached to the message they react to, an empty emoji means the contact removed the reaction
+	reactedMessage, err := app.ConversationService.ApplyMessageReaction(
+		context.Background(),
+		uuid.MustParse(conversationDetails.OrganizationId),
+		reactionMessageEvent.Reaction.MessageId,
+		reactionMessageEvent.Reaction.Emoji,
+		model.MessageDirectionEnum_InBound,
+		time.Unix(unixTimestamp, 0),
 	)
 
 	if err != nil {
-		app.Logger.Error("error processing incoming reaction message", err.Error(), nil)
+		if err.Error() == qrm.ErrNoRows.Error() {
+			// * the contact reacted to a message we do not have in the database, nothing to attach the reaction to
+			return nil
+		}
+		app.Logger.Error("error attaching reaction to the message", err.Error(), nil)
+		return err
+	}
+
+	reactions := []api_types.MessageReactionSchema{}
+	if parsedReactions := app.ConversationService.ParseMessageReactions(reactedMessage.Reactions); parsedReactions != nil {
+		reactions = *parsedReactions
+	}
+
+	reactionEvent := event_service.NewMessageReactionEvent(reactedMessage.UniqueId.String(), reactions, &conversationDetails.OrganizationId)
+	err = app.Redis.PublishMessageToRedisChannel(app.Constants.RedisApiServerEventChannelName, reactionEvent.ToJson())
+	if err != nil {
+		app.Logger.Error("error sending api server event", err.Error(), nil)
 		return err
 	}
 
@@ -979,11 +1289,105 @@ func handleReactionMessageEvent(event events.BaseEvent, app interfaces.App) erro
 }
 
 func handleQuickReplyMessageEvent(event events.BaseEvent, app interfaces.App) error {
+	// * quick replies are the taps on the quick reply buttons of the template messages
+	quickReplyEvent := event.(*events.QuickReplyMessageEvent)
+
+	err := _processIncomingMessage(
+		app,
+		quickReplyEvent.BusinessAccountId,
+		quickReplyEvent.PhoneNumber,
+		quickReplyEvent.MessageId,
+		quickReplyEvent.BaseMessageEvent,
+		api_types.InteractiveReplyMessageData{
+			ReplyType: api_types.QuickReply,
+			Id:        quickReplyEvent.ButtonPayload,
+			Title:     quickReplyEvent.ButtonText,
+		},
+		model.MessageTypeEnum_InteractiveReply,
+	)
+
+	if err != nil {
+		app.Logger.Error("error processing incoming quick reply message", err.Error(), nil)
+		return err
+	}
 
 	return nil
 }
 
 func handleContactMessageEvent(event events.BaseEvent, app interfaces.App) error {
+	contactMessageEvent := event.(*events.ContactMessageEvent)
+
+	sharedContacts := []api_types.SharedContactSchema{}
+	for _, contact := range contactMessageEvent.Contacts.Contacts {
+		sharedContact := api_types.SharedContactSchema{
+			FormattedName: contact.Name.FormattedName,
+			Phones:        []api_types.SharedContactPhoneSchema{},
+			Emails:        []api_types.SharedContactEmailSchema{},
+		}
+
+		if contact.Name.FirstName != "" {
+			sharedContact.FirstName = &contact.Name.FirstName
+		}
+		if contact.Name.LastName != "" {
+			sharedContact.LastName = &contact.Name.LastName
+		}
+		if contact.Org.Company != "" {
+			sharedContact.Company = &contact.Org.Company
+		}
+		if contact.Org.Title != "" {
+			sharedContact.Title = &contact.Org.Title
+		}
+		if contact.Birthday != "" {
+			sharedContact.Birthday = &contact.Birthday
+		}
+
+		for _, phone := range contact.Phones {
+			sharedPhone := api_types.SharedContactPhoneSchema{Phone: phone.Phone}
+			if phone.WaId != "" {
+				sharedPhone.WaId = &phone.WaId
+			}
+			if phone.Type != "" {
+				phoneType := string(phone.Type)
+				sharedPhone.Type = &phoneType
+			}
+			sharedContact.Phones = append(sharedContact.Phones, sharedPhone)
+		}
+
+		for _, email := range contact.Emails {
+			sharedEmail := api_types.SharedContactEmailSchema{Email: email.Email}
+			if email.Type != "" {
+				emailType := string(email.Type)
+				sharedEmail.Type = &emailType
+			}
+			sharedContact.Emails = append(sharedContact.Emails, sharedEmail)
+		}
+
+		if len(contact.Urls) > 0 {
+			urls := []string{}
+			for _, url := range contact.Urls {
+				urls = append(urls, url.Url)
+			}
+			sharedContact.Urls = &urls
+		}
+
+		sharedContacts = append(sharedContacts, sharedContact)
+	}
+
+	err := _processIncomingMessage(
+		app,
+		contactMessageEvent.BusinessAccountId,
+		contactMessageEvent.PhoneNumber,
+		contactMessageEvent.MessageId,
+		contactMessageEvent.BaseMessageEvent,
+		api_types.ContactsMessageData{Contacts: sharedContacts},
+		model.MessageTypeEnum_Contacts,
+	)
+
+	if err != nil {
+		app.Logger.Error("error processing incoming contact message", err.Error(), nil)
+		return err
+	}
+
 	return nil
 }
 
@@ -1049,13 +1453,6 @@ func handleSecurityEvent(event events.BaseEvent, app interfaces.App) error {
 
 }
 
-func handleAccountAlerts(event events.BaseEvent, app interfaces.App) error {
-	// send an api_server_event to webhook
-
-	return nil
-
-}
-
 func handleAdInteractionEvent(event events.BaseEvent, app interfaces.App) error {
 	// send an api_server_event to webhook
 
@@ -1075,23 +1472,65 @@ func handleAccountReviewUpdateEvent(event events.BaseEvent, app interfaces.App)
 
 }
 
-func handleAccountUpdateEvent(event events.BaseEvent, app interfaces.App) error {
+func handleTemplateMessageEvent(event events.BaseEvent, app interfaces.App) error {
 
 	return nil
 
 }
 
-func handleTemplateMessageEvent(event events.BaseEvent, app interfaces.App) error {
+func handleListInteractionMessageEvent(event events.BaseEvent, app interfaces.App) error {
+	listInteractionEvent := event.(*events.ListInteractionMessageEvent)
 
-	return nil
+	messageData := api_types.InteractiveReplyMessageData{
+		ReplyType: api_types.ListReply,
+		Id:        listInteractionEvent.ListId,
+		Title:     listInteractionEvent.Title,
+	}
 
-}
+	if listInteractionEvent.Description != "" {
+		messageData.Description = &listInteractionEvent.Description
+	}
+
+	err := _processIncomingMessage(
+		app,
+		listInteractionEvent.BusinessAccountId,
+		listInteractionEvent.PhoneNumber,
+		listInteractionEvent.MessageId,
+		listInteractionEvent.BaseMessageEvent,
+		messageData,
+		model.MessageTypeEnum_InteractiveReply,
+	)
+
+	if err != nil {
+		app.Logger.Error("error processing incoming list interaction message", err.Error(), nil)
+		return err
+	}
 
-func handleListInteractionMessageEvent(event events.BaseEvent, app interfaces.App) error {
 	return nil
 }
 
 func handleReplyButtonInteractionEvent(event events.BaseEvent, app interfaces.App) error {
+	replyButtonEvent := event.(*events.ReplyButtonInteractionEvent)
+
+	err := _processIncomingMessage(
+		app,
+		replyButtonEvent.BusinessAccountId,
+		replyButtonEvent.PhoneNumber,
+		replyButtonEvent.MessageId,
+		replyButtonEvent.BaseMessageEvent,
+		api_types.InteractiveReplyMessageData{
+			ReplyType: api_types.ButtonReply,
+			Id:        replyButtonEvent.ReplyButtonId,
+			Title:     replyButtonEvent.Title,
+		},
+		model.MessageTypeEnum_InteractiveReply,
+	)
+
+	if err != nil {
+		app.Logger.Error("error processing incoming reply button interaction", err.Error(), nil)
+		return err
+	}
+
 	return nil
 }
 
@@ -1100,10 +1539,67 @@ func handleBusinessCapabilityUpdateEvent(event events.BaseEvent, app interfaces.
 }
 
 func handleProductInquiryEvent(event events.BaseEvent, app interfaces.App) error {
+	productInquiryEvent := event.(*events.ProductInquiryEvent)
+
+	err := _processIncomingMessage(
+		app,
+		productInquiryEvent.BusinessAccountId,
+		productInquiryEvent.PhoneNumber,
+		productInquiryEvent.MessageId,
+		productInquiryEvent.BaseMessageEvent,
+		api_types.ProductInquiryMessageData{
+			Text:              productInquiryEvent.Text,
+			CatalogId:         productInquiryEvent.CatalogId,
+			ProductRetailerId: productInquiryEvent.ProductRetailerId,
+		},
+		model.MessageTypeEnum_ProductInquiry,
+	)
+
+	if err != nil {
+		app.Logger.Error("error processing incoming product inquiry message", err.Error(), nil)
+		return err
+	}
+
 	return nil
 }
 
 func handleOrderReceivedEvent(event events.BaseEvent, app interfaces.App) error {
+	orderReceivedEvent := event.(*events.OrderReceivedEvent)
+
+	productItems := []api_types.OrderProductItemSchema{}
+	for _, item := range orderReceivedEvent.Order.ProductItems {
+		productItems = append(productItems, api_types.OrderProductItemSchema{
+			ProductRetailerId: item.ProductRetailerId,
+			Quantity:          item.Quantity,
+			ItemPrice:         item.ItemPrice,
+			Currency:          item.Currency,
+		})
+	}
+
+	messageData := api_types.OrderMessageData{
+		CatalogId:    orderReceivedEvent.Order.CatalogId,
+		ProductItems: productItems,
+	}
+
+	if orderReceivedEvent.Order.Text != "" {
+		messageData.Text = &orderReceivedEvent.Order.Text
+	}
+
+	err := _processIncomingMessage(
+		app,
+		orderReceivedEvent.BusinessAccountId,
+		orderReceivedEvent.PhoneNumber,
+		orderReceivedEvent.MessageId,
+		orderReceivedEvent.BaseMessageEvent,
+		messageData,
+		model.MessageTypeEnum_Order,
+	)
+
+	if err != nil {
+		app.Logger.Error("error processing incoming order message", err.Error(), nil)
+		return err
+	}
+
 	return nil
 }
 
@@ -1130,22 +1626,442 @@ func handleWarnEvent(event events.BaseEvent, app interfaces.App) error {
 	return nil
 }
 
-func handleMessageTemplateUpdateEvent(event events.BaseEvent, app interfaces.App) error {
+// ! https://developers.facebook.com/docs/graph-api/webhooks/reference/whatsapp-business-account/#message_template_status_update
+type messageTemplateStatusUpdateValue struct {
+	Event                   string      `json:"event"`
+	MessageTemplateId       json.Number `json:"message_template_id"`
+	MessageTemplateName     string      `json:"message_template_name"`
+	MessageTemplateLanguage string      `json:"message_template_language"`
+	Reason                  string      `json:"reason"`
+	OtherInfo               *struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	} `json:"other_info"`
+}
+
+func handleMessageTemplateUpdateEvent(businessAccount model.WhatsappBusinessAccount, value json.RawMessage, app interfaces.App) error {
+	var statusUpdate messageTemplateStatusUpdateValue
+	if err := json.Unmarshal(value, &statusUpdate); err != nil {
+		return err
+	}
+
+	reason := statusUpdate.Reason
+	if statusUpdate.OtherInfo != nil && statusUpdate.OtherInfo.Description != "" {
+		reason = statusUpdate.OtherInfo.Description
+	}
+
+	// * a flagged template is still sendable, whatsapp pauses it if its quality does not recover, only the reason is kept
+	if statusUpdate.Event == "FLAGGED" {
+		template, err := app.TemplateService.UpdateTemplateQuality(context.Background(), businessAccount.AccountId, statusUpdate.MessageTemplateId.String(), model.MessageTemplateQualityEnum_Red)
+		if err != nil {
+			return err
+		}
+		notifyTemplateStatusChange(app, template.MessageTemplate, "Message template flagged", "was flagged for its low quality, it is paused if its quality does not improve", reason)
+		return nil
+	}
+
+	status, ok := template_service.ParseTemplateStatus(statusUpdate.Event)
+	if !ok {
+		app.Logger.Info("unknown message template status", "event", statusUpdate.Event)
+		return nil
+	}
+
+	statusChange, err := app.TemplateService.UpdateTemplateStatus(context.Background(), businessAccount.AccountId, statusUpdate.MessageTemplateId.String(), status, reason)
+	if err != nil {
+		return err
+	}
+
+	// * the campaigns using the template are paused by the campaign manager, the members are told why here
+	if statusChange.PreviousStatus != status && (status == model.MessageTemplateStatusEnum_Rejected || status == model.MessageTemplateStatusEnum_Paused || status == model.MessageTemplateStatusEnum_Disabled) {
+		notifyTemplateStatusChange(app, statusChange.Template.MessageTemplate, "Message template "+strings.ToLower(status.String()), "was "+strings.ToLower(status.String())+" by whatsapp", reason)
+	}
+
+	return nil
+}
+
+// ! https://developers.facebook.com/docs/graph-api/webhooks/reference/whatsapp-business-account/#message_template_quality_update
+type messageTemplateQualityUpdateValue struct {
+	PreviousQualityScore    string      `json:"previous_quality_score"`
+	NewQualityScore         string      `json:"new_quality_score"`
+	MessageTemplateId       json.Number `json:"message_template_id"`
+	MessageTemplateName     string      `json:"message_template_name"`
+	MessageTemplateLanguage string      `json:"message_template_language"`
+}
+
+func handleMessageTemplateQualityUpdateEvent(businessAccount model.WhatsappBusinessAccount, value json.RawMessage, app interfaces.App) error {
+	var qualityUpdate messageTemplateQualityUpdateValue
+	if err := json.Unmarshal(value, &qualityUpdate); err != nil {
+		return err
+	}
+
+	_, err := app.TemplateService.UpdateTemplateQuality(context.Background(), businessAccount.AccountId, qualityUpdate.MessageTemplateId.String(), template_service.ParseTemplateQuality(qualityUpdate.NewQualityScore))
+	return err
+}
+
+func notifyTemplateStatusChange(app interfaces.App, template model.MessageTemplate, title, change, reason string) {
+	orgId := template.OrganizationId.String()
+	notificationType := "MessageTemplate"
+	description := fmt.Sprintf("The template %s (%s) %s.", template.Name, template.Language, change)
+	if reason != "" && reason != "NONE" {
+		description += " Reason: " + reason
+	}
+
+	app.NotificationService.SendInAppNotification(notification_service.InAppNotificationParams{
+		Title:          title,
+		Description:    description,
+		OrganizationId: &orgId,
+		Type:           &notificationType,
+	})
+}
+
+// ! https://developers.facebook.com/docs/graph-api/webhooks/reference/whatsapp-business-account/#phone_number_quality_update
+type phoneNumberQualityUpdateValue struct {
+	DisplayPhoneNumber string `json:"display_phone_number"`
+	Event              string `json:"event"`
+	CurrentLimit       string `json:"current_limit"`
+	OldLimit           string `json:"old_limit"`
+}
 
+func handlePhoneNumberQualityUpdateEvent(businessAccount model.WhatsappBusinessAccount, value json.RawMessage, app interfaces.App) error {
+	var qualityUpdate phoneNumberQualityUpdateValue
+	if err := json.Unmarshal(value, &qualityUpdate); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	phoneNumber, err := app.BusinessAccountService.FindPhoneNumberByDisplayPhoneNumber(ctx, businessAccount, qualityUpdate.DisplayPhoneNumber)
+	if err != nil {
+		return err
+	}
+
+	// * the webhook does not carry the quality rating itself, it is fetched from whatsapp along with the rest of the health
+	health := business_account_service.PhoneNumberHealth{}
+	whatsAppPhoneNumber, err := app.BusinessAccountService.FetchWhatsAppPhoneNumber(ctx, businessAccount, phoneNumber.PhoneNumberId)
+	if err != nil {
+		app.Logger.Error("error fetching the health of the phone number", "phone_number_id", phoneNumber.PhoneNumberId, "error", err.Error())
+		if qualityUpdate.Event == "FLAGGED" {
+			qualityRating := model.PhoneNumberQualityRatingEnum_Red
+			health.QualityRating = &qualityRating
+		}
+	} else {
+		health = whatsAppPhoneNumber.Health()
+	}
+
+	if qualityUpdate.CurrentLimit != "" {
+		messagingLimitTier := business_account_service.ParsePhoneNumberMessagingLimitTier(qualityUpdate.CurrentLimit)
+		health.MessagingLimitTier = &messagingLimitTier
+	}
+
+	if qualityUpdate.Event != "" {
+		health.Description = &qualityUpdate.Event
+	}
+
+	healthEvents, err := app.BusinessAccountService.UpdatePhoneNumberHealth(ctx, *phoneNumber, health)
+	if err != nil {
+		return err
+	}
+
+	handlePhoneNumberHealthEvents(app, businessAccount, &phoneNumber.WhatsappBusinessAccountPhoneNumber, healthEvents)
 	return nil
 }
 
-func handleMessageTemplateQualityUpdateEvent(event events.BaseEvent, app interfaces.App) error {
+// ! https://developers.facebook.com/docs/graph-api/webhooks/reference/whatsapp-business-account/#phone_number_name_update
+type phoneNumberNameUpdateValue struct {
+	DisplayPhoneNumber    string `json:"display_phone_number"`
+	Decision              string `json:"decision"`
+	RequestedVerifiedName string `json:"requested_verified_name"`
+	RejectionReason       string `json:"rejection_reason"`
+}
+
+func handlePhoneNumberNameUpdateEvent(businessAccount model.WhatsappBusinessAccount, value json.RawMessage, app interfaces.App) error {
+	var nameUpdate phoneNumberNameUpdateValue
+	if err := json.Unmarshal(value, &nameUpdate); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	phoneNumber, err := app.BusinessAccountService.FindPhoneNumberByDisplayPhoneNumber(ctx, businessAccount, nameUpdate.DisplayPhoneNumber)
+	if err != nil {
+		return err
+	}
+
+	nameStatus := business_account_service.ParsePhoneNumberNameStatus(nameUpdate.Decision)
+	health := business_account_service.PhoneNumberHealth{
+		NameStatus: &nameStatus,
+	}
+
+	if nameStatus == model.PhoneNumberNameStatusEnum_Approved && nameUpdate.RequestedVerifiedName != "" {
+		health.VerifiedName = &nameUpdate.RequestedVerifiedName
+	}
+
+	if nameUpdate.RejectionReason != "" && nameUpdate.RejectionReason != "NONE" {
+		health.Description = &nameUpdate.RejectionReason
+	}
+
+	healthEvents, err := app.BusinessAccountService.UpdatePhoneNumberHealth(ctx, *phoneNumber, health)
+	if err != nil {
+		return err
+	}
 
+	handlePhoneNumberHealthEvents(app, businessAccount, &phoneNumber.WhatsappBusinessAccountPhoneNumber, healthEvents)
 	return nil
 }
 
-func handlePhoneNumberNameUpdateEvent(event events.BaseEvent, app interfaces.App) error {
+// ! https://developers.facebook.com/docs/graph-api/webhooks/reference/whatsapp-business-account/#account_alerts
+type accountAlertsValue struct {
+	EntityType string `json:"entity_type"`
+	EntityId   string `json:"entity_id"`
+	AlertInfo  struct {
+		AlertSeverity    string `json:"alert_severity"`
+		AlertStatus      string `json:"alert_status"`
+		AlertType        string `json:"alert_type"`
+		AlertDescription string `json:"alert_description"`
+	} `json:"alert_info"`
+}
+
+func handleAccountAlerts(businessAccount model.WhatsappBusinessAccount, value json.RawMessage, app interfaces.App) error {
+	var alert accountAlertsValue
+	if err := json.Unmarshal(value, &alert); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var phoneNumber *model.WhatsappBusinessAccountPhoneNumber
+	if alert.EntityType == "PHONE_NUMBER" {
+		phoneNumberWithBusinessAccount, err := app.BusinessAccountService.GetPhoneNumber(ctx, businessAccount.OrganizationId, alert.EntityId)
+		if err == nil {
+			phoneNumber = &phoneNumberWithBusinessAccount.WhatsappBusinessAccountPhoneNumber
+		}
+	}
+
+	var description *string
+	if alert.AlertInfo.AlertDescription != "" {
+		description = &alert.AlertInfo.AlertDescription
+	}
+
+	// * the informational alerts and the ones whatsapp resolved are only kept in the history
+	isDowngrade := (alert.AlertInfo.AlertSeverity == "CRITICAL" || alert.AlertInfo.AlertSeverity == "WARNING") && alert.AlertInfo.AlertStatus != "NONE" && alert.AlertInfo.AlertStatus != "RESOLVED"
 
+	healthEvent, err := app.BusinessAccountService.RecordAccountHealthEvent(ctx, businessAccount, phoneNumber, model.PhoneNumberHealthEventTypeEnum_AccountAlert, alert.AlertInfo.AlertType, description, isDowngrade)
+	if err != nil {
+		return err
+	}
+
+	handlePhoneNumberHealthEvents(app, businessAccount, phoneNumber, []model.PhoneNumberHealthEvent{*healthEvent})
 	return nil
 }
 
-func handlePhoneNumberQualityUpdateEvent(event events.BaseEvent, app interfaces.App) error {
+// ! https://developers.facebook.com/docs/graph-api/webhooks/reference/whatsapp-business-account/#account_update
+type accountUpdateValue struct {
+	PhoneNumber string `json:"phone_number"`
+	Event       string `json:"event"`
+	BanInfo     *struct {
+		WabaBanState interface{} `json:"waba_ban_state"`
+		WabaBanDate  string      `json:"waba_ban_date"`
+	} `json:"ban_info"`
+	ViolationInfo *struct {
+		ViolationType string `json:"violation_type"`
+	} `json:"violation_info"`
+	RestrictionInfo []struct {
+		RestrictionType string `json:"restriction_type"`
+	} `json:"restriction_info"`
+}
+
+// accountUpdateDowngradeEvents are the account updates which limit or stop the messaging of the business account
+var accountUpdateDowngradeEvents = map[string]bool{
+	"DISABLED_UPDATE":     true,
+	"ACCOUNT_VIOLATION":   true,
+	"ACCOUNT_RESTRICTION": true,
+	"ACCOUNT_DELETED":     true,
+}
+
+func handleAccountUpdateEvent(businessAccount model.WhatsappBusinessAccount, value json.RawMessage, app interfaces.App) error {
+	var accountUpdate accountUpdateValue
+	if err := json.Unmarshal(value, &accountUpdate); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	var phoneNumber *model.WhatsappBusinessAccountPhoneNumber
+	if accountUpdate.PhoneNumber != "" {
+		phoneNumberWithBusinessAccount, err := app.BusinessAccountService.FindPhoneNumberByDisplayPhoneNumber(ctx, businessAccount, accountUpdate.PhoneNumber)
+		if err == nil {
+			phoneNumber = &phoneNumberWithBusinessAccount.WhatsappBusinessAccountPhoneNumber
+		}
+	}
+
+	details := []string{}
+	if accountUpdate.BanInfo != nil {
+		details = append(details, fmt.Sprintf("ban state %v", accountUpdate.BanInfo.WabaBanState))
+		if accountUpdate.BanInfo.WabaBanDate != "" {
+			details = append(details, "ban date "+accountUpdate.BanInfo.WabaBanDate)
+		}
+	}
+	if accountUpdate.ViolationInfo != nil && accountUpdate.ViolationInfo.ViolationType != "" {
+		details = append(details, "violation "+accountUpdate.ViolationInfo.ViolationType)
+	}
+	for _, restriction := range accountUpdate.RestrictionInfo {
+		details = append(details, "restriction "+restriction.RestrictionType)
+	}
+
+	var description *string
+	if len(details) > 0 {
+		joinedDetails := strings.Join(details, ", ")
+		description = &joinedDetails
+	}
+
+	healthEvent, err := app.BusinessAccountService.RecordAccountHealthEvent(ctx, businessAccount, phoneNumber, model.PhoneNumberHealthEventTypeEnum_AccountUpdate, accountUpdate.Event, description, accountUpdateDowngradeEvents[accountUpdate.Event])
+	if err != nil {
+		return err
+	}
 
+	handlePhoneNumberHealthEvents(app, businessAccount, phoneNumber, []model.PhoneNumberHealthEvent{*healthEvent})
 	return nil
 }
+
+// handlePhoneNumberHealthEvents tells the organization about the downgrades among the health events, by in app notification,
+// email and slack as configured, and pauses the running campaigns of a phone number whose quality rating dropped to red
+func handlePhoneNumberHealthEvents(app interfaces.App, businessAccount model.WhatsappBusinessAccount, phoneNumber *model.WhatsappBusinessAccountPhoneNumber, healthEvents []model.PhoneNumberHealthEvent) {
+	downgrades := []model.PhoneNumberHealthEvent{}
+	isQualityRed := false
+	for _, healthEvent := range healthEvents {
+		if !healthEvent.IsDowngrade {
+			continue
+		}
+		downgrades = append(downgrades, healthEvent)
+		if healthEvent.Type == model.PhoneNumberHealthEventTypeEnum_QualityRating && healthEvent.NewValue != nil && *healthEvent.NewValue == model.PhoneNumberQualityRatingEnum_Red.String() {
+			isQualityRed = true
+		}
+	}
+
+	if len(downgrades) == 0 {
+		return
+	}
+
+	ctx := context.Background()
+	configuration, err := app.BusinessAccountService.GetPhoneNumberHealthConfiguration(ctx, businessAccount.OrganizationId)
+	if err != nil {
+		app.Logger.Error("error fetching the phone number health configuration", "organization_id", businessAccount.OrganizationId.String(), "error", err.Error())
+		return
+	}
+
+	title := "WhatsApp business account alert"
+	subject := "your whatsapp business account"
+	if phoneNumber != nil {
+		title = "WhatsApp phone number health downgraded"
+		subject = "the phone number " + phoneNumber.PhoneNumberId
+		if phoneNumber.DisplayPhoneNumber != nil && *phoneNumber.DisplayPhoneNumber != "" {
+			subject = "the phone number " + *phoneNumber.DisplayPhoneNumber
+		}
+	}
+
+	changes := []string{}
+	for _, downgrade := range downgrades {
+		changes = append(changes, describePhoneNumberHealthEvent(downgrade))
+	}
+	description := fmt.Sprintf("WhatsApp reported for %s: %s.", subject, strings.Join(changes, "; "))
+
+	if isQualityRed && phoneNumber != nil && configuration.IsCampaignAutoPauseEnabled {
+		pausedCampaigns, err := app.BusinessAccountService.PauseRunningCampaigns(ctx, *phoneNumber)
+		if err != nil {
+			app.Logger.Error("error pausing the campaigns of the phone number", "phone_number_id", phoneNumber.PhoneNumberId, "error", err.Error())
+		}
+
+		for _, campaign := range pausedCampaigns {
+			if app.CampaignManager != nil {
+				app.CampaignManager.StopCampaign(campaign.UniqueId.String())
+			}
+			cmCommand := campaign_manager.NewStopCampaignCommand(campaign.UniqueId.String())
+			app.Redis.PublishMessageToRedisChannel(app.Constants.RedisCampaignManagerChannelName, cmCommand.ToJson())
+		}
+
+		if len(pausedCampaigns) > 0 {
+			description += fmt.Sprintf(" %d running campaign(s) of the phone number were paused.", len(pausedCampaigns))
+		}
+	}
+
+	orgId := businessAccount.OrganizationId.String()
+	ctaUrl := "/settings?tab=whatsapp-business-account"
+	notificationType := "PhoneNumberHealth"
+	app.NotificationService.SendInAppNotification(notification_service.InAppNotificationParams{
+		Title:          title,
+		Description:    description,
+		OrganizationId: &orgId,
+		CtaUrl:         &ctaUrl,
+		Type:           &notificationType,
+	})
+
+	if configuration.IsEmailNotificationEnabled {
+		var owners []struct {
+			model.OrganizationMember
+			User model.User
+		}
+		err := SELECT(table.OrganizationMember.AllColumns, table.User.AllColumns).
+			FROM(table.OrganizationMember.
+				INNER_JOIN(table.User, table.User.UniqueId.EQ(table.OrganizationMember.UserId)),
+			).
+			WHERE(
+				table.OrganizationMember.OrganizationId.EQ(UUID(businessAccount.OrganizationId)).
+					AND(table.OrganizationMember.AccessLevel.EQ(utils.EnumExpression(model.UserPermissionLevelEnum_Owner.String()))),
+			).
+			QueryContext(ctx, app.Db, &owners)
+
+		if err != nil {
+			app.Logger.Error("error fetching the owners to email about the phone number health", "organization_id", orgId, "error", err.Error())
+		}
+
+		for _, owner := range owners {
+			if err := app.NotificationService.SendEmail(owner.User.Email, title, description, app.Constants.IsProduction); err != nil {
+				app.Logger.Error("error emailing about the phone number health", "error", err.Error())
+			}
+		}
+	}
+
+	if configuration.IsSlackNotificationEnabled {
+		var organization model.Organization
+		err := SELECT(table.Organization.SlackWebhookUrl, table.Organization.SlackChannel).
+			FROM(table.Organization).
+			WHERE(table.Organization.UniqueId.EQ(UUID(businessAccount.OrganizationId))).
+			QueryContext(ctx, app.Db, &organization)
+
+		if err == nil && organization.SlackWebhookUrl != nil && *organization.SlackWebhookUrl != "" && organization.SlackChannel != nil {
+			app.NotificationService.SendSlackNotificationWithConfig(notification_service.SlackConfig{
+				SlackWebhookUrl: *organization.SlackWebhookUrl,
+				SlackChannel:    *organization.SlackChannel,
+			}, notification_service.SlackNotificationParams{
+				Title:   title,
+				Message: description,
+			})
+		}
+	}
+}
+
+func describePhoneNumberHealthEvent(healthEvent model.PhoneNumberHealthEvent) string {
+	previousValue, newValue := "", ""
+	if healthEvent.PreviousValue != nil {
+		previousValue = *healthEvent.PreviousValue
+	}
+	if healthEvent.NewValue != nil {
+		newValue = *healthEvent.NewValue
+	}
+
+	var change string
+	switch healthEvent.Type {
+	case model.PhoneNumberHealthEventTypeEnum_QualityRating:
+		change = fmt.Sprintf("the quality rating dropped from %s to %s", previousValue, newValue)
+	case model.PhoneNumberHealthEventTypeEnum_MessagingLimitTier:
+		change = fmt.Sprintf("the messaging limit dropped from %s to %s", previousValue, newValue)
+	case model.PhoneNumberHealthEventTypeEnum_NameStatus:
+		change = fmt.Sprintf("the display name is now %s", strings.ToLower(newValue))
+	case model.PhoneNumberHealthEventTypeEnum_AccountAlert:
+		change = "alert " + newValue
+	default:
+		change = "account update " + newValue
+	}
+
+	if healthEvent.Description != nil && *healthEvent.Description != "" {
+		change += " (" + *healthEvent.Description + ")"
+	}
+
+	return change
+}
@@ -21,5 +21,6 @@ func MountServices(app *interfaces.App) {
 		koa.String("ai.api_key"),
 		api_types.Gpt4o,
 		koa.String("ai.azure_endpoint"),
+		app.AiServiceConfig,
 	)
 }
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/knadh/koanf/providers/env"
 	"github.com/knadh/koanf/v2"
@@ -14,11 +16,16 @@ import (
 	"github.com/wapikit/wapikit/interfaces"
 	"github.com/wapikit/wapikit/internal/campaign_manager"
 	"github.com/wapikit/wapikit/internal/database"
+	"github.com/wapikit/wapikit/services/ai_service"
+	"github.com/wapikit/wapikit/services/business_account_service"
+	"github.com/wapikit/wapikit/services/chatbot_service"
 	"github.com/wapikit/wapikit/services/conversation_service"
 	"github.com/wapikit/wapikit/services/encryption_service"
 	"github.com/wapikit/wapikit/services/event_service"
+	"github.com/wapikit/wapikit/services/media_service"
 	"github.com/wapikit/wapikit/services/notification_service"
 	cache_service "github.com/wapikit/wapikit/services/redis_service"
+	"github.com/wapikit/wapikit/services/template_service"
 )
 
 // because this will be a single binary, we will be providing the flags here
@@ -163,10 +170,87 @@ func main() {
 	}
 
 	app.ConversationService = conversation_service.NewConversationService(dbInstance, logger, redisClient)
+	app.ConversationService.NotificationService = app.NotificationService
+	app.BusinessAccountService = business_account_service.NewBusinessAccountService(dbInstance, logger)
+	app.ConversationService.BusinessAccountService = app.BusinessAccountService
+	transcriptFallbackFonts, err := conversation_service.LoadTranscriptFonts(koa.Strings("conversation.transcript_fallback_fonts"))
+	if err != nil {
+		logger.Error("error loading the transcript fallback fonts", "error", err.Error())
+		os.Exit(1)
+	}
+	app.ConversationService.TranscriptFallbackFonts = transcriptFallbackFonts
+	app.ChatbotService = chatbot_service.NewChatbotService(dbInstance, logger, redisClient, app.ConversationService)
 	app.EventService = event_service.NewEventService(dbInstance, logger, redisClient, app.Constants.RedisApiServerEventChannelName)
+
+	// ===== INIT MEDIA SERVICE =====
+	var mediaStorage media_service.StorageBackend
+	if koa.String("media.storage") == "s3" {
+		mediaStorage, err = media_service.NewS3Storage(media_service.S3StorageConfig{
+			Endpoint:     koa.String("media.s3.endpoint"),
+			Region:       koa.String("media.s3.region"),
+			Bucket:       koa.String("media.s3.bucket"),
+			AccessKey:    koa.String("media.s3.access_key"),
+			SecretKey:    koa.String("media.s3.secret_key"),
+			UsePathStyle: koa.Bool("media.s3.use_path_style"),
+		})
+	} else {
+		mediaStoragePath := koa.String("media.filesystem_path")
+		if mediaStoragePath == "" {
+			mediaStoragePath = "uploads"
+		}
+		mediaStorage, err = media_service.NewFilesystemStorage(mediaStoragePath)
+	}
+
+	if err != nil {
+		logger.Error("error initializing media storage", "error", err.Error())
+		os.Exit(1)
+	}
+
+	mediaUrlSigningKey := koa.String("media.url_signing_key")
+	if mediaUrlSigningKey == "" {
+		mediaUrlSigningKey = koa.String("app.encryption_key")
+	}
+
+	app.MediaService = media_service.NewMediaService(dbInstance, logger, mediaStorage, media_service.MediaServiceConfig{
+		UrlSigningKey:   mediaUrlSigningKey,
+		SignedUrlExpiry: time.Duration(koa.Int64("media.signed_url_expiry_minutes")) * time.Minute,
+		MaxSizeInBytes:  koa.Int64("media.max_file_size_mb") << 20,
+	})
+	app.TemplateService = template_service.NewTemplateService(dbInstance, logger, redisClient, app.BusinessAccountService, app.MediaService)
 	app.CampaignManager = campaign_manager.NewCampaignManager(dbInstance, *logger, redisClient, nil, constants.RedisApiServerEventChannelName, constants.RedisCampaignManagerChannelName)
 	app.CampaignManager.NotificationService = app.NotificationService
 
+	// ===== CONFIGURE THE AI SERVICES =====
+	aiProviderApiKeys := map[ai_service.AiProvider]string{}
+	for provider, apiKey := range koa.StringMap("ai.router.api_keys") {
+		if apiKey != "" {
+			aiProviderApiKeys[ai_service.AiProvider(provider)] = apiKey
+		}
+	}
+	aiMaxRetries := ai_service.DefaultModelRouterConfig.MaxRetries
+	if koa.Exists("ai.router.max_retries") {
+		aiMaxRetries = koa.Int("ai.router.max_retries")
+	}
+	app.AiServiceConfig = ai_service.AiServiceConfig{
+		ModelRouter: ai_service.ModelRouterConfig{
+			Timeout:         time.Duration(koa.Int64("ai.router.timeout_seconds")) * time.Second,
+			MaxRetries:      aiMaxRetries,
+			RetryBackoff:    time.Duration(koa.Int64("ai.router.retry_backoff_ms")) * time.Millisecond,
+			ProviderApiKeys: aiProviderApiKeys,
+			UseStubProvider: koa.Bool("ai.router.use_stub_provider"),
+		},
+		SemanticCache: ai_service.SemanticCacheConfig{
+			Enabled:                   !koa.Exists("ai.semantic_cache.enabled") || koa.Bool("ai.semantic_cache.enabled"),
+			SimilarityThreshold:       koa.Float64("ai.semantic_cache.similarity_threshold"),
+			TTL:                       time.Duration(koa.Int64("ai.semantic_cache.ttl_minutes")) * time.Minute,
+			MaxEntriesPerOrganization: koa.Int64("ai.semantic_cache.max_entries_per_organization"),
+		},
+		NotificationService: app.NotificationService,
+		IsProduction:        constants.IsProduction,
+	}
+	app.ConversationService.AiServiceConfig = app.AiServiceConfig
+	app.ChatbotService.AiServiceConfig = app.AiServiceConfig
+
 	MountServices(app)
 	var wg sync.WaitGroup
 	wg.Add(3)
@@ -187,6 +271,13 @@ func main() {
 	}
 
 	if doStartAPIServer {
+		// * reopen the snoozed conversations and fire the follow-up reminders
+		go app.ConversationService.RunScheduler(context.Background())
+		// * time out the chatbot flow sessions the contacts did not reply to
+		go app.ChatbotService.RunScheduler(context.Background())
+		// * sync the message templates the webhooks missed
+		go app.TemplateService.RunScheduler(context.Background())
+
 		go func() {
 			defer wg.Done()
 			api.InitHTTPServer(app)
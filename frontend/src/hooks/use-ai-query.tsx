@@ -1,6 +1,7 @@
 import { useCallback, useRef, useState } from 'react'
 import { getBackendUrl } from '~/constants'
 import { useAuthState } from './use-auth-state'
+import { errorNotification } from '~/reusable-functions'
 
 const useAiQuery = (
 	params:
@@ -37,6 +38,11 @@ const useAiQuery = (
 					if (!currentMessageIdInStream.current) return
 					setCompleteResponse(data => data + parsedChunk.content)
 				} else if (parsedChunk.type === 'finish') {
+					if (parsedChunk.finishReason === 'error') {
+						errorNotification({
+							message: parsedChunk.error || 'The AI model failed to answer'
+						})
+					}
 					setResponseState('idle')
 					currentMessageIdInStream.current = null
 					return
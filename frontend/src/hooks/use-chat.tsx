@@ -46,7 +46,11 @@ const useChat = ({ chatId }: { chatId: string }) => {
 						if (!currentMessageIdInStream.current) return
 						updateChatMessage(currentMessageIdInStream.current, parsedChunk.content)
 					} else if (parsedChunk.type === 'finish') {
-						// ! TODO: AI: error handling here
+						if (parsedChunk.finishReason === 'error') {
+							errorNotification({
+								message: parsedChunk.error || 'The AI model failed to answer'
+							})
+						}
 						setChatBotState(ChatBotStateEnum.Idle)
 						currentMessageIdInStream.current = null
 						return
@@ -3,10 +3,11 @@ module github.com/wapikit/wapikit
 go 1.23.5
 
 require (
-	github.com/cockroachdb/redact v1.1.3
+	github.com/cockroachdb/redact v1.1.3 // indirect
 	github.com/gabriel-vasile/mimetype v1.4.8
 	github.com/go-jet/jet v2.3.0+incompatible
 	github.com/go-jet/jet/v2 v2.12.0
+	github.com/go-pdf/fpdf v0.9.0
 	github.com/go-redis/redis/v8 v8.11.5
 	github.com/go-redsync/redsync/v4 v4.13.0
 	github.com/golang-jwt/jwt v3.2.2+incompatible
@@ -31,6 +32,9 @@ require (
 	github.com/vmihailenco/msgpack/v5 v5.4.1
 	github.com/wapikit/wapi.go v0.1.3
 	golang.org/x/crypto v0.32.0
+	golang.org/x/image v0.12.0
+	golang.org/x/net v0.34.0
+	golang.org/x/text v0.21.0
 )
 
 require (
@@ -90,11 +94,9 @@ require (
 	go.opentelemetry.io/otel/metric v1.26.0 // indirect
 	go.opentelemetry.io/otel/trace v1.26.0 // indirect
 	golang.org/x/exp v0.0.0-20250128182459-e0ece0dbea4c // indirect
-	golang.org/x/net v0.34.0 // indirect
 	golang.org/x/oauth2 v0.21.0 // indirect
 	golang.org/x/sync v0.10.0 // indirect
 	golang.org/x/sys v0.29.0 // indirect
-	golang.org/x/text v0.21.0 // indirect
 	golang.org/x/time v0.9.0 // indirect
 	google.golang.org/api v0.183.0 // indirect
 	google.golang.org/genproto v0.0.0-20240528184218-531527333157 // indirect
@@ -16,29 +16,38 @@ import (
 	"github.com/wapikit/wapikit/api/api_types"
 	"github.com/wapikit/wapikit/internal/campaign_manager"
 	ai_service "github.com/wapikit/wapikit/services/ai_service"
+	"github.com/wapikit/wapikit/services/business_account_service"
+	"github.com/wapikit/wapikit/services/chatbot_service"
 	"github.com/wapikit/wapikit/services/conversation_service"
 	"github.com/wapikit/wapikit/services/encryption_service"
 	"github.com/wapikit/wapikit/services/event_service"
+	"github.com/wapikit/wapikit/services/media_service"
 	"github.com/wapikit/wapikit/services/notification_service"
 	cache_service "github.com/wapikit/wapikit/services/redis_service"
+	"github.com/wapikit/wapikit/services/template_service"
 
 	. "github.com/go-jet/jet/v2/postgres"
 )
 
 type App struct {
-	Db                  *sql.DB
-	Redis               *cache_service.RedisClient
-	WapiClient          *wapi.Client
-	Logger              slog.Logger
-	Koa                 *koanf.Koanf
-	Fs                  stuffbin.FileSystem
-	Constants           *Constants
-	CampaignManager     *campaign_manager.CampaignManager
-	AiService           *ai_service.AiService
-	EncryptionService   *encryption_service.EncryptionService
-	NotificationService *notification_service.NotificationService
-	ConversationService *conversation_service.ConversationService
-	EventService        *event_service.EventService
+	Db                     *sql.DB
+	Redis                  *cache_service.RedisClient
+	WapiClient             *wapi.Client
+	Logger                 slog.Logger
+	Koa                    *koanf.Koanf
+	Fs                     stuffbin.FileSystem
+	Constants              *Constants
+	CampaignManager        *campaign_manager.CampaignManager
+	AiService              *ai_service.AiService
+	AiServiceConfig        ai_service.AiServiceConfig
+	EncryptionService      *encryption_service.EncryptionService
+	NotificationService    *notification_service.NotificationService
+	ConversationService    *conversation_service.ConversationService
+	MediaService           *media_service.MediaService
+	BusinessAccountService *business_account_service.BusinessAccountService
+	EventService           *event_service.EventService
+	ChatbotService         *chatbot_service.ChatbotService
+	TemplateService        *template_service.TemplateService
 }
 
 type RateLimitConfig struct {
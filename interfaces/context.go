@@ -16,27 +16,36 @@ import (
 	"github.com/wapikit/wapikit/api/api_types"
 	"github.com/wapikit/wapikit/internal/campaign_manager"
 	ai_service "github.com/wapikit/wapikit/services/ai_service"
+	"github.com/wapikit/wapikit/services/business_account_service"
+	"github.com/wapikit/wapikit/services/chatbot_service"
 	"github.com/wapikit/wapikit/services/conversation_service"
 	"github.com/wapikit/wapikit/services/encryption_service"
 	"github.com/wapikit/wapikit/services/event_service"
+	"github.com/wapikit/wapikit/services/media_service"
 	"github.com/wapikit/wapikit/services/notification_service"
 	cache_service "github.com/wapikit/wapikit/services/redis_service"
+	"github.com/wapikit/wapikit/services/template_service"
 )
 
 type App struct {
-	Db                  *sql.DB
-	Redis               *cache_service.RedisClient
-	WapiClient          *wapi.Client
-	Logger              slog.Logger
-	Koa                 *koanf.Koanf
-	Fs                  stuffbin.FileSystem
-	Constants           *Constants
-	CampaignManager     *campaign_manager.CampaignManager
-	AiService           *ai_service.AiService
-	EncryptionService   *encryption_service.EncryptionService
-	NotificationService *notification_service.NotificationService
-	EventService        *event_service.EventService
-	ConversationService *conversation_service.ConversationService
+	Db                     *sql.DB
+	Redis                  *cache_service.RedisClient
+	WapiClient             *wapi.Client
+	Logger                 slog.Logger
+	Koa                    *koanf.Koanf
+	Fs                     stuffbin.FileSystem
+	Constants              *Constants
+	CampaignManager        *campaign_manager.CampaignManager
+	AiService              *ai_service.AiService
+	AiServiceConfig        ai_service.AiServiceConfig
+	EncryptionService      *encryption_service.EncryptionService
+	NotificationService    *notification_service.NotificationService
+	EventService           *event_service.EventService
+	ConversationService    *conversation_service.ConversationService
+	MediaService           *media_service.MediaService
+	BusinessAccountService *business_account_service.BusinessAccountService
+	ChatbotService         *chatbot_service.ChatbotService
+	TemplateService        *template_service.TemplateService
 }
 
 type RateLimitConfig struct {
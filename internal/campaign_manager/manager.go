@@ -295,6 +295,9 @@ func (cm *CampaignManager) queueRunningCampaigns() {
 	for {
 		select {
 		case <-ticker.C:
+			// * a campaign whose template got paused or rejected is paused before it is queued again
+			cm.pauseCampaignsWithUnsendableTemplates(context.Background())
+
 			currentRunningCampaignIds := cm.getRunningCampaignsUniqueIds()
 
 			cm.Logger.Debug("running campaigns", "campaigns", currentRunningCampaignIds)
@@ -324,7 +327,13 @@ func (cm *CampaignManager) queueRunningCampaigns() {
 			}
 
 			campaignsQuery := SELECT(table.Campaign.AllColumns, table.WhatsappBusinessAccount.AllColumns).
-				FROM(table.Campaign.LEFT_JOIN(table.WhatsappBusinessAccount, table.WhatsappBusinessAccount.OrganizationId.EQ(table.Campaign.OrganizationId))).
+				FROM(
+					// * the campaign is sent with the business account its phone number belongs to
+					table.Campaign.
+						LEFT_JOIN(table.WhatsappBusinessAccountPhoneNumber, table.WhatsappBusinessAccountPhoneNumber.PhoneNumberId.EQ(table.Campaign.PhoneNumber).
+							AND(table.WhatsappBusinessAccountPhoneNumber.OrganizationId.EQ(table.Campaign.OrganizationId))).
+						LEFT_JOIN(table.WhatsappBusinessAccount, table.WhatsappBusinessAccount.UniqueId.EQ(table.WhatsappBusinessAccountPhoneNumber.WhatsappBusinessAccountId)),
+				).
 				WHERE(whereCondition)
 
 			context := context.Background()
@@ -0,0 +1,84 @@
+package campaign_manager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/services/notification_service"
+	"github.com/wapikit/wapikit/utils"
+)
+
+// pauseCampaignsWithUnsendableTemplates pauses the running campaigns whose template whatsapp paused, rejected, disabled or deleted,
+// every message of such a campaign would fail, the template status is kept current by the template webhooks and sync
+func (cm *CampaignManager) pauseCampaignsWithUnsendableTemplates(ctx context.Context) {
+	var campaigns []struct {
+		model.Campaign
+		model.MessageTemplate
+	}
+
+	err := SELECT(table.Campaign.AllColumns, table.MessageTemplate.AllColumns).
+		FROM(
+			table.Campaign.
+				INNER_JOIN(table.MessageTemplate, table.MessageTemplate.TemplateId.EQ(table.Campaign.MessageTemplateId).
+					AND(table.MessageTemplate.OrganizationId.EQ(table.Campaign.OrganizationId))),
+		).
+		WHERE(
+			table.Campaign.Status.EQ(utils.EnumExpression(model.CampaignStatusEnum_Running.String())).
+				AND(table.MessageTemplate.Status.NOT_EQ(utils.EnumExpression(model.MessageTemplateStatusEnum_Approved.String()))),
+		).
+		QueryContext(ctx, cm.Db, &campaigns)
+
+	if err != nil {
+		cm.Logger.Error("error fetching the campaigns with an unsendable template", "error", err.Error())
+		return
+	}
+
+	for _, campaign := range campaigns {
+		var pausedCampaigns []model.Campaign
+		err := table.Campaign.
+			UPDATE(table.Campaign.Status, table.Campaign.UpdatedAt).
+			SET(utils.EnumExpression(model.CampaignStatusEnum_Paused.String()), time.Now()).
+			WHERE(
+				table.Campaign.UniqueId.EQ(UUID(campaign.Campaign.UniqueId)).
+					AND(table.Campaign.Status.EQ(utils.EnumExpression(model.CampaignStatusEnum_Running.String()))),
+			).
+			RETURNING(table.Campaign.UniqueId).
+			QueryContext(ctx, cm.Db, &pausedCampaigns)
+
+		if err != nil || len(pausedCampaigns) == 0 {
+			// * the campaign was paused or finished in the meantime
+			continue
+		}
+
+		cm.StopCampaign(campaign.Campaign.UniqueId.String())
+
+		cm.Logger.Info("paused the campaign as its template can not be sent", "campaign_id", campaign.Campaign.UniqueId.String(), "template_status", campaign.MessageTemplate.Status.String())
+
+		if cm.NotificationService == nil {
+			continue
+		}
+
+		orgId := campaign.Campaign.OrganizationId.String()
+		memberId := campaign.Campaign.CreatedByOrganizationMemberId.String()
+		ctaUrl := fmt.Sprintf("/campaigns/new-or-edit?id=%s", campaign.Campaign.UniqueId.String())
+		notificationType := "Campaign"
+		description := fmt.Sprintf("The campaign %s was paused because whatsapp marked its template %s as %s.", campaign.Campaign.Name, campaign.MessageTemplate.Name, strings.ToLower(campaign.MessageTemplate.Status.String()))
+		if campaign.MessageTemplate.StatusReason != nil {
+			description += " Reason: " + *campaign.MessageTemplate.StatusReason
+		}
+
+		cm.NotificationService.SendInAppNotification(notification_service.InAppNotificationParams{
+			Title:                "Campaign paused",
+			Description:          description,
+			OrganizationId:       &orgId,
+			OrganizationMemberId: &memberId,
+			CtaUrl:               &ctaUrl,
+			Type:                 &notificationType,
+		})
+	}
+}
@@ -0,0 +1,71 @@
+-- Add value to enum type: "OrgRolePermissionEnum"
+ALTER TYPE "public"."OrgRolePermissionEnum" ADD VALUE 'Get:CannedResponse';
+-- Add value to enum type: "OrgRolePermissionEnum"
+ALTER TYPE "public"."OrgRolePermissionEnum" ADD VALUE 'Create:CannedResponse';
+-- Add value to enum type: "OrgRolePermissionEnum"
+ALTER TYPE "public"."OrgRolePermissionEnum" ADD VALUE 'Update:CannedResponse';
+-- Add value to enum type: "OrgRolePermissionEnum"
+ALTER TYPE "public"."OrgRolePermissionEnum" ADD VALUE 'Delete:CannedResponse';
+-- Create enum type "CannedResponseVisibilityEnum"
+CREATE TYPE "public"."CannedResponseVisibilityEnum" AS ENUM ('Organization', 'Team', 'Personal');
+-- Create "CannedResponseFolder" table
+CREATE TABLE "public"."CannedResponseFolder" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "Name" text NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "CannedResponseFolderToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "CannedResponseFolderNameOrganizationIdUniqueIndex" to table: "CannedResponseFolder"
+CREATE UNIQUE INDEX "CannedResponseFolderNameOrganizationIdUniqueIndex" ON "public"."CannedResponseFolder" ("Name", "OrganizationId");
+-- Create "CannedResponse" table
+CREATE TABLE "public"."CannedResponse" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "Title" text NOT NULL,
+  "Shortcut" text NOT NULL,
+  "Content" text NOT NULL,
+  "Visibility" "public"."CannedResponseVisibilityEnum" NOT NULL,
+  "FolderId" uuid NULL,
+  "OrganizationRoleId" uuid NULL,
+  "CreatedByOrganizationMemberId" uuid NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "UsageCount" integer NOT NULL DEFAULT 0,
+  "LastUsedAt" timestamptz NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "CannedResponseToCannedResponseFolderForeignKey" FOREIGN KEY ("FolderId") REFERENCES "public"."CannedResponseFolder" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "CannedResponseToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "CannedResponseToOrganizationMemberForeignKey" FOREIGN KEY ("CreatedByOrganizationMemberId") REFERENCES "public"."OrganizationMember" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "CannedResponseToOrganizationRoleForeignKey" FOREIGN KEY ("OrganizationRoleId") REFERENCES "public"."OrganizationRole" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "CannedResponseFolderIdIndex" to table: "CannedResponse"
+CREATE INDEX "CannedResponseFolderIdIndex" ON "public"."CannedResponse" ("FolderId");
+-- Create index "CannedResponseOrganizationIdIndex" to table: "CannedResponse"
+CREATE INDEX "CannedResponseOrganizationIdIndex" ON "public"."CannedResponse" ("OrganizationId");
+-- Create index "CannedResponseShortcutOrganizationIdUniqueIndex" to table: "CannedResponse"
+CREATE UNIQUE INDEX "CannedResponseShortcutOrganizationIdUniqueIndex" ON "public"."CannedResponse" ("Shortcut", "OrganizationId") WHERE ("Visibility" <> 'Personal'::"public"."CannedResponseVisibilityEnum");
+-- Create index "CannedResponseShortcutOrganizationMemberIdUniqueIndex" to table: "CannedResponse"
+CREATE UNIQUE INDEX "CannedResponseShortcutOrganizationMemberIdUniqueIndex" ON "public"."CannedResponse" ("Shortcut", "CreatedByOrganizationMemberId") WHERE ("Visibility" = 'Personal'::"public"."CannedResponseVisibilityEnum");
+-- Create "CannedResponseUsageLog" table
+CREATE TABLE "public"."CannedResponseUsageLog" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "CannedResponseId" uuid NOT NULL,
+  "OrganizationMemberId" uuid NOT NULL,
+  "ConversationId" uuid NOT NULL,
+  "MessageId" uuid NULL,
+  "OrganizationId" uuid NOT NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "CannedResponseUsageLogToCannedResponseForeignKey" FOREIGN KEY ("CannedResponseId") REFERENCES "public"."CannedResponse" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "CannedResponseUsageLogToConversationForeignKey" FOREIGN KEY ("ConversationId") REFERENCES "public"."Conversation" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "CannedResponseUsageLogToMessageForeignKey" FOREIGN KEY ("MessageId") REFERENCES "public"."Message" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "CannedResponseUsageLogToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "CannedResponseUsageLogToOrganizationMemberForeignKey" FOREIGN KEY ("OrganizationMemberId") REFERENCES "public"."OrganizationMember" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "CannedResponseUsageLogCannedResponseIdIndex" to table: "CannedResponseUsageLog"
+CREATE INDEX "CannedResponseUsageLogCannedResponseIdIndex" ON "public"."CannedResponseUsageLog" ("CannedResponseId");
+-- Create index "CannedResponseUsageLogOrganizationIdIndex" to table: "CannedResponseUsageLog"
+CREATE INDEX "CannedResponseUsageLogOrganizationIdIndex" ON "public"."CannedResponseUsageLog" ("OrganizationId");
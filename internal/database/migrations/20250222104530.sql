@@ -0,0 +1,26 @@
+-- Add value to enum type: "ConversationStatusEnum"
+ALTER TYPE "public"."ConversationStatusEnum" ADD VALUE 'Snoozed';
+-- Modify "Conversation" table
+ALTER TABLE "public"."Conversation" ADD COLUMN "SnoozedUntil" timestamptz NULL, ADD COLUMN "IsSnoozedUntilContactReply" boolean NOT NULL DEFAULT false;
+-- Create index "ConversationSnoozedUntilIndex" to table: "Conversation"
+CREATE INDEX "ConversationSnoozedUntilIndex" ON "public"."Conversation" ("SnoozedUntil");
+-- Create "ConversationReminder" table
+CREATE TABLE "public"."ConversationReminder" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "ConversationId" uuid NOT NULL,
+  "OrganizationMemberId" uuid NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "RemindAt" timestamptz NOT NULL,
+  "Note" text NULL,
+  "FiredAt" timestamptz NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "ConversationReminderToConversationForeignKey" FOREIGN KEY ("ConversationId") REFERENCES "public"."Conversation" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "ConversationReminderToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "ConversationReminderToOrganizationMemberForeignKey" FOREIGN KEY ("OrganizationMemberId") REFERENCES "public"."OrganizationMember" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "ConversationReminderConversationIdIndex" to table: "ConversationReminder"
+CREATE INDEX "ConversationReminderConversationIdIndex" ON "public"."ConversationReminder" ("ConversationId");
+-- Create index "ConversationReminderRemindAtIndex" to table: "ConversationReminder"
+CREATE INDEX "ConversationReminderRemindAtIndex" ON "public"."ConversationReminder" ("RemindAt");
@@ -0,0 +1,4 @@
+-- Create index "ContactFullTextSearchIndex" to table: "Contact"
+CREATE INDEX "ContactFullTextSearchIndex" ON "public"."Contact" USING GIN ((to_tsvector('simple'::regconfig, COALESCE("Name", '') || ' ' || COALESCE("PhoneNumber", '')) || jsonb_to_tsvector('simple'::regconfig, COALESCE("Attributes", '{}'::jsonb), '["string", "numeric"]'::jsonb)));
+-- Create index "MessageFullTextSearchIndex" to table: "Message"
+CREATE INDEX "MessageFullTextSearchIndex" ON "public"."Message" USING GIN ((to_tsvector('simple'::regconfig, COALESCE("MessageData" ->> 'text', '') || ' ' || COALESCE("MessageData" ->> 'caption', ''))));
@@ -0,0 +1,2 @@
+-- Add value to enum type: "MessageTypeEnum"
+ALTER TYPE "public"."MessageTypeEnum" ADD VALUE 'InteractiveReply';
@@ -0,0 +1,8 @@
+-- Add value to enum type: "MessageTypeEnum"
+ALTER TYPE "public"."MessageTypeEnum" ADD VALUE 'Order';
+-- Add value to enum type: "MessageTypeEnum"
+ALTER TYPE "public"."MessageTypeEnum" ADD VALUE 'ProductInquiry';
+-- Modify "Message" table
+ALTER TABLE "public"."Message" ADD COLUMN "Reactions" jsonb NULL;
+-- Create index "MessageWhatsAppMessageIdIndex" to table: "Message"
+CREATE INDEX "MessageWhatsAppMessageIdIndex" ON "public"."Message" ("WhatsAppMessageId");
@@ -0,0 +1,23 @@
+-- Create enum type "MediaStorageBackendEnum"
+CREATE TYPE "public"."MediaStorageBackendEnum" AS ENUM ('Filesystem', 'S3');
+-- Create "MediaFile" table
+CREATE TABLE "public"."MediaFile" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "WhatsAppMediaId" text NULL,
+  "Sha256" text NOT NULL,
+  "MimeType" text NOT NULL,
+  "SizeInBytes" bigint NOT NULL,
+  "FileName" text NULL,
+  "StorageBackend" "public"."MediaStorageBackendEnum" NOT NULL,
+  "StorageKey" text NOT NULL,
+  "ThumbnailStorageKey" text NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "MediaFileToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "MediaFileOrganizationIdSha256Index" to table: "MediaFile"
+CREATE INDEX "MediaFileOrganizationIdSha256Index" ON "public"."MediaFile" ("OrganizationId", "Sha256");
+-- Create index "MediaFileOrganizationIdWhatsAppMediaIdUniqueIndex" to table: "MediaFile"
+CREATE UNIQUE INDEX "MediaFileOrganizationIdWhatsAppMediaIdUniqueIndex" ON "public"."MediaFile" ("OrganizationId", "WhatsAppMediaId");
@@ -0,0 +1,35 @@
+-- Modify "WhatsappBusinessAccount" table
+ALTER TABLE "public"."WhatsappBusinessAccount" ADD COLUMN "Name" text NULL;
+-- Create "WhatsappBusinessAccountPhoneNumber" table
+CREATE TABLE "public"."WhatsappBusinessAccountPhoneNumber" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "WhatsappBusinessAccountId" uuid NOT NULL,
+  "PhoneNumberId" text NOT NULL,
+  "DisplayPhoneNumber" text NULL,
+  "VerifiedName" text NULL,
+  "IsDefault" boolean NOT NULL DEFAULT false,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "WhatsappBusinessAccountPhoneNumberToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "WhatsappBusinessAccountPhoneNumberToWhatsappBusinessAccountForeignKey" FOREIGN KEY ("WhatsappBusinessAccountId") REFERENCES "public"."WhatsappBusinessAccount" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "WhatsappBusinessAccountPhoneNumberOrganizationIdIndex" to table: "WhatsappBusinessAccountPhoneNumber"
+CREATE INDEX "WhatsappBusinessAccountPhoneNumberOrganizationIdIndex" ON "public"."WhatsappBusinessAccountPhoneNumber" ("OrganizationId");
+-- Create index "WhatsappBusinessAccountPhoneNumberPhoneNumberIdUniqueIndex" to table: "WhatsappBusinessAccountPhoneNumber"
+CREATE UNIQUE INDEX "WhatsappBusinessAccountPhoneNumberPhoneNumberIdUniqueIndex" ON "public"."WhatsappBusinessAccountPhoneNumber" ("PhoneNumberId");
+-- Create index "WhatsappBusinessAccountPhoneNumberWhatsappBusinessAccountIdIndex" to table: "WhatsappBusinessAccountPhoneNumber"
+CREATE INDEX "WhatsappBusinessAccountPhoneNumberWhatsappBusinessAccountIdIndex" ON "public"."WhatsappBusinessAccountPhoneNumber" ("WhatsappBusinessAccountId");
+-- Backfill the phone numbers already in use, organizations had a single business account until now
+INSERT INTO "public"."WhatsappBusinessAccountPhoneNumber" ("UpdatedAt", "OrganizationId", "WhatsappBusinessAccountId", "PhoneNumberId", "IsDefault")
+SELECT DISTINCT ON ("PhoneNumberId") now(), "OrganizationId", "WhatsappBusinessAccountId", "PhoneNumberId", false
+FROM (
+  SELECT w."OrganizationId", w."UniqueId" AS "WhatsappBusinessAccountId", w."PhoneNumberId" FROM "public"."WhatsappBusinessAccount" w
+  UNION ALL
+  SELECT c."OrganizationId", w."UniqueId", c."PhoneNumberUsed" FROM "public"."Conversation" c JOIN "public"."WhatsappBusinessAccount" w ON w."OrganizationId" = c."OrganizationId"
+  UNION ALL
+  SELECT c."OrganizationId", w."UniqueId", c."PhoneNumber" FROM "public"."Campaign" c JOIN "public"."WhatsappBusinessAccount" w ON w."OrganizationId" = c."OrganizationId"
+) AS "PhoneNumbersInUse"
+WHERE "PhoneNumberId" <> ''
+ON CONFLICT DO NOTHING;
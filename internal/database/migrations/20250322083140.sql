@@ -0,0 +1,56 @@
+-- Create enum type "ConversationBulkActionEnum"
+CREATE TYPE "public"."ConversationBulkActionEnum" AS ENUM ('Assign', 'Close', 'Tag', 'Untag', 'MarkRead');
+-- Create enum type "ConversationBulkActionJobStatusEnum"
+CREATE TYPE "public"."ConversationBulkActionJobStatusEnum" AS ENUM ('Queued', 'InProgress', 'Completed', 'Errored');
+-- Create enum type "ConversationTaggingRuleTypeEnum"
+CREATE TYPE "public"."ConversationTaggingRuleTypeEnum" AS ENUM ('Keyword', 'ContactAttribute', 'OriginatingCampaign');
+-- Create "ConversationBulkActionJob" table
+CREATE TABLE "public"."ConversationBulkActionJob" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "CreatedByOrganizationMemberId" uuid NOT NULL,
+  "Action" "public"."ConversationBulkActionEnum" NOT NULL,
+  "Status" "public"."ConversationBulkActionJobStatusEnum" NOT NULL,
+  "Selection" jsonb NOT NULL,
+  "Parameters" jsonb NULL,
+  "TotalCount" integer NOT NULL DEFAULT 0,
+  "ProcessedCount" integer NOT NULL DEFAULT 0,
+  "FailedCount" integer NOT NULL DEFAULT 0,
+  "Error" text NULL,
+  "StartedAt" timestamptz NULL,
+  "CompletedAt" timestamptz NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "ConversationBulkActionJobToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "ConversationBulkActionJobToOrganizationMemberForeignKey" FOREIGN KEY ("CreatedByOrganizationMemberId") REFERENCES "public"."OrganizationMember" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "ConversationBulkActionJobOrganizationIdIndex" to table: "ConversationBulkActionJob"
+CREATE INDEX "ConversationBulkActionJobOrganizationIdIndex" ON "public"."ConversationBulkActionJob" ("OrganizationId");
+-- Create index "ConversationBulkActionJobStatusIndex" to table: "ConversationBulkActionJob"
+CREATE INDEX "ConversationBulkActionJobStatusIndex" ON "public"."ConversationBulkActionJob" ("Status");
+-- Create "ConversationTaggingRule" table
+CREATE TABLE "public"."ConversationTaggingRule" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "Name" text NOT NULL,
+  "Type" "public"."ConversationTaggingRuleTypeEnum" NOT NULL,
+  "IsEnabled" boolean NOT NULL DEFAULT true,
+  "Keywords" jsonb NULL,
+  "AttributeKey" text NULL,
+  "AttributeValue" text NULL,
+  "CampaignId" uuid NULL,
+  "TagId" uuid NOT NULL,
+  "CreatedByOrganizationMemberId" uuid NOT NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "ConversationTaggingRuleToCampaignForeignKey" FOREIGN KEY ("CampaignId") REFERENCES "public"."Campaign" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "ConversationTaggingRuleToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "ConversationTaggingRuleToOrganizationMemberForeignKey" FOREIGN KEY ("CreatedByOrganizationMemberId") REFERENCES "public"."OrganizationMember" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "ConversationTaggingRuleToTagForeignKey" FOREIGN KEY ("TagId") REFERENCES "public"."Tag" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "ConversationTaggingRuleOrganizationIdIndex" to table: "ConversationTaggingRule"
+CREATE INDEX "ConversationTaggingRuleOrganizationIdIndex" ON "public"."ConversationTaggingRule" ("OrganizationId");
+-- Create index "ConversationTaggingRuleTagIdIndex" to table: "ConversationTaggingRule"
+CREATE INDEX "ConversationTaggingRuleTagIdIndex" ON "public"."ConversationTaggingRule" ("TagId");
@@ -0,0 +1,51 @@
+-- Create enum type "CsatSurveyStatusEnum"
+CREATE TYPE "public"."CsatSurveyStatusEnum" AS ENUM ('Sent', 'AwaitingComment', 'Completed', 'Expired');
+-- Create "CsatSurveyConfiguration" table
+CREATE TABLE "public"."CsatSurveyConfiguration" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "IsEnabled" boolean NOT NULL DEFAULT false,
+  "Question" text NOT NULL,
+  "CommentPrompt" text NULL,
+  "ThankYouMessage" text NULL,
+  "ResponseWindowInHours" integer NOT NULL DEFAULT 24,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "CsatSurveyConfigurationToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "CsatSurveyConfigurationOrganizationIdIndex" to table: "CsatSurveyConfiguration"
+CREATE UNIQUE INDEX "CsatSurveyConfigurationOrganizationIdIndex" ON "public"."CsatSurveyConfiguration" ("OrganizationId");
+-- Create "CsatSurveyResponse" table
+CREATE TABLE "public"."CsatSurveyResponse" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "ConversationId" uuid NOT NULL,
+  "ContactId" uuid NOT NULL,
+  "AssignedToOrganizationMemberId" uuid NULL,
+  "PhoneNumberUsed" text NOT NULL,
+  "WhatsAppMessageId" text NULL,
+  "CommentPromptWhatsAppMessageId" text NULL,
+  "Status" "public"."CsatSurveyStatusEnum" NOT NULL,
+  "Rating" integer NULL,
+  "Comment" text NULL,
+  "SentAt" timestamptz NOT NULL,
+  "ExpiresAt" timestamptz NOT NULL,
+  "RatedAt" timestamptz NULL,
+  "CommentedAt" timestamptz NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "CsatSurveyResponseToContactForeignKey" FOREIGN KEY ("ContactId") REFERENCES "public"."Contact" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "CsatSurveyResponseToConversationForeignKey" FOREIGN KEY ("ConversationId") REFERENCES "public"."Conversation" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "CsatSurveyResponseToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "CsatSurveyResponseToOrganizationMemberForeignKey" FOREIGN KEY ("AssignedToOrganizationMemberId") REFERENCES "public"."OrganizationMember" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "CsatSurveyResponseAssignedToOrganizationMemberIdIndex" to table: "CsatSurveyResponse"
+CREATE INDEX "CsatSurveyResponseAssignedToOrganizationMemberIdIndex" ON "public"."CsatSurveyResponse" ("AssignedToOrganizationMemberId");
+-- Create index "CsatSurveyResponseContactIdStatusIndex" to table: "CsatSurveyResponse"
+CREATE INDEX "CsatSurveyResponseContactIdStatusIndex" ON "public"."CsatSurveyResponse" ("ContactId", "Status");
+-- Create index "CsatSurveyResponseConversationIdIndex" to table: "CsatSurveyResponse"
+CREATE UNIQUE INDEX "CsatSurveyResponseConversationIdIndex" ON "public"."CsatSurveyResponse" ("ConversationId");
+-- Create index "CsatSurveyResponseOrganizationIdIndex" to table: "CsatSurveyResponse"
+CREATE INDEX "CsatSurveyResponseOrganizationIdIndex" ON "public"."CsatSurveyResponse" ("OrganizationId");
@@ -0,0 +1,59 @@
+-- Add value to enum type: "OrgRolePermissionEnum"
+ALTER TYPE "public"."OrgRolePermissionEnum" ADD VALUE 'Get:ChatbotFlow';
+-- Add value to enum type: "OrgRolePermissionEnum"
+ALTER TYPE "public"."OrgRolePermissionEnum" ADD VALUE 'Create:ChatbotFlow';
+-- Add value to enum type: "OrgRolePermissionEnum"
+ALTER TYPE "public"."OrgRolePermissionEnum" ADD VALUE 'Update:ChatbotFlow';
+-- Add value to enum type: "OrgRolePermissionEnum"
+ALTER TYPE "public"."OrgRolePermissionEnum" ADD VALUE 'Delete:ChatbotFlow';
+-- Create enum type "ChatbotFlowSessionStatusEnum"
+CREATE TYPE "public"."ChatbotFlowSessionStatusEnum" AS ENUM ('Waiting', 'Finished', 'HandedOff', 'TimedOut', 'Failed');
+-- Create "ChatbotFlow" table
+CREATE TABLE "public"."ChatbotFlow" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "Name" text NOT NULL,
+  "Description" text NULL,
+  "IsEnabled" boolean NOT NULL DEFAULT false,
+  "Definition" jsonb NOT NULL,
+  "TriggerKeywords" jsonb NULL,
+  "TriggerOnNewConversation" boolean NOT NULL DEFAULT false,
+  "Priority" integer NOT NULL DEFAULT 0,
+  "SessionTimeoutInMinutes" integer NOT NULL DEFAULT 60,
+  "CreatedByOrganizationMemberId" uuid NOT NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "ChatbotFlowToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "ChatbotFlowToOrganizationMemberForeignKey" FOREIGN KEY ("CreatedByOrganizationMemberId") REFERENCES "public"."OrganizationMember" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "ChatbotFlowOrganizationIdIndex" to table: "ChatbotFlow"
+CREATE INDEX "ChatbotFlowOrganizationIdIndex" ON "public"."ChatbotFlow" ("OrganizationId");
+-- Create "ChatbotFlowSession" table
+CREATE TABLE "public"."ChatbotFlowSession" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "ChatbotFlowId" uuid NOT NULL,
+  "ConversationId" uuid NOT NULL,
+  "ContactId" uuid NOT NULL,
+  "Status" "public"."ChatbotFlowSessionStatusEnum" NOT NULL,
+  "CurrentStepId" text NOT NULL,
+  "Variables" jsonb NULL,
+  "LastReply" jsonb NULL,
+  "ExpiresAt" timestamptz NULL,
+  "EndedAt" timestamptz NULL,
+  "Error" text NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "ChatbotFlowSessionToChatbotFlowForeignKey" FOREIGN KEY ("ChatbotFlowId") REFERENCES "public"."ChatbotFlow" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "ChatbotFlowSessionToContactForeignKey" FOREIGN KEY ("ContactId") REFERENCES "public"."Contact" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "ChatbotFlowSessionToConversationForeignKey" FOREIGN KEY ("ConversationId") REFERENCES "public"."Conversation" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "ChatbotFlowSessionToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "ChatbotFlowSessionChatbotFlowIdIndex" to table: "ChatbotFlowSession"
+CREATE INDEX "ChatbotFlowSessionChatbotFlowIdIndex" ON "public"."ChatbotFlowSession" ("ChatbotFlowId");
+-- Create index "ChatbotFlowSessionConversationIdStatusIndex" to table: "ChatbotFlowSession"
+CREATE INDEX "ChatbotFlowSessionConversationIdStatusIndex" ON "public"."ChatbotFlowSession" ("ConversationId", "Status");
+-- Create index "ChatbotFlowSessionStatusExpiresAtIndex" to table: "ChatbotFlowSession"
+CREATE INDEX "ChatbotFlowSessionStatusExpiresAtIndex" ON "public"."ChatbotFlowSession" ("Status", "ExpiresAt");
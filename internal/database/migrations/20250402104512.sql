@@ -0,0 +1,40 @@
+-- Create enum type "AiHandoffReasonEnum"
+CREATE TYPE "public"."AiHandoffReasonEnum" AS ENUM ('LowConfidence', 'ContactRequestedHuman', 'ForbiddenTopic', 'MaxTurnsReached', 'AiError');
+-- Modify "Message" table
+ALTER TABLE "public"."Message" ADD COLUMN "IsAiGenerated" boolean NOT NULL DEFAULT false;
+-- Create "AiAutoResponderConfiguration" table
+CREATE TABLE "public"."AiAutoResponderConfiguration" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "IsEnabled" boolean NOT NULL DEFAULT false,
+  "Instructions" text NULL,
+  "MinConfidence" integer NOT NULL DEFAULT 70,
+  "MaxAiTurns" integer NOT NULL DEFAULT 5,
+  "ForbiddenTopics" jsonb NULL,
+  "HandoffOrganizationRoleId" uuid NULL,
+  "HandoffMessage" text NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "AiAutoResponderConfigurationToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "AiAutoResponderConfigurationToOrganizationRoleForeignKey" FOREIGN KEY ("HandoffOrganizationRoleId") REFERENCES "public"."OrganizationRole" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "AiAutoResponderConfigurationOrganizationIdIndex" to table: "AiAutoResponderConfiguration"
+CREATE UNIQUE INDEX "AiAutoResponderConfigurationOrganizationIdIndex" ON "public"."AiAutoResponderConfiguration" ("OrganizationId");
+-- Create "AiAutoResponderHandoff" table
+CREATE TABLE "public"."AiAutoResponderHandoff" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "ConversationId" uuid NOT NULL,
+  "Reason" "public"."AiHandoffReasonEnum" NOT NULL,
+  "AssignedToOrganizationMemberId" uuid NULL,
+  "Details" text NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "AiAutoResponderHandoffToConversationForeignKey" FOREIGN KEY ("ConversationId") REFERENCES "public"."Conversation" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "AiAutoResponderHandoffToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "AiAutoResponderHandoffToOrganizationMemberForeignKey" FOREIGN KEY ("AssignedToOrganizationMemberId") REFERENCES "public"."OrganizationMember" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "AiAutoResponderHandoffConversationIdIndex" to table: "AiAutoResponderHandoff"
+CREATE UNIQUE INDEX "AiAutoResponderHandoffConversationIdIndex" ON "public"."AiAutoResponderHandoff" ("ConversationId");
@@ -0,0 +1,51 @@
+-- Create extension "vector"
+CREATE EXTENSION IF NOT EXISTS "vector";
+-- Create enum type "KnowledgeBaseSourceTypeEnum"
+CREATE TYPE "public"."KnowledgeBaseSourceTypeEnum" AS ENUM ('FaqList', 'PdfDocument', 'WebPage');
+-- Create enum type "KnowledgeBaseDocumentStatusEnum"
+CREATE TYPE "public"."KnowledgeBaseDocumentStatusEnum" AS ENUM ('Indexing', 'Indexed', 'IndexingFailed');
+-- Modify "AiChatMessage" table
+ALTER TABLE "public"."AiChatMessage" ADD COLUMN "Citations" jsonb NULL;
+-- Create "KnowledgeBaseDocument" table
+CREATE TABLE "public"."KnowledgeBaseDocument" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "Title" text NOT NULL,
+  "SourceType" "public"."KnowledgeBaseSourceTypeEnum" NOT NULL,
+  "SourceUrl" text NULL,
+  "Content" text NOT NULL,
+  "Status" "public"."KnowledgeBaseDocumentStatusEnum" NOT NULL DEFAULT 'Indexing',
+  "Error" text NULL,
+  "ChunkCount" integer NOT NULL DEFAULT 0,
+  "EmbeddingModel" text NULL,
+  "CreatedByOrganizationMemberId" uuid NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "KnowledgeBaseDocumentToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "KnowledgeBaseDocumentToOrganizationMemberForeignKey" FOREIGN KEY ("CreatedByOrganizationMemberId") REFERENCES "public"."OrganizationMember" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "KnowledgeBaseDocumentOrganizationIdIndex" to table: "KnowledgeBaseDocument"
+CREATE INDEX "KnowledgeBaseDocumentOrganizationIdIndex" ON "public"."KnowledgeBaseDocument" ("OrganizationId");
+-- Create "KnowledgeBaseChunk" table
+CREATE TABLE "public"."KnowledgeBaseChunk" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "KnowledgeBaseDocumentId" uuid NOT NULL,
+  "ChunkIndex" integer NOT NULL,
+  "Content" text NOT NULL,
+  "Embedding" public.vector(1536) NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "KnowledgeBaseChunkToKnowledgeBaseDocumentForeignKey" FOREIGN KEY ("KnowledgeBaseDocumentId") REFERENCES "public"."KnowledgeBaseDocument" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "KnowledgeBaseChunkToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "KnowledgeBaseChunkDocumentIdIndex" to table: "KnowledgeBaseChunk"
+CREATE UNIQUE INDEX "KnowledgeBaseChunkDocumentIdIndex" ON "public"."KnowledgeBaseChunk" ("KnowledgeBaseDocumentId", "ChunkIndex");
+-- Create index "KnowledgeBaseChunkEmbeddingIndex" to table: "KnowledgeBaseChunk"
+CREATE INDEX "KnowledgeBaseChunkEmbeddingIndex" ON "public"."KnowledgeBaseChunk" USING hnsw ("Embedding" vector_cosine_ops);
+-- Create index "KnowledgeBaseChunkFullTextSearchIndex" to table: "KnowledgeBaseChunk"
+CREATE INDEX "KnowledgeBaseChunkFullTextSearchIndex" ON "public"."KnowledgeBaseChunk" USING GIN ((to_tsvector('simple'::regconfig, "Content")));
+-- Create index "KnowledgeBaseChunkOrganizationIdIndex" to table: "KnowledgeBaseChunk"
+CREATE INDEX "KnowledgeBaseChunkOrganizationIdIndex" ON "public"."KnowledgeBaseChunk" ("OrganizationId");
@@ -0,0 +1,2 @@
+-- Modify "AiApiCallLogs" table
+ALTER TABLE "public"."AiApiCallLogs" ADD COLUMN "IsCacheHit" boolean NOT NULL DEFAULT false, ADD COLUMN "CacheSimilarity" double precision NULL;
@@ -0,0 +1,22 @@
+-- Modify "Organization" table
+ALTER TABLE "public"."Organization" ADD COLUMN "IsConversationSummaryOnCloseEnabled" boolean NOT NULL DEFAULT false;
+-- Create "ConversationSummary" table
+CREATE TABLE "public"."ConversationSummary" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "ConversationId" uuid NOT NULL,
+  "LastMessageId" uuid NOT NULL,
+  "Content" text NOT NULL,
+  "Model" "public"."AiModelEnum" NOT NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "ConversationSummaryToConversationForeignKey" FOREIGN KEY ("ConversationId") REFERENCES "public"."Conversation" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "ConversationSummaryToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "ConversationSummaryConversationIdIndex" to table: "ConversationSummary"
+CREATE UNIQUE INDEX "ConversationSummaryConversationIdIndex" ON "public"."ConversationSummary" ("ConversationId");
+-- Create index "ConversationSummaryFullTextSearchIndex" to table: "ConversationSummary"
+CREATE INDEX "ConversationSummaryFullTextSearchIndex" ON "public"."ConversationSummary" USING GIN ((to_tsvector('simple'::regconfig, "Content")));
+-- Create index "ConversationSummaryOrganizationIdIndex" to table: "ConversationSummary"
+CREATE INDEX "ConversationSummaryOrganizationIdIndex" ON "public"."ConversationSummary" ("OrganizationId");
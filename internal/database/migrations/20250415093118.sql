@@ -0,0 +1,39 @@
+-- Modify "Tag" table
+ALTER TABLE "public"."Tag" DROP CONSTRAINT "UniqueLabel";
+-- Create "ContactTag" table
+CREATE TABLE "public"."ContactTag" (
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "ContactId" uuid NOT NULL,
+  "TagId" uuid NOT NULL,
+  PRIMARY KEY ("ContactId", "TagId"),
+  CONSTRAINT "ContactTagToContactForeignKey" FOREIGN KEY ("ContactId") REFERENCES "public"."Contact" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "ContactTagToTagForeignKey" FOREIGN KEY ("TagId") REFERENCES "public"."Tag" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "ContactTagTagIdIndex" to table: "ContactTag"
+CREATE INDEX "ContactTagTagIdIndex" ON "public"."ContactTag" ("TagId");
+-- Create "SegmentRecommendationJob" table
+CREATE TABLE "public"."SegmentRecommendationJob" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "CreatedByOrganizationMemberId" uuid NOT NULL,
+  "ContactListId" uuid NOT NULL,
+  "Status" "public"."ConversationBulkActionJobStatusEnum" NOT NULL,
+  "Parameters" jsonb NULL,
+  "TotalCount" integer NOT NULL DEFAULT 0,
+  "ProcessedCount" integer NOT NULL DEFAULT 0,
+  "FailedCount" integer NOT NULL DEFAULT 0,
+  "Error" text NULL,
+  "StartedAt" timestamptz NULL,
+  "CompletedAt" timestamptz NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "SegmentRecommendationJobToContactListForeignKey" FOREIGN KEY ("ContactListId") REFERENCES "public"."ContactList" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "SegmentRecommendationJobToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "SegmentRecommendationJobToOrganizationMemberForeignKey" FOREIGN KEY ("CreatedByOrganizationMemberId") REFERENCES "public"."OrganizationMember" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "SegmentRecommendationJobOrganizationIdIndex" to table: "SegmentRecommendationJob"
+CREATE INDEX "SegmentRecommendationJobOrganizationIdIndex" ON "public"."SegmentRecommendationJob" ("OrganizationId");
+-- Create index "SegmentRecommendationJobStatusIndex" to table: "SegmentRecommendationJob"
+CREATE INDEX "SegmentRecommendationJobStatusIndex" ON "public"."SegmentRecommendationJob" ("Status");
@@ -0,0 +1,20 @@
+-- Modify "AiApiCallLogs" table
+ALTER TABLE "public"."AiApiCallLogs" ADD COLUMN "Feature" text NOT NULL DEFAULT 'unknown', ADD COLUMN "OrganizationMemberId" uuid NULL, ADD COLUMN "CostInUsd" double precision NOT NULL DEFAULT 0, ADD CONSTRAINT "AiApiCallLogsToOrganizationMemberForeignKey" FOREIGN KEY ("OrganizationMemberId") REFERENCES "public"."OrganizationMember" ("UniqueId") ON UPDATE NO ACTION ON DELETE SET NULL;
+-- Create index "AiApiCallLogsOrganizationIdCreatedAtIndex" to table: "AiApiCallLogs"
+CREATE INDEX "AiApiCallLogsOrganizationIdCreatedAtIndex" ON "public"."AiApiCallLogs" ("OrganizationId", "CreatedAt");
+-- Create "AiBudgetConfiguration" table
+CREATE TABLE "public"."AiBudgetConfiguration" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "MonthlyTokenLimit" bigint NULL,
+  "MonthlyCostLimitInUsd" double precision NULL,
+  "SoftLimitPercentage" integer NOT NULL DEFAULT 80,
+  "IsHardLimitEnabled" boolean NOT NULL DEFAULT true,
+  "SoftLimitNotifiedAt" timestamptz NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "AiBudgetConfigurationToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "AiBudgetConfigurationOrganizationIdIndex" to table: "AiBudgetConfiguration"
+CREATE UNIQUE INDEX "AiBudgetConfigurationOrganizationIdIndex" ON "public"."AiBudgetConfiguration" ("OrganizationId");
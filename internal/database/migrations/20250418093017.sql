@@ -0,0 +1,14 @@
+-- Create "AiPiiRedactionConfiguration" table
+CREATE TABLE "public"."AiPiiRedactionConfiguration" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "IsEnabled" boolean NOT NULL DEFAULT true,
+  "EnabledDetectors" jsonb NULL,
+  "CustomDetectors" jsonb NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "AiPiiRedactionConfigurationToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "AiPiiRedactionConfigurationOrganizationIdIndex" to table: "AiPiiRedactionConfiguration"
+CREATE UNIQUE INDEX "AiPiiRedactionConfigurationOrganizationIdIndex" ON "public"."AiPiiRedactionConfiguration" ("OrganizationId");
@@ -0,0 +1,27 @@
+-- Create enum type "MessageSentimentEnum"
+CREATE TYPE "public"."MessageSentimentEnum" AS ENUM ('Positive', 'Neutral', 'Negative');
+-- Create enum type "MessageIntentEnum"
+CREATE TYPE "public"."MessageIntentEnum" AS ENUM ('Purchase', 'Support', 'Complaint', 'Inquiry', 'Feedback', 'Greeting', 'Other');
+-- Create enum type "ConversationPriorityEnum"
+CREATE TYPE "public"."ConversationPriorityEnum" AS ENUM ('Low', 'Normal', 'High', 'Urgent');
+-- Modify "Conversation" table
+ALTER TABLE "public"."Conversation" ADD COLUMN "Sentiment" "public"."MessageSentimentEnum" NULL, ADD COLUMN "Intent" "public"."MessageIntentEnum" NULL, ADD COLUMN "Language" text NULL, ADD COLUMN "Priority" "public"."ConversationPriorityEnum" NOT NULL DEFAULT 'Normal';
+-- Create index "ConversationOrganizationIdPriorityIndex" to table: "Conversation"
+CREATE INDEX "ConversationOrganizationIdPriorityIndex" ON "public"."Conversation" ("OrganizationId", "Priority");
+-- Modify "Message" table
+ALTER TABLE "public"."Message" ADD COLUMN "Sentiment" "public"."MessageSentimentEnum" NULL, ADD COLUMN "Intent" "public"."MessageIntentEnum" NULL, ADD COLUMN "Language" text NULL, ADD COLUMN "ClassifiedAt" timestamptz NULL;
+-- Create "MessageClassificationConfiguration" table
+CREATE TABLE "public"."MessageClassificationConfiguration" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "IsEnabled" boolean NOT NULL DEFAULT false,
+  "EscalationOrganizationRoleId" uuid NULL,
+  "EscalationPriority" "public"."ConversationPriorityEnum" NOT NULL DEFAULT 'Urgent',
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "MessageClassificationConfigurationToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "MessageClassificationConfigurationToOrganizationRoleForeignKey" FOREIGN KEY ("EscalationOrganizationRoleId") REFERENCES "public"."OrganizationRole" ("UniqueId") ON UPDATE NO ACTION ON DELETE SET NULL
+);
+-- Create index "MessageClassificationConfigurationOrganizationIdIndex" to table: "MessageClassificationConfiguration"
+CREATE UNIQUE INDEX "MessageClassificationConfigurationOrganizationIdIndex" ON "public"."MessageClassificationConfiguration" ("OrganizationId");
@@ -0,0 +1,38 @@
+-- Add value to enum type: "OrgRolePermissionEnum"
+ALTER TYPE "public"."OrgRolePermissionEnum" ADD VALUE 'Create:MessageTemplates';
+-- Add value to enum type: "OrgRolePermissionEnum"
+ALTER TYPE "public"."OrgRolePermissionEnum" ADD VALUE 'Update:MessageTemplates';
+-- Add value to enum type: "OrgRolePermissionEnum"
+ALTER TYPE "public"."OrgRolePermissionEnum" ADD VALUE 'Delete:MessageTemplates';
+-- Create enum type "MessageTemplateStatusEnum"
+CREATE TYPE "public"."MessageTemplateStatusEnum" AS ENUM ('Approved', 'Pending', 'Rejected', 'Paused', 'Disabled', 'InAppeal', 'PendingDeletion', 'Deleted', 'LimitExceeded', 'Archived');
+-- Create enum type "MessageTemplateQualityEnum"
+CREATE TYPE "public"."MessageTemplateQualityEnum" AS ENUM ('Green', 'Yellow', 'Red', 'Unknown');
+-- Create enum type "MessageTemplateCategoryEnum"
+CREATE TYPE "public"."MessageTemplateCategoryEnum" AS ENUM ('Marketing', 'Utility', 'Authentication');
+-- Create "MessageTemplate" table
+CREATE TABLE "public"."MessageTemplate" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "WhatsappBusinessAccountId" uuid NOT NULL,
+  "TemplateId" text NOT NULL,
+  "Name" text NOT NULL,
+  "Language" text NOT NULL,
+  "Category" "public"."MessageTemplateCategoryEnum" NOT NULL,
+  "Status" "public"."MessageTemplateStatusEnum" NOT NULL,
+  "Quality" "public"."MessageTemplateQualityEnum" NOT NULL DEFAULT 'Unknown',
+  "StatusReason" text NULL,
+  "Components" jsonb NOT NULL DEFAULT '[]'::jsonb,
+  "LastSyncedAt" timestamptz NULL,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "MessageTemplateToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "MessageTemplateToWhatsappBusinessAccountForeignKey" FOREIGN KEY ("WhatsappBusinessAccountId") REFERENCES "public"."WhatsappBusinessAccount" ("UniqueId") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "MessageTemplateTemplateIdUniqueIndex" to table: "MessageTemplate"
+CREATE UNIQUE INDEX "MessageTemplateTemplateIdUniqueIndex" ON "public"."MessageTemplate" ("TemplateId");
+-- Create index "MessageTemplateOrganizationIdStatusIndex" to table: "MessageTemplate"
+CREATE INDEX "MessageTemplateOrganizationIdStatusIndex" ON "public"."MessageTemplate" ("OrganizationId", "Status");
+-- Create index "MessageTemplateWhatsappBusinessAccountIdIndex" to table: "MessageTemplate"
+CREATE INDEX "MessageTemplateWhatsappBusinessAccountIdIndex" ON "public"."MessageTemplate" ("WhatsappBusinessAccountId");
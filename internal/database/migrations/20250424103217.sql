@@ -0,0 +1,45 @@
+-- Create enum type "PhoneNumberQualityRatingEnum"
+CREATE TYPE "public"."PhoneNumberQualityRatingEnum" AS ENUM ('Green', 'Yellow', 'Red', 'Unknown');
+-- Create enum type "PhoneNumberMessagingLimitTierEnum"
+CREATE TYPE "public"."PhoneNumberMessagingLimitTierEnum" AS ENUM ('Tier50', 'Tier250', 'Tier1K', 'Tier2K', 'Tier10K', 'Tier100K', 'TierUnlimited', 'Unknown');
+-- Create enum type "PhoneNumberNameStatusEnum"
+CREATE TYPE "public"."PhoneNumberNameStatusEnum" AS ENUM ('Approved', 'AvailableWithoutReview', 'PendingReview', 'Declined', 'Expired', 'None', 'Unknown');
+-- Create enum type "PhoneNumberHealthEventTypeEnum"
+CREATE TYPE "public"."PhoneNumberHealthEventTypeEnum" AS ENUM ('QualityRating', 'MessagingLimitTier', 'NameStatus', 'AccountAlert', 'AccountUpdate');
+-- Modify "WhatsappBusinessAccountPhoneNumber" table
+ALTER TABLE "public"."WhatsappBusinessAccountPhoneNumber" ADD COLUMN "QualityRating" "public"."PhoneNumberQualityRatingEnum" NOT NULL DEFAULT 'Unknown', ADD COLUMN "MessagingLimitTier" "public"."PhoneNumberMessagingLimitTierEnum" NOT NULL DEFAULT 'Unknown', ADD COLUMN "NameStatus" "public"."PhoneNumberNameStatusEnum" NOT NULL DEFAULT 'Unknown', ADD COLUMN "HealthUpdatedAt" timestamptz NULL;
+-- Create "PhoneNumberHealthEvent" table
+CREATE TABLE "public"."PhoneNumberHealthEvent" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "OrganizationId" uuid NOT NULL,
+  "WhatsappBusinessAccountId" uuid NOT NULL,
+  "PhoneNumberId" uuid NULL,
+  "Type" "public"."PhoneNumberHealthEventTypeEnum" NOT NULL,
+  "PreviousValue" text NULL,
+  "NewValue" text NULL,
+  "Description" text NULL,
+  "IsDowngrade" boolean NOT NULL DEFAULT false,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "PhoneNumberHealthEventToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION,
+  CONSTRAINT "PhoneNumberHealthEventToWhatsappBusinessAccountForeignKey" FOREIGN KEY ("WhatsappBusinessAccountId") REFERENCES "public"."WhatsappBusinessAccount" ("UniqueId") ON UPDATE NO ACTION ON DELETE CASCADE,
+  CONSTRAINT "PhoneNumberHealthEventToWhatsappBusinessAccountPhoneNumberForeignKey" FOREIGN KEY ("PhoneNumberId") REFERENCES "public"."WhatsappBusinessAccountPhoneNumber" ("UniqueId") ON UPDATE NO ACTION ON DELETE CASCADE
+);
+-- Create index "PhoneNumberHealthEventPhoneNumberIdCreatedAtIndex" to table: "PhoneNumberHealthEvent"
+CREATE INDEX "PhoneNumberHealthEventPhoneNumberIdCreatedAtIndex" ON "public"."PhoneNumberHealthEvent" ("PhoneNumberId", "CreatedAt");
+-- Create index "PhoneNumberHealthEventWhatsappBusinessAccountIdCreatedAtIndex" to table: "PhoneNumberHealthEvent"
+CREATE INDEX "PhoneNumberHealthEventWhatsappBusinessAccountIdCreatedAtIndex" ON "public"."PhoneNumberHealthEvent" ("WhatsappBusinessAccountId", "CreatedAt");
+-- Create "PhoneNumberHealthConfiguration" table
+CREATE TABLE "public"."PhoneNumberHealthConfiguration" (
+  "UniqueId" uuid NOT NULL DEFAULT gen_random_uuid(),
+  "CreatedAt" timestamptz NOT NULL DEFAULT now(),
+  "UpdatedAt" timestamptz NOT NULL,
+  "OrganizationId" uuid NOT NULL,
+  "IsCampaignAutoPauseEnabled" boolean NOT NULL DEFAULT false,
+  "IsEmailNotificationEnabled" boolean NOT NULL DEFAULT true,
+  "IsSlackNotificationEnabled" boolean NOT NULL DEFAULT true,
+  PRIMARY KEY ("UniqueId"),
+  CONSTRAINT "PhoneNumberHealthConfigurationToOrganizationForeignKey" FOREIGN KEY ("OrganizationId") REFERENCES "public"."Organization" ("UniqueId") ON UPDATE NO ACTION ON DELETE NO ACTION
+);
+-- Create index "PhoneNumberHealthConfigurationOrganizationIdIndex" to table: "PhoneNumberHealthConfiguration"
+CREATE UNIQUE INDEX "PhoneNumberHealthConfigurationOrganizationIdIndex" ON "public"."PhoneNumberHealthConfiguration" ("OrganizationId");
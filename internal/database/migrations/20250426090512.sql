@@ -0,0 +1,2 @@
+-- Modify "Organization" table
+ALTER TABLE "public"."Organization" ADD COLUMN "IsAiCrossProviderFallbackEnabled" boolean NOT NULL DEFAULT false;
@@ -25,7 +25,7 @@ enum "ContactStatusEnum" {
 
 enum "ConversationStatusEnum" {
   schema = schema.public
-  values = ["Active", "Closed", "Deleted", "Resolved"]
+  values = ["Active", "Closed", "Deleted", "Resolved", "Snoozed"]
 }
 
 enum "MessageDirectionEnum" {
@@ -106,7 +106,18 @@ enum "OrgRolePermissionEnum" {
     "Delete:OrganizationRole",
     "Update:IntegrationSettings",
     "Get:MessageTemplates",
-    "Get:PhoneNumbers"
+    "Get:PhoneNumbers",
+    "Get:CannedResponse",
+    "Create:CannedResponse",
+    "Update:CannedResponse",
+    "Delete:CannedResponse",
+    "Get:ChatbotFlow",
+    "Create:ChatbotFlow",
+    "Update:ChatbotFlow",
+    "Delete:ChatbotFlow",
+    "Create:MessageTemplates",
+    "Update:MessageTemplates",
+    "Delete:MessageTemplates"
   ]
 }
 
@@ -124,10 +135,18 @@ enum "MessageTypeEnum" {
     "Reaction",
     "Address",
     "Interactive",
-    "Template"
+    "Template",
+    "InteractiveReply",
+    "Order",
+    "ProductInquiry"
   ]
 }
 
+enum "MediaStorageBackendEnum" {
+  schema = schema.public
+  values = ["Filesystem", "S3"]
+}
+
 enum "CountryEnum" {
   schema = schema.public
   values = [
@@ -170,6 +189,101 @@ enum "AiChatMessageRoleEnum" {
   values = ["System", "User", "Assistant", "Data"]
 }
 
+enum "CannedResponseVisibilityEnum" {
+  schema = schema.public
+  values = ["Organization", "Team", "Personal"]
+}
+
+enum "ConversationBulkActionEnum" {
+  schema = schema.public
+  values = ["Assign", "Close", "Tag", "Untag", "MarkRead"]
+}
+
+enum "ConversationBulkActionJobStatusEnum" {
+  schema = schema.public
+  values = ["Queued", "InProgress", "Completed", "Errored"]
+}
+
+enum "ConversationTaggingRuleTypeEnum" {
+  schema = schema.public
+  values = ["Keyword", "ContactAttribute", "OriginatingCampaign"]
+}
+
+enum "CsatSurveyStatusEnum" {
+  schema = schema.public
+  values = ["Sent", "AwaitingComment", "Completed", "Expired"]
+}
+
+enum "ChatbotFlowSessionStatusEnum" {
+  schema = schema.public
+  values = ["Waiting", "Finished", "HandedOff", "TimedOut", "Failed"]
+}
+
+enum "AiHandoffReasonEnum" {
+  schema = schema.public
+  values = ["LowConfidence", "ContactRequestedHuman", "ForbiddenTopic", "MaxTurnsReached", "AiError"]
+}
+
+enum "KnowledgeBaseSourceTypeEnum" {
+  schema = schema.public
+  values = ["FaqList", "PdfDocument", "WebPage"]
+}
+
+enum "KnowledgeBaseDocumentStatusEnum" {
+  schema = schema.public
+  values = ["Indexing", "Indexed", "IndexingFailed"]
+}
+
+enum "MessageSentimentEnum" {
+  schema = schema.public
+  values = ["Positive", "Neutral", "Negative"]
+}
+
+enum "MessageIntentEnum" {
+  schema = schema.public
+  values = ["Purchase", "Support", "Complaint", "Inquiry", "Feedback", "Greeting", "Other"]
+}
+
+enum "ConversationPriorityEnum" {
+  schema = schema.public
+  values = ["Low", "Normal", "High", "Urgent"]
+}
+
+enum "MessageTemplateStatusEnum" {
+  schema = schema.public
+  values = ["Approved", "Pending", "Rejected", "Paused", "Disabled", "InAppeal", "PendingDeletion", "Deleted", "LimitExceeded", "Archived"]
+}
+
+enum "MessageTemplateQualityEnum" {
+  schema = schema.public
+  values = ["Green", "Yellow", "Red", "Unknown"]
+}
+
+enum "MessageTemplateCategoryEnum" {
+  schema = schema.public
+  values = ["Marketing", "Utility", "Authentication"]
+}
+
+enum "PhoneNumberQualityRatingEnum" {
+  schema = schema.public
+  values = ["Green", "Yellow", "Red", "Unknown"]
+}
+
+enum "PhoneNumberMessagingLimitTierEnum" {
+  schema = schema.public
+  values = ["Tier50", "Tier250", "Tier1K", "Tier2K", "Tier10K", "Tier100K", "TierUnlimited", "Unknown"]
+}
+
+enum "PhoneNumberNameStatusEnum" {
+  schema = schema.public
+  values = ["Approved", "AvailableWithoutReview", "PendingReview", "Declined", "Expired", "None", "Unknown"]
+}
+
+enum "PhoneNumberHealthEventTypeEnum" {
+  schema = schema.public
+  values = ["QualityRating", "MessagingLimitTier", "NameStatus", "AccountAlert", "AccountUpdate"]
+}
+
 // ===== PRIMARY TABLES ====
 
 table "User" {
@@ -327,6 +441,19 @@ table "Organization" {
     null = false
   }
 
+  column "IsConversationSummaryOnCloseEnabled" {
+    type    = boolean
+    default = false
+    null    = false
+  }
+
+  # the AI calls may fall back to the models of other providers on the keys of the platform, only asked when the organization brings its own key
+  column "IsAiCrossProviderFallbackEnabled" {
+    type    = boolean
+    default = false
+    null    = false
+  }
+
   primary_key {
     columns = [column.UniqueId]
   }
@@ -715,6 +842,12 @@ table "WhatsappBusinessAccount" {
     null = false
   }
 
+  // label of the business account to tell multiple accounts of an organization apart, e.g. a country or a brand
+  column "Name" {
+    type = text
+    null = true
+  }
+
   primary_key {
     columns = [column.UniqueId]
   }
@@ -736,6 +869,112 @@ table "WhatsappBusinessAccount" {
   }
 }
 
+table "WhatsappBusinessAccountPhoneNumber" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  column "WhatsappBusinessAccountId" {
+    type = uuid
+    null = false
+  }
+
+  // id of the phone number in the whatsapp cloud API, the conversations, messages and campaigns refer to the phone number by this id
+  column "PhoneNumberId" {
+    type = text
+    null = false
+  }
+
+  column "DisplayPhoneNumber" {
+    type = text
+    null = true
+  }
+
+  column "VerifiedName" {
+    type = text
+    null = true
+  }
+
+  // the phone number used when nothing else is chosen, only one phone number of an organization is the default
+  column "IsDefault" {
+    type    = boolean
+    null    = false
+    default = false
+  }
+
+  // the health of the phone number as last told by whatsapp, every change is kept in the PhoneNumberHealthEvent table
+  column "QualityRating" {
+    type    = enum.PhoneNumberQualityRatingEnum
+    null    = false
+    default = "Unknown"
+  }
+
+  column "MessagingLimitTier" {
+    type    = enum.PhoneNumberMessagingLimitTierEnum
+    null    = false
+    default = "Unknown"
+  }
+
+  column "NameStatus" {
+    type    = enum.PhoneNumberNameStatusEnum
+    null    = false
+    default = "Unknown"
+  }
+
+  column "HealthUpdatedAt" {
+    type = timestamptz
+    null = true
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "WhatsappBusinessAccountPhoneNumberToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "WhatsappBusinessAccountPhoneNumberToWhatsappBusinessAccountForeignKey" {
+    columns     = [column.WhatsappBusinessAccountId]
+    ref_columns = [table.WhatsappBusinessAccount.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "WhatsappBusinessAccountPhoneNumberPhoneNumberIdUniqueIndex" {
+    columns = [column.PhoneNumberId]
+    unique  = true
+  }
+
+  index "WhatsappBusinessAccountPhoneNumberOrganizationIdIndex" {
+    columns = [column.OrganizationId]
+  }
+
+  index "WhatsappBusinessAccountPhoneNumberWhatsappBusinessAccountIdIndex" {
+    columns = [column.WhatsappBusinessAccountId]
+  }
+}
+
 table "Contact" {
   schema = schema.public
   column "UniqueId" {
@@ -797,6 +1036,13 @@ table "Contact" {
     columns = [column.OrganizationId, column.PhoneNumber]
     unique  = true
   }
+
+  index "ContactFullTextSearchIndex" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, COALESCE(\"Name\", '') || ' ' || COALESCE(\"PhoneNumber\", '')) || jsonb_to_tsvector('simple'::regconfig, COALESCE(\"Attributes\", '{}'::jsonb), '[\"string\", \"numeric\"]'::jsonb)"
+    }
+  }
 }
 
 table "ContactList" {
@@ -1001,6 +1247,39 @@ table "Conversation" {
     null = true
   }
 
+  column "SnoozedUntil" {
+    type = timestamptz
+    null = true
+  }
+
+  column "IsSnoozedUntilContactReply" {
+    type    = boolean
+    null    = false
+    default = false
+  }
+
+  # the classification of the last inbound message of the contact, set in the background by the AI
+  column "Sentiment" {
+    type = enum.MessageSentimentEnum
+    null = true
+  }
+
+  column "Intent" {
+    type = enum.MessageIntentEnum
+    null = true
+  }
+
+  column "Language" {
+    type = text
+    null = true
+  }
+
+  column "Priority" {
+    type    = enum.ConversationPriorityEnum
+    null    = false
+    default = "Normal"
+  }
+
   primary_key {
     columns = [column.UniqueId]
   }
@@ -1036,6 +1315,14 @@ table "Conversation" {
   index "ConversationInitiatedByCampaignIdIndex" {
     columns = [column.InitiatedByCampaignId]
   }
+
+  index "ConversationSnoozedUntilIndex" {
+    columns = [column.SnoozedUntil]
+  }
+
+  index "ConversationOrganizationIdPriorityIndex" {
+    columns = [column.OrganizationId, column.Priority]
+  }
 }
 
 table "ConversationAssignment" {
@@ -1097,6 +1384,87 @@ table "ConversationAssignment" {
 
 }
 
+table "ConversationReminder" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "ConversationId" {
+    type = uuid
+    null = false
+  }
+
+  column "OrganizationMemberId" {
+    type = uuid
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  column "RemindAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "Note" {
+    type = text
+    null = true
+  }
+
+  column "FiredAt" {
+    type = timestamptz
+    null = true
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "ConversationReminderToConversationForeignKey" {
+    columns     = [column.ConversationId]
+    ref_columns = [table.Conversation.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "ConversationReminderToOrganizationMemberForeignKey" {
+    columns     = [column.OrganizationMemberId]
+    ref_columns = [table.OrganizationMember.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "ConversationReminderToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "ConversationReminderConversationIdIndex" {
+    columns = [column.ConversationId]
+  }
+
+  index "ConversationReminderRemindAtIndex" {
+    columns = [column.RemindAt]
+  }
+}
+
 table "Message" {
   schema = schema.public
   column "UniqueId" {
@@ -1179,7 +1547,42 @@ table "Message" {
     null = true
   }
 
-  primary_key {
+  # reactions of the contact and the agents on this message, reactions are never stored as standalone messages
+  column "Reactions" {
+    type = jsonb
+    null = true
+  }
+
+  # the message was written by the AI auto responder, not by a member of the organization
+  column "IsAiGenerated" {
+    type    = boolean
+    null    = false
+    default = false
+  }
+
+  # the classification of an inbound message, set in the background by the AI when the organization enabled it
+  column "Sentiment" {
+    type = enum.MessageSentimentEnum
+    null = true
+  }
+
+  column "Intent" {
+    type = enum.MessageIntentEnum
+    null = true
+  }
+
+  # the ISO 639-1 code of the language of the message
+  column "Language" {
+    type = text
+    null = true
+  }
+
+  column "ClassifiedAt" {
+    type = timestamptz
+    null = true
+  }
+
+  primary_key {
     columns = [column.UniqueId]
   }
 
@@ -1238,6 +1641,105 @@ table "Message" {
   index "MessagePhoneNumberUsedIndex" {
     columns = [column.PhoneNumberUsed]
   }
+
+  index "MessageWhatsAppMessageIdIndex" {
+    columns = [column.WhatsAppMessageId]
+  }
+
+  index "MessageFullTextSearchIndex" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, COALESCE(\"MessageData\" ->> 'text', '') || ' ' || COALESCE(\"MessageData\" ->> 'caption', ''))"
+    }
+  }
+}
+
+# media files of the messages, downloaded from whatsapp on receipt so they outlive the expiring whatsapp media urls
+table "MediaFile" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  # media id on the whatsapp business platform, used by the messages to reference the media
+  column "WhatsAppMediaId" {
+    type = text
+    null = true
+  }
+
+  # hex encoded sha256 of the content, files with the same content share the same stored object
+  column "Sha256" {
+    type = text
+    null = false
+  }
+
+  column "MimeType" {
+    type = text
+    null = false
+  }
+
+  column "SizeInBytes" {
+    type = bigint
+    null = false
+  }
+
+  column "FileName" {
+    type = text
+    null = true
+  }
+
+  column "StorageBackend" {
+    type = enum.MediaStorageBackendEnum
+    null = false
+  }
+
+  column "StorageKey" {
+    type = text
+    null = false
+  }
+
+  column "ThumbnailStorageKey" {
+    type = text
+    null = true
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "MediaFileToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "MediaFileOrganizationIdWhatsAppMediaIdUniqueIndex" {
+    columns = [column.OrganizationId, column.WhatsAppMediaId]
+    unique  = true
+  }
+
+  index "MediaFileOrganizationIdSha256Index" {
+    columns = [column.OrganizationId, column.Sha256]
+  }
 }
 
 table "TrackLink" {
@@ -1399,11 +1901,6 @@ table "Tag" {
     columns = [column.UniqueId]
   }
 
-  unique "UniqueLabel" {
-    columns = [column.Label]
-  }
-
-
   foreign_key "TagToOrganizationForeignKey" {
     columns     = [column.OrganizationId]
     ref_columns = [table.Organization.column.UniqueId]
@@ -1421,9 +1918,8 @@ table "Tag" {
   }
 }
 
-table "Integration" {
+table "CannedResponseFolder" {
   schema = schema.public
-
   column "UniqueId" {
     type    = uuid
     null    = false
@@ -1439,27 +1935,13 @@ table "Integration" {
     null = false
   }
 
-  primary_key {
-    columns = [column.UniqueId]
+  column "Name" {
+    type = text
+    null = false
   }
-}
-
-// this stores the installed integration for a Organization
-table "OrganizationIntegration" {
-  schema = schema.public
 
-  column "UniqueId" {
-    type    = uuid
-    null    = false
-    default = sql("gen_random_uuid()")
-  }
-  column "CreatedAt" {
-    type    = timestamptz
-    null    = false
-    default = sql("now()")
-  }
-  column "UpdatedAt" {
-    type = timestamptz
+  column "OrganizationId" {
+    type = uuid
     null = false
   }
 
@@ -1467,11 +1949,21 @@ table "OrganizationIntegration" {
     columns = [column.UniqueId]
   }
 
+  foreign_key "CannedResponseFolderToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "CannedResponseFolderNameOrganizationIdUniqueIndex" {
+    columns = [column.Name, column.OrganizationId]
+    unique  = true
+  }
 }
 
-table "Notification" {
+table "CannedResponse" {
   schema = schema.public
-
   column "UniqueId" {
     type    = uuid
     null    = false
@@ -1487,40 +1979,57 @@ table "Notification" {
     null = false
   }
 
-  column "ctaUrl" {
+  column "Title" {
     type = text
-    null = true
+    null = false
   }
 
-  column "title" {
+  # shortcut typed by the agents in the inbox composer, for example "/thanks"
+  column "Shortcut" {
     type = text
     null = false
   }
 
-  column "description" {
+  # content may contain variables like {{contact.name}}, {{agent.name}} or {{contact.attributes.<key>}}
+  column "Content" {
     type = text
     null = false
   }
 
-  column "type" {
-    type = text
-    null = true
+  column "Visibility" {
+    type = enum.CannedResponseVisibilityEnum
+    null = false
   }
 
-  column "isBroadcast" {
-    type    = boolean
-    default = false
-    null    = false
+  column "FolderId" {
+    type = uuid
+    null = true
   }
 
-  // if the above broadcast is true then the org id and org member id can be null, because the notification has been sent to all platform users
-  column "OrganizationMemberId" {
+  # for team visibility, only the members having this role assigned can use the response
+  column "OrganizationRoleId" {
     type = uuid
     null = true
   }
 
+  column "CreatedByOrganizationMemberId" {
+    type = uuid
+    null = false
+  }
+
   column "OrganizationId" {
     type = uuid
+    null = false
+  }
+
+  column "UsageCount" {
+    type    = int
+    null    = false
+    default = 0
+  }
+
+  column "LastUsedAt" {
+    type = timestamptz
     null = true
   }
 
@@ -1528,36 +2037,57 @@ table "Notification" {
     columns = [column.UniqueId]
   }
 
-  foreign_key "NotificationToOrganizationMemberForeignKey" {
-    columns     = [column.OrganizationMemberId]
-    ref_columns = [table.OrganizationMember.column.UniqueId]
+  foreign_key "CannedResponseToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
     on_delete   = NO_ACTION
     on_update   = NO_ACTION
   }
 
-  foreign_key "NotificationToOrganizationForeignKey" {
-    columns     = [column.OrganizationId]
-    ref_columns = [table.Organization.column.UniqueId]
+  foreign_key "CannedResponseToCannedResponseFolderForeignKey" {
+    columns     = [column.FolderId]
+    ref_columns = [table.CannedResponseFolder.column.UniqueId]
     on_delete   = NO_ACTION
     on_update   = NO_ACTION
   }
 
-  index "NotificationOrganizationMemberIdIndex" {
-    columns = [column.OrganizationMemberId]
+  foreign_key "CannedResponseToOrganizationRoleForeignKey" {
+    columns     = [column.OrganizationRoleId]
+    ref_columns = [table.OrganizationRole.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
   }
 
-  index "NotificationOrganizationIdIndex" {
+  foreign_key "CannedResponseToOrganizationMemberForeignKey" {
+    columns     = [column.CreatedByOrganizationMemberId]
+    ref_columns = [table.OrganizationMember.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "CannedResponseOrganizationIdIndex" {
     columns = [column.OrganizationId]
   }
 
-  index "NotificationTypeIndex" {
-    columns = [column.type]
+  index "CannedResponseFolderIdIndex" {
+    columns = [column.FolderId]
+  }
+
+  index "CannedResponseShortcutOrganizationIdUniqueIndex" {
+    columns = [column.Shortcut, column.OrganizationId]
+    unique  = true
+    where   = "(\"Visibility\" <> 'Personal'::\"CannedResponseVisibilityEnum\")"
+  }
+
+  index "CannedResponseShortcutOrganizationMemberIdUniqueIndex" {
+    columns = [column.Shortcut, column.CreatedByOrganizationMemberId]
+    unique  = true
+    where   = "(\"Visibility\" = 'Personal'::\"CannedResponseVisibilityEnum\")"
   }
 }
 
-table "NotificationReadLog" {
+table "CannedResponseUsageLog" {
   schema = schema.public
-
   column "UniqueId" {
     type    = uuid
     null    = false
@@ -1568,17 +2098,28 @@ table "NotificationReadLog" {
     null    = false
     default = sql("now()")
   }
-  column "UpdatedAt" {
-    type = timestamptz
+
+  column "CannedResponseId" {
+    type = uuid
     null = false
   }
 
-  column "ReadByUserId" {
+  column "OrganizationMemberId" {
     type = uuid
     null = false
   }
 
-  column "NotificationId" {
+  column "ConversationId" {
+    type = uuid
+    null = false
+  }
+
+  column "MessageId" {
+    type = uuid
+    null = true
+  }
+
+  column "OrganizationId" {
     type = uuid
     null = false
   }
@@ -1587,30 +2128,51 @@ table "NotificationReadLog" {
     columns = [column.UniqueId]
   }
 
-  foreign_key "NotificationReadLogToNotificationForeignKey" {
-    columns     = [column.NotificationId]
-    ref_columns = [table.Notification.column.UniqueId]
+  foreign_key "CannedResponseUsageLogToCannedResponseForeignKey" {
+    columns     = [column.CannedResponseId]
+    ref_columns = [table.CannedResponse.column.UniqueId]
     on_delete   = NO_ACTION
     on_update   = NO_ACTION
   }
 
-  foreign_key "NotificationReadLogToUserForeignKey" {
-    columns     = [column.ReadByUserId]
-    ref_columns = [table.User.column.UniqueId]
+  foreign_key "CannedResponseUsageLogToOrganizationMemberForeignKey" {
+    columns     = [column.OrganizationMemberId]
+    ref_columns = [table.OrganizationMember.column.UniqueId]
     on_delete   = NO_ACTION
     on_update   = NO_ACTION
   }
 
-  index "NotificationReadLogNotificationIdIndex" {
-    columns = [column.NotificationId]
+  foreign_key "CannedResponseUsageLogToConversationForeignKey" {
+    columns     = [column.ConversationId]
+    ref_columns = [table.Conversation.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
   }
 
-  index "NotificationReadLogReadByUserIdIndex" {
-    columns = [column.ReadByUserId]
+  foreign_key "CannedResponseUsageLogToMessageForeignKey" {
+    columns     = [column.MessageId]
+    ref_columns = [table.Message.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "CannedResponseUsageLogToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "CannedResponseUsageLogCannedResponseIdIndex" {
+    columns = [column.CannedResponseId]
+  }
+
+  index "CannedResponseUsageLogOrganizationIdIndex" {
+    columns = [column.OrganizationId]
   }
 }
 
-table "AiChat" {
+table "Integration" {
   schema = schema.public
 
   column "UniqueId" {
@@ -1618,22 +2180,211 @@ table "AiChat" {
     null    = false
     default = sql("gen_random_uuid()")
   }
-
   column "CreatedAt" {
     type    = timestamptz
     null    = false
     default = sql("now()")
   }
-
   column "UpdatedAt" {
     type = timestamptz
     null = false
   }
 
-  column "Status" {
-    type    = enum.AiChatStatusEnum
-    null    = false
-    default = "Active"
+  primary_key {
+    columns = [column.UniqueId]
+  }
+}
+
+// this stores the installed integration for a Organization
+table "OrganizationIntegration" {
+  schema = schema.public
+
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+}
+
+table "Notification" {
+  schema = schema.public
+
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "ctaUrl" {
+    type = text
+    null = true
+  }
+
+  column "title" {
+    type = text
+    null = false
+  }
+
+  column "description" {
+    type = text
+    null = false
+  }
+
+  column "type" {
+    type = text
+    null = true
+  }
+
+  column "isBroadcast" {
+    type    = boolean
+    default = false
+    null    = false
+  }
+
+  // if the above broadcast is true then the org id and org member id can be null, because the notification has been sent to all platform users
+  column "OrganizationMemberId" {
+    type = uuid
+    null = true
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = true
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "NotificationToOrganizationMemberForeignKey" {
+    columns     = [column.OrganizationMemberId]
+    ref_columns = [table.OrganizationMember.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "NotificationToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "NotificationOrganizationMemberIdIndex" {
+    columns = [column.OrganizationMemberId]
+  }
+
+  index "NotificationOrganizationIdIndex" {
+    columns = [column.OrganizationId]
+  }
+
+  index "NotificationTypeIndex" {
+    columns = [column.type]
+  }
+}
+
+table "NotificationReadLog" {
+  schema = schema.public
+
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "ReadByUserId" {
+    type = uuid
+    null = false
+  }
+
+  column "NotificationId" {
+    type = uuid
+    null = false
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "NotificationReadLogToNotificationForeignKey" {
+    columns     = [column.NotificationId]
+    ref_columns = [table.Notification.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "NotificationReadLogToUserForeignKey" {
+    columns     = [column.ReadByUserId]
+    ref_columns = [table.User.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "NotificationReadLogNotificationIdIndex" {
+    columns = [column.NotificationId]
+  }
+
+  index "NotificationReadLogReadByUserIdIndex" {
+    columns = [column.ReadByUserId]
+  }
+}
+
+table "AiChat" {
+  schema = schema.public
+
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "Status" {
+    type    = enum.AiChatStatusEnum
+    null    = false
+    default = "Active"
   }
 
   column "OrganizationId" {
@@ -1733,6 +2484,12 @@ table "AiChatMessage" {
     null = false
   }
 
+  # the knowledge base documents the answer was grounded on, only for the assistant messages
+  column "Citations" {
+    type = jsonb
+    null = true
+  }
+
   primary_key {
     columns = [column.UniqueId]
   }
@@ -1904,6 +2661,38 @@ table "AiApiCallLogs" {
     null = false
   }
 
+  // * calls answered from the semantic cache are logged too, with no tokens used, the hit rate is computed from them
+  column "IsCacheHit" {
+    type    = boolean
+    null    = false
+    default = false
+  }
+
+  column "CacheSimilarity" {
+    type = double_precision
+    null = true
+  }
+
+  // * the AI feature the call was made for, e.g. chat or summary, the calls logged before it was recorded are "unknown"
+  column "Feature" {
+    type    = text
+    null    = false
+    default = "unknown"
+  }
+
+  // * the member the call was made for, null for the calls made in the background, e.g. the auto responder
+  column "OrganizationMemberId" {
+    type = uuid
+    null = true
+  }
+
+  // * the cost of the call at the prices of the time of the call
+  column "CostInUsd" {
+    type    = double_precision
+    null    = false
+    default = 0
+  }
+
   primary_key {
     columns = [column.UniqueId]
   }
@@ -1915,6 +2704,17 @@ table "AiApiCallLogs" {
     on_update   = NO_ACTION
   }
 
+  foreign_key "AiApiCallLogsToOrganizationMemberForeignKey" {
+    columns     = [column.OrganizationMemberId]
+    ref_columns = [table.OrganizationMember.column.UniqueId]
+    on_delete   = SET_NULL
+    on_update   = NO_ACTION
+  }
+
+  index "AiApiCallLogsOrganizationIdCreatedAtIndex" {
+    columns = [column.OrganizationId, column.CreatedAt]
+  }
+
 }
 
 // ==== JOIN TABLES ======
@@ -2085,8 +2885,13 @@ table "ConversationTag" {
   }
 }
 
-table "CampaignTag" {
+table "ConversationBulkActionJob" {
   schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
   column "CreatedAt" {
     type    = timestamptz
     null    = false
@@ -2097,37 +2902,1718 @@ table "CampaignTag" {
     null = false
   }
 
-  column "CampaignId" {
+  column "OrganizationId" {
     type = uuid
     null = false
   }
 
-  column "TagId" {
+  column "CreatedByOrganizationMemberId" {
     type = uuid
     null = false
   }
 
-  primary_key {
-    columns = [column.CampaignId, column.TagId]
+  column "Action" {
+    type = enum.ConversationBulkActionEnum
+    null = false
   }
 
-  foreign_key "CampaignTagToCampaignForeignKey" {
-    columns     = [column.CampaignId]
-    ref_columns = [table.Campaign.column.UniqueId]
-    on_delete   = NO_ACTION
-    on_update   = NO_ACTION
+  column "Status" {
+    type = enum.ConversationBulkActionJobStatusEnum
+    null = false
   }
 
-  foreign_key "CampaignTagToTagForeignKey" {
-    columns     = [column.TagId]
-    ref_columns = [table.Tag.column.UniqueId]
-    on_delete   = NO_ACTION
-    on_update   = NO_ACTION
+  # the selected conversation ids or the filter matching the conversations, resolved when the job starts
+  column "Selection" {
+    type = jsonb
+    null = false
   }
 
-  index "CampaignTagIdCampaignIdUniqueIndex" {
-    columns = [column.CampaignId, column.TagId]
-    unique  = true
+  # the parameters of the action, the member to assign to or the tags to add or remove
+  column "Parameters" {
+    type = jsonb
+    null = true
+  }
+
+  column "TotalCount" {
+    type    = int
+    null    = false
+    default = 0
+  }
+
+  column "ProcessedCount" {
+    type    = int
+    null    = false
+    default = 0
+  }
+
+  column "FailedCount" {
+    type    = int
+    null    = false
+    default = 0
+  }
+
+  column "Error" {
+    type = text
+    null = true
+  }
+
+  column "StartedAt" {
+    type = timestamptz
+    null = true
+  }
+
+  column "CompletedAt" {
+    type = timestamptz
+    null = true
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "ConversationBulkActionJobToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "ConversationBulkActionJobToOrganizationMemberForeignKey" {
+    columns     = [column.CreatedByOrganizationMemberId]
+    ref_columns = [table.OrganizationMember.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "ConversationBulkActionJobOrganizationIdIndex" {
+    columns = [column.OrganizationId]
+  }
+
+  index "ConversationBulkActionJobStatusIndex" {
+    columns = [column.Status]
+  }
+}
+
+table "ConversationTaggingRule" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  column "Name" {
+    type = text
+    null = false
+  }
+
+  column "Type" {
+    type = enum.ConversationTaggingRuleTypeEnum
+    null = false
+  }
+
+  column "IsEnabled" {
+    type    = boolean
+    null    = false
+    default = true
+  }
+
+  # keyword rules, a json array of keywords matched case insensitively against the text of the inbound messages
+  column "Keywords" {
+    type = jsonb
+    null = true
+  }
+
+  # contact attribute rules, the value is optional, without it the rule matches when the attribute is set
+  column "AttributeKey" {
+    type = text
+    null = true
+  }
+
+  column "AttributeValue" {
+    type = text
+    null = true
+  }
+
+  # campaign rules, without a campaign the rule matches the conversations initiated by any campaign
+  column "CampaignId" {
+    type = uuid
+    null = true
+  }
+
+  column "TagId" {
+    type = uuid
+    null = false
+  }
+
+  column "CreatedByOrganizationMemberId" {
+    type = uuid
+    null = false
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "ConversationTaggingRuleToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "ConversationTaggingRuleToTagForeignKey" {
+    columns     = [column.TagId]
+    ref_columns = [table.Tag.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "ConversationTaggingRuleToCampaignForeignKey" {
+    columns     = [column.CampaignId]
+    ref_columns = [table.Campaign.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "ConversationTaggingRuleToOrganizationMemberForeignKey" {
+    columns     = [column.CreatedByOrganizationMemberId]
+    ref_columns = [table.OrganizationMember.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "ConversationTaggingRuleOrganizationIdIndex" {
+    columns = [column.OrganizationId]
+  }
+
+  index "ConversationTaggingRuleTagIdIndex" {
+    columns = [column.TagId]
+  }
+}
+
+table "CsatSurveyConfiguration" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  column "IsEnabled" {
+    type    = boolean
+    null    = false
+    default = false
+  }
+
+  column "Question" {
+    type = text
+    null = false
+  }
+
+  # without a comment prompt the survey is completed with the rating alone
+  column "CommentPrompt" {
+    type = text
+    null = true
+  }
+
+  column "ThankYouMessage" {
+    type = text
+    null = true
+  }
+
+  # the survey can be answered for this long after it is sent, then it expires
+  column "ResponseWindowInHours" {
+    type    = integer
+    null    = false
+    default = 24
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "CsatSurveyConfigurationToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "CsatSurveyConfigurationOrganizationIdIndex" {
+    columns = [column.OrganizationId]
+    unique  = true
+  }
+}
+
+table "CsatSurveyResponse" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  column "ConversationId" {
+    type = uuid
+    null = false
+  }
+
+  column "ContactId" {
+    type = uuid
+    null = false
+  }
+
+  # the member the conversation was assigned to when it was closed
+  column "AssignedToOrganizationMemberId" {
+    type = uuid
+    null = true
+  }
+
+  column "PhoneNumberUsed" {
+    type = text
+    null = false
+  }
+
+  column "WhatsAppMessageId" {
+    type = text
+    null = true
+  }
+
+  # the comment prompt sent after the rating, only a reply to it is recorded as the comment
+  column "CommentPromptWhatsAppMessageId" {
+    type = text
+    null = true
+  }
+
+  column "Status" {
+    type = enum.CsatSurveyStatusEnum
+    null = false
+  }
+
+  # 1 to 3, from poor to great
+  column "Rating" {
+    type = integer
+    null = true
+  }
+
+  column "Comment" {
+    type = text
+    null = true
+  }
+
+  column "SentAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "ExpiresAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "RatedAt" {
+    type = timestamptz
+    null = true
+  }
+
+  column "CommentedAt" {
+    type = timestamptz
+    null = true
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "CsatSurveyResponseToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "CsatSurveyResponseToConversationForeignKey" {
+    columns     = [column.ConversationId]
+    ref_columns = [table.Conversation.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "CsatSurveyResponseToContactForeignKey" {
+    columns     = [column.ContactId]
+    ref_columns = [table.Contact.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "CsatSurveyResponseToOrganizationMemberForeignKey" {
+    columns     = [column.AssignedToOrganizationMemberId]
+    ref_columns = [table.OrganizationMember.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "CsatSurveyResponseOrganizationIdIndex" {
+    columns = [column.OrganizationId]
+  }
+
+  # a conversation is surveyed once, even if it is reopened and closed again
+  index "CsatSurveyResponseConversationIdIndex" {
+    columns = [column.ConversationId]
+    unique  = true
+  }
+
+  index "CsatSurveyResponseContactIdStatusIndex" {
+    columns = [column.ContactId, column.Status]
+  }
+
+  index "CsatSurveyResponseAssignedToOrganizationMemberIdIndex" {
+    columns = [column.AssignedToOrganizationMemberId]
+  }
+}
+
+# automated multi step flows answering the contacts, the steps are defined as a json state machine
+table "ChatbotFlow" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  column "Name" {
+    type = text
+    null = false
+  }
+
+  column "Description" {
+    type = text
+    null = true
+  }
+
+  column "IsEnabled" {
+    type    = boolean
+    null    = false
+    default = false
+  }
+
+  # start step id and the steps of the flow
+  column "Definition" {
+    type = jsonb
+    null = false
+  }
+
+  # json array of the keywords starting the flow when a contact sends one of them
+  column "TriggerKeywords" {
+    type = jsonb
+    null = true
+  }
+
+  column "TriggerOnNewConversation" {
+    type    = boolean
+    null    = false
+    default = false
+  }
+
+  # when multiple flows are triggered by the same message, the one with the highest priority runs
+  column "Priority" {
+    type    = integer
+    null    = false
+    default = 0
+  }
+
+  # how long a session waits for a reply of the contact when the waiting step sets no timeout of its own
+  column "SessionTimeoutInMinutes" {
+    type    = integer
+    null    = false
+    default = 60
+  }
+
+  column "CreatedByOrganizationMemberId" {
+    type = uuid
+    null = false
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "ChatbotFlowToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "ChatbotFlowToOrganizationMemberForeignKey" {
+    columns     = [column.CreatedByOrganizationMemberId]
+    ref_columns = [table.OrganizationMember.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "ChatbotFlowOrganizationIdIndex" {
+    columns = [column.OrganizationId]
+  }
+}
+
+# state of a flow running in a conversation
+table "ChatbotFlowSession" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  column "ChatbotFlowId" {
+    type = uuid
+    null = false
+  }
+
+  column "ConversationId" {
+    type = uuid
+    null = false
+  }
+
+  column "ContactId" {
+    type = uuid
+    null = false
+  }
+
+  column "Status" {
+    type = enum.ChatbotFlowSessionStatusEnum
+    null = false
+  }
+
+  # the step the session is waiting at, or the last executed step once the session has ended
+  column "CurrentStepId" {
+    type = text
+    null = false
+  }
+
+  # values captured from the replies of the contact and the webhook responses
+  column "Variables" {
+    type = jsonb
+    null = true
+  }
+
+  # the last reply of the contact, the branch steps are evaluated against it
+  column "LastReply" {
+    type = jsonb
+    null = true
+  }
+
+  column "ExpiresAt" {
+    type = timestamptz
+    null = true
+  }
+
+  column "EndedAt" {
+    type = timestamptz
+    null = true
+  }
+
+  column "Error" {
+    type = text
+    null = true
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "ChatbotFlowSessionToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "ChatbotFlowSessionToChatbotFlowForeignKey" {
+    columns     = [column.ChatbotFlowId]
+    ref_columns = [table.ChatbotFlow.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "ChatbotFlowSessionToConversationForeignKey" {
+    columns     = [column.ConversationId]
+    ref_columns = [table.Conversation.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "ChatbotFlowSessionToContactForeignKey" {
+    columns     = [column.ContactId]
+    ref_columns = [table.Contact.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "ChatbotFlowSessionChatbotFlowIdIndex" {
+    columns = [column.ChatbotFlowId]
+  }
+
+  index "ChatbotFlowSessionConversationIdStatusIndex" {
+    columns = [column.ConversationId, column.Status]
+  }
+
+  index "ChatbotFlowSessionStatusExpiresAtIndex" {
+    columns = [column.Status, column.ExpiresAt]
+  }
+}
+
+table "AiAutoResponderConfiguration" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  column "IsEnabled" {
+    type    = boolean
+    null    = false
+    default = false
+  }
+
+  # what the AI must know about the business, e.g. opening hours, policies, tone of voice
+  column "Instructions" {
+    type = text
+    null = true
+  }
+
+  # the replies the model is less confident about, in percent, are handed off to a human instead of being sent
+  column "MinConfidence" {
+    type    = integer
+    null    = false
+    default = 70
+  }
+
+  # after this many AI replies in a conversation it is handed off to a human
+  column "MaxAiTurns" {
+    type    = integer
+    null    = false
+    default = 5
+  }
+
+  column "ForbiddenTopics" {
+    type = jsonb
+    null = true
+  }
+
+  # the team, an organization role, the conversations are handed off to
+  column "HandoffOrganizationRoleId" {
+    type = uuid
+    null = true
+  }
+
+  # sent to the contact when the conversation is handed off to a human
+  column "HandoffMessage" {
+    type = text
+    null = true
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "AiAutoResponderConfigurationToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "AiAutoResponderConfigurationToOrganizationRoleForeignKey" {
+    columns     = [column.HandoffOrganizationRoleId]
+    ref_columns = [table.OrganizationRole.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "AiAutoResponderConfigurationOrganizationIdIndex" {
+    columns = [column.OrganizationId]
+    unique  = true
+  }
+}
+
+# a conversation handed off by the AI auto responder is never answered by the AI again
+table "AiAutoResponderHandoff" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  column "ConversationId" {
+    type = uuid
+    null = false
+  }
+
+  column "Reason" {
+    type = enum.AiHandoffReasonEnum
+    null = false
+  }
+
+  column "AssignedToOrganizationMemberId" {
+    type = uuid
+    null = true
+  }
+
+  # the topic or the error that caused the handoff, if any
+  column "Details" {
+    type = text
+    null = true
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "AiAutoResponderHandoffToConversationForeignKey" {
+    columns     = [column.ConversationId]
+    ref_columns = [table.Conversation.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "AiAutoResponderHandoffToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "AiAutoResponderHandoffToOrganizationMemberForeignKey" {
+    columns     = [column.AssignedToOrganizationMemberId]
+    ref_columns = [table.OrganizationMember.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "AiAutoResponderHandoffConversationIdIndex" {
+    columns = [column.ConversationId]
+    unique  = true
+  }
+}
+
+# documents an organization uploaded to ground the AI answers on, the extracted text is split in chunks that are retrieved by similarity
+table "KnowledgeBaseDocument" {
+  schema = schema.public
+
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  column "Title" {
+    type = text
+    null = false
+  }
+
+  column "SourceType" {
+    type = enum.KnowledgeBaseSourceTypeEnum
+    null = false
+  }
+
+  column "SourceUrl" {
+    type = text
+    null = true
+  }
+
+  # the plain text extracted from the source, kept so that the document can be indexed again with another model
+  column "Content" {
+    type = text
+    null = false
+  }
+
+  column "Status" {
+    type    = enum.KnowledgeBaseDocumentStatusEnum
+    null    = false
+    default = "Indexing"
+  }
+
+  column "Error" {
+    type = text
+    null = true
+  }
+
+  column "ChunkCount" {
+    type    = int
+    null    = false
+    default = 0
+  }
+
+  # the embeddings of two models can not be compared, the chunks are searched by keywords when the model changed since indexing
+  column "EmbeddingModel" {
+    type = text
+    null = true
+  }
+
+  column "CreatedByOrganizationMemberId" {
+    type = uuid
+    null = true
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "KnowledgeBaseDocumentToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "KnowledgeBaseDocumentToOrganizationMemberForeignKey" {
+    columns     = [column.CreatedByOrganizationMemberId]
+    ref_columns = [table.OrganizationMember.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "KnowledgeBaseDocumentOrganizationIdIndex" {
+    columns = [column.OrganizationId]
+  }
+}
+
+table "KnowledgeBaseChunk" {
+  schema = schema.public
+
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  column "KnowledgeBaseDocumentId" {
+    type = uuid
+    null = false
+  }
+
+  column "ChunkIndex" {
+    type = int
+    null = false
+  }
+
+  column "Content" {
+    type = text
+    null = false
+  }
+
+  # pgvector embedding, the shorter vectors of some models are padded with zeros, null when the model of the organization has no embeddings
+  column "Embedding" {
+    type = sql("vector(1536)")
+    null = true
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "KnowledgeBaseChunkToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "KnowledgeBaseChunkToKnowledgeBaseDocumentForeignKey" {
+    columns     = [column.KnowledgeBaseDocumentId]
+    ref_columns = [table.KnowledgeBaseDocument.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "KnowledgeBaseChunkOrganizationIdIndex" {
+    columns = [column.OrganizationId]
+  }
+
+  index "KnowledgeBaseChunkDocumentIdIndex" {
+    columns = [column.KnowledgeBaseDocumentId, column.ChunkIndex]
+    unique  = true
+  }
+
+  index "KnowledgeBaseChunkEmbeddingIndex" {
+    type = HNSW
+    on {
+      column = column.Embedding
+      ops    = "vector_cosine_ops"
+    }
+  }
+
+  index "KnowledgeBaseChunkFullTextSearchIndex" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, \"Content\")"
+    }
+  }
+}
+
+table "ConversationSummary" {
+  schema = schema.public
+
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  column "ConversationId" {
+    type = uuid
+    null = false
+  }
+
+  # the summary covers the conversation up to this message, a newer message makes it stale
+  column "LastMessageId" {
+    type = uuid
+    null = false
+  }
+
+  column "Content" {
+    type = text
+    null = false
+  }
+
+  column "Model" {
+    type = enum.AiModelEnum
+    null = false
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "ConversationSummaryToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "ConversationSummaryToConversationForeignKey" {
+    columns     = [column.ConversationId]
+    ref_columns = [table.Conversation.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "ConversationSummaryOrganizationIdIndex" {
+    columns = [column.OrganizationId]
+  }
+
+  index "ConversationSummaryConversationIdIndex" {
+    columns = [column.ConversationId]
+    unique  = true
+  }
+
+  index "ConversationSummaryFullTextSearchIndex" {
+    type = GIN
+    on {
+      expr = "to_tsvector('simple'::regconfig, \"Content\")"
+    }
+  }
+}
+
+table "ContactTag" {
+  schema = schema.public
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "ContactId" {
+    type = uuid
+    null = false
+  }
+
+  column "TagId" {
+    type = uuid
+    null = false
+  }
+
+  primary_key {
+    columns = [column.ContactId, column.TagId]
+  }
+
+  foreign_key "ContactTagToContactForeignKey" {
+    columns     = [column.ContactId]
+    ref_columns = [table.Contact.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "ContactTagToTagForeignKey" {
+    columns     = [column.TagId]
+    ref_columns = [table.Tag.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "ContactTagTagIdIndex" {
+    columns = [column.TagId]
+  }
+}
+
+table "SegmentRecommendationJob" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  column "CreatedByOrganizationMemberId" {
+    type = uuid
+    null = false
+  }
+
+  column "ContactListId" {
+    type = uuid
+    null = false
+  }
+
+  column "Status" {
+    type = enum.ConversationBulkActionJobStatusEnum
+    null = false
+  }
+
+  # the ids of the tags the contacts are classified into, the model proposes its own labels when empty
+  column "Parameters" {
+    type = jsonb
+    null = true
+  }
+
+  column "TotalCount" {
+    type    = int
+    null    = false
+    default = 0
+  }
+
+  column "ProcessedCount" {
+    type    = int
+    null    = false
+    default = 0
+  }
+
+  column "FailedCount" {
+    type    = int
+    null    = false
+    default = 0
+  }
+
+  column "Error" {
+    type = text
+    null = true
+  }
+
+  column "StartedAt" {
+    type = timestamptz
+    null = true
+  }
+
+  column "CompletedAt" {
+    type = timestamptz
+    null = true
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "SegmentRecommendationJobToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "SegmentRecommendationJobToOrganizationMemberForeignKey" {
+    columns     = [column.CreatedByOrganizationMemberId]
+    ref_columns = [table.OrganizationMember.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "SegmentRecommendationJobToContactListForeignKey" {
+    columns     = [column.ContactListId]
+    ref_columns = [table.ContactList.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "SegmentRecommendationJobOrganizationIdIndex" {
+    columns = [column.OrganizationId]
+  }
+
+  index "SegmentRecommendationJobStatusIndex" {
+    columns = [column.Status]
+  }
+}
+
+table "CampaignTag" {
+  schema = schema.public
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "CampaignId" {
+    type = uuid
+    null = false
+  }
+
+  column "TagId" {
+    type = uuid
+    null = false
+  }
+
+  primary_key {
+    columns = [column.CampaignId, column.TagId]
+  }
+
+  foreign_key "CampaignTagToCampaignForeignKey" {
+    columns     = [column.CampaignId]
+    ref_columns = [table.Campaign.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "CampaignTagToTagForeignKey" {
+    columns     = [column.TagId]
+    ref_columns = [table.Tag.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "CampaignTagIdCampaignIdUniqueIndex" {
+    columns = [column.CampaignId, column.TagId]
+    unique  = true
+  }
+}
+
+# the monthly AI budget of an organization, the usage is read back from the AI API call logs of the month
+table "AiBudgetConfiguration" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  # no token limit when null
+  column "MonthlyTokenLimit" {
+    type = bigint
+    null = true
+  }
+
+  # no cost limit when null
+  column "MonthlyCostLimitInUsd" {
+    type = double_precision
+    null = true
+  }
+
+  # the owners are warned once a month when the usage reaches this percentage of a limit
+  column "SoftLimitPercentage" {
+    type    = integer
+    null    = false
+    default = 80
+  }
+
+  # the AI features are blocked until the next month when a limit is reached, the owners are only warned otherwise
+  column "IsHardLimitEnabled" {
+    type    = boolean
+    null    = false
+    default = true
+  }
+
+  column "SoftLimitNotifiedAt" {
+    type = timestamptz
+    null = true
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "AiBudgetConfigurationToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "AiBudgetConfigurationOrganizationIdIndex" {
+    columns = [column.OrganizationId]
+    unique  = true
+  }
+}
+
+table "AiPiiRedactionConfiguration" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  # the PII is replaced with placeholders before the prompts are sent to the AI provider, and restored in the answers
+  column "IsEnabled" {
+    type    = boolean
+    null    = false
+    default = true
+  }
+
+  # the names of the built in detectors to apply, all of them when null
+  column "EnabledDetectors" {
+    type = jsonb
+    null = true
+  }
+
+  # the regular expressions of the organization along with the names of their placeholders
+  column "CustomDetectors" {
+    type = jsonb
+    null = true
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "AiPiiRedactionConfigurationToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "AiPiiRedactionConfigurationOrganizationIdIndex" {
+    columns = [column.OrganizationId]
+    unique  = true
+  }
+}
+
+table "MessageClassificationConfiguration" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  # every inbound message is classified by the AI, which uses the AI budget of the organization
+  column "IsEnabled" {
+    type    = boolean
+    null    = false
+    default = false
+  }
+
+  # the unassigned conversations reaching the escalation priority are assigned to the least busy member of this team
+  column "EscalationOrganizationRoleId" {
+    type = uuid
+    null = true
+  }
+
+  column "EscalationPriority" {
+    type    = enum.ConversationPriorityEnum
+    null    = false
+    default = "Urgent"
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "MessageClassificationConfigurationToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "MessageClassificationConfigurationToOrganizationRoleForeignKey" {
+    columns     = [column.EscalationOrganizationRoleId]
+    ref_columns = [table.OrganizationRole.column.UniqueId]
+    on_delete   = SET_NULL
+    on_update   = NO_ACTION
+  }
+
+  index "MessageClassificationConfigurationOrganizationIdIndex" {
+    columns = [column.OrganizationId]
+    unique  = true
+  }
+}
+
+table "MessageTemplate" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  column "WhatsappBusinessAccountId" {
+    type = uuid
+    null = false
+  }
+
+  # the id of the template in whatsapp, the campaigns refer to the template with it
+  column "TemplateId" {
+    type = text
+    null = false
+  }
+
+  column "Name" {
+    type = text
+    null = false
+  }
+
+  column "Language" {
+    type = text
+    null = false
+  }
+
+  column "Category" {
+    type = enum.MessageTemplateCategoryEnum
+    null = false
+  }
+
+  column "Status" {
+    type = enum.MessageTemplateStatusEnum
+    null = false
+  }
+
+  column "Quality" {
+    type    = enum.MessageTemplateQualityEnum
+    null    = false
+    default = "Unknown"
+  }
+
+  # why the template has been rejected, paused or disabled, as told by whatsapp
+  column "StatusReason" {
+    type = text
+    null = true
+  }
+
+  column "Components" {
+    type    = jsonb
+    null    = false
+    default = sql("'[]'::jsonb")
+  }
+
+  column "LastSyncedAt" {
+    type = timestamptz
+    null = true
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "MessageTemplateToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "MessageTemplateToWhatsappBusinessAccountForeignKey" {
+    columns     = [column.WhatsappBusinessAccountId]
+    ref_columns = [table.WhatsappBusinessAccount.column.UniqueId]
+    on_delete   = CASCADE
+    on_update   = NO_ACTION
+  }
+
+  index "MessageTemplateTemplateIdUniqueIndex" {
+    columns = [column.TemplateId]
+    unique  = true
+  }
+
+  index "MessageTemplateOrganizationIdStatusIndex" {
+    columns = [column.OrganizationId, column.Status]
+  }
+
+  index "MessageTemplateWhatsappBusinessAccountIdIndex" {
+    columns = [column.WhatsappBusinessAccountId]
+  }
+}
+
+table "PhoneNumberHealthEvent" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  column "WhatsappBusinessAccountId" {
+    type = uuid
+    null = false
+  }
+
+  # null for the alerts and updates of the business account itself
+  column "PhoneNumberId" {
+    type = uuid
+    null = true
+  }
+
+  column "Type" {
+    type = enum.PhoneNumberHealthEventTypeEnum
+    null = false
+  }
+
+  column "PreviousValue" {
+    type = text
+    null = true
+  }
+
+  column "NewValue" {
+    type = text
+    null = true
+  }
+
+  # the reason or the details whatsapp gave along with the change
+  column "Description" {
+    type = text
+    null = true
+  }
+
+  # the change lowers what the phone number can send, the organization is notified of it
+  column "IsDowngrade" {
+    type    = boolean
+    null    = false
+    default = false
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "PhoneNumberHealthEventToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "PhoneNumberHealthEventToWhatsappBusinessAccountForeignKey" {
+    columns     = [column.WhatsappBusinessAccountId]
+    ref_columns = [table.WhatsappBusinessAccount.column.UniqueId]
+    on_delete   = CASCADE
+    on_update   = NO_ACTION
+  }
+
+  foreign_key "PhoneNumberHealthEventToWhatsappBusinessAccountPhoneNumberForeignKey" {
+    columns     = [column.PhoneNumberId]
+    ref_columns = [table.WhatsappBusinessAccountPhoneNumber.column.UniqueId]
+    on_delete   = CASCADE
+    on_update   = NO_ACTION
+  }
+
+  index "PhoneNumberHealthEventPhoneNumberIdCreatedAtIndex" {
+    columns = [column.PhoneNumberId, column.CreatedAt]
+  }
+
+  index "PhoneNumberHealthEventWhatsappBusinessAccountIdCreatedAtIndex" {
+    columns = [column.WhatsappBusinessAccountId, column.CreatedAt]
   }
 }
 
+table "PhoneNumberHealthConfiguration" {
+  schema = schema.public
+  column "UniqueId" {
+    type    = uuid
+    null    = false
+    default = sql("gen_random_uuid()")
+  }
+  column "CreatedAt" {
+    type    = timestamptz
+    null    = false
+    default = sql("now()")
+  }
+  column "UpdatedAt" {
+    type = timestamptz
+    null = false
+  }
+
+  column "OrganizationId" {
+    type = uuid
+    null = false
+  }
+
+  # the running campaigns of a phone number are paused when its quality rating drops to red
+  column "IsCampaignAutoPauseEnabled" {
+    type    = boolean
+    null    = false
+    default = false
+  }
+
+  # the owners and admins are emailed on a downgrade, the in-app notification is always sent
+  column "IsEmailNotificationEnabled" {
+    type    = boolean
+    null    = false
+    default = true
+  }
+
+  # the downgrades are posted to the slack channel of the organization when it is configured
+  column "IsSlackNotificationEnabled" {
+    type    = boolean
+    null    = false
+    default = true
+  }
+
+  primary_key {
+    columns = [column.UniqueId]
+  }
+
+  foreign_key "PhoneNumberHealthConfigurationToOrganizationForeignKey" {
+    columns     = [column.OrganizationId]
+    ref_columns = [table.Organization.column.UniqueId]
+    on_delete   = NO_ACTION
+    on_update   = NO_ACTION
+  }
+
+  index "PhoneNumberHealthConfigurationOrganizationIdIndex" {
+    columns = [column.OrganizationId]
+    unique  = true
+  }
+}
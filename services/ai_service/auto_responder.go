@@ -0,0 +1,140 @@
+package ai_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	"github.com/wapikit/wapikit/api/api_types"
+)
+
+const SYSTEM_PROMPT_AUTO_RESPONDER = `You are a customer support assistant answering the contacts of a business on WhatsApp on behalf of the business. You will be provided with the instructions of the business and the latest messages of the conversation, the messages of the contact are from the user and the messages of the business are from you. Reply to the last message of the contact in the language of the contact, in at most 3 short sentences, without any buzz words or jargons. Only use the facts given in the instructions and the conversation, never make up prices, dates, policies or promises. You must respond back with a json string with the following properties:
+- "reply": the message to send to the contact.
+- "confidence": a number between 0 and 1 that reflects how sure you are that the reply is correct and answers the contact.
+- "handoff": true if the contact asks for a human, is upset, or the question can not be answered with the facts you have, false otherwise.
+- "handoffReason": a short explanation of why the conversation must be handed off, an empty string otherwise.
+- "topic": the topic of the last message of the contact in a few words.`
+
+// autoReplyConversationLength is how many of the latest messages of the conversation are given to the model
+const autoReplyConversationLength = 20
+
+// AutoReplyParams is what the auto responder knows when it answers a contact
+type AutoReplyParams struct {
+	OrganizationId  uuid.UUID
+	Instructions    string
+	ForbiddenTopics []string
+	// Messages of the conversation, oldest first
+	Messages []model.Message
+}
+
+type AutoReplyResponse struct {
+	Reply         string  `json:"reply"`
+	Confidence    float64 `json:"confidence"`
+	Handoff       bool    `json:"handoff"`
+	HandoffReason string  `json:"handoffReason"`
+	Topic         string  `json:"topic"`
+}
+
+// GenerateAutoReply asks the model for a reply to the last message of the contact and how confident it is about it,
+// the personal data in the messages is redacted before it leaves the server
+func (ai *AiService) GenerateAutoReply(ctx context.Context, params AutoReplyParams) (*AutoReplyResponse, error) {
+	systemPromptContent := SYSTEM_PROMPT_AUTO_RESPONDER
+	if params.Instructions != "" {
+		systemPromptContent = strings.Join([]string{systemPromptContent, "Instructions of the business:", params.Instructions}, "\n")
+	}
+	if len(params.ForbiddenTopics) > 0 {
+		systemPromptContent = strings.Join([]string{systemPromptContent, "Never talk about the following topics, hand the conversation off instead:", strings.Join(params.ForbiddenTopics, ", ")}, "\n")
+	}
+
+	messages := params.Messages
+	if len(messages) > autoReplyConversationLength {
+		messages = messages[len(messages)-autoReplyConversationLength:]
+	}
+
+	// * the facts of the knowledge base relevant to the last message of the contact are part of what the AI may answer with
+	matches := []KnowledgeBaseMatch{}
+	for index := len(messages) - 1; index >= 0; index-- {
+		if messages[index].Direction == model.MessageDirectionEnum_InBound {
+			matches = ai.relevantKnowledge(ctx, params.OrganizationId, messageText(messages[index]))
+			break
+		}
+	}
+	if len(matches) > 0 {
+		systemPromptContent = knowledgeBasePrompt(matches, strings.Join([]string{systemPromptContent, "Facts from the knowledge base of the business, they count as facts given in the instructions:"}, "\n"))
+	}
+
+	inputPrompt := []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, systemPromptContent),
+	}
+
+	for _, message := range messages {
+		text := messageText(message)
+		if text == "" {
+			continue
+		}
+		if message.Direction == model.MessageDirectionEnum_InBound {
+			inputPrompt = append(inputPrompt, llms.TextParts(llms.ChatMessageTypeHuman, text))
+		} else {
+			inputPrompt = append(inputPrompt, llms.TextParts(llms.ChatMessageTypeAI, text))
+		}
+	}
+
+	aiResponse, err := ai.QueryAiModel(ctx, AiTaskAutoReply, inputPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	requestJson, _ := json.Marshal(inputPrompt)
+	ai.LogApiCall(params.OrganizationId, ai.Db, AiTaskAutoReply, string(requestJson), aiResponse.Content, model.AiModelEnum(aiResponse.ModelUsed), aiResponse.InputTokenUsed, aiResponse.OutputTokenUsed)
+
+	var autoReply AutoReplyResponse
+	if err := json.Unmarshal([]byte(stripCodeFence(aiResponse.Content)), &autoReply); err != nil {
+		return nil, fmt.Errorf("error parsing the auto reply of the model: %w", err)
+	}
+
+	autoReply.Reply = strings.TrimSpace(autoReply.Reply)
+	return &autoReply, nil
+}
+
+// messageText returns the text the contact or the business wrote in the message, empty for the media without a caption
+func messageText(message model.Message) string {
+	if message.MessageData == nil {
+		return ""
+	}
+
+	switch message.MessageType {
+	case model.MessageTypeEnum_Text:
+		var data api_types.TextMessageData
+		if err := json.Unmarshal([]byte(*message.MessageData), &data); err == nil {
+			return data.Text
+		}
+	case model.MessageTypeEnum_Interactive:
+		var data api_types.InteractiveMessageData
+		if err := json.Unmarshal([]byte(*message.MessageData), &data); err == nil {
+			return data.Body
+		}
+	case model.MessageTypeEnum_InteractiveReply:
+		var data api_types.InteractiveReplyMessageData
+		if err := json.Unmarshal([]byte(*message.MessageData), &data); err == nil {
+			return data.Title
+		}
+	}
+
+	return ""
+}
+
+// stripCodeFence removes the markdown code fence some models wrap their json responses in
+func stripCodeFence(content string) string {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, "```") {
+		return content
+	}
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSuffix(content, "```")
+	return strings.TrimSpace(content)
+}
@@ -0,0 +1,300 @@
+package ai_service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	"github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	"github.com/wapikit/wapikit/services/notification_service"
+	"github.com/wapikit/wapikit/utils"
+)
+
+const AiBudgetDefaultSoftLimitPercentage = 80
+
+// ErrAiBudgetExceeded is returned when the organization used up its monthly AI budget and blocks the AI features when it is reached
+var ErrAiBudgetExceeded = errors.New("the monthly AI budget of the organization is used up, it resets at the start of the next month")
+
+// AiBudgetStatus is the usage of the organization in the current month against its AI budget
+type AiBudgetStatus struct {
+	Configuration model.AiBudgetConfiguration
+	PeriodStart   time.Time
+	TokensUsed    int64
+	CostInUsd     float64
+	// UsagePercentage is the usage against the tightest limit, nil when the budget has no limit
+	UsagePercentage    *float64
+	IsSoftLimitReached bool
+	IsHardLimitReached bool
+}
+
+// IsBlocked tells whether the AI features of the organization are blocked until the next month
+func (status *AiBudgetStatus) IsBlocked() bool {
+	return status.IsHardLimitReached && status.Configuration.IsHardLimitEnabled
+}
+
+// AiBudgetPeriodStart returns the start of the month the usage is counted from, the budgets reset every month in UTC
+func AiBudgetPeriodStart(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// GetAiBudgetConfiguration returns the AI budget of the organization, a budget without limits if the organization never configured it
+func GetAiBudgetConfiguration(ctx context.Context, db *sql.DB, organizationId uuid.UUID) (*model.AiBudgetConfiguration, error) {
+	var configuration model.AiBudgetConfiguration
+	err := SELECT(table.AiBudgetConfiguration.AllColumns).
+		FROM(table.AiBudgetConfiguration).
+		WHERE(table.AiBudgetConfiguration.OrganizationId.EQ(UUID(organizationId))).
+		LIMIT(1).
+		QueryContext(ctx, db, &configuration)
+
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return &model.AiBudgetConfiguration{
+				OrganizationId:      organizationId,
+				SoftLimitPercentage: AiBudgetDefaultSoftLimitPercentage,
+				IsHardLimitEnabled:  true,
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &configuration, nil
+}
+
+// UpdateAiBudgetConfiguration creates or updates the AI budget of the organization, a limit left out is removed
+func UpdateAiBudgetConfiguration(ctx context.Context, db *sql.DB, organizationId uuid.UUID, payload api_types.UpdateAiBudgetSchema) (*model.AiBudgetConfiguration, error) {
+	if payload.SoftLimitPercentage < 1 || payload.SoftLimitPercentage > 100 {
+		return nil, fmt.Errorf("soft limit percentage must be between 1 and 100")
+	}
+	if payload.MonthlyTokenLimit != nil && *payload.MonthlyTokenLimit <= 0 {
+		return nil, fmt.Errorf("monthly token limit must be greater than 0")
+	}
+	if payload.MonthlyCostLimitInUsd != nil && *payload.MonthlyCostLimitInUsd <= 0 {
+		return nil, fmt.Errorf("monthly cost limit must be greater than 0")
+	}
+
+	configuration := model.AiBudgetConfiguration{
+		OrganizationId:        organizationId,
+		MonthlyTokenLimit:     payload.MonthlyTokenLimit,
+		MonthlyCostLimitInUsd: payload.MonthlyCostLimitInUsd,
+		SoftLimitPercentage:   int32(payload.SoftLimitPercentage),
+		IsHardLimitEnabled:    payload.IsHardLimitEnabled,
+		CreatedAt:             time.Now(),
+		UpdatedAt:             time.Now(),
+	}
+
+	// * the warning of the month is sent again for the new limits
+	var updatedConfiguration model.AiBudgetConfiguration
+	err := table.AiBudgetConfiguration.
+		INSERT(table.AiBudgetConfiguration.MutableColumns).
+		MODEL(configuration).
+		ON_CONFLICT(table.AiBudgetConfiguration.OrganizationId).
+		DO_UPDATE(SET(
+			table.AiBudgetConfiguration.MonthlyTokenLimit.SET(table.AiBudgetConfiguration.EXCLUDED.MonthlyTokenLimit),
+			table.AiBudgetConfiguration.MonthlyCostLimitInUsd.SET(table.AiBudgetConfiguration.EXCLUDED.MonthlyCostLimitInUsd),
+			table.AiBudgetConfiguration.SoftLimitPercentage.SET(table.AiBudgetConfiguration.EXCLUDED.SoftLimitPercentage),
+			table.AiBudgetConfiguration.IsHardLimitEnabled.SET(table.AiBudgetConfiguration.EXCLUDED.IsHardLimitEnabled),
+			table.AiBudgetConfiguration.SoftLimitNotifiedAt.SET(TimestampzExp(NULL)),
+			table.AiBudgetConfiguration.UpdatedAt.SET(table.AiBudgetConfiguration.EXCLUDED.UpdatedAt),
+		)).
+		RETURNING(table.AiBudgetConfiguration.AllColumns).
+		QueryContext(ctx, db, &updatedConfiguration)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedConfiguration, nil
+}
+
+func hasAiBudgetLimit(configuration model.AiBudgetConfiguration) bool {
+	return configuration.MonthlyTokenLimit != nil || configuration.MonthlyCostLimitInUsd != nil
+}
+
+// GetAiBudgetStatus returns the usage of the organization in the current month against its AI budget
+func GetAiBudgetStatus(ctx context.Context, db *sql.DB, organizationId uuid.UUID) (*AiBudgetStatus, error) {
+	configuration, err := GetAiBudgetConfiguration(ctx, db, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	return aiBudgetStatus(ctx, db, *configuration)
+}
+
+// CheckAiBudget returns ErrAiBudgetExceeded when the organization used up its AI budget of the month and the budget blocks the AI features,
+// the usage is not counted for the budgets without a hard limit
+func CheckAiBudget(ctx context.Context, db *sql.DB, organizationId uuid.UUID) error {
+	configuration, err := GetAiBudgetConfiguration(ctx, db, organizationId)
+	if err != nil {
+		return err
+	}
+	if !configuration.IsHardLimitEnabled || !hasAiBudgetLimit(*configuration) {
+		return nil
+	}
+
+	status, err := aiBudgetStatus(ctx, db, *configuration)
+	if err != nil {
+		return err
+	}
+	if status.IsBlocked() {
+		return ErrAiBudgetExceeded
+	}
+
+	return nil
+}
+
+func aiBudgetStatus(ctx context.Context, db *sql.DB, configuration model.AiBudgetConfiguration) (*AiBudgetStatus, error) {
+	periodStart := AiBudgetPeriodStart(time.Now())
+	usage, err := queryAiUsage(ctx, db, configuration.OrganizationId, periodStart, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	status := &AiBudgetStatus{
+		Configuration: configuration,
+		PeriodStart:   periodStart,
+		TokensUsed:    usage.InputTokens + usage.OutputTokens,
+		CostInUsd:     usage.CostInUsd,
+	}
+
+	if !hasAiBudgetLimit(configuration) {
+		return status, nil
+	}
+
+	usagePercentage := 0.0
+	if configuration.MonthlyTokenLimit != nil {
+		usagePercentage = math.Max(usagePercentage, float64(status.TokensUsed)*100/float64(*configuration.MonthlyTokenLimit))
+	}
+	if configuration.MonthlyCostLimitInUsd != nil {
+		usagePercentage = math.Max(usagePercentage, status.CostInUsd*100/(*configuration.MonthlyCostLimitInUsd))
+	}
+
+	status.UsagePercentage = &usagePercentage
+	status.IsSoftLimitReached = usagePercentage >= float64(configuration.SoftLimitPercentage)
+	status.IsHardLimitReached = usagePercentage >= 100
+	return status, nil
+}
+
+// ParseAiBudgetStatusToApiSchema returns the usage part of the budget in the shape of the API
+func ParseAiBudgetStatusToApiSchema(status AiBudgetStatus) api_types.AiBudgetUsageSchema {
+	return api_types.AiBudgetUsageSchema{
+		PeriodStart:        status.PeriodStart,
+		TokensUsed:         status.TokensUsed,
+		CostInUsd:          status.CostInUsd,
+		UsagePercentage:    status.UsagePercentage,
+		IsSoftLimitReached: status.IsSoftLimitReached,
+		IsHardLimitReached: status.IsHardLimitReached,
+	}
+}
+
+func ParseAiBudgetToApiSchema(status AiBudgetStatus) api_types.AiBudgetSchema {
+	return api_types.AiBudgetSchema{
+		MonthlyTokenLimit:     status.Configuration.MonthlyTokenLimit,
+		MonthlyCostLimitInUsd: status.Configuration.MonthlyCostLimitInUsd,
+		SoftLimitPercentage:   int(status.Configuration.SoftLimitPercentage),
+		IsHardLimitEnabled:    status.Configuration.IsHardLimitEnabled,
+		Usage:                 ParseAiBudgetStatusToApiSchema(status),
+	}
+}
+
+// notifyAiBudgetSoftLimit warns the owners of the organization, once a month, that its usage reached the soft limit of its budget
+func (ai *AiService) notifyAiBudgetSoftLimit(organizationId uuid.UUID) {
+	notificationService := ai.Config.NotificationService
+	if notificationService == nil {
+		return
+	}
+
+	ctx := context.Background()
+	configuration, err := GetAiBudgetConfiguration(ctx, ai.Db, organizationId)
+	if err != nil {
+		ai.Logger.Error("error checking the AI budget", "organization_id", organizationId.String(), "error", err.Error())
+		return
+	}
+	if !hasAiBudgetLimit(*configuration) {
+		return
+	}
+
+	status, err := aiBudgetStatus(ctx, ai.Db, *configuration)
+	if err != nil {
+		ai.Logger.Error("error checking the AI budget", "organization_id", organizationId.String(), "error", err.Error())
+		return
+	}
+	if !status.IsSoftLimitReached {
+		return
+	}
+
+	// * claiming the warning of the month in the update keeps the calls made at the same time from sending it twice
+	var claimedConfigurations []model.AiBudgetConfiguration
+	err = table.AiBudgetConfiguration.
+		UPDATE(table.AiBudgetConfiguration.SoftLimitNotifiedAt).
+		SET(TimestampzT(time.Now())).
+		WHERE(
+			table.AiBudgetConfiguration.OrganizationId.EQ(UUID(organizationId)).
+				AND(
+					table.AiBudgetConfiguration.SoftLimitNotifiedAt.IS_NULL().
+						OR(table.AiBudgetConfiguration.SoftLimitNotifiedAt.LT(TimestampzT(status.PeriodStart))),
+				),
+		).
+		RETURNING(table.AiBudgetConfiguration.UniqueId).
+		QueryContext(ctx, ai.Db, &claimedConfigurations)
+
+	if err != nil {
+		ai.Logger.Error("error claiming the AI budget warning", "organization_id", organizationId.String(), "error", err.Error())
+		return
+	}
+	if len(claimedConfigurations) == 0 {
+		return
+	}
+
+	var owners []struct {
+		model.OrganizationMember
+		User model.User
+	}
+	err = SELECT(table.OrganizationMember.AllColumns, table.User.AllColumns).
+		FROM(table.OrganizationMember.
+			INNER_JOIN(table.User, table.User.UniqueId.EQ(table.OrganizationMember.UserId)),
+		).
+		WHERE(
+			table.OrganizationMember.OrganizationId.EQ(UUID(organizationId)).
+				AND(table.OrganizationMember.AccessLevel.EQ(utils.EnumExpression(model.UserPermissionLevelEnum_Owner.String()))),
+		).
+		QueryContext(ctx, ai.Db, &owners)
+
+	if err != nil {
+		ai.Logger.Error("error fetching the owners to warn about the AI budget", "organization_id", organizationId.String(), "error", err.Error())
+		return
+	}
+
+	title := "Your organization is close to its monthly AI budget"
+	description := fmt.Sprintf("Your organization used %.0f%% of its monthly AI budget.", *status.UsagePercentage)
+	if status.Configuration.IsHardLimitEnabled {
+		description += " The AI features will be blocked until the start of next month once the budget is used up."
+	}
+
+	orgId := organizationId.String()
+	ctaUrl := "/settings?tab=ai-settings"
+	notificationType := "AiBudget"
+	for _, owner := range owners {
+		memberId := owner.UniqueId.String()
+		notificationService.SendInAppNotification(notification_service.InAppNotificationParams{
+			Title:                title,
+			Description:          description,
+			OrganizationId:       &orgId,
+			OrganizationMemberId: &memberId,
+			CtaUrl:               &ctaUrl,
+			Type:                 &notificationType,
+		})
+
+		if err := notificationService.SendEmail(owner.User.Email, title, description, ai.Config.IsProduction); err != nil {
+			ai.Logger.Error("error sending the AI budget warning email", "organization_id", orgId, "error", err.Error())
+		}
+	}
+}
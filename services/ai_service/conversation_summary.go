@@ -0,0 +1,164 @@
+package ai_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	"github.com/wapikit/wapikit/.db-generated/table"
+)
+
+const (
+	// * a summary only changes with a new message, the cache entry of an older last message is never read again
+	conversationSummaryCacheTtl = 7 * 24 * time.Hour
+	// * the oldest messages of the long conversations are left out, the summary is about where the conversation ended up
+	conversationSummaryMaxMessages = 200
+)
+
+func conversationSummaryCacheKey(conversationId, lastMessageId uuid.UUID) string {
+	return "conversation_summary:" + conversationId.String() + ":" + lastMessageId.String()
+}
+
+// FetchConversationMessagesForSummary returns the latest messages of the conversation to summarize, oldest first
+func (ai *AiService) FetchConversationMessagesForSummary(ctx context.Context, conversationId uuid.UUID) ([]model.Message, error) {
+	var messages []model.Message
+	err := SELECT(table.Message.AllColumns).
+		FROM(table.Message).
+		WHERE(table.Message.ConversationId.EQ(UUID(conversationId))).
+		ORDER_BY(table.Message.CreatedAt.DESC()).
+		LIMIT(conversationSummaryMaxMessages).
+		QueryContext(ctx, ai.Db, &messages)
+
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(messages)
+	return messages, nil
+}
+
+// ConversationSummaryPrompt builds the prompt summarizing the messages of a conversation, the personal data in them is redacted
+func (ai *AiService) ConversationSummaryPrompt(messages []model.Message) []llms.MessageContent {
+	lines := make([]string, 0, len(messages))
+	for _, message := range messages {
+		text := messageText(message)
+		if text == "" {
+			text = "[" + message.MessageType.String() + " message]"
+		}
+
+		author := "Contact"
+		if message.Direction == model.MessageDirectionEnum_OutBound {
+			author = "Organization member"
+		}
+
+		lines = append(lines, author+": "+text)
+	}
+
+	return []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, SYSTEM_PROMPT_CHAT_SUMMARY_GENERATION),
+		llms.TextParts(llms.ChatMessageTypeHuman, strings.Join(lines, "\n")),
+	}
+}
+
+// GetCachedConversationSummary returns the summary of the conversation up to the message when it was already generated
+func (ai *AiService) GetCachedConversationSummary(ctx context.Context, conversationId, lastMessageId uuid.UUID) (string, bool) {
+	var summary string
+	isCached, err := ai.Redis.GetCachedData(conversationSummaryCacheKey(conversationId, lastMessageId), &summary)
+	if err == nil && isCached {
+		return summary, true
+	}
+
+	// * the summaries stored on close outlive the cache
+	var storedSummary model.ConversationSummary
+	err = SELECT(table.ConversationSummary.Content).
+		FROM(table.ConversationSummary).
+		WHERE(
+			table.ConversationSummary.ConversationId.EQ(UUID(conversationId)).
+				AND(table.ConversationSummary.LastMessageId.EQ(UUID(lastMessageId))),
+		).
+		LIMIT(1).
+		QueryContext(ctx, ai.Db, &storedSummary)
+
+	if err != nil {
+		return "", false
+	}
+
+	return storedSummary.Content, true
+}
+
+func (ai *AiService) CacheConversationSummary(conversationId, lastMessageId uuid.UUID, summary string) {
+	if err := ai.Redis.CacheData(conversationSummaryCacheKey(conversationId, lastMessageId), summary, conversationSummaryCacheTtl); err != nil {
+		ai.Logger.Error("error caching the conversation summary", "conversation_id", conversationId.String(), "error", err.Error())
+	}
+}
+
+// SummarizeConversation returns the summary of the conversation up to its last message, it is generated only when it is not cached
+func (ai *AiService) SummarizeConversation(ctx context.Context, organizationId, conversationId uuid.UUID, messages []model.Message) (string, error) {
+	if len(messages) == 0 {
+		return "", errors.New("the conversation has no messages to summarize")
+	}
+
+	lastMessageId := messages[len(messages)-1].UniqueId
+	if summary, isCached := ai.GetCachedConversationSummary(ctx, conversationId, lastMessageId); isCached {
+		return summary, nil
+	}
+
+	inputPrompt := ai.ConversationSummaryPrompt(messages)
+	aiResponse, err := ai.QueryAiModel(ctx, AiTaskSummary, inputPrompt)
+	if err != nil {
+		return "", err
+	}
+
+	requestJson, _ := json.Marshal(inputPrompt)
+	ai.LogApiCall(organizationId, ai.Db, AiTaskSummary, string(requestJson), aiResponse.Content, model.AiModelEnum(aiResponse.ModelUsed), aiResponse.InputTokenUsed, aiResponse.OutputTokenUsed)
+
+	summary := strings.TrimSpace(aiResponse.Content)
+	ai.CacheConversationSummary(conversationId, lastMessageId, summary)
+	return summary, nil
+}
+
+// StoreClosedConversationSummary summarizes a closed conversation and keeps the summary to search it later,
+// the summary of a conversation closed again replaces the previous one
+func (ai *AiService) StoreClosedConversationSummary(ctx context.Context, organizationId, conversationId uuid.UUID) error {
+	messages, err := ai.FetchConversationMessagesForSummary(ctx, conversationId)
+	if err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+
+	summary, err := ai.SummarizeConversation(ctx, organizationId, conversationId, messages)
+	if err != nil {
+		return err
+	}
+
+	_, err = table.ConversationSummary.
+		INSERT(table.ConversationSummary.MutableColumns).
+		MODEL(model.ConversationSummary{
+			OrganizationId: organizationId,
+			ConversationId: conversationId,
+			LastMessageId:  messages[len(messages)-1].UniqueId,
+			Content:        summary,
+			Model:          model.AiModelEnum(ai.DefaultAiModel),
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		}).
+		ON_CONFLICT(table.ConversationSummary.ConversationId).
+		DO_UPDATE(SET(
+			table.ConversationSummary.LastMessageId.SET(table.ConversationSummary.EXCLUDED.LastMessageId),
+			table.ConversationSummary.Content.SET(table.ConversationSummary.EXCLUDED.Content),
+			table.ConversationSummary.Model.SET(table.ConversationSummary.EXCLUDED.Model),
+			table.ConversationSummary.UpdatedAt.SET(table.ConversationSummary.EXCLUDED.UpdatedAt),
+		)).
+		ExecContext(ctx, ai.Db)
+
+	return err
+}
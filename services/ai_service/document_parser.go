@@ -0,0 +1,412 @@
+package ai_service
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wapikit/wapikit/utils"
+	"golang.org/x/net/html"
+)
+
+const (
+	maxWebPageSize      = 5 << 20
+	webPageFetchTimeout = 15 * time.Second
+	// * a single content stream of a pdf is not expected to inflate past this, anything bigger is skipped
+	maxPdfStreamSize = 20 << 20
+)
+
+var (
+	ErrPdfHasNoText   = errors.New("no text found in the PDF, scanned PDFs are not supported")
+	ErrPdfIsEncrypted = errors.New("encrypted PDFs are not supported")
+
+	pdfStreamRegex  = regexp.MustCompile(`(?s)<<(.*?)>>\s*stream\r?\n`)
+	whitespaceRegex = regexp.MustCompile(`[ \t\f\v]+`)
+	blankLinesRegex = regexp.MustCompile(`\n\s*\n\s*(\n\s*)+`)
+)
+
+// ExtractPdfText returns the text of the content streams of the pdf, it reads the text layer only, without any font mapping,
+// which covers the pdfs exported from word processors and browsers
+func ExtractPdfText(data []byte) (string, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("%PDF")) {
+		return "", errors.New("the file is not a PDF")
+	}
+	if bytes.Contains(data, []byte("/Encrypt")) {
+		return "", ErrPdfIsEncrypted
+	}
+
+	var text strings.Builder
+	for _, match := range pdfStreamRegex.FindAllSubmatchIndex(data, -1) {
+		dictionary := string(data[match[2]:match[3]])
+		streamStart := match[1]
+		streamEnd := bytes.Index(data[streamStart:], []byte("endstream"))
+		if streamEnd < 0 {
+			break
+		}
+		stream := data[streamStart : streamStart+streamEnd]
+
+		// * images, fonts and other binary streams have no text to extract
+		if strings.Contains(dictionary, "/Subtype") || strings.Contains(dictionary, "/Length1") || strings.Contains(dictionary, "/Type /XRef") || strings.Contains(dictionary, "/Type/XRef") {
+			continue
+		}
+
+		if strings.Contains(dictionary, "/Filter") {
+			if !strings.Contains(dictionary, "/FlateDecode") {
+				continue
+			}
+			inflated, err := inflatePdfStream(stream)
+			if err != nil {
+				continue
+			}
+			stream = inflated
+		}
+
+		streamText := textOfPdfContentStream(stream)
+		if strings.TrimSpace(streamText) != "" {
+			text.WriteString(streamText)
+			text.WriteString("\n")
+		}
+	}
+
+	result := normalizeExtractedText(text.String())
+	if result == "" {
+		return "", ErrPdfHasNoText
+	}
+	return result, nil
+}
+
+func inflatePdfStream(stream []byte) ([]byte, error) {
+	reader, err := zlib.NewReader(bytes.NewReader(stream))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	inflated, err := io.ReadAll(io.LimitReader(reader, maxPdfStreamSize))
+	// * some writers pad the streams, the data read before the error is still usable
+	if err != nil && len(inflated) == 0 {
+		return nil, err
+	}
+	return inflated, nil
+}
+
+// textOfPdfContentStream collects the strings shown by the text operators (Tj, TJ, ' and ") of a content stream,
+// the operators moving to a new line become line breaks
+func textOfPdfContentStream(stream []byte) string {
+	var text strings.Builder
+	var operands []string
+	inTextObject := false
+
+	for i := 0; i < len(stream); {
+		character := stream[i]
+		switch {
+		case character == '(':
+			value, next := readPdfLiteralString(stream, i)
+			operands = append(operands, value)
+			i = next
+		case character == '<' && i+1 < len(stream) && stream[i+1] != '<':
+			value, next := readPdfHexString(stream, i)
+			operands = append(operands, value)
+			i = next
+		case character == '[':
+			operands = append(operands, "[")
+			i++
+		case character == ']':
+			// * the strings of a TJ array are joined, the big negative offsets between them are word gaps
+			start := len(operands) - 1
+			for start >= 0 && operands[start] != "[" {
+				start--
+			}
+			if start < 0 {
+				i++
+				continue
+			}
+			var joined strings.Builder
+			for _, operand := range operands[start+1:] {
+				if offset, err := strconv.ParseFloat(operand, 64); err == nil {
+					if offset < -200 {
+						joined.WriteString(" ")
+					}
+					continue
+				}
+				joined.WriteString(strings.TrimPrefix(operand, "\x00"))
+			}
+			operands = append(operands[:start], "\x00"+joined.String())
+			i++
+		case character == '%':
+			for i < len(stream) && stream[i] != '\n' && stream[i] != '\r' {
+				i++
+			}
+		case isPdfWhitespace(character):
+			i++
+		case isPdfDelimiter(character):
+			i++
+		default:
+			start := i
+			for i < len(stream) && !isPdfWhitespace(stream[i]) && !isPdfDelimiter(stream[i]) {
+				i++
+			}
+			token := string(stream[start:i])
+
+			switch token {
+			case "BT":
+				inTextObject = true
+			case "ET":
+				inTextObject = false
+				text.WriteString("\n")
+			case "Tj", "TJ":
+				if inTextObject && len(operands) > 0 {
+					text.WriteString(strings.TrimPrefix(operands[len(operands)-1], "\x00"))
+				}
+			case "'", "\"":
+				if inTextObject && len(operands) > 0 {
+					text.WriteString("\n")
+					text.WriteString(strings.TrimPrefix(operands[len(operands)-1], "\x00"))
+				}
+			case "Td", "TD", "T*", "Tm":
+				if inTextObject {
+					text.WriteString("\n")
+				}
+			default:
+				// * numbers and names are operands of the next operator
+				if _, err := strconv.ParseFloat(token, 64); err == nil {
+					operands = append(operands, token)
+					continue
+				}
+				if strings.HasPrefix(token, "/") {
+					operands = append(operands, token)
+					continue
+				}
+			}
+			operands = operands[:0]
+		}
+	}
+
+	return text.String()
+}
+
+func readPdfLiteralString(stream []byte, start int) (string, int) {
+	var value strings.Builder
+	depth := 0
+	i := start
+	for ; i < len(stream); i++ {
+		character := stream[i]
+		switch character {
+		case '(':
+			depth++
+			if depth == 1 {
+				continue
+			}
+		case ')':
+			depth--
+			if depth == 0 {
+				return "\x00" + value.String(), i + 1
+			}
+		case '\\':
+			i++
+			if i >= len(stream) {
+				continue
+			}
+			escaped := stream[i]
+			switch escaped {
+			case 'n':
+				value.WriteString("\n")
+			case 'r':
+				value.WriteString("\r")
+			case 't':
+				value.WriteString("\t")
+			case 'b', 'f':
+			case '\r', '\n':
+				// * a line continuation
+			default:
+				if escaped >= '0' && escaped <= '7' {
+					end := i
+					for end < len(stream) && end < i+3 && stream[end] >= '0' && stream[end] <= '7' {
+						end++
+					}
+					code, _ := strconv.ParseUint(string(stream[i:end]), 8, 8)
+					value.WriteRune(rune(byte(code)))
+					i = end - 1
+					continue
+				}
+				value.WriteRune(rune(escaped))
+			}
+			continue
+		}
+		value.WriteRune(rune(character))
+	}
+	return "\x00" + value.String(), i
+}
+
+func readPdfHexString(stream []byte, start int) (string, int) {
+	end := bytes.IndexByte(stream[start:], '>')
+	if end < 0 {
+		return "\x00", len(stream)
+	}
+	hex := make([]byte, 0, end)
+	for _, character := range stream[start+1 : start+end] {
+		if !isPdfWhitespace(character) {
+			hex = append(hex, character)
+		}
+	}
+	if len(hex)%2 == 1 {
+		hex = append(hex, '0')
+	}
+
+	decoded := make([]byte, 0, len(hex)/2)
+	for i := 0; i+1 < len(hex); i += 2 {
+		value, err := strconv.ParseUint(string(hex[i:i+2]), 16, 8)
+		if err != nil {
+			return "\x00", start + end + 1
+		}
+		decoded = append(decoded, byte(value))
+	}
+
+	// * two byte codes with a zero high byte are utf-16, anything else needs the font mapping and is left out
+	var value strings.Builder
+	if len(decoded)%2 == 0 && len(decoded) > 0 && decoded[0] == 0 {
+		for i := 0; i+1 < len(decoded); i += 2 {
+			value.WriteRune(rune(decoded[i])<<8 | rune(decoded[i+1]))
+		}
+	} else if len(decoded) > 0 && decoded[0] != 0 {
+		for _, character := range decoded {
+			value.WriteRune(rune(character))
+		}
+	}
+	return "\x00" + value.String(), start + end + 1
+}
+
+func isPdfWhitespace(character byte) bool {
+	return character == ' ' || character == '\n' || character == '\r' || character == '\t' || character == '\f' || character == 0
+}
+
+func isPdfDelimiter(character byte) bool {
+	return character == '(' || character == ')' || character == '<' || character == '>' || character == '[' || character == ']' || character == '{' || character == '}' || character == '%'
+}
+
+// ExtractHtmlText returns the title and the readable text of an html page, without the scripts, styles and navigation
+func ExtractHtmlText(reader io.Reader) (string, string, error) {
+	skippedElements := map[string]bool{
+		"script": true, "style": true, "noscript": true, "svg": true, "template": true,
+		"iframe": true, "nav": true, "head": true,
+	}
+	blockElements := map[string]bool{
+		"p": true, "div": true, "br": true, "li": true, "tr": true, "section": true, "article": true,
+		"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "table": true,
+		"ul": true, "ol": true, "header": true, "footer": true, "main": true, "blockquote": true, "dt": true, "dd": true,
+	}
+
+	var title, text strings.Builder
+	tokenizer := html.NewTokenizer(reader)
+	skipDepth := 0
+	inTitle := false
+
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			if errors.Is(tokenizer.Err(), io.EOF) {
+				return strings.TrimSpace(whitespaceRegex.ReplaceAllString(title.String(), " ")), normalizeExtractedText(text.String()), nil
+			}
+			return "", "", tokenizer.Err()
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+			if tag == "title" {
+				inTitle = tokenType == html.StartTagToken
+			} else if skippedElements[tag] && tokenType == html.StartTagToken {
+				skipDepth++
+			}
+			if blockElements[tag] {
+				text.WriteString("\n")
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+			if tag == "title" {
+				inTitle = false
+			} else if skippedElements[tag] && skipDepth > 0 {
+				skipDepth--
+			}
+			if blockElements[tag] {
+				text.WriteString("\n")
+			}
+		case html.TextToken:
+			if inTitle {
+				title.Write(tokenizer.Text())
+				continue
+			}
+			if skipDepth == 0 {
+				text.Write(tokenizer.Text())
+			}
+		}
+	}
+}
+
+// FetchWebPage downloads a public web page and returns its title and text, the private and loopback addresses are refused
+// at connection time so that a redirect or a dns answer can not point the server at the internal network
+func FetchWebPage(ctx context.Context, pageUrl string) (string, string, error) {
+	parsedUrl, err := url.Parse(pageUrl)
+	if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+		return "", "", errors.New("invalid url, only http and https urls are supported")
+	}
+
+	client := utils.NewPublicHttpClient(webPageFetchTimeout)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, parsedUrl.String(), nil)
+	if err != nil {
+		return "", "", err
+	}
+	request.Header.Set("User-Agent", "WapikitKnowledgeBase/1.0")
+	request.Header.Set("Accept", "text/html,text/plain")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return "", "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return "", "", fmt.Errorf("the page responded with status %d", response.StatusCode)
+	}
+
+	body := io.LimitReader(response.Body, maxWebPageSize)
+	contentType := response.Header.Get("Content-Type")
+	switch {
+	case strings.HasPrefix(contentType, "text/plain"):
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return "", "", err
+		}
+		return "", normalizeExtractedText(string(data)), nil
+	case contentType == "" || strings.HasPrefix(contentType, "text/html") || strings.HasPrefix(contentType, "application/xhtml+xml"):
+		return ExtractHtmlText(body)
+	default:
+		return "", "", fmt.Errorf("unsupported content type %s, only html and plain text pages are supported", contentType)
+	}
+}
+
+// normalizeExtractedText collapses the runs of spaces and of blank lines left behind by the layout of the source
+func normalizeExtractedText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	text = strings.ReplaceAll(text, " ", " ")
+	text = whitespaceRegex.ReplaceAllString(text, " ")
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	text = strings.Join(lines, "\n")
+	text = blankLinesRegex.ReplaceAllString(text, "\n\n")
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,609 @@
+package ai_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	"github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+)
+
+const (
+	// * sizes are in characters, a chunk of this size is a few paragraphs, small enough to be precise and big enough to carry context
+	knowledgeBaseChunkSize    = 1200
+	knowledgeBaseChunkOverlap = 200
+	KnowledgeBaseMaxChunks    = 2000
+	knowledgeBaseEmbedBatch   = 32
+	// * the size of the pgvector column, the embedding models of the providers return at most this many dimensions
+	knowledgeBaseEmbeddingDimensions = 1536
+	KnowledgeBaseDefaultMatches      = 5
+	// * below this cosine similarity a passage is unrelated to the question and would only confuse the model
+	knowledgeBaseMinSimilarity   = 0.3
+	knowledgeBaseExcerptLength   = 300
+	knowledgeBaseIndexingTimeout = 10 * time.Minute
+	// * a document is indexed by one worker at a time, so that a re-index does not race the first one
+	knowledgeBaseIndexingLockPrefix = "knowledge_base_indexing:"
+)
+
+// keywordSearchQuery ranks the chunks of the indexed documents of the organization ($1) against the query ($2) by full text search,
+// it is used for the documents without comparable embeddings, $3 is the embedding model whose chunks are searched by vectors instead
+const keywordSearchQuery = `
+SELECT c."UniqueId", ts_rank(to_tsvector('simple'::regconfig, c."Content"), plainto_tsquery('simple'::regconfig, $2)) AS "Rank"
+FROM "KnowledgeBaseChunk" c
+JOIN "KnowledgeBaseDocument" d ON d."UniqueId" = c."KnowledgeBaseDocumentId"
+WHERE c."OrganizationId" = $1
+	AND d."Status" = 'Indexed'
+	AND d."EmbeddingModel" IS DISTINCT FROM $3
+	AND to_tsvector('simple'::regconfig, c."Content") @@ plainto_tsquery('simple'::regconfig, $2)
+ORDER BY "Rank" DESC
+LIMIT $4`
+
+// vectorSearchQuery returns the chunks of the indexed documents of the organization ($1) embedded with the model ($3) closest to the
+// embedding of the query ($2), by the cosine distance of pgvector which the HNSW index of the embeddings serves
+const vectorSearchQuery = `
+SELECT c."UniqueId", 1 - (c."Embedding" <=> $2::vector) AS "Similarity"
+FROM "KnowledgeBaseChunk" c
+JOIN "KnowledgeBaseDocument" d ON d."UniqueId" = c."KnowledgeBaseDocumentId"
+WHERE c."OrganizationId" = $1
+	AND d."Status" = 'Indexed'
+	AND d."EmbeddingModel" = $3
+	AND c."Embedding" IS NOT NULL
+ORDER BY c."Embedding" <=> $2::vector
+LIMIT $4`
+
+// KnowledgeBaseMatch is a passage of the knowledge base relevant to a query
+type KnowledgeBaseMatch struct {
+	Chunk    model.KnowledgeBaseChunk
+	Document model.KnowledgeBaseDocument
+	Score    float64
+}
+
+func ParseKnowledgeBaseDocumentToApiSchema(document model.KnowledgeBaseDocument) api_types.KnowledgeBaseDocumentSchema {
+	return api_types.KnowledgeBaseDocumentSchema{
+		UniqueId:   document.UniqueId.String(),
+		Title:      document.Title,
+		SourceType: api_types.KnowledgeBaseSourceTypeEnum(document.SourceType.String()),
+		SourceUrl:  document.SourceUrl,
+		Status:     api_types.KnowledgeBaseDocumentStatusEnum(document.Status.String()),
+		Error:      document.Error,
+		ChunkCount: int(document.ChunkCount),
+		CreatedAt:  document.CreatedAt,
+		UpdatedAt:  document.UpdatedAt,
+	}
+}
+
+// FaqListContent turns a list of questions and answers into the content of a document, one question per paragraph
+// so that every question and its answer make a chunk of their own
+func FaqListContent(faqs []api_types.KnowledgeBaseFaqSchema) (string, error) {
+	paragraphs := make([]string, 0, len(faqs))
+	for _, faq := range faqs {
+		question := strings.TrimSpace(faq.Question)
+		answer := strings.TrimSpace(faq.Answer)
+		if question == "" || answer == "" {
+			return "", errors.New("every FAQ needs a question and an answer")
+		}
+		answer = blankLinesRegex.ReplaceAllString(strings.ReplaceAll(answer, "\n\n", "\n"), "\n")
+		paragraphs = append(paragraphs, "Q: "+question+"\nA: "+answer)
+	}
+	return strings.Join(paragraphs, "\n\n"), nil
+}
+
+// SplitIntoChunks splits a text into overlapping chunks along the paragraphs, the sentences and finally the words,
+// the FAQ lists are split per question
+func SplitIntoChunks(text string, sourceType model.KnowledgeBaseSourceTypeEnum) []string {
+	paragraphs := strings.Split(text, "\n\n")
+
+	if sourceType == model.KnowledgeBaseSourceTypeEnum_FaqList {
+		chunks := []string{}
+		for _, paragraph := range paragraphs {
+			chunks = append(chunks, splitLongText(strings.TrimSpace(paragraph))...)
+		}
+		return chunks
+	}
+
+	chunks := []string{}
+	current := ""
+	for _, paragraph := range paragraphs {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+
+		if utf8.RuneCountInString(current)+utf8.RuneCountInString(paragraph)+2 <= knowledgeBaseChunkSize {
+			if current != "" {
+				current += "\n\n"
+			}
+			current += paragraph
+			continue
+		}
+
+		if current != "" {
+			chunks = append(chunks, current)
+		}
+
+		if utf8.RuneCountInString(paragraph) > knowledgeBaseChunkSize {
+			parts := splitLongText(paragraph)
+			chunks = append(chunks, parts[:len(parts)-1]...)
+			current = parts[len(parts)-1]
+			continue
+		}
+
+		// * the next chunk starts with the end of the previous one, so that a passage cut in two is still found whole
+		current = overlapOf(current) + paragraph
+	}
+
+	if current != "" {
+		chunks = append(chunks, current)
+	}
+
+	return chunks
+}
+
+// splitLongText splits a paragraph longer than a chunk at the sentence or word boundaries
+func splitLongText(text string) []string {
+	runes := []rune(text)
+	if len(runes) <= knowledgeBaseChunkSize {
+		return []string{text}
+	}
+
+	chunks := []string{}
+	for start := 0; start < len(runes); {
+		end := start + knowledgeBaseChunkSize
+		if end >= len(runes) {
+			chunks = append(chunks, strings.TrimSpace(string(runes[start:])))
+			break
+		}
+
+		cut := lastBoundary(runes[start:end])
+		if cut <= knowledgeBaseChunkOverlap {
+			cut = end - start
+		}
+		chunks = append(chunks, strings.TrimSpace(string(runes[start:start+cut])))
+		start += cut - knowledgeBaseChunkOverlap
+	}
+
+	return chunks
+}
+
+// lastBoundary returns the position after the last sentence end of the text, or after its last space when it has no sentence end
+func lastBoundary(runes []rune) int {
+	lastSpace := -1
+	for i := len(runes) - 1; i > 0; i-- {
+		if (runes[i-1] == '.' || runes[i-1] == '?' || runes[i-1] == '!' || runes[i-1] == '\n') && runes[i] == ' ' {
+			return i
+		}
+		if lastSpace < 0 && runes[i] == ' ' {
+			lastSpace = i
+		}
+	}
+	return lastSpace
+}
+
+func overlapOf(chunk string) string {
+	runes := []rune(chunk)
+	if len(runes) <= knowledgeBaseChunkOverlap {
+		return ""
+	}
+	overlap := runes[len(runes)-knowledgeBaseChunkOverlap:]
+	// * the overlap starts at a word, not in the middle of one
+	for i, character := range overlap {
+		if character == ' ' || character == '\n' {
+			return strings.TrimSpace(string(overlap[i:])) + "\n\n"
+		}
+	}
+	return ""
+}
+
+// IndexKnowledgeBaseDocumentInBackground indexes the document in a go routine, the status of the document tells when it is done
+func (ai *AiService) IndexKnowledgeBaseDocumentInBackground(documentId uuid.UUID) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), knowledgeBaseIndexingTimeout)
+		defer cancel()
+
+		lock, err := ai.Redis.AcquireLock(knowledgeBaseIndexingLockPrefix+documentId.String(), knowledgeBaseIndexingTimeout)
+		if err != nil {
+			ai.Logger.Error("error acquiring knowledge base indexing lock", "document_id", documentId.String(), "error", err.Error())
+			return
+		}
+		defer func() {
+			if err := ai.Redis.ReleaseLock(lock); err != nil {
+				ai.Logger.Error("error releasing knowledge base indexing lock", "document_id", documentId.String(), "error", err.Error())
+			}
+		}()
+
+		if err := ai.IndexKnowledgeBaseDocument(ctx, documentId); err != nil {
+			ai.Logger.Error("error indexing knowledge base document", "document_id", documentId.String(), "error", err.Error())
+		}
+	}()
+}
+
+// IndexKnowledgeBaseDocument splits the document in chunks, embeds them with the model of the organization and replaces the previous chunks,
+// the document is marked as failed with the reason when anything goes wrong, the models without embeddings index for the keyword search only
+func (ai *AiService) IndexKnowledgeBaseDocument(ctx context.Context, documentId uuid.UUID) error {
+	var document model.KnowledgeBaseDocument
+	err := SELECT(table.KnowledgeBaseDocument.AllColumns).
+		FROM(table.KnowledgeBaseDocument).
+		WHERE(table.KnowledgeBaseDocument.UniqueId.EQ(UUID(documentId))).
+		LIMIT(1).
+		QueryContext(ctx, ai.Db, &document)
+
+	if err != nil {
+		return err
+	}
+
+	err = ai.indexKnowledgeBaseDocument(ctx, document)
+	if err != nil {
+		errorMessage := err.Error()
+		_, updateErr := table.KnowledgeBaseDocument.
+			UPDATE(table.KnowledgeBaseDocument.Status, table.KnowledgeBaseDocument.Error, table.KnowledgeBaseDocument.UpdatedAt).
+			MODEL(model.KnowledgeBaseDocument{
+				Status:    model.KnowledgeBaseDocumentStatusEnum_IndexingFailed,
+				Error:     &errorMessage,
+				UpdatedAt: time.Now(),
+			}).
+			WHERE(table.KnowledgeBaseDocument.UniqueId.EQ(UUID(documentId))).
+			ExecContext(ctx, ai.Db)
+
+		if updateErr != nil {
+			ai.Logger.Error("error marking knowledge base document as failed", "document_id", documentId.String(), "error", updateErr.Error())
+		}
+	}
+
+	return err
+}
+
+func (ai *AiService) indexKnowledgeBaseDocument(ctx context.Context, document model.KnowledgeBaseDocument) error {
+	chunks := SplitIntoChunks(document.Content, document.SourceType)
+	if len(chunks) == 0 {
+		return errors.New("the document has no text")
+	}
+	if len(chunks) > KnowledgeBaseMaxChunks {
+		return fmt.Errorf("the document is too long, it makes %d passages and at most %d are allowed", len(chunks), KnowledgeBaseMaxChunks)
+	}
+
+	var embeddings [][]float32
+	var embeddingModel *string
+	embedder, embeddingModelName, err := ai.ModelRouter.SelectEmbedder(ctx, ai.DefaultAiModel, ai.ApiKey)
+	if err == nil {
+		for start := 0; start < len(chunks); start += knowledgeBaseEmbedBatch {
+			end := min(start+knowledgeBaseEmbedBatch, len(chunks))
+			batch, err := embedder.CreateEmbedding(ctx, chunks[start:end])
+			if err != nil {
+				return fmt.Errorf("error creating the embeddings: %w", err)
+			}
+			if len(batch) != end-start {
+				return errors.New("error creating the embeddings: the model returned fewer embeddings than passages")
+			}
+			embeddings = append(embeddings, batch...)
+		}
+		embeddingModel = &embeddingModelName
+	} else if !errors.Is(err, ErrEmbeddingsNotSupported) {
+		return err
+	}
+
+	chunksToInsert := make([]model.KnowledgeBaseChunk, 0, len(chunks))
+	for index, chunk := range chunks {
+		chunkToInsert := model.KnowledgeBaseChunk{
+			OrganizationId:          document.OrganizationId,
+			KnowledgeBaseDocumentId: document.UniqueId,
+			ChunkIndex:              int32(index),
+			Content:                 chunk,
+			CreatedAt:               time.Now(),
+			UpdatedAt:               time.Now(),
+		}
+		if embeddings != nil {
+			vector, err := embeddingToVector(embeddings[index])
+			if err != nil {
+				return err
+			}
+			chunkToInsert.Embedding = &vector
+		}
+		chunksToInsert = append(chunksToInsert, chunkToInsert)
+	}
+
+	tx, err := ai.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = table.KnowledgeBaseChunk.
+		DELETE().
+		WHERE(table.KnowledgeBaseChunk.KnowledgeBaseDocumentId.EQ(UUID(document.UniqueId))).
+		ExecContext(ctx, tx)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = table.KnowledgeBaseChunk.
+		INSERT(table.KnowledgeBaseChunk.MutableColumns).
+		MODELS(chunksToInsert).
+		ExecContext(ctx, tx)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = table.KnowledgeBaseDocument.
+		UPDATE(
+			table.KnowledgeBaseDocument.Status,
+			table.KnowledgeBaseDocument.Error,
+			table.KnowledgeBaseDocument.ChunkCount,
+			table.KnowledgeBaseDocument.EmbeddingModel,
+			table.KnowledgeBaseDocument.UpdatedAt,
+		).
+		MODEL(model.KnowledgeBaseDocument{
+			Status:         model.KnowledgeBaseDocumentStatusEnum_Indexed,
+			Error:          nil,
+			ChunkCount:     int32(len(chunksToInsert)),
+			EmbeddingModel: embeddingModel,
+			UpdatedAt:      time.Now(),
+		}).
+		WHERE(table.KnowledgeBaseDocument.UniqueId.EQ(UUID(document.UniqueId))).
+		ExecContext(ctx, tx)
+
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// * the cached answers were given without the new passages
+	if err := ai.VectorCache.Clear(ctx, document.OrganizationId, SemanticCacheScopeAiChat); err != nil {
+		ai.Logger.Error("error clearing the semantic cache", "organization_id", document.OrganizationId.String(), "error", err.Error())
+	}
+
+	return nil
+}
+
+// SearchKnowledgeBase returns the passages of the knowledge base of the organization closest to the query, the most relevant first,
+// the documents embedded with the current model are compared by meaning, the others by keywords
+func (ai *AiService) SearchKnowledgeBase(ctx context.Context, organizationId uuid.UUID, query string, limit int) ([]KnowledgeBaseMatch, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []KnowledgeBaseMatch{}, nil
+	}
+	if limit <= 0 {
+		limit = KnowledgeBaseDefaultMatches
+	}
+
+	matches := []KnowledgeBaseMatch{}
+	var searchedEmbeddingModel *string
+
+	embedder, embeddingModel, err := ai.ModelRouter.SelectEmbedder(ctx, ai.DefaultAiModel, ai.ApiKey)
+	if err == nil {
+		vectorMatches, err := ai.searchKnowledgeBaseByVector(ctx, organizationId, embedder, embeddingModel, ai.SanitizeInput(query), limit)
+		if err != nil {
+			// * the keyword search still answers, only less precisely
+			ai.Logger.Error("error searching the knowledge base by vector", "organization_id", organizationId.String(), "error", err.Error())
+		} else {
+			matches = append(matches, vectorMatches...)
+			searchedEmbeddingModel = &embeddingModel
+		}
+	}
+
+	if len(matches) >= limit {
+		return matches[:limit], nil
+	}
+
+	keywordMatches, err := ai.searchKnowledgeBaseByKeywords(ctx, organizationId, searchedEmbeddingModel, query, limit-len(matches))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(matches, keywordMatches...), nil
+}
+
+func (ai *AiService) searchKnowledgeBaseByVector(ctx context.Context, organizationId uuid.UUID, embedder Embedder, embeddingModel, query string, limit int) ([]KnowledgeBaseMatch, error) {
+	queryEmbeddings, err := embedder.CreateEmbedding(ctx, []string{query})
+	if err != nil {
+		return nil, err
+	}
+	if len(queryEmbeddings) == 0 {
+		return nil, errors.New("the model returned no embedding for the query")
+	}
+
+	queryVector, err := embeddingToVector(queryEmbeddings[0])
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := ai.Db.QueryContext(ctx, vectorSearchQuery, organizationId, queryVector, embeddingModel, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	similarities := map[uuid.UUID]float64{}
+	for rows.Next() {
+		var chunkId uuid.UUID
+		var similarity float64
+		if err := rows.Scan(&chunkId, &similarity); err != nil {
+			return nil, err
+		}
+		if similarity < knowledgeBaseMinSimilarity {
+			continue
+		}
+		similarities[chunkId] = similarity
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ai.fetchKnowledgeBaseMatches(ctx, similarities)
+}
+
+func (ai *AiService) searchKnowledgeBaseByKeywords(ctx context.Context, organizationId uuid.UUID, excludedEmbeddingModel *string, query string, limit int) ([]KnowledgeBaseMatch, error) {
+	rows, err := ai.Db.QueryContext(ctx, keywordSearchQuery, organizationId, query, excludedEmbeddingModel, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ranks := map[uuid.UUID]float64{}
+	for rows.Next() {
+		var chunkId uuid.UUID
+		var rank float64
+		if err := rows.Scan(&chunkId, &rank); err != nil {
+			return nil, err
+		}
+		ranks[chunkId] = rank
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ai.fetchKnowledgeBaseMatches(ctx, ranks)
+}
+
+// fetchKnowledgeBaseMatches loads the chunks scored by a search along with their documents, the best scored first
+func (ai *AiService) fetchKnowledgeBaseMatches(ctx context.Context, scores map[uuid.UUID]float64) ([]KnowledgeBaseMatch, error) {
+	if len(scores) == 0 {
+		return []KnowledgeBaseMatch{}, nil
+	}
+
+	chunkIds := make([]Expression, 0, len(scores))
+	for chunkId := range scores {
+		chunkIds = append(chunkIds, UUID(chunkId))
+	}
+
+	var chunks []struct {
+		model.KnowledgeBaseChunk
+		Document model.KnowledgeBaseDocument
+	}
+
+	// * the embeddings are of no use past the search, they are not loaded
+	err := SELECT(
+		table.KnowledgeBaseChunk.UniqueId,
+		table.KnowledgeBaseChunk.CreatedAt,
+		table.KnowledgeBaseChunk.UpdatedAt,
+		table.KnowledgeBaseChunk.OrganizationId,
+		table.KnowledgeBaseChunk.KnowledgeBaseDocumentId,
+		table.KnowledgeBaseChunk.ChunkIndex,
+		table.KnowledgeBaseChunk.Content,
+		table.KnowledgeBaseDocument.AllColumns,
+	).
+		FROM(table.KnowledgeBaseChunk.
+			INNER_JOIN(table.KnowledgeBaseDocument, table.KnowledgeBaseDocument.UniqueId.EQ(table.KnowledgeBaseChunk.KnowledgeBaseDocumentId)),
+		).
+		WHERE(table.KnowledgeBaseChunk.UniqueId.IN(chunkIds...)).
+		QueryContext(ctx, ai.Db, &chunks)
+
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]KnowledgeBaseMatch, 0, len(chunks))
+	for _, chunk := range chunks {
+		matches = append(matches, KnowledgeBaseMatch{
+			Chunk:    chunk.KnowledgeBaseChunk,
+			Document: chunk.Document,
+			Score:    scores[chunk.UniqueId],
+		})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+
+	return matches, nil
+}
+
+// embeddingToVector formats the embedding as a pgvector literal of knowledgeBaseEmbeddingDimensions dimensions, the shorter
+// embeddings are padded with zeros which leaves their cosine distance to the other embeddings of their model unchanged
+func embeddingToVector(embedding []float32) (string, error) {
+	if len(embedding) == 0 || len(embedding) > knowledgeBaseEmbeddingDimensions {
+		return "", fmt.Errorf("embeddings of %d dimensions are not supported, at most %d are", len(embedding), knowledgeBaseEmbeddingDimensions)
+	}
+
+	var vector strings.Builder
+	vector.WriteString("[")
+	for index := 0; index < knowledgeBaseEmbeddingDimensions; index++ {
+		if index > 0 {
+			vector.WriteString(",")
+		}
+		if index < len(embedding) {
+			vector.WriteString(strconv.FormatFloat(float64(embedding[index]), 'g', -1, 32))
+		} else {
+			vector.WriteString("0")
+		}
+	}
+	vector.WriteString("]")
+
+	return vector.String(), nil
+}
+
+// CosineSimilarity returns the cosine of the angle between two vectors, 0 when their sizes differ or one of them is empty
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// KnowledgeBaseCitations returns the citations of the matches, numbered in the order they are given to the model
+func KnowledgeBaseCitations(matches []KnowledgeBaseMatch) []api_types.KnowledgeBaseCitationSchema {
+	citations := make([]api_types.KnowledgeBaseCitationSchema, 0, len(matches))
+	for index, match := range matches {
+		excerpt := match.Chunk.Content
+		if runes := []rune(excerpt); len(runes) > knowledgeBaseExcerptLength {
+			excerpt = string(runes[:knowledgeBaseExcerptLength]) + "..."
+		}
+
+		citations = append(citations, api_types.KnowledgeBaseCitationSchema{
+			Index:      index + 1,
+			DocumentId: match.Document.UniqueId.String(),
+			Title:      match.Document.Title,
+			SourceType: api_types.KnowledgeBaseSourceTypeEnum(match.Document.SourceType.String()),
+			SourceUrl:  match.Document.SourceUrl,
+			ChunkId:    match.Chunk.UniqueId.String(),
+			Excerpt:    excerpt,
+			Score:      match.Score,
+		})
+	}
+	return citations
+}
+
+// knowledgeBasePrompt renders the matches for the model, numbered so that the answer can cite them
+func knowledgeBasePrompt(matches []KnowledgeBaseMatch, instructions string) string {
+	var prompt strings.Builder
+	prompt.WriteString(instructions)
+	for index, match := range matches {
+		prompt.WriteString(fmt.Sprintf("\n\n[%d] %s\n%s", index+1, match.Document.Title, match.Chunk.Content))
+	}
+	return prompt.String()
+}
+
+// relevantKnowledge searches the knowledge base for the query, a failing search only costs the answer its grounding
+func (ai *AiService) relevantKnowledge(ctx context.Context, organizationId uuid.UUID, query string) []KnowledgeBaseMatch {
+	matches, err := ai.SearchKnowledgeBase(ctx, organizationId, query, KnowledgeBaseDefaultMatches)
+	if err != nil {
+		ai.Logger.Error("error searching the knowledge base", "organization_id", organizationId.String(), "error", err.Error())
+		return []KnowledgeBaseMatch{}
+	}
+	return matches
+}
@@ -0,0 +1,104 @@
+package ai_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/wapikit/wapikit/.db-generated/model"
+)
+
+const SYSTEM_PROMPT_MESSAGE_CLASSIFICATION = `You are an AI assistant for a WhatsApp Business Management tool used for sending marketing campaign and customer engagement. You will be provided with a message a contact sent to the business. Classify the message and respond back with a json string with the following properties:
+- "sentiment": the sentiment of the contact, one of "Positive", "Neutral" or "Negative".
+- "intent": what the contact wants, one of "Purchase" (wants to buy or asks about prices and availability), "Support" (needs help with a product or an order), "Complaint" (is unhappy about something that went wrong), "Inquiry" (asks for information), "Feedback" (shares an opinion), "Greeting" (only greets or thanks) or "Other".
+- "language": the ISO 639-1 code of the language of the message, e.g. "en".`
+
+// classificationMaxMessageLength bounds the text sent for classification, the beginning of a message tells enough about it
+const classificationMaxMessageLength = 2000
+
+// ErrNothingToClassify is returned for the messages without a text, e.g. the media without a caption
+var ErrNothingToClassify = errors.New("the message has no text to classify")
+
+var (
+	messageSentiments = []model.MessageSentimentEnum{
+		model.MessageSentimentEnum_Positive,
+		model.MessageSentimentEnum_Neutral,
+		model.MessageSentimentEnum_Negative,
+	}
+	messageIntents = []model.MessageIntentEnum{
+		model.MessageIntentEnum_Purchase,
+		model.MessageIntentEnum_Support,
+		model.MessageIntentEnum_Complaint,
+		model.MessageIntentEnum_Inquiry,
+		model.MessageIntentEnum_Feedback,
+		model.MessageIntentEnum_Greeting,
+		model.MessageIntentEnum_Other,
+	}
+)
+
+// MessageClassification is the sentiment, the intent and the language of a message of a contact
+type MessageClassification struct {
+	Sentiment model.MessageSentimentEnum
+	Intent    model.MessageIntentEnum
+	// Language is the lower cased ISO 639-1 code, empty when the model could not tell
+	Language string
+}
+
+// ClassifyMessage asks the model for the sentiment, the intent and the language of the message, the values the model
+// answers with outside of the known ones fall back on Neutral and Other
+func (ai *AiService) ClassifyMessage(ctx context.Context, message model.Message) (*MessageClassification, error) {
+	text := strings.TrimSpace(messageText(message))
+	if text == "" {
+		return nil, ErrNothingToClassify
+	}
+	if runes := []rune(text); len(runes) > classificationMaxMessageLength {
+		text = string(runes[:classificationMaxMessageLength])
+	}
+
+	inputPrompt := []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, SYSTEM_PROMPT_MESSAGE_CLASSIFICATION),
+		llms.TextParts(llms.ChatMessageTypeHuman, text),
+	}
+
+	aiResponse, err := ai.QueryAiModel(ctx, AiTaskClassification, inputPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	requestJson, _ := json.Marshal(inputPrompt)
+	ai.LogApiCall(message.OrganizationId, ai.Db, AiTaskClassification, string(requestJson), aiResponse.Content, model.AiModelEnum(aiResponse.ModelUsed), aiResponse.InputTokenUsed, aiResponse.OutputTokenUsed)
+
+	var classificationResponse struct {
+		Sentiment string `json:"sentiment"`
+		Intent    string `json:"intent"`
+		Language  string `json:"language"`
+	}
+	if err := json.Unmarshal([]byte(stripCodeFence(aiResponse.Content)), &classificationResponse); err != nil {
+		return nil, fmt.Errorf("error parsing the message classification of the model: %w", err)
+	}
+
+	classification := MessageClassification{
+		Sentiment: model.MessageSentimentEnum_Neutral,
+		Intent:    model.MessageIntentEnum_Other,
+	}
+	for _, sentiment := range messageSentiments {
+		if strings.EqualFold(strings.TrimSpace(classificationResponse.Sentiment), sentiment.String()) {
+			classification.Sentiment = sentiment
+		}
+	}
+	for _, intent := range messageIntents {
+		if strings.EqualFold(strings.TrimSpace(classificationResponse.Intent), intent.String()) {
+			classification.Intent = intent
+		}
+	}
+
+	language := strings.ToLower(strings.TrimSpace(classificationResponse.Language))
+	if len(language) == 2 && strings.Trim(language, "abcdefghijklmnopqrstuvwxyz") == "" {
+		classification.Language = language
+	}
+
+	return &classification, nil
+}
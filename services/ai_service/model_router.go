@@ -2,43 +2,215 @@ package ai_service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+	"unicode/utf8"
 
 	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/llms/anthropic"
 	"github.com/tmc/langchaingo/llms/googleai"
 	"github.com/tmc/langchaingo/llms/mistral"
 	"github.com/tmc/langchaingo/llms/openai"
 	"github.com/wapikit/wapikit/api/api_types"
 )
 
-// Enhanced model mapping with performance metrics
+// AiProvider is the company serving a model, the API key of a provider works for all of its models
+type AiProvider string
+
+const (
+	AiProviderOpenAi    AiProvider = "openai"
+	AiProviderAnthropic AiProvider = "anthropic"
+	AiProviderGoogleAi  AiProvider = "googleai"
+	AiProviderMistral   AiProvider = "mistral"
+	// AiProviderStub answers offline with canned responses, for the tests and the local development without an API key
+	AiProviderStub AiProvider = "stub"
+)
+
+// AiTask is what a model is queried for, every task has its own chain of models to fall back on
+type AiTask string
+
+const (
+	AiTaskChat                AiTask = "chat"
+	AiTaskIntentDetection     AiTask = "intent_detection"
+	AiTaskSummary             AiTask = "summary"
+	AiTaskResponseSuggestions AiTask = "response_suggestions"
+	AiTaskAutoReply           AiTask = "auto_reply"
+	AiTaskSegmentation        AiTask = "segmentation"
+	AiTaskClassification      AiTask = "classification"
+	AiTaskTemplateDrafting    AiTask = "template_drafting"
+)
+
+// Enhanced model mapping with performance metrics, the prices are the list prices of the providers in USD per million tokens
 var AiModelEnumToLlmModelConfig = map[api_types.AiModelEnum]ModelConfig{
-	api_types.Gpt4oMini:   {Name: "gpt-4o-mini", RequiresAPIKey: true},
-	api_types.Gpt4o:       {Name: "gpt-4o", RequiresAPIKey: true},
-	api_types.Mistral:     {Name: "mistral", RequiresAPIKey: true},
-	api_types.Gemini15Pro: {Name: "gemini-15-pro", RequiresAPIKey: true},
-	api_types.Claude35:    {Name: "claude-3-5-haiku-latest", RequiresAPIKey: true},
+	api_types.Gpt4oMini:   {Name: "gpt-4o-mini", Provider: AiProviderOpenAi, RequiresAPIKey: true, InputCostPerMillionTokens: 0.15, OutputCostPerMillionTokens: 0.6},
+	api_types.Gpt4o:       {Name: "gpt-4o", Provider: AiProviderOpenAi, RequiresAPIKey: true, InputCostPerMillionTokens: 2.5, OutputCostPerMillionTokens: 10},
+	api_types.Gpt4:        {Name: "gpt-4", Provider: AiProviderOpenAi, RequiresAPIKey: true, InputCostPerMillionTokens: 30, OutputCostPerMillionTokens: 60},
+	api_types.GptO1:       {Name: "o1", Provider: AiProviderOpenAi, RequiresAPIKey: true, InputCostPerMillionTokens: 15, OutputCostPerMillionTokens: 60},
+	api_types.GptO3Mini:   {Name: "o3-mini", Provider: AiProviderOpenAi, RequiresAPIKey: true, InputCostPerMillionTokens: 1.1, OutputCostPerMillionTokens: 4.4},
+	api_types.Mistral:     {Name: "mistral-large-latest", Provider: AiProviderMistral, RequiresAPIKey: true, InputCostPerMillionTokens: 2, OutputCostPerMillionTokens: 6},
+	api_types.Gemini15Pro: {Name: "gemini-1.5-pro", Provider: AiProviderGoogleAi, RequiresAPIKey: true, InputCostPerMillionTokens: 1.25, OutputCostPerMillionTokens: 5},
+	api_types.Claude35:    {Name: "claude-3-5-haiku-latest", Provider: AiProviderAnthropic, RequiresAPIKey: true, InputCostPerMillionTokens: 0.8, OutputCostPerMillionTokens: 4},
 }
 
 // ModelConfig holds configuration details for an AI model.
 type ModelConfig struct {
-	Name           string
-	RequiresAPIKey bool
+	Name                       string
+	Provider                   AiProvider
+	RequiresAPIKey             bool
+	InputCostPerMillionTokens  float64
+	OutputCostPerMillionTokens float64
+}
+
+// ModelRouterConfig controls how the router retries a model and falls back on the next ones
+type ModelRouterConfig struct {
+	// Timeout bounds a single call to a model, a call timing out is retried
+	Timeout time.Duration
+	// MaxRetries is how many times a model is called again on a provider error before the next model of the chain is tried
+	MaxRetries   int
+	RetryBackoff time.Duration
+	// ProviderApiKeys are the API keys of the platform, used for the organizations without a key of their own
+	// and to fall back on the models of the other providers
+	ProviderApiKeys map[AiProvider]string
+	// FallbackChains are the models tried in order after the model of the organization, the models without an API key are skipped
+	FallbackChains  map[AiTask][]api_types.AiModelEnum
+	UseStubProvider bool
+}
+
+var DefaultModelRouterConfig = ModelRouterConfig{
+	Timeout:         60 * time.Second,
+	MaxRetries:      2,
+	RetryBackoff:    500 * time.Millisecond,
+	ProviderApiKeys: map[AiProvider]string{},
+	FallbackChains: map[AiTask][]api_types.AiModelEnum{
+		// * the intent only routes the query, a small and fast model is enough
+		AiTaskIntentDetection:     {api_types.Gpt4oMini, api_types.Claude35, api_types.Gemini15Pro, api_types.Mistral},
+		AiTaskSummary:             {api_types.Gpt4o, api_types.Claude35, api_types.Gemini15Pro, api_types.Mistral},
+		AiTaskResponseSuggestions: {api_types.Gpt4oMini, api_types.Claude35, api_types.Gemini15Pro, api_types.Mistral},
+		AiTaskAutoReply:           {api_types.Gpt4o, api_types.Claude35, api_types.Gemini15Pro},
+		AiTaskSegmentation:        {api_types.Gpt4oMini, api_types.Claude35, api_types.Gemini15Pro, api_types.Mistral},
+		// * every inbound message is classified, the cheapest models come first
+		AiTaskClassification: {api_types.Gpt4oMini, api_types.Claude35, api_types.Gemini15Pro, api_types.Mistral},
+		// * the copy is written once and sent to every contact of a campaign, it deserves a capable model
+		AiTaskTemplateDrafting: {api_types.Gpt4o, api_types.Claude35, api_types.Gemini15Pro, api_types.Mistral},
+		AiTaskChat:             {api_types.Gpt4o, api_types.Claude35, api_types.Gemini15Pro, api_types.Mistral},
+	},
+}
+
+// withDefaults replaces the zero values of the configuration with their default
+func (config ModelRouterConfig) withDefaults() ModelRouterConfig {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultModelRouterConfig.Timeout
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = DefaultModelRouterConfig.MaxRetries
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = DefaultModelRouterConfig.RetryBackoff
+	}
+	if config.ProviderApiKeys == nil {
+		config.ProviderApiKeys = map[AiProvider]string{}
+	}
+	if config.FallbackChains == nil {
+		config.FallbackChains = DefaultModelRouterConfig.FallbackChains
+	}
+	return config
 }
 
 // ModelRouter manages the selection of AI models.
 type ModelRouter struct {
 	models map[api_types.AiModelEnum]ModelConfig
+	config ModelRouterConfig
 }
 
 // NewModelRouter initializes and returns a ModelRouter with predefined models.
-func NewModelRouter() *ModelRouter {
+func NewModelRouter(config ModelRouterConfig) *ModelRouter {
 	return &ModelRouter{
 		models: AiModelEnumToLlmModelConfig,
+		config: config.withDefaults(),
+	}
+}
+
+// ErrNoModelAvailable is returned when none of the models of a task has an API key
+var ErrNoModelAvailable = errors.New("no AI model with an API key is available for the task")
+
+// ModelCandidate is a model the router may query along with the API key to query it with
+type ModelCandidate struct {
+	Model  api_types.AiModelEnum
+	ApiKey string
+}
+
+// ProviderOf returns the provider serving the model
+func (mr *ModelRouter) ProviderOf(model api_types.AiModelEnum) AiProvider {
+	if mr.config.UseStubProvider {
+		return AiProviderStub
+	}
+	return mr.models[model].Provider
+}
+
+// apiKeyFor returns the key to query the model with, the key of the organization is only sent to the provider it is for
+func (mr *ModelRouter) apiKeyFor(model api_types.AiModelEnum, preferredModel api_types.AiModelEnum, apiKey string) string {
+	provider := mr.ProviderOf(model)
+	if apiKey != "" && provider == mr.ProviderOf(preferredModel) {
+		return apiKey
 	}
+	return mr.config.ProviderApiKeys[provider]
 }
 
-func (mr *ModelRouter) SelectModel(ctx context.Context, model api_types.AiModelEnum, apiKey string) (llms.Model, error) {
+// CandidatesForTask returns the models to query in order for the task, the preferred model of the organization first
+// and then the fallback chain of the task, the models without an API key are left out. An organization with its own key
+// only falls back to the models of the other providers, billed to the platform, when it opted in to the fallback
+func (mr *ModelRouter) CandidatesForTask(task AiTask, preferredModel api_types.AiModelEnum, apiKey string, allowCrossProviderFallback bool) []ModelCandidate {
+	if mr.config.UseStubProvider {
+		return []ModelCandidate{{Model: preferredModel}}
+	}
+
+	chain, exists := mr.config.FallbackChains[task]
+	if !exists {
+		chain = mr.config.FallbackChains[AiTaskChat]
+	}
+
+	candidates := []ModelCandidate{}
+	for _, model := range append([]api_types.AiModelEnum{preferredModel}, chain...) {
+		if _, exists := mr.models[model]; !exists {
+			continue
+		}
+
+		isAlreadyCandidate := false
+		for _, candidate := range candidates {
+			if candidate.Model == model {
+				isAlreadyCandidate = true
+				break
+			}
+		}
+		if isAlreadyCandidate {
+			continue
+		}
+
+		if apiKey != "" && !allowCrossProviderFallback && mr.ProviderOf(model) != mr.ProviderOf(preferredModel) {
+			continue
+		}
+
+		candidateApiKey := mr.apiKeyFor(model, preferredModel, apiKey)
+		if candidateApiKey == "" {
+			continue
+		}
+
+		candidates = append(candidates, ModelCandidate{Model: model, ApiKey: candidateApiKey})
+	}
+
+	return candidates
+}
+
+// SelectModel returns the client of the provider of the model, the stub model answering the task when the stub provider is used
+func (mr *ModelRouter) SelectModel(ctx context.Context, task AiTask, model api_types.AiModelEnum, apiKey string) (llms.Model, error) {
+	if mr.config.UseStubProvider {
+		return NewStubModel(task), nil
+	}
+
 	config, exists := mr.models[model]
 	if !exists {
 		return nil, errors.New("model configuration not found")
@@ -48,22 +220,230 @@ func (mr *ModelRouter) SelectModel(ctx context.Context, model api_types.AiModelE
 		return nil, errors.New("API key is required for the selected model")
 	}
 
-	switch model {
-	case api_types.Gemini15Pro:
-		return googleai.New(ctx, googleai.WithAPIKey(apiKey))
-	case api_types.Mistral:
-		return mistral.New(mistral.WithAPIKey(apiKey))
-	default:
+	switch config.Provider {
+	case AiProviderOpenAi:
 		return openai.New(openai.WithModel(config.Name), openai.WithToken(apiKey))
+	case AiProviderAnthropic:
+		return anthropic.New(anthropic.WithModel(config.Name), anthropic.WithToken(apiKey))
+	case AiProviderGoogleAi:
+		return googleai.New(ctx, googleai.WithAPIKey(apiKey), googleai.WithDefaultModel(config.Name))
+	case AiProviderMistral:
+		return mistral.New(mistral.WithAPIKey(apiKey), mistral.WithModel(config.Name))
+	default:
+		return nil, fmt.Errorf("unsupported AI provider %s", config.Provider)
 	}
 }
 
-// SelectModelsForTask returns a list of models to try for a given task.
-// For simplicity, we return all available models here.
-func (mr *ModelRouter) SelectModelsForTask(taskType string) []api_types.AiModelEnum {
-	var models []api_types.AiModelEnum
-	for model := range mr.models {
-		models = append(models, model)
+// EstimateCost returns the cost in USD of the tokens used with the model, the calls answered by the stub provider cost nothing
+func (mr *ModelRouter) EstimateCost(model api_types.AiModelEnum, inputTokens, outputTokens int) float64 {
+	if mr.config.UseStubProvider {
+		return 0
+	}
+
+	config := mr.models[model]
+	return (float64(inputTokens)*config.InputCostPerMillionTokens + float64(outputTokens)*config.OutputCostPerMillionTokens) / 1_000_000
+}
+
+// RoutedResponse is the response of the first model of the chain that answered
+type RoutedResponse struct {
+	Response  *llms.ContentResponse
+	ModelUsed api_types.AiModelEnum
+}
+
+// GenerateContent queries the models of the task in order until one answers, every model is retried with a backoff
+// on the errors worth a retry, e.g. a rate limit or a timeout, each call is bounded by the timeout of the router
+func (mr *ModelRouter) GenerateContent(ctx context.Context, task AiTask, preferredModel api_types.AiModelEnum, apiKey string, allowCrossProviderFallback bool, inputPrompt []llms.MessageContent, options ...llms.CallOption) (*RoutedResponse, error) {
+	candidates := mr.CandidatesForTask(task, preferredModel, apiKey, allowCrossProviderFallback)
+	if len(candidates) == 0 {
+		return nil, ErrNoModelAvailable
+	}
+
+	candidateErrors := []error{}
+	for _, candidate := range candidates {
+		llm, err := mr.SelectModel(ctx, task, candidate.Model, candidate.ApiKey)
+		if err != nil {
+			candidateErrors = append(candidateErrors, fmt.Errorf("%s: %w", candidate.Model, err))
+			continue
+		}
+
+		for attempt := 0; attempt <= mr.config.MaxRetries; attempt++ {
+			if attempt > 0 {
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(mr.config.RetryBackoff * time.Duration(1<<(attempt-1))):
+				}
+			}
+
+			attemptCtx, cancel := context.WithTimeout(ctx, mr.config.Timeout)
+			response, err := llm.GenerateContent(attemptCtx, inputPrompt, options...)
+			cancel()
+
+			if err == nil {
+				return &RoutedResponse{Response: response, ModelUsed: candidate.Model}, nil
+			}
+
+			// * the caller gave up, e.g. the client disconnected, none of the next models would be waited for either
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			candidateErrors = append(candidateErrors, fmt.Errorf("%s: %w", candidate.Model, err))
+			if !isRetryableProviderError(err) {
+				break
+			}
+		}
+	}
+
+	return nil, errors.Join(candidateErrors...)
+}
+
+var providerStatusCodePattern = regexp.MustCompile(`status code:? (\d{3})`)
+
+// isRetryableProviderError tells the transient errors, e.g. a rate limit or an outage of the provider, from the ones that would
+// fail again, e.g. an invalid API key or a prompt too long for the model
+func isRetryableProviderError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	if match := providerStatusCodePattern.FindStringSubmatch(err.Error()); match != nil {
+		statusCode, _ := strconv.Atoi(match[1])
+		return statusCode == 429 || statusCode >= 500
+	}
+
+	// * the network errors carry no status code and are worth a retry
+	return true
+}
+
+// TokenUsage returns the tokens of the prompt and of the completion a choice used, every provider reports them under its own keys,
+// the usage missing from the response counts as zero
+func TokenUsage(choice *llms.ContentChoice) (int, int) {
+	if choice == nil || choice.GenerationInfo == nil {
+		return 0, 0
+	}
+
+	info := choice.GenerationInfo
+	for _, keys := range [][2]string{
+		{"PromptTokens", "CompletionTokens"},
+		{"InputTokens", "OutputTokens"},
+		{"input_tokens", "output_tokens"},
+	} {
+		inputTokens, hasInputTokens := intValue(info[keys[0]])
+		outputTokens, hasOutputTokens := intValue(info[keys[1]])
+		if hasInputTokens || hasOutputTokens {
+			return inputTokens, outputTokens
+		}
+	}
+
+	// * mistral reports a usage struct, its json has the same shape as the usage of openai
+	if usage, exists := info["usage"]; exists && usage != nil {
+		usageJson, err := json.Marshal(usage)
+		if err == nil {
+			var usageInfo struct {
+				PromptTokens     int `json:"prompt_tokens"`
+				CompletionTokens int `json:"completion_tokens"`
+			}
+			if err := json.Unmarshal(usageJson, &usageInfo); err == nil {
+				return usageInfo.PromptTokens, usageInfo.CompletionTokens
+			}
+		}
+	}
+
+	return 0, 0
+}
+
+// EstimateTokens approximates the tokens of a text for the providers not reporting their usage, a token is about four characters
+func EstimateTokens(text string) int {
+	characterCount := utf8.RuneCountInString(text)
+	if characterCount == 0 {
+		return 0
+	}
+	return (characterCount + 3) / 4
+}
+
+// EstimatePromptTokens approximates the tokens of the text parts of a prompt
+func EstimatePromptTokens(messages []llms.MessageContent) int {
+	tokens := 0
+	for _, message := range messages {
+		for _, part := range message.Parts {
+			if textPart, isText := part.(llms.TextContent); isText {
+				tokens += EstimateTokens(textPart.Text)
+			}
+		}
+	}
+	return tokens
+}
+
+func intValue(value any) (int, bool) {
+	switch number := value.(type) {
+	case int:
+		return number, true
+	case int32:
+		return int(number), true
+	case int64:
+		return int(number), true
+	case float64:
+		return int(number), true
+	default:
+		return 0, false
+	}
+}
+
+// ErrEmbeddingsNotSupported is returned for the models whose provider has no embedding model,
+// the knowledge base is searched by keywords for the organizations using them
+var ErrEmbeddingsNotSupported = errors.New("the selected model does not support embeddings")
+
+// Embedder turns texts into vectors whose cosine similarity reflects how close their meaning is
+type Embedder interface {
+	CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// embeddingModelNames are the embedding models of the providers of the chat models, the vectors of two of them can not be compared
+var embeddingModelNames = map[AiProvider]string{
+	AiProviderOpenAi:   "text-embedding-3-small",
+	AiProviderMistral:  "mistral-embed",
+	AiProviderGoogleAi: "embedding-001",
+	AiProviderStub:     stubEmbeddingModel,
+}
+
+// SelectEmbedder returns the embedder of the provider of the model along with the name of its embedding model
+func (mr *ModelRouter) SelectEmbedder(ctx context.Context, model api_types.AiModelEnum, apiKey string) (Embedder, string, error) {
+	provider := mr.ProviderOf(model)
+	embeddingModel, exists := embeddingModelNames[provider]
+	if !exists {
+		return nil, "", ErrEmbeddingsNotSupported
+	}
+
+	if provider == AiProviderStub {
+		return NewStubEmbedder(), embeddingModel, nil
+	}
+
+	if apiKey == "" {
+		apiKey = mr.config.ProviderApiKeys[provider]
+	}
+	if apiKey == "" {
+		return nil, "", errors.New("API key is required for the selected model")
+	}
+
+	switch provider {
+	case AiProviderGoogleAi:
+		embedder, err := googleai.New(ctx, googleai.WithAPIKey(apiKey), googleai.WithDefaultEmbeddingModel(embeddingModel))
+		return embedder, embeddingModel, err
+	case AiProviderMistral:
+		embedder, err := mistral.New(mistral.WithAPIKey(apiKey))
+		return embedder, embeddingModel, err
+	default:
+		embedder, err := openai.New(openai.WithToken(apiKey), openai.WithEmbeddingModel(embeddingModel))
+		return embedder, embeddingModel, err
+	}
+}
+
+// SelectModelsForTask returns the models to try in order for a task, the models of the fallback chain of the task
+func (mr *ModelRouter) SelectModelsForTask(task AiTask) []api_types.AiModelEnum {
+	chain, exists := mr.config.FallbackChains[task]
+	if !exists {
+		chain = mr.config.FallbackChains[AiTaskChat]
 	}
-	return models
+	return append([]api_types.AiModelEnum{}, chain...)
 }
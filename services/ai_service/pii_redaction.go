@@ -0,0 +1,271 @@
+package ai_service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	"github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	pii_redactor "github.com/wapikit/wapikit/services/pii_redactor_service"
+)
+
+const (
+	aiPiiMaxCustomDetectors   = 20
+	aiPiiMaxPatternLength     = 500
+	aiPiiMaxPreviewTextLength = 10000
+)
+
+// GetAiPiiRedactionConfiguration returns the PII redaction of the organization, all the built in detectors if the organization never configured it
+func GetAiPiiRedactionConfiguration(ctx context.Context, db *sql.DB, organizationId uuid.UUID) (*model.AiPiiRedactionConfiguration, error) {
+	var configuration model.AiPiiRedactionConfiguration
+	err := SELECT(table.AiPiiRedactionConfiguration.AllColumns).
+		FROM(table.AiPiiRedactionConfiguration).
+		WHERE(table.AiPiiRedactionConfiguration.OrganizationId.EQ(UUID(organizationId))).
+		LIMIT(1).
+		QueryContext(ctx, db, &configuration)
+
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return &model.AiPiiRedactionConfiguration{
+				OrganizationId: organizationId,
+				IsEnabled:      true,
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &configuration, nil
+}
+
+func parseEnabledPiiDetectors(enabledDetectors *string) []pii_redactor.DetectorName {
+	if enabledDetectors == nil {
+		return nil
+	}
+
+	names := []pii_redactor.DetectorName{}
+	if err := json.Unmarshal([]byte(*enabledDetectors), &names); err != nil {
+		return nil
+	}
+	return names
+}
+
+func parseCustomPiiDetectors(customDetectors *string) []api_types.PiiCustomDetectorSchema {
+	detectors := []api_types.PiiCustomDetectorSchema{}
+	if customDetectors == nil {
+		return detectors
+	}
+
+	if err := json.Unmarshal([]byte(*customDetectors), &detectors); err != nil {
+		return []api_types.PiiCustomDetectorSchema{}
+	}
+	return detectors
+}
+
+func piiRedactorConfig(enabledDetectors []pii_redactor.DetectorName, customDetectors []api_types.PiiCustomDetectorSchema) pii_redactor.RedactorConfig {
+	config := pii_redactor.RedactorConfig{
+		EnabledDetectors: enabledDetectors,
+	}
+	for _, customDetector := range customDetectors {
+		config.CustomDetectors = append(config.CustomDetectors, pii_redactor.CustomDetectorConfig{
+			Name:    customDetector.Name,
+			Pattern: customDetector.Pattern,
+		})
+	}
+	return config
+}
+
+// NewPiiRedactorFromConfiguration returns the redactor of the configuration, a redactor without detectors when the redaction is disabled
+func NewPiiRedactorFromConfiguration(configuration model.AiPiiRedactionConfiguration) (*pii_redactor.PIIRedactor, error) {
+	if !configuration.IsEnabled {
+		return pii_redactor.NewConfiguredPIIRedactor(pii_redactor.RedactorConfig{
+			EnabledDetectors: []pii_redactor.DetectorName{},
+		})
+	}
+
+	return pii_redactor.NewConfiguredPIIRedactor(piiRedactorConfig(
+		parseEnabledPiiDetectors(configuration.EnabledDetectors),
+		parseCustomPiiDetectors(configuration.CustomDetectors),
+	))
+}
+
+// LoadPiiRedactor returns the redactor the prompts of the organization are redacted with
+func LoadPiiRedactor(ctx context.Context, db *sql.DB, organizationId uuid.UUID) (*pii_redactor.PIIRedactor, error) {
+	configuration, err := GetAiPiiRedactionConfiguration(ctx, db, organizationId)
+	if err != nil {
+		return nil, err
+	}
+	return NewPiiRedactorFromConfiguration(*configuration)
+}
+
+// validatePiiRedactionPayload checks the detectors of the payload and returns the custom ones trimmed, the redactor is built
+// to make sure every pattern compiles
+func validatePiiRedactionPayload(payload api_types.UpdateAiPiiRedactionConfigurationSchema) ([]pii_redactor.DetectorName, []api_types.PiiCustomDetectorSchema, error) {
+	enabledDetectors := []pii_redactor.DetectorName{}
+	isEnabled := map[pii_redactor.DetectorName]bool{}
+	for _, name := range payload.EnabledDetectors {
+		detectorName := pii_redactor.DetectorName(strings.ToUpper(strings.TrimSpace(name)))
+		if _, exists := pii_redactor.RegisteredDetector(detectorName); !exists {
+			return nil, nil, fmt.Errorf("unknown PII detector %q", name)
+		}
+		if !isEnabled[detectorName] {
+			isEnabled[detectorName] = true
+			enabledDetectors = append(enabledDetectors, detectorName)
+		}
+	}
+
+	customDetectors := []api_types.PiiCustomDetectorSchema{}
+	if payload.CustomDetectors != nil {
+		for _, customDetector := range *payload.CustomDetectors {
+			customDetector.Name = strings.ToUpper(strings.TrimSpace(customDetector.Name))
+			if len(customDetector.Pattern) > aiPiiMaxPatternLength {
+				return nil, nil, fmt.Errorf("the pattern of the custom detector %q must be at most %d characters", customDetector.Name, aiPiiMaxPatternLength)
+			}
+			customDetectors = append(customDetectors, customDetector)
+		}
+	}
+	if len(customDetectors) > aiPiiMaxCustomDetectors {
+		return nil, nil, fmt.Errorf("at most %d custom detectors are allowed", aiPiiMaxCustomDetectors)
+	}
+
+	if _, err := pii_redactor.NewConfiguredPIIRedactor(piiRedactorConfig(enabledDetectors, customDetectors)); err != nil {
+		return nil, nil, err
+	}
+
+	return enabledDetectors, customDetectors, nil
+}
+
+// UpdateAiPiiRedactionConfiguration creates or updates the PII redaction of the organization
+func UpdateAiPiiRedactionConfiguration(ctx context.Context, db *sql.DB, organizationId uuid.UUID, payload api_types.UpdateAiPiiRedactionConfigurationSchema) (*model.AiPiiRedactionConfiguration, error) {
+	enabledDetectors, customDetectors, err := validatePiiRedactionPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonEnabledDetectors, err := json.Marshal(enabledDetectors)
+	if err != nil {
+		return nil, err
+	}
+	stringEnabledDetectors := string(jsonEnabledDetectors)
+
+	jsonCustomDetectors, err := json.Marshal(customDetectors)
+	if err != nil {
+		return nil, err
+	}
+	stringCustomDetectors := string(jsonCustomDetectors)
+
+	configuration := model.AiPiiRedactionConfiguration{
+		OrganizationId:   organizationId,
+		IsEnabled:        payload.IsEnabled,
+		EnabledDetectors: &stringEnabledDetectors,
+		CustomDetectors:  &stringCustomDetectors,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	var updatedConfiguration model.AiPiiRedactionConfiguration
+	err = table.AiPiiRedactionConfiguration.
+		INSERT(table.AiPiiRedactionConfiguration.MutableColumns).
+		MODEL(configuration).
+		ON_CONFLICT(table.AiPiiRedactionConfiguration.OrganizationId).
+		DO_UPDATE(SET(
+			table.AiPiiRedactionConfiguration.IsEnabled.SET(table.AiPiiRedactionConfiguration.EXCLUDED.IsEnabled),
+			table.AiPiiRedactionConfiguration.EnabledDetectors.SET(table.AiPiiRedactionConfiguration.EXCLUDED.EnabledDetectors),
+			table.AiPiiRedactionConfiguration.CustomDetectors.SET(table.AiPiiRedactionConfiguration.EXCLUDED.CustomDetectors),
+			table.AiPiiRedactionConfiguration.UpdatedAt.SET(table.AiPiiRedactionConfiguration.EXCLUDED.UpdatedAt),
+		)).
+		RETURNING(table.AiPiiRedactionConfiguration.AllColumns).
+		QueryContext(ctx, db, &updatedConfiguration)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedConfiguration, nil
+}
+
+// PreviewAiPiiRedaction redacts the text the way a prompt of the organization is redacted, with the configuration of the payload
+// when given so that it can be tried before it is saved
+func PreviewAiPiiRedaction(ctx context.Context, db *sql.DB, organizationId uuid.UUID, payload api_types.PreviewAiPiiRedactionSchema) (string, error) {
+	if len(payload.Text) > aiPiiMaxPreviewTextLength {
+		return "", fmt.Errorf("the text must be at most %d characters", aiPiiMaxPreviewTextLength)
+	}
+
+	var redactor *pii_redactor.PIIRedactor
+	if payload.Configuration != nil {
+		enabledDetectors, customDetectors, err := validatePiiRedactionPayload(*payload.Configuration)
+		if err != nil {
+			return "", err
+		}
+		if !payload.Configuration.IsEnabled {
+			enabledDetectors, customDetectors = []pii_redactor.DetectorName{}, nil
+		}
+		redactor, err = pii_redactor.NewConfiguredPIIRedactor(piiRedactorConfig(enabledDetectors, customDetectors))
+		if err != nil {
+			return "", err
+		}
+	} else {
+		var err error
+		redactor, err = LoadPiiRedactor(ctx, db, organizationId)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return redactor.NewRedaction().Redact(payload.Text), nil
+}
+
+func ParseAiPiiRedactionConfigurationToApiSchema(configuration model.AiPiiRedactionConfiguration) api_types.AiPiiRedactionConfigurationSchema {
+	availableDetectors := []api_types.PiiDetectorSchema{}
+	allDetectorNames := []string{}
+	for _, detector := range pii_redactor.RegisteredDetectors() {
+		availableDetectors = append(availableDetectors, api_types.PiiDetectorSchema{
+			Name:        string(detector.Name),
+			Description: detector.Description,
+		})
+		allDetectorNames = append(allDetectorNames, string(detector.Name))
+	}
+
+	enabledDetectors := allDetectorNames
+	if enabledDetectorNames := parseEnabledPiiDetectors(configuration.EnabledDetectors); enabledDetectorNames != nil {
+		enabledDetectors = []string{}
+		for _, name := range enabledDetectorNames {
+			enabledDetectors = append(enabledDetectors, string(name))
+		}
+	}
+
+	return api_types.AiPiiRedactionConfigurationSchema{
+		IsEnabled:          configuration.IsEnabled,
+		EnabledDetectors:   enabledDetectors,
+		CustomDetectors:    parseCustomPiiDetectors(configuration.CustomDetectors),
+		AvailableDetectors: availableDetectors,
+	}
+}
+
+// redactPrompt replaces the PII of the text parts of the prompt with the placeholders of the redaction, the prompt is left as is
+func redactPrompt(redaction *pii_redactor.Redaction, prompt []llms.MessageContent) []llms.MessageContent {
+	redactedPrompt := make([]llms.MessageContent, 0, len(prompt))
+	for _, message := range prompt {
+		redactedMessage := llms.MessageContent{
+			Role:  message.Role,
+			Parts: make([]llms.ContentPart, 0, len(message.Parts)),
+		}
+		for _, part := range message.Parts {
+			if textPart, isText := part.(llms.TextContent); isText {
+				part = llms.TextContent{Text: redaction.Redact(textPart.Text)}
+			}
+			redactedMessage.Parts = append(redactedMessage.Parts, part)
+		}
+		redactedPrompt = append(redactedPrompt, redactedMessage)
+	}
+	return redactedPrompt
+}
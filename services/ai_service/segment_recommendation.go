@@ -0,0 +1,291 @@
+package ai_service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	"github.com/wapikit/wapikit/.db-generated/table"
+)
+
+const (
+	// MaxSegmentRecommendations is the number of tags recommended at most for a conversation or a contact
+	MaxSegmentRecommendations = 5
+	// * the latest messages tell the most about where the contact stands now
+	segmentRecommendationMaxMessages = 100
+	segmentLabelMaxLength            = 50
+)
+
+// SegmentRecommendationInput is what a recommendation is based on, the attributes of the contact and the messages of
+// a conversation of the contact, or of all of them
+type SegmentRecommendationInput struct {
+	OrganizationId uuid.UUID
+	Contact        model.Contact
+	Messages       []model.Message
+	// AllowedLabels restricts the recommendations to the given labels, the model proposes its own labels when empty
+	AllowedLabels []string
+}
+
+// FetchMessagesForSegmentRecommendation returns the latest messages of the contact, oldest first,
+// only the ones of the conversation when a conversation is given
+func (ai *AiService) FetchMessagesForSegmentRecommendation(ctx context.Context, contactId uuid.UUID, conversationId *uuid.UUID) ([]model.Message, error) {
+	whereCondition := table.Message.ContactId.EQ(UUID(contactId))
+	if conversationId != nil {
+		whereCondition = whereCondition.AND(table.Message.ConversationId.EQ(UUID(*conversationId)))
+	}
+
+	var messages []model.Message
+	err := SELECT(table.Message.AllColumns).
+		FROM(table.Message).
+		WHERE(whereCondition).
+		ORDER_BY(table.Message.CreatedAt.DESC()).
+		LIMIT(segmentRecommendationMaxMessages).
+		QueryContext(ctx, ai.Db, &messages)
+
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(messages)
+	return messages, nil
+}
+
+// RecommendSegments asks the model for the tags to segment the contact with, the personal data in the attributes and
+// the messages is redacted before it leaves the server, the labels returned are normalized and at most MaxSegmentRecommendations
+func (ai *AiService) RecommendSegments(ctx context.Context, input SegmentRecommendationInput) ([]string, error) {
+	systemPromptContent := SYSTEM_PROMPT_SEGMENT_SUGGESTIONS
+	if len(input.AllowedLabels) > 0 {
+		systemPromptContent = strings.Join([]string{systemPromptContent, "Only use the following tags, respond with an empty tags array when none of them fits:", strings.Join(input.AllowedLabels, ", ")}, "\n")
+	}
+
+	lines := []string{}
+	if input.Contact.Attributes != nil {
+		if attributes := strings.TrimSpace(*input.Contact.Attributes); attributes != "" && attributes != "{}" && attributes != "null" {
+			lines = append(lines, "Contact attributes: "+attributes)
+		}
+	}
+
+	for _, message := range input.Messages {
+		text := messageText(message)
+		if text == "" {
+			text = "[" + message.MessageType.String() + " message]"
+		}
+
+		author := "Contact"
+		if message.Direction == model.MessageDirectionEnum_OutBound {
+			author = "Organization member"
+		}
+
+		lines = append(lines, author+": "+text)
+	}
+
+	if len(lines) == 0 {
+		return []string{}, nil
+	}
+
+	inputPrompt := []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, systemPromptContent),
+		llms.TextParts(llms.ChatMessageTypeHuman, strings.Join(lines, "\n")),
+	}
+
+	aiResponse, err := ai.QueryAiModel(ctx, AiTaskSegmentation, inputPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	requestJson, _ := json.Marshal(inputPrompt)
+	ai.LogApiCall(input.OrganizationId, ai.Db, AiTaskSegmentation, string(requestJson), aiResponse.Content, model.AiModelEnum(aiResponse.ModelUsed), aiResponse.InputTokenUsed, aiResponse.OutputTokenUsed)
+
+	var segmentSuggestions struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.Unmarshal([]byte(stripCodeFence(aiResponse.Content)), &segmentSuggestions); err != nil {
+		return nil, fmt.Errorf("error parsing the segment recommendations of the model: %w", err)
+	}
+
+	return NormalizeSegmentLabels(segmentSuggestions.Tags, input.AllowedLabels), nil
+}
+
+// NormalizeSegmentLabels trims the labels and drops the empty and the duplicated ones, ignoring the case,
+// when allowed labels are given the labels take their spelling and the others are dropped
+func NormalizeSegmentLabels(labels []string, allowedLabels []string) []string {
+	allowedLabelsByKey := map[string]string{}
+	for _, allowedLabel := range allowedLabels {
+		allowedLabelsByKey[strings.ToLower(strings.TrimSpace(allowedLabel))] = allowedLabel
+	}
+
+	normalizedLabels := []string{}
+	seenLabels := map[string]bool{}
+	for _, label := range labels {
+		label = strings.Join(strings.Fields(label), " ")
+		if runes := []rune(label); len(runes) > segmentLabelMaxLength {
+			label = strings.TrimSpace(string(runes[:segmentLabelMaxLength]))
+		}
+		if label == "" {
+			continue
+		}
+
+		key := strings.ToLower(label)
+		if len(allowedLabels) > 0 {
+			allowedLabel, isAllowed := allowedLabelsByKey[key]
+			if !isAllowed {
+				continue
+			}
+			label = allowedLabel
+		}
+
+		if seenLabels[key] {
+			continue
+		}
+		seenLabels[key] = true
+		normalizedLabels = append(normalizedLabels, label)
+
+		if len(normalizedLabels) == MaxSegmentRecommendations {
+			break
+		}
+	}
+
+	return normalizedLabels
+}
+
+// FindOrganizationTagsByLabels returns the tags of the organization with the labels, keyed by their lower cased label
+func FindOrganizationTagsByLabels(ctx context.Context, db *sql.DB, organizationId uuid.UUID, labels []string) (map[string]model.Tag, error) {
+	tagsByLabel := map[string]model.Tag{}
+	if len(labels) == 0 {
+		return tagsByLabel, nil
+	}
+
+	lowerCasedLabels := make([]Expression, 0, len(labels))
+	for _, label := range labels {
+		lowerCasedLabels = append(lowerCasedLabels, String(strings.ToLower(label)))
+	}
+
+	var tags []model.Tag
+	err := SELECT(table.Tag.AllColumns).
+		FROM(table.Tag).
+		WHERE(
+			table.Tag.OrganizationId.EQ(UUID(organizationId)).
+				AND(LOWER(table.Tag.Label).IN(lowerCasedLabels...)),
+		).
+		ORDER_BY(table.Tag.CreatedAt.ASC()).
+		QueryContext(ctx, db, &tags)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range tags {
+		key := strings.ToLower(tag.Label)
+		// * the oldest tag wins when the organization has the label in different cases
+		if _, exists := tagsByLabel[key]; !exists {
+			tagsByLabel[key] = tag
+		}
+	}
+
+	return tagsByLabel, nil
+}
+
+// EnsureOrganizationTags returns the tags of the organization with the normalized labels in the same order, the missing ones are created,
+// the labels are matched ignoring the case so that "vip" reuses the existing "VIP" tag
+func EnsureOrganizationTags(ctx context.Context, db *sql.DB, organizationId uuid.UUID, labels []string) ([]model.Tag, error) {
+	tagsByLabel, err := FindOrganizationTagsByLabels(ctx, db, organizationId, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	insertQuery := table.Tag.INSERT(
+		table.Tag.Label,
+		table.Tag.OrganizationId,
+		table.Tag.CreatedAt,
+		table.Tag.UpdatedAt,
+	)
+	missingLabelCount := 0
+	for _, label := range labels {
+		if _, exists := tagsByLabel[strings.ToLower(label)]; !exists {
+			insertQuery = insertQuery.VALUES(label, organizationId, time.Now(), time.Now())
+			missingLabelCount++
+		}
+	}
+
+	if missingLabelCount > 0 {
+		// * a tag created in the meantime by another request is picked up by the select below
+		_, err = insertQuery.
+			ON_CONFLICT(table.Tag.Label, table.Tag.OrganizationId).
+			DO_NOTHING().
+			ExecContext(ctx, db)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tagsByLabel, err = FindOrganizationTagsByLabels(ctx, db, organizationId, labels)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tags := []model.Tag{}
+	for _, label := range labels {
+		if tag, exists := tagsByLabel[strings.ToLower(label)]; exists {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, nil
+}
+
+// TagContact adds the tags to the contact, the tags the contact already has are left as they are
+func TagContact(ctx context.Context, db *sql.DB, contactId uuid.UUID, tags []model.Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	insertQuery := table.ContactTag.INSERT(
+		table.ContactTag.ContactId,
+		table.ContactTag.TagId,
+		table.ContactTag.CreatedAt,
+		table.ContactTag.UpdatedAt,
+	)
+	for _, tag := range tags {
+		insertQuery = insertQuery.VALUES(contactId, tag.UniqueId, time.Now(), time.Now())
+	}
+
+	_, err := insertQuery.
+		ON_CONFLICT(table.ContactTag.ContactId, table.ContactTag.TagId).
+		DO_NOTHING().
+		ExecContext(ctx, db)
+
+	return err
+}
+
+// TagConversation adds the tags to the conversation, the tags the conversation already has are left as they are
+func TagConversation(ctx context.Context, db *sql.DB, conversationId uuid.UUID, tags []model.Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	insertQuery := table.ConversationTag.INSERT(
+		table.ConversationTag.ConversationId,
+		table.ConversationTag.TagId,
+		table.ConversationTag.CreatedAt,
+		table.ConversationTag.UpdatedAt,
+	)
+	for _, tag := range tags {
+		insertQuery = insertQuery.VALUES(conversationId, tag.UniqueId, time.Now(), time.Now())
+	}
+
+	_, err := insertQuery.
+		ON_CONFLICT(table.ConversationTag.ConversationId, table.ConversationTag.TagId).
+		DO_NOTHING().
+		ExecContext(ctx, db)
+
+	return err
+}
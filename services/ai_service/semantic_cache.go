@@ -0,0 +1,139 @@
+package ai_service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	"github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	cache_service "github.com/wapikit/wapikit/services/redis_service"
+)
+
+// SemanticCacheScopeAiChat is the scope of the questions asked in the AI chat box
+const SemanticCacheScopeAiChat = "ai_chat"
+
+// withDefaults replaces the zero values of the configuration with their default
+func (config SemanticCacheConfig) withDefaults() SemanticCacheConfig {
+	if config.SimilarityThreshold <= 0 || config.SimilarityThreshold > 1 {
+		config.SimilarityThreshold = DefaultSemanticCacheConfig.SimilarityThreshold
+	}
+	if config.TTL <= 0 {
+		config.TTL = DefaultSemanticCacheConfig.TTL
+	}
+	if config.MaxEntriesPerOrganization <= 0 {
+		config.MaxEntriesPerOrganization = DefaultSemanticCacheConfig.MaxEntriesPerOrganization
+	}
+	return config
+}
+
+// SemanticCacheLookup is the result of looking a query up in the semantic cache, Entry is nil on a miss,
+// the embedding of the query is kept to store the response of a miss without embedding the query again
+type SemanticCacheLookup struct {
+	Entry      *SemanticCacheEntry
+	Similarity float64
+
+	query          string
+	embedding      []float32
+	embeddingModel string
+}
+
+// LookupSemanticCache finds the cached response of the organization to a query with the same meaning,
+// nil is returned when the cache is disabled or the model of the organization has no embeddings
+func (ai *AiService) LookupSemanticCache(ctx context.Context, organizationId uuid.UUID, scope, query string) *SemanticCacheLookup {
+	if !ai.VectorCache.config.Enabled {
+		return nil
+	}
+
+	// * the query leaves the server to be embedded, the personal data in it is redacted first
+	query = strings.TrimSpace(ai.SanitizeInput(query))
+	if query == "" {
+		return nil
+	}
+
+	embedder, embeddingModel, err := ai.ModelRouter.SelectEmbedder(ctx, ai.DefaultAiModel, ai.ApiKey)
+	if err != nil {
+		return nil
+	}
+
+	embeddings, err := embedder.CreateEmbedding(ctx, []string{query})
+	if err != nil || len(embeddings) != 1 {
+		if err != nil {
+			ai.Logger.Error("error embedding the query for the semantic cache", "organization_id", organizationId.String(), "error", err.Error())
+		}
+		return nil
+	}
+
+	lookup := &SemanticCacheLookup{
+		query:          query,
+		embedding:      embeddings[0],
+		embeddingModel: embeddingModel,
+	}
+
+	lookup.Entry, lookup.Similarity, err = ai.VectorCache.FindNearest(ctx, organizationId, scope, embeddingModel, lookup.embedding)
+	if err != nil {
+		ai.Logger.Error("error looking up the semantic cache", "organization_id", organizationId.String(), "error", err.Error())
+		return lookup
+	}
+
+	ai.Logger.Info("semantic cache lookup", "organization_id", organizationId.String(), "scope", scope, "hit", lookup.Entry != nil, "similarity", lookup.Similarity)
+	return lookup
+}
+
+// StoreInSemanticCache caches the response the model gave to the query of a lookup that missed
+func (ai *AiService) StoreInSemanticCache(ctx context.Context, organizationId uuid.UUID, scope string, lookup *SemanticCacheLookup, response string, citations []api_types.KnowledgeBaseCitationSchema) {
+	if lookup == nil || lookup.Entry != nil || strings.TrimSpace(response) == "" {
+		return
+	}
+
+	err := ai.VectorCache.Store(ctx, organizationId, scope, lookup.embeddingModel, SemanticCacheEntry{
+		Query:     lookup.query,
+		Embedding: lookup.embedding,
+		Response:  response,
+		Citations: citations,
+	})
+
+	if err != nil {
+		ai.Logger.Error("error storing in the semantic cache", "organization_id", organizationId.String(), "error", err.Error())
+	}
+}
+
+// LogSemanticCacheHit logs a query answered from the cache with the other AI API calls, so that the hit rate of an
+// organization is the share of its calls with IsCacheHit
+func (ai *AiService) LogSemanticCacheHit(organizationId uuid.UUID, request string, lookup *SemanticCacheLookup) error {
+	similarity := lookup.Similarity
+
+	_, err := table.AiApiCallLogs.INSERT(
+		table.AiApiCallLogs.MutableColumns,
+	).MODEL(
+		model.AiApiCallLogs{
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+			OrganizationId:  organizationId,
+			Request:         request,
+			Response:        lookup.Entry.Response,
+			Model:           model.AiModelEnum(ai.DefaultAiModel),
+			InputTokenUsed:  0,
+			OutputTokenUsed: 0,
+			IsCacheHit:      true,
+			CacheSimilarity: &similarity,
+			// * only the questions of the AI chat box are cached
+			Feature:              string(AiTaskChat),
+			OrganizationMemberId: ai.OrganizationMemberId,
+		},
+	).Exec(ai.Db)
+
+	if err != nil {
+		ai.Logger.Error("error logging the semantic cache hit", "organization_id", organizationId.String(), "error", err.Error())
+	}
+
+	return err
+}
+
+// ClearSemanticCache drops the cached responses of the organization for the scope, the answers built on data that
+// changed must not be served anymore
+func ClearSemanticCache(ctx context.Context, redis *cache_service.RedisClient, config SemanticCacheConfig, organizationId uuid.UUID, scope string) error {
+	return NewRedisVectorCache(redis, config.withDefaults()).Clear(ctx, organizationId, scope)
+}
@@ -4,15 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"log/slog"
 	"strings"
 	"time"
 
+	. "github.com/go-jet/jet/v2/postgres"
 	"github.com/google/uuid"
 	"github.com/tmc/langchaingo/llms"
 
+	"github.com/wapikit/wapikit/services/notification_service"
 	pii_redactor "github.com/wapikit/wapikit/services/pii_redactor_service"
 	cache_service "github.com/wapikit/wapikit/services/redis_service"
 	"github.com/wapikit/wapikit/utils"
@@ -51,6 +53,8 @@ Always interpret queries from a marketing and customer engagement perspective, a
 
 	SYSTEM_PROMPT_RESPONSE_SUGGESTION_GENERATION = "You are an AI assistant for a WhatsApp Business Management tool used for sending marketing campaign and customer engagement. You will be provided with the chat messages between user and a internal organization member, and you responsibility is to generate a response to the chat. The response should be not be more than 500 words. It should clear and concise, with easy english and no jargons. The response should be in a way that it should be able to answer the query of the user and also provide a solution to the query from the perspective of the organization. You must response back with a json string with response property where response is an array of strings where each element of array is a response suggestion to the contact message."
 
+	SYSTEM_PROMPT_KNOWLEDGE_BASE = "Here are passages from the knowledge base of the organization that may help, answer from them when they are relevant and cite the passages you used with their number in square brackets, e.g. [1]. Do not cite a passage you did not use."
+
 	SYSTEM_PROMPT_SEGMENT_SUGGESTIONS = "You are an AI assistant for a WhatsApp Business Management tool used for sending marketing campaign and customer engagement. You will be provided with the conversation or a contact details from the application. Your responsibility is to generate a segment suggestion for the entity provided. You should response back with a json string with tags properties where tags are array of string. Keep them shorter in length, the tag strings will be used to create tags in the backend where your provided string will be label of the tag and will be used as segment identifiers. You should provide a maximum of 5 tags. You should not use any buzz words or jargons. Example are: ['VIP', 'High Potential', 'Low Potential', 'Engaged', 'Not Engaged', 'Potential Customer', 'Existing Customer', 'Needs Support', 'Urgent']"
 )
 
@@ -112,6 +116,15 @@ func (tr *TemporalRange) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// AiServiceConfig is the configuration the AI services are created with, it is read once at startup
+type AiServiceConfig struct {
+	ModelRouter   ModelRouterConfig
+	SemanticCache SemanticCacheConfig
+	// NotificationService sends the soft limit warnings of the AI budgets, no warning is sent without it
+	NotificationService *notification_service.NotificationService
+	IsProduction        bool
+}
+
 type AiService struct {
 	Logger         *slog.Logger
 	Redis          *cache_service.RedisClient
@@ -122,6 +135,11 @@ type AiService struct {
 	ContextBuilder *ContextBuilder
 	Redactor       *pii_redactor.PIIRedactor
 	VectorCache    *RedisVectorCache
+	// AllowCrossProviderFallback lets the calls of an organization with its own key fall back to the models of the other providers
+	AllowCrossProviderFallback bool
+	// OrganizationMemberId is the member the AI calls are made for, the calls are accounted to them in the usage report
+	OrganizationMemberId *uuid.UUID
+	Config               AiServiceConfig
 }
 
 // Enhanced initialization
@@ -131,6 +149,7 @@ func NewAiService(
 	db *sql.DB,
 	apiKey string,
 	defaultModel api_types.AiModelEnum,
+	config AiServiceConfig,
 ) *AiService {
 	return &AiService{
 		Logger:         logger,
@@ -138,11 +157,48 @@ func NewAiService(
 		Db:             db,
 		ApiKey:         apiKey,
 		DefaultAiModel: defaultModel,
-		ModelRouter:    NewModelRouter(),
-		VectorCache:    NewRedisVectorCache(redis),
+		ModelRouter:    NewModelRouter(config.ModelRouter),
+		VectorCache:    NewRedisVectorCache(redis, config.SemanticCache.withDefaults()),
 		Redactor:       pii_redactor.NewPIIRedactor(),
 		ContextBuilder: NewContextBuilder(db, logger),
+		Config:         config,
+	}
+}
+
+// ErrAiNotEnabled is returned when the organization did not enable AI or did not choose a model
+var ErrAiNotEnabled = errors.New("AI is not enabled for the organization")
+
+// NewOrganizationAiService creates the AI service with the model, the API key and the PII redaction of the organization,
+// ErrAiBudgetExceeded is returned once the organization used up its AI budget of the month
+func NewOrganizationAiService(ctx context.Context, logger *slog.Logger, redis *cache_service.RedisClient, db *sql.DB, organizationId uuid.UUID, config AiServiceConfig) (*AiService, error) {
+	var organization model.Organization
+	err := SELECT(table.Organization.AllColumns).
+		FROM(table.Organization).
+		WHERE(table.Organization.UniqueId.EQ(UUID(organizationId))).
+		LIMIT(1).
+		QueryContext(ctx, db, &organization)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !organization.IsAiEnabled || organization.AiModel == nil {
+		return nil, ErrAiNotEnabled
+	}
+
+	if err := CheckAiBudget(ctx, db, organizationId); err != nil {
+		return nil, err
+	}
+
+	redactor, err := LoadPiiRedactor(ctx, db, organizationId)
+	if err != nil {
+		return nil, err
 	}
+
+	aiService := NewAiService(logger, redis, db, organization.AiApiKey, api_types.AiModelEnum(*organization.AiModel), config)
+	aiService.Redactor = redactor
+	aiService.AllowCrossProviderFallback = organization.IsAiCrossProviderFallbackEnabled
+	return aiService, nil
 }
 
 func (ai *AiService) DetectIntent(query string, organizationId uuid.UUID) (*DetectIntentResponse, error) {
@@ -155,14 +211,14 @@ func (ai *AiService) DetectIntent(query string, organizationId uuid.UUID) (*Dete
 		userPrompt,
 	}
 
-	intentResponse, err := ai.QueryAiModel(context.Background(), ai.DefaultAiModel, inputPrompt)
-
-	fmt.Println("Intent response", intentResponse.Content)
+	intentResponse, err := ai.QueryAiModel(context.Background(), AiTaskIntentDetection, inputPrompt)
 
 	if err != nil {
 		return nil, err
 	}
 
+	fmt.Println("Intent response", intentResponse.Content)
+
 	var detectIntentResponse DetectIntentResponse
 	err = json.Unmarshal([]byte(intentResponse.Content), &detectIntentResponse)
 	if err != nil {
@@ -171,7 +227,7 @@ func (ai *AiService) DetectIntent(query string, organizationId uuid.UUID) (*Dete
 	}
 
 	// * log the API call
-	ai.LogApiCall(organizationId, ai.Db, query, intentResponse.Content, model.AiModelEnum(ai.DefaultAiModel), intentResponse.InputTokenUsed, intentResponse.OutputTokenUsed)
+	ai.LogApiCall(organizationId, ai.Db, AiTaskIntentDetection, query, intentResponse.Content, model.AiModelEnum(intentResponse.ModelUsed), intentResponse.InputTokenUsed, intentResponse.OutputTokenUsed)
 	return &detectIntentResponse, nil
 }
 
@@ -185,7 +241,9 @@ func (ai *AiService) buildIntentDetectionPrompt(query string) []llms.MessageCont
 	}
 }
 
-func (ai *AiService) GetResponseSuggestions(ctx context.Context, messages []model.Message) ([]string, error) {
+// GetResponseSuggestions suggests replies to the last messages of the contact, grounded on the knowledge base of the organization,
+// the knowledge base passages given to the model are returned as the citations of the suggestions
+func (ai *AiService) GetResponseSuggestions(ctx context.Context, organizationId uuid.UUID, messages []model.Message) ([]string, []api_types.KnowledgeBaseCitationSchema, error) {
 	var responseSuggestions struct {
 		Response []string `json:"response"`
 	}
@@ -196,6 +254,18 @@ func (ai *AiService) GetResponseSuggestions(ctx context.Context, messages []mode
 		systemPrompt,
 	}
 
+	// * the knowledge base is searched with the last message of the contact, it is what the suggestions answer
+	matches := []KnowledgeBaseMatch{}
+	for index := len(messages) - 1; index >= 0; index-- {
+		if messages[index].Direction == model.MessageDirectionEnum_InBound {
+			matches = ai.relevantKnowledge(ctx, organizationId, messageText(messages[index]))
+			break
+		}
+	}
+	if len(matches) > 0 {
+		inputPrompt = append(inputPrompt, llms.TextParts(llms.ChatMessageTypeSystem, knowledgeBasePrompt(matches, SYSTEM_PROMPT_KNOWLEDGE_BASE)))
+	}
+
 	// ! TODO: what if messages has attachments ?
 	for _, message := range messages {
 		if message.Direction == model.MessageDirectionEnum_InBound {
@@ -207,54 +277,53 @@ func (ai *AiService) GetResponseSuggestions(ctx context.Context, messages []mode
 
 	inputPrompt = append(inputPrompt, userPrompt)
 
-	aiResponse, err := ai.QueryAiModel(ctx, ai.DefaultAiModel, inputPrompt)
+	aiResponse, err := ai.QueryAiModel(ctx, AiTaskResponseSuggestions, inputPrompt)
 
 	if err != nil {
-		return []string{}, err
+		return []string{}, nil, err
 	}
 
+	requestJson, _ := json.Marshal(inputPrompt)
+	ai.LogApiCall(organizationId, ai.Db, AiTaskResponseSuggestions, string(requestJson), aiResponse.Content, model.AiModelEnum(aiResponse.ModelUsed), aiResponse.InputTokenUsed, aiResponse.OutputTokenUsed)
+
 	err = json.Unmarshal([]byte(aiResponse.Content), &responseSuggestions)
 
 	if err != nil {
 		fmt.Println("Error unmarshalling response suggestions", err)
-		return []string{}, err
+		return []string{}, nil, err
 	}
 
-	return responseSuggestions.Response, nil
+	return responseSuggestions.Response, KnowledgeBaseCitations(matches), nil
 }
 
 type AiQueryResponse struct {
 	Content         string
 	InputTokenUsed  int
 	OutputTokenUsed int
+	// ModelUsed is the model that answered, another one than the model of the organization when it failed
+	ModelUsed api_types.AiModelEnum
 }
 
-func (ai *AiService) QueryAiModel(ctx context.Context, model api_types.AiModelEnum, inputPrompt []llms.MessageContent) (*AiQueryResponse, error) {
-	var llm llms.Model
-
-	llm, err := ai.ModelRouter.SelectModel(ctx, model, ai.ApiKey)
+// QueryAiModel queries the model of the organization for the task, falling back on the next models of the task when it fails
+func (ai *AiService) QueryAiModel(ctx context.Context, task AiTask, inputPrompt []llms.MessageContent) (*AiQueryResponse, error) {
+	// * the provider sees placeholders in place of the PII, the answer gets the PII back
+	redaction := ai.Redactor.NewRedaction()
+	routedResponse, err := ai.ModelRouter.GenerateContent(ctx, task, ai.DefaultAiModel, ai.ApiKey, ai.AllowCrossProviderFallback, redactPrompt(redaction, inputPrompt))
 
 	if err != nil {
-		ai.Logger.Error("Error creating OpenAI model in query AI model function", err)
-	}
-
-	completion, err := llm.GenerateContent(ctx,
-		inputPrompt,
-	)
-
-	if err != nil {
-		ai.Logger.Error("Error generating content from AI model", err)
+		ai.Logger.Error("Error generating content from AI model", "task", task, "error", err.Error())
 		return nil, err
 	}
 
-	rawJson, _ := json.Marshal(completion)
-	ai.Logger.Info("AI response", string(rawJson))
+	rawJson, _ := json.Marshal(routedResponse.Response)
+	ai.Logger.Info("AI response", "model", routedResponse.ModelUsed, "response", string(rawJson))
 
-	var response AiQueryResponse
-	for _, choice := range completion.Choices {
-		response.Content = choice.Content
-		response.InputTokenUsed = choice.GenerationInfo["PromptTokens"].(int)
-		response.OutputTokenUsed = choice.GenerationInfo["CompletionTokens"].(int)
+	response := AiQueryResponse{
+		ModelUsed: routedResponse.ModelUsed,
+	}
+	for _, choice := range routedResponse.Response.Choices {
+		response.Content = redaction.Restore(choice.Content)
+		response.InputTokenUsed, response.OutputTokenUsed = TokenUsage(choice)
 	}
 
 	return &response, nil
@@ -265,8 +334,8 @@ func (ai *AiService) SanitizeInput(input string) string {
 	return ai.Redactor.Redact(input)
 }
 
-// BuildChatBoxQueryInputPrompt builds an input prompt for the chat box query.
-func (ai *AiService) BuildChatBoxQueryInputPrompt(query string, contextMessages []api_types.AiChatMessageSchema, orgId uuid.UUID) []llms.MessageContent {
+// BuildChatBoxQueryInputPrompt builds an input prompt for the chat box query, along with the citations of the knowledge base passages in it.
+func (ai *AiService) BuildChatBoxQueryInputPrompt(ctx context.Context, query string, contextMessages []api_types.AiChatMessageSchema, orgId uuid.UUID) ([]llms.MessageContent, []api_types.KnowledgeBaseCitationSchema) {
 
 	intent, err := ai.DetectIntent(query, orgId)
 	context := ""
@@ -295,89 +364,147 @@ func (ai *AiService) BuildChatBoxQueryInputPrompt(query string, contextMessages
 		inputPrompt = append(inputPrompt, llms.TextParts(llms.ChatMessageTypeHuman, fullContextText))
 	}
 
+	matches := ai.relevantKnowledge(ctx, orgId, query)
+	if len(matches) > 0 {
+		inputPrompt = append(inputPrompt, llms.TextParts(llms.ChatMessageTypeSystem, knowledgeBasePrompt(matches, SYSTEM_PROMPT_KNOWLEDGE_BASE)))
+	}
+
 	inputPrompt = append(inputPrompt, userPrompt)
 	jsonInputPrompt, _ := json.Marshal(inputPrompt)
 	ai.Logger.Info("Input prompt for AI model", string(jsonInputPrompt))
-	return inputPrompt
+	return inputPrompt, KnowledgeBaseCitations(matches)
 }
 
+// ErrAiModelTimedOut is returned when the model did not finish streaming its answer within the timeout of the router
+var ErrAiModelTimedOut = errors.New("the AI model took too long to answer")
+
+// StreamingResult is the answer of a model streamed chunk by chunk, the stream channel is closed when the generation ends
+// and Wait returns how it ended along with the tokens it used
 type StreamingResult struct {
 	StreamChannel    <-chan string
 	ModelUsed        api_types.AiModelEnum
 	InputTokensUsed  int
 	OutputTokensUsed int
+	// Err is the error the generation stopped with, it is nil when the model finished its answer
+	Err    error
+	done   chan struct{}
+	cancel context.CancelFunc
 }
 
-func (ai *AiService) QueryAiModelWithStreaming(ctx context.Context, inputPrompt []llms.MessageContent) (*StreamingResult, error) {
-	streamChannel := make(chan string)
-	tokenChannel := make(chan struct {
-		inputTokens  int
-		outputTokens int
-	})
+// Cancel stops the generation, e.g. when the client the answer is streamed to is gone
+func (result *StreamingResult) Cancel() {
+	result.cancel()
+}
+
+// Wait blocks until the generation ends and returns its error, the token usage is set once it returns
+func (result *StreamingResult) Wait() error {
+	<-result.done
+	return result.Err
+}
+
+// QueryAiModelWithStreaming streams the answer of the first model of the task with an API key, a stream already sent can not
+// be retried on another model. The generation stops when the context is cancelled, e.g. when the client of the request
+// disconnects, so the caller must either drain the stream channel or cancel the stream
+func (ai *AiService) QueryAiModelWithStreaming(ctx context.Context, task AiTask, inputPrompt []llms.MessageContent) (*StreamingResult, error) {
+	candidates := ai.ModelRouter.CandidatesForTask(task, ai.DefaultAiModel, ai.ApiKey, ai.AllowCrossProviderFallback)
+	if len(candidates) == 0 {
+		return nil, ErrNoModelAvailable
+	}
 
-	model := ai.DefaultAiModel
-	llm, err := ai.ModelRouter.SelectModel(ctx, model, ai.ApiKey)
+	model := candidates[0].Model
+	llm, err := ai.ModelRouter.SelectModel(ctx, task, model, candidates[0].ApiKey)
 
 	if err != nil {
 		return nil, err
 	}
 
+	streamChannel := make(chan string)
+	generationCtx, cancel := context.WithTimeout(ctx, ai.ModelRouter.config.Timeout)
+	result := &StreamingResult{
+		StreamChannel: streamChannel,
+		ModelUsed:     model,
+		done:          make(chan struct{}),
+		cancel:        cancel,
+	}
+
 	go func() {
+		defer close(result.done)
 		defer close(streamChannel)
-		defer close(tokenChannel)
+		defer cancel()
 
-		resp, err := llm.GenerateContent(ctx,
-			inputPrompt,
-			llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
-				streamChannel <- string(chunk)
+		streamedResponse := strings.Builder{}
+		send := func(text string) error {
+			if text == "" {
 				return nil
-			}),
-		)
-
-		if err != nil {
-			log.Fatal(err)
+			}
+			select {
+			case streamChannel <- text:
+				streamedResponse.WriteString(text)
+				return nil
+			case <-generationCtx.Done():
+				return generationCtx.Err()
+			}
 		}
 
-		inputTokenUsed := 0
-		outputTokenUsed := 0
+		// * the provider sees placeholders in place of the PII, the chunks get the PII back before they are sent on
+		redaction := ai.Redactor.NewRedaction()
+		restorer := redaction.NewStreamRestorer()
 
-		for _, choice := range resp.Choices {
-			inputTokenUsed = choice.GenerationInfo["PromptTokens"].(int)
-			outputTokenUsed = choice.GenerationInfo["CompletionTokens"].(int)
+		resp, err := llm.GenerateContent(generationCtx,
+			redactPrompt(redaction, inputPrompt),
+			llms.WithStreamingFunc(func(_ context.Context, chunk []byte) error {
+				return send(restorer.Write(string(chunk)))
+			}),
+		)
+		if err == nil {
+			err = send(restorer.Flush())
 		}
 
-		tokenChannel <- struct {
-			inputTokens  int
-			outputTokens int
-		}{inputTokens: inputTokenUsed, outputTokens: outputTokenUsed}
-	}()
+		if err != nil && errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			err = fmt.Errorf("%w: %w", ErrAiModelTimedOut, err)
+		}
 
-	result := &StreamingResult{
-		StreamChannel: streamChannel,
-		ModelUsed:     model,
-	}
+		if err != nil {
+			// * a stream cancelled by its caller is not an error of the provider
+			if !errors.Is(err, context.Canceled) {
+				ai.Logger.Error("error streaming the answer of the AI model", "model", string(model), "task", string(task), "error", err.Error())
+			}
+			result.Err = err
+		} else {
+			for _, choice := range resp.Choices {
+				result.InputTokensUsed, result.OutputTokensUsed = TokenUsage(choice)
+			}
+		}
 
-	go func() {
-		tokens := <-tokenChannel
-		result.InputTokensUsed = tokens.inputTokens
-		result.OutputTokensUsed = tokens.outputTokens
+		// * the providers bill the tokens of a stream stopped midway too, the usage not reported by the provider is estimated
+		if result.InputTokensUsed == 0 {
+			result.InputTokensUsed = EstimatePromptTokens(inputPrompt)
+		}
+		if result.OutputTokensUsed == 0 {
+			result.OutputTokensUsed = EstimateTokens(streamedResponse.String())
+		}
 	}()
 
 	return result, nil
 }
 
-func (ai *AiService) LogApiCall(organizationId uuid.UUID, db *sql.DB, request, response string, aiModel model.AiModelEnum, inputTokenUsed, outputTokenUsed int) error {
+// LogApiCall logs the call along with its cost for the usage report and the budget of the organization,
+// the owners are warned when the call takes the usage over the soft limit of the budget
+func (ai *AiService) LogApiCall(organizationId uuid.UUID, db *sql.DB, task AiTask, request, response string, aiModel model.AiModelEnum, inputTokenUsed, outputTokenUsed int) error {
 	fmt.Println("Logging API call")
 
 	apiLogToInsert := model.AiApiCallLogs{
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
-		OrganizationId:  organizationId,
-		Request:         request,
-		Response:        response,
-		Model:           model.AiModelEnum(ai.DefaultAiModel),
-		InputTokenUsed:  int32(inputTokenUsed),
-		OutputTokenUsed: int32(outputTokenUsed),
+		CreatedAt:            time.Now(),
+		UpdatedAt:            time.Now(),
+		OrganizationId:       organizationId,
+		Request:              request,
+		Response:             response,
+		Model:                aiModel,
+		InputTokenUsed:       int32(inputTokenUsed),
+		OutputTokenUsed:      int32(outputTokenUsed),
+		Feature:              string(task),
+		OrganizationMemberId: ai.OrganizationMemberId,
+		CostInUsd:            ai.ModelRouter.EstimateCost(api_types.AiModelEnum(aiModel), inputTokenUsed, outputTokenUsed),
 	}
 
 	insertQuery := table.AiApiCallLogs.INSERT(
@@ -395,5 +522,7 @@ func (ai *AiService) LogApiCall(organizationId uuid.UUID, db *sql.DB, request, r
 		return err
 	}
 
+	go ai.notifyAiBudgetSoftLimit(organizationId)
+
 	return nil
 }
@@ -0,0 +1,125 @@
+package ai_service
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+const (
+	stubEmbeddingModel      = "stub-embedding"
+	stubEmbeddingDimensions = 256
+)
+
+// stubResponses are the canned answers of the stub model, shaped like the answers the prompts of every task ask for
+var stubResponses = map[AiTask]string{
+	AiTaskChat:                "This is a response of the offline AI provider, configure an API key to get real answers.",
+	AiTaskIntentDetection:     `{"primaryIntent":"chat","confidence":1,"entities":[],"temporalContext":{"start":"","end":"","timezone":"UTC"}}`,
+	AiTaskSummary:             "- This is a summary of the offline AI provider, configure an API key to get real summaries.",
+	AiTaskResponseSuggestions: `{"response":["Thank you for reaching out, we will get back to you shortly."]}`,
+	// * the stub never answers a contact on its own, the conversation is handed off to the team
+	AiTaskAutoReply:      `{"reply":"","confidence":0,"handoff":true,"handoffReason":"The offline AI provider does not reply to the contacts","topic":""}`,
+	AiTaskSegmentation:   `{"tags":["Engaged"]}`,
+	AiTaskClassification: `{"sentiment":"Neutral","intent":"Other","language":"en"}`,
+	AiTaskTemplateDrafting: `{"variants":[{"name":"offline_draft","angle":"A plain draft of the offline AI provider","components":[` +
+		`{"type":"BODY","text":"Hello {{1}}, this is a draft of the offline AI provider, configure an API key to get real drafts.","example":{"body_text":[["John"]]}},` +
+		`{"type":"FOOTER","text":"Reply STOP to opt out"}]}]}`,
+}
+
+// StubModel answers offline with the canned response of its task, it lets the tests and the local development run the AI features
+// without an API key and without calling a provider
+type StubModel struct {
+	task AiTask
+}
+
+// NewStubModel returns the stub model answering the task
+func NewStubModel(task AiTask) *StubModel {
+	return &StubModel{task: task}
+}
+
+func (stub *StubModel) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	callOptions := llms.CallOptions{}
+	for _, option := range options {
+		option(&callOptions)
+	}
+
+	content, exists := stubResponses[stub.task]
+	if !exists {
+		content = stubResponses[AiTaskChat]
+	}
+
+	if callOptions.StreamingFunc != nil {
+		for _, word := range strings.SplitAfter(content, " ") {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if err := callOptions.StreamingFunc(ctx, []byte(word)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	inputTokens := 0
+	for _, message := range messages {
+		for _, part := range message.Parts {
+			if textPart, isText := part.(llms.TextContent); isText {
+				inputTokens += len(strings.Fields(textPart.Text))
+			}
+		}
+	}
+
+	return &llms.ContentResponse{
+		Choices: []*llms.ContentChoice{
+			{
+				Content:    content,
+				StopReason: "stop",
+				GenerationInfo: map[string]any{
+					"PromptTokens":     inputTokens,
+					"CompletionTokens": len(strings.Fields(content)),
+				},
+			},
+		},
+	}, nil
+}
+
+func (stub *StubModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, stub, prompt, options...)
+}
+
+// StubEmbedder embeds the texts offline as hashed bags of words, the texts sharing words are close to each other
+// which is enough to exercise the knowledge base and the semantic cache
+type StubEmbedder struct{}
+
+func NewStubEmbedder() *StubEmbedder {
+	return &StubEmbedder{}
+}
+
+func (stub *StubEmbedder) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		embedding := make([]float32, stubEmbeddingDimensions)
+		for _, word := range strings.Fields(strings.ToLower(text)) {
+			hash := fnv.New32a()
+			hash.Write([]byte(word))
+			embedding[hash.Sum32()%stubEmbeddingDimensions]++
+		}
+
+		norm := float32(0)
+		for _, value := range embedding {
+			norm += value * value
+		}
+		if norm > 0 {
+			norm = float32(math.Sqrt(float64(norm)))
+			for index := range embedding {
+				embedding[index] /= norm
+			}
+		}
+
+		embeddings = append(embeddings, embedding)
+	}
+
+	return embeddings, nil
+}
@@ -0,0 +1,228 @@
+package ai_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	"github.com/wapikit/wapikit/api/api_types"
+)
+
+const SYSTEM_PROMPT_TEMPLATE_DRAFTING = `You are a copywriter for a WhatsApp Business Management tool used for sending marketing campaign and customer engagement. You will be provided with the brief of a business for a WhatsApp message template, write templates that Meta approves on the first review. Follow these rules:
+- The components are a BODY, optionally a HEADER with the TEXT format, a FOOTER and BUTTONS, in the format of the WhatsApp Business Management API.
+- The personalized parts are placeholders numbered in order starting at {{1}}, e.g. {{1}} for the name of the contact, and every placeholder has a sample value in the example of its component.
+- The body is at most 1024 characters, it never starts or ends with a placeholder, two placeholders are always separated by words and the text around them tells what the message is about.
+- The header is at most 60 characters with at most one placeholder and no emojis, line breaks or formatting. The footer is at most 60 characters without placeholders.
+- At most 10 buttons: QUICK_REPLY buttons grouped together, at most 2 URL buttons whose url may only end with {{1}}, at most 1 PHONE_NUMBER button. Button texts are at most 25 characters.
+- Never use misleading or threatening wording, never ask for passwords, card numbers or other sensitive data.
+You must respond back with a json string with a "variants" property, an array of objects with the following properties:
+- "name": the name of the template, lower case letters, digits and underscores only.
+- "angle": one sentence telling how the variant differs from the others, e.g. the hook or the call to action it tests.
+- "components": the array of the components, e.g. [{"type":"HEADER","format":"TEXT","text":"Your order is on its way"},{"type":"BODY","text":"Hi {{1}}, your order {{2}} ships today.","example":{"body_text":[["John","#1234"]]}},{"type":"FOOTER","text":"Reply STOP to opt out"},{"type":"BUTTONS","buttons":[{"type":"URL","text":"Track order","url":"https://example.com/track/{{1}}","example":["1234"]}]}].`
+
+// templateCategoryGuidance tells the model what Meta accepts in each category, a template written outside of its category is recategorized or rejected
+var templateCategoryGuidance = map[api_types.MessageTemplateCategory]string{
+	api_types.MARKETING: "The category is MARKETING: promotions, offers, announcements, re-engagement. Make it engaging with a single clear call to action and a way to opt out, e.g. in the footer.",
+	api_types.UTILITY:   "The category is UTILITY: a transactional update about an order, an account, a booking or a request the contact made. Stay factual and specific, never promote, upsell or mention offers or discounts, or Meta recategorizes the template as marketing.",
+}
+
+const (
+	TemplateDraftMaxBriefLength  = 2000
+	TemplateDraftMaxVariants     = 4
+	TemplateDraftDefaultVariants = 2
+	templateDraftMaxToneLength   = 100
+)
+
+var (
+	templateDraftLanguagePattern = regexp.MustCompile(`^[a-z]{2,3}(_[A-Z]{2})?$`)
+	templateNameInvalidPattern   = regexp.MustCompile(`[^a-z0-9_]+`)
+)
+
+// TemplateDraftParams is the brief the templates are drafted from
+type TemplateDraftParams struct {
+	OrganizationId uuid.UUID
+	Brief          string
+	Category       api_types.MessageTemplateCategory
+	// Language is the code of the language of the template, e.g. en_US
+	Language     string
+	VariantCount int
+	Tone         string
+}
+
+// TemplateDraft is a variant of a template drafted by the model, along with the rules of Meta it still breaks
+type TemplateDraft struct {
+	Name       string
+	Angle      string
+	Components []api_types.WhatsAppBusinessHSMWhatsAppHSMComponent
+	Issues     []TemplateRuleIssue
+}
+
+type templateDraftsResponse struct {
+	Variants []struct {
+		Name       string                                              `json:"name"`
+		Angle      string                                              `json:"angle"`
+		Components []api_types.WhatsAppBusinessHSMWhatsAppHSMComponent `json:"components"`
+	} `json:"variants"`
+}
+
+// NormalizeTemplateDraftParams checks the brief and fills in the defaults, the returned params are the ones to draft with
+func NormalizeTemplateDraftParams(params TemplateDraftParams) (TemplateDraftParams, error) {
+	params.Brief = strings.TrimSpace(params.Brief)
+	if params.Brief == "" {
+		return params, fmt.Errorf("brief is required")
+	}
+	if len([]rune(params.Brief)) > TemplateDraftMaxBriefLength {
+		return params, fmt.Errorf("brief must be at most %d characters", TemplateDraftMaxBriefLength)
+	}
+
+	if _, isSupported := templateCategoryGuidance[params.Category]; !isSupported {
+		if params.Category == api_types.AUTHENTICATION {
+			return params, fmt.Errorf("the text of the authentication templates is preset by Meta, it can not be drafted")
+		}
+		return params, fmt.Errorf("invalid template category")
+	}
+
+	params.Language = strings.TrimSpace(params.Language)
+	if params.Language == "" {
+		params.Language = "en_US"
+	}
+	if !templateDraftLanguagePattern.MatchString(params.Language) {
+		return params, fmt.Errorf("invalid language code, e.g. en_US")
+	}
+
+	if params.VariantCount == 0 {
+		params.VariantCount = TemplateDraftDefaultVariants
+	}
+	if params.VariantCount < 1 || params.VariantCount > TemplateDraftMaxVariants {
+		return params, fmt.Errorf("the number of variants must be between 1 and %d", TemplateDraftMaxVariants)
+	}
+
+	params.Tone = strings.TrimSpace(params.Tone)
+	if len([]rune(params.Tone)) > templateDraftMaxToneLength {
+		return params, fmt.Errorf("tone must be at most %d characters", templateDraftMaxToneLength)
+	}
+
+	return params, nil
+}
+
+// DraftTemplates asks the model for variants of a template written from the brief, the variants differ in their hook or call to action
+// so that they can be A/B tested, the variants breaking a rule of Meta are sent back to the model once to be fixed, the issues left are
+// returned along with the variant
+func (ai *AiService) DraftTemplates(ctx context.Context, params TemplateDraftParams) ([]TemplateDraft, error) {
+	params, err := NormalizeTemplateDraftParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	instructions := []string{
+		SYSTEM_PROMPT_TEMPLATE_DRAFTING,
+		templateCategoryGuidance[params.Category],
+		fmt.Sprintf("Write the templates in the language with the code %s.", params.Language),
+		fmt.Sprintf("Write exactly %d variants, each testing a different angle.", params.VariantCount),
+	}
+	if params.Tone != "" {
+		instructions = append(instructions, "The tone of the templates is: "+params.Tone)
+	}
+
+	inputPrompt := []llms.MessageContent{
+		llms.TextParts(llms.ChatMessageTypeSystem, strings.Join(instructions, "\n")),
+		llms.TextParts(llms.ChatMessageTypeHuman, params.Brief),
+	}
+
+	drafts, aiResponseContent, err := ai.queryTemplateDrafts(ctx, params, inputPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	issuesToFix := []string{}
+	for _, draft := range drafts {
+		for _, issue := range draft.Issues {
+			if issue.Severity == TemplateRuleIssueSeverityError {
+				issuesToFix = append(issuesToFix, fmt.Sprintf("%s: %s %s", draft.Name, strings.ToLower(string(issue.Component)), issue.Message))
+			}
+		}
+	}
+	if len(issuesToFix) == 0 {
+		return drafts, nil
+	}
+
+	repairPrompt := append(inputPrompt,
+		llms.TextParts(llms.ChatMessageTypeAI, aiResponseContent),
+		llms.TextParts(llms.ChatMessageTypeHuman, "Meta would reject these variants for the following issues, fix them and respond with all the variants in the same format:\n"+strings.Join(issuesToFix, "\n")),
+	)
+
+	repairedDrafts, _, err := ai.queryTemplateDrafts(ctx, params, repairPrompt)
+	if err != nil || len(repairedDrafts) < len(drafts) {
+		// * the first drafts are still worth showing, their issues tell what to fix by hand
+		return drafts, nil
+	}
+
+	return repairedDrafts, nil
+}
+
+func (ai *AiService) queryTemplateDrafts(ctx context.Context, params TemplateDraftParams, inputPrompt []llms.MessageContent) ([]TemplateDraft, string, error) {
+	aiResponse, err := ai.QueryAiModel(ctx, AiTaskTemplateDrafting, inputPrompt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	requestJson, _ := json.Marshal(inputPrompt)
+	ai.LogApiCall(params.OrganizationId, ai.Db, AiTaskTemplateDrafting, string(requestJson), aiResponse.Content, model.AiModelEnum(aiResponse.ModelUsed), aiResponse.InputTokenUsed, aiResponse.OutputTokenUsed)
+
+	var response templateDraftsResponse
+	if err := json.Unmarshal([]byte(stripCodeFence(aiResponse.Content)), &response); err != nil {
+		return nil, "", fmt.Errorf("error parsing the template drafts of the model: %w", err)
+	}
+
+	drafts := []TemplateDraft{}
+	usedNames := map[string]bool{}
+	for index, variant := range response.Variants {
+		if len(drafts) == params.VariantCount {
+			break
+		}
+
+		name := normalizeTemplateName(variant.Name)
+		if name == "" {
+			name = "template"
+		}
+		// * the variants of an A/B test are submitted to Meta side by side, their names must differ
+		if usedNames[name] {
+			name = name + "_" + strconv.Itoa(index+1)
+		}
+		usedNames[name] = true
+
+		components := variant.Components
+		if components == nil {
+			components = []api_types.WhatsAppBusinessHSMWhatsAppHSMComponent{}
+		}
+
+		drafts = append(drafts, TemplateDraft{
+			Name:       name,
+			Angle:      strings.TrimSpace(variant.Angle),
+			Components: components,
+			Issues:     ValidateTemplateComponents(name, params.Category, components),
+		})
+	}
+
+	if len(drafts) == 0 {
+		return nil, "", fmt.Errorf("the model did not draft any template")
+	}
+
+	return drafts, aiResponse.Content, nil
+}
+
+// normalizeTemplateName turns the name the model gave into a name Meta accepts, e.g. "Order Shipped!" into order_shipped
+func normalizeTemplateName(name string) string {
+	name = templateNameInvalidPattern.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "_")
+	name = strings.Trim(name, "_")
+	if len(name) > templateNameMaxLength {
+		name = name[:templateNameMaxLength]
+	}
+	return name
+}
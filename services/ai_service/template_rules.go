@@ -0,0 +1,334 @@
+package ai_service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wapikit/wapikit/api/api_types"
+)
+
+// ! https://developers.facebook.com/docs/whatsapp/business-management-api/message-templates/components
+// * the limits below are the ones Meta rejects a template for, the category checks are warnings as Meta may only recategorize the template
+
+const (
+	templateNameMaxLength        = 512
+	templateHeaderTextMaxLength  = 60
+	templateBodyMaxLength        = 1024
+	templateFooterMaxLength      = 60
+	templateButtonTextMaxLength  = 25
+	templateButtonUrlMaxLength   = 2000
+	templateButtonPhoneMaxLength = 20
+	templateMaxButtons           = 10
+	templateMaxUrlButtons        = 2
+	templateMaxPhoneButtons      = 1
+	templateMaxCopyCodeButtons   = 1
+)
+
+var (
+	templateNamePattern                = regexp.MustCompile(`^[a-z0-9_]+$`)
+	templatePlaceholderPattern         = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+	templateAdjacentPlaceholderPattern = regexp.MustCompile(`\}\}\s*\{\{`)
+	// * the words that make Meta recategorize a utility template as a marketing one
+	templatePromotionalPattern = regexp.MustCompile(`(?i)\b(offer|offers|discount|discounts|sale|deal|deals|coupon|promo|promotion|free|buy now|shop now|limited time|% off|off on|exclusive)\b`)
+)
+
+// TemplateRuleIssueSeverity tells whether Meta rejects the template for the issue or it is only a risk of a rejection or a recategorization
+type TemplateRuleIssueSeverity string
+
+const (
+	TemplateRuleIssueSeverityError   TemplateRuleIssueSeverity = "error"
+	TemplateRuleIssueSeverityWarning TemplateRuleIssueSeverity = "warning"
+)
+
+// TemplateRuleIssue is a rule of Meta the template breaks, the component is empty for the issues of the template as a whole
+type TemplateRuleIssue struct {
+	Component api_types.MessageTemplateComponentType
+	Severity  TemplateRuleIssueSeverity
+	Message   string
+}
+
+type templateRuleChecker struct {
+	issues []TemplateRuleIssue
+}
+
+func (checker *templateRuleChecker) add(component api_types.MessageTemplateComponentType, severity TemplateRuleIssueSeverity, format string, args ...interface{}) {
+	checker.issues = append(checker.issues, TemplateRuleIssue{
+		Component: component,
+		Severity:  severity,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+// ValidateTemplateComponents checks the name and the components of a template against the rules Meta reviews the templates with,
+// the template is accepted by Meta only when none of the issues returned is an error
+func ValidateTemplateComponents(name string, category api_types.MessageTemplateCategory, components []api_types.WhatsAppBusinessHSMWhatsAppHSMComponent) []TemplateRuleIssue {
+	checker := &templateRuleChecker{issues: []TemplateRuleIssue{}}
+
+	if name == "" {
+		checker.add("", TemplateRuleIssueSeverityError, "the name is required")
+	} else if len(name) > templateNameMaxLength || !templateNamePattern.MatchString(name) {
+		checker.add("", TemplateRuleIssueSeverityError, "the name must be at most %d lower case letters, digits and underscores", templateNameMaxLength)
+	}
+
+	componentsByType := map[api_types.MessageTemplateComponentType][]api_types.WhatsAppBusinessHSMWhatsAppHSMComponent{}
+	for _, component := range components {
+		if component.Type == nil {
+			checker.add("", TemplateRuleIssueSeverityError, "every component must have a type")
+			continue
+		}
+		componentsByType[*component.Type] = append(componentsByType[*component.Type], component)
+		if len(componentsByType[*component.Type]) == 2 {
+			checker.add(*component.Type, TemplateRuleIssueSeverityError, "a template can have only one %s component", *component.Type)
+		}
+	}
+
+	if category == api_types.AUTHENTICATION {
+		// * the text of the authentication templates is preset by Meta, only the code expiration and the buttons can be chosen
+		for _, component := range components {
+			if component.Text != nil && *component.Text != "" {
+				checker.add(*component.Type, TemplateRuleIssueSeverityError, "the text of an authentication template is preset by Meta and can not be written")
+			}
+		}
+		return checker.issues
+	}
+
+	if headers := componentsByType[api_types.HEADER]; len(headers) > 0 {
+		checker.checkHeader(headers[0])
+	}
+
+	bodies := componentsByType[api_types.BODY]
+	if len(bodies) == 0 {
+		checker.add(api_types.BODY, TemplateRuleIssueSeverityError, "the body is required")
+	} else {
+		checker.checkBody(bodies[0], category)
+	}
+
+	if footers := componentsByType[api_types.FOOTER]; len(footers) > 0 {
+		checker.checkFooter(footers[0])
+	}
+
+	if buttons := componentsByType[api_types.BUTTONS]; len(buttons) > 0 {
+		checker.checkButtons(buttons[0])
+	}
+
+	return checker.issues
+}
+
+// HasTemplateRuleErrors reports whether Meta would reject the template for one of the issues
+func HasTemplateRuleErrors(issues []TemplateRuleIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == TemplateRuleIssueSeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPlaceholders checks the placeholders of the text are {{1}}, {{2}}, ... in order without a gap, it returns how many there are
+func (checker *templateRuleChecker) checkPlaceholders(componentType api_types.MessageTemplateComponentType, text string) int {
+	// * a brace left unmatched is a typo of a placeholder, Meta would send it to the contact as it is
+	if textWithoutPlaceholders := templatePlaceholderPattern.ReplaceAllString(text, ""); strings.Contains(textWithoutPlaceholders, "{{") || strings.Contains(textWithoutPlaceholders, "}}") {
+		checker.add(componentType, TemplateRuleIssueSeverityError, "the placeholders must be written with double curly braces, e.g. {{1}}")
+	}
+
+	matches := templatePlaceholderPattern.FindAllStringSubmatch(text, -1)
+	for index, match := range matches {
+		number, err := strconv.Atoi(match[1])
+		if err != nil {
+			checker.add(componentType, TemplateRuleIssueSeverityError, "the placeholder %s must be a number, e.g. {{1}}", match[0])
+			return len(matches)
+		}
+		if number != index+1 {
+			checker.add(componentType, TemplateRuleIssueSeverityError, "the placeholders must be numbered in order starting at {{1}}, found %s in place of {{%d}}", match[0], index+1)
+			return len(matches)
+		}
+	}
+
+	return len(matches)
+}
+
+func (checker *templateRuleChecker) checkHeader(header api_types.WhatsAppBusinessHSMWhatsAppHSMComponent) {
+	format := api_types.TEXT
+	if header.Format != nil {
+		format = *header.Format
+	}
+
+	if format != api_types.TEXT {
+		if format != api_types.LOCATION && (header.Example == nil || header.Example.HeaderHandle == nil || len(*header.Example.HeaderHandle) == 0) {
+			checker.add(api_types.HEADER, TemplateRuleIssueSeverityError, "a %s header needs a sample media uploaded to Meta", format)
+		}
+		return
+	}
+
+	text := ""
+	if header.Text != nil {
+		text = *header.Text
+	}
+	if strings.TrimSpace(text) == "" {
+		checker.add(api_types.HEADER, TemplateRuleIssueSeverityError, "a text header can not be empty")
+		return
+	}
+	if length := len([]rune(text)); length > templateHeaderTextMaxLength {
+		checker.add(api_types.HEADER, TemplateRuleIssueSeverityError, "the header must be at most %d characters, it has %d", templateHeaderTextMaxLength, length)
+	}
+	if strings.ContainsAny(text, "\n*_~") {
+		checker.add(api_types.HEADER, TemplateRuleIssueSeverityError, "the header can not have line breaks or formatting characters")
+	}
+
+	placeholderCount := checker.checkPlaceholders(api_types.HEADER, text)
+	if placeholderCount > 1 {
+		checker.add(api_types.HEADER, TemplateRuleIssueSeverityError, "the header can have at most one placeholder")
+	}
+	if placeholderCount > 0 && (header.Example == nil || header.Example.HeaderText == nil || len(*header.Example.HeaderText) != placeholderCount) {
+		checker.add(api_types.HEADER, TemplateRuleIssueSeverityError, "the placeholder of the header needs a sample value")
+	}
+}
+
+func (checker *templateRuleChecker) checkBody(body api_types.WhatsAppBusinessHSMWhatsAppHSMComponent, category api_types.MessageTemplateCategory) {
+	text := ""
+	if body.Text != nil {
+		text = strings.TrimSpace(*body.Text)
+	}
+	if text == "" {
+		checker.add(api_types.BODY, TemplateRuleIssueSeverityError, "the body can not be empty")
+		return
+	}
+	if length := len([]rune(text)); length > templateBodyMaxLength {
+		checker.add(api_types.BODY, TemplateRuleIssueSeverityError, "the body must be at most %d characters, it has %d", templateBodyMaxLength, length)
+	}
+
+	placeholderCount := checker.checkPlaceholders(api_types.BODY, text)
+	if placeholderCount > 0 {
+		if strings.HasPrefix(text, "{{") || strings.HasSuffix(text, "}}") {
+			checker.add(api_types.BODY, TemplateRuleIssueSeverityError, "the body can not start or end with a placeholder")
+		}
+		if templateAdjacentPlaceholderPattern.MatchString(text) {
+			checker.add(api_types.BODY, TemplateRuleIssueSeverityError, "the placeholders must be separated by some text")
+		}
+
+		// * Meta rejects the templates that are mostly placeholders, the text around them must tell what the message is about
+		wordCount := len(strings.Fields(templatePlaceholderPattern.ReplaceAllString(text, " ")))
+		if wordCount < 2*placeholderCount+1 {
+			checker.add(api_types.BODY, TemplateRuleIssueSeverityError, "the body has too many placeholders for its length, write at least %d words around them", 2*placeholderCount+1)
+		}
+
+		hasExamples := body.Example != nil && body.Example.BodyText != nil && len(*body.Example.BodyText) > 0 && len((*body.Example.BodyText)[0]) == placeholderCount
+		if !hasExamples {
+			checker.add(api_types.BODY, TemplateRuleIssueSeverityError, "every placeholder of the body needs a sample value, %d are needed", placeholderCount)
+		}
+	}
+
+	if strings.Contains(text, "\n\n\n") {
+		checker.add(api_types.BODY, TemplateRuleIssueSeverityWarning, "more than one empty line in a row is shown as a single one")
+	}
+
+	if category == api_types.UTILITY {
+		if promotionalWord := templatePromotionalPattern.FindString(text); promotionalWord != "" {
+			checker.add(api_types.BODY, TemplateRuleIssueSeverityWarning, "%q reads as promotional, Meta may recategorize the template as marketing", promotionalWord)
+		}
+	}
+}
+
+func (checker *templateRuleChecker) checkFooter(footer api_types.WhatsAppBusinessHSMWhatsAppHSMComponent) {
+	text := ""
+	if footer.Text != nil {
+		text = *footer.Text
+	}
+	if strings.TrimSpace(text) == "" {
+		checker.add(api_types.FOOTER, TemplateRuleIssueSeverityError, "the footer can not be empty")
+		return
+	}
+	if length := len([]rune(text)); length > templateFooterMaxLength {
+		checker.add(api_types.FOOTER, TemplateRuleIssueSeverityError, "the footer must be at most %d characters, it has %d", templateFooterMaxLength, length)
+	}
+	if templatePlaceholderPattern.MatchString(text) {
+		checker.add(api_types.FOOTER, TemplateRuleIssueSeverityError, "the footer can not have placeholders")
+	}
+}
+
+func (checker *templateRuleChecker) checkButtons(component api_types.WhatsAppBusinessHSMWhatsAppHSMComponent) {
+	if component.Buttons == nil || len(*component.Buttons) == 0 {
+		checker.add(api_types.BUTTONS, TemplateRuleIssueSeverityError, "the buttons component needs at least one button")
+		return
+	}
+
+	buttons := *component.Buttons
+	if len(buttons) > templateMaxButtons {
+		checker.add(api_types.BUTTONS, TemplateRuleIssueSeverityError, "a template can have at most %d buttons", templateMaxButtons)
+	}
+
+	countByType := map[api_types.TemplateMessageButtonType]int{}
+	isQuickReplyGroupClosed := false
+	previousWasQuickReply := false
+	for index, button := range buttons {
+		if button.Type == nil {
+			checker.add(api_types.BUTTONS, TemplateRuleIssueSeverityError, "the button %d must have a type", index+1)
+			continue
+		}
+		countByType[*button.Type]++
+
+		// * the quick replies must be grouped together, either before or after the other buttons
+		isQuickReply := *button.Type == api_types.QUICKREPLY
+		if isQuickReply && !previousWasQuickReply && countByType[api_types.QUICKREPLY] > 1 {
+			isQuickReplyGroupClosed = true
+		}
+		previousWasQuickReply = isQuickReply
+
+		text := ""
+		if button.Text != nil {
+			text = strings.TrimSpace(*button.Text)
+		}
+		if text == "" && *button.Type != api_types.COPYCODE {
+			checker.add(api_types.BUTTONS, TemplateRuleIssueSeverityError, "the button %d needs a text", index+1)
+		}
+		if length := len([]rune(text)); length > templateButtonTextMaxLength {
+			checker.add(api_types.BUTTONS, TemplateRuleIssueSeverityError, "the text of the button %d must be at most %d characters, it has %d", index+1, templateButtonTextMaxLength, length)
+		}
+		if templatePlaceholderPattern.MatchString(text) {
+			checker.add(api_types.BUTTONS, TemplateRuleIssueSeverityError, "the text of the button %d can not have placeholders", index+1)
+		}
+
+		switch *button.Type {
+		case api_types.URL:
+			url := ""
+			if button.Url != nil {
+				url = strings.TrimSpace(*button.Url)
+			}
+			if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+				checker.add(api_types.BUTTONS, TemplateRuleIssueSeverityError, "the button %d needs a valid url", index+1)
+			} else if len(url) > templateButtonUrlMaxLength {
+				checker.add(api_types.BUTTONS, TemplateRuleIssueSeverityError, "the url of the button %d must be at most %d characters", index+1, templateButtonUrlMaxLength)
+			}
+			if placeholders := templatePlaceholderPattern.FindAllString(url, -1); len(placeholders) > 0 {
+				if len(placeholders) > 1 || !strings.HasSuffix(url, "{{1}}") {
+					checker.add(api_types.BUTTONS, TemplateRuleIssueSeverityError, "the url of the button %d can only have the placeholder {{1}} at its end", index+1)
+				} else if button.Example == nil || len(*button.Example) == 0 {
+					checker.add(api_types.BUTTONS, TemplateRuleIssueSeverityError, "the placeholder of the url of the button %d needs a sample value", index+1)
+				}
+			}
+		case api_types.PHONENUMBER:
+			phoneNumber := ""
+			if button.PhoneNumber != nil {
+				phoneNumber = strings.TrimSpace(*button.PhoneNumber)
+			}
+			if !strings.HasPrefix(phoneNumber, "+") || len(phoneNumber) > templateButtonPhoneMaxLength {
+				checker.add(api_types.BUTTONS, TemplateRuleIssueSeverityError, "the button %d needs a phone number in the international format, e.g. +15550001234", index+1)
+			}
+		}
+	}
+
+	if isQuickReplyGroupClosed {
+		checker.add(api_types.BUTTONS, TemplateRuleIssueSeverityError, "the quick reply buttons must be grouped together")
+	}
+	if countByType[api_types.URL] > templateMaxUrlButtons {
+		checker.add(api_types.BUTTONS, TemplateRuleIssueSeverityError, "a template can have at most %d url buttons", templateMaxUrlButtons)
+	}
+	if countByType[api_types.PHONENUMBER] > templateMaxPhoneButtons {
+		checker.add(api_types.BUTTONS, TemplateRuleIssueSeverityError, "a template can have at most %d phone number button", templateMaxPhoneButtons)
+	}
+	if countByType[api_types.COPYCODE] > templateMaxCopyCodeButtons {
+		checker.add(api_types.BUTTONS, TemplateRuleIssueSeverityError, "a template can have at most %d copy code button", templateMaxCopyCodeButtons)
+	}
+}
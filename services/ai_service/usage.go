@@ -0,0 +1,144 @@
+package ai_service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/api/api_types"
+)
+
+// aiUsageColumns aggregates the AI API call logs of the organization ($1) made in the window from $2 to $3
+const aiUsageColumns = `
+	COUNT(*),
+	COUNT(*) FILTER (WHERE l."IsCacheHit"),
+	COALESCE(SUM(l."InputTokenUsed"), 0),
+	COALESCE(SUM(l."OutputTokenUsed"), 0),
+	COALESCE(SUM(l."CostInUsd"), 0)`
+
+const aiUsageQuery = `
+SELECT` + aiUsageColumns + `
+FROM "AiApiCallLogs" l
+WHERE l."OrganizationId" = $1 AND l."CreatedAt" >= $2 AND l."CreatedAt" < $3`
+
+const aiUsageByFeatureQuery = `
+SELECT l."Feature",` + aiUsageColumns + `
+FROM "AiApiCallLogs" l
+WHERE l."OrganizationId" = $1 AND l."CreatedAt" >= $2 AND l."CreatedAt" < $3
+GROUP BY l."Feature"
+ORDER BY 6 DESC, 2 DESC`
+
+const aiUsageByMemberQuery = `
+SELECT l."OrganizationMemberId", u."Name",` + aiUsageColumns + `
+FROM "AiApiCallLogs" l
+LEFT JOIN "OrganizationMember" m ON m."UniqueId" = l."OrganizationMemberId"
+LEFT JOIN "User" u ON u."UniqueId" = m."UserId"
+WHERE l."OrganizationId" = $1 AND l."CreatedAt" >= $2 AND l."CreatedAt" < $3
+GROUP BY l."OrganizationMemberId", u."Name"
+ORDER BY 7 DESC, 3 DESC`
+
+// AiUsage is the number of AI calls of an organization along with the tokens and the cost they used
+type AiUsage struct {
+	CallCount     int64
+	CacheHitCount int64
+	InputTokens   int64
+	OutputTokens  int64
+	CostInUsd     float64
+}
+
+func (usage AiUsage) toApiSchema() api_types.AiUsageSchema {
+	return api_types.AiUsageSchema{
+		CallCount:     usage.CallCount,
+		CacheHitCount: usage.CacheHitCount,
+		InputTokens:   usage.InputTokens,
+		OutputTokens:  usage.OutputTokens,
+		CostInUsd:     usage.CostInUsd,
+	}
+}
+
+func queryAiUsage(ctx context.Context, db *sql.DB, organizationId uuid.UUID, from, to time.Time) (*AiUsage, error) {
+	var usage AiUsage
+	err := db.QueryRowContext(ctx, aiUsageQuery, organizationId, from, to).
+		Scan(&usage.CallCount, &usage.CacheHitCount, &usage.InputTokens, &usage.OutputTokens, &usage.CostInUsd)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &usage, nil
+}
+
+// GetAiUsageReport returns the AI usage of the organization in the window broken down by feature and by member,
+// the calls made in the background, e.g. by the auto responder, are grouped under a member without id
+func GetAiUsageReport(ctx context.Context, db *sql.DB, organizationId uuid.UUID, from, to time.Time) (*api_types.AiUsageReportSchema, error) {
+	report := &api_types.AiUsageReportSchema{
+		From:      from,
+		To:        to,
+		ByFeature: []api_types.AiFeatureUsageSchema{},
+		ByMember:  []api_types.AiMemberUsageSchema{},
+	}
+
+	total := AiUsage{}
+	featureRows, err := db.QueryContext(ctx, aiUsageByFeatureQuery, organizationId, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer featureRows.Close()
+
+	for featureRows.Next() {
+		var feature string
+		var usage AiUsage
+		if err := featureRows.Scan(&feature, &usage.CallCount, &usage.CacheHitCount, &usage.InputTokens, &usage.OutputTokens, &usage.CostInUsd); err != nil {
+			return nil, err
+		}
+
+		total.CallCount += usage.CallCount
+		total.CacheHitCount += usage.CacheHitCount
+		total.InputTokens += usage.InputTokens
+		total.OutputTokens += usage.OutputTokens
+		total.CostInUsd += usage.CostInUsd
+
+		report.ByFeature = append(report.ByFeature, api_types.AiFeatureUsageSchema{
+			Feature: feature,
+			Usage:   usage.toApiSchema(),
+		})
+	}
+	if err := featureRows.Err(); err != nil {
+		return nil, err
+	}
+	report.Total = total.toApiSchema()
+
+	memberRows, err := db.QueryContext(ctx, aiUsageByMemberQuery, organizationId, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer memberRows.Close()
+
+	for memberRows.Next() {
+		var memberId uuid.NullUUID
+		var name sql.NullString
+		var usage AiUsage
+		if err := memberRows.Scan(&memberId, &name, &usage.CallCount, &usage.CacheHitCount, &usage.InputTokens, &usage.OutputTokens, &usage.CostInUsd); err != nil {
+			return nil, err
+		}
+
+		memberUsage := api_types.AiMemberUsageSchema{
+			Usage: usage.toApiSchema(),
+		}
+		if memberId.Valid {
+			stringMemberId := memberId.UUID.String()
+			memberUsage.OrganizationMemberId = &stringMemberId
+		}
+		if name.Valid {
+			memberUsage.Name = &name.String
+		}
+
+		report.ByMember = append(report.ByMember, memberUsage)
+	}
+	if err := memberRows.Err(); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
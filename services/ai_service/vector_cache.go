@@ -5,37 +5,188 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/wapikit/wapikit/api/api_types"
 	cache_service "github.com/wapikit/wapikit/services/redis_service"
 )
 
+// SemanticCacheConfig controls when a query is answered from the response of an earlier query of the same meaning
+type SemanticCacheConfig struct {
+	Enabled bool
+	// SimilarityThreshold is the cosine similarity from which two queries are considered the same question
+	SimilarityThreshold float64
+	TTL                 time.Duration
+	// MaxEntriesPerOrganization bounds the entries of an organization per scope, the least recently used are evicted first
+	MaxEntriesPerOrganization int64
+}
+
+var DefaultSemanticCacheConfig = SemanticCacheConfig{
+	Enabled:                   true,
+	SimilarityThreshold:       0.95,
+	TTL:                       60 * time.Minute,
+	MaxEntriesPerOrganization: 500,
+}
+
+// SemanticCacheEntry is a query answered by the AI model, stored with its embedding to be compared with the next queries
+type SemanticCacheEntry struct {
+	Id        string                                  `json:"id"`
+	Query     string                                  `json:"query"`
+	Embedding []float32                               `json:"embedding"`
+	Response  string                                  `json:"response"`
+	Citations []api_types.KnowledgeBaseCitationSchema `json:"citations,omitempty"`
+	CreatedAt time.Time                               `json:"createdAt"`
+}
+
+// RedisVectorCache stores the entries of an organization under "vector_cache:<organization>:<scope>:<embedding model>",
+// every entry is a key expiring with the TTL and a sorted set indexes them by their last use for the eviction
 type RedisVectorCache struct {
-	client   *cache_service.RedisClient
-	prefix   string
-	indexKey string
+	client *cache_service.RedisClient
+	prefix string
+	config SemanticCacheConfig
 }
 
 // NewRedisVectorCache initializes a new RedisVectorCache.
-func NewRedisVectorCache(client *cache_service.RedisClient) *RedisVectorCache {
+func NewRedisVectorCache(client *cache_service.RedisClient, config SemanticCacheConfig) *RedisVectorCache {
 	return &RedisVectorCache{
-		client:   client,
-		prefix:   "vector_cache:",
-		indexKey: "vector_cache:keys",
+		client: client,
+		prefix: "vector_cache:",
+		config: config,
 	}
 }
 
-// StoreQueryEmbedding stores the embedding for a query in Redis.
-// The embedding is stored as a JSON string under key "vector_cache:<query>".
-func (vc *RedisVectorCache) StoreQueryEmbedding(query string, embedding []float64, ttl time.Duration) error {
-	data, err := json.Marshal(embedding)
+func (vc *RedisVectorCache) namespace(organizationId uuid.UUID, scope, embeddingModel string) string {
+	return vc.prefix + organizationId.String() + ":" + scope + ":" + embeddingModel
+}
+
+func (vc *RedisVectorCache) indexKey(namespace string) string {
+	return namespace + ":index"
+}
+
+func (vc *RedisVectorCache) entryKey(namespace, entryId string) string {
+	return namespace + ":entry:" + entryId
+}
+
+// FindNearest returns the cached entry closest to the embedding with its similarity, nil when no entry reaches the threshold,
+// the expired entries found on the way are removed from the index
+func (vc *RedisVectorCache) FindNearest(ctx context.Context, organizationId uuid.UUID, scope, embeddingModel string, embedding []float32) (*SemanticCacheEntry, float64, error) {
+	namespace := vc.namespace(organizationId, scope, embeddingModel)
+	indexKey := vc.indexKey(namespace)
+
+	entryIds, err := vc.client.ZRevRange(ctx, indexKey, 0, vc.config.MaxEntriesPerOrganization-1).Result()
 	if err != nil {
+		return nil, 0, err
+	}
+	if len(entryIds) == 0 {
+		return nil, 0, nil
+	}
+
+	entryKeys := make([]string, 0, len(entryIds))
+	for _, entryId := range entryIds {
+		entryKeys = append(entryKeys, vc.entryKey(namespace, entryId))
+	}
+
+	values, err := vc.client.MGet(ctx, entryKeys...).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var nearest *SemanticCacheEntry
+	nearestSimilarity := 0.0
+	expiredEntryIds := []interface{}{}
+
+	for index, value := range values {
+		stringValue, ok := value.(string)
+		if !ok {
+			expiredEntryIds = append(expiredEntryIds, entryIds[index])
+			continue
+		}
+
+		var entry SemanticCacheEntry
+		if err := json.Unmarshal([]byte(stringValue), &entry); err != nil {
+			expiredEntryIds = append(expiredEntryIds, entryIds[index])
+			continue
+		}
+
+		similarity := CosineSimilarity(embedding, entry.Embedding)
+		if similarity >= vc.config.SimilarityThreshold && similarity > nearestSimilarity {
+			nearest = &entry
+			nearestSimilarity = similarity
+		}
+	}
+
+	if len(expiredEntryIds) > 0 {
+		vc.client.ZRem(ctx, indexKey, expiredEntryIds...)
+	}
+
+	if nearest != nil {
+		// * a hit keeps the entry from being evicted, it does not extend its TTL so that the answers do not get stale
+		vc.client.ZAdd(ctx, indexKey, redis.Z{Score: float64(time.Now().UnixMilli()), Member: nearest.Id})
+	}
+
+	return nearest, nearestSimilarity, nil
+}
+
+// Store adds the entry to the cache and evicts the least recently used entries above the limit of the organization
+func (vc *RedisVectorCache) Store(ctx context.Context, organizationId uuid.UUID, scope, embeddingModel string, entry SemanticCacheEntry) error {
+	namespace := vc.namespace(organizationId, scope, embeddingModel)
+	indexKey := vc.indexKey(namespace)
+
+	if entry.Id == "" {
+		entry.Id = uuid.NewString()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	if err := vc.client.CacheData(vc.entryKey(namespace, entry.Id), entry, vc.config.TTL); err != nil {
+		return err
+	}
+
+	pipeline := vc.client.TxPipeline()
+	pipeline.ZAdd(ctx, indexKey, redis.Z{Score: float64(entry.CreatedAt.UnixMilli()), Member: entry.Id})
+	// * the index outlives its last entry by one TTL at most
+	pipeline.Expire(ctx, indexKey, vc.config.TTL)
+	entryCount := pipeline.ZCard(ctx, indexKey)
+	if _, err := pipeline.Exec(ctx); err != nil {
 		return err
 	}
-	key := vc.prefix + query
-	// Cache the embedding.
-	if err = vc.client.CacheData(key, string(data), ttl); err != nil {
+
+	excess := entryCount.Val() - vc.config.MaxEntriesPerOrganization
+	if excess <= 0 {
+		return nil
+	}
+
+	evicted, err := vc.client.ZPopMin(ctx, indexKey, excess).Result()
+	if err != nil {
 		return err
 	}
-	// Also add the query to an index set so we can later iterate over all embeddings.
-	ctx := context.Background()
-	return vc.client.Client.SAdd(ctx, vc.indexKey, query).Err()
+
+	evictedKeys := make([]string, 0, len(evicted))
+	for _, member := range evicted {
+		evictedKeys = append(evictedKeys, vc.entryKey(namespace, member.Member.(string)))
+	}
+
+	return vc.client.Del(ctx, evictedKeys...).Err()
+}
+
+// Clear removes all the entries of the organization for the scope, e.g. when the data the answers are based on changed
+func (vc *RedisVectorCache) Clear(ctx context.Context, organizationId uuid.UUID, scope string) error {
+	var cursor uint64
+	pattern := vc.prefix + organizationId.String() + ":" + scope + ":*"
+	for {
+		keys, nextCursor, err := vc.client.Scan(ctx, cursor, pattern, 100).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if err := vc.client.Del(ctx, keys...).Err(); err != nil {
+				return err
+			}
+		}
+		if nextCursor == 0 {
+			return nil
+		}
+		cursor = nextCursor
+	}
 }
@@ -0,0 +1,480 @@
+package business_account_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	"github.com/wapikit/wapikit/utils"
+)
+
+// ! https://developers.facebook.com/docs/whatsapp/messaging-limits
+// * the quality rating, the messaging limit tier and the status of the display name of a phone number decide how many
+// * contacts it can message, whatsapp tells their changes with the account webhooks and every change is kept as a health event
+
+const (
+	whatsAppGraphApiUrl        = "https://graph.facebook.com/v21.0"
+	phoneNumberHealthFields    = "id,display_phone_number,verified_name,quality_rating,messaging_limit_tier,name_status,code_verification_status,platform_type"
+	PhoneNumberHealthEventsMax = 50
+)
+
+var (
+	phoneNumberQualityRatings = map[string]model.PhoneNumberQualityRatingEnum{
+		"GREEN":   model.PhoneNumberQualityRatingEnum_Green,
+		"YELLOW":  model.PhoneNumberQualityRatingEnum_Yellow,
+		"RED":     model.PhoneNumberQualityRatingEnum_Red,
+		"UNKNOWN": model.PhoneNumberQualityRatingEnum_Unknown,
+	}
+	// * ordered from the lowest to the highest tier
+	phoneNumberMessagingLimitTiers = []struct {
+		whatsAppValue string
+		tier          model.PhoneNumberMessagingLimitTierEnum
+	}{
+		{"TIER_50", model.PhoneNumberMessagingLimitTierEnum_Tier50},
+		{"TIER_250", model.PhoneNumberMessagingLimitTierEnum_Tier250},
+		{"TIER_1K", model.PhoneNumberMessagingLimitTierEnum_Tier1K},
+		{"TIER_2K", model.PhoneNumberMessagingLimitTierEnum_Tier2K},
+		{"TIER_10K", model.PhoneNumberMessagingLimitTierEnum_Tier10K},
+		{"TIER_100K", model.PhoneNumberMessagingLimitTierEnum_Tier100K},
+		{"TIER_UNLIMITED", model.PhoneNumberMessagingLimitTierEnum_TierUnlimited},
+	}
+	phoneNumberNameStatuses = map[string]model.PhoneNumberNameStatusEnum{
+		"APPROVED":                 model.PhoneNumberNameStatusEnum_Approved,
+		"AVAILABLE_WITHOUT_REVIEW": model.PhoneNumberNameStatusEnum_AvailableWithoutReview,
+		"PENDING_REVIEW":           model.PhoneNumberNameStatusEnum_PendingReview,
+		"DECLINED":                 model.PhoneNumberNameStatusEnum_Declined,
+		"EXPIRED":                  model.PhoneNumberNameStatusEnum_Expired,
+		"NONE":                     model.PhoneNumberNameStatusEnum_None,
+		// * the decisions of the display name webhook
+		"PENDING":  model.PhoneNumberNameStatusEnum_PendingReview,
+		"DEFERRED": model.PhoneNumberNameStatusEnum_PendingReview,
+		"REJECTED": model.PhoneNumberNameStatusEnum_Declined,
+	}
+)
+
+// ParsePhoneNumberQualityRating maps a quality rating of whatsapp, e.g. YELLOW, to the quality rating of the local table
+func ParsePhoneNumberQualityRating(qualityRating string) model.PhoneNumberQualityRatingEnum {
+	if parsedQualityRating, ok := phoneNumberQualityRatings[strings.ToUpper(strings.TrimSpace(qualityRating))]; ok {
+		return parsedQualityRating
+	}
+	return model.PhoneNumberQualityRatingEnum_Unknown
+}
+
+// ParsePhoneNumberMessagingLimitTier maps a messaging limit tier of whatsapp, e.g. TIER_1K, to the tier of the local table
+func ParsePhoneNumberMessagingLimitTier(tier string) model.PhoneNumberMessagingLimitTierEnum {
+	tier = strings.ToUpper(strings.TrimSpace(tier))
+	for _, messagingLimitTier := range phoneNumberMessagingLimitTiers {
+		if messagingLimitTier.whatsAppValue == tier {
+			return messagingLimitTier.tier
+		}
+	}
+	return model.PhoneNumberMessagingLimitTierEnum_Unknown
+}
+
+// ParsePhoneNumberNameStatus maps a display name status or a display name decision of whatsapp to the name status of the local table
+func ParsePhoneNumberNameStatus(nameStatus string) model.PhoneNumberNameStatusEnum {
+	if parsedNameStatus, ok := phoneNumberNameStatuses[strings.ToUpper(strings.TrimSpace(nameStatus))]; ok {
+		return parsedNameStatus
+	}
+	return model.PhoneNumberNameStatusEnum_Unknown
+}
+
+func qualityRatingRank(qualityRating model.PhoneNumberQualityRatingEnum) int {
+	switch qualityRating {
+	case model.PhoneNumberQualityRatingEnum_Red:
+		return 1
+	case model.PhoneNumberQualityRatingEnum_Yellow:
+		return 2
+	default:
+		// * a phone number whatsapp did not rate yet is in good standing
+		return 3
+	}
+}
+
+func messagingLimitTierRank(tier model.PhoneNumberMessagingLimitTierEnum) int {
+	for index, messagingLimitTier := range phoneNumberMessagingLimitTiers {
+		if messagingLimitTier.tier == tier {
+			return index
+		}
+	}
+	return -1
+}
+
+// PhoneNumberHealth is the health of a phone number, the nil values are left unchanged on an update
+type PhoneNumberHealth struct {
+	QualityRating      *model.PhoneNumberQualityRatingEnum
+	MessagingLimitTier *model.PhoneNumberMessagingLimitTierEnum
+	NameStatus         *model.PhoneNumberNameStatusEnum
+	VerifiedName       *string
+	// Description is the reason whatsapp gave for the change, it is kept along with the health events
+	Description *string
+}
+
+// WhatsAppPhoneNumber is a phone number as the graph API returns it
+type WhatsAppPhoneNumber struct {
+	Id                     string `json:"id"`
+	DisplayPhoneNumber     string `json:"display_phone_number"`
+	VerifiedName           string `json:"verified_name"`
+	QualityRating          string `json:"quality_rating"`
+	MessagingLimitTier     string `json:"messaging_limit_tier"`
+	NameStatus             string `json:"name_status"`
+	CodeVerificationStatus string `json:"code_verification_status"`
+	PlatformType           string `json:"platform_type"`
+}
+
+// Health returns the health whatsapp reported for the phone number
+func (phoneNumber WhatsAppPhoneNumber) Health() PhoneNumberHealth {
+	health := PhoneNumberHealth{}
+
+	if phoneNumber.QualityRating != "" {
+		qualityRating := ParsePhoneNumberQualityRating(phoneNumber.QualityRating)
+		health.QualityRating = &qualityRating
+	}
+	if phoneNumber.MessagingLimitTier != "" {
+		messagingLimitTier := ParsePhoneNumberMessagingLimitTier(phoneNumber.MessagingLimitTier)
+		health.MessagingLimitTier = &messagingLimitTier
+	}
+	if phoneNumber.NameStatus != "" {
+		nameStatus := ParsePhoneNumberNameStatus(phoneNumber.NameStatus)
+		health.NameStatus = &nameStatus
+	}
+	if phoneNumber.VerifiedName != "" {
+		health.VerifiedName = &phoneNumber.VerifiedName
+	}
+
+	return health
+}
+
+// FetchWhatsAppPhoneNumber fetches the phone number along with its health from whatsapp
+func (service *BusinessAccountService) FetchWhatsAppPhoneNumber(ctx context.Context, businessAccount model.WhatsappBusinessAccount, phoneNumberId string) (*WhatsAppPhoneNumber, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s?fields=%s", whatsAppGraphApiUrl, url.PathEscape(phoneNumberId), url.QueryEscape(phoneNumberHealthFields)), nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Authorization", "Bearer "+businessAccount.AccessToken)
+
+	response, err := service.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		return nil, fmt.Errorf("whatsapp answered with status %d: %s", response.StatusCode, string(body))
+	}
+
+	var phoneNumber WhatsAppPhoneNumber
+	if err := json.NewDecoder(response.Body).Decode(&phoneNumber); err != nil {
+		return nil, err
+	}
+
+	return &phoneNumber, nil
+}
+
+// FindPhoneNumberByDisplayPhoneNumber returns the phone number of the business account with the given display phone number, the
+// health webhooks only tell the display phone number, the phone numbers are synced once if none matches
+func (service *BusinessAccountService) FindPhoneNumberByDisplayPhoneNumber(ctx context.Context, businessAccount model.WhatsappBusinessAccount, displayPhoneNumber string) (*PhoneNumberWithBusinessAccount, error) {
+	digits := phoneNumberDigits(displayPhoneNumber)
+	if digits == "" {
+		return nil, ErrPhoneNumberNotFound
+	}
+
+	findPhoneNumber := func() (*PhoneNumberWithBusinessAccount, error) {
+		var phoneNumbers []model.WhatsappBusinessAccountPhoneNumber
+		err := SELECT(table.WhatsappBusinessAccountPhoneNumber.AllColumns).
+			FROM(table.WhatsappBusinessAccountPhoneNumber).
+			WHERE(table.WhatsappBusinessAccountPhoneNumber.WhatsappBusinessAccountId.EQ(UUID(businessAccount.UniqueId))).
+			QueryContext(ctx, service.Db, &phoneNumbers)
+
+		if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+			return nil, err
+		}
+
+		// * whatsapp formats the display phone number, e.g. +1 555-010-0000, only its digits are compared
+		for _, phoneNumber := range phoneNumbers {
+			if phoneNumber.DisplayPhoneNumber != nil && phoneNumberDigits(*phoneNumber.DisplayPhoneNumber) == digits {
+				return &PhoneNumberWithBusinessAccount{
+					WhatsappBusinessAccountPhoneNumber: phoneNumber,
+					WhatsappBusinessAccount:            businessAccount,
+				}, nil
+			}
+		}
+
+		return nil, ErrPhoneNumberNotFound
+	}
+
+	phoneNumber, err := findPhoneNumber()
+	if !errors.Is(err, ErrPhoneNumberNotFound) {
+		return phoneNumber, err
+	}
+
+	if _, err := service.SyncPhoneNumbers(ctx, businessAccount); err != nil {
+		return nil, err
+	}
+
+	return findPhoneNumber()
+}
+
+func phoneNumberDigits(phoneNumber string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phoneNumber)
+}
+
+// UpdatePhoneNumberHealth stores the health of the phone number and returns a health event for every value that changed, the
+// first health stored for a phone number is its baseline and only records the downgrades, e.g. a quality rating already red
+func (service *BusinessAccountService) UpdatePhoneNumberHealth(ctx context.Context, phoneNumber PhoneNumberWithBusinessAccount, health PhoneNumberHealth) ([]model.PhoneNumberHealthEvent, error) {
+	current := phoneNumber.WhatsappBusinessAccountPhoneNumber
+	isBaseline := current.HealthUpdatedAt == nil
+	now := time.Now()
+
+	updated := current
+	updated.UpdatedAt = now
+	updated.HealthUpdatedAt = &now
+
+	eventsToRecord := []model.PhoneNumberHealthEvent{}
+	newEvent := func(eventType model.PhoneNumberHealthEventTypeEnum, previousValue, newValue string, isDowngrade bool) model.PhoneNumberHealthEvent {
+		return model.PhoneNumberHealthEvent{
+			CreatedAt:                 now,
+			OrganizationId:            current.OrganizationId,
+			WhatsappBusinessAccountId: current.WhatsappBusinessAccountId,
+			PhoneNumberId:             &current.UniqueId,
+			Type:                      eventType,
+			PreviousValue:             &previousValue,
+			NewValue:                  &newValue,
+			Description:               health.Description,
+			IsDowngrade:               isDowngrade,
+		}
+	}
+
+	if health.QualityRating != nil && *health.QualityRating != current.QualityRating {
+		updated.QualityRating = *health.QualityRating
+		isDowngrade := *health.QualityRating != model.PhoneNumberQualityRatingEnum_Unknown && qualityRatingRank(*health.QualityRating) < qualityRatingRank(current.QualityRating)
+		eventsToRecord = append(eventsToRecord, newEvent(model.PhoneNumberHealthEventTypeEnum_QualityRating, current.QualityRating.String(), health.QualityRating.String(), isDowngrade))
+	}
+
+	if health.MessagingLimitTier != nil && *health.MessagingLimitTier != current.MessagingLimitTier {
+		updated.MessagingLimitTier = *health.MessagingLimitTier
+		isDowngrade := messagingLimitTierRank(*health.MessagingLimitTier) >= 0 && messagingLimitTierRank(*health.MessagingLimitTier) < messagingLimitTierRank(current.MessagingLimitTier)
+		eventsToRecord = append(eventsToRecord, newEvent(model.PhoneNumberHealthEventTypeEnum_MessagingLimitTier, current.MessagingLimitTier.String(), health.MessagingLimitTier.String(), isDowngrade))
+	}
+
+	if health.NameStatus != nil && *health.NameStatus != current.NameStatus {
+		updated.NameStatus = *health.NameStatus
+		isDowngrade := *health.NameStatus == model.PhoneNumberNameStatusEnum_Declined || *health.NameStatus == model.PhoneNumberNameStatusEnum_Expired
+		eventsToRecord = append(eventsToRecord, newEvent(model.PhoneNumberHealthEventTypeEnum_NameStatus, current.NameStatus.String(), health.NameStatus.String(), isDowngrade))
+	}
+
+	if health.VerifiedName != nil && *health.VerifiedName != "" {
+		updated.VerifiedName = health.VerifiedName
+	}
+
+	_, err := table.WhatsappBusinessAccountPhoneNumber.
+		UPDATE(
+			table.WhatsappBusinessAccountPhoneNumber.QualityRating,
+			table.WhatsappBusinessAccountPhoneNumber.MessagingLimitTier,
+			table.WhatsappBusinessAccountPhoneNumber.NameStatus,
+			table.WhatsappBusinessAccountPhoneNumber.VerifiedName,
+			table.WhatsappBusinessAccountPhoneNumber.HealthUpdatedAt,
+			table.WhatsappBusinessAccountPhoneNumber.UpdatedAt,
+		).
+		MODEL(updated).
+		WHERE(table.WhatsappBusinessAccountPhoneNumber.UniqueId.EQ(UUID(current.UniqueId))).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// * the baseline is not a change, but a phone number first seen flagged or with a declined name is still a downgrade
+	if isBaseline {
+		eventsToRecord = slices.DeleteFunc(eventsToRecord, func(event model.PhoneNumberHealthEvent) bool {
+			return !event.IsDowngrade
+		})
+	}
+
+	if len(eventsToRecord) == 0 {
+		return []model.PhoneNumberHealthEvent{}, nil
+	}
+
+	return service.insertPhoneNumberHealthEvents(ctx, eventsToRecord)
+}
+
+// RecordAccountHealthEvent records an alert or an update of the business account, along with the phone number it is about if any
+func (service *BusinessAccountService) RecordAccountHealthEvent(ctx context.Context, businessAccount model.WhatsappBusinessAccount, phoneNumber *model.WhatsappBusinessAccountPhoneNumber, eventType model.PhoneNumberHealthEventTypeEnum, newValue string, description *string, isDowngrade bool) (*model.PhoneNumberHealthEvent, error) {
+	event := model.PhoneNumberHealthEvent{
+		CreatedAt:                 time.Now(),
+		OrganizationId:            businessAccount.OrganizationId,
+		WhatsappBusinessAccountId: businessAccount.UniqueId,
+		Type:                      eventType,
+		NewValue:                  &newValue,
+		Description:               description,
+		IsDowngrade:               isDowngrade,
+	}
+	if phoneNumber != nil {
+		event.PhoneNumberId = &phoneNumber.UniqueId
+	}
+
+	insertedEvents, err := service.insertPhoneNumberHealthEvents(ctx, []model.PhoneNumberHealthEvent{event})
+	if err != nil {
+		return nil, err
+	}
+
+	return &insertedEvents[0], nil
+}
+
+func (service *BusinessAccountService) insertPhoneNumberHealthEvents(ctx context.Context, events []model.PhoneNumberHealthEvent) ([]model.PhoneNumberHealthEvent, error) {
+	insertedEvents := []model.PhoneNumberHealthEvent{}
+
+	err := table.PhoneNumberHealthEvent.
+		INSERT(table.PhoneNumberHealthEvent.MutableColumns).
+		MODELS(events).
+		RETURNING(table.PhoneNumberHealthEvent.AllColumns).
+		QueryContext(ctx, service.Db, &insertedEvents)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return insertedEvents, nil
+}
+
+// ListPhoneNumberHealthEvents returns the latest health events of the phone number, along with the ones of its business account
+func (service *BusinessAccountService) ListPhoneNumberHealthEvents(ctx context.Context, phoneNumber model.WhatsappBusinessAccountPhoneNumber) ([]model.PhoneNumberHealthEvent, error) {
+	events := []model.PhoneNumberHealthEvent{}
+
+	err := SELECT(table.PhoneNumberHealthEvent.AllColumns).
+		FROM(table.PhoneNumberHealthEvent).
+		WHERE(
+			table.PhoneNumberHealthEvent.PhoneNumberId.EQ(UUID(phoneNumber.UniqueId)).
+				OR(
+					table.PhoneNumberHealthEvent.WhatsappBusinessAccountId.EQ(UUID(phoneNumber.WhatsappBusinessAccountId)).
+						AND(table.PhoneNumberHealthEvent.PhoneNumberId.IS_NULL()),
+				),
+		).
+		ORDER_BY(table.PhoneNumberHealthEvent.CreatedAt.DESC()).
+		LIMIT(PhoneNumberHealthEventsMax).
+		QueryContext(ctx, service.Db, &events)
+
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetPhoneNumberHealthConfiguration returns how the organization is told about the downgrades of its phone numbers
+func (service *BusinessAccountService) GetPhoneNumberHealthConfiguration(ctx context.Context, organizationId uuid.UUID) (*model.PhoneNumberHealthConfiguration, error) {
+	var configuration model.PhoneNumberHealthConfiguration
+	err := SELECT(table.PhoneNumberHealthConfiguration.AllColumns).
+		FROM(table.PhoneNumberHealthConfiguration).
+		WHERE(table.PhoneNumberHealthConfiguration.OrganizationId.EQ(UUID(organizationId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &configuration)
+
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return &model.PhoneNumberHealthConfiguration{
+				OrganizationId:             organizationId,
+				IsCampaignAutoPauseEnabled: false,
+				IsEmailNotificationEnabled: true,
+				IsSlackNotificationEnabled: true,
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &configuration, nil
+}
+
+// UpdatePhoneNumberHealthConfiguration creates or updates the phone number health configuration of the organization
+func (service *BusinessAccountService) UpdatePhoneNumberHealthConfiguration(ctx context.Context, organizationId uuid.UUID, payload api_types.UpdatePhoneNumberHealthConfigurationSchema) (*model.PhoneNumberHealthConfiguration, error) {
+	configuration, err := service.GetPhoneNumberHealthConfiguration(ctx, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	configuration.IsCampaignAutoPauseEnabled = payload.IsCampaignAutoPauseEnabled
+	if payload.IsEmailNotificationEnabled != nil {
+		configuration.IsEmailNotificationEnabled = *payload.IsEmailNotificationEnabled
+	}
+	if payload.IsSlackNotificationEnabled != nil {
+		configuration.IsSlackNotificationEnabled = *payload.IsSlackNotificationEnabled
+	}
+	configuration.UpdatedAt = time.Now()
+
+	var updatedConfiguration model.PhoneNumberHealthConfiguration
+	err = table.PhoneNumberHealthConfiguration.
+		INSERT(
+			table.PhoneNumberHealthConfiguration.UpdatedAt,
+			table.PhoneNumberHealthConfiguration.OrganizationId,
+			table.PhoneNumberHealthConfiguration.IsCampaignAutoPauseEnabled,
+			table.PhoneNumberHealthConfiguration.IsEmailNotificationEnabled,
+			table.PhoneNumberHealthConfiguration.IsSlackNotificationEnabled,
+		).
+		MODEL(configuration).
+		ON_CONFLICT(table.PhoneNumberHealthConfiguration.OrganizationId).
+		DO_UPDATE(
+			SET(
+				table.PhoneNumberHealthConfiguration.IsCampaignAutoPauseEnabled.SET(table.PhoneNumberHealthConfiguration.EXCLUDED.IsCampaignAutoPauseEnabled),
+				table.PhoneNumberHealthConfiguration.IsEmailNotificationEnabled.SET(table.PhoneNumberHealthConfiguration.EXCLUDED.IsEmailNotificationEnabled),
+				table.PhoneNumberHealthConfiguration.IsSlackNotificationEnabled.SET(table.PhoneNumberHealthConfiguration.EXCLUDED.IsSlackNotificationEnabled),
+				table.PhoneNumberHealthConfiguration.UpdatedAt.SET(table.PhoneNumberHealthConfiguration.EXCLUDED.UpdatedAt),
+			),
+		).
+		RETURNING(table.PhoneNumberHealthConfiguration.AllColumns).
+		QueryContext(ctx, service.Db, &updatedConfiguration)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedConfiguration, nil
+}
+
+func ParsePhoneNumberHealthConfigurationToApiSchema(configuration model.PhoneNumberHealthConfiguration) api_types.PhoneNumberHealthConfigurationSchema {
+	return api_types.PhoneNumberHealthConfigurationSchema{
+		IsCampaignAutoPauseEnabled: configuration.IsCampaignAutoPauseEnabled,
+		IsEmailNotificationEnabled: configuration.IsEmailNotificationEnabled,
+		IsSlackNotificationEnabled: configuration.IsSlackNotificationEnabled,
+	}
+}
+
+// PauseRunningCampaigns pauses the running campaigns sent from the phone number and returns them, the caller stops them in the campaign manager
+func (service *BusinessAccountService) PauseRunningCampaigns(ctx context.Context, phoneNumber model.WhatsappBusinessAccountPhoneNumber) ([]model.Campaign, error) {
+	pausedCampaigns := []model.Campaign{}
+
+	err := table.Campaign.
+		UPDATE(table.Campaign.Status, table.Campaign.UpdatedAt).
+		SET(utils.EnumExpression(model.CampaignStatusEnum_Paused.String()), time.Now()).
+		WHERE(
+			table.Campaign.OrganizationId.EQ(UUID(phoneNumber.OrganizationId)).
+				AND(table.Campaign.PhoneNumber.EQ(String(phoneNumber.PhoneNumberId))).
+				AND(table.Campaign.Status.EQ(utils.EnumExpression(model.CampaignStatusEnum_Running.String()))),
+		).
+		RETURNING(table.Campaign.AllColumns).
+		QueryContext(ctx, service.Db, &pausedCampaigns)
+
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, err
+	}
+
+	return pausedCampaigns, nil
+}
@@ -0,0 +1,303 @@
+package business_account_service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	wapi "github.com/wapikit/wapi.go/pkg/client"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+)
+
+var (
+	ErrPhoneNumberNotFound     = errors.New("phone number not found in the business accounts of the organization")
+	ErrBusinessAccountNotFound = errors.New("no whatsapp business account is configured for the organization")
+)
+
+// BusinessAccountService resolves which whatsapp business account, and so which access token, serves a phone number,
+// an organization can have multiple business accounts and every business account can have multiple phone numbers
+type BusinessAccountService struct {
+	Logger     *slog.Logger
+	Db         *sql.DB
+	httpClient *http.Client
+}
+
+func NewBusinessAccountService(db *sql.DB, logger *slog.Logger) *BusinessAccountService {
+	return &BusinessAccountService{
+		Logger:     logger,
+		Db:         db,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type PhoneNumberWithBusinessAccount struct {
+	model.WhatsappBusinessAccountPhoneNumber
+	WhatsappBusinessAccount model.WhatsappBusinessAccount
+}
+
+// NewClient creates a whatsapp cloud API client authorized with the access token of the business account
+func (service *BusinessAccountService) NewClient(businessAccount model.WhatsappBusinessAccount) *wapi.Client {
+	return wapi.New(&wapi.ClientConfig{
+		BusinessAccountId: businessAccount.AccountId,
+		ApiAccessToken:    businessAccount.AccessToken,
+		WebhookSecret:     businessAccount.WebhookSecret,
+	})
+}
+
+// GetPhoneNumber returns the phone number along with the business account it belongs to
+func (service *BusinessAccountService) GetPhoneNumber(ctx context.Context, organizationId uuid.UUID, phoneNumberId string) (*PhoneNumberWithBusinessAccount, error) {
+	var phoneNumber PhoneNumberWithBusinessAccount
+
+	err := SELECT(
+		table.WhatsappBusinessAccountPhoneNumber.AllColumns,
+		table.WhatsappBusinessAccount.AllColumns,
+	).
+		FROM(
+			table.WhatsappBusinessAccountPhoneNumber.
+				LEFT_JOIN(table.WhatsappBusinessAccount, table.WhatsappBusinessAccount.UniqueId.EQ(table.WhatsappBusinessAccountPhoneNumber.WhatsappBusinessAccountId)),
+		).
+		WHERE(
+			table.WhatsappBusinessAccountPhoneNumber.PhoneNumberId.EQ(String(phoneNumberId)).
+				AND(table.WhatsappBusinessAccountPhoneNumber.OrganizationId.EQ(UUID(organizationId))),
+		).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &phoneNumber)
+
+	// * the phone numbers are only registered by the sync with whatsapp and the webhook events, an unknown id is never trusted
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, ErrPhoneNumberNotFound
+		}
+		return nil, err
+	}
+
+	return &phoneNumber, nil
+}
+
+// GetDefaultPhoneNumber returns the default phone number of the organization, or the oldest one when none is marked as default
+func (service *BusinessAccountService) GetDefaultPhoneNumber(ctx context.Context, organizationId uuid.UUID) (*PhoneNumberWithBusinessAccount, error) {
+	var phoneNumber PhoneNumberWithBusinessAccount
+
+	err := SELECT(
+		table.WhatsappBusinessAccountPhoneNumber.AllColumns,
+		table.WhatsappBusinessAccount.AllColumns,
+	).
+		FROM(
+			table.WhatsappBusinessAccountPhoneNumber.
+				LEFT_JOIN(table.WhatsappBusinessAccount, table.WhatsappBusinessAccount.UniqueId.EQ(table.WhatsappBusinessAccountPhoneNumber.WhatsappBusinessAccountId)),
+		).
+		WHERE(table.WhatsappBusinessAccountPhoneNumber.OrganizationId.EQ(UUID(organizationId))).
+		ORDER_BY(
+			table.WhatsappBusinessAccountPhoneNumber.IsDefault.DESC(),
+			table.WhatsappBusinessAccountPhoneNumber.CreatedAt.ASC(),
+		).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &phoneNumber)
+
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, ErrPhoneNumberNotFound
+		}
+		return nil, err
+	}
+
+	return &phoneNumber, nil
+}
+
+// ListPhoneNumbers returns all the phone numbers of the organization across its business accounts
+func (service *BusinessAccountService) ListPhoneNumbers(ctx context.Context, organizationId uuid.UUID) ([]PhoneNumberWithBusinessAccount, error) {
+	phoneNumbers := []PhoneNumberWithBusinessAccount{}
+
+	err := SELECT(
+		table.WhatsappBusinessAccountPhoneNumber.AllColumns,
+		table.WhatsappBusinessAccount.AllColumns,
+	).
+		FROM(
+			table.WhatsappBusinessAccountPhoneNumber.
+				LEFT_JOIN(table.WhatsappBusinessAccount, table.WhatsappBusinessAccount.UniqueId.EQ(table.WhatsappBusinessAccountPhoneNumber.WhatsappBusinessAccountId)),
+		).
+		WHERE(table.WhatsappBusinessAccountPhoneNumber.OrganizationId.EQ(UUID(organizationId))).
+		ORDER_BY(table.WhatsappBusinessAccountPhoneNumber.CreatedAt.ASC()).
+		QueryContext(ctx, service.Db, &phoneNumbers)
+
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, err
+	}
+
+	return phoneNumbers, nil
+}
+
+// ListBusinessAccounts returns the business accounts of the organization, the oldest first
+func (service *BusinessAccountService) ListBusinessAccounts(ctx context.Context, organizationId uuid.UUID) ([]model.WhatsappBusinessAccount, error) {
+	businessAccounts := []model.WhatsappBusinessAccount{}
+
+	err := SELECT(table.WhatsappBusinessAccount.AllColumns).
+		FROM(table.WhatsappBusinessAccount).
+		WHERE(table.WhatsappBusinessAccount.OrganizationId.EQ(UUID(organizationId))).
+		ORDER_BY(table.WhatsappBusinessAccount.CreatedAt.ASC()).
+		QueryContext(ctx, service.Db, &businessAccounts)
+
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, err
+	}
+
+	return businessAccounts, nil
+}
+
+// RegisterPhoneNumber links a phone number to a business account, the details are updated if the phone number is already registered
+func (service *BusinessAccountService) RegisterPhoneNumber(ctx context.Context, businessAccount model.WhatsappBusinessAccount, phoneNumberId string, displayPhoneNumber, verifiedName *string) (*PhoneNumberWithBusinessAccount, error) {
+	var registeredPhoneNumber model.WhatsappBusinessAccountPhoneNumber
+
+	// * the first phone number of the organization becomes its default phone number
+	isDefault := NOT(EXISTS(
+		SELECT(Int(1)).
+			FROM(table.WhatsappBusinessAccountPhoneNumber).
+			WHERE(table.WhatsappBusinessAccountPhoneNumber.OrganizationId.EQ(UUID(businessAccount.OrganizationId))),
+	))
+
+	err := table.WhatsappBusinessAccountPhoneNumber.
+		INSERT(
+			table.WhatsappBusinessAccountPhoneNumber.UpdatedAt,
+			table.WhatsappBusinessAccountPhoneNumber.OrganizationId,
+			table.WhatsappBusinessAccountPhoneNumber.WhatsappBusinessAccountId,
+			table.WhatsappBusinessAccountPhoneNumber.PhoneNumberId,
+			table.WhatsappBusinessAccountPhoneNumber.DisplayPhoneNumber,
+			table.WhatsappBusinessAccountPhoneNumber.VerifiedName,
+			table.WhatsappBusinessAccountPhoneNumber.IsDefault,
+		).
+		VALUES(
+			time.Now(),
+			UUID(businessAccount.OrganizationId),
+			UUID(businessAccount.UniqueId),
+			phoneNumberId,
+			nullableString(displayPhoneNumber),
+			nullableString(verifiedName),
+			isDefault,
+		).
+		ON_CONFLICT(table.WhatsappBusinessAccountPhoneNumber.PhoneNumberId).
+		DO_UPDATE(
+			SET(
+				table.WhatsappBusinessAccountPhoneNumber.DisplayPhoneNumber.SET(
+					StringExp(COALESCE(table.WhatsappBusinessAccountPhoneNumber.EXCLUDED.DisplayPhoneNumber, table.WhatsappBusinessAccountPhoneNumber.DisplayPhoneNumber)),
+				),
+				table.WhatsappBusinessAccountPhoneNumber.VerifiedName.SET(
+					StringExp(COALESCE(table.WhatsappBusinessAccountPhoneNumber.EXCLUDED.VerifiedName, table.WhatsappBusinessAccountPhoneNumber.VerifiedName)),
+				),
+				// * the phone number may have been moved to another business account of the organization
+				table.WhatsappBusinessAccountPhoneNumber.WhatsappBusinessAccountId.SET(table.WhatsappBusinessAccountPhoneNumber.EXCLUDED.WhatsappBusinessAccountId),
+				table.WhatsappBusinessAccountPhoneNumber.UpdatedAt.SET(table.WhatsappBusinessAccountPhoneNumber.EXCLUDED.UpdatedAt),
+			).
+				// * a phone number can not be taken over by the business account of another organization
+				WHERE(table.WhatsappBusinessAccountPhoneNumber.OrganizationId.EQ(UUID(businessAccount.OrganizationId))),
+		).
+		RETURNING(table.WhatsappBusinessAccountPhoneNumber.AllColumns).
+		QueryContext(ctx, service.Db, &registeredPhoneNumber)
+
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, ErrPhoneNumberNotFound
+		}
+		return nil, err
+	}
+
+	return &PhoneNumberWithBusinessAccount{
+		WhatsappBusinessAccountPhoneNumber: registeredPhoneNumber,
+		WhatsappBusinessAccount:            businessAccount,
+	}, nil
+}
+
+// EnsurePhoneNumber registers the phone number a webhook event has been received on, if it is not known yet
+func (service *BusinessAccountService) EnsurePhoneNumber(ctx context.Context, businessAccountId, phoneNumberId, displayPhoneNumber string) error {
+	if phoneNumberId == "" {
+		return nil
+	}
+
+	var existingPhoneNumber model.WhatsappBusinessAccountPhoneNumber
+	err := SELECT(table.WhatsappBusinessAccountPhoneNumber.AllColumns).
+		FROM(table.WhatsappBusinessAccountPhoneNumber).
+		WHERE(table.WhatsappBusinessAccountPhoneNumber.PhoneNumberId.EQ(String(phoneNumberId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &existingPhoneNumber)
+
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, qrm.ErrNoRows) {
+		return err
+	}
+
+	var businessAccount model.WhatsappBusinessAccount
+	err = SELECT(table.WhatsappBusinessAccount.AllColumns).
+		FROM(table.WhatsappBusinessAccount).
+		WHERE(table.WhatsappBusinessAccount.AccountId.EQ(String(businessAccountId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &businessAccount)
+
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return ErrBusinessAccountNotFound
+		}
+		return err
+	}
+
+	_, err = service.RegisterPhoneNumber(ctx, businessAccount, phoneNumberId, &displayPhoneNumber, nil)
+	return err
+}
+
+// SyncPhoneNumbers fetches the phone numbers of the business account from whatsapp and registers them
+func (service *BusinessAccountService) SyncPhoneNumbers(ctx context.Context, businessAccount model.WhatsappBusinessAccount) ([]PhoneNumberWithBusinessAccount, error) {
+	phoneNumbersResponse, err := service.NewClient(businessAccount).Business.PhoneNumber.FetchAll(true)
+	if err != nil {
+		return nil, err
+	}
+
+	syncedPhoneNumbers := []PhoneNumberWithBusinessAccount{}
+	if phoneNumbersResponse == nil {
+		return syncedPhoneNumbers, nil
+	}
+
+	for _, phoneNumber := range phoneNumbersResponse.Data {
+		displayPhoneNumber := phoneNumber.DisplayPhoneNumber
+		verifiedName := phoneNumber.VerifiedName
+
+		registeredPhoneNumber, err := service.RegisterPhoneNumber(ctx, businessAccount, phoneNumber.Id, &displayPhoneNumber, &verifiedName)
+		if err != nil {
+			service.Logger.Error("error registering the phone number of the business account", "phone_number_id", phoneNumber.Id, "error", err.Error())
+			continue
+		}
+
+		syncedPhoneNumbers = append(syncedPhoneNumbers, *registeredPhoneNumber)
+	}
+
+	return syncedPhoneNumbers, nil
+}
+
+// SetDefaultPhoneNumber marks the phone number as the default one of the organization
+func (service *BusinessAccountService) SetDefaultPhoneNumber(ctx context.Context, organizationId uuid.UUID, phoneNumberId string) error {
+	if _, err := service.GetPhoneNumber(ctx, organizationId, phoneNumberId); err != nil {
+		return err
+	}
+
+	_, err := table.WhatsappBusinessAccountPhoneNumber.
+		UPDATE(table.WhatsappBusinessAccountPhoneNumber.IsDefault, table.WhatsappBusinessAccountPhoneNumber.UpdatedAt).
+		SET(table.WhatsappBusinessAccountPhoneNumber.PhoneNumberId.EQ(String(phoneNumberId)), time.Now()).
+		WHERE(table.WhatsappBusinessAccountPhoneNumber.OrganizationId.EQ(UUID(organizationId))).
+		ExecContext(ctx, service.Db)
+
+	return err
+}
+
+func nullableString(value *string) Expression {
+	if value == nil || *value == "" {
+		return NULL
+	}
+	return String(*value)
+}
@@ -0,0 +1,251 @@
+package chatbot_service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+)
+
+// maxWebhookResponseSize caps how much of the response of a webhook is read
+const maxWebhookResponseSize = 1 << 20
+
+// liveActions performs the steps of a flow for real, against whatsapp and the database
+type liveActions struct {
+	ctx          context.Context
+	service      *ChatbotService
+	conversation conversationWithContact
+}
+
+func (service *ChatbotService) newLiveActions(ctx context.Context, conversation conversationWithContact) *liveActions {
+	return &liveActions{
+		ctx:          ctx,
+		service:      service,
+		conversation: conversation,
+	}
+}
+
+func (actions *liveActions) sendMessage(text string, buttons []api_types.InteractiveMessageButtonSchema) error {
+	if len(buttons) == 0 {
+		_, err := actions.service.ConversationService.SendTextMessage(actions.ctx, actions.conversation.Conversation, actions.conversation.Contact.PhoneNumber, text)
+		return err
+	}
+
+	var messageData api_types.NewMessageDataSchema
+	err := messageData.FromInteractiveMessageData(api_types.InteractiveMessageData{
+		InteractiveType: api_types.ReplyButtons,
+		Body:            text,
+		Buttons:         &buttons,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = actions.service.ConversationService.SendMessage(actions.ctx, actions.conversation.Conversation, actions.conversation.Contact.PhoneNumber, messageData)
+	return err
+}
+
+func (actions *liveActions) setContactAttribute(key, value string) error {
+	var contact model.Contact
+	err := SELECT(table.Contact.Attributes).
+		FROM(table.Contact).
+		WHERE(table.Contact.UniqueId.EQ(UUID(actions.conversation.ContactId))).
+		LIMIT(1).
+		QueryContext(actions.ctx, actions.service.Db, &contact)
+
+	if err != nil {
+		return err
+	}
+
+	attributes := map[string]interface{}{}
+	if contact.Attributes != nil {
+		_ = json.Unmarshal([]byte(*contact.Attributes), &attributes)
+	}
+	attributes[key] = value
+
+	jsonAttributes, err := json.Marshal(attributes)
+	if err != nil {
+		return err
+	}
+	stringAttributes := string(jsonAttributes)
+
+	_, err = table.Contact.
+		UPDATE(table.Contact.Attributes, table.Contact.UpdatedAt).
+		MODEL(model.Contact{Attributes: &stringAttributes, UpdatedAt: time.Now()}).
+		WHERE(table.Contact.UniqueId.EQ(UUID(actions.conversation.ContactId))).
+		ExecContext(actions.ctx, actions.service.Db)
+
+	return err
+}
+
+func (actions *liveActions) addTag(tagId uuid.UUID) error {
+	_, err := table.ConversationTag.
+		INSERT(
+			table.ConversationTag.ConversationId,
+			table.ConversationTag.TagId,
+			table.ConversationTag.CreatedAt,
+			table.ConversationTag.UpdatedAt,
+		).
+		VALUES(actions.conversation.UniqueId, tagId, time.Now(), time.Now()).
+		ON_CONFLICT(table.ConversationTag.ConversationId, table.ConversationTag.TagId).
+		DO_NOTHING().
+		ExecContext(actions.ctx, actions.service.Db)
+
+	return err
+}
+
+func (actions *liveActions) assignTeam(roleId uuid.UUID) error {
+	_, err := actions.service.ConversationService.AssignConversationToTeam(actions.ctx, actions.conversation.OrganizationId, actions.conversation.UniqueId, roleId)
+	return err
+}
+
+func (actions *liveActions) callWebhook(url string, payload map[string]interface{}) (map[string]interface{}, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(actions.ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := actions.service.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, maxWebhookResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(responseBody) > maxWebhookResponseSize {
+		return nil, fmt.Errorf("webhook response is larger than %d bytes", maxWebhookResponseSize)
+	}
+
+	// * only a json object response is saved to the variables, anything else is a plain acknowledgement
+	result := map[string]interface{}{}
+	if len(bytes.TrimSpace(responseBody)) > 0 {
+		_ = json.Unmarshal(responseBody, &result)
+	}
+
+	return result, nil
+}
+
+// simulatedActions only records what the steps would do, nothing is sent to whatsapp, stored or called
+type simulatedActions struct{}
+
+func (simulatedActions) sendMessage(text string, buttons []api_types.InteractiveMessageButtonSchema) error {
+	return nil
+}
+
+func (simulatedActions) setContactAttribute(key, value string) error {
+	return nil
+}
+
+func (simulatedActions) addTag(tagId uuid.UUID) error {
+	return nil
+}
+
+func (simulatedActions) assignTeam(roleId uuid.UUID) error {
+	return nil
+}
+
+func (simulatedActions) callWebhook(url string, payload map[string]interface{}) (map[string]interface{}, error) {
+	return map[string]interface{}{}, nil
+}
+
+// Simulate runs the flow against the given messages of a test contact without talking to whatsapp,
+// the first message starts the flow and every next one answers the step the flow waits at
+func (service *ChatbotService) Simulate(definition api_types.ChatbotFlowDefinitionSchema, sessionTimeoutInMinutes int, payload api_types.SimulateChatbotFlowSchema) api_types.SimulateChatbotFlowResponseSchema {
+	contact := flowContact{
+		Name:        "Test contact",
+		PhoneNumber: "+15555550100",
+	}
+	if payload.ContactName != nil && *payload.ContactName != "" {
+		contact.Name = *payload.ContactName
+	}
+	if payload.ContactAttributes != nil {
+		contact.Attributes = *payload.ContactAttributes
+	}
+
+	timeout := time.Duration(sessionTimeoutInMinutes) * time.Minute
+	if timeout <= 0 {
+		timeout = time.Hour
+	}
+
+	events := []api_types.ChatbotFlowSimulationEventSchema{}
+	messageIndex := 0
+
+	run := newFlowRun(definition, contact, simulatedActions{}, timeout)
+	run.onStep = func(step api_types.ChatbotFlowStepSchema, description string, text *string, buttons *[]api_types.InteractiveMessageButtonSchema) {
+		events = append(events, api_types.ChatbotFlowSimulationEventSchema{
+			MessageIndex: messageIndex,
+			StepId:       step.Id,
+			StepType:     step.Type,
+			Description:  description,
+			Text:         text,
+			Buttons:      buttons,
+		})
+	}
+
+	var state *flowState
+	for index, message := range payload.Messages {
+		messageIndex = index
+		reply := flowReply{Text: stringValue(message.Text), ButtonId: stringValue(message.ButtonId)}
+		if message.ButtonTitle != nil {
+			reply.Text = *message.ButtonTitle
+		}
+
+		if state == nil {
+			state = run.start(definition.StartStepId, &reply)
+			continue
+		}
+
+		// * the flow is over, the remaining messages would go to the agents or start another flow
+		if !isWaitingForReply(state) {
+			break
+		}
+		run.resume(state, reply)
+	}
+
+	if state == nil {
+		state = &flowState{CurrentStepId: definition.StartStepId, Variables: map[string]string{}, Status: model.ChatbotFlowSessionStatusEnum_Waiting}
+	} else if payload.TimeoutLastWait != nil && *payload.TimeoutLastWait && isWaitingForReply(state) {
+		messageIndex = -1
+		run.timeout(state)
+	}
+
+	response := api_types.SimulateChatbotFlowResponseSchema{
+		Events:            events,
+		Variables:         state.Variables,
+		CurrentStepId:     state.CurrentStepId,
+		IsWaitingForReply: isWaitingForReply(state),
+		IsHandedOff:       state.Status == model.ChatbotFlowSessionStatusEnum_HandedOff,
+		IsFinished:        state.Status == model.ChatbotFlowSessionStatusEnum_Finished || state.Status == model.ChatbotFlowSessionStatusEnum_TimedOut,
+	}
+	if state.Error != "" {
+		response.Error = &state.Error
+	}
+
+	return response
+}
+
+func isWaitingForReply(state *flowState) bool {
+	return state.Status == model.ChatbotFlowSessionStatusEnum_Waiting && state.WaitTimeout > 0
+}
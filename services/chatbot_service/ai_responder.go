@@ -0,0 +1,424 @@
+package chatbot_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	"github.com/wapikit/wapikit/services/ai_service"
+	"github.com/wapikit/wapikit/services/conversation_service"
+)
+
+const (
+	AiAutoResponderDefaultMinConfidence = 70
+	AiAutoResponderDefaultMaxAiTurns    = 5
+	AiAutoResponderMaxAiTurns           = 50
+	aiAutoResponderMaxForbiddenTopics   = 50
+	aiAutoResponderMaxInstructionLength = 4000
+	aiAutoResponderHistoryLength        = 20
+	// * the reply must come back well within the session lock, so that the next message of the contact waits for it
+	aiAutoReplyTimeout = 20 * time.Second
+)
+
+// humanRequestPhrases are the phrases a contact asking for a person uses, the conversation is handed off without asking the model
+var humanRequestPhrases = []string{
+	"human",
+	"real person",
+	"talk to someone",
+	"speak to someone",
+	"talk to a person",
+	"speak to a person",
+	"agent",
+	"representative",
+	"customer care",
+	"customer service",
+	"call me",
+}
+
+func (service *ChatbotService) ParseAiAutoResponderConfigurationToApiSchema(configuration model.AiAutoResponderConfiguration) api_types.AiAutoResponderConfigurationSchema {
+	response := api_types.AiAutoResponderConfigurationSchema{
+		IsEnabled:       configuration.IsEnabled,
+		Instructions:    configuration.Instructions,
+		MinConfidence:   int(configuration.MinConfidence),
+		MaxAiTurns:      int(configuration.MaxAiTurns),
+		ForbiddenTopics: parseForbiddenTopics(configuration.ForbiddenTopics),
+		HandoffMessage:  configuration.HandoffMessage,
+	}
+
+	if configuration.HandoffOrganizationRoleId != nil {
+		roleId := configuration.HandoffOrganizationRoleId.String()
+		response.HandoffOrganizationRoleId = &roleId
+	}
+
+	return response
+}
+
+// GetAiAutoResponderConfiguration returns the AI auto responder configuration of the organization, a disabled default one if the organization never configured it
+func (service *ChatbotService) GetAiAutoResponderConfiguration(ctx context.Context, organizationId uuid.UUID) (*model.AiAutoResponderConfiguration, error) {
+	var configuration model.AiAutoResponderConfiguration
+	err := SELECT(table.AiAutoResponderConfiguration.AllColumns).
+		FROM(table.AiAutoResponderConfiguration).
+		WHERE(table.AiAutoResponderConfiguration.OrganizationId.EQ(UUID(organizationId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &configuration)
+
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return &model.AiAutoResponderConfiguration{
+				OrganizationId: organizationId,
+				IsEnabled:      false,
+				MinConfidence:  AiAutoResponderDefaultMinConfidence,
+				MaxAiTurns:     AiAutoResponderDefaultMaxAiTurns,
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &configuration, nil
+}
+
+// UpdateAiAutoResponderConfiguration creates or updates the AI auto responder configuration of the organization,
+// the auto responder can only be enabled with a team to hand the conversations off to
+func (service *ChatbotService) UpdateAiAutoResponderConfiguration(ctx context.Context, organizationId uuid.UUID, payload api_types.UpdateAiAutoResponderConfigurationSchema) (*model.AiAutoResponderConfiguration, error) {
+	if payload.MinConfidence < 0 || payload.MinConfidence > 100 {
+		return nil, fmt.Errorf("minimum confidence must be between 0 and 100")
+	}
+	if payload.MaxAiTurns < 1 || payload.MaxAiTurns > AiAutoResponderMaxAiTurns {
+		return nil, fmt.Errorf("maximum AI turns must be between 1 and %d", AiAutoResponderMaxAiTurns)
+	}
+
+	instructions := trimmedOrNil(payload.Instructions)
+	if instructions != nil && len(*instructions) > aiAutoResponderMaxInstructionLength {
+		return nil, fmt.Errorf("instructions must be at most %d characters", aiAutoResponderMaxInstructionLength)
+	}
+
+	forbiddenTopics := []string{}
+	if payload.ForbiddenTopics != nil {
+		for _, topic := range *payload.ForbiddenTopics {
+			topic = strings.TrimSpace(topic)
+			if topic != "" {
+				forbiddenTopics = append(forbiddenTopics, topic)
+			}
+		}
+	}
+	if len(forbiddenTopics) > aiAutoResponderMaxForbiddenTopics {
+		return nil, fmt.Errorf("at most %d forbidden topics are allowed", aiAutoResponderMaxForbiddenTopics)
+	}
+	jsonForbiddenTopics, err := json.Marshal(forbiddenTopics)
+	if err != nil {
+		return nil, err
+	}
+	stringForbiddenTopics := string(jsonForbiddenTopics)
+
+	var handoffRoleId *uuid.UUID
+	if payload.HandoffOrganizationRoleId != nil && *payload.HandoffOrganizationRoleId != "" {
+		roleId, err := uuid.Parse(*payload.HandoffOrganizationRoleId)
+		if err != nil {
+			return nil, fmt.Errorf("invalid handoff role id")
+		}
+
+		var roles []model.OrganizationRole
+		err = SELECT(table.OrganizationRole.UniqueId).
+			FROM(table.OrganizationRole).
+			WHERE(
+				table.OrganizationRole.UniqueId.EQ(UUID(roleId)).
+					AND(table.OrganizationRole.OrganizationId.EQ(UUID(organizationId))),
+			).
+			LIMIT(1).
+			QueryContext(ctx, service.Db, &roles)
+
+		if err != nil {
+			return nil, err
+		}
+		if len(roles) == 0 {
+			return nil, fmt.Errorf("handoff role not found")
+		}
+		handoffRoleId = &roleId
+	}
+
+	if payload.IsEnabled && handoffRoleId == nil {
+		return nil, fmt.Errorf("a handoff role is required to enable the AI auto responder")
+	}
+
+	configuration := model.AiAutoResponderConfiguration{
+		OrganizationId:            organizationId,
+		IsEnabled:                 payload.IsEnabled,
+		Instructions:              instructions,
+		MinConfidence:             int32(payload.MinConfidence),
+		MaxAiTurns:                int32(payload.MaxAiTurns),
+		ForbiddenTopics:           &stringForbiddenTopics,
+		HandoffOrganizationRoleId: handoffRoleId,
+		HandoffMessage:            trimmedOrNil(payload.HandoffMessage),
+		CreatedAt:                 time.Now(),
+		UpdatedAt:                 time.Now(),
+	}
+
+	var updatedConfiguration model.AiAutoResponderConfiguration
+	err = table.AiAutoResponderConfiguration.
+		INSERT(table.AiAutoResponderConfiguration.MutableColumns).
+		MODEL(configuration).
+		ON_CONFLICT(table.AiAutoResponderConfiguration.OrganizationId).
+		DO_UPDATE(SET(
+			table.AiAutoResponderConfiguration.IsEnabled.SET(table.AiAutoResponderConfiguration.EXCLUDED.IsEnabled),
+			table.AiAutoResponderConfiguration.Instructions.SET(table.AiAutoResponderConfiguration.EXCLUDED.Instructions),
+			table.AiAutoResponderConfiguration.MinConfidence.SET(table.AiAutoResponderConfiguration.EXCLUDED.MinConfidence),
+			table.AiAutoResponderConfiguration.MaxAiTurns.SET(table.AiAutoResponderConfiguration.EXCLUDED.MaxAiTurns),
+			table.AiAutoResponderConfiguration.ForbiddenTopics.SET(table.AiAutoResponderConfiguration.EXCLUDED.ForbiddenTopics),
+			table.AiAutoResponderConfiguration.HandoffOrganizationRoleId.SET(table.AiAutoResponderConfiguration.EXCLUDED.HandoffOrganizationRoleId),
+			table.AiAutoResponderConfiguration.HandoffMessage.SET(table.AiAutoResponderConfiguration.EXCLUDED.HandoffMessage),
+			table.AiAutoResponderConfiguration.UpdatedAt.SET(table.AiAutoResponderConfiguration.EXCLUDED.UpdatedAt),
+		)).
+		RETURNING(table.AiAutoResponderConfiguration.AllColumns).
+		QueryContext(ctx, service.Db, &updatedConfiguration)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedConfiguration, nil
+}
+
+// autoRespond answers the message of the contact with the AI when the organization enabled the auto responder,
+// the conversation is handed off to the agents as soon as the AI is unsure, the contact asks for a person or a guardrail trips,
+// and the AI never talks in it again
+func (service *ChatbotService) autoRespond(ctx context.Context, conversation conversationWithContact, message model.Message) error {
+	configuration, err := service.GetAiAutoResponderConfiguration(ctx, conversation.OrganizationId)
+	if err != nil {
+		return err
+	}
+	if !configuration.IsEnabled {
+		return nil
+	}
+
+	var handoffs []model.AiAutoResponderHandoff
+	err = SELECT(table.AiAutoResponderHandoff.UniqueId).
+		FROM(table.AiAutoResponderHandoff).
+		WHERE(table.AiAutoResponderHandoff.ConversationId.EQ(UUID(conversation.UniqueId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &handoffs)
+
+	if err != nil {
+		return err
+	}
+	if len(handoffs) > 0 {
+		return nil
+	}
+
+	var organization model.Organization
+	err = SELECT(table.Organization.AllColumns).
+		FROM(table.Organization).
+		WHERE(table.Organization.UniqueId.EQ(UUID(conversation.OrganizationId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &organization)
+
+	if err != nil {
+		return err
+	}
+	if !organization.IsAiEnabled || organization.AiModel == nil {
+		return nil
+	}
+
+	// * media without a caption can not be answered by the AI, the agents see it in the inbox as usual
+	text := strings.TrimSpace(replyFromMessage(message).Text)
+	if text == "" {
+		return nil
+	}
+
+	if asksForHuman(text) {
+		return service.handOff(ctx, conversation, *configuration, model.AiHandoffReasonEnum_ContactRequestedHuman, text)
+	}
+
+	forbiddenTopics := parseForbiddenTopics(configuration.ForbiddenTopics)
+	if topic := mentionedTopic(text, forbiddenTopics); topic != "" {
+		return service.handOff(ctx, conversation, *configuration, model.AiHandoffReasonEnum_ForbiddenTopic, topic)
+	}
+
+	var aiMessages []model.Message
+	err = SELECT(table.Message.UniqueId).
+		FROM(table.Message).
+		WHERE(
+			table.Message.ConversationId.EQ(UUID(conversation.UniqueId)).
+				AND(table.Message.IsAiGenerated.IS_TRUE()),
+		).
+		LIMIT(int64(configuration.MaxAiTurns)).
+		QueryContext(ctx, service.Db, &aiMessages)
+
+	if err != nil {
+		return err
+	}
+	if len(aiMessages) >= int(configuration.MaxAiTurns) {
+		return service.handOff(ctx, conversation, *configuration, model.AiHandoffReasonEnum_MaxTurnsReached, fmt.Sprintf("%d AI replies sent", len(aiMessages)))
+	}
+
+	// * the agents take over the conversations once the AI budget of the month is used up
+	if err := ai_service.CheckAiBudget(ctx, service.Db, conversation.OrganizationId); err != nil {
+		if errors.Is(err, ai_service.ErrAiBudgetExceeded) {
+			return service.handOff(ctx, conversation, *configuration, model.AiHandoffReasonEnum_AiError, err.Error())
+		}
+		return err
+	}
+
+	var messages []model.Message
+	err = SELECT(table.Message.AllColumns).
+		FROM(table.Message).
+		WHERE(table.Message.ConversationId.EQ(UUID(conversation.UniqueId))).
+		ORDER_BY(table.Message.CreatedAt.DESC()).
+		LIMIT(aiAutoResponderHistoryLength).
+		QueryContext(ctx, service.Db, &messages)
+
+	if err != nil {
+		return err
+	}
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+
+	redactor, err := ai_service.LoadPiiRedactor(ctx, service.Db, conversation.OrganizationId)
+	if err != nil {
+		return err
+	}
+
+	aiService := ai_service.NewAiService(service.Logger, service.Redis, service.Db, organization.AiApiKey, api_types.AiModelEnum(*organization.AiModel), service.AiServiceConfig)
+	aiService.Redactor = redactor
+	aiService.AllowCrossProviderFallback = organization.IsAiCrossProviderFallbackEnabled
+	aiCtx, cancel := context.WithTimeout(ctx, aiAutoReplyTimeout)
+	defer cancel()
+	autoReply, err := aiService.GenerateAutoReply(aiCtx, ai_service.AutoReplyParams{
+		OrganizationId:  conversation.OrganizationId,
+		Instructions:    stringValue(configuration.Instructions),
+		ForbiddenTopics: forbiddenTopics,
+		Messages:        messages,
+	})
+
+	if err != nil {
+		service.Logger.Error("error generating AI auto reply", "conversation_id", conversation.UniqueId.String(), "error", err.Error())
+		return service.handOff(ctx, conversation, *configuration, model.AiHandoffReasonEnum_AiError, err.Error())
+	}
+
+	if autoReply.Handoff || autoReply.Reply == "" {
+		return service.handOff(ctx, conversation, *configuration, model.AiHandoffReasonEnum_LowConfidence, autoReply.HandoffReason)
+	}
+
+	if autoReply.Confidence < float64(configuration.MinConfidence)/100 {
+		return service.handOff(ctx, conversation, *configuration, model.AiHandoffReasonEnum_LowConfidence, fmt.Sprintf("confidence %.0f%%", autoReply.Confidence*100))
+	}
+
+	// * the model is told about the forbidden topics, the reply is still checked in case it did not listen
+	if topic := mentionedTopic(autoReply.Reply+" "+autoReply.Topic, forbiddenTopics); topic != "" {
+		return service.handOff(ctx, conversation, *configuration, model.AiHandoffReasonEnum_ForbiddenTopic, topic)
+	}
+
+	_, err = service.ConversationService.SendAiTextMessage(ctx, conversation.Conversation, conversation.Contact.PhoneNumber, autoReply.Reply)
+	return err
+}
+
+// handOff stops the AI auto responder in the conversation and assigns it to the least busy member of the handoff team
+func (service *ChatbotService) handOff(ctx context.Context, conversation conversationWithContact, configuration model.AiAutoResponderConfiguration, reason model.AiHandoffReasonEnum, details string) error {
+	handoff := model.AiAutoResponderHandoff{
+		OrganizationId: conversation.OrganizationId,
+		ConversationId: conversation.UniqueId,
+		Reason:         reason,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	if details != "" {
+		handoff.Details = &details
+	}
+
+	if configuration.HandoffMessage != nil && *configuration.HandoffMessage != "" {
+		_, err := service.ConversationService.SendAiTextMessage(ctx, conversation.Conversation, conversation.Contact.PhoneNumber, *configuration.HandoffMessage)
+		if err != nil {
+			service.Logger.Error("error sending AI handoff message", "conversation_id", conversation.UniqueId.String(), "error", err.Error())
+		}
+	}
+
+	if configuration.HandoffOrganizationRoleId != nil {
+		memberId, err := service.ConversationService.AssignConversationToTeam(ctx, conversation.OrganizationId, conversation.UniqueId, *configuration.HandoffOrganizationRoleId)
+		if err != nil {
+			// * the conversation stays unassigned in the inbox, the AI still stays out of it
+			if !errors.Is(err, conversation_service.ErrTeamHasNoMembers) {
+				service.Logger.Error("error assigning AI handoff conversation", "conversation_id", conversation.UniqueId.String(), "error", err.Error())
+			}
+		} else {
+			handoff.AssignedToOrganizationMemberId = memberId
+		}
+	}
+
+	_, err := table.AiAutoResponderHandoff.
+		INSERT(table.AiAutoResponderHandoff.MutableColumns).
+		MODEL(handoff).
+		ON_CONFLICT(table.AiAutoResponderHandoff.ConversationId).
+		DO_NOTHING().
+		ExecContext(ctx, service.Db)
+
+	return err
+}
+
+func asksForHuman(text string) bool {
+	text = strings.ToLower(text)
+	for _, phrase := range humanRequestPhrases {
+		if containsWord(text, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// mentionedTopic returns the first of the topics the text mentions, empty if it mentions none
+func mentionedTopic(text string, topics []string) string {
+	text = strings.ToLower(text)
+	for _, topic := range topics {
+		if containsWord(text, strings.ToLower(topic)) {
+			return topic
+		}
+	}
+	return ""
+}
+
+// containsWord reports whether the phrase is in the text as whole words, so that "agent" does not match "agenda"
+func containsWord(text, phrase string) bool {
+	for start := 0; ; {
+		index := strings.Index(text[start:], phrase)
+		if index < 0 {
+			return false
+		}
+		index += start
+		end := index + len(phrase)
+		if (index == 0 || !isWordCharacter(text[index-1])) && (end == len(text) || !isWordCharacter(text[end])) {
+			return true
+		}
+		start = index + 1
+	}
+}
+
+func isWordCharacter(character byte) bool {
+	return character >= 'a' && character <= 'z' || character >= '0' && character <= '9' || character == '_' || character >= 0x80
+}
+
+func parseForbiddenTopics(forbiddenTopics *string) []string {
+	topics := []string{}
+	if forbiddenTopics != nil {
+		_ = json.Unmarshal([]byte(*forbiddenTopics), &topics)
+	}
+	return topics
+}
+
+func trimmedOrNil(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
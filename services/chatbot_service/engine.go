@@ -0,0 +1,526 @@
+package chatbot_service
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	"github.com/wapikit/wapikit/api/api_types"
+	"github.com/wapikit/wapikit/utils"
+)
+
+const (
+	// maxStepsPerRun stops a flow looping on itself without ever waiting for the contact
+	maxStepsPerRun = 50
+
+	maxFlowSteps              = 100
+	maxWaitTimeoutInMinutes   = 1440
+	maxReplyButtons           = 3
+	maxReplyButtonTitleLength = 20
+)
+
+var placeholderRegex = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_.\-]+)\s*\}\}`)
+
+// flowReply is a message of the contact as the flow sees it, the button id is set when the contact tapped a reply button
+type flowReply struct {
+	Text     string `json:"text"`
+	ButtonId string `json:"buttonId,omitempty"`
+}
+
+// flowState is what a session persists between two messages of the contact
+type flowState struct {
+	CurrentStepId string
+	Variables     map[string]string
+	LastReply     *flowReply
+	Status        model.ChatbotFlowSessionStatusEnum
+	// WaitTimeout is how long the WaitForReply step the flow stopped at waits for the contact
+	WaitTimeout time.Duration
+	Error       string
+}
+
+type flowContact struct {
+	Name        string
+	PhoneNumber string
+	Attributes  map[string]interface{}
+}
+
+// flowActions are the side effects of the steps, the live session talks to whatsapp and the database while the simulator only records them
+type flowActions interface {
+	sendMessage(text string, buttons []api_types.InteractiveMessageButtonSchema) error
+	setContactAttribute(key, value string) error
+	addTag(tagId uuid.UUID) error
+	assignTeam(roleId uuid.UUID) error
+	callWebhook(url string, payload map[string]interface{}) (map[string]interface{}, error)
+}
+
+// flowRun executes a flow definition for one contact until it waits for a reply, hands off or ends
+type flowRun struct {
+	steps              map[string]api_types.ChatbotFlowStepSchema
+	contact            flowContact
+	actions            flowActions
+	defaultWaitTimeout time.Duration
+	// onStep is called for every executed step, with the text and buttons the step sends if any
+	onStep func(step api_types.ChatbotFlowStepSchema, description string, text *string, buttons *[]api_types.InteractiveMessageButtonSchema)
+}
+
+func newFlowRun(definition api_types.ChatbotFlowDefinitionSchema, contact flowContact, actions flowActions, defaultWaitTimeout time.Duration) *flowRun {
+	steps := make(map[string]api_types.ChatbotFlowStepSchema, len(definition.Steps))
+	for _, step := range definition.Steps {
+		steps[step.Id] = step
+	}
+	if contact.Attributes == nil {
+		contact.Attributes = map[string]interface{}{}
+	}
+	return &flowRun{
+		steps:              steps,
+		contact:            contact,
+		actions:            actions,
+		defaultWaitTimeout: defaultWaitTimeout,
+	}
+}
+
+// start begins the flow at its first step, the message that triggered the flow is available to the steps as the last reply
+func (run *flowRun) start(startStepId string, trigger *flowReply) *flowState {
+	state := &flowState{
+		CurrentStepId: startStepId,
+		Variables:     map[string]string{},
+		LastReply:     trigger,
+		Status:        model.ChatbotFlowSessionStatusEnum_Waiting,
+	}
+	run.execute(state, startStepId)
+	return state
+}
+
+// resume answers the WaitForReply step the flow is waiting at and runs the flow until it stops again
+func (run *flowRun) resume(state *flowState, reply flowReply) {
+	step, ok := run.steps[state.CurrentStepId]
+	if !ok || step.Type != api_types.WaitForReply {
+		run.fail(state, fmt.Errorf("the flow is not waiting at a WaitForReply step"))
+		return
+	}
+
+	state.LastReply = &reply
+	if step.SaveAs != nil && *step.SaveAs != "" {
+		state.Variables[*step.SaveAs] = reply.Text
+	}
+	run.trace(step, fmt.Sprintf("received the reply %q", reply.Text), nil, nil)
+
+	run.moveTo(state, step.Next)
+}
+
+// timeout handles the contact not answering the WaitForReply step the flow is waiting at in time
+func (run *flowRun) timeout(state *flowState) {
+	step, ok := run.steps[state.CurrentStepId]
+	if !ok || step.Type != api_types.WaitForReply {
+		state.Status = model.ChatbotFlowSessionStatusEnum_TimedOut
+		return
+	}
+
+	if step.TimeoutNext == nil || *step.TimeoutNext == "" {
+		run.trace(step, "the contact did not reply in time, the session ends", nil, nil)
+		state.Status = model.ChatbotFlowSessionStatusEnum_TimedOut
+		return
+	}
+
+	run.trace(step, "the contact did not reply in time", nil, nil)
+	run.execute(state, *step.TimeoutNext)
+}
+
+func (run *flowRun) moveTo(state *flowState, next *string) {
+	if next == nil || *next == "" {
+		state.Status = model.ChatbotFlowSessionStatusEnum_Finished
+		return
+	}
+	run.execute(state, *next)
+}
+
+func (run *flowRun) execute(state *flowState, stepId string) {
+	for executedSteps := 0; ; executedSteps++ {
+		if executedSteps >= maxStepsPerRun {
+			run.fail(state, fmt.Errorf("the flow ran %d steps without waiting for the contact, it probably loops", maxStepsPerRun))
+			return
+		}
+
+		step, ok := run.steps[stepId]
+		if !ok {
+			run.fail(state, fmt.Errorf("step %s does not exist", stepId))
+			return
+		}
+		state.CurrentStepId = step.Id
+
+		next, err := run.executeStep(state, step)
+		if err != nil {
+			run.fail(state, fmt.Errorf("step %s: %w", step.Id, err))
+			return
+		}
+
+		// * the step stopped the flow, it is waiting for the contact or handed off
+		if state.Status != model.ChatbotFlowSessionStatusEnum_Waiting || state.WaitTimeout > 0 {
+			return
+		}
+
+		if next == nil || *next == "" {
+			state.Status = model.ChatbotFlowSessionStatusEnum_Finished
+			return
+		}
+		stepId = *next
+	}
+}
+
+// executeStep runs a single step and returns the step to go to next
+func (run *flowRun) executeStep(state *flowState, step api_types.ChatbotFlowStepSchema) (*string, error) {
+	state.WaitTimeout = 0
+
+	switch step.Type {
+	case api_types.SendMessage:
+		text := run.render(state, stringValue(step.Text))
+		var buttons []api_types.InteractiveMessageButtonSchema
+		if step.Buttons != nil {
+			buttons = *step.Buttons
+		}
+		run.trace(step, "sent a message", &text, step.Buttons)
+		if err := run.actions.sendMessage(text, buttons); err != nil {
+			return nil, err
+		}
+		return step.Next, nil
+
+	case api_types.WaitForReply:
+		timeout := run.defaultWaitTimeout
+		if step.TimeoutInMinutes != nil && *step.TimeoutInMinutes > 0 {
+			timeout = time.Duration(*step.TimeoutInMinutes) * time.Minute
+		}
+		state.WaitTimeout = timeout
+		run.trace(step, fmt.Sprintf("waiting up to %s for the reply of the contact", timeout), nil, nil)
+		return nil, nil
+
+	case api_types.Branch:
+		if step.Conditions != nil {
+			for _, condition := range *step.Conditions {
+				if run.conditionMatches(state, condition) {
+					run.trace(step, fmt.Sprintf("matched the %s condition %q", condition.MatchType, condition.Value), nil, nil)
+					next := condition.Next
+					return &next, nil
+				}
+			}
+		}
+		run.trace(step, "no condition matched, took the default branch", nil, nil)
+		return step.DefaultNext, nil
+
+	case api_types.SetAttribute:
+		key := stringValue(step.AttributeKey)
+		value := run.render(state, stringValue(step.AttributeValue))
+		run.trace(step, fmt.Sprintf("set the contact attribute %s to %q", key, value), nil, nil)
+		if err := run.actions.setContactAttribute(key, value); err != nil {
+			return nil, err
+		}
+		run.contact.Attributes[key] = value
+		return step.Next, nil
+
+	case api_types.AddTag:
+		tagId, err := uuid.Parse(stringValue(step.TagId))
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag id")
+		}
+		run.trace(step, "tagged the conversation", nil, nil)
+		if err := run.actions.addTag(tagId); err != nil {
+			return nil, err
+		}
+		return step.Next, nil
+
+	case api_types.AssignTeam:
+		roleId, err := uuid.Parse(stringValue(step.RoleId))
+		if err != nil {
+			return nil, fmt.Errorf("invalid role id")
+		}
+		run.trace(step, "assigned the conversation to the team", nil, nil)
+		if err := run.actions.assignTeam(roleId); err != nil {
+			return nil, err
+		}
+		return step.Next, nil
+
+	case api_types.CallWebhook:
+		response, err := run.actions.callWebhook(stringValue(step.Url), run.webhookPayload(state, step))
+		if err != nil {
+			if step.ErrorNext != nil && *step.ErrorNext != "" {
+				run.trace(step, fmt.Sprintf("the webhook call failed: %s", err.Error()), nil, nil)
+				return step.ErrorNext, nil
+			}
+			return nil, fmt.Errorf("webhook call failed: %w", err)
+		}
+		run.trace(step, "called the webhook", nil, nil)
+		if step.SaveAs != nil && *step.SaveAs != "" {
+			for key, value := range response {
+				switch value.(type) {
+				case string, float64, bool:
+					state.Variables[fmt.Sprintf("%s.%s", *step.SaveAs, key)] = fmt.Sprint(value)
+				}
+			}
+		}
+		return step.Next, nil
+
+	case api_types.HandOff:
+		var text *string
+		if step.Text != nil && strings.TrimSpace(*step.Text) != "" {
+			renderedText := run.render(state, *step.Text)
+			text = &renderedText
+			if err := run.actions.sendMessage(renderedText, nil); err != nil {
+				return nil, err
+			}
+		}
+		run.trace(step, "handed the conversation off to the agents", text, nil)
+		if step.RoleId != nil && *step.RoleId != "" {
+			roleId, err := uuid.Parse(*step.RoleId)
+			if err != nil {
+				return nil, fmt.Errorf("invalid role id")
+			}
+			if err := run.actions.assignTeam(roleId); err != nil {
+				return nil, err
+			}
+		}
+		state.Status = model.ChatbotFlowSessionStatusEnum_HandedOff
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("unsupported step type %s", step.Type)
+}
+
+func (run *flowRun) conditionMatches(state *flowState, condition api_types.ChatbotFlowBranchConditionSchema) bool {
+	value, buttonId := "", ""
+	if condition.Variable != nil && *condition.Variable != "" {
+		value = state.Variables[*condition.Variable]
+		buttonId = value
+	} else if state.LastReply != nil {
+		value = state.LastReply.Text
+		buttonId = state.LastReply.ButtonId
+	}
+
+	switch condition.MatchType {
+	case api_types.ButtonId:
+		return buttonId != "" && buttonId == condition.Value
+	case api_types.KeywordMatch:
+		loweredValue := strings.ToLower(value)
+		for _, keyword := range strings.Split(condition.Value, ",") {
+			keyword = strings.ToLower(strings.TrimSpace(keyword))
+			if keyword != "" && strings.Contains(loweredValue, keyword) {
+				return true
+			}
+		}
+		return false
+	case api_types.RegexMatch:
+		expression, err := regexp.Compile(condition.Value)
+		if err != nil {
+			return false
+		}
+		return expression.MatchString(value)
+	}
+
+	return false
+}
+
+// render replaces the {{reply}}, {{contact.name}}, {{contact.phone}} and {{variables.<name>}} placeholders, unknown placeholders are left as they are
+func (run *flowRun) render(state *flowState, text string) string {
+	return placeholderRegex.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := placeholderRegex.FindStringSubmatch(placeholder)[1]
+		switch {
+		case name == "reply":
+			if state.LastReply == nil {
+				return ""
+			}
+			return state.LastReply.Text
+		case name == "contact.name":
+			return run.contact.Name
+		case name == "contact.phone":
+			return run.contact.PhoneNumber
+		case strings.HasPrefix(name, "variables."):
+			return state.Variables[strings.TrimPrefix(name, "variables.")]
+		}
+		return placeholder
+	})
+}
+
+func (run *flowRun) webhookPayload(state *flowState, step api_types.ChatbotFlowStepSchema) map[string]interface{} {
+	payload := map[string]interface{}{
+		"stepId": step.Id,
+		"contact": map[string]interface{}{
+			"name":        run.contact.Name,
+			"phoneNumber": run.contact.PhoneNumber,
+			"attributes":  run.contact.Attributes,
+		},
+		"variables": state.Variables,
+	}
+	if state.LastReply != nil {
+		payload["reply"] = state.LastReply
+	}
+	return payload
+}
+
+func (run *flowRun) fail(state *flowState, err error) {
+	state.Status = model.ChatbotFlowSessionStatusEnum_Failed
+	state.WaitTimeout = 0
+	state.Error = err.Error()
+	if step, ok := run.steps[state.CurrentStepId]; ok {
+		run.trace(step, fmt.Sprintf("the session failed: %s", err.Error()), nil, nil)
+	}
+}
+
+func (run *flowRun) trace(step api_types.ChatbotFlowStepSchema, description string, text *string, buttons *[]api_types.InteractiveMessageButtonSchema) {
+	if run.onStep != nil {
+		run.onStep(step, description, text, buttons)
+	}
+}
+
+// ValidateFlowDefinition checks the steps of the flow reference each other correctly and have the inputs their type needs
+func ValidateFlowDefinition(definition api_types.ChatbotFlowDefinitionSchema) error {
+	if len(definition.Steps) == 0 {
+		return fmt.Errorf("the flow must have at least one step")
+	}
+	if len(definition.Steps) > maxFlowSteps {
+		return fmt.Errorf("the flow can have at most %d steps", maxFlowSteps)
+	}
+
+	stepIds := make(map[string]bool, len(definition.Steps))
+	for _, step := range definition.Steps {
+		if strings.TrimSpace(step.Id) == "" {
+			return fmt.Errorf("every step must have an id")
+		}
+		if stepIds[step.Id] {
+			return fmt.Errorf("step id %s is used more than once", step.Id)
+		}
+		stepIds[step.Id] = true
+	}
+
+	if !stepIds[definition.StartStepId] {
+		return fmt.Errorf("the start step %s does not exist", definition.StartStepId)
+	}
+
+	checkReference := func(step api_types.ChatbotFlowStepSchema, field string, reference *string) error {
+		if reference != nil && *reference != "" && !stepIds[*reference] {
+			return fmt.Errorf("step %s: %s references the unknown step %s", step.Id, field, *reference)
+		}
+		return nil
+	}
+
+	for _, step := range definition.Steps {
+		if err := checkReference(step, "next", step.Next); err != nil {
+			return err
+		}
+
+		switch step.Type {
+		case api_types.SendMessage:
+			if strings.TrimSpace(stringValue(step.Text)) == "" {
+				return fmt.Errorf("step %s: text is required for a SendMessage step", step.Id)
+			}
+			if step.Buttons != nil {
+				if len(*step.Buttons) > maxReplyButtons {
+					return fmt.Errorf("step %s: a message can have at most %d buttons", step.Id, maxReplyButtons)
+				}
+				for _, button := range *step.Buttons {
+					if strings.TrimSpace(button.Id) == "" || strings.TrimSpace(button.Title) == "" {
+						return fmt.Errorf("step %s: every button needs an id and a title", step.Id)
+					}
+					if len([]rune(button.Title)) > maxReplyButtonTitleLength {
+						return fmt.Errorf("step %s: button titles can be at most %d characters", step.Id, maxReplyButtonTitleLength)
+					}
+				}
+			}
+
+		case api_types.WaitForReply:
+			if step.TimeoutInMinutes != nil && (*step.TimeoutInMinutes < 1 || *step.TimeoutInMinutes > maxWaitTimeoutInMinutes) {
+				return fmt.Errorf("step %s: timeout must be between 1 and %d minutes", step.Id, maxWaitTimeoutInMinutes)
+			}
+			if err := checkReference(step, "timeoutNext", step.TimeoutNext); err != nil {
+				return err
+			}
+
+		case api_types.Branch:
+			if step.Conditions == nil || len(*step.Conditions) == 0 {
+				return fmt.Errorf("step %s: a Branch step needs at least one condition", step.Id)
+			}
+			for _, condition := range *step.Conditions {
+				if strings.TrimSpace(condition.Value) == "" {
+					return fmt.Errorf("step %s: every condition needs a value", step.Id)
+				}
+				if !stepIds[condition.Next] {
+					return fmt.Errorf("step %s: a condition references the unknown step %s", step.Id, condition.Next)
+				}
+				switch condition.MatchType {
+				case api_types.ButtonId, api_types.KeywordMatch:
+				case api_types.RegexMatch:
+					if _, err := regexp.Compile(condition.Value); err != nil {
+						return fmt.Errorf("step %s: invalid regular expression %q", step.Id, condition.Value)
+					}
+				default:
+					return fmt.Errorf("step %s: invalid condition match type", step.Id)
+				}
+			}
+			if err := checkReference(step, "defaultNext", step.DefaultNext); err != nil {
+				return err
+			}
+
+		case api_types.SetAttribute:
+			if strings.TrimSpace(stringValue(step.AttributeKey)) == "" {
+				return fmt.Errorf("step %s: attribute key is required for a SetAttribute step", step.Id)
+			}
+
+		case api_types.AddTag:
+			if _, err := uuid.Parse(stringValue(step.TagId)); err != nil {
+				return fmt.Errorf("step %s: a valid tag id is required for an AddTag step", step.Id)
+			}
+
+		case api_types.AssignTeam:
+			if _, err := uuid.Parse(stringValue(step.RoleId)); err != nil {
+				return fmt.Errorf("step %s: a valid role id is required for an AssignTeam step", step.Id)
+			}
+
+		case api_types.CallWebhook:
+			parsedUrl, err := url.Parse(stringValue(step.Url))
+			if err != nil || (parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https") || parsedUrl.Host == "" {
+				return fmt.Errorf("step %s: a valid http(s) url is required for a CallWebhook step", step.Id)
+			}
+			// * the addresses a host name resolves to are checked when the webhook is called
+			if ip := net.ParseIP(parsedUrl.Hostname()); parsedUrl.Hostname() == "localhost" || (ip != nil && !utils.IsPublicIp(ip)) {
+				return fmt.Errorf("step %s: the url of a CallWebhook step must point to a public address", step.Id)
+			}
+			if err := checkReference(step, "errorNext", step.ErrorNext); err != nil {
+				return err
+			}
+
+		case api_types.HandOff:
+			if step.RoleId != nil && *step.RoleId != "" {
+				if _, err := uuid.Parse(*step.RoleId); err != nil {
+					return fmt.Errorf("step %s: invalid role id", step.Id)
+				}
+			}
+
+		default:
+			return fmt.Errorf("step %s: invalid step type", step.Id)
+		}
+	}
+
+	return nil
+}
+
+// DefinitionReferences returns the tags and roles the steps of the flow use, for checking they belong to the organization
+func DefinitionReferences(definition api_types.ChatbotFlowDefinitionSchema) (tagIds []string, roleIds []string) {
+	for _, step := range definition.Steps {
+		if step.Type == api_types.AddTag && step.TagId != nil {
+			tagIds = append(tagIds, *step.TagId)
+		}
+		if (step.Type == api_types.AssignTeam || step.Type == api_types.HandOff) && step.RoleId != nil && *step.RoleId != "" {
+			roleIds = append(roleIds, *step.RoleId)
+		}
+	}
+	return tagIds, roleIds
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,612 @@
+package chatbot_service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	"github.com/wapikit/wapikit/services/ai_service"
+	"github.com/wapikit/wapikit/services/conversation_service"
+	cache_service "github.com/wapikit/wapikit/services/redis_service"
+	"github.com/wapikit/wapikit/utils"
+)
+
+const (
+	chatbotSchedulerInterval = 30 * time.Second
+	chatbotSchedulerLockKey  = "chatbot_flow_scheduler_lock"
+	// * the messages of a conversation are processed one at a time, so that two quick replies do not both answer the same step
+	sessionLockKeyPrefix = "chatbot_flow_session:"
+	sessionLockTtl       = 30 * time.Second
+	webhookTimeout       = 10 * time.Second
+)
+
+// ChatbotService runs the chatbot flows of the organizations, a flow starts on a trigger keyword or on a new conversation
+// and goes on with every reply of the contact until it ends, hands off to the agents or times out
+type ChatbotService struct {
+	Logger              *slog.Logger
+	Db                  *sql.DB
+	Redis               *cache_service.RedisClient
+	ConversationService *conversation_service.ConversationService
+	// AiServiceConfig is the configuration the AI services of the AI responders are created with
+	AiServiceConfig ai_service.AiServiceConfig
+
+	httpClient *http.Client
+}
+
+func NewChatbotService(db *sql.DB, logger *slog.Logger, redis *cache_service.RedisClient, conversationService *conversation_service.ConversationService) *ChatbotService {
+	return &ChatbotService{
+		Logger:              logger,
+		Db:                  db,
+		Redis:               redis,
+		ConversationService: conversationService,
+		httpClient:          utils.NewPublicHttpClient(webhookTimeout),
+	}
+}
+
+type conversationWithContact struct {
+	model.Conversation
+	Contact model.Contact
+}
+
+func (service *ChatbotService) ParseChatbotFlowToApiSchema(flow model.ChatbotFlow) api_types.ChatbotFlowSchema {
+	var definition api_types.ChatbotFlowDefinitionSchema
+	_ = json.Unmarshal([]byte(flow.Definition), &definition)
+	if definition.Steps == nil {
+		definition.Steps = []api_types.ChatbotFlowStepSchema{}
+	}
+
+	return api_types.ChatbotFlowSchema{
+		UniqueId:                 flow.UniqueId.String(),
+		Name:                     flow.Name,
+		Description:              flow.Description,
+		IsEnabled:                flow.IsEnabled,
+		Definition:               definition,
+		TriggerKeywords:          parseTriggerKeywords(flow.TriggerKeywords),
+		TriggerOnNewConversation: flow.TriggerOnNewConversation,
+		Priority:                 int(flow.Priority),
+		SessionTimeoutInMinutes:  int(flow.SessionTimeoutInMinutes),
+		CreatedAt:                flow.CreatedAt,
+		UpdatedAt:                flow.UpdatedAt,
+	}
+}
+
+// HandleInboundMessage feeds a new message of the contact to the session waiting for it, starts the first flow the message triggers
+// or lets the AI auto responder answer it, the conversations assigned to an agent are left to the agent
+func (service *ChatbotService) HandleInboundMessage(ctx context.Context, message model.Message) error {
+	if message.ConversationId == nil {
+		return nil
+	}
+
+	lock, err := service.Redis.AcquireLock(sessionLockKeyPrefix+message.ConversationId.String(), sessionLockTtl)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := service.Redis.ReleaseLock(lock); err != nil {
+			service.Logger.Error("error releasing chatbot flow session lock", "conversation_id", message.ConversationId.String(), "error", err.Error())
+		}
+	}()
+
+	conversation, err := service.fetchConversation(ctx, *message.ConversationId)
+	if err != nil {
+		return err
+	}
+
+	reply := replyFromMessage(message)
+
+	var session model.ChatbotFlowSession
+	err = SELECT(table.ChatbotFlowSession.AllColumns).
+		FROM(table.ChatbotFlowSession).
+		WHERE(
+			table.ChatbotFlowSession.ConversationId.EQ(UUID(conversation.UniqueId)).
+				AND(table.ChatbotFlowSession.Status.EQ(utils.EnumExpression(model.ChatbotFlowSessionStatusEnum_Waiting.String()))),
+		).
+		ORDER_BY(table.ChatbotFlowSession.CreatedAt.DESC()).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &session)
+
+	if err == nil {
+		isWaiting, err := service.resumeSession(ctx, session, *conversation, reply)
+		if err != nil || isWaiting {
+			return err
+		}
+		// * the session timed out before the contact replied, the reply may start another flow
+	} else if !errors.Is(err, qrm.ErrNoRows) {
+		return err
+	}
+
+	isBotAllowed, err := service.isBotAllowed(ctx, *conversation)
+	if err != nil || !isBotAllowed {
+		return err
+	}
+
+	isFlowStarted, err := service.startTriggeredFlow(ctx, *conversation, message, reply)
+	if err != nil || isFlowStarted {
+		return err
+	}
+
+	// * the messages no flow is triggered by are answered by the AI auto responder, when the organization enabled it
+	return service.autoRespond(ctx, *conversation, message)
+}
+
+// resumeSession answers the waiting session with the reply of the contact, it returns whether the session still waited for the reply
+func (service *ChatbotService) resumeSession(ctx context.Context, session model.ChatbotFlowSession, conversation conversationWithContact, reply flowReply) (bool, error) {
+	flow, err := service.fetchFlow(ctx, session.ChatbotFlowId)
+	if err != nil {
+		return false, err
+	}
+
+	run, state, err := service.restoreSession(ctx, session, *flow, conversation)
+	if err != nil {
+		return false, service.saveSession(ctx, session, &flowState{Status: model.ChatbotFlowSessionStatusEnum_Failed, CurrentStepId: session.CurrentStepId, Error: err.Error()})
+	}
+
+	if session.ExpiresAt != nil && session.ExpiresAt.Before(time.Now()) {
+		run.timeout(state)
+		return false, service.saveSession(ctx, session, state)
+	}
+
+	// * an agent picked the conversation up while the flow was waiting, the bot steps aside
+	isAssigned, err := service.isAssignedSince(ctx, conversation.UniqueId, session.UpdatedAt)
+	if err != nil {
+		return true, err
+	}
+	if isAssigned {
+		state.Status = model.ChatbotFlowSessionStatusEnum_HandedOff
+		return true, service.saveSession(ctx, session, state)
+	}
+
+	if !flow.IsEnabled {
+		state.Status = model.ChatbotFlowSessionStatusEnum_Finished
+		return false, service.saveSession(ctx, session, state)
+	}
+
+	run.resume(state, reply)
+	return true, service.saveSession(ctx, session, state)
+}
+
+// isBotAllowed reports whether the flows and the AI auto responder may answer in the conversation,
+// they do not in the closed conversations and in the ones an agent has
+func (service *ChatbotService) isBotAllowed(ctx context.Context, conversation conversationWithContact) (bool, error) {
+	if conversation.Status != model.ConversationStatusEnum_Active {
+		return false, nil
+	}
+
+	_, err := service.ConversationService.GetConversationAssigneeId(ctx, conversation.UniqueId)
+	if err == nil {
+		return false, nil
+	} else if !errors.Is(err, qrm.ErrNoRows) {
+		return false, err
+	}
+
+	// * a conversation handed off to the agents stays with them, even when nobody picked it up yet
+	var handedOffSessions []model.ChatbotFlowSession
+	err = SELECT(table.ChatbotFlowSession.UniqueId).
+		FROM(table.ChatbotFlowSession).
+		WHERE(
+			table.ChatbotFlowSession.ConversationId.EQ(UUID(conversation.UniqueId)).
+				AND(table.ChatbotFlowSession.Status.EQ(utils.EnumExpression(model.ChatbotFlowSessionStatusEnum_HandedOff.String()))),
+		).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &handedOffSessions)
+
+	if err != nil {
+		return false, err
+	}
+
+	return len(handedOffSessions) == 0, nil
+}
+
+// startTriggeredFlow starts the enabled flow with the highest priority the message triggers, either with one of its keywords
+// or by being the first message of the conversation, it returns whether a flow started
+func (service *ChatbotService) startTriggeredFlow(ctx context.Context, conversation conversationWithContact, message model.Message, reply flowReply) (bool, error) {
+	var flows []model.ChatbotFlow
+	err := SELECT(table.ChatbotFlow.AllColumns).
+		FROM(table.ChatbotFlow).
+		WHERE(
+			table.ChatbotFlow.OrganizationId.EQ(UUID(conversation.OrganizationId)).
+				AND(table.ChatbotFlow.IsEnabled.IS_TRUE()),
+		).
+		ORDER_BY(table.ChatbotFlow.Priority.DESC(), table.ChatbotFlow.CreatedAt.ASC()).
+		QueryContext(ctx, service.Db, &flows)
+
+	if err != nil {
+		return false, err
+	}
+	if len(flows) == 0 {
+		return false, nil
+	}
+
+	isFirstMessage, err := service.isFirstInboundMessage(ctx, conversation.UniqueId, message.UniqueId)
+	if err != nil {
+		return false, err
+	}
+
+	for _, flow := range flows {
+		if !flowIsTriggered(flow, reply, isFirstMessage) {
+			continue
+		}
+
+		var definition api_types.ChatbotFlowDefinitionSchema
+		if err := json.Unmarshal([]byte(flow.Definition), &definition); err != nil {
+			service.Logger.Error("error parsing chatbot flow definition", "flow_id", flow.UniqueId.String(), "error", err.Error())
+			continue
+		}
+
+		run := newFlowRun(definition, contactOfConversation(conversation), service.newLiveActions(ctx, conversation), sessionTimeout(flow))
+		state := run.start(definition.StartStepId, &reply)
+
+		session := model.ChatbotFlowSession{
+			OrganizationId: conversation.OrganizationId,
+			ChatbotFlowId:  flow.UniqueId,
+			ConversationId: conversation.UniqueId,
+			ContactId:      conversation.ContactId,
+			CreatedAt:      time.Now(),
+		}
+		applyFlowState(&session, state)
+
+		_, err := table.ChatbotFlowSession.
+			INSERT(table.ChatbotFlowSession.MutableColumns).
+			MODEL(session).
+			ExecContext(ctx, service.Db)
+
+		return true, err
+	}
+
+	return false, nil
+}
+
+// StopConversationSessions hands the waiting sessions of the conversation off, it is called when an agent replies to the contact
+func (service *ChatbotService) StopConversationSessions(ctx context.Context, conversationId uuid.UUID) error {
+	_, err := table.ChatbotFlowSession.
+		UPDATE(table.ChatbotFlowSession.Status, table.ChatbotFlowSession.ExpiresAt, table.ChatbotFlowSession.EndedAt, table.ChatbotFlowSession.UpdatedAt).
+		SET(
+			utils.EnumExpression(model.ChatbotFlowSessionStatusEnum_HandedOff.String()),
+			NULL,
+			TimestampzT(time.Now()),
+			TimestampzT(time.Now()),
+		).
+		WHERE(
+			table.ChatbotFlowSession.ConversationId.EQ(UUID(conversationId)).
+				AND(table.ChatbotFlowSession.Status.EQ(utils.EnumExpression(model.ChatbotFlowSessionStatusEnum_Waiting.String()))),
+		).
+		ExecContext(ctx, service.Db)
+
+	return err
+}
+
+// RunScheduler times out the sessions the contact did not reply to in time,
+// it is a blocking function and must be executed in a go routine
+func (service *ChatbotService) RunScheduler(ctx context.Context) {
+	ticker := time.NewTicker(chatbotSchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// * only one instance of the server must time the sessions out at a time
+			lock, err := service.Redis.AcquireLock(chatbotSchedulerLockKey, chatbotSchedulerInterval)
+			if err != nil {
+				continue
+			}
+
+			service.timeoutExpiredSessions(ctx)
+
+			if err := service.Redis.ReleaseLock(lock); err != nil {
+				service.Logger.Error("error releasing chatbot scheduler lock", "error", err.Error())
+			}
+		}
+	}
+}
+
+func (service *ChatbotService) timeoutExpiredSessions(ctx context.Context) {
+	var expiredSessions []model.ChatbotFlowSession
+
+	err := SELECT(table.ChatbotFlowSession.AllColumns).
+		FROM(table.ChatbotFlowSession).
+		WHERE(
+			table.ChatbotFlowSession.Status.EQ(utils.EnumExpression(model.ChatbotFlowSessionStatusEnum_Waiting.String())).
+				AND(table.ChatbotFlowSession.ExpiresAt.LT_EQ(TimestampzT(time.Now()))),
+		).
+		LIMIT(200).
+		QueryContext(ctx, service.Db, &expiredSessions)
+
+	if err != nil {
+		service.Logger.Error("error fetching expired chatbot flow sessions", "error", err.Error())
+		return
+	}
+
+	for _, session := range expiredSessions {
+		if err := service.timeoutSession(ctx, session); err != nil {
+			service.Logger.Error("error timing out chatbot flow session", "session_id", session.UniqueId.String(), "error", err.Error())
+		}
+	}
+}
+
+func (service *ChatbotService) timeoutSession(ctx context.Context, session model.ChatbotFlowSession) error {
+	lock, err := service.Redis.AcquireLock(sessionLockKeyPrefix+session.ConversationId.String(), sessionLockTtl)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := service.Redis.ReleaseLock(lock); err != nil {
+			service.Logger.Error("error releasing chatbot flow session lock", "conversation_id", session.ConversationId.String(), "error", err.Error())
+		}
+	}()
+
+	// * a reply of the contact may have answered the session while waiting for the lock
+	err = SELECT(table.ChatbotFlowSession.AllColumns).
+		FROM(table.ChatbotFlowSession).
+		WHERE(
+			table.ChatbotFlowSession.UniqueId.EQ(UUID(session.UniqueId)).
+				AND(table.ChatbotFlowSession.Status.EQ(utils.EnumExpression(model.ChatbotFlowSessionStatusEnum_Waiting.String()))).
+				AND(table.ChatbotFlowSession.ExpiresAt.LT_EQ(TimestampzT(time.Now()))),
+		).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &session)
+
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	flow, err := service.fetchFlow(ctx, session.ChatbotFlowId)
+	if err != nil {
+		return err
+	}
+
+	conversation, err := service.fetchConversation(ctx, session.ConversationId)
+	if err != nil {
+		return err
+	}
+
+	run, state, err := service.restoreSession(ctx, session, *flow, *conversation)
+	if err != nil {
+		return service.saveSession(ctx, session, &flowState{Status: model.ChatbotFlowSessionStatusEnum_Failed, CurrentStepId: session.CurrentStepId, Error: err.Error()})
+	}
+
+	// * the flows that were disabled or whose conversation is gone simply expire, without sending anything more to the contact
+	if !flow.IsEnabled || conversation.Status != model.ConversationStatusEnum_Active {
+		state.Status = model.ChatbotFlowSessionStatusEnum_TimedOut
+	} else {
+		run.timeout(state)
+	}
+
+	return service.saveSession(ctx, session, state)
+}
+
+// restoreSession rebuilds the run and the state of a persisted session
+func (service *ChatbotService) restoreSession(ctx context.Context, session model.ChatbotFlowSession, flow model.ChatbotFlow, conversation conversationWithContact) (*flowRun, *flowState, error) {
+	var definition api_types.ChatbotFlowDefinitionSchema
+	if err := json.Unmarshal([]byte(flow.Definition), &definition); err != nil {
+		return nil, nil, fmt.Errorf("invalid flow definition: %w", err)
+	}
+
+	state := &flowState{
+		CurrentStepId: session.CurrentStepId,
+		Variables:     map[string]string{},
+		Status:        session.Status,
+	}
+	if session.Variables != nil {
+		_ = json.Unmarshal([]byte(*session.Variables), &state.Variables)
+	}
+	if session.LastReply != nil {
+		var lastReply flowReply
+		if err := json.Unmarshal([]byte(*session.LastReply), &lastReply); err == nil {
+			state.LastReply = &lastReply
+		}
+	}
+
+	run := newFlowRun(definition, contactOfConversation(conversation), service.newLiveActions(ctx, conversation), sessionTimeout(flow))
+	return run, state, nil
+}
+
+func (service *ChatbotService) saveSession(ctx context.Context, session model.ChatbotFlowSession, state *flowState) error {
+	applyFlowState(&session, state)
+
+	_, err := table.ChatbotFlowSession.
+		UPDATE(
+			table.ChatbotFlowSession.Status,
+			table.ChatbotFlowSession.CurrentStepId,
+			table.ChatbotFlowSession.Variables,
+			table.ChatbotFlowSession.LastReply,
+			table.ChatbotFlowSession.ExpiresAt,
+			table.ChatbotFlowSession.EndedAt,
+			table.ChatbotFlowSession.Error,
+			table.ChatbotFlowSession.UpdatedAt,
+		).
+		MODEL(session).
+		WHERE(table.ChatbotFlowSession.UniqueId.EQ(UUID(session.UniqueId))).
+		ExecContext(ctx, service.Db)
+
+	return err
+}
+
+// applyFlowState copies the state of the run to the session, a waiting session expires when its WaitForReply step times out
+func applyFlowState(session *model.ChatbotFlowSession, state *flowState) {
+	session.Status = state.Status
+	session.CurrentStepId = state.CurrentStepId
+	session.UpdatedAt = time.Now()
+	session.ExpiresAt = nil
+	session.EndedAt = nil
+	session.Error = nil
+
+	if state.Variables != nil {
+		variables, _ := json.Marshal(state.Variables)
+		stringVariables := string(variables)
+		session.Variables = &stringVariables
+	}
+
+	if state.LastReply != nil {
+		lastReply, _ := json.Marshal(state.LastReply)
+		stringLastReply := string(lastReply)
+		session.LastReply = &stringLastReply
+	}
+
+	if state.Status == model.ChatbotFlowSessionStatusEnum_Waiting {
+		expiresAt := time.Now().Add(state.WaitTimeout)
+		session.ExpiresAt = &expiresAt
+	} else {
+		endedAt := time.Now()
+		session.EndedAt = &endedAt
+	}
+
+	if state.Error != "" {
+		sessionError := state.Error
+		session.Error = &sessionError
+	}
+}
+
+func (service *ChatbotService) fetchFlow(ctx context.Context, flowId uuid.UUID) (*model.ChatbotFlow, error) {
+	var flow model.ChatbotFlow
+	err := SELECT(table.ChatbotFlow.AllColumns).
+		FROM(table.ChatbotFlow).
+		WHERE(table.ChatbotFlow.UniqueId.EQ(UUID(flowId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &flow)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &flow, nil
+}
+
+func (service *ChatbotService) fetchConversation(ctx context.Context, conversationId uuid.UUID) (*conversationWithContact, error) {
+	var conversation conversationWithContact
+	err := SELECT(table.Conversation.AllColumns, table.Contact.AllColumns).
+		FROM(table.Conversation.LEFT_JOIN(table.Contact, table.Contact.UniqueId.EQ(table.Conversation.ContactId))).
+		WHERE(table.Conversation.UniqueId.EQ(UUID(conversationId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &conversation)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &conversation, nil
+}
+
+// isAssignedSince tells whether the conversation was assigned to a member after the given time
+func (service *ChatbotService) isAssignedSince(ctx context.Context, conversationId uuid.UUID, since time.Time) (bool, error) {
+	var assignments []model.ConversationAssignment
+	err := SELECT(table.ConversationAssignment.AllColumns).
+		FROM(table.ConversationAssignment).
+		WHERE(
+			table.ConversationAssignment.ConversationId.EQ(UUID(conversationId)).
+				AND(table.ConversationAssignment.Status.EQ(utils.EnumExpression(model.ConversationAssignmentStatus_Assigned.String()))).
+				AND(table.ConversationAssignment.UpdatedAt.GT(TimestampzT(since))),
+		).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &assignments)
+
+	if err != nil {
+		return false, err
+	}
+
+	return len(assignments) > 0, nil
+}
+
+func (service *ChatbotService) isFirstInboundMessage(ctx context.Context, conversationId, messageId uuid.UUID) (bool, error) {
+	var previousMessages []model.Message
+	err := SELECT(table.Message.UniqueId).
+		FROM(table.Message).
+		WHERE(
+			table.Message.ConversationId.EQ(UUID(conversationId)).
+				AND(table.Message.Direction.EQ(utils.EnumExpression(model.MessageDirectionEnum_InBound.String()))).
+				AND(table.Message.UniqueId.NOT_EQ(UUID(messageId))),
+		).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &previousMessages)
+
+	if err != nil {
+		return false, err
+	}
+
+	return len(previousMessages) == 0, nil
+}
+
+func flowIsTriggered(flow model.ChatbotFlow, reply flowReply, isFirstMessage bool) bool {
+	if flow.TriggerOnNewConversation && isFirstMessage {
+		return true
+	}
+
+	text := strings.ToLower(strings.TrimSpace(reply.Text))
+	if text == "" {
+		return false
+	}
+
+	for _, keyword := range parseTriggerKeywords(flow.TriggerKeywords) {
+		if strings.ToLower(strings.TrimSpace(keyword)) == text {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseTriggerKeywords(triggerKeywords *string) []string {
+	keywords := []string{}
+	if triggerKeywords != nil {
+		_ = json.Unmarshal([]byte(*triggerKeywords), &keywords)
+	}
+	return keywords
+}
+
+// replyFromMessage extracts what the flow needs from a message of the contact, the messages other than text and button replies have no text
+func replyFromMessage(message model.Message) flowReply {
+	if message.MessageData == nil {
+		return flowReply{}
+	}
+
+	switch message.MessageType {
+	case model.MessageTypeEnum_Text:
+		var data api_types.TextMessageData
+		if err := json.Unmarshal([]byte(*message.MessageData), &data); err == nil {
+			return flowReply{Text: data.Text}
+		}
+	case model.MessageTypeEnum_InteractiveReply:
+		var data api_types.InteractiveReplyMessageData
+		if err := json.Unmarshal([]byte(*message.MessageData), &data); err == nil {
+			return flowReply{Text: data.Title, ButtonId: data.Id}
+		}
+	}
+
+	return flowReply{}
+}
+
+func contactOfConversation(conversation conversationWithContact) flowContact {
+	attributes := map[string]interface{}{}
+	if conversation.Contact.Attributes != nil {
+		_ = json.Unmarshal([]byte(*conversation.Contact.Attributes), &attributes)
+	}
+
+	return flowContact{
+		Name:        conversation.Contact.Name,
+		PhoneNumber: conversation.Contact.PhoneNumber,
+		Attributes:  attributes,
+	}
+}
+
+func sessionTimeout(flow model.ChatbotFlow) time.Duration {
+	if flow.SessionTimeoutInMinutes <= 0 {
+		return time.Hour
+	}
+	return time.Duration(flow.SessionTimeoutInMinutes) * time.Minute
+}
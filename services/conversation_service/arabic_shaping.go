@@ -0,0 +1,173 @@
+package conversation_service
+
+import "unicode"
+
+// * the pdf fonts are drawn without a shaping engine, so the arabic letters are replaced with their contextual presentation
+// * forms before the line is laid out, a letter otherwise shows in its isolated form whatever its neighbours are
+
+// arabicLetterForms are the presentation forms of an arabic letter, a right joining letter has no initial and medial form
+type arabicLetterForms struct {
+	isolated rune
+	final    rune
+	initial  rune
+	medial   rune
+}
+
+func (forms arabicLetterForms) isDualJoining() bool {
+	return forms.initial != 0
+}
+
+func dualJoining(isolated rune) arabicLetterForms {
+	return arabicLetterForms{isolated: isolated, final: isolated + 1, initial: isolated + 2, medial: isolated + 3}
+}
+
+func rightJoining(isolated rune) arabicLetterForms {
+	return arabicLetterForms{isolated: isolated, final: isolated + 1}
+}
+
+var arabicLetters = map[rune]arabicLetterForms{
+	0x0622: rightJoining(0xFE81), // alef with madda above
+	0x0623: rightJoining(0xFE83), // alef with hamza above
+	0x0624: rightJoining(0xFE85), // waw with hamza above
+	0x0625: rightJoining(0xFE87), // alef with hamza below
+	0x0626: dualJoining(0xFE89),  // yeh with hamza above
+	0x0627: rightJoining(0xFE8D), // alef
+	0x0628: dualJoining(0xFE8F),  // beh
+	0x0629: rightJoining(0xFE93), // teh marbuta
+	0x062A: dualJoining(0xFE95),  // teh
+	0x062B: dualJoining(0xFE99),  // theh
+	0x062C: dualJoining(0xFE9D),  // jeem
+	0x062D: dualJoining(0xFEA1),  // hah
+	0x062E: dualJoining(0xFEA5),  // khah
+	0x062F: rightJoining(0xFEA9), // dal
+	0x0630: rightJoining(0xFEAB), // thal
+	0x0631: rightJoining(0xFEAD), // reh
+	0x0632: rightJoining(0xFEAF), // zain
+	0x0633: dualJoining(0xFEB1),  // seen
+	0x0634: dualJoining(0xFEB5),  // sheen
+	0x0635: dualJoining(0xFEB9),  // sad
+	0x0636: dualJoining(0xFEBD),  // dad
+	0x0637: dualJoining(0xFEC1),  // tah
+	0x0638: dualJoining(0xFEC5),  // zah
+	0x0639: dualJoining(0xFEC9),  // ain
+	0x063A: dualJoining(0xFECD),  // ghain
+	0x0641: dualJoining(0xFED1),  // feh
+	0x0642: dualJoining(0xFED5),  // qaf
+	0x0643: dualJoining(0xFED9),  // kaf
+	0x0644: dualJoining(0xFEDD),  // lam
+	0x0645: dualJoining(0xFEE1),  // meem
+	0x0646: dualJoining(0xFEE5),  // noon
+	0x0647: dualJoining(0xFEE9),  // heh
+	0x0648: rightJoining(0xFEED), // waw
+	0x0649: rightJoining(0xFEEF), // alef maksura
+	0x064A: dualJoining(0xFEF1),  // yeh
+	0x067E: dualJoining(0xFB56),  // peh
+	0x0686: dualJoining(0xFB7A),  // tcheh
+	0x0698: rightJoining(0xFB8A), // jeh
+	0x06A9: dualJoining(0xFB8E),  // keheh
+	0x06AF: dualJoining(0xFB92),  // gaf
+	0x06CC: dualJoining(0xFBFC),  // farsi yeh
+}
+
+const (
+	arabicLam     = 0x0644
+	arabicTatweel = 0x0640
+)
+
+// arabicLamAlefLigatures are the isolated forms of the ligatures of lam with the alefs, the final form follows each
+var arabicLamAlefLigatures = map[rune]rune{
+	0x0622: 0xFEF5,
+	0x0623: 0xFEF7,
+	0x0625: 0xFEF9,
+	0x0627: 0xFEFB,
+}
+
+// isArabicTransparent tells if the character is a mark drawn over its letter, it is skipped when the letters are joined
+func isArabicTransparent(character rune) bool {
+	return unicode.Is(unicode.Mn, character)
+}
+
+// joinsToNext tells if the character connects to the letter following it
+func joinsToNext(character rune) bool {
+	if character == arabicTatweel {
+		return true
+	}
+	forms, isLetter := arabicLetters[character]
+	return isLetter && forms.isDualJoining()
+}
+
+// joinsToPrevious tells if the character connects to the letter preceding it
+func joinsToPrevious(character rune) bool {
+	if character == arabicTatweel {
+		return true
+	}
+	_, isLetter := arabicLetters[character]
+	return isLetter
+}
+
+// shapeArabic replaces the arabic letters of the text with their presentation forms, the text is kept in its logical order
+func shapeArabic(text string) string {
+	characters := []rune(text)
+
+	hasArabic := false
+	for _, character := range characters {
+		if _, isLetter := arabicLetters[character]; isLetter {
+			hasArabic = true
+			break
+		}
+	}
+	if !hasArabic {
+		return text
+	}
+
+	neighbour := func(index int, step int) (rune, int) {
+		for index += step; index >= 0 && index < len(characters); index += step {
+			if !isArabicTransparent(characters[index]) {
+				return characters[index], index
+			}
+		}
+		return 0, -1
+	}
+
+	shaped := make([]rune, 0, len(characters))
+	for index := 0; index < len(characters); index++ {
+		character := characters[index]
+		forms, isLetter := arabicLetters[character]
+		if !isLetter {
+			shaped = append(shaped, character)
+			continue
+		}
+
+		previous, _ := neighbour(index, -1)
+		isJoinedToPrevious := joinsToNext(previous)
+
+		next, nextIndex := neighbour(index, 1)
+		if character == arabicLam {
+			if ligature, isLigature := arabicLamAlefLigatures[next]; isLigature {
+				if isJoinedToPrevious {
+					ligature++
+				}
+				// * the marks between the lam and the alef stay after the ligature
+				shaped = append(shaped, ligature)
+				shaped = append(shaped, characters[index+1:nextIndex]...)
+				index = nextIndex
+				continue
+			}
+		}
+
+		isJoinedToNext := forms.isDualJoining() && joinsToPrevious(next)
+
+		switch {
+		case isJoinedToPrevious && isJoinedToNext:
+			shaped = append(shaped, forms.medial)
+		case isJoinedToPrevious:
+			shaped = append(shaped, forms.final)
+		case isJoinedToNext:
+			shaped = append(shaped, forms.initial)
+		default:
+			shaped = append(shaped, forms.isolated)
+		}
+	}
+
+	return string(shaped)
+}
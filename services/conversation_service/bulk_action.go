@@ -0,0 +1,621 @@
+package conversation_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	"github.com/wapikit/wapikit/services/event_service"
+	"github.com/wapikit/wapikit/utils"
+)
+
+const (
+	// MaxBulkActionConversations caps the number of conversations a single bulk action job can touch
+	MaxBulkActionConversations = 10000
+	bulkActionBatchSize        = 100
+	// * a queued job is started right away by the api server, the scheduler only picks up the ones left behind, e.g. by a restart
+	bulkActionQueuedPickupDelay = time.Minute
+	bulkActionStaleAfter        = 10 * time.Minute
+)
+
+// BulkActionSelection is the set of conversations a bulk action job runs on, either the given ids or all the conversations matching the filter
+type BulkActionSelection struct {
+	ConversationIds []string                                      `json:"conversationIds,omitempty"`
+	Filter          *api_types.ConversationBulkActionFilterSchema `json:"filter,omitempty"`
+	// IsOwner is false when the job is created by a member, the job then only touches the conversations visible to the member
+	IsOwner bool `json:"isOwner"`
+}
+
+// BulkActionParameters are the inputs of the action, only the ones needed by the action are set
+type BulkActionParameters struct {
+	OrganizationMemberId *string  `json:"organizationMemberId,omitempty"`
+	TagIds               []string `json:"tagIds,omitempty"`
+}
+
+type CreateBulkActionJobParams struct {
+	OrganizationId                uuid.UUID
+	CreatedByOrganizationMemberId uuid.UUID
+	Action                        model.ConversationBulkActionEnum
+	Selection                     BulkActionSelection
+	Parameters                    BulkActionParameters
+}
+
+func (service *ConversationService) ParseBulkActionJobToApiSchema(job model.ConversationBulkActionJob) api_types.ConversationBulkActionJobSchema {
+	return api_types.ConversationBulkActionJobSchema{
+		UniqueId:       job.UniqueId.String(),
+		Action:         api_types.ConversationBulkActionEnum(job.Action.String()),
+		Status:         api_types.ConversationBulkActionJobStatusEnum(job.Status.String()),
+		TotalCount:     int(job.TotalCount),
+		ProcessedCount: int(job.ProcessedCount),
+		FailedCount:    int(job.FailedCount),
+		Error:          job.Error,
+		CreatedAt:      job.CreatedAt,
+		StartedAt:      job.StartedAt,
+		CompletedAt:    job.CompletedAt,
+	}
+}
+
+// CreateBulkActionJob queues a bulk action job, the job must be started with StartBulkActionJob
+func (service *ConversationService) CreateBulkActionJob(ctx context.Context, params CreateBulkActionJobParams) (*model.ConversationBulkActionJob, error) {
+	selectionJson, err := json.Marshal(params.Selection)
+	if err != nil {
+		return nil, err
+	}
+
+	parametersJson, err := json.Marshal(params.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	parameters := string(parametersJson)
+
+	jobToInsert := model.ConversationBulkActionJob{
+		CreatedAt:                     time.Now(),
+		UpdatedAt:                     time.Now(),
+		OrganizationId:                params.OrganizationId,
+		CreatedByOrganizationMemberId: params.CreatedByOrganizationMemberId,
+		Action:                        params.Action,
+		Status:                        model.ConversationBulkActionJobStatusEnum_Queued,
+		Selection:                     string(selectionJson),
+		Parameters:                    &parameters,
+	}
+
+	var insertedJob model.ConversationBulkActionJob
+	err = table.ConversationBulkActionJob.
+		INSERT(table.ConversationBulkActionJob.MutableColumns).
+		MODEL(jobToInsert).
+		RETURNING(table.ConversationBulkActionJob.AllColumns).
+		QueryContext(ctx, service.Db, &insertedJob)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &insertedJob, nil
+}
+
+// StartBulkActionJob runs the job in the background, detached from the request that created it
+func (service *ConversationService) StartBulkActionJob(jobId uuid.UUID) {
+	go service.RunBulkActionJob(context.Background(), jobId)
+}
+
+// RunBulkActionJob applies the action of a queued job to its conversations in batches and publishes the progress after every batch,
+// it is a blocking function and must be executed in a go routine
+func (service *ConversationService) RunBulkActionJob(ctx context.Context, jobId uuid.UUID) {
+	var job model.ConversationBulkActionJob
+
+	// * the status check makes sure a job is run only once, even if the api server and the scheduler race
+	err := table.ConversationBulkActionJob.
+		UPDATE(
+			table.ConversationBulkActionJob.Status,
+			table.ConversationBulkActionJob.StartedAt,
+			table.ConversationBulkActionJob.UpdatedAt,
+		).
+		SET(
+			utils.EnumExpression(model.ConversationBulkActionJobStatusEnum_InProgress.String()),
+			TimestampzT(time.Now()),
+			TimestampzT(time.Now()),
+		).
+		WHERE(
+			table.ConversationBulkActionJob.UniqueId.EQ(UUID(jobId)).
+				AND(table.ConversationBulkActionJob.Status.EQ(utils.EnumExpression(model.ConversationBulkActionJobStatusEnum_Queued.String()))),
+		).
+		RETURNING(table.ConversationBulkActionJob.AllColumns).
+		QueryContext(ctx, service.Db, &job)
+
+	if err != nil {
+		if !errors.Is(err, qrm.ErrNoRows) {
+			service.Logger.Error("error starting conversation bulk action job", "job_id", jobId.String(), "error", err.Error())
+		}
+		return
+	}
+
+	var parameters BulkActionParameters
+	if job.Parameters != nil {
+		if err := json.Unmarshal([]byte(*job.Parameters), &parameters); err != nil {
+			service.finishBulkActionJob(ctx, &job, fmt.Errorf("invalid job parameters: %w", err))
+			return
+		}
+	}
+
+	conversationIds, err := service.resolveBulkActionConversations(ctx, job)
+	if err != nil {
+		service.finishBulkActionJob(ctx, &job, err)
+		return
+	}
+
+	job.TotalCount = int32(len(conversationIds))
+	service.updateBulkActionJobProgress(ctx, &job)
+
+	for start := 0; start < len(conversationIds); start += bulkActionBatchSize {
+		end := min(start+bulkActionBatchSize, len(conversationIds))
+		batch := conversationIds[start:end]
+
+		if err := service.applyBulkAction(ctx, job, parameters, batch); err != nil {
+			service.Logger.Error("error applying conversation bulk action", "job_id", job.UniqueId.String(), "action", job.Action.String(), "error", err.Error())
+			job.FailedCount += int32(len(batch))
+		}
+
+		job.ProcessedCount += int32(len(batch))
+		service.updateBulkActionJobProgress(ctx, &job)
+	}
+
+	service.finishBulkActionJob(ctx, &job, nil)
+}
+
+// resolveBulkActionConversations returns the ids of the conversations selected by the job, in a stable order
+func (service *ConversationService) resolveBulkActionConversations(ctx context.Context, job model.ConversationBulkActionJob) ([]uuid.UUID, error) {
+	var selection BulkActionSelection
+	if err := json.Unmarshal([]byte(job.Selection), &selection); err != nil {
+		return nil, fmt.Errorf("invalid job selection: %w", err)
+	}
+
+	condition := service.ConversationVisibilityCondition(job.OrganizationId, job.CreatedByOrganizationMemberId, selection.IsOwner)
+
+	if len(selection.ConversationIds) > 0 {
+		conversationIdExpressions := make([]Expression, 0, len(selection.ConversationIds))
+		for _, conversationId := range selection.ConversationIds {
+			conversationUuid, err := uuid.Parse(conversationId)
+			if err != nil {
+				return nil, fmt.Errorf("invalid conversation id %s", conversationId)
+			}
+			conversationIdExpressions = append(conversationIdExpressions, UUID(conversationUuid))
+		}
+		condition = condition.AND(table.Conversation.UniqueId.IN(conversationIdExpressions...))
+	} else {
+		filterCondition, err := bulkActionFilterCondition(selection.Filter)
+		if err != nil {
+			return nil, err
+		}
+		condition = condition.AND(filterCondition)
+	}
+
+	var conversations []model.Conversation
+	err := SELECT(table.Conversation.UniqueId).
+		FROM(table.Conversation).
+		WHERE(condition).
+		ORDER_BY(table.Conversation.CreatedAt.ASC()).
+		LIMIT(MaxBulkActionConversations).
+		QueryContext(ctx, service.Db, &conversations)
+
+	if err != nil {
+		return nil, err
+	}
+
+	conversationIds := make([]uuid.UUID, 0, len(conversations))
+	for _, conversation := range conversations {
+		conversationIds = append(conversationIds, conversation.UniqueId)
+	}
+
+	return conversationIds, nil
+}
+
+func bulkActionFilterCondition(filter *api_types.ConversationBulkActionFilterSchema) (BoolExpression, error) {
+	if filter == nil {
+		filter = &api_types.ConversationBulkActionFilterSchema{}
+	}
+
+	var condition BoolExpression
+
+	// * same as the inbox, closed and snoozed conversations are only selected when asked for by status
+	if filter.Status != nil {
+		status := model.ConversationStatusEnum(*filter.Status)
+		switch status {
+		case model.ConversationStatusEnum_Active, model.ConversationStatusEnum_Closed, model.ConversationStatusEnum_Resolved, model.ConversationStatusEnum_Snoozed, model.ConversationStatusEnum_Deleted:
+		default:
+			return nil, fmt.Errorf("invalid status in the filter")
+		}
+		condition = table.Conversation.Status.EQ(utils.EnumExpression(status.String()))
+	} else {
+		condition = table.Conversation.Status.NOT_IN(
+			utils.EnumExpression(model.ConversationStatusEnum_Closed.String()),
+			utils.EnumExpression(model.ConversationStatusEnum_Snoozed.String()),
+		)
+	}
+
+	parseId := func(name string, value *string) (*uuid.UUID, error) {
+		if value == nil || *value == "" {
+			return nil, nil
+		}
+		parsedId, err := uuid.Parse(*value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s in the filter", name)
+		}
+		return &parsedId, nil
+	}
+
+	contactId, err := parseId("contact id", filter.ContactId)
+	if err != nil {
+		return nil, err
+	}
+	if contactId != nil {
+		condition = condition.AND(table.Conversation.ContactId.EQ(UUID(*contactId)))
+	}
+
+	campaignId, err := parseId("campaign id", filter.CampaignId)
+	if err != nil {
+		return nil, err
+	}
+	if campaignId != nil {
+		condition = condition.AND(table.Conversation.InitiatedByCampaignId.EQ(UUID(*campaignId)))
+	}
+
+	listId, err := parseId("list id", filter.ListId)
+	if err != nil {
+		return nil, err
+	}
+	if listId != nil {
+		condition = condition.AND(table.Conversation.ContactId.IN(
+			SELECT(table.ContactListContact.ContactId).
+				FROM(table.ContactListContact).
+				WHERE(table.ContactListContact.ContactListId.EQ(UUID(*listId))),
+		))
+	}
+
+	tagId, err := parseId("tag id", filter.TagId)
+	if err != nil {
+		return nil, err
+	}
+	if tagId != nil {
+		condition = condition.AND(table.Conversation.UniqueId.IN(
+			SELECT(table.ConversationTag.ConversationId).
+				FROM(table.ConversationTag).
+				WHERE(table.ConversationTag.TagId.EQ(UUID(*tagId))),
+		))
+	}
+
+	if filter.PhoneNumberUsed != nil && *filter.PhoneNumberUsed != "" {
+		condition = condition.AND(table.Conversation.PhoneNumberUsed.EQ(String(*filter.PhoneNumberUsed)))
+	}
+
+	return condition, nil
+}
+
+func (service *ConversationService) applyBulkAction(ctx context.Context, job model.ConversationBulkActionJob, parameters BulkActionParameters, conversationIds []uuid.UUID) error {
+	conversationIdExpressions := make([]Expression, 0, len(conversationIds))
+	for _, conversationId := range conversationIds {
+		conversationIdExpressions = append(conversationIdExpressions, UUID(conversationId))
+	}
+
+	orgId := job.OrganizationId.String()
+
+	switch job.Action {
+	case model.ConversationBulkActionEnum_Assign:
+		if parameters.OrganizationMemberId == nil {
+			return fmt.Errorf("organization member id is required to assign conversations")
+		}
+		return service.bulkAssignConversations(ctx, orgId, uuid.MustParse(*parameters.OrganizationMemberId), conversationIds, conversationIdExpressions)
+
+	case model.ConversationBulkActionEnum_Close:
+		var closedConversations []model.Conversation
+		err := table.Conversation.
+			UPDATE(table.Conversation.Status, table.Conversation.UpdatedAt).
+			SET(utils.EnumExpression(model.ConversationStatusEnum_Closed.String()), TimestampzT(time.Now())).
+			WHERE(
+				table.Conversation.UniqueId.IN(conversationIdExpressions...).
+					AND(table.Conversation.Status.NOT_IN(
+						utils.EnumExpression(model.ConversationStatusEnum_Closed.String()),
+						utils.EnumExpression(model.ConversationStatusEnum_Deleted.String()),
+					)),
+			).
+			RETURNING(table.Conversation.UniqueId).
+			QueryContext(ctx, service.Db, &closedConversations)
+
+		if err != nil {
+			return err
+		}
+
+		for _, conversation := range closedConversations {
+			event := event_service.NewConversationClosedEvent(conversation.UniqueId.String(), nil, &orgId)
+			service.Redis.PublishMessageToRedisChannel(service.Redis.RedisApiServerEventChannelName, event.ToJson())
+
+			// * the job already runs in the background, the surveys are sent one after the other to not flood the cloud API
+			if err := service.SendCsatSurvey(ctx, conversation.UniqueId); err != nil {
+				service.Logger.Error("error sending csat survey", "conversation_id", conversation.UniqueId.String(), "error", err.Error())
+			}
+			if err := service.storeConversationSummary(ctx, job.OrganizationId, conversation.UniqueId); err != nil {
+				service.Logger.Error("error storing conversation summary", "conversation_id", conversation.UniqueId.String(), "error", err.Error())
+			}
+		}
+		return nil
+
+	case model.ConversationBulkActionEnum_Tag:
+		if len(parameters.TagIds) == 0 {
+			return fmt.Errorf("tag ids are required to tag conversations")
+		}
+
+		insertQuery := table.ConversationTag.INSERT(
+			table.ConversationTag.ConversationId,
+			table.ConversationTag.TagId,
+			table.ConversationTag.CreatedAt,
+			table.ConversationTag.UpdatedAt,
+		)
+		for _, conversationId := range conversationIds {
+			for _, tagId := range parameters.TagIds {
+				insertQuery = insertQuery.VALUES(conversationId, uuid.MustParse(tagId), time.Now(), time.Now())
+			}
+		}
+
+		_, err := insertQuery.
+			ON_CONFLICT(table.ConversationTag.ConversationId, table.ConversationTag.TagId).
+			DO_NOTHING().
+			ExecContext(ctx, service.Db)
+		return err
+
+	case model.ConversationBulkActionEnum_Untag:
+		if len(parameters.TagIds) == 0 {
+			return fmt.Errorf("tag ids are required to untag conversations")
+		}
+
+		tagIdExpressions := make([]Expression, 0, len(parameters.TagIds))
+		for _, tagId := range parameters.TagIds {
+			tagIdExpressions = append(tagIdExpressions, UUID(uuid.MustParse(tagId)))
+		}
+
+		_, err := table.ConversationTag.
+			DELETE().
+			WHERE(
+				table.ConversationTag.ConversationId.IN(conversationIdExpressions...).
+					AND(table.ConversationTag.TagId.IN(tagIdExpressions...)),
+			).
+			ExecContext(ctx, service.Db)
+		return err
+
+	case model.ConversationBulkActionEnum_MarkRead:
+		return service.bulkMarkConversationsAsRead(ctx, job.OrganizationId, conversationIdExpressions)
+	}
+
+	return fmt.Errorf("unsupported bulk action %s", job.Action.String())
+}
+
+func (service *ConversationService) bulkAssignConversations(ctx context.Context, orgId string, organizationMemberId uuid.UUID, conversationIds []uuid.UUID, conversationIdExpressions []Expression) error {
+	var assignee struct {
+		model.OrganizationMember
+		User model.User
+	}
+
+	err := SELECT(table.OrganizationMember.AllColumns, table.User.AllColumns).
+		FROM(table.OrganizationMember.LEFT_JOIN(table.User, table.User.UniqueId.EQ(table.OrganizationMember.UserId))).
+		WHERE(
+			table.OrganizationMember.UniqueId.EQ(UUID(organizationMemberId)).
+				AND(table.OrganizationMember.OrganizationId.EQ(UUID(uuid.MustParse(orgId)))),
+		).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &assignee)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = table.ConversationAssignment.
+		UPDATE(table.ConversationAssignment.Status, table.ConversationAssignment.UpdatedAt).
+		SET(utils.EnumExpression(model.ConversationAssignmentStatus_Unassigned.String()), TimestampzT(time.Now())).
+		WHERE(
+			table.ConversationAssignment.ConversationId.IN(conversationIdExpressions...).
+				AND(table.ConversationAssignment.AssignedToOrganizationMemberId.NOT_EQ(UUID(organizationMemberId))).
+				AND(table.ConversationAssignment.Status.EQ(utils.EnumExpression(model.ConversationAssignmentStatus_Assigned.String()))),
+		).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		return err
+	}
+
+	insertQuery := table.ConversationAssignment.INSERT(
+		table.ConversationAssignment.ConversationId,
+		table.ConversationAssignment.AssignedToOrganizationMemberId,
+		table.ConversationAssignment.Status,
+		table.ConversationAssignment.CreatedAt,
+		table.ConversationAssignment.UpdatedAt,
+	)
+	for _, conversationId := range conversationIds {
+		insertQuery = insertQuery.VALUES(
+			conversationId,
+			organizationMemberId,
+			utils.EnumExpression(model.ConversationAssignmentStatus_Assigned.String()),
+			time.Now(),
+			time.Now(),
+		)
+	}
+
+	_, err = insertQuery.
+		ON_CONFLICT(table.ConversationAssignment.ConversationId, table.ConversationAssignment.AssignedToOrganizationMemberId).
+		DO_UPDATE(
+			SET(
+				table.ConversationAssignment.Status.SET(utils.EnumExpression(model.ConversationAssignmentStatus_Assigned.String())),
+				table.ConversationAssignment.UpdatedAt.SET(TimestampzT(time.Now())),
+			),
+		).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		return err
+	}
+
+	userId := assignee.User.UniqueId.String()
+	for _, conversationId := range conversationIds {
+		event := event_service.NewChatAssignmentEvent(conversationId.String(), &userId, &orgId)
+		service.Redis.PublishMessageToRedisChannel(service.Redis.RedisApiServerEventChannelName, event.ToJson())
+	}
+
+	return nil
+}
+
+// bulkMarkConversationsAsRead marks the unread inbound messages of the conversations as read and queues the read receipts
+func (service *ConversationService) bulkMarkConversationsAsRead(ctx context.Context, organizationId uuid.UUID, conversationIdExpressions []Expression) error {
+	_, err := table.Message.UPDATE(table.Message.Status).
+		SET(utils.EnumExpression(model.MessageStatusEnum_Read.String())).
+		WHERE(
+			table.Message.ConversationId.IN(conversationIdExpressions...).
+				AND(table.Message.Direction.EQ(utils.EnumExpression(model.MessageDirectionEnum_InBound.String()))).
+				AND(table.Message.Status.EQ(utils.EnumExpression(model.MessageStatusEnum_Sent.String()))),
+		).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		return err
+	}
+
+	if service.BusinessAccountService == nil {
+		return nil
+	}
+
+	// * whatsapp marks the earlier messages of the thread as read along with the given one, so only the latest inbound message of every conversation is needed
+	var latestInboundMessages []model.Message
+	err = SELECT(table.Message.AllColumns).
+		DISTINCT(table.Message.ConversationId).
+		FROM(table.Message).
+		WHERE(
+			table.Message.ConversationId.IN(conversationIdExpressions...).
+				AND(table.Message.Direction.EQ(utils.EnumExpression(model.MessageDirectionEnum_InBound.String()))).
+				AND(table.Message.WhatsAppMessageId.IS_NOT_NULL()),
+		).
+		ORDER_BY(table.Message.ConversationId, table.Message.CreatedAt.DESC()).
+		QueryContext(ctx, service.Db, &latestInboundMessages)
+
+	if err != nil {
+		return err
+	}
+
+	for _, message := range latestInboundMessages {
+		phoneNumber, err := service.BusinessAccountService.GetPhoneNumber(ctx, organizationId, message.PhoneNumberUsed)
+		if err != nil {
+			// * the messages are marked as read locally, the read receipt can not be sent without the business account of the phone number
+			service.Logger.Warn("read receipt not sent", "conversation_id", message.ConversationId.String(), "error", err.Error())
+			continue
+		}
+
+		service.QueueStatusUpdate(StatusUpdateParams{
+			PhoneNumberId:     message.PhoneNumberUsed,
+			AccessToken:       phoneNumber.WhatsappBusinessAccount.AccessToken,
+			ConversationId:    message.ConversationId.String(),
+			WhatsAppMessageId: *message.WhatsAppMessageId,
+		})
+	}
+
+	return nil
+}
+
+func (service *ConversationService) updateBulkActionJobProgress(ctx context.Context, job *model.ConversationBulkActionJob) {
+	job.UpdatedAt = time.Now()
+
+	_, err := table.ConversationBulkActionJob.
+		UPDATE(
+			table.ConversationBulkActionJob.TotalCount,
+			table.ConversationBulkActionJob.ProcessedCount,
+			table.ConversationBulkActionJob.FailedCount,
+			table.ConversationBulkActionJob.UpdatedAt,
+		).
+		MODEL(*job).
+		WHERE(table.ConversationBulkActionJob.UniqueId.EQ(UUID(job.UniqueId))).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		service.Logger.Error("error updating conversation bulk action job progress", "job_id", job.UniqueId.String(), "error", err.Error())
+	}
+
+	service.publishBulkActionJobProgress(*job)
+}
+
+func (service *ConversationService) finishBulkActionJob(ctx context.Context, job *model.ConversationBulkActionJob, jobErr error) {
+	completedAt := time.Now()
+	job.CompletedAt = &completedAt
+	job.UpdatedAt = completedAt
+	job.Status = model.ConversationBulkActionJobStatusEnum_Completed
+
+	if jobErr != nil {
+		errorMessage := jobErr.Error()
+		job.Status = model.ConversationBulkActionJobStatusEnum_Errored
+		job.Error = &errorMessage
+	}
+
+	_, err := table.ConversationBulkActionJob.
+		UPDATE(
+			table.ConversationBulkActionJob.Status,
+			table.ConversationBulkActionJob.Error,
+			table.ConversationBulkActionJob.CompletedAt,
+			table.ConversationBulkActionJob.UpdatedAt,
+		).
+		MODEL(*job).
+		WHERE(table.ConversationBulkActionJob.UniqueId.EQ(UUID(job.UniqueId))).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		service.Logger.Error("error finishing conversation bulk action job", "job_id", job.UniqueId.String(), "error", err.Error())
+	}
+
+	service.publishBulkActionJobProgress(*job)
+}
+
+func (service *ConversationService) publishBulkActionJobProgress(job model.ConversationBulkActionJob) {
+	orgId := job.OrganizationId.String()
+	event := event_service.NewConversationBulkActionProgressEvent(service.ParseBulkActionJobToApiSchema(job), &orgId)
+	service.Redis.PublishMessageToRedisChannel(service.Redis.RedisApiServerEventChannelName, event.ToJson())
+}
+
+// resumeBulkActionJobs starts the queued jobs left behind and fails the ones that stopped making progress, e.g. because the server restarted
+func (service *ConversationService) resumeBulkActionJobs(ctx context.Context) {
+	var staleJobs []model.ConversationBulkActionJob
+	err := SELECT(table.ConversationBulkActionJob.AllColumns).
+		FROM(table.ConversationBulkActionJob).
+		WHERE(
+			table.ConversationBulkActionJob.Status.EQ(utils.EnumExpression(model.ConversationBulkActionJobStatusEnum_InProgress.String())).
+				AND(table.ConversationBulkActionJob.UpdatedAt.LT(TimestampzT(time.Now().Add(-bulkActionStaleAfter)))),
+		).
+		QueryContext(ctx, service.Db, &staleJobs)
+
+	if err != nil {
+		service.Logger.Error("error fetching stale conversation bulk action jobs", "error", err.Error())
+	}
+
+	for _, job := range staleJobs {
+		service.finishBulkActionJob(ctx, &job, fmt.Errorf("the job stopped making progress, %d of %d conversations were processed", job.ProcessedCount, job.TotalCount))
+	}
+
+	var queuedJobs []model.ConversationBulkActionJob
+	err = SELECT(table.ConversationBulkActionJob.UniqueId).
+		FROM(table.ConversationBulkActionJob).
+		WHERE(
+			table.ConversationBulkActionJob.Status.EQ(utils.EnumExpression(model.ConversationBulkActionJobStatusEnum_Queued.String())).
+				AND(table.ConversationBulkActionJob.CreatedAt.LT(TimestampzT(time.Now().Add(-bulkActionQueuedPickupDelay)))),
+		).
+		ORDER_BY(table.ConversationBulkActionJob.CreatedAt.ASC()).
+		LIMIT(10).
+		QueryContext(ctx, service.Db, &queuedJobs)
+
+	if err != nil {
+		service.Logger.Error("error fetching queued conversation bulk action jobs", "error", err.Error())
+		return
+	}
+
+	for _, job := range queuedJobs {
+		service.StartBulkActionJob(job.UniqueId)
+	}
+}
@@ -0,0 +1,111 @@
+package conversation_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/utils"
+)
+
+// CannedResponseVariables holds the values used to resolve the variables of a canned response
+type CannedResponseVariables struct {
+	Contact          model.Contact
+	AgentName        string
+	OrganizationName string
+}
+
+var cannedResponseVariableRegex = regexp.MustCompile(`{{\s*([a-zA-Z0-9_.]+)\s*}}`)
+
+// RenderCannedResponse replaces the supported variables in the canned response content,
+// unknown variables are left as it is so the agent can spot them before sending.
+func (service *ConversationService) RenderCannedResponse(content string, variables CannedResponseVariables) string {
+	var attributes map[string]interface{}
+	if variables.Contact.Attributes != nil {
+		_ = json.Unmarshal([]byte(*variables.Contact.Attributes), &attributes)
+	}
+
+	firstName, lastName := utils.ParseName(variables.Contact.Name)
+
+	return cannedResponseVariableRegex.ReplaceAllStringFunc(content, func(match string) string {
+		variable := cannedResponseVariableRegex.FindStringSubmatch(match)[1]
+		switch variable {
+		case "contact.name":
+			return variables.Contact.Name
+		case "contact.firstName":
+			return firstName
+		case "contact.lastName":
+			return lastName
+		case "contact.phoneNumber":
+			return variables.Contact.PhoneNumber
+		case "agent.name":
+			return variables.AgentName
+		case "organization.name":
+			return variables.OrganizationName
+		}
+
+		if attributeKey, found := strings.CutPrefix(variable, "contact.attributes."); found {
+			if value, ok := attributes[attributeKey]; ok && value != nil {
+				return fmt.Sprintf("%v", value)
+			}
+			return ""
+		}
+
+		return match
+	})
+}
+
+// CannedResponseVisibilityCondition returns the condition to filter the canned responses
+// visible to an organization member, organization wide responses are visible to everyone,
+// team responses to the members having the role assigned and personal responses to their creator only.
+func (service *ConversationService) CannedResponseVisibilityCondition(organizationId, organizationMemberId uuid.UUID) BoolExpression {
+	memberRolesQuery := SELECT(table.RoleAssignment.OrganizationRoleId).
+		FROM(table.RoleAssignment).
+		WHERE(table.RoleAssignment.OrganizationMemberId.EQ(UUID(organizationMemberId)))
+
+	return table.CannedResponse.OrganizationId.EQ(UUID(organizationId)).AND(
+		table.CannedResponse.Visibility.EQ(utils.EnumExpression(model.CannedResponseVisibilityEnum_Organization.String())).
+			OR(table.CannedResponse.Visibility.EQ(utils.EnumExpression(model.CannedResponseVisibilityEnum_Team.String())).
+				AND(table.CannedResponse.OrganizationRoleId.IN(memberRolesQuery))).
+			OR(table.CannedResponse.CreatedByOrganizationMemberId.EQ(UUID(organizationMemberId))),
+	)
+}
+
+// RecordCannedResponseUsage logs the usage of a canned response and bumps its usage counter
+func (service *ConversationService) RecordCannedResponseUsage(ctx context.Context, cannedResponse model.CannedResponse, organizationMemberId, conversationId uuid.UUID, messageId *uuid.UUID) error {
+	usageLog := model.CannedResponseUsageLog{
+		CannedResponseId:     cannedResponse.UniqueId,
+		OrganizationMemberId: organizationMemberId,
+		ConversationId:       conversationId,
+		MessageId:            messageId,
+		OrganizationId:       cannedResponse.OrganizationId,
+		CreatedAt:            time.Now(),
+	}
+
+	_, err := table.CannedResponseUsageLog.
+		INSERT(table.CannedResponseUsageLog.MutableColumns).
+		MODEL(usageLog).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = table.CannedResponse.
+		UPDATE(table.CannedResponse.UsageCount, table.CannedResponse.LastUsedAt).
+		SET(
+			table.CannedResponse.UsageCount.ADD(Int(1)),
+			TimestampzT(time.Now()),
+		).
+		WHERE(table.CannedResponse.UniqueId.EQ(UUID(cannedResponse.UniqueId))).
+		ExecContext(ctx, service.Db)
+
+	return err
+}
@@ -0,0 +1,619 @@
+package conversation_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	"github.com/wapikit/wapikit/services/event_service"
+	"github.com/wapikit/wapikit/utils"
+)
+
+const (
+	CsatDefaultQuestion              = "How would you rate the support you received?"
+	CsatDefaultResponseWindowInHours = 24
+	CsatMaxResponseWindowInHours     = 7 * 24
+
+	// * the survey button ids look like csat:<survey id>:<rating>, so the reply can be traced back to the survey
+	csatButtonIdPrefix = "csat:"
+	// * how long a text message of the contact is taken as the comment of the rating they just gave
+	csatCommentWindow = time.Hour
+	// * whatsapp only allows free form messages within 24 hours of the last message of the contact
+	customerServiceWindow = 24 * time.Hour
+)
+
+// * the ratings of the survey, the titles are the labels of the reply buttons
+var csatRatings = []struct {
+	Rating int
+	Title  string
+}{
+	{Rating: 1, Title: "Poor"},
+	{Rating: 2, Title: "Okay"},
+	{Rating: 3, Title: "Great"},
+}
+
+// CsatSatisfiedRating is the rating counted as a satisfied contact in the CSAT score
+const CsatSatisfiedRating = 3
+
+func (service *ConversationService) ParseCsatSurveyConfigurationToApiSchema(configuration model.CsatSurveyConfiguration) api_types.CsatSurveyConfigurationSchema {
+	return api_types.CsatSurveyConfigurationSchema{
+		IsEnabled:             configuration.IsEnabled,
+		Question:              configuration.Question,
+		CommentPrompt:         configuration.CommentPrompt,
+		ThankYouMessage:       configuration.ThankYouMessage,
+		ResponseWindowInHours: int(configuration.ResponseWindowInHours),
+	}
+}
+
+// GetCsatSurveyConfiguration returns the CSAT survey configuration of the organization, a disabled default one if the organization never configured it
+func (service *ConversationService) GetCsatSurveyConfiguration(ctx context.Context, organizationId uuid.UUID) (*model.CsatSurveyConfiguration, error) {
+	var configuration model.CsatSurveyConfiguration
+	err := SELECT(table.CsatSurveyConfiguration.AllColumns).
+		FROM(table.CsatSurveyConfiguration).
+		WHERE(table.CsatSurveyConfiguration.OrganizationId.EQ(UUID(organizationId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &configuration)
+
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return &model.CsatSurveyConfiguration{
+				OrganizationId:        organizationId,
+				IsEnabled:             false,
+				Question:              CsatDefaultQuestion,
+				ResponseWindowInHours: CsatDefaultResponseWindowInHours,
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &configuration, nil
+}
+
+// UpdateCsatSurveyConfiguration creates or updates the CSAT survey configuration of the organization
+func (service *ConversationService) UpdateCsatSurveyConfiguration(ctx context.Context, organizationId uuid.UUID, payload api_types.UpdateCsatSurveyConfigurationSchema) (*model.CsatSurveyConfiguration, error) {
+	question := strings.TrimSpace(payload.Question)
+	if question == "" {
+		return nil, fmt.Errorf("question is required")
+	}
+	if len(question) > interactiveBodyMaxLength {
+		return nil, fmt.Errorf("question must be at most %d characters", interactiveBodyMaxLength)
+	}
+
+	responseWindowInHours := CsatDefaultResponseWindowInHours
+	if payload.ResponseWindowInHours != nil {
+		responseWindowInHours = *payload.ResponseWindowInHours
+	}
+	if responseWindowInHours < 1 || responseWindowInHours > CsatMaxResponseWindowInHours {
+		return nil, fmt.Errorf("response window must be between 1 and %d hours", CsatMaxResponseWindowInHours)
+	}
+
+	configuration := model.CsatSurveyConfiguration{
+		OrganizationId:        organizationId,
+		IsEnabled:             payload.IsEnabled,
+		Question:              question,
+		CommentPrompt:         trimmedOrNil(payload.CommentPrompt),
+		ThankYouMessage:       trimmedOrNil(payload.ThankYouMessage),
+		ResponseWindowInHours: int32(responseWindowInHours),
+		CreatedAt:             time.Now(),
+		UpdatedAt:             time.Now(),
+	}
+
+	var updatedConfiguration model.CsatSurveyConfiguration
+	err := table.CsatSurveyConfiguration.
+		INSERT(table.CsatSurveyConfiguration.MutableColumns).
+		MODEL(configuration).
+		ON_CONFLICT(table.CsatSurveyConfiguration.OrganizationId).
+		DO_UPDATE(SET(
+			table.CsatSurveyConfiguration.IsEnabled.SET(table.CsatSurveyConfiguration.EXCLUDED.IsEnabled),
+			table.CsatSurveyConfiguration.Question.SET(table.CsatSurveyConfiguration.EXCLUDED.Question),
+			table.CsatSurveyConfiguration.CommentPrompt.SET(table.CsatSurveyConfiguration.EXCLUDED.CommentPrompt),
+			table.CsatSurveyConfiguration.ThankYouMessage.SET(table.CsatSurveyConfiguration.EXCLUDED.ThankYouMessage),
+			table.CsatSurveyConfiguration.ResponseWindowInHours.SET(table.CsatSurveyConfiguration.EXCLUDED.ResponseWindowInHours),
+			table.CsatSurveyConfiguration.UpdatedAt.SET(table.CsatSurveyConfiguration.EXCLUDED.UpdatedAt),
+		)).
+		RETURNING(table.CsatSurveyConfiguration.AllColumns).
+		QueryContext(ctx, service.Db, &updatedConfiguration)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedConfiguration, nil
+}
+
+// CloseConversation closes the conversation, notifies the clients, sends the CSAT survey and stores the summary in the background,
+// it returns the closed conversation or qrm.ErrNoRows if the conversation is already closed or deleted
+func (service *ConversationService) CloseConversation(ctx context.Context, conversationId uuid.UUID, userId *string) (*model.Conversation, error) {
+	var closedConversation model.Conversation
+	err := table.Conversation.
+		UPDATE(table.Conversation.Status, table.Conversation.UpdatedAt).
+		SET(utils.EnumExpression(model.ConversationStatusEnum_Closed.String()), TimestampzT(time.Now())).
+		WHERE(
+			table.Conversation.UniqueId.EQ(UUID(conversationId)).
+				AND(table.Conversation.Status.NOT_IN(
+					utils.EnumExpression(model.ConversationStatusEnum_Closed.String()),
+					utils.EnumExpression(model.ConversationStatusEnum_Deleted.String()),
+				)),
+		).
+		RETURNING(table.Conversation.AllColumns).
+		QueryContext(ctx, service.Db, &closedConversation)
+
+	if err != nil {
+		return nil, err
+	}
+
+	orgId := closedConversation.OrganizationId.String()
+	event := event_service.NewConversationClosedEvent(closedConversation.UniqueId.String(), userId, &orgId)
+	service.Redis.PublishMessageToRedisChannel(service.Redis.RedisApiServerEventChannelName, event.ToJson())
+
+	go func() {
+		if err := service.SendCsatSurvey(context.Background(), closedConversation.UniqueId); err != nil {
+			service.Logger.Error("error sending csat survey", "conversation_id", closedConversation.UniqueId.String(), "error", err.Error())
+		}
+		if err := service.storeConversationSummary(context.Background(), closedConversation.OrganizationId, closedConversation.UniqueId); err != nil {
+			service.Logger.Error("error storing conversation summary", "conversation_id", closedConversation.UniqueId.String(), "error", err.Error())
+		}
+	}()
+
+	return &closedConversation, nil
+}
+
+// SendCsatSurvey sends the CSAT survey of the organization to the contact of a closed conversation,
+// nothing is sent if the survey is disabled, the conversation was already surveyed or the contact can not be messaged anymore
+func (service *ConversationService) SendCsatSurvey(ctx context.Context, conversationId uuid.UUID) error {
+	var conversation struct {
+		model.Conversation
+		Contact model.Contact
+	}
+
+	err := SELECT(table.Conversation.AllColumns, table.Contact.AllColumns).
+		FROM(table.Conversation.LEFT_JOIN(table.Contact, table.Contact.UniqueId.EQ(table.Conversation.ContactId))).
+		WHERE(table.Conversation.UniqueId.EQ(UUID(conversationId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &conversation)
+
+	if err != nil {
+		return err
+	}
+
+	if conversation.Status != model.ConversationStatusEnum_Closed {
+		return nil
+	}
+
+	configuration, err := service.GetCsatSurveyConfiguration(ctx, conversation.OrganizationId)
+	if err != nil {
+		return err
+	}
+
+	if !configuration.IsEnabled {
+		return nil
+	}
+
+	// * outside of the customer service window only templates can be sent, a survey the contact was not expecting is not worth a template
+	lastInboundMessageAt, err := service.lastInboundMessageAt(ctx, conversation.UniqueId)
+	if err != nil {
+		return err
+	}
+	if lastInboundMessageAt == nil || time.Since(*lastInboundMessageAt) >= customerServiceWindow {
+		return nil
+	}
+
+	assigneeId, err := service.GetConversationAssigneeId(ctx, conversation.UniqueId)
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return err
+	}
+
+	sentAt := time.Now()
+	survey := model.CsatSurveyResponse{
+		OrganizationId:                 conversation.OrganizationId,
+		ConversationId:                 conversation.UniqueId,
+		ContactId:                      conversation.ContactId,
+		AssignedToOrganizationMemberId: assigneeId,
+		PhoneNumberUsed:                conversation.PhoneNumberUsed,
+		Status:                         model.CsatSurveyStatusEnum_Sent,
+		SentAt:                         sentAt,
+		ExpiresAt:                      sentAt.Add(time.Duration(configuration.ResponseWindowInHours) * time.Hour),
+		CreatedAt:                      sentAt,
+		UpdatedAt:                      sentAt,
+	}
+
+	// * the survey is recorded before it is sent, the unique conversation id makes sure a reopened and closed again conversation is surveyed only once
+	var insertedSurveys []model.CsatSurveyResponse
+	err = table.CsatSurveyResponse.
+		INSERT(table.CsatSurveyResponse.MutableColumns).
+		MODEL(survey).
+		ON_CONFLICT(table.CsatSurveyResponse.ConversationId).
+		DO_NOTHING().
+		RETURNING(table.CsatSurveyResponse.AllColumns).
+		QueryContext(ctx, service.Db, &insertedSurveys)
+
+	if err != nil {
+		return err
+	}
+
+	if len(insertedSurveys) == 0 {
+		return nil
+	}
+	survey = insertedSurveys[0]
+
+	buttons := make([]api_types.InteractiveMessageButtonSchema, 0, len(csatRatings))
+	for _, rating := range csatRatings {
+		buttons = append(buttons, api_types.InteractiveMessageButtonSchema{
+			Id:    fmt.Sprintf("%s%s:%d", csatButtonIdPrefix, survey.UniqueId.String(), rating.Rating),
+			Title: rating.Title,
+		})
+	}
+
+	var messageData api_types.NewMessageDataSchema
+	err = messageData.FromInteractiveMessageData(api_types.InteractiveMessageData{
+		InteractiveType: api_types.ReplyButtons,
+		Body:            configuration.Question,
+		Buttons:         &buttons,
+	})
+	if err != nil {
+		return err
+	}
+
+	sentMessage, err := service.SendMessage(ctx, conversation.Conversation, conversation.Contact.PhoneNumber, messageData)
+	if err != nil {
+		// * the survey was never delivered, forget it so that the contact is not waited for
+		_, deleteErr := table.CsatSurveyResponse.
+			DELETE().
+			WHERE(table.CsatSurveyResponse.UniqueId.EQ(UUID(survey.UniqueId))).
+			ExecContext(ctx, service.Db)
+		if deleteErr != nil {
+			service.Logger.Error("error deleting unsent csat survey", "survey_id", survey.UniqueId.String(), "error", deleteErr.Error())
+		}
+		return err
+	}
+
+	_, err = table.CsatSurveyResponse.
+		UPDATE(table.CsatSurveyResponse.WhatsAppMessageId, table.CsatSurveyResponse.UpdatedAt).
+		SET(String(*sentMessage.WhatsAppMessageId), TimestampzT(time.Now())).
+		WHERE(table.CsatSurveyResponse.UniqueId.EQ(UUID(survey.UniqueId))).
+		ExecContext(ctx, service.Db)
+
+	return err
+}
+
+type CsatSurveyReplyParams struct {
+	OrganizationId            uuid.UUID
+	PhoneNumberId             string
+	WhatsappBusinessAccountId string
+	ContactPhoneNumber        string
+	WhatsAppMessageId         string
+	MessageType               model.MessageTypeEnum
+	MessageData               interface{}
+	SentAt                    time.Time
+	// RepliedToWhatsAppMessageId is the whatsapp id of the message the contact replied to, if any
+	RepliedToWhatsAppMessageId string
+}
+
+type csatSurveyWithConversation struct {
+	model.CsatSurveyResponse
+	Conversation model.Conversation
+	Contact      model.Contact
+}
+
+// HandleCsatSurveyReply records the rating or the comment of a contact replying to a CSAT survey,
+// it returns true when the message was a survey reply, the message is then stored in the surveyed conversation and must not be processed any further
+func (service *ConversationService) HandleCsatSurveyReply(ctx context.Context, params CsatSurveyReplyParams) (bool, error) {
+	switch data := params.MessageData.(type) {
+	case api_types.InteractiveReplyMessageData:
+		if data.ReplyType != api_types.ButtonReply || !strings.HasPrefix(data.Id, csatButtonIdPrefix) {
+			return false, nil
+		}
+		surveyId, rating, ok := parseCsatButtonId(data.Id)
+		if !ok {
+			return false, nil
+		}
+		return service.recordCsatRating(ctx, params, surveyId, rating)
+
+	case api_types.TextMessageData:
+		// * only a reply to the comment prompt is a comment, any other message is a new question of the contact
+		if params.RepliedToWhatsAppMessageId == "" {
+			return false, nil
+		}
+		return service.recordCsatComment(ctx, params, data.Text)
+	}
+
+	return false, nil
+}
+
+func parseCsatButtonId(buttonId string) (uuid.UUID, int, bool) {
+	parts := strings.Split(strings.TrimPrefix(buttonId, csatButtonIdPrefix), ":")
+	if len(parts) != 2 {
+		return uuid.Nil, 0, false
+	}
+
+	surveyId, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, 0, false
+	}
+
+	rating, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return uuid.Nil, 0, false
+	}
+
+	for _, csatRating := range csatRatings {
+		if csatRating.Rating == rating {
+			return surveyId, rating, true
+		}
+	}
+
+	return uuid.Nil, 0, false
+}
+
+func (service *ConversationService) recordCsatRating(ctx context.Context, params CsatSurveyReplyParams, surveyId uuid.UUID, rating int) (bool, error) {
+	configuration, err := service.GetCsatSurveyConfiguration(ctx, params.OrganizationId)
+	if err != nil {
+		return false, err
+	}
+
+	nextStatus := model.CsatSurveyStatusEnum_Completed
+	if configuration.CommentPrompt != nil {
+		nextStatus = model.CsatSurveyStatusEnum_AwaitingComment
+	}
+
+	// * only the first answer counts, tapping another button of the same survey later is ignored
+	var ratedSurveys []model.CsatSurveyResponse
+	err = table.CsatSurveyResponse.
+		UPDATE(
+			table.CsatSurveyResponse.Status,
+			table.CsatSurveyResponse.Rating,
+			table.CsatSurveyResponse.RatedAt,
+			table.CsatSurveyResponse.UpdatedAt,
+		).
+		SET(
+			utils.EnumExpression(nextStatus.String()),
+			Int(int64(rating)),
+			TimestampzT(params.SentAt),
+			TimestampzT(time.Now()),
+		).
+		WHERE(
+			table.CsatSurveyResponse.UniqueId.EQ(UUID(surveyId)).
+				AND(table.CsatSurveyResponse.OrganizationId.EQ(UUID(params.OrganizationId))).
+				AND(table.CsatSurveyResponse.Status.EQ(utils.EnumExpression(model.CsatSurveyStatusEnum_Sent.String()))).
+				AND(table.CsatSurveyResponse.ExpiresAt.GT(TimestampzT(time.Now()))),
+		).
+		RETURNING(table.CsatSurveyResponse.AllColumns).
+		QueryContext(ctx, service.Db, &ratedSurveys)
+
+	if err != nil {
+		return false, err
+	}
+
+	survey, err := service.fetchCsatSurvey(ctx, surveyId)
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			// * not a survey of ours, let the message be processed as any other reply
+			return false, nil
+		}
+		return false, err
+	}
+
+	if survey.OrganizationId != params.OrganizationId {
+		return false, nil
+	}
+
+	if err := service.storeCsatReplyMessage(ctx, params, survey.Conversation); err != nil {
+		return true, err
+	}
+
+	if len(ratedSurveys) == 0 {
+		return true, nil
+	}
+
+	followUp := configuration.ThankYouMessage
+	if nextStatus == model.CsatSurveyStatusEnum_AwaitingComment {
+		followUp = configuration.CommentPrompt
+	}
+
+	if followUp == nil {
+		return true, nil
+	}
+
+	sentMessage, err := service.SendTextMessage(ctx, survey.Conversation, survey.Contact.PhoneNumber, *followUp)
+	if err != nil {
+		return true, err
+	}
+
+	if nextStatus == model.CsatSurveyStatusEnum_AwaitingComment && sentMessage.WhatsAppMessageId != nil {
+		_, err = table.CsatSurveyResponse.
+			UPDATE(table.CsatSurveyResponse.CommentPromptWhatsAppMessageId, table.CsatSurveyResponse.UpdatedAt).
+			SET(String(*sentMessage.WhatsAppMessageId), TimestampzT(time.Now())).
+			WHERE(table.CsatSurveyResponse.UniqueId.EQ(UUID(survey.UniqueId))).
+			ExecContext(ctx, service.Db)
+
+		if err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+func (service *ConversationService) recordCsatComment(ctx context.Context, params CsatSurveyReplyParams, comment string) (bool, error) {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return false, nil
+	}
+
+	var survey csatSurveyWithConversation
+	err := SELECT(
+		table.CsatSurveyResponse.AllColumns,
+		table.Conversation.AllColumns,
+		table.Contact.AllColumns,
+	).
+		FROM(
+			table.CsatSurveyResponse.
+				LEFT_JOIN(table.Conversation, table.Conversation.UniqueId.EQ(table.CsatSurveyResponse.ConversationId)).
+				LEFT_JOIN(table.Contact, table.Contact.UniqueId.EQ(table.CsatSurveyResponse.ContactId)),
+		).
+		WHERE(
+			table.CsatSurveyResponse.OrganizationId.EQ(UUID(params.OrganizationId)).
+				AND(table.Contact.PhoneNumber.EQ(String(params.ContactPhoneNumber))).
+				AND(table.CsatSurveyResponse.PhoneNumberUsed.EQ(String(params.PhoneNumberId))).
+				AND(table.CsatSurveyResponse.CommentPromptWhatsAppMessageId.EQ(String(params.RepliedToWhatsAppMessageId))).
+				AND(table.CsatSurveyResponse.Status.EQ(utils.EnumExpression(model.CsatSurveyStatusEnum_AwaitingComment.String()))).
+				AND(table.CsatSurveyResponse.RatedAt.GT(TimestampzT(time.Now().Add(-csatCommentWindow)))),
+		).
+		ORDER_BY(table.CsatSurveyResponse.RatedAt.DESC()).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &survey)
+
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	result, err := table.CsatSurveyResponse.
+		UPDATE(
+			table.CsatSurveyResponse.Status,
+			table.CsatSurveyResponse.Comment,
+			table.CsatSurveyResponse.CommentedAt,
+			table.CsatSurveyResponse.UpdatedAt,
+		).
+		SET(
+			utils.EnumExpression(model.CsatSurveyStatusEnum_Completed.String()),
+			String(comment),
+			TimestampzT(params.SentAt),
+			TimestampzT(time.Now()),
+		).
+		WHERE(
+			table.CsatSurveyResponse.UniqueId.EQ(UUID(survey.UniqueId)).
+				AND(table.CsatSurveyResponse.Status.EQ(utils.EnumExpression(model.CsatSurveyStatusEnum_AwaitingComment.String()))),
+		).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		return false, err
+	}
+
+	if res, _ := result.RowsAffected(); res == 0 {
+		return false, nil
+	}
+
+	if err := service.storeCsatReplyMessage(ctx, params, survey.Conversation); err != nil {
+		return true, err
+	}
+
+	configuration, err := service.GetCsatSurveyConfiguration(ctx, params.OrganizationId)
+	if err != nil {
+		return true, err
+	}
+
+	if configuration.ThankYouMessage != nil {
+		if _, err := service.SendTextMessage(ctx, survey.Conversation, survey.Contact.PhoneNumber, *configuration.ThankYouMessage); err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+func (service *ConversationService) fetchCsatSurvey(ctx context.Context, surveyId uuid.UUID) (*csatSurveyWithConversation, error) {
+	var survey csatSurveyWithConversation
+	err := SELECT(
+		table.CsatSurveyResponse.AllColumns,
+		table.Conversation.AllColumns,
+		table.Contact.AllColumns,
+	).
+		FROM(
+			table.CsatSurveyResponse.
+				LEFT_JOIN(table.Conversation, table.Conversation.UniqueId.EQ(table.CsatSurveyResponse.ConversationId)).
+				LEFT_JOIN(table.Contact, table.Contact.UniqueId.EQ(table.CsatSurveyResponse.ContactId)),
+		).
+		WHERE(table.CsatSurveyResponse.UniqueId.EQ(UUID(surveyId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &survey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &survey, nil
+}
+
+// storeCsatReplyMessage stores the reply of the contact in the surveyed conversation, so that it shows up in its history
+func (service *ConversationService) storeCsatReplyMessage(ctx context.Context, params CsatSurveyReplyParams, conversation model.Conversation) error {
+	messageData, err := json.Marshal(params.MessageData)
+	if err != nil {
+		return err
+	}
+	stringMessageData := string(messageData)
+
+	_, err = table.Message.
+		INSERT(table.Message.MutableColumns).
+		MODEL(model.Message{
+			WhatsAppMessageId:         &params.WhatsAppMessageId,
+			WhatsappBusinessAccountId: &params.WhatsappBusinessAccountId,
+			ConversationId:            &conversation.UniqueId,
+			ContactId:                 conversation.ContactId,
+			MessageType:               params.MessageType,
+			Status:                    model.MessageStatusEnum_Sent,
+			Direction:                 model.MessageDirectionEnum_InBound,
+			MessageData:               &stringMessageData,
+			OrganizationId:            conversation.OrganizationId,
+			PhoneNumberUsed:           params.PhoneNumberId,
+			CreatedAt:                 params.SentAt,
+			UpdatedAt:                 time.Now(),
+		}).
+		ExecContext(ctx, service.Db)
+
+	return err
+}
+
+// expireCsatSurveys closes the surveys the contacts did not answer in time,
+// a rated survey whose comment never came is completed with the rating alone
+func (service *ConversationService) expireCsatSurveys(ctx context.Context) {
+	_, err := table.CsatSurveyResponse.
+		UPDATE(table.CsatSurveyResponse.Status, table.CsatSurveyResponse.UpdatedAt).
+		SET(utils.EnumExpression(model.CsatSurveyStatusEnum_Expired.String()), TimestampzT(time.Now())).
+		WHERE(
+			table.CsatSurveyResponse.Status.EQ(utils.EnumExpression(model.CsatSurveyStatusEnum_Sent.String())).
+				AND(table.CsatSurveyResponse.ExpiresAt.LT_EQ(TimestampzT(time.Now()))),
+		).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		service.Logger.Error("error expiring unanswered csat surveys", "error", err.Error())
+	}
+
+	_, err = table.CsatSurveyResponse.
+		UPDATE(table.CsatSurveyResponse.Status, table.CsatSurveyResponse.UpdatedAt).
+		SET(utils.EnumExpression(model.CsatSurveyStatusEnum_Completed.String()), TimestampzT(time.Now())).
+		WHERE(
+			table.CsatSurveyResponse.Status.EQ(utils.EnumExpression(model.CsatSurveyStatusEnum_AwaitingComment.String())).
+				AND(table.CsatSurveyResponse.RatedAt.LT_EQ(TimestampzT(time.Now().Add(-csatCommentWindow)))),
+		).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		service.Logger.Error("error completing csat surveys awaiting a comment", "error", err.Error())
+	}
+}
+
+func trimmedOrNil(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
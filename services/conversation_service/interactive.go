@@ -0,0 +1,234 @@
+package conversation_service
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/wapikit/wapi.go/pkg/components"
+	"github.com/wapikit/wapikit/api/api_types"
+)
+
+// * limits enforced by the WhatsApp cloud API on the interactive messages
+const (
+	interactiveBodyMaxLength           = 1024
+	interactiveHeaderMaxLength         = 60
+	interactiveFooterMaxLength         = 60
+	interactiveMaxReplyButtons         = 3
+	interactiveButtonTitleMaxLength    = 20
+	interactiveButtonIdMaxLength       = 256
+	interactiveMaxListSections         = 10
+	interactiveMaxListRows             = 10
+	interactiveListTitleMaxLength      = 24
+	interactiveListRowDescriptionLimit = 72
+)
+
+type interactiveText struct {
+	Text string `json:"text"`
+}
+
+type interactiveHeader struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type interactiveReplyButton struct {
+	Type  string `json:"type"`
+	Reply struct {
+		Id    string `json:"id"`
+		Title string `json:"title"`
+	} `json:"reply"`
+}
+
+type interactiveListRow struct {
+	Id          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+type interactiveListSection struct {
+	Title string               `json:"title,omitempty"`
+	Rows  []interactiveListRow `json:"rows"`
+}
+
+type interactiveAction struct {
+	Buttons    []interactiveReplyButton `json:"buttons,omitempty"`
+	Button     string                   `json:"button,omitempty"`
+	Sections   []interactiveListSection `json:"sections,omitempty"`
+	Name       string                   `json:"name,omitempty"`
+	Parameters *struct {
+		DisplayText string `json:"display_text"`
+		Url         string `json:"url"`
+	} `json:"parameters,omitempty"`
+}
+
+type interactivePayload struct {
+	Type   string             `json:"type"`
+	Header *interactiveHeader `json:"header,omitempty"`
+	Body   interactiveText    `json:"body"`
+	Footer *interactiveText   `json:"footer,omitempty"`
+	Action interactiveAction  `json:"action"`
+}
+
+// InteractiveMessage is a reply buttons, list or CTA URL message compatible with the WhatsApp cloud API
+type InteractiveMessage struct {
+	Interactive interactivePayload
+}
+
+// NewInteractiveMessage validates the interactive message data against the cloud API limits and builds the message
+func NewInteractiveMessage(data api_types.InteractiveMessageData) (*InteractiveMessage, error) {
+	if data.Body == "" {
+		return nil, fmt.Errorf("body is required for interactive messages")
+	}
+
+	if utf8.RuneCountInString(data.Body) > interactiveBodyMaxLength {
+		return nil, fmt.Errorf("body must be at most %d characters", interactiveBodyMaxLength)
+	}
+
+	payload := interactivePayload{
+		Body: interactiveText{Text: data.Body},
+	}
+
+	if data.Header != nil && *data.Header != "" {
+		if utf8.RuneCountInString(*data.Header) > interactiveHeaderMaxLength {
+			return nil, fmt.Errorf("header must be at most %d characters", interactiveHeaderMaxLength)
+		}
+		payload.Header = &interactiveHeader{Type: "text", Text: *data.Header}
+	}
+
+	if data.Footer != nil && *data.Footer != "" {
+		if utf8.RuneCountInString(*data.Footer) > interactiveFooterMaxLength {
+			return nil, fmt.Errorf("footer must be at most %d characters", interactiveFooterMaxLength)
+		}
+		payload.Footer = &interactiveText{Text: *data.Footer}
+	}
+
+	switch data.InteractiveType {
+	case api_types.ReplyButtons:
+		if data.Buttons == nil || len(*data.Buttons) == 0 || len(*data.Buttons) > interactiveMaxReplyButtons {
+			return nil, fmt.Errorf("reply buttons message must have between 1 and %d buttons", interactiveMaxReplyButtons)
+		}
+
+		buttonIds := map[string]bool{}
+		for _, button := range *data.Buttons {
+			if button.Id == "" || utf8.RuneCountInString(button.Id) > interactiveButtonIdMaxLength {
+				return nil, fmt.Errorf("button id is required and must be at most %d characters", interactiveButtonIdMaxLength)
+			}
+			if button.Title == "" || utf8.RuneCountInString(button.Title) > interactiveButtonTitleMaxLength {
+				return nil, fmt.Errorf("button title is required and must be at most %d characters", interactiveButtonTitleMaxLength)
+			}
+			if buttonIds[button.Id] {
+				return nil, fmt.Errorf("button ids must be unique")
+			}
+			buttonIds[button.Id] = true
+
+			replyButton := interactiveReplyButton{Type: "reply"}
+			replyButton.Reply.Id = button.Id
+			replyButton.Reply.Title = button.Title
+			payload.Action.Buttons = append(payload.Action.Buttons, replyButton)
+		}
+		payload.Type = "button"
+
+	case api_types.List:
+		if data.ListButtonText == nil || *data.ListButtonText == "" || utf8.RuneCountInString(*data.ListButtonText) > interactiveButtonTitleMaxLength {
+			return nil, fmt.Errorf("list button text is required and must be at most %d characters", interactiveButtonTitleMaxLength)
+		}
+
+		if data.Sections == nil || len(*data.Sections) == 0 || len(*data.Sections) > interactiveMaxListSections {
+			return nil, fmt.Errorf("list message must have between 1 and %d sections", interactiveMaxListSections)
+		}
+
+		// * section titles are required by the cloud API only when the list has more than one section
+		isSectionTitleRequired := len(*data.Sections) > 1
+		totalRows := 0
+		rowIds := map[string]bool{}
+
+		for _, section := range *data.Sections {
+			if (isSectionTitleRequired && section.Title == "") || utf8.RuneCountInString(section.Title) > interactiveListTitleMaxLength {
+				return nil, fmt.Errorf("section title must be at most %d characters and is required for multiple sections", interactiveListTitleMaxLength)
+			}
+
+			listSection := interactiveListSection{Title: section.Title}
+			for _, row := range section.Rows {
+				if row.Id == "" || utf8.RuneCountInString(row.Id) > interactiveButtonIdMaxLength {
+					return nil, fmt.Errorf("row id is required and must be at most %d characters", interactiveButtonIdMaxLength)
+				}
+				if row.Title == "" || utf8.RuneCountInString(row.Title) > interactiveListTitleMaxLength {
+					return nil, fmt.Errorf("row title is required and must be at most %d characters", interactiveListTitleMaxLength)
+				}
+				if rowIds[row.Id] {
+					return nil, fmt.Errorf("row ids must be unique across the list")
+				}
+				rowIds[row.Id] = true
+
+				listRow := interactiveListRow{Id: row.Id, Title: row.Title}
+				if row.Description != nil {
+					if utf8.RuneCountInString(*row.Description) > interactiveListRowDescriptionLimit {
+						return nil, fmt.Errorf("row description must be at most %d characters", interactiveListRowDescriptionLimit)
+					}
+					listRow.Description = *row.Description
+				}
+				listSection.Rows = append(listSection.Rows, listRow)
+				totalRows++
+			}
+
+			if len(listSection.Rows) == 0 {
+				return nil, fmt.Errorf("each section must have at least one row")
+			}
+			payload.Action.Sections = append(payload.Action.Sections, listSection)
+		}
+
+		if totalRows > interactiveMaxListRows {
+			return nil, fmt.Errorf("list message can have at most %d rows in total", interactiveMaxListRows)
+		}
+
+		payload.Action.Button = *data.ListButtonText
+		payload.Type = "list"
+
+	case api_types.CtaUrl:
+		if data.CtaUrl == nil || data.CtaUrl.Url == "" {
+			return nil, fmt.Errorf("cta url is required for cta url messages")
+		}
+		if data.CtaUrl.DisplayText == "" || utf8.RuneCountInString(data.CtaUrl.DisplayText) > interactiveButtonTitleMaxLength {
+			return nil, fmt.Errorf("cta display text is required and must be at most %d characters", interactiveButtonTitleMaxLength)
+		}
+
+		payload.Action.Name = "cta_url"
+		payload.Action.Parameters = &struct {
+			DisplayText string `json:"display_text"`
+			Url         string `json:"url"`
+		}{
+			DisplayText: data.CtaUrl.DisplayText,
+			Url:         data.CtaUrl.Url,
+		}
+		payload.Type = "cta_url"
+
+	default:
+		return nil, fmt.Errorf("unsupported interactive message type: %s", data.InteractiveType)
+	}
+
+	return &InteractiveMessage{Interactive: payload}, nil
+}
+
+// ToJson converts the interactive message to the cloud API compatible JSON payload
+func (message *InteractiveMessage) ToJson(configs components.ApiCompatibleJsonConverterConfigs) ([]byte, error) {
+	if configs.SendToPhoneNumber == "" {
+		return nil, fmt.Errorf("send to phone number is required")
+	}
+
+	jsonData := map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"recipient_type":    "individual",
+		"to":                configs.SendToPhoneNumber,
+		"type":              "interactive",
+		"interactive":       message.Interactive,
+	}
+
+	if configs.ReplyToMessageId != "" {
+		jsonData["context"] = map[string]string{
+			"message_id": configs.ReplyToMessageId,
+		}
+	}
+
+	return json.Marshal(jsonData)
+}
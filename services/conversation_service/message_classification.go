@@ -0,0 +1,362 @@
+package conversation_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	"github.com/wapikit/wapikit/services/ai_service"
+	"github.com/wapikit/wapikit/utils"
+)
+
+const (
+	// * a burst of inbound messages waits in the queue, the messages arriving once it is full are left unclassified
+	messageClassificationQueueSize = 1000
+	messageClassificationWorkers   = 4
+	messageClassificationTimeout   = time.Minute
+)
+
+// conversationPriorities are the priorities of a conversation from the lowest to the highest
+var conversationPriorities = []model.ConversationPriorityEnum{
+	model.ConversationPriorityEnum_Low,
+	model.ConversationPriorityEnum_Normal,
+	model.ConversationPriorityEnum_High,
+	model.ConversationPriorityEnum_Urgent,
+}
+
+type messageClassificationQueue struct {
+	startWorkers sync.Once
+	messages     chan model.Message
+}
+
+func (service *ConversationService) ParseMessageClassificationConfigurationToApiSchema(configuration model.MessageClassificationConfiguration) api_types.MessageClassificationConfigurationSchema {
+	response := api_types.MessageClassificationConfigurationSchema{
+		IsEnabled:          configuration.IsEnabled,
+		EscalationPriority: api_types.ConversationPriorityEnum(configuration.EscalationPriority.String()),
+	}
+	if configuration.EscalationOrganizationRoleId != nil {
+		roleId := configuration.EscalationOrganizationRoleId.String()
+		response.EscalationRoleId = &roleId
+	}
+	return response
+}
+
+// GetMessageClassificationConfiguration returns the message classification configuration of the organization, a disabled default one if the organization never configured it
+func (service *ConversationService) GetMessageClassificationConfiguration(ctx context.Context, organizationId uuid.UUID) (*model.MessageClassificationConfiguration, error) {
+	var configuration model.MessageClassificationConfiguration
+	err := SELECT(table.MessageClassificationConfiguration.AllColumns).
+		FROM(table.MessageClassificationConfiguration).
+		WHERE(table.MessageClassificationConfiguration.OrganizationId.EQ(UUID(organizationId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &configuration)
+
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return &model.MessageClassificationConfiguration{
+				OrganizationId:     organizationId,
+				IsEnabled:          false,
+				EscalationPriority: model.ConversationPriorityEnum_Urgent,
+			}, nil
+		}
+		return nil, err
+	}
+
+	return &configuration, nil
+}
+
+// UpdateMessageClassificationConfiguration creates or updates the message classification configuration of the organization
+func (service *ConversationService) UpdateMessageClassificationConfiguration(ctx context.Context, organizationId uuid.UUID, payload api_types.UpdateMessageClassificationConfigurationSchema) (*model.MessageClassificationConfiguration, error) {
+	escalationPriority := model.ConversationPriorityEnum_Urgent
+	if payload.EscalationPriority != nil {
+		priority := model.ConversationPriorityEnum(*payload.EscalationPriority)
+		if !slices.Contains(conversationPriorities, priority) {
+			return nil, fmt.Errorf("invalid escalation priority")
+		}
+		escalationPriority = priority
+	}
+
+	var escalationRoleId *uuid.UUID
+	if payload.EscalationRoleId != nil && *payload.EscalationRoleId != "" {
+		roleId, err := uuid.Parse(*payload.EscalationRoleId)
+		if err != nil {
+			return nil, fmt.Errorf("invalid escalation role id")
+		}
+
+		var roles []model.OrganizationRole
+		err = SELECT(table.OrganizationRole.UniqueId).
+			FROM(table.OrganizationRole).
+			WHERE(
+				table.OrganizationRole.UniqueId.EQ(UUID(roleId)).
+					AND(table.OrganizationRole.OrganizationId.EQ(UUID(organizationId))),
+			).
+			LIMIT(1).
+			QueryContext(ctx, service.Db, &roles)
+
+		if err != nil {
+			return nil, err
+		}
+		if len(roles) == 0 {
+			return nil, fmt.Errorf("escalation role not found")
+		}
+		escalationRoleId = &roleId
+	}
+
+	configuration := model.MessageClassificationConfiguration{
+		OrganizationId:               organizationId,
+		IsEnabled:                    payload.IsEnabled,
+		EscalationOrganizationRoleId: escalationRoleId,
+		EscalationPriority:           escalationPriority,
+		CreatedAt:                    time.Now(),
+		UpdatedAt:                    time.Now(),
+	}
+
+	var updatedConfiguration model.MessageClassificationConfiguration
+	err := table.MessageClassificationConfiguration.
+		INSERT(table.MessageClassificationConfiguration.MutableColumns).
+		MODEL(configuration).
+		ON_CONFLICT(table.MessageClassificationConfiguration.OrganizationId).
+		DO_UPDATE(SET(
+			table.MessageClassificationConfiguration.IsEnabled.SET(table.MessageClassificationConfiguration.EXCLUDED.IsEnabled),
+			table.MessageClassificationConfiguration.EscalationOrganizationRoleId.SET(table.MessageClassificationConfiguration.EXCLUDED.EscalationOrganizationRoleId),
+			table.MessageClassificationConfiguration.EscalationPriority.SET(table.MessageClassificationConfiguration.EXCLUDED.EscalationPriority),
+			table.MessageClassificationConfiguration.UpdatedAt.SET(table.MessageClassificationConfiguration.EXCLUDED.UpdatedAt),
+		)).
+		RETURNING(table.MessageClassificationConfiguration.AllColumns).
+		QueryContext(ctx, service.Db, &updatedConfiguration)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedConfiguration, nil
+}
+
+// QueueMessageClassification queues an inbound message to be classified in the background, it never blocks,
+// the message is left unclassified when the queue is full
+func (service *ConversationService) QueueMessageClassification(message model.Message) {
+	if message.Direction != model.MessageDirectionEnum_InBound || message.ConversationId == nil {
+		return
+	}
+
+	queue := service.messageClassifications
+	queue.startWorkers.Do(func() {
+		for range messageClassificationWorkers {
+			go service.runMessageClassificationWorker()
+		}
+	})
+
+	select {
+	case queue.messages <- message:
+	default:
+		service.Logger.Warn("message classification queue is full, the message is left unclassified", "message_id", message.UniqueId.String())
+	}
+}
+
+func (service *ConversationService) runMessageClassificationWorker() {
+	for message := range service.messageClassifications.messages {
+		ctx, cancel := context.WithTimeout(context.Background(), messageClassificationTimeout)
+		if err := service.ClassifyMessage(ctx, message); err != nil {
+			service.Logger.Error("error classifying message", "message_id", message.UniqueId.String(), "error", err.Error())
+		}
+		cancel()
+	}
+}
+
+// ClassifyMessage stores the sentiment, the intent and the language of an inbound message on the message and its conversation,
+// raises the priority of the conversation accordingly and escalates it to the escalation team of the organization when it reaches
+// the escalation priority, nothing is done when the organization did not enable the classification or the AI
+func (service *ConversationService) ClassifyMessage(ctx context.Context, message model.Message) error {
+	if message.ConversationId == nil {
+		return nil
+	}
+
+	configuration, err := service.GetMessageClassificationConfiguration(ctx, message.OrganizationId)
+	if err != nil {
+		return err
+	}
+	if !configuration.IsEnabled {
+		return nil
+	}
+
+	aiService, err := ai_service.NewOrganizationAiService(ctx, service.Logger, service.Redis, service.Db, message.OrganizationId, service.AiServiceConfig)
+	if err != nil {
+		if errors.Is(err, ai_service.ErrAiNotEnabled) || errors.Is(err, ai_service.ErrAiBudgetExceeded) {
+			return nil
+		}
+		return err
+	}
+
+	classification, err := aiService.ClassifyMessage(ctx, message)
+	if err != nil {
+		if errors.Is(err, ai_service.ErrNothingToClassify) {
+			return nil
+		}
+		return err
+	}
+
+	var language *string
+	if classification.Language != "" {
+		language = &classification.Language
+	}
+
+	classifiedAt := time.Now()
+	_, err = table.Message.
+		UPDATE(table.Message.Sentiment, table.Message.Intent, table.Message.Language, table.Message.ClassifiedAt).
+		MODEL(model.Message{
+			Sentiment:    &classification.Sentiment,
+			Intent:       &classification.Intent,
+			Language:     language,
+			ClassifiedAt: &classifiedAt,
+		}).
+		WHERE(table.Message.UniqueId.EQ(UUID(message.UniqueId))).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		return err
+	}
+
+	var conversation model.Conversation
+	err = SELECT(table.Conversation.AllColumns).
+		FROM(table.Conversation).
+		WHERE(table.Conversation.UniqueId.EQ(UUID(*message.ConversationId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &conversation)
+
+	if err != nil {
+		return err
+	}
+
+	if language == nil {
+		language = conversation.Language
+	}
+
+	_, err = table.Conversation.
+		UPDATE(table.Conversation.Sentiment, table.Conversation.Intent, table.Conversation.Language, table.Conversation.UpdatedAt).
+		MODEL(model.Conversation{
+			Sentiment: &classification.Sentiment,
+			Intent:    &classification.Intent,
+			Language:  language,
+			UpdatedAt: time.Now(),
+		}).
+		WHERE(table.Conversation.UniqueId.EQ(UUID(conversation.UniqueId))).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		return err
+	}
+
+	// * the priority of a conversation is only ever raised, a friendly follow up does not bury a complaint nobody answered yet.
+	// * the raise is conditional on the stored priority so that of the messages classified at once only one escalates the conversation
+	priority := messagePriority(*classification)
+	isEscalationPriority := comparePriorities(priority, configuration.EscalationPriority) >= 0
+	isEscalated := false
+	if isEscalationPriority {
+		isEscalated, err = service.raiseConversationPriority(ctx, conversation.UniqueId, priority, configuration.EscalationPriority)
+		if err != nil {
+			return err
+		}
+	}
+	if !isEscalated {
+		if _, err := service.raiseConversationPriority(ctx, conversation.UniqueId, priority, priority); err != nil {
+			return err
+		}
+	}
+
+	if !isEscalated || configuration.EscalationOrganizationRoleId == nil || conversation.Status != model.ConversationStatusEnum_Active {
+		return nil
+	}
+
+	return service.escalateConversation(ctx, conversation, *configuration.EscalationOrganizationRoleId)
+}
+
+// raiseConversationPriority sets the priority of the conversation when its stored priority is lower than the given bound,
+// the enum values are ordered by priority in the database, it reports whether the conversation was updated
+func (service *ConversationService) raiseConversationPriority(ctx context.Context, conversationId uuid.UUID, priority model.ConversationPriorityEnum, below model.ConversationPriorityEnum) (bool, error) {
+	result, err := table.Conversation.
+		UPDATE(table.Conversation.Priority).
+		SET(utils.EnumExpression(priority.String())).
+		WHERE(
+			table.Conversation.UniqueId.EQ(UUID(conversationId)).
+				AND(table.Conversation.Priority.LT(utils.EnumExpression(below.String()))),
+		).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+// escalateConversation assigns the conversation to the escalation team, unless an organization member is already on it
+func (service *ConversationService) escalateConversation(ctx context.Context, conversation model.Conversation, roleId uuid.UUID) error {
+	var assignments []model.ConversationAssignment
+	err := SELECT(table.ConversationAssignment.ConversationId).
+		FROM(table.ConversationAssignment).
+		WHERE(
+			table.ConversationAssignment.ConversationId.EQ(UUID(conversation.UniqueId)).
+				AND(table.ConversationAssignment.Status.EQ(utils.EnumExpression(model.ConversationAssignmentStatus_Assigned.String()))),
+		).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &assignments)
+
+	if err != nil {
+		return err
+	}
+	if len(assignments) > 0 {
+		return nil
+	}
+
+	_, err = service.AssignConversationToTeam(ctx, conversation.OrganizationId, conversation.UniqueId, roleId)
+	if errors.Is(err, ErrTeamHasNoMembers) {
+		// * the conversation stays unassigned in the inbox, it is still sorted first by its priority
+		return nil
+	}
+	return err
+}
+
+// messagePriority is the priority the conversation deserves for the message, complaints and unhappy contacts come first
+func messagePriority(classification ai_service.MessageClassification) model.ConversationPriorityEnum {
+	isNegative := classification.Sentiment == model.MessageSentimentEnum_Negative
+	switch {
+	case isNegative && classification.Intent == model.MessageIntentEnum_Complaint:
+		return model.ConversationPriorityEnum_Urgent
+	case isNegative, classification.Intent == model.MessageIntentEnum_Complaint, classification.Intent == model.MessageIntentEnum_Purchase:
+		return model.ConversationPriorityEnum_High
+	default:
+		return model.ConversationPriorityEnum_Normal
+	}
+}
+
+// comparePriorities returns a positive number when the priority a is higher than b, a negative one when it is lower and 0 when they are equal
+func comparePriorities(a, b model.ConversationPriorityEnum) int {
+	return slices.Index(conversationPriorities, a) - slices.Index(conversationPriorities, b)
+}
+
+// SetConversationClassificationOfApiSchema copies the classification and the priority of the conversation onto its api schema
+func (service *ConversationService) SetConversationClassificationOfApiSchema(schema *api_types.ConversationSchema, conversation model.Conversation) {
+	if conversation.Sentiment != nil {
+		sentiment := api_types.MessageSentimentEnum(conversation.Sentiment.String())
+		schema.Sentiment = &sentiment
+	}
+	if conversation.Intent != nil {
+		intent := api_types.MessageIntentEnum(conversation.Intent.String())
+		schema.Intent = &intent
+	}
+	schema.Language = conversation.Language
+	priority := api_types.ConversationPriorityEnum(conversation.Priority.String())
+	schema.Priority = &priority
+}
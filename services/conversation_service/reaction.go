@@ -0,0 +1,77 @@
+package conversation_service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+)
+
+// ParseMessageReactions parses the reactions stored on a message record
+func (service *ConversationService) ParseMessageReactions(reactions *string) *[]api_types.MessageReactionSchema {
+	if reactions == nil || *reactions == "" {
+		return nil
+	}
+
+	var parsedReactions []api_types.MessageReactionSchema
+	if err := json.Unmarshal([]byte(*reactions), &parsedReactions); err != nil {
+		service.Logger.Error("failed to parse message reactions", "error", err.Error())
+		return nil
+	}
+
+	if len(parsedReactions) == 0 {
+		return nil
+	}
+
+	return &parsedReactions
+}
+
+// ApplyMessageReaction attaches a reaction to the message with the given whatsapp message id.
+// WhatsApp allows a single reaction per participant, so a new reaction replaces the previous one from the same direction,
+// and an empty reaction removes it.
+func (service *ConversationService) ApplyMessageReaction(ctx context.Context, organizationId uuid.UUID, whatsAppMessageId, reaction string, direction model.MessageDirectionEnum, reactedAt time.Time) (*model.Message, error) {
+	newReactions := []api_types.MessageReactionSchema{}
+	if reaction != "" {
+		newReactions = append(newReactions, api_types.MessageReactionSchema{
+			Reaction:  reaction,
+			Direction: api_types.MessageDirectionEnum(direction.String()),
+			ReactedAt: reactedAt,
+		})
+	}
+
+	newReactionsJson, err := json.Marshal(newReactions)
+	if err != nil {
+		return nil, err
+	}
+
+	// * the reactions are replaced in a single statement, so concurrent reactions on the same message never overwrite each other
+	reactionsExpression := Raw(
+		`COALESCE((SELECT jsonb_agg(existingReaction) FROM jsonb_array_elements(COALESCE("Message"."Reactions", '[]'::jsonb)) AS existingReaction WHERE existingReaction ->> 'direction' <> #direction), '[]'::jsonb) || #newReactions::jsonb`,
+		RawArgs{
+			"#direction":    direction.String(),
+			"#newReactions": string(newReactionsJson),
+		},
+	)
+
+	var updatedMessage model.Message
+	err = table.Message.
+		UPDATE(table.Message.Reactions, table.Message.UpdatedAt).
+		SET(reactionsExpression, TimestampzT(time.Now())).
+		WHERE(
+			table.Message.WhatsAppMessageId.EQ(String(whatsAppMessageId)).
+				AND(table.Message.OrganizationId.EQ(UUID(organizationId))),
+		).
+		RETURNING(table.Message.AllColumns).
+		QueryContext(ctx, service.Db, &updatedMessage)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedMessage, nil
+}
@@ -0,0 +1,205 @@
+package conversation_service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/paulbellamy/ratecounter"
+)
+
+// ! https://developers.facebook.com/docs/whatsapp/cloud-api/guides/mark-message-as-read
+// ! https://developers.facebook.com/docs/whatsapp/cloud-api/typing-indicators
+// * marking a message as read marks all the earlier messages of the thread as read too, so only the latest
+// * inbound message of a conversation is sent, the pending updates are batched per conversation for that reason.
+
+const (
+	whatsAppGraphApiUrl = "https://graph.facebook.com/v21.0"
+
+	statusUpdatesPerSecondLimit = 20
+	statusUpdateFlushInterval   = 500 * time.Millisecond
+	statusUpdateWorkerIdleTime  = time.Minute
+	// * whatsapp shows the typing indicator for 25 seconds or until a message is sent, no need to refresh it more often
+	typingIndicatorMinInterval = 10 * time.Second
+)
+
+type StatusUpdateParams struct {
+	PhoneNumberId     string
+	AccessToken       string
+	ConversationId    string
+	WhatsAppMessageId string
+	// ShowTypingIndicator shows the typing indicator to the contact along with the read receipt
+	ShowTypingIndicator bool
+}
+
+type statusUpdateWorker struct {
+	mutex                 sync.Mutex
+	pending               map[string]StatusUpdateParams
+	lastTypingIndicatorAt map[string]time.Time
+	rateLimiter           *ratecounter.RateCounter
+	lastActivityAt        time.Time
+}
+
+type statusUpdateDispatcher struct {
+	mutex      sync.Mutex
+	workers    map[string]*statusUpdateWorker
+	httpClient *http.Client
+}
+
+// QueueStatusUpdate queues a read receipt, optionally with a typing indicator, for an inbound message,
+// the updates are batched and rate limited per phone number and sent in the background
+func (service *ConversationService) QueueStatusUpdate(params StatusUpdateParams) {
+	if params.PhoneNumberId == "" || params.AccessToken == "" || params.WhatsAppMessageId == "" {
+		return
+	}
+
+	dispatcher := service.statusUpdates
+	dispatcher.mutex.Lock()
+	defer dispatcher.mutex.Unlock()
+
+	worker, exists := dispatcher.workers[params.PhoneNumberId]
+	if !exists {
+		worker = &statusUpdateWorker{
+			pending:               make(map[string]StatusUpdateParams),
+			lastTypingIndicatorAt: make(map[string]time.Time),
+			rateLimiter:           ratecounter.NewRateCounter(1 * time.Second),
+			lastActivityAt:        time.Now(),
+		}
+		dispatcher.workers[params.PhoneNumberId] = worker
+		go service.runStatusUpdateWorker(params.PhoneNumberId, worker)
+	}
+
+	worker.mutex.Lock()
+	defer worker.mutex.Unlock()
+
+	key := params.ConversationId
+	if key == "" {
+		key = params.WhatsAppMessageId
+	}
+
+	if existing, ok := worker.pending[key]; ok && existing.ShowTypingIndicator {
+		// * a newer read receipt must not drop the typing indicator requested in the same batch
+		params.ShowTypingIndicator = true
+	}
+
+	if params.ShowTypingIndicator {
+		if lastShownAt, ok := worker.lastTypingIndicatorAt[key]; ok && time.Since(lastShownAt) < typingIndicatorMinInterval {
+			// * the indicator is still visible to the contact, only the read receipt of the new message is sent
+			params.ShowTypingIndicator = false
+		}
+	}
+
+	worker.pending[key] = params
+	worker.lastActivityAt = time.Now()
+}
+
+func (service *ConversationService) runStatusUpdateWorker(phoneNumberId string, worker *statusUpdateWorker) {
+	ticker := time.NewTicker(statusUpdateFlushInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		worker.mutex.Lock()
+		batch := worker.pending
+		worker.pending = make(map[string]StatusUpdateParams)
+		for key, lastShownAt := range worker.lastTypingIndicatorAt {
+			if time.Since(lastShownAt) > typingIndicatorMinInterval {
+				delete(worker.lastTypingIndicatorAt, key)
+			}
+		}
+		isIdle := len(batch) == 0 && time.Since(worker.lastActivityAt) > statusUpdateWorkerIdleTime
+		worker.mutex.Unlock()
+
+		if isIdle && service.stopIdleStatusUpdateWorker(phoneNumberId, worker) {
+			return
+		}
+
+		for key, update := range batch {
+			for worker.rateLimiter.Rate() >= statusUpdatesPerSecondLimit {
+				time.Sleep(50 * time.Millisecond)
+			}
+			worker.rateLimiter.Incr(1)
+
+			if err := service.sendStatusUpdate(update); err != nil {
+				service.Logger.Error("error sending read receipt to whatsapp", "phone_number_id", phoneNumberId, "error", err.Error())
+				continue
+			}
+
+			if update.ShowTypingIndicator {
+				worker.mutex.Lock()
+				worker.lastTypingIndicatorAt[key] = time.Now()
+				worker.mutex.Unlock()
+			}
+		}
+	}
+}
+
+// stopIdleStatusUpdateWorker removes the worker when nothing has been queued in a while, it reports whether the worker must exit
+func (service *ConversationService) stopIdleStatusUpdateWorker(phoneNumberId string, worker *statusUpdateWorker) bool {
+	dispatcher := service.statusUpdates
+	dispatcher.mutex.Lock()
+	defer dispatcher.mutex.Unlock()
+
+	worker.mutex.Lock()
+	defer worker.mutex.Unlock()
+
+	// * an update may have been queued after the idle check
+	if len(worker.pending) > 0 {
+		return false
+	}
+
+	delete(dispatcher.workers, phoneNumberId)
+	return true
+}
+
+func (service *ConversationService) sendStatusUpdate(update StatusUpdateParams) error {
+	payload := map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"status":            "read",
+		"message_id":        update.WhatsAppMessageId,
+	}
+
+	if update.ShowTypingIndicator {
+		payload["typing_indicator"] = map[string]string{
+			"type": "text",
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("%s/%s/messages", whatsAppGraphApiUrl, update.PhoneNumberId),
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+update.AccessToken)
+
+	response, err := service.statusUpdates.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		return fmt.Errorf("whatsapp status update failed with status %d: %s", response.StatusCode, string(responseBody))
+	}
+
+	return nil
+}
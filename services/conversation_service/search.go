@@ -0,0 +1,133 @@
+package conversation_service
+
+import (
+	"context"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	"github.com/wapikit/wapikit/utils"
+)
+
+// * these expressions must stay in sync with the MessageFullTextSearchIndex and ContactFullTextSearchIndex indexes, otherwise postgres would not use the indexes
+const (
+	messageSearchDocument = `to_tsvector('simple'::regconfig, COALESCE("Message"."MessageData" ->> 'text', '') || ' ' || COALESCE("Message"."MessageData" ->> 'caption', ''))`
+	contactSearchDocument = `to_tsvector('simple'::regconfig, COALESCE("Contact"."Name", '') || ' ' || COALESCE("Contact"."PhoneNumber", '')) || jsonb_to_tsvector('simple'::regconfig, COALESCE("Contact"."Attributes", '{}'::jsonb), '["string", "numeric"]'::jsonb)`
+
+	searchQuery            = `websearch_to_tsquery('simple'::regconfig, #query)`
+	searchHighlightOptions = `'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5'`
+)
+
+type ConversationSearchResult struct {
+	api_types.ConversationSearchResultSchema
+	TotalResults int `json:"totalResults"`
+}
+
+// ConversationVisibilityCondition returns the condition to filter the conversations visible to an organization member,
+// owners can see all the conversations of the organization, members can see the unassigned conversations and the ones assigned to them.
+func (service *ConversationService) ConversationVisibilityCondition(organizationId, organizationMemberId uuid.UUID, isOwner bool) BoolExpression {
+	condition := table.Conversation.OrganizationId.EQ(UUID(organizationId)).
+		AND(table.Conversation.Status.NOT_EQ(utils.EnumExpression(model.ConversationStatusEnum_Deleted.String())))
+
+	if isOwner {
+		return condition
+	}
+
+	assignedConversationsQuery := SELECT(table.ConversationAssignment.ConversationId).
+		FROM(table.ConversationAssignment).
+		WHERE(table.ConversationAssignment.Status.EQ(utils.EnumExpression(model.ConversationAssignmentStatus_Assigned.String())))
+
+	memberConversationsQuery := SELECT(table.ConversationAssignment.ConversationId).
+		FROM(table.ConversationAssignment).
+		WHERE(
+			table.ConversationAssignment.Status.EQ(utils.EnumExpression(model.ConversationAssignmentStatus_Assigned.String())).
+				AND(table.ConversationAssignment.AssignedToOrganizationMemberId.EQ(UUID(organizationMemberId))),
+		)
+
+	return condition.AND(
+		table.Conversation.UniqueId.NOT_IN(assignedConversationsQuery).
+			OR(table.Conversation.UniqueId.IN(memberConversationsQuery)),
+	)
+}
+
+// SearchConversations runs a full-text search over the text messages, media captions and contact details,
+// the results are ranked by relevance and the matched terms are highlighted.
+func (service *ConversationService) SearchConversations(ctx context.Context, query string, visibilityCondition BoolExpression, page, perPage int64) ([]ConversationSearchResult, error) {
+	queryArgs := RawArgs{"#query": query}
+
+	messageHitsQuery := SELECT(
+		table.Conversation.UniqueId.AS("ConversationSearchResultSchema.conversationId"),
+		table.Message.UniqueId.AS("ConversationSearchResultSchema.messageId"),
+		table.Contact.UniqueId.AS("ConversationSearchResultSchema.contactId"),
+		table.Contact.Name.AS("ConversationSearchResultSchema.contactName"),
+		table.Contact.PhoneNumber.AS("ConversationSearchResultSchema.contactPhone"),
+		String(string(api_types.MessageContent)).AS("ConversationSearchResultSchema.matchType"),
+		RawString(
+			`ts_headline('simple'::regconfig, COALESCE("Message"."MessageData" ->> 'text', "Message"."MessageData" ->> 'caption', ''), `+searchQuery+`, `+searchHighlightOptions+`)`,
+			queryArgs,
+		).AS("ConversationSearchResultSchema.highlight"),
+		RawFloat(`ts_rank(`+messageSearchDocument+`, `+searchQuery+`)`, queryArgs).AS("ConversationSearchResultSchema.rank"),
+		table.Message.CreatedAt.AS("ConversationSearchResultSchema.matchedAt"),
+	).
+		FROM(
+			table.Message.
+				INNER_JOIN(table.Conversation, table.Conversation.UniqueId.EQ(table.Message.ConversationId)).
+				INNER_JOIN(table.Contact, table.Contact.UniqueId.EQ(table.Conversation.ContactId)),
+		).
+		WHERE(
+			visibilityCondition.
+				AND(table.Message.MessageType.IN(
+					utils.EnumExpression(model.MessageTypeEnum_Text.String()),
+					utils.EnumExpression(model.MessageTypeEnum_Image.String()),
+					utils.EnumExpression(model.MessageTypeEnum_Video.String()),
+					utils.EnumExpression(model.MessageTypeEnum_Document.String()),
+				)).
+				AND(RawBool(messageSearchDocument+` @@ `+searchQuery, queryArgs)),
+		)
+
+	contactHitsQuery := SELECT(
+		table.Conversation.UniqueId.AS("ConversationSearchResultSchema.conversationId"),
+		NULL.AS("ConversationSearchResultSchema.messageId"),
+		table.Contact.UniqueId.AS("ConversationSearchResultSchema.contactId"),
+		table.Contact.Name.AS("ConversationSearchResultSchema.contactName"),
+		table.Contact.PhoneNumber.AS("ConversationSearchResultSchema.contactPhone"),
+		String(string(api_types.ContactDetails)).AS("ConversationSearchResultSchema.matchType"),
+		RawString(
+			`ts_headline('simple'::regconfig, "Contact"."Name" || ' ' || "Contact"."PhoneNumber" || ' ' || COALESCE((SELECT string_agg(value, ' ') FROM jsonb_each_text("Contact"."Attributes")), ''), `+searchQuery+`, `+searchHighlightOptions+`)`,
+			queryArgs,
+		).AS("ConversationSearchResultSchema.highlight"),
+		RawFloat(`ts_rank(`+contactSearchDocument+`, `+searchQuery+`)`, queryArgs).AS("ConversationSearchResultSchema.rank"),
+		table.Conversation.CreatedAt.AS("ConversationSearchResultSchema.matchedAt"),
+	).
+		FROM(
+			table.Conversation.
+				INNER_JOIN(table.Contact, table.Contact.UniqueId.EQ(table.Conversation.ContactId)),
+		).
+		WHERE(
+			visibilityCondition.
+				AND(RawBool(contactSearchDocument+` @@ `+searchQuery, queryArgs)),
+		)
+
+	searchHits := UNION_ALL(messageHitsQuery, contactHitsQuery).AsTable("searchHits")
+	rankColumn := FloatColumn("ConversationSearchResultSchema.rank").From(searchHits)
+	matchedAtColumn := TimestampzColumn("ConversationSearchResultSchema.matchedAt").From(searchHits)
+
+	var results []ConversationSearchResult
+	err := SELECT(
+		searchHits.AllColumns(),
+		COUNT(STAR).OVER().AS("totalResults"),
+	).
+		FROM(searchHits).
+		ORDER_BY(rankColumn.DESC(), matchedAtColumn.DESC()).
+		LIMIT(perPage).
+		OFFSET((page-1)*perPage).
+		QueryContext(ctx, service.Db, &results)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
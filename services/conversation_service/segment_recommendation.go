@@ -0,0 +1,372 @@
+package conversation_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	"github.com/wapikit/wapikit/services/ai_service"
+	"github.com/wapikit/wapikit/services/event_service"
+	"github.com/wapikit/wapikit/utils"
+)
+
+const (
+	// MaxSegmentRecommendationContacts caps the size of the lists a segment recommendation job can classify, every contact is a call to the AI model
+	MaxSegmentRecommendationContacts    = 5000
+	segmentRecommendationContactTimeout = time.Minute
+	// * e.g. a revoked API key fails every contact, the job stops instead of calling the model for the whole list
+	segmentRecommendationMaxConsecutiveFailures = 10
+)
+
+// SegmentRecommendationJobParameters are the inputs of a segment recommendation job
+type SegmentRecommendationJobParameters struct {
+	// TagIds are the tags the contacts are classified into, the AI proposes its own labels when empty
+	TagIds []string `json:"tagIds,omitempty"`
+}
+
+type CreateSegmentRecommendationJobParams struct {
+	OrganizationId                uuid.UUID
+	CreatedByOrganizationMemberId uuid.UUID
+	ContactListId                 uuid.UUID
+	Parameters                    SegmentRecommendationJobParameters
+}
+
+func (service *ConversationService) ParseSegmentRecommendationJobToApiSchema(job model.SegmentRecommendationJob) api_types.SegmentRecommendationJobSchema {
+	return api_types.SegmentRecommendationJobSchema{
+		UniqueId:       job.UniqueId.String(),
+		ContactListId:  job.ContactListId.String(),
+		Status:         api_types.ConversationBulkActionJobStatusEnum(job.Status.String()),
+		TotalCount:     int(job.TotalCount),
+		ProcessedCount: int(job.ProcessedCount),
+		FailedCount:    int(job.FailedCount),
+		Error:          job.Error,
+		CreatedAt:      job.CreatedAt,
+		StartedAt:      job.StartedAt,
+		CompletedAt:    job.CompletedAt,
+	}
+}
+
+// CreateSegmentRecommendationJob queues the classification of the contacts of a list, the job must be started with StartSegmentRecommendationJob
+func (service *ConversationService) CreateSegmentRecommendationJob(ctx context.Context, params CreateSegmentRecommendationJobParams) (*model.SegmentRecommendationJob, error) {
+	parametersJson, err := json.Marshal(params.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	parameters := string(parametersJson)
+
+	jobToInsert := model.SegmentRecommendationJob{
+		CreatedAt:                     time.Now(),
+		UpdatedAt:                     time.Now(),
+		OrganizationId:                params.OrganizationId,
+		CreatedByOrganizationMemberId: params.CreatedByOrganizationMemberId,
+		ContactListId:                 params.ContactListId,
+		Status:                        model.ConversationBulkActionJobStatusEnum_Queued,
+		Parameters:                    &parameters,
+	}
+
+	var insertedJob model.SegmentRecommendationJob
+	err = table.SegmentRecommendationJob.
+		INSERT(table.SegmentRecommendationJob.MutableColumns).
+		MODEL(jobToInsert).
+		RETURNING(table.SegmentRecommendationJob.AllColumns).
+		QueryContext(ctx, service.Db, &insertedJob)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &insertedJob, nil
+}
+
+// StartSegmentRecommendationJob runs the job in the background, detached from the request that created it
+func (service *ConversationService) StartSegmentRecommendationJob(jobId uuid.UUID) {
+	go service.RunSegmentRecommendationJob(context.Background(), jobId)
+}
+
+// RunSegmentRecommendationJob asks the AI model of the organization for the tags of every contact of the list, based on the
+// messages of all the conversations of the contact, tags the contact with them and publishes the progress after every contact,
+// it is a blocking function and must be executed in a go routine
+func (service *ConversationService) RunSegmentRecommendationJob(ctx context.Context, jobId uuid.UUID) {
+	var job model.SegmentRecommendationJob
+
+	// * the status check makes sure a job is run only once, even if the api server and the scheduler race
+	err := table.SegmentRecommendationJob.
+		UPDATE(
+			table.SegmentRecommendationJob.Status,
+			table.SegmentRecommendationJob.StartedAt,
+			table.SegmentRecommendationJob.UpdatedAt,
+		).
+		SET(
+			utils.EnumExpression(model.ConversationBulkActionJobStatusEnum_InProgress.String()),
+			TimestampzT(time.Now()),
+			TimestampzT(time.Now()),
+		).
+		WHERE(
+			table.SegmentRecommendationJob.UniqueId.EQ(UUID(jobId)).
+				AND(table.SegmentRecommendationJob.Status.EQ(utils.EnumExpression(model.ConversationBulkActionJobStatusEnum_Queued.String()))),
+		).
+		RETURNING(table.SegmentRecommendationJob.AllColumns).
+		QueryContext(ctx, service.Db, &job)
+
+	if err != nil {
+		if !errors.Is(err, qrm.ErrNoRows) {
+			service.Logger.Error("error starting segment recommendation job", "job_id", jobId.String(), "error", err.Error())
+		}
+		return
+	}
+
+	var parameters SegmentRecommendationJobParameters
+	if job.Parameters != nil {
+		if err := json.Unmarshal([]byte(*job.Parameters), &parameters); err != nil {
+			service.finishSegmentRecommendationJob(ctx, &job, fmt.Errorf("invalid job parameters: %w", err))
+			return
+		}
+	}
+
+	allowedLabels, err := service.resolveSegmentRecommendationLabels(ctx, job.OrganizationId, parameters.TagIds)
+	if err != nil {
+		service.finishSegmentRecommendationJob(ctx, &job, err)
+		return
+	}
+
+	aiService, err := ai_service.NewOrganizationAiService(ctx, service.Logger, service.Redis, service.Db, job.OrganizationId, service.AiServiceConfig)
+	if err != nil {
+		service.finishSegmentRecommendationJob(ctx, &job, err)
+		return
+	}
+	aiService.OrganizationMemberId = &job.CreatedByOrganizationMemberId
+
+	var contactListContacts []model.ContactListContact
+	err = SELECT(table.ContactListContact.ContactId).
+		FROM(table.ContactListContact.
+			INNER_JOIN(table.Contact, table.Contact.UniqueId.EQ(table.ContactListContact.ContactId)),
+		).
+		WHERE(
+			table.ContactListContact.ContactListId.EQ(UUID(job.ContactListId)).
+				AND(table.Contact.OrganizationId.EQ(UUID(job.OrganizationId))),
+		).
+		ORDER_BY(table.ContactListContact.ContactId.ASC()).
+		LIMIT(MaxSegmentRecommendationContacts).
+		QueryContext(ctx, service.Db, &contactListContacts)
+
+	if err != nil {
+		service.finishSegmentRecommendationJob(ctx, &job, err)
+		return
+	}
+
+	job.TotalCount = int32(len(contactListContacts))
+	service.updateSegmentRecommendationJobProgress(ctx, &job)
+
+	consecutiveFailures := 0
+	for _, contactListContact := range contactListContacts {
+		err := service.classifyContact(ctx, aiService, job.OrganizationId, contactListContact.ContactId, allowedLabels)
+		if err != nil {
+			service.Logger.Error("error classifying contact", "job_id", job.UniqueId.String(), "contact_id", contactListContact.ContactId.String(), "error", err.Error())
+			job.FailedCount++
+			consecutiveFailures++
+		} else {
+			consecutiveFailures = 0
+		}
+
+		job.ProcessedCount++
+		service.updateSegmentRecommendationJobProgress(ctx, &job)
+
+		if consecutiveFailures == segmentRecommendationMaxConsecutiveFailures {
+			service.finishSegmentRecommendationJob(ctx, &job, fmt.Errorf("the last %d contacts could not be classified: %w", consecutiveFailures, err))
+			return
+		}
+	}
+
+	service.finishSegmentRecommendationJob(ctx, &job, nil)
+}
+
+// resolveSegmentRecommendationLabels returns the labels of the tags the contacts are classified into, none when the AI proposes its own
+func (service *ConversationService) resolveSegmentRecommendationLabels(ctx context.Context, organizationId uuid.UUID, tagIds []string) ([]string, error) {
+	if len(tagIds) == 0 {
+		return nil, nil
+	}
+
+	tagUuidExpressions := make([]Expression, 0, len(tagIds))
+	for _, tagId := range tagIds {
+		tagUuid, err := uuid.Parse(tagId)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag id %s", tagId)
+		}
+		tagUuidExpressions = append(tagUuidExpressions, UUID(tagUuid))
+	}
+
+	var tags []model.Tag
+	err := SELECT(table.Tag.Label).
+		FROM(table.Tag).
+		WHERE(
+			table.Tag.UniqueId.IN(tagUuidExpressions...).
+				AND(table.Tag.OrganizationId.EQ(UUID(organizationId))),
+		).
+		QueryContext(ctx, service.Db, &tags)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) == 0 {
+		return nil, errors.New("none of the tags to classify the contacts into exists anymore")
+	}
+
+	labels := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		labels = append(labels, tag.Label)
+	}
+
+	return labels, nil
+}
+
+// classifyContact tags the contact with the tags the AI model recommends for it, the contacts without attributes and messages are left untagged
+func (service *ConversationService) classifyContact(ctx context.Context, aiService *ai_service.AiService, organizationId, contactId uuid.UUID, allowedLabels []string) error {
+	ctx, cancel := context.WithTimeout(ctx, segmentRecommendationContactTimeout)
+	defer cancel()
+
+	var contact model.Contact
+	err := SELECT(table.Contact.AllColumns).
+		FROM(table.Contact).
+		WHERE(
+			table.Contact.UniqueId.EQ(UUID(contactId)).
+				AND(table.Contact.OrganizationId.EQ(UUID(organizationId))),
+		).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &contact)
+
+	if err != nil {
+		return err
+	}
+
+	messages, err := aiService.FetchMessagesForSegmentRecommendation(ctx, contactId, nil)
+	if err != nil {
+		return err
+	}
+
+	labels, err := aiService.RecommendSegments(ctx, ai_service.SegmentRecommendationInput{
+		OrganizationId: organizationId,
+		Contact:        contact,
+		Messages:       messages,
+		AllowedLabels:  allowedLabels,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(labels) == 0 {
+		return nil
+	}
+
+	tags, err := ai_service.EnsureOrganizationTags(ctx, service.Db, organizationId, labels)
+	if err != nil {
+		return err
+	}
+
+	return ai_service.TagContact(ctx, service.Db, contactId, tags)
+}
+
+func (service *ConversationService) updateSegmentRecommendationJobProgress(ctx context.Context, job *model.SegmentRecommendationJob) {
+	job.UpdatedAt = time.Now()
+
+	_, err := table.SegmentRecommendationJob.
+		UPDATE(
+			table.SegmentRecommendationJob.TotalCount,
+			table.SegmentRecommendationJob.ProcessedCount,
+			table.SegmentRecommendationJob.FailedCount,
+			table.SegmentRecommendationJob.UpdatedAt,
+		).
+		MODEL(*job).
+		WHERE(table.SegmentRecommendationJob.UniqueId.EQ(UUID(job.UniqueId))).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		service.Logger.Error("error updating segment recommendation job progress", "job_id", job.UniqueId.String(), "error", err.Error())
+	}
+
+	service.publishSegmentRecommendationJobProgress(*job)
+}
+
+func (service *ConversationService) finishSegmentRecommendationJob(ctx context.Context, job *model.SegmentRecommendationJob, jobErr error) {
+	completedAt := time.Now()
+	job.CompletedAt = &completedAt
+	job.UpdatedAt = completedAt
+	job.Status = model.ConversationBulkActionJobStatusEnum_Completed
+
+	if jobErr != nil {
+		errorMessage := jobErr.Error()
+		job.Status = model.ConversationBulkActionJobStatusEnum_Errored
+		job.Error = &errorMessage
+	}
+
+	_, err := table.SegmentRecommendationJob.
+		UPDATE(
+			table.SegmentRecommendationJob.Status,
+			table.SegmentRecommendationJob.Error,
+			table.SegmentRecommendationJob.CompletedAt,
+			table.SegmentRecommendationJob.UpdatedAt,
+		).
+		MODEL(*job).
+		WHERE(table.SegmentRecommendationJob.UniqueId.EQ(UUID(job.UniqueId))).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		service.Logger.Error("error finishing segment recommendation job", "job_id", job.UniqueId.String(), "error", err.Error())
+	}
+
+	service.publishSegmentRecommendationJobProgress(*job)
+}
+
+func (service *ConversationService) publishSegmentRecommendationJobProgress(job model.SegmentRecommendationJob) {
+	orgId := job.OrganizationId.String()
+	event := event_service.NewSegmentRecommendationProgressEvent(service.ParseSegmentRecommendationJobToApiSchema(job), &orgId)
+	service.Redis.PublishMessageToRedisChannel(service.Redis.RedisApiServerEventChannelName, event.ToJson())
+}
+
+// resumeSegmentRecommendationJobs starts the queued jobs left behind and fails the ones that stopped making progress, e.g. because the server restarted
+func (service *ConversationService) resumeSegmentRecommendationJobs(ctx context.Context) {
+	var staleJobs []model.SegmentRecommendationJob
+	err := SELECT(table.SegmentRecommendationJob.AllColumns).
+		FROM(table.SegmentRecommendationJob).
+		WHERE(
+			table.SegmentRecommendationJob.Status.EQ(utils.EnumExpression(model.ConversationBulkActionJobStatusEnum_InProgress.String())).
+				AND(table.SegmentRecommendationJob.UpdatedAt.LT(TimestampzT(time.Now().Add(-bulkActionStaleAfter)))),
+		).
+		QueryContext(ctx, service.Db, &staleJobs)
+
+	if err != nil {
+		service.Logger.Error("error fetching stale segment recommendation jobs", "error", err.Error())
+	}
+
+	for _, job := range staleJobs {
+		service.finishSegmentRecommendationJob(ctx, &job, fmt.Errorf("the job stopped making progress, %d of %d contacts were classified", job.ProcessedCount, job.TotalCount))
+	}
+
+	var queuedJobs []model.SegmentRecommendationJob
+	err = SELECT(table.SegmentRecommendationJob.UniqueId).
+		FROM(table.SegmentRecommendationJob).
+		WHERE(
+			table.SegmentRecommendationJob.Status.EQ(utils.EnumExpression(model.ConversationBulkActionJobStatusEnum_Queued.String())).
+				AND(table.SegmentRecommendationJob.CreatedAt.LT(TimestampzT(time.Now().Add(-bulkActionQueuedPickupDelay)))),
+		).
+		ORDER_BY(table.SegmentRecommendationJob.CreatedAt.ASC()).
+		LIMIT(10).
+		QueryContext(ctx, service.Db, &queuedJobs)
+
+	if err != nil {
+		service.Logger.Error("error fetching queued segment recommendation jobs", "error", err.Error())
+		return
+	}
+
+	for _, job := range queuedJobs {
+		service.StartSegmentRecommendationJob(job.UniqueId)
+	}
+}
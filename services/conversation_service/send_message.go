@@ -0,0 +1,136 @@
+package conversation_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	"github.com/wapikit/wapikit/utils"
+)
+
+// SendMessage sends a message to the contact of the conversation from the phone number of the conversation and stores it,
+// it is used for the messages sent by the system on behalf of the organization, e.g. the CSAT surveys
+func (service *ConversationService) SendMessage(ctx context.Context, conversation model.Conversation, contactPhoneNumber string, data api_types.NewMessageDataSchema) (*model.Message, error) {
+	return service.sendMessage(ctx, conversation, contactPhoneNumber, data, false)
+}
+
+// SendAiMessage sends a message written by the AI auto responder, it is stored labelled as AI generated, see SendMessage
+func (service *ConversationService) SendAiMessage(ctx context.Context, conversation model.Conversation, contactPhoneNumber string, data api_types.NewMessageDataSchema) (*model.Message, error) {
+	return service.sendMessage(ctx, conversation, contactPhoneNumber, data, true)
+}
+
+func (service *ConversationService) sendMessage(ctx context.Context, conversation model.Conversation, contactPhoneNumber string, data api_types.NewMessageDataSchema, isAiGenerated bool) (*model.Message, error) {
+	if service.BusinessAccountService == nil {
+		return nil, fmt.Errorf("business account service is not configured")
+	}
+
+	phoneNumber, err := service.BusinessAccountService.GetPhoneNumber(ctx, conversation.OrganizationId, conversation.PhoneNumberUsed)
+	if err != nil {
+		return nil, err
+	}
+
+	discriminator, err := data.Discriminator()
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := service.BuildSendMessagePayload(discriminator, data)
+	if err != nil {
+		return nil, err
+	}
+
+	messagingClient := service.BusinessAccountService.NewClient(phoneNumber.WhatsappBusinessAccount).NewMessagingClient(conversation.PhoneNumberUsed)
+	response, err := messagingClient.Message.Send(payload, contactPhoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Messages) == 0 {
+		return nil, fmt.Errorf("whatsapp did not return the id of the sent message")
+	}
+	whatsAppMessageId := response.Messages[0].ID
+
+	messageData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	stringMessageData := string(messageData)
+
+	messageToInsert := model.Message{
+		ConversationId:            &conversation.UniqueId,
+		ContactId:                 conversation.ContactId,
+		Direction:                 model.MessageDirectionEnum_OutBound,
+		Status:                    model.MessageStatusEnum_Sent,
+		MessageType:               model.MessageTypeEnum(discriminator),
+		OrganizationId:            conversation.OrganizationId,
+		WhatsAppMessageId:         &whatsAppMessageId,
+		WhatsappBusinessAccountId: &phoneNumber.WhatsappBusinessAccount.AccountId,
+		PhoneNumberUsed:           conversation.PhoneNumberUsed,
+		MessageData:               &stringMessageData,
+		IsAiGenerated:             isAiGenerated,
+		CreatedAt:                 time.Now(),
+		UpdatedAt:                 time.Now(),
+	}
+
+	var insertedMessage model.Message
+	err = table.Message.
+		INSERT(table.Message.MutableColumns).
+		MODEL(messageToInsert).
+		RETURNING(table.Message.AllColumns).
+		QueryContext(ctx, service.Db, &insertedMessage)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &insertedMessage, nil
+}
+
+// SendTextMessage sends a text message to the contact of the conversation, see SendMessage
+func (service *ConversationService) SendTextMessage(ctx context.Context, conversation model.Conversation, contactPhoneNumber, text string) (*model.Message, error) {
+	var data api_types.NewMessageDataSchema
+	if err := data.FromTextMessageData(api_types.TextMessageData{Text: text}); err != nil {
+		return nil, err
+	}
+	return service.SendMessage(ctx, conversation, contactPhoneNumber, data)
+}
+
+// SendAiTextMessage sends a text message written by the AI auto responder, see SendAiMessage
+func (service *ConversationService) SendAiTextMessage(ctx context.Context, conversation model.Conversation, contactPhoneNumber, text string) (*model.Message, error) {
+	var data api_types.NewMessageDataSchema
+	if err := data.FromTextMessageData(api_types.TextMessageData{Text: text}); err != nil {
+		return nil, err
+	}
+	return service.SendAiMessage(ctx, conversation, contactPhoneNumber, data)
+}
+
+// lastInboundMessageAt returns when the contact last wrote in the conversation, nil if the contact never did
+func (service *ConversationService) lastInboundMessageAt(ctx context.Context, conversationId uuid.UUID) (*time.Time, error) {
+	var lastMessage model.Message
+	err := SELECT(table.Message.CreatedAt).
+		FROM(table.Message).
+		WHERE(
+			table.Message.ConversationId.EQ(UUID(conversationId)).
+				AND(table.Message.Direction.EQ(utils.EnumExpression(model.MessageDirectionEnum_InBound.String()))),
+		).
+		ORDER_BY(table.Message.CreatedAt.DESC()).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &lastMessage)
+
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &lastMessage.CreatedAt, nil
+}
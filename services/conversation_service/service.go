@@ -5,18 +5,33 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"time"
 
 	"github.com/wapikit/wapi.go/pkg/components"
 	"github.com/wapikit/wapikit/.db-generated/model"
 	"github.com/wapikit/wapikit/api/api_types"
+	"github.com/wapikit/wapikit/services/ai_service"
+	"github.com/wapikit/wapikit/services/business_account_service"
+	"github.com/wapikit/wapikit/services/notification_service"
 	cache_service "github.com/wapikit/wapikit/services/redis_service"
 	"github.com/wapikit/wapikit/utils"
 )
 
 type ConversationService struct {
-	Logger *slog.Logger
-	Redis  *cache_service.RedisClient
-	Db     *sql.DB
+	Logger              *slog.Logger
+	Redis               *cache_service.RedisClient
+	Db                  *sql.DB
+	NotificationService *notification_service.NotificationService
+	// BusinessAccountService resolves the phone numbers the read receipts of the bulk actions are sent from
+	BusinessAccountService *business_account_service.BusinessAccountService
+	// TranscriptFallbackFonts draw the characters of the pdf transcripts the embedded font does not cover
+	TranscriptFallbackFonts []*TranscriptFont
+	// AiServiceConfig is the configuration the AI services of the organizations are created with
+	AiServiceConfig ai_service.AiServiceConfig
+
+	statusUpdates          *statusUpdateDispatcher
+	messageClassifications *messageClassificationQueue
 }
 
 // NewConversationService creates a new instance of the ConversationService
@@ -25,6 +40,13 @@ func NewConversationService(db *sql.DB, logger *slog.Logger, redis *cache_servic
 		Logger: logger,
 		Redis:  redis,
 		Db:     db,
+		statusUpdates: &statusUpdateDispatcher{
+			workers:    make(map[string]*statusUpdateWorker),
+			httpClient: &http.Client{Timeout: 30 * time.Second},
+		},
+		messageClassifications: &messageClassificationQueue{
+			messages: make(chan model.Message, messageClassificationQueueSize),
+		},
 	}
 }
 
@@ -39,6 +61,18 @@ func (service *ConversationService) ParseDbMessageToApiMessage(message model.Mes
 		_ = json.Unmarshal([]byte(*message.MessageData), &rawData)
 	}
 
+	var repliedTo *string
+	if message.RepliedTo != nil {
+		repliedToMessageId := message.RepliedTo.String()
+		repliedTo = &repliedToMessageId
+	}
+	reactions := service.ParseMessageReactions(message.Reactions)
+
+	var isAiGenerated *bool
+	if message.IsAiGenerated {
+		isAiGenerated = &message.IsAiGenerated
+	}
+
 	switch message.MessageType {
 	case model.MessageTypeEnum_Text:
 		textMessageData, err := utils.ConvertMapToStruct[api_types.TextMessageData](rawData)
@@ -54,6 +88,9 @@ func (service *ConversationService) ParseDbMessageToApiMessage(message model.Mes
 			Direction:      api_types.MessageDirectionEnum(message.Direction.String()),
 			MessageType:    api_types.Text,
 			Status:         api_types.MessageStatusEnum(message.Status.String()),
+			RepliedTo:      repliedTo,
+			Reactions:      reactions,
+			IsAiGenerated:  isAiGenerated,
 			MessageData:    *textMessageData,
 		}
 		_ = apiMsg.FromTextMessage(textMsg)
@@ -71,6 +108,9 @@ func (service *ConversationService) ParseDbMessageToApiMessage(message model.Mes
 			Direction:      api_types.MessageDirectionEnum(message.Direction.String()),
 			MessageType:    api_types.Audio,
 			Status:         api_types.MessageStatusEnum(message.Status.String()),
+			RepliedTo:      repliedTo,
+			Reactions:      reactions,
+			IsAiGenerated:  isAiGenerated,
 			MessageData:    *audioMessageData,
 		}
 		_ = apiMsg.FromAudioMessage(audioMsg)
@@ -88,6 +128,9 @@ func (service *ConversationService) ParseDbMessageToApiMessage(message model.Mes
 			Direction:      api_types.MessageDirectionEnum(message.Direction.String()),
 			MessageType:    api_types.Video,
 			Status:         api_types.MessageStatusEnum(message.Status.String()),
+			RepliedTo:      repliedTo,
+			Reactions:      reactions,
+			IsAiGenerated:  isAiGenerated,
 			MessageData:    *videoMessageData,
 		}
 		_ = apiMsg.FromVideoMessage(videoMsg)
@@ -105,6 +148,9 @@ func (service *ConversationService) ParseDbMessageToApiMessage(message model.Mes
 			Direction:      api_types.MessageDirectionEnum(message.Direction.String()),
 			MessageType:    api_types.Image,
 			Status:         api_types.MessageStatusEnum(message.Status.String()),
+			RepliedTo:      repliedTo,
+			Reactions:      reactions,
+			IsAiGenerated:  isAiGenerated,
 			MessageData:    *imageMessageData,
 		}
 		_ = apiMsg.FromImageMessage(imageMsg)
@@ -122,6 +168,9 @@ func (service *ConversationService) ParseDbMessageToApiMessage(message model.Mes
 			Direction:      api_types.MessageDirectionEnum(message.Direction.String()),
 			MessageType:    api_types.Document,
 			Status:         api_types.MessageStatusEnum(message.Status.String()),
+			RepliedTo:      repliedTo,
+			Reactions:      reactions,
+			IsAiGenerated:  isAiGenerated,
 			MessageData:    *documentMessageData,
 		}
 		_ = apiMsg.FromDocumentMessage(documentMsg)
@@ -139,6 +188,9 @@ func (service *ConversationService) ParseDbMessageToApiMessage(message model.Mes
 			Direction:      api_types.MessageDirectionEnum(message.Direction.String()),
 			MessageType:    api_types.Sticker,
 			Status:         api_types.MessageStatusEnum(message.Status.String()),
+			RepliedTo:      repliedTo,
+			Reactions:      reactions,
+			IsAiGenerated:  isAiGenerated,
 			MessageData:    *stickerMessageData,
 		}
 		_ = apiMsg.FromStickerMessage(stickerMsg)
@@ -157,10 +209,141 @@ func (service *ConversationService) ParseDbMessageToApiMessage(message model.Mes
 			Direction:      api_types.MessageDirectionEnum(message.Direction.String()),
 			MessageType:    api_types.Reaction,
 			Status:         api_types.MessageStatusEnum(message.Status.String()),
+			RepliedTo:      repliedTo,
+			Reactions:      reactions,
+			IsAiGenerated:  isAiGenerated,
 			MessageData:    *reactionMessageData,
 		}
 		_ = apiMsg.FromReactionMessage(reactionMsg)
 
+	case model.MessageTypeEnum_Interactive:
+		interactiveMessageData, err := utils.ConvertMapToStruct[api_types.InteractiveMessageData](rawData)
+		if err != nil {
+			service.Logger.Error("failed to convert message data to interactive message data", err.Error(), nil)
+			return apiMsg
+		}
+
+		interactiveMsg := api_types.InteractiveMessage{
+			UniqueId:       message.UniqueId.String(),
+			ConversationId: message.ConversationId.String(),
+			CreatedAt:      message.CreatedAt,
+			Direction:      api_types.MessageDirectionEnum(message.Direction.String()),
+			MessageType:    api_types.Interactive,
+			Status:         api_types.MessageStatusEnum(message.Status.String()),
+			RepliedTo:      repliedTo,
+			Reactions:      reactions,
+			IsAiGenerated:  isAiGenerated,
+			MessageData:    *interactiveMessageData,
+		}
+		_ = apiMsg.FromInteractiveMessage(interactiveMsg)
+
+	case model.MessageTypeEnum_InteractiveReply:
+		interactiveReplyMessageData, err := utils.ConvertMapToStruct[api_types.InteractiveReplyMessageData](rawData)
+		if err != nil {
+			service.Logger.Error("failed to convert message data to interactive reply message data", err.Error(), nil)
+			return apiMsg
+		}
+
+		interactiveReplyMessageData.RepliedToMessageId = repliedTo
+
+		interactiveReplyMsg := api_types.InteractiveReplyMessage{
+			UniqueId:       message.UniqueId.String(),
+			ConversationId: message.ConversationId.String(),
+			CreatedAt:      message.CreatedAt,
+			Direction:      api_types.MessageDirectionEnum(message.Direction.String()),
+			MessageType:    api_types.InteractiveReply,
+			Status:         api_types.MessageStatusEnum(message.Status.String()),
+			RepliedTo:      repliedTo,
+			Reactions:      reactions,
+			IsAiGenerated:  isAiGenerated,
+			MessageData:    *interactiveReplyMessageData,
+		}
+		_ = apiMsg.FromInteractiveReplyMessage(interactiveReplyMsg)
+
+	case model.MessageTypeEnum_Location:
+		locationMessageData, err := utils.ConvertMapToStruct[api_types.LocationMessageData](rawData)
+		if err != nil {
+			service.Logger.Error("failed to convert message data to location message data", err.Error(), nil)
+			return apiMsg
+		}
+
+		locationMsg := api_types.LocationMessage{
+			UniqueId:       message.UniqueId.String(),
+			ConversationId: message.ConversationId.String(),
+			CreatedAt:      message.CreatedAt,
+			Direction:      api_types.MessageDirectionEnum(message.Direction.String()),
+			MessageType:    api_types.Location,
+			Status:         api_types.MessageStatusEnum(message.Status.String()),
+			RepliedTo:      repliedTo,
+			Reactions:      reactions,
+			IsAiGenerated:  isAiGenerated,
+			MessageData:    *locationMessageData,
+		}
+		_ = apiMsg.FromLocationMessage(locationMsg)
+
+	case model.MessageTypeEnum_Contacts:
+		contactsMessageData, err := utils.ConvertMapToStruct[api_types.ContactsMessageData](rawData)
+		if err != nil {
+			service.Logger.Error("failed to convert message data to contacts message data", err.Error(), nil)
+			return apiMsg
+		}
+
+		contactsMsg := api_types.ContactsMessage{
+			UniqueId:       message.UniqueId.String(),
+			ConversationId: message.ConversationId.String(),
+			CreatedAt:      message.CreatedAt,
+			Direction:      api_types.MessageDirectionEnum(message.Direction.String()),
+			MessageType:    api_types.Contacts,
+			Status:         api_types.MessageStatusEnum(message.Status.String()),
+			RepliedTo:      repliedTo,
+			Reactions:      reactions,
+			IsAiGenerated:  isAiGenerated,
+			MessageData:    *contactsMessageData,
+		}
+		_ = apiMsg.FromContactsMessage(contactsMsg)
+
+	case model.MessageTypeEnum_Order:
+		orderMessageData, err := utils.ConvertMapToStruct[api_types.OrderMessageData](rawData)
+		if err != nil {
+			service.Logger.Error("failed to convert message data to order message data", err.Error(), nil)
+			return apiMsg
+		}
+
+		orderMsg := api_types.OrderMessage{
+			UniqueId:       message.UniqueId.String(),
+			ConversationId: message.ConversationId.String(),
+			CreatedAt:      message.CreatedAt,
+			Direction:      api_types.MessageDirectionEnum(message.Direction.String()),
+			MessageType:    api_types.Order,
+			Status:         api_types.MessageStatusEnum(message.Status.String()),
+			RepliedTo:      repliedTo,
+			Reactions:      reactions,
+			IsAiGenerated:  isAiGenerated,
+			MessageData:    *orderMessageData,
+		}
+		_ = apiMsg.FromOrderMessage(orderMsg)
+
+	case model.MessageTypeEnum_ProductInquiry:
+		productInquiryMessageData, err := utils.ConvertMapToStruct[api_types.ProductInquiryMessageData](rawData)
+		if err != nil {
+			service.Logger.Error("failed to convert message data to product inquiry message data", err.Error(), nil)
+			return apiMsg
+		}
+
+		productInquiryMsg := api_types.ProductInquiryMessage{
+			UniqueId:       message.UniqueId.String(),
+			ConversationId: message.ConversationId.String(),
+			CreatedAt:      message.CreatedAt,
+			Direction:      api_types.MessageDirectionEnum(message.Direction.String()),
+			MessageType:    api_types.ProductInquiry,
+			Status:         api_types.MessageStatusEnum(message.Status.String()),
+			RepliedTo:      repliedTo,
+			Reactions:      reactions,
+			IsAiGenerated:  isAiGenerated,
+			MessageData:    *productInquiryMessageData,
+		}
+		_ = apiMsg.FromProductInquiryMessage(productInquiryMsg)
+
 	default:
 		service.Logger.Error("unsupported message type", message.MessageType.String(), nil)
 	}
@@ -292,6 +475,17 @@ func (service *ConversationService) BuildSendMessagePayload(messageType string,
 		}
 		return reactionMsg, nil
 
+	case string(api_types.Interactive):
+		interactiveData, err := utils.ConvertMapToStruct[api_types.InteractiveMessageData](dataMap)
+		if err != nil {
+			return nil, err
+		}
+		interactiveMsg, err := NewInteractiveMessage(*interactiveData)
+		if err != nil {
+			return nil, err
+		}
+		return interactiveMsg, nil
+
 	default:
 		return nil, fmt.Errorf("unsupported message type: %s", messageType)
 	}
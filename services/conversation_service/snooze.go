@@ -0,0 +1,274 @@
+package conversation_service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/services/notification_service"
+	"github.com/wapikit/wapikit/utils"
+)
+
+const (
+	conversationSchedulerLockKey  = "conversation_scheduler_lock"
+	conversationSchedulerInterval = 30 * time.Second
+)
+
+// SnoozeConversation snoozes a conversation until the given time and/or until the contact replies
+func (service *ConversationService) SnoozeConversation(ctx context.Context, conversationId uuid.UUID, snoozedUntil *time.Time, untilContactReplies bool) error {
+	if snoozedUntil == nil && !untilContactReplies {
+		return fmt.Errorf("either snoozed until time or until contact replies is required")
+	}
+
+	var snoozedUntilExpression Expression = NULL
+	if snoozedUntil != nil {
+		if snoozedUntil.Before(time.Now()) {
+			return fmt.Errorf("snoozed until time must be in the future")
+		}
+		snoozedUntilExpression = TimestampzT(*snoozedUntil)
+	}
+
+	_, err := table.Conversation.
+		UPDATE(
+			table.Conversation.Status,
+			table.Conversation.SnoozedUntil,
+			table.Conversation.IsSnoozedUntilContactReply,
+			table.Conversation.UpdatedAt,
+		).
+		SET(
+			utils.EnumExpression(model.ConversationStatusEnum_Snoozed.String()),
+			snoozedUntilExpression,
+			untilContactReplies,
+			time.Now(),
+		).
+		WHERE(table.Conversation.UniqueId.EQ(UUID(conversationId))).
+		ExecContext(ctx, service.Db)
+
+	return err
+}
+
+// ReopenSnoozedConversation moves a snoozed conversation back to active and notifies the assignee about it
+func (service *ConversationService) ReopenSnoozedConversation(ctx context.Context, conversationId uuid.UUID, reason string) error {
+	var reopenedConversation struct {
+		model.Conversation
+		Contact model.Contact
+	}
+
+	// * the status check in the where clause makes sure a conversation is reopened only once, even if the scheduler and the webhook race
+	err := table.Conversation.
+		UPDATE(
+			table.Conversation.Status,
+			table.Conversation.SnoozedUntil,
+			table.Conversation.IsSnoozedUntilContactReply,
+			table.Conversation.UpdatedAt,
+		).
+		SET(
+			utils.EnumExpression(model.ConversationStatusEnum_Active.String()),
+			NULL,
+			false,
+			time.Now(),
+		).
+		WHERE(
+			table.Conversation.UniqueId.EQ(UUID(conversationId)).
+				AND(table.Conversation.Status.EQ(utils.EnumExpression(model.ConversationStatusEnum_Snoozed.String()))),
+		).
+		RETURNING(table.Conversation.AllColumns).
+		QueryContext(ctx, service.Db, &reopenedConversation.Conversation)
+
+	if err != nil {
+		return err
+	}
+
+	err = SELECT(table.Contact.AllColumns).
+		FROM(table.Contact).
+		WHERE(table.Contact.UniqueId.EQ(UUID(reopenedConversation.ContactId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &reopenedConversation.Contact)
+
+	if err != nil {
+		service.Logger.Error("error fetching contact of the reopened conversation", "error", err.Error())
+	}
+
+	assigneeId, err := service.GetConversationAssigneeId(ctx, conversationId)
+	if err != nil || assigneeId == nil {
+		// * nobody is assigned to the conversation, nobody to notify
+		return nil
+	}
+
+	contactName := reopenedConversation.Contact.Name
+	if contactName == "" {
+		contactName = reopenedConversation.Contact.PhoneNumber
+	}
+
+	service.sendConversationNotification(
+		reopenedConversation.Conversation,
+		*assigneeId,
+		"Conversation reopened",
+		fmt.Sprintf("Your snoozed conversation with %s has been reopened, %s.", contactName, reason),
+	)
+
+	return nil
+}
+
+// RunScheduler reopens the conversations whose snooze time is over, fires the due follow-up reminders, resumes the bulk action and
+// segment recommendation jobs left behind and expires the unanswered CSAT surveys,
+// it is a blocking function and must be executed in a go routine
+func (service *ConversationService) RunScheduler(ctx context.Context) {
+	ticker := time.NewTicker(conversationSchedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// * only one instance of the server must process the snoozes and reminders at a time
+			lock, err := service.Redis.AcquireLock(conversationSchedulerLockKey, conversationSchedulerInterval)
+			if err != nil {
+				continue
+			}
+
+			service.reopenExpiredSnoozes(ctx)
+			service.fireDueReminders(ctx)
+			service.resumeBulkActionJobs(ctx)
+			service.resumeSegmentRecommendationJobs(ctx)
+			service.expireCsatSurveys(ctx)
+
+			if err := service.Redis.ReleaseLock(lock); err != nil {
+				service.Logger.Error("error releasing conversation scheduler lock", "error", err.Error())
+			}
+		}
+	}
+}
+
+func (service *ConversationService) reopenExpiredSnoozes(ctx context.Context) {
+	var expiredConversations []model.Conversation
+
+	err := SELECT(table.Conversation.AllColumns).
+		FROM(table.Conversation).
+		WHERE(
+			table.Conversation.Status.EQ(utils.EnumExpression(model.ConversationStatusEnum_Snoozed.String())).
+				AND(table.Conversation.SnoozedUntil.IS_NOT_NULL()).
+				AND(table.Conversation.SnoozedUntil.LT_EQ(TimestampzT(time.Now()))),
+		).
+		LIMIT(500).
+		QueryContext(ctx, service.Db, &expiredConversations)
+
+	if err != nil {
+		service.Logger.Error("error fetching expired snoozed conversations", "error", err.Error())
+		return
+	}
+
+	for _, conversation := range expiredConversations {
+		err := service.ReopenSnoozedConversation(ctx, conversation.UniqueId, "the snooze time is over")
+		if err != nil {
+			service.Logger.Error("error reopening snoozed conversation", "conversation_id", conversation.UniqueId.String(), "error", err.Error())
+		}
+	}
+}
+
+func (service *ConversationService) fireDueReminders(ctx context.Context) {
+	var dueReminders []struct {
+		model.ConversationReminder
+		Conversation model.Conversation
+		Contact      model.Contact
+	}
+
+	err := SELECT(
+		table.ConversationReminder.AllColumns,
+		table.Conversation.AllColumns,
+		table.Contact.AllColumns,
+	).
+		FROM(
+			table.ConversationReminder.
+				LEFT_JOIN(table.Conversation, table.Conversation.UniqueId.EQ(table.ConversationReminder.ConversationId)).
+				LEFT_JOIN(table.Contact, table.Contact.UniqueId.EQ(table.Conversation.ContactId)),
+		).
+		WHERE(
+			table.ConversationReminder.FiredAt.IS_NULL().
+				AND(table.ConversationReminder.RemindAt.LT_EQ(TimestampzT(time.Now()))),
+		).
+		LIMIT(500).
+		QueryContext(ctx, service.Db, &dueReminders)
+
+	if err != nil {
+		service.Logger.Error("error fetching due conversation reminders", "error", err.Error())
+		return
+	}
+
+	for _, reminder := range dueReminders {
+		result, err := table.ConversationReminder.
+			UPDATE(table.ConversationReminder.FiredAt, table.ConversationReminder.UpdatedAt).
+			SET(TimestampzT(time.Now()), TimestampzT(time.Now())).
+			WHERE(
+				table.ConversationReminder.UniqueId.EQ(UUID(reminder.UniqueId)).
+					AND(table.ConversationReminder.FiredAt.IS_NULL()),
+			).
+			ExecContext(ctx, service.Db)
+
+		if err != nil {
+			service.Logger.Error("error marking conversation reminder as fired", "reminder_id", reminder.UniqueId.String(), "error", err.Error())
+			continue
+		}
+
+		if res, _ := result.RowsAffected(); res == 0 {
+			continue
+		}
+
+		contactName := reminder.Contact.Name
+		if contactName == "" {
+			contactName = reminder.Contact.PhoneNumber
+		}
+
+		description := fmt.Sprintf("Follow up on your conversation with %s.", contactName)
+		if reminder.Note != nil && *reminder.Note != "" {
+			description = fmt.Sprintf("%s\n%s", description, *reminder.Note)
+		}
+
+		service.sendConversationNotification(reminder.Conversation, reminder.OrganizationMemberId, "Follow-up reminder", description)
+	}
+}
+
+// GetConversationAssigneeId returns the member the conversation is assigned to, qrm.ErrNoRows if nobody is
+func (service *ConversationService) GetConversationAssigneeId(ctx context.Context, conversationId uuid.UUID) (*uuid.UUID, error) {
+	var assignment model.ConversationAssignment
+
+	err := SELECT(table.ConversationAssignment.AllColumns).
+		FROM(table.ConversationAssignment).
+		WHERE(
+			table.ConversationAssignment.ConversationId.EQ(UUID(conversationId)).
+				AND(table.ConversationAssignment.Status.EQ(utils.EnumExpression(model.ConversationAssignmentStatus_Assigned.String()))),
+		).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &assignment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &assignment.AssignedToOrganizationMemberId, nil
+}
+
+func (service *ConversationService) sendConversationNotification(conversation model.Conversation, organizationMemberId uuid.UUID, title, description string) {
+	if service.NotificationService == nil {
+		return
+	}
+
+	orgId := conversation.OrganizationId.String()
+	memberId := organizationMemberId.String()
+	ctaUrl := fmt.Sprintf("/conversations?id=%s", conversation.UniqueId.String())
+	notificationType := "Conversation"
+
+	service.NotificationService.SendInAppNotification(notification_service.InAppNotificationParams{
+		Title:                title,
+		Description:          description,
+		OrganizationId:       &orgId,
+		OrganizationMemberId: &memberId,
+		CtaUrl:               &ctaUrl,
+		Type:                 &notificationType,
+	})
+}
@@ -0,0 +1,41 @@
+package conversation_service
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	"github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/services/ai_service"
+)
+
+// storeConversationSummary summarizes a closed conversation with the AI model of the organization,
+// only when the organization asked for the summaries to be stored on close
+func (service *ConversationService) storeConversationSummary(ctx context.Context, organizationId, conversationId uuid.UUID) error {
+	var organization model.Organization
+	err := SELECT(table.Organization.IsConversationSummaryOnCloseEnabled).
+		FROM(table.Organization).
+		WHERE(table.Organization.UniqueId.EQ(UUID(organizationId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &organization)
+
+	if err != nil {
+		return err
+	}
+
+	if !organization.IsConversationSummaryOnCloseEnabled {
+		return nil
+	}
+
+	aiService, err := ai_service.NewOrganizationAiService(ctx, service.Logger, service.Redis, service.Db, organizationId, service.AiServiceConfig)
+	if err != nil {
+		if errors.Is(err, ai_service.ErrAiNotEnabled) || errors.Is(err, ai_service.ErrAiBudgetExceeded) {
+			return nil
+		}
+		return err
+	}
+
+	return aiService.StoreClosedConversationSummary(ctx, organizationId, conversationId)
+}
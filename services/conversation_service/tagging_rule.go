@@ -0,0 +1,200 @@
+package conversation_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+)
+
+type TaggingRuleWithTag struct {
+	model.ConversationTaggingRule
+	Tag model.Tag
+}
+
+func (service *ConversationService) ParseTaggingRuleToApiSchema(rule TaggingRuleWithTag) api_types.ConversationTaggingRuleSchema {
+	keywords := []string{}
+	if rule.Keywords != nil {
+		_ = json.Unmarshal([]byte(*rule.Keywords), &keywords)
+	}
+
+	var campaignId *string
+	if rule.CampaignId != nil {
+		stringCampaignId := rule.CampaignId.String()
+		campaignId = &stringCampaignId
+	}
+
+	return api_types.ConversationTaggingRuleSchema{
+		UniqueId:       rule.UniqueId.String(),
+		Name:           rule.Name,
+		Type:           api_types.ConversationTaggingRuleTypeEnum(rule.Type.String()),
+		IsEnabled:      rule.IsEnabled,
+		Keywords:       keywords,
+		AttributeKey:   rule.AttributeKey,
+		AttributeValue: rule.AttributeValue,
+		CampaignId:     campaignId,
+		CreatedAt:      rule.CreatedAt,
+		Tag: api_types.TagSchema{
+			UniqueId: rule.Tag.UniqueId.String(),
+			Label:    rule.Tag.Label,
+		},
+	}
+}
+
+// ApplyTaggingRules tags the conversation of a new inbound message with the tags of the enabled tagging rules the message matches,
+// it returns the ids of the tags that matched
+func (service *ConversationService) ApplyTaggingRules(ctx context.Context, message model.Message) ([]uuid.UUID, error) {
+	if message.ConversationId == nil {
+		return nil, nil
+	}
+
+	var rules []model.ConversationTaggingRule
+	err := SELECT(table.ConversationTaggingRule.AllColumns).
+		FROM(table.ConversationTaggingRule).
+		WHERE(
+			table.ConversationTaggingRule.OrganizationId.EQ(UUID(message.OrganizationId)).
+				AND(table.ConversationTaggingRule.IsEnabled.IS_TRUE()),
+		).
+		QueryContext(ctx, service.Db, &rules)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	var conversation struct {
+		model.Conversation
+		Contact model.Contact
+	}
+
+	err = SELECT(table.Conversation.AllColumns, table.Contact.AllColumns).
+		FROM(table.Conversation.LEFT_JOIN(table.Contact, table.Contact.UniqueId.EQ(table.Conversation.ContactId))).
+		WHERE(table.Conversation.UniqueId.EQ(UUID(*message.ConversationId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &conversation)
+
+	if err != nil {
+		return nil, err
+	}
+
+	messageText := strings.ToLower(service.describeTranscriptMessage(service.ParseDbMessageToApiMessage(message), nil).Text)
+
+	contactAttributes := map[string]interface{}{}
+	if conversation.Contact.Attributes != nil {
+		_ = json.Unmarshal([]byte(*conversation.Contact.Attributes), &contactAttributes)
+	}
+
+	matchedTagIds := []uuid.UUID{}
+	for _, rule := range rules {
+		if taggingRuleMatches(rule, messageText, contactAttributes, conversation.InitiatedByCampaignId) {
+			matchedTagIds = append(matchedTagIds, rule.TagId)
+		}
+	}
+
+	if len(matchedTagIds) == 0 {
+		return nil, nil
+	}
+
+	insertQuery := table.ConversationTag.INSERT(
+		table.ConversationTag.ConversationId,
+		table.ConversationTag.TagId,
+		table.ConversationTag.CreatedAt,
+		table.ConversationTag.UpdatedAt,
+	)
+	for _, tagId := range matchedTagIds {
+		insertQuery = insertQuery.VALUES(*message.ConversationId, tagId, time.Now(), time.Now())
+	}
+
+	_, err = insertQuery.
+		ON_CONFLICT(table.ConversationTag.ConversationId, table.ConversationTag.TagId).
+		DO_NOTHING().
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return matchedTagIds, nil
+}
+
+func taggingRuleMatches(rule model.ConversationTaggingRule, messageText string, contactAttributes map[string]interface{}, initiatedByCampaignId *uuid.UUID) bool {
+	switch rule.Type {
+	case model.ConversationTaggingRuleTypeEnum_Keyword:
+		if rule.Keywords == nil || messageText == "" {
+			return false
+		}
+		keywords := []string{}
+		if err := json.Unmarshal([]byte(*rule.Keywords), &keywords); err != nil {
+			return false
+		}
+		for _, keyword := range keywords {
+			keyword = strings.ToLower(strings.TrimSpace(keyword))
+			if keyword != "" && strings.Contains(messageText, keyword) {
+				return true
+			}
+		}
+		return false
+
+	case model.ConversationTaggingRuleTypeEnum_ContactAttribute:
+		if rule.AttributeKey == nil {
+			return false
+		}
+		value, ok := contactAttributes[*rule.AttributeKey]
+		if !ok || value == nil {
+			return false
+		}
+		// * without an expected value, the rule matches every contact having the attribute
+		if rule.AttributeValue == nil || *rule.AttributeValue == "" {
+			return true
+		}
+		return strings.EqualFold(fmt.Sprint(value), *rule.AttributeValue)
+
+	case model.ConversationTaggingRuleTypeEnum_OriginatingCampaign:
+		if initiatedByCampaignId == nil {
+			return false
+		}
+		// * without a campaign, the rule matches the conversations started by any campaign
+		return rule.CampaignId == nil || *rule.CampaignId == *initiatedByCampaignId
+	}
+
+	return false
+}
+
+// ValidateTaggingRule checks the rule has the inputs its type needs
+func ValidateTaggingRule(payload api_types.ConversationTaggingRuleInputSchema) error {
+	if strings.TrimSpace(payload.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	switch payload.Type {
+	case api_types.Keyword:
+		if payload.Keywords == nil {
+			return fmt.Errorf("keywords are required for a keyword rule")
+		}
+		for _, keyword := range *payload.Keywords {
+			if strings.TrimSpace(keyword) != "" {
+				return nil
+			}
+		}
+		return fmt.Errorf("keywords are required for a keyword rule")
+	case api_types.ContactAttribute:
+		if payload.AttributeKey == nil || strings.TrimSpace(*payload.AttributeKey) == "" {
+			return fmt.Errorf("attribute key is required for a contact attribute rule")
+		}
+	case api_types.OriginatingCampaign:
+	default:
+		return fmt.Errorf("invalid rule type")
+	}
+
+	return nil
+}
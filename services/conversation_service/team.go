@@ -0,0 +1,91 @@
+package conversation_service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+)
+
+// ErrTeamHasNoMembers is returned when a conversation is assigned to a team (an organization role) nobody has
+var ErrTeamHasNoMembers = errors.New("team has no members")
+
+// leastBusyTeamMemberQuery picks the member of the role with the fewest active conversations assigned,
+// the ties going to the member who joined the role first
+const leastBusyTeamMemberQuery = `
+SELECT ra."OrganizationMemberId"
+FROM "RoleAssignment" ra
+JOIN "OrganizationRole" r ON r."UniqueId" = ra."OrganizationRoleId"
+LEFT JOIN "ConversationAssignment" ca
+	ON ca."AssignedToOrganizationMemberId" = ra."OrganizationMemberId" AND ca."Status" = 'Assigned'
+LEFT JOIN "Conversation" c
+	ON c."UniqueId" = ca."ConversationId" AND c."Status" = 'Active'
+WHERE ra."OrganizationRoleId" = $1 AND r."OrganizationId" = $2
+GROUP BY ra."OrganizationMemberId"
+ORDER BY COUNT(c."UniqueId") ASC, MIN(ra."CreatedAt") ASC
+LIMIT 1`
+
+// AssignConversation assigns the conversation to the organization member, unassigning whoever had it before
+func (service *ConversationService) AssignConversation(ctx context.Context, organizationId, conversationId, organizationMemberId uuid.UUID) error {
+	return service.bulkAssignConversations(
+		ctx,
+		organizationId.String(),
+		organizationMemberId,
+		[]uuid.UUID{conversationId},
+		[]Expression{UUID(conversationId)},
+	)
+}
+
+// AssignConversationToTeam assigns the conversation to the least busy member of the team and notifies them,
+// it returns the id of the member the conversation went to
+func (service *ConversationService) AssignConversationToTeam(ctx context.Context, organizationId, conversationId, roleId uuid.UUID) (*uuid.UUID, error) {
+	var memberId uuid.UUID
+	err := service.Db.QueryRowContext(ctx, leastBusyTeamMemberQuery, roleId, organizationId).Scan(&memberId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTeamHasNoMembers
+		}
+		return nil, err
+	}
+
+	err = service.AssignConversation(ctx, organizationId, conversationId, memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	var conversation struct {
+		model.Conversation
+		Contact model.Contact
+	}
+
+	err = SELECT(table.Conversation.AllColumns, table.Contact.AllColumns).
+		FROM(table.Conversation.LEFT_JOIN(table.Contact, table.Contact.UniqueId.EQ(table.Conversation.ContactId))).
+		WHERE(table.Conversation.UniqueId.EQ(UUID(conversationId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &conversation)
+
+	if err != nil {
+		// * the conversation is assigned already, only the notification is missed
+		service.Logger.Error("error fetching the conversation assigned to the team", "conversationId", conversationId.String(), "error", err.Error())
+		return &memberId, nil
+	}
+
+	contactName := conversation.Contact.Name
+	if contactName == "" {
+		contactName = conversation.Contact.PhoneNumber
+	}
+
+	service.sendConversationNotification(
+		conversation.Conversation,
+		memberId,
+		"Conversation assigned",
+		fmt.Sprintf("Your conversation with %s has been handed over to you.", contactName),
+	)
+
+	return &memberId, nil
+}
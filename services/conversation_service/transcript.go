@@ -0,0 +1,510 @@
+package conversation_service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+)
+
+const (
+	TranscriptEntryTypeMessage = "message"
+	TranscriptEntryTypeNote    = "note"
+)
+
+// ConversationTranscript is a printable snapshot of a conversation, it is rendered as html or pdf
+type ConversationTranscript struct {
+	ConversationId     string
+	OrganizationName   string
+	ContactName        string
+	ContactPhoneNumber string
+	PhoneNumberUsed    string
+	Status             string
+	StartedAt          time.Time
+	GeneratedAt        time.Time
+	Entries            []TranscriptEntry
+	AssignmentHistory  []TranscriptAssignment
+}
+
+// TranscriptEntry is a message or an internal note of the conversation, in the order they happened
+type TranscriptEntry struct {
+	Type       string
+	CreatedAt  time.Time
+	Author     string
+	IsOutbound bool
+	Text       string
+	MediaLabel string
+	MediaUrl   *string
+	Reactions  string
+	Status     string
+}
+
+type TranscriptAssignment struct {
+	MemberName string
+	Status     string
+	AssignedAt time.Time
+	UpdatedAt  time.Time
+}
+
+type BuildTranscriptParams struct {
+	OrganizationId uuid.UUID
+	ConversationId uuid.UUID
+	// IncludeNotes includes the reminder notes of the agents, they are internal and must be left out when the transcript is shared with the contact
+	IncludeNotes bool
+	// ResolveMediaUrl returns a link to the media file of the given whatsapp media id, nil if the media is not available
+	ResolveMediaUrl func(whatsAppMediaId string) *string
+}
+
+// BuildConversationTranscript gathers the messages, notes and assignment history of a conversation
+func (service *ConversationService) BuildConversationTranscript(ctx context.Context, params BuildTranscriptParams) (*ConversationTranscript, error) {
+	var conversation struct {
+		model.Conversation
+		Contact      model.Contact
+		Organization model.Organization
+	}
+
+	err := SELECT(
+		table.Conversation.AllColumns,
+		table.Contact.AllColumns,
+		table.Organization.AllColumns,
+	).
+		FROM(
+			table.Conversation.
+				LEFT_JOIN(table.Contact, table.Contact.UniqueId.EQ(table.Conversation.ContactId)).
+				LEFT_JOIN(table.Organization, table.Organization.UniqueId.EQ(table.Conversation.OrganizationId)),
+		).
+		WHERE(
+			table.Conversation.UniqueId.EQ(UUID(params.ConversationId)).
+				AND(table.Conversation.OrganizationId.EQ(UUID(params.OrganizationId))),
+		).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &conversation)
+
+	if err != nil {
+		return nil, err
+	}
+
+	contactName := conversation.Contact.Name
+	if contactName == "" {
+		contactName = conversation.Contact.PhoneNumber
+	}
+
+	transcript := &ConversationTranscript{
+		ConversationId:     conversation.UniqueId.String(),
+		OrganizationName:   conversation.Organization.Name,
+		ContactName:        contactName,
+		ContactPhoneNumber: conversation.Contact.PhoneNumber,
+		PhoneNumberUsed:    conversation.PhoneNumberUsed,
+		Status:             conversation.Status.String(),
+		StartedAt:          conversation.CreatedAt,
+		GeneratedAt:        time.Now(),
+		Entries:            []TranscriptEntry{},
+		AssignmentHistory:  []TranscriptAssignment{},
+	}
+
+	var messages []model.Message
+	err = SELECT(table.Message.AllColumns).
+		FROM(table.Message).
+		WHERE(
+			table.Message.ConversationId.EQ(UUID(params.ConversationId)).
+				AND(table.Message.OrganizationId.EQ(UUID(params.OrganizationId))),
+		).
+		ORDER_BY(table.Message.CreatedAt.ASC()).
+		QueryContext(ctx, service.Db, &messages)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, message := range messages {
+		entry := service.describeTranscriptMessage(service.ParseDbMessageToApiMessage(message), params.ResolveMediaUrl)
+		entry.Type = TranscriptEntryTypeMessage
+		entry.CreatedAt = message.CreatedAt
+		entry.Status = message.Status.String()
+		entry.IsOutbound = message.Direction == model.MessageDirectionEnum_OutBound
+		if entry.IsOutbound {
+			entry.Author = conversation.Organization.Name
+		} else {
+			entry.Author = contactName
+		}
+		transcript.Entries = append(transcript.Entries, entry)
+	}
+
+	if params.IncludeNotes {
+		var notes []struct {
+			model.ConversationReminder
+			User model.User
+		}
+
+		err = SELECT(
+			table.ConversationReminder.AllColumns,
+			table.User.AllColumns,
+		).
+			FROM(
+				table.ConversationReminder.
+					LEFT_JOIN(table.OrganizationMember, table.OrganizationMember.UniqueId.EQ(table.ConversationReminder.OrganizationMemberId)).
+					LEFT_JOIN(table.User, table.User.UniqueId.EQ(table.OrganizationMember.UserId)),
+			).
+			WHERE(
+				table.ConversationReminder.ConversationId.EQ(UUID(params.ConversationId)).
+					AND(table.ConversationReminder.OrganizationId.EQ(UUID(params.OrganizationId))).
+					AND(table.ConversationReminder.Note.IS_NOT_NULL()),
+			).
+			ORDER_BY(table.ConversationReminder.CreatedAt.ASC()).
+			QueryContext(ctx, service.Db, &notes)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, note := range notes {
+			if strings.TrimSpace(*note.Note) == "" {
+				continue
+			}
+			transcript.Entries = append(transcript.Entries, TranscriptEntry{
+				Type:      TranscriptEntryTypeNote,
+				CreatedAt: note.CreatedAt,
+				Author:    note.User.Name,
+				Text:      *note.Note,
+			})
+		}
+
+		// * the notes are fetched separately, keep the messages and notes in the order they were created
+		sort.SliceStable(transcript.Entries, func(i, j int) bool {
+			return transcript.Entries[i].CreatedAt.Before(transcript.Entries[j].CreatedAt)
+		})
+	}
+
+	var assignments []struct {
+		model.ConversationAssignment
+		User model.User
+	}
+
+	err = SELECT(
+		table.ConversationAssignment.AllColumns,
+		table.User.AllColumns,
+	).
+		FROM(
+			table.ConversationAssignment.
+				LEFT_JOIN(table.OrganizationMember, table.OrganizationMember.UniqueId.EQ(table.ConversationAssignment.AssignedToOrganizationMemberId)).
+				LEFT_JOIN(table.User, table.User.UniqueId.EQ(table.OrganizationMember.UserId)),
+		).
+		WHERE(table.ConversationAssignment.ConversationId.EQ(UUID(params.ConversationId))).
+		ORDER_BY(table.ConversationAssignment.CreatedAt.ASC()).
+		QueryContext(ctx, service.Db, &assignments)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, assignment := range assignments {
+		transcript.AssignmentHistory = append(transcript.AssignmentHistory, TranscriptAssignment{
+			MemberName: assignment.User.Name,
+			Status:     assignment.Status.String(),
+			AssignedAt: assignment.CreatedAt,
+			UpdatedAt:  assignment.UpdatedAt,
+		})
+	}
+
+	return transcript, nil
+}
+
+// describeTranscriptMessage converts a message to the plain text shown in the transcript
+func (service *ConversationService) describeTranscriptMessage(message api_types.MessageSchema, resolveMediaUrl func(string) *string) TranscriptEntry {
+	var entry TranscriptEntry
+
+	mediaUrl := func(mediaId string) *string {
+		if resolveMediaUrl == nil || mediaId == "" {
+			return nil
+		}
+		return resolveMediaUrl(mediaId)
+	}
+
+	value, err := message.ValueByDiscriminator()
+	if err != nil {
+		entry.Text = "[Unsupported message]"
+		return entry
+	}
+
+	var reactions *[]api_types.MessageReactionSchema
+
+	switch typedMessage := value.(type) {
+	case api_types.TextMessage:
+		entry.Text = typedMessage.MessageData.Text
+		reactions = typedMessage.Reactions
+
+	case api_types.ImageMessage:
+		entry.MediaLabel = "Image"
+		entry.MediaUrl = mediaUrl(typedMessage.MessageData.Id)
+		entry.Text = stringValue(typedMessage.MessageData.Caption)
+		reactions = typedMessage.Reactions
+
+	case api_types.VideoMessage:
+		entry.MediaLabel = "Video"
+		entry.MediaUrl = mediaUrl(typedMessage.MessageData.Id)
+		entry.Text = stringValue(typedMessage.MessageData.Caption)
+		reactions = typedMessage.Reactions
+
+	case api_types.AudioMessage:
+		entry.MediaLabel = "Audio"
+		entry.MediaUrl = mediaUrl(typedMessage.MessageData.Id)
+		reactions = typedMessage.Reactions
+
+	case api_types.DocumentMessage:
+		entry.MediaLabel = "Document"
+		if typedMessage.MessageData.FileName != "" {
+			entry.MediaLabel = fmt.Sprintf("Document: %s", typedMessage.MessageData.FileName)
+		}
+		entry.MediaUrl = mediaUrl(typedMessage.MessageData.Id)
+		entry.Text = stringValue(typedMessage.MessageData.Caption)
+		reactions = typedMessage.Reactions
+
+	case api_types.StickerMessage:
+		entry.MediaLabel = "Sticker"
+		entry.MediaUrl = mediaUrl(typedMessage.MessageData.Id)
+		reactions = typedMessage.Reactions
+
+	case api_types.LocationMessage:
+		parts := []string{}
+		if name := stringValue(typedMessage.MessageData.Name); name != "" {
+			parts = append(parts, name)
+		}
+		if address := stringValue(typedMessage.MessageData.Address); address != "" {
+			parts = append(parts, address)
+		}
+		parts = append(parts, fmt.Sprintf("(%f, %f)", typedMessage.MessageData.Latitude, typedMessage.MessageData.Longitude))
+		entry.Text = "[Location] " + strings.Join(parts, ", ")
+		reactions = typedMessage.Reactions
+
+	case api_types.ReactionMessage:
+		entry.Text = fmt.Sprintf("[Reaction] %s", typedMessage.MessageData.Reaction)
+
+	case api_types.ContactsMessage:
+		contacts := []string{}
+		for _, contact := range typedMessage.MessageData.Contacts {
+			phones := []string{}
+			for _, phone := range contact.Phones {
+				phones = append(phones, phone.Phone)
+			}
+			if len(phones) > 0 {
+				contacts = append(contacts, fmt.Sprintf("%s (%s)", contact.FormattedName, strings.Join(phones, ", ")))
+			} else {
+				contacts = append(contacts, contact.FormattedName)
+			}
+		}
+		entry.Text = "[Contact] " + strings.Join(contacts, "; ")
+		reactions = typedMessage.Reactions
+
+	case api_types.InteractiveMessage:
+		lines := []string{}
+		if header := stringValue(typedMessage.MessageData.Header); header != "" {
+			lines = append(lines, header)
+		}
+		lines = append(lines, typedMessage.MessageData.Body)
+		if footer := stringValue(typedMessage.MessageData.Footer); footer != "" {
+			lines = append(lines, footer)
+		}
+		options := []string{}
+		if typedMessage.MessageData.Buttons != nil {
+			for _, button := range *typedMessage.MessageData.Buttons {
+				options = append(options, button.Title)
+			}
+		}
+		if typedMessage.MessageData.Sections != nil {
+			for _, section := range *typedMessage.MessageData.Sections {
+				for _, row := range section.Rows {
+					options = append(options, row.Title)
+				}
+			}
+		}
+		if typedMessage.MessageData.CtaUrl != nil {
+			options = append(options, fmt.Sprintf("%s: %s", typedMessage.MessageData.CtaUrl.DisplayText, typedMessage.MessageData.CtaUrl.Url))
+		}
+		if len(options) > 0 {
+			lines = append(lines, "Options: "+strings.Join(options, " | "))
+		}
+		entry.Text = strings.Join(lines, "\n")
+		reactions = typedMessage.Reactions
+
+	case api_types.InteractiveReplyMessage:
+		entry.Text = fmt.Sprintf("[Selected] %s", typedMessage.MessageData.Title)
+		reactions = typedMessage.Reactions
+
+	case api_types.OrderMessage:
+		items := []string{}
+		for _, item := range typedMessage.MessageData.ProductItems {
+			items = append(items, fmt.Sprintf("%d x %s (%.2f %s)", item.Quantity, item.ProductRetailerId, item.ItemPrice, item.Currency))
+		}
+		entry.Text = "[Order] " + strings.Join(items, ", ")
+		if text := stringValue(typedMessage.MessageData.Text); text != "" {
+			entry.Text = fmt.Sprintf("%s\n%s", entry.Text, text)
+		}
+		reactions = typedMessage.Reactions
+
+	case api_types.ProductInquiryMessage:
+		entry.Text = fmt.Sprintf("[Product inquiry: %s] %s", typedMessage.MessageData.ProductRetailerId, typedMessage.MessageData.Text)
+		reactions = typedMessage.Reactions
+
+	default:
+		entry.Text = "[Unsupported message]"
+	}
+
+	if reactions != nil && len(*reactions) > 0 {
+		emojis := []string{}
+		for _, reaction := range *reactions {
+			emojis = append(emojis, reaction.Reaction)
+		}
+		entry.Reactions = strings.Join(emojis, " ")
+	}
+
+	return entry
+}
+
+var transcriptHtmlTemplate = template.Must(template.New("transcript").Funcs(template.FuncMap{
+	"formatTime": func(t time.Time) string {
+		return t.UTC().Format("02 Jan 2006 15:04 UTC")
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Conversation with {{.ContactName}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2937; max-width: 820px; margin: 24px auto; padding: 0 16px; }
+h1 { font-size: 20px; margin-bottom: 4px; }
+h2 { font-size: 16px; margin-top: 32px; border-bottom: 1px solid #e5e7eb; padding-bottom: 4px; }
+.meta { color: #6b7280; font-size: 13px; }
+.entry { margin: 12px 0; padding: 8px 12px; border-radius: 8px; background: #f3f4f6; white-space: pre-wrap; }
+.entry.outbound { background: #dcfce7; margin-left: 80px; }
+.entry.inbound { margin-right: 80px; }
+.entry.note { background: #fef9c3; border: 1px dashed #ca8a04; }
+.entry .author { font-weight: 600; font-size: 13px; }
+.entry .time { color: #6b7280; font-size: 12px; margin-left: 8px; }
+.entry .media { font-size: 13px; }
+.entry .reactions { font-size: 13px; margin-top: 4px; }
+table { border-collapse: collapse; width: 100%; font-size: 13px; }
+th, td { text-align: left; padding: 6px 8px; border-bottom: 1px solid #e5e7eb; }
+</style>
+</head>
+<body>
+<h1>Conversation with {{.ContactName}}</h1>
+<div class="meta">
+<div>Organization: {{.OrganizationName}}</div>
+<div>Contact: {{.ContactName}} ({{.ContactPhoneNumber}})</div>
+<div>Business phone number: {{.PhoneNumberUsed}}</div>
+<div>Status: {{.Status}}</div>
+<div>Started at: {{formatTime .StartedAt}}</div>
+<div>Generated at: {{formatTime .GeneratedAt}}</div>
+</div>
+<h2>Messages</h2>
+{{range .Entries}}
+<div class="entry {{if eq .Type "note"}}note{{else if .IsOutbound}}outbound{{else}}inbound{{end}}">
+<div><span class="author">{{if eq .Type "note"}}Note by {{end}}{{.Author}}</span><span class="time">{{formatTime .CreatedAt}}</span></div>
+{{if .MediaLabel}}<div class="media">[{{.MediaLabel}}]{{if .MediaUrl}} <a href="{{.MediaUrl}}">Open</a>{{end}}</div>{{end}}
+{{if .Text}}<div>{{.Text}}</div>{{end}}
+{{if .Reactions}}<div class="reactions">Reactions: {{.Reactions}}</div>{{end}}
+</div>
+{{else}}
+<p class="meta">No messages in this conversation.</p>
+{{end}}
+{{if .AssignmentHistory}}
+<h2>Assignment history</h2>
+<table>
+<tr><th>Member</th><th>Status</th><th>Assigned at</th><th>Last updated at</th></tr>
+{{range .AssignmentHistory}}
+<tr><td>{{.MemberName}}</td><td>{{.Status}}</td><td>{{formatTime .AssignedAt}}</td><td>{{formatTime .UpdatedAt}}</td></tr>
+{{end}}
+</table>
+{{end}}
+</body>
+</html>
+`))
+
+// RenderTranscriptHtml renders the transcript as a standalone html document
+func (service *ConversationService) RenderTranscriptHtml(transcript ConversationTranscript) ([]byte, error) {
+	var document bytes.Buffer
+	if err := transcriptHtmlTemplate.Execute(&document, transcript); err != nil {
+		return nil, err
+	}
+	return document.Bytes(), nil
+}
+
+// RenderTranscriptPdf renders the transcript as a pdf document
+func (service *ConversationService) RenderTranscriptPdf(transcript ConversationTranscript) ([]byte, error) {
+	formatTime := func(t time.Time) string {
+		return t.UTC().Format("02 Jan 2006 15:04 UTC")
+	}
+
+	document := newPdfDocument(service.TranscriptFallbackFonts)
+	document.writeText(fmt.Sprintf("Conversation with %s", transcript.ContactName), pdfFontBold, 16)
+	document.addSpacing(4)
+	document.writeText(fmt.Sprintf("Organization: %s", transcript.OrganizationName), pdfFontRegular, 9)
+	document.writeText(fmt.Sprintf("Contact: %s (%s)", transcript.ContactName, transcript.ContactPhoneNumber), pdfFontRegular, 9)
+	document.writeText(fmt.Sprintf("Business phone number: %s", transcript.PhoneNumberUsed), pdfFontRegular, 9)
+	document.writeText(fmt.Sprintf("Status: %s", transcript.Status), pdfFontRegular, 9)
+	document.writeText(fmt.Sprintf("Started at: %s", formatTime(transcript.StartedAt)), pdfFontRegular, 9)
+	document.writeText(fmt.Sprintf("Generated at: %s", formatTime(transcript.GeneratedAt)), pdfFontRegular, 9)
+
+	document.addSpacing(12)
+	document.writeText("Messages", pdfFontBold, 13)
+	document.addSpacing(4)
+
+	if len(transcript.Entries) == 0 {
+		document.writeText("No messages in this conversation.", pdfFontRegular, 10)
+	}
+
+	for _, entry := range transcript.Entries {
+		author := entry.Author
+		if entry.Type == TranscriptEntryTypeNote {
+			author = "Note by " + author
+		}
+		document.writeText(fmt.Sprintf("%s - %s", author, formatTime(entry.CreatedAt)), pdfFontBold, 10)
+		if entry.MediaLabel != "" {
+			mediaLine := fmt.Sprintf("[%s]", entry.MediaLabel)
+			if entry.MediaUrl != nil {
+				mediaLine = fmt.Sprintf("%s %s", mediaLine, *entry.MediaUrl)
+			}
+			document.writeText(mediaLine, pdfFontRegular, 9)
+		}
+		if entry.Text != "" {
+			document.writeText(entry.Text, pdfFontRegular, 10)
+		}
+		if entry.Reactions != "" {
+			document.writeText(fmt.Sprintf("Reactions: %s", entry.Reactions), pdfFontRegular, 9)
+		}
+		document.addSpacing(8)
+	}
+
+	if len(transcript.AssignmentHistory) > 0 {
+		document.addSpacing(8)
+		document.writeText("Assignment history", pdfFontBold, 13)
+		document.addSpacing(4)
+		for _, assignment := range transcript.AssignmentHistory {
+			document.writeText(fmt.Sprintf(
+				"%s - %s, assigned at %s, last updated at %s",
+				assignment.MemberName,
+				assignment.Status,
+				formatTime(assignment.AssignedAt),
+				formatTime(assignment.UpdatedAt),
+			), pdfFontRegular, 10)
+		}
+	}
+
+	return document.bytes()
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
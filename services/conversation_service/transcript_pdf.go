@@ -0,0 +1,325 @@
+package conversation_service
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/go-pdf/fpdf"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// * the transcripts are laid out with the embedded DejaVu Sans truetype font, it covers the latin, greek, cyrillic, arabic
+// * and hebrew scripts along with the symbols, the characters of the other scripts, e.g. devanagari or CJK, are drawn with
+// * the first fallback font configured which covers them
+
+//go:embed fonts/DejaVuSans.ttf
+var dejaVuSansRegular []byte
+
+//go:embed fonts/DejaVuSans-Bold.ttf
+var dejaVuSansBold []byte
+
+type pdfFontStyle string
+
+const (
+	pdfFontRegular pdfFontStyle = ""
+	pdfFontBold    pdfFontStyle = "B"
+
+	pdfPageMargin  = 50.0 // in points
+	pdfLineSpacing = 1.35
+)
+
+// TranscriptFont is a truetype font the transcripts are drawn with
+type TranscriptFont struct {
+	data []byte
+	font *sfnt.Font
+}
+
+// ParseTranscriptFont parses a truetype font, the fonts with postscript outlines, e.g. most .otf files, are not supported
+func ParseTranscriptFont(data []byte) (*TranscriptFont, error) {
+	font, err := sfnt.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return &TranscriptFont{data: data, font: font}, nil
+}
+
+// LoadTranscriptFonts reads the truetype font files the transcripts fall back to, in order
+func LoadTranscriptFonts(paths []string) ([]*TranscriptFont, error) {
+	fonts := []*TranscriptFont{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		font, err := ParseTranscriptFont(data)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the transcript font %s: %w", path, err)
+		}
+		fonts = append(fonts, font)
+	}
+	return fonts, nil
+}
+
+func (font *TranscriptFont) covers(character rune, buffer *sfnt.Buffer) bool {
+	glyphIndex, err := font.font.GlyphIndex(buffer, character)
+	return err == nil && glyphIndex != 0
+}
+
+var dejaVuSans = func() *TranscriptFont {
+	font, err := ParseTranscriptFont(dejaVuSansRegular)
+	if err != nil {
+		panic(err)
+	}
+	return font
+}()
+
+// pdfTextRun is a part of a line drawn with a single font
+type pdfTextRun struct {
+	fontIndex int
+	text      string
+}
+
+type pdfDocument struct {
+	pdf *fpdf.Fpdf
+	// fonts are the fonts of the document in order of preference, DejaVu Sans first and then the fallback fonts
+	fonts  []*TranscriptFont
+	buffer sfnt.Buffer
+}
+
+func newPdfDocument(fallbackFonts []*TranscriptFont) *pdfDocument {
+	pdf := fpdf.New("P", "pt", "A4", "")
+	pdf.SetMargins(pdfPageMargin, pdfPageMargin, pdfPageMargin)
+	pdf.SetAutoPageBreak(false, pdfPageMargin)
+	pdf.SetCellMargin(0)
+
+	document := &pdfDocument{
+		pdf:   pdf,
+		fonts: append([]*TranscriptFont{dejaVuSans}, fallbackFonts...),
+	}
+
+	pdf.AddUTF8FontFromBytes(pdfFontFamily(0), string(pdfFontRegular), dejaVuSansRegular)
+	pdf.AddUTF8FontFromBytes(pdfFontFamily(0), string(pdfFontBold), dejaVuSansBold)
+	for i, font := range fallbackFonts {
+		// * the fallback fonts have no bold variant, the bold text falls back to their regular style
+		pdf.AddUTF8FontFromBytes(pdfFontFamily(i+1), string(pdfFontRegular), font.data)
+		pdf.AddUTF8FontFromBytes(pdfFontFamily(i+1), string(pdfFontBold), font.data)
+	}
+
+	pdf.AddPage()
+	return document
+}
+
+func pdfFontFamily(fontIndex int) string {
+	return fmt.Sprintf("transcript%d", fontIndex)
+}
+
+func (document *pdfDocument) addSpacing(points float64) {
+	document.pdf.SetY(document.pdf.GetY() + points)
+}
+
+// writeText writes the text wrapped to the page width, starting a new page when the current one is full
+func (document *pdfDocument) writeText(text string, style pdfFontStyle, size float64) {
+	pageWidth, pageHeight := document.pdf.GetPageSize()
+	maxWidth := pageWidth - 2*pdfPageMargin
+	lineHeight := size * pdfLineSpacing
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		paragraph = shapeArabic(replaceSupplementaryCharacters(strings.ReplaceAll(paragraph, "\t", "    ")))
+		for _, line := range document.wrapLine(paragraph, style, size, maxWidth) {
+			if document.pdf.GetY()+lineHeight > pageHeight-pdfPageMargin {
+				document.pdf.AddPage()
+			}
+			document.drawLine(line, style, size, lineHeight, maxWidth)
+		}
+	}
+}
+
+// drawLine draws the line in its visual order, the lines starting in a right to left script are aligned to the right
+func (document *pdfDocument) drawLine(line string, style pdfFontStyle, size float64, lineHeight float64, maxWidth float64) {
+	visualLine, isRightToLeft := visualPdfLine(line)
+	runs := document.textRuns(visualLine)
+
+	x := pdfPageMargin
+	if isRightToLeft {
+		x += maxWidth - document.runsWidth(runs, style, size)
+	}
+
+	y := document.pdf.GetY()
+	for _, run := range runs {
+		document.pdf.SetFont(pdfFontFamily(run.fontIndex), string(style), size)
+		runWidth := document.pdf.GetStringWidth(run.text)
+		document.pdf.SetXY(x, y)
+		document.pdf.CellFormat(runWidth, lineHeight, run.text, "", 0, "L", false, 0, "")
+		x += runWidth
+	}
+
+	document.pdf.SetXY(pdfPageMargin, y+lineHeight)
+}
+
+// textRuns splits the text into the runs drawn with the same font, a character no font covers is drawn with DejaVu Sans
+func (document *pdfDocument) textRuns(text string) []pdfTextRun {
+	runs := []pdfTextRun{}
+	var currentRun strings.Builder
+	currentFontIndex := 0
+
+	for _, character := range text {
+		fontIndex := currentFontIndex
+		// * the spaces and the marks stay in the run they are in as long as its font covers them
+		if currentRun.Len() == 0 || !(unicode.IsSpace(character) || unicode.Is(unicode.Mn, character)) || !document.fonts[currentFontIndex].covers(character, &document.buffer) {
+			fontIndex = document.fontIndexFor(character)
+		}
+
+		if fontIndex != currentFontIndex && currentRun.Len() > 0 {
+			runs = append(runs, pdfTextRun{fontIndex: currentFontIndex, text: currentRun.String()})
+			currentRun.Reset()
+		}
+		currentFontIndex = fontIndex
+		currentRun.WriteRune(character)
+	}
+
+	if currentRun.Len() > 0 {
+		runs = append(runs, pdfTextRun{fontIndex: currentFontIndex, text: currentRun.String()})
+	}
+
+	return runs
+}
+
+func (document *pdfDocument) fontIndexFor(character rune) int {
+	for index, font := range document.fonts {
+		if font.covers(character, &document.buffer) {
+			return index
+		}
+	}
+	return 0
+}
+
+func (document *pdfDocument) runsWidth(runs []pdfTextRun, style pdfFontStyle, size float64) float64 {
+	width := 0.0
+	for _, run := range runs {
+		document.pdf.SetFont(pdfFontFamily(run.fontIndex), string(style), size)
+		width += document.pdf.GetStringWidth(run.text)
+	}
+	return width
+}
+
+func (document *pdfDocument) textWidth(text string, style pdfFontStyle, size float64) float64 {
+	return document.runsWidth(document.textRuns(text), style, size)
+}
+
+// wrapLine wraps the text, in its logical order, into the lines fitting the width
+func (document *pdfDocument) wrapLine(text string, style pdfFontStyle, size float64, maxWidth float64) []string {
+	if document.textWidth(text, style, size) <= maxWidth {
+		return []string{text}
+	}
+
+	lines := []string{}
+	currentLine := ""
+	for _, word := range strings.Fields(text) {
+		// * break the words longer than a line, e.g. the urls
+		for document.textWidth(word, style, size) > maxWidth {
+			if currentLine != "" {
+				lines = append(lines, currentLine)
+				currentLine = ""
+			}
+			wordRunes := []rune(word)
+			cut := 1
+			for cut < len(wordRunes) && document.textWidth(string(wordRunes[:cut+1]), style, size) <= maxWidth {
+				cut++
+			}
+			lines = append(lines, string(wordRunes[:cut]))
+			word = string(wordRunes[cut:])
+		}
+		if word == "" {
+			continue
+		}
+
+		if currentLine == "" {
+			currentLine = word
+			continue
+		}
+		if document.textWidth(currentLine+" "+word, style, size) > maxWidth {
+			lines = append(lines, currentLine)
+			currentLine = word
+			continue
+		}
+		currentLine += " " + word
+	}
+
+	if currentLine != "" {
+		lines = append(lines, currentLine)
+	}
+
+	return lines
+}
+
+// replaceSupplementaryCharacters writes the code point of the characters out of the basic multilingual plane, e.g. most
+// emojis, the truetype fonts of the pdf only map the characters of the basic multilingual plane
+func replaceSupplementaryCharacters(text string) string {
+	if !strings.ContainsFunc(text, func(character rune) bool { return character > 0xFFFF }) {
+		return text
+	}
+
+	var replaced strings.Builder
+	for _, character := range text {
+		if character > 0xFFFF {
+			fmt.Fprintf(&replaced, "[U+%X]", character)
+			continue
+		}
+		replaced.WriteRune(character)
+	}
+	return replaced.String()
+}
+
+func isRightToLeftCharacter(character rune) bool {
+	return unicode.In(character, unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko)
+}
+
+// visualPdfLine reorders the line from its logical order to the order it is drawn in from left to right, the right to left
+// runs are reversed, and so are the runs of the line when it starts in a right to left script
+func visualPdfLine(line string) (string, bool) {
+	if strings.IndexFunc(line, isRightToLeftCharacter) < 0 {
+		return line, false
+	}
+
+	var paragraph bidi.Paragraph
+	if _, err := paragraph.SetString(line); err != nil {
+		return line, false
+	}
+	ordering, err := paragraph.Order()
+	if err != nil {
+		return line, false
+	}
+
+	runs := make([]string, ordering.NumRuns())
+	for i := range runs {
+		run := ordering.Run(i)
+		runText := run.String()
+		if run.Direction() == bidi.RightToLeft {
+			runCharacters := []rune(runText)
+			slices.Reverse(runCharacters)
+			runText = string(runCharacters)
+		}
+		runs[i] = runText
+	}
+
+	isRightToLeft := !paragraph.IsLeftToRight()
+	if isRightToLeft {
+		slices.Reverse(runs)
+	}
+
+	return strings.Join(runs, ""), isRightToLeft
+}
+
+func (document *pdfDocument) bytes() ([]byte, error) {
+	var output bytes.Buffer
+	if err := document.pdf.Output(&output); err != nil {
+		return nil, err
+	}
+	return output.Bytes(), nil
+}
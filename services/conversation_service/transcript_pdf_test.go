@@ -0,0 +1,133 @@
+package conversation_service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+)
+
+// utf16Text is the text as the pdf content stream holds it, the code points of the unicode fonts in big endian
+func utf16Text(text string) []byte {
+	var encoded bytes.Buffer
+	for _, unit := range utf16.Encode([]rune(text)) {
+		encoded.WriteByte(byte(unit >> 8))
+		encoded.WriteByte(byte(unit))
+	}
+	return encoded.Bytes()
+}
+
+func renderUncompressedPdf(t *testing.T, lines ...string) []byte {
+	t.Helper()
+
+	document := newPdfDocument(nil)
+	document.pdf.SetCompression(false)
+	for _, line := range lines {
+		document.writeText(line, pdfFontRegular, 10)
+	}
+
+	output, err := document.bytes()
+	if err != nil {
+		t.Fatalf("error rendering the pdf: %v", err)
+	}
+	return output
+}
+
+func TestPdfKeepsNonLatinText(t *testing.T) {
+	output := renderUncompressedPdf(t,
+		"Здравствуйте, где мой заказ?",
+		"Καλησπέρα σας",
+		"Zażółć gęślą jaźń",
+	)
+
+	for _, expected := range []string{"Здравствуйте, где мой заказ?", "Καλησπέρα σας", "Zażółć gęślą jaźń"} {
+		if !bytes.Contains(output, utf16Text(expected)) {
+			t.Errorf("the pdf does not contain %q", expected)
+		}
+	}
+}
+
+func TestPdfDrawsRightToLeftTextInVisualOrder(t *testing.T) {
+	output := renderUncompressedPdf(t, "שלום עולם", "سلام")
+
+	// * the hebrew words are drawn from right to left, so the last letter is drawn first
+	if !bytes.Contains(output, utf16Text("םלוע םולש")) {
+		t.Error("the pdf does not contain the hebrew line in its visual order")
+	}
+
+	// * meem isolated, the final lam alef ligature and seen initial, drawn from right to left
+	if !bytes.Contains(output, utf16Text("\uFEE1\uFEFC\uFEB3")) {
+		t.Error("the pdf does not contain the shaped arabic word in its visual order")
+	}
+}
+
+func TestPdfWritesTheCodePointOfSupplementaryCharacters(t *testing.T) {
+	output := renderUncompressedPdf(t, "Thanks 😀")
+
+	if !bytes.Contains(output, utf16Text("Thanks [U+1F600]")) {
+		t.Error("the pdf does not contain the code point of the emoji")
+	}
+}
+
+func TestShapeArabic(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected string
+	}{
+		{text: "سلام", expected: "\uFEB3\uFEFC\uFEE1"},
+		{text: "لا", expected: "\uFEFB"},
+		{text: "بيت", expected: "\uFE91\uFEF4\uFE96"},
+		{text: "بار", expected: "\uFE91\uFE8E\uFEAD"},
+		{text: "order 42", expected: "order 42"},
+	}
+
+	for _, testCase := range testCases {
+		if shaped := shapeArabic(testCase.text); shaped != testCase.expected {
+			t.Errorf("shapeArabic(%q) = %q, expected %q", testCase.text, shaped, testCase.expected)
+		}
+	}
+}
+
+func TestVisualPdfLine(t *testing.T) {
+	testCases := []struct {
+		line          string
+		expected      string
+		isRightToLeft bool
+	}{
+		{line: "hello world", expected: "hello world", isRightToLeft: false},
+		{line: "שלום world", expected: "world םולש", isRightToLeft: true},
+		{line: "order שלום done", expected: "order םולש done", isRightToLeft: false},
+	}
+
+	for _, testCase := range testCases {
+		visualLine, isRightToLeft := visualPdfLine(testCase.line)
+		if visualLine != testCase.expected || isRightToLeft != testCase.isRightToLeft {
+			t.Errorf("visualPdfLine(%q) = %q, %v, expected %q, %v", testCase.line, visualLine, isRightToLeft, testCase.expected, testCase.isRightToLeft)
+		}
+	}
+}
+
+func TestRenderTranscriptPdf(t *testing.T) {
+	service := &ConversationService{}
+	createdAt := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+
+	output, err := service.RenderTranscriptPdf(ConversationTranscript{
+		OrganizationName:   "Wapikit",
+		ContactName:        "Анна Петрова",
+		ContactPhoneNumber: "+79001234567",
+		Status:             "Active",
+		StartedAt:          createdAt,
+		GeneratedAt:        createdAt,
+		Entries: []TranscriptEntry{
+			{Type: TranscriptEntryTypeMessage, CreatedAt: createdAt, Author: "Анна Петрова", Text: strings.Repeat("Здравствуйте, где мой заказ? مرحبا 😀 ", 200)},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("error rendering the transcript: %v", err)
+	}
+	if !bytes.HasPrefix(output, []byte("%PDF-")) {
+		t.Error("the transcript is not a pdf document")
+	}
+}
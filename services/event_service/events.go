@@ -23,6 +23,10 @@ const (
 	ApiServerMessageDeliveredEvent   ApiServerEventType = "MessageDelivered"
 	ApiServerMessageSentEvent        ApiServerEventType = "MessageSent"
 	ApiServerMessageErroredEvent     ApiServerEventType = "MessageErrored"
+	ApiServerMessageReactionEvent    ApiServerEventType = "MessageReaction"
+
+	ApiServerConversationBulkActionProgressEvent ApiServerEventType = "ConversationBulkActionProgress"
+	ApiServerSegmentRecommendationProgressEvent  ApiServerEventType = "SegmentRecommendationProgress"
 )
 
 type EventAuthDetails struct {
@@ -369,3 +373,62 @@ func NewConversationReadEvent(conversationId string, orgId *string) *Conversatio
 		},
 	}
 }
+
+type MessageReactionEvent struct {
+	BaseApiServerEvent
+}
+
+func NewMessageReactionEvent(messageId string, reactions []api_types.MessageReactionSchema, orgId *string) *MessageReactionEvent {
+	return &MessageReactionEvent{
+		BaseApiServerEvent: BaseApiServerEvent{
+			EventType:      ApiServerMessageReactionEvent,
+			UserId:         nil,
+			OrganizationId: orgId,
+			Data: struct {
+				MessageId string                            `json:"messageId"`
+				Reactions []api_types.MessageReactionSchema `json:"reactions"`
+			}{
+				MessageId: messageId,
+				Reactions: reactions,
+			},
+		},
+	}
+}
+
+type ConversationBulkActionProgressEvent struct {
+	BaseApiServerEvent
+}
+
+func NewConversationBulkActionProgressEvent(job api_types.ConversationBulkActionJobSchema, orgId *string) *ConversationBulkActionProgressEvent {
+	return &ConversationBulkActionProgressEvent{
+		BaseApiServerEvent: BaseApiServerEvent{
+			EventType:      ApiServerConversationBulkActionProgressEvent,
+			UserId:         nil,
+			OrganizationId: orgId,
+			Data: struct {
+				Job api_types.ConversationBulkActionJobSchema `json:"job"`
+			}{
+				Job: job,
+			},
+		},
+	}
+}
+
+type SegmentRecommendationProgressEvent struct {
+	BaseApiServerEvent
+}
+
+func NewSegmentRecommendationProgressEvent(job api_types.SegmentRecommendationJobSchema, orgId *string) *SegmentRecommendationProgressEvent {
+	return &SegmentRecommendationProgressEvent{
+		BaseApiServerEvent: BaseApiServerEvent{
+			EventType:      ApiServerSegmentRecommendationProgressEvent,
+			UserId:         nil,
+			OrganizationId: orgId,
+			Data: struct {
+				Job api_types.SegmentRecommendationJobSchema `json:"job"`
+			}{
+				Job: job,
+			},
+		},
+	}
+}
@@ -112,6 +112,24 @@ func (service *EventService) HandleApiServerEvents(ctx context.Context) <-chan A
 					}
 					streamChannel <- conversationClosedEvent
 
+				case ApiServerConversationBulkActionProgressEvent:
+					var bulkActionProgressEvent ConversationBulkActionProgressEvent
+					err := json.Unmarshal(apiServerEventData, &bulkActionProgressEvent)
+					if err != nil {
+						service.Logger.Error("Unable to unmarshal conversation bulk action progress event", err.Error(), nil)
+						continue
+					}
+					streamChannel <- bulkActionProgressEvent
+
+				case ApiServerSegmentRecommendationProgressEvent:
+					var segmentRecommendationProgressEvent SegmentRecommendationProgressEvent
+					err := json.Unmarshal(apiServerEventData, &segmentRecommendationProgressEvent)
+					if err != nil {
+						service.Logger.Error("Unable to unmarshal segment recommendation progress event", err.Error(), nil)
+						continue
+					}
+					streamChannel <- segmentRecommendationProgressEvent
+
 				case ApiServerErrorEvent:
 					var errorEvent ErrorEvent
 					err := json.Unmarshal(apiServerEventData, &errorEvent)
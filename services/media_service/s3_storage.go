@@ -0,0 +1,254 @@
+package media_service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wapikit/wapikit/.db-generated/model"
+)
+
+const (
+	s3SigningAlgorithm  = "AWS4-HMAC-SHA256"
+	s3UnsignedPayload   = "UNSIGNED-PAYLOAD"
+	s3MaxPresignExpiry  = 7 * 24 * time.Hour
+	s3AmzDateTimeFormat = "20060102T150405Z"
+	s3AmzDateFormat     = "20060102"
+)
+
+type S3StorageConfig struct {
+	// Endpoint of the S3 compatible API, e.g. https://s3.us-east-1.amazonaws.com or http://localhost:9000 for MinIO
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// UsePathStyle addresses the bucket as a path segment instead of a sub domain, required by MinIO
+	UsePathStyle bool
+}
+
+// S3Storage stores the media files in a S3 compatible object storage, the requests are signed with AWS signature version 4
+type S3Storage struct {
+	config     S3StorageConfig
+	endpoint   *url.URL
+	httpClient *http.Client
+}
+
+func NewS3Storage(config S3StorageConfig) (*S3Storage, error) {
+	if config.Endpoint == "" || config.Bucket == "" || config.AccessKey == "" || config.SecretKey == "" {
+		return nil, fmt.Errorf("endpoint, bucket, access key and secret key are required for the s3 media storage")
+	}
+
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint: %s", config.Endpoint)
+	}
+
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+
+	return &S3Storage{
+		config:     config,
+		endpoint:   endpoint,
+		httpClient: &http.Client{Timeout: 5 * time.Minute},
+	}, nil
+}
+
+func (storage *S3Storage) Name() model.MediaStorageBackendEnum {
+	return model.MediaStorageBackendEnum_S3
+}
+
+func (storage *S3Storage) objectUrl(key string) *url.URL {
+	objectUrl := *storage.endpoint
+	escapedKey := escapeS3Key(key)
+
+	if storage.config.UsePathStyle {
+		objectUrl.Path = "/" + storage.config.Bucket + "/" + key
+		objectUrl.RawPath = "/" + storage.config.Bucket + "/" + escapedKey
+	} else {
+		objectUrl.Host = storage.config.Bucket + "." + objectUrl.Host
+		objectUrl.Path = "/" + key
+		objectUrl.RawPath = "/" + escapedKey
+	}
+
+	return &objectUrl
+}
+
+func (storage *S3Storage) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, storage.objectUrl(key).String(), content)
+	if err != nil {
+		return err
+	}
+
+	request.ContentLength = size
+	request.Header.Set("Content-Type", contentType)
+
+	response, err := storage.do(request)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+
+	return nil
+}
+
+func (storage *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, storage.objectUrl(key).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := storage.do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+func (storage *S3Storage) Delete(ctx context.Context, key string) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodDelete, storage.objectUrl(key).String(), nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := storage.do(request)
+	if err != nil {
+		if err == ErrMediaObjectNotFound {
+			return nil
+		}
+		return err
+	}
+	response.Body.Close()
+
+	return nil
+}
+
+func (storage *S3Storage) PresignedUrl(key string, expiry time.Duration) (string, bool, error) {
+	if expiry > s3MaxPresignExpiry {
+		expiry = s3MaxPresignExpiry
+	}
+
+	now := time.Now().UTC()
+	objectUrl := storage.objectUrl(key)
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", s3SigningAlgorithm)
+	query.Set("X-Amz-Credential", storage.config.AccessKey+"/"+storage.credentialScope(now))
+	query.Set("X-Amz-Date", now.Format(s3AmzDateTimeFormat))
+	query.Set("X-Amz-Expires", strconv.FormatInt(int64(expiry.Seconds()), 10))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		objectUrl.EscapedPath(),
+		canonicalQueryString(query),
+		"host:" + objectUrl.Host + "\n",
+		"host",
+		s3UnsignedPayload,
+	}, "\n")
+
+	query.Set("X-Amz-Signature", storage.signature(now, canonicalRequest))
+	objectUrl.RawQuery = canonicalQueryString(query)
+
+	return objectUrl.String(), true, nil
+}
+
+// do signs the request with the authorization header and executes it
+func (storage *S3Storage) do(request *http.Request) (*http.Response, error) {
+	now := time.Now().UTC()
+
+	request.Header.Set("x-amz-date", now.Format(s3AmzDateTimeFormat))
+	request.Header.Set("x-amz-content-sha256", s3UnsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + request.URL.Host + "\n" +
+		"x-amz-content-sha256:" + s3UnsignedPayload + "\n" +
+		"x-amz-date:" + now.Format(s3AmzDateTimeFormat) + "\n"
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		canonicalQueryString(request.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		s3UnsignedPayload,
+	}, "\n")
+
+	request.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3SigningAlgorithm,
+		storage.config.AccessKey,
+		storage.credentialScope(now),
+		signedHeaders,
+		storage.signature(now, canonicalRequest),
+	))
+
+	response, err := storage.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		response.Body.Close()
+		return nil, ErrMediaObjectNotFound
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		defer response.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		return nil, fmt.Errorf("s3 request failed with status %d: %s", response.StatusCode, string(body))
+	}
+
+	return response, nil
+}
+
+func (storage *S3Storage) credentialScope(t time.Time) string {
+	return t.Format(s3AmzDateFormat) + "/" + storage.config.Region + "/s3/aws4_request"
+}
+
+func (storage *S3Storage) signature(t time.Time, canonicalRequest string) string {
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		s3SigningAlgorithm,
+		t.Format(s3AmzDateTimeFormat),
+		storage.credentialScope(t),
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSha256([]byte("AWS4"+storage.config.SecretKey), t.Format(s3AmzDateFormat))
+	signingKey = hmacSha256(signingKey, storage.config.Region)
+	signingKey = hmacSha256(signingKey, "s3")
+	signingKey = hmacSha256(signingKey, "aws4_request")
+
+	return hex.EncodeToString(hmacSha256(signingKey, stringToSign))
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// escapeS3Key uri encodes every segment of the key as required by the signature version 4
+func escapeS3Key(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(url.PathEscape(segment), "+", "%2B")
+	}
+	return strings.Join(segments, "/")
+}
+
+func canonicalQueryString(query url.Values) string {
+	// * url.Values.Encode sorts the keys, the spaces must be encoded as %20 for the signature version 4
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
@@ -0,0 +1,430 @@
+package media_service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/utils"
+)
+
+type MediaCategory string
+
+const (
+	ImageMedia    MediaCategory = "image"
+	VideoMedia    MediaCategory = "video"
+	AudioMedia    MediaCategory = "audio"
+	DocumentMedia MediaCategory = "document"
+	StickerMedia  MediaCategory = "sticker"
+)
+
+var (
+	ErrMediaTooLarge          = errors.New("media file is too large")
+	ErrUnsupportedMediaType   = errors.New("media type is not supported")
+	ErrEmptyMedia             = errors.New("media file is empty")
+	ErrInvalidMediaSignature  = errors.New("invalid or expired media url signature")
+	errMediaDownloadHttpError = errors.New("failed to download media from whatsapp")
+)
+
+// * media types supported by the whatsapp cloud API, gif images are sent as videos by the conversation controller
+var allowedMimeTypes = map[string]MediaCategory{
+	"image/jpeg":                    ImageMedia,
+	"image/png":                     ImageMedia,
+	"image/gif":                     ImageMedia,
+	"image/webp":                    StickerMedia,
+	"video/mp4":                     VideoMedia,
+	"video/3gpp":                    VideoMedia,
+	"audio/aac":                     AudioMedia,
+	"audio/amr":                     AudioMedia,
+	"audio/mpeg":                    AudioMedia,
+	"audio/mp4":                     AudioMedia,
+	"audio/x-m4a":                   AudioMedia,
+	"audio/ogg":                     AudioMedia,
+	"text/plain":                    DocumentMedia,
+	"application/pdf":               DocumentMedia,
+	"application/msword":            DocumentMedia,
+	"application/vnd.ms-excel":      DocumentMedia,
+	"application/vnd.ms-powerpoint": DocumentMedia,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   DocumentMedia,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         DocumentMedia,
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": DocumentMedia,
+}
+
+// * size limits of the whatsapp cloud API for each media category
+var mediaSizeLimits = map[MediaCategory]int64{
+	ImageMedia:    5 << 20,
+	VideoMedia:    16 << 20,
+	AudioMedia:    16 << 20,
+	DocumentMedia: 100 << 20,
+	StickerMedia:  500 << 10,
+}
+
+const maxMediaSizeInBytes = 100 << 20
+
+type MediaServiceConfig struct {
+	// UrlSigningKey is used to sign the urls of the media served by the api server
+	UrlSigningKey   string
+	SignedUrlExpiry time.Duration
+	// MaxSizeInBytes optionally lowers the size limits of the whatsapp cloud API
+	MaxSizeInBytes int64
+}
+
+type MediaService struct {
+	Logger     *slog.Logger
+	Db         *sql.DB
+	Storage    StorageBackend
+	Config     MediaServiceConfig
+	httpClient *http.Client
+}
+
+// NewMediaService creates a new instance of the MediaService
+func NewMediaService(db *sql.DB, logger *slog.Logger, storage StorageBackend, config MediaServiceConfig) *MediaService {
+	if config.SignedUrlExpiry == 0 {
+		config.SignedUrlExpiry = time.Hour
+	}
+
+	return &MediaService{
+		Logger:     logger,
+		Db:         db,
+		Storage:    storage,
+		Config:     config,
+		httpClient: &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+type StoreMediaParams struct {
+	OrganizationId  uuid.UUID
+	Content         io.Reader
+	FileName        *string
+	WhatsAppMediaId *string
+}
+
+// StoreMedia validates the content of the media file and persists it in the storage backend,
+// files with the same content are stored only once per organization.
+func (service *MediaService) StoreMedia(ctx context.Context, params StoreMediaParams) (*model.MediaFile, error) {
+	if params.WhatsAppMediaId != nil {
+		existingMediaFile, err := service.GetMediaFileByWhatsAppMediaId(ctx, params.OrganizationId, *params.WhatsAppMediaId)
+		if err == nil {
+			return existingMediaFile, nil
+		}
+	}
+
+	// * the content is spooled to a temporary file, so large documents are never held in memory
+	tempFile, err := os.CreateTemp("", "wapikit-media-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tempFile, hasher), io.LimitReader(params.Content, maxMediaSizeInBytes+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if size == 0 {
+		return nil, ErrEmptyMedia
+	}
+
+	if size > maxMediaSizeInBytes {
+		return nil, ErrMediaTooLarge
+	}
+
+	if _, err := tempFile.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	// * the mime type is detected from the content, the type claimed by the sender is never trusted
+	detectedMime, err := mimetype.DetectReader(tempFile)
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType, category, err := resolveMediaType(detectedMime)
+	if err != nil {
+		return nil, err
+	}
+
+	sizeLimit := mediaSizeLimits[category]
+	if service.Config.MaxSizeInBytes > 0 && service.Config.MaxSizeInBytes < sizeLimit {
+		sizeLimit = service.Config.MaxSizeInBytes
+	}
+
+	if size > sizeLimit {
+		return nil, fmt.Errorf("%w: %s files can be at most %d bytes", ErrMediaTooLarge, category, sizeLimit)
+	}
+
+	contentHash := hex.EncodeToString(hasher.Sum(nil))
+
+	mediaFile := model.MediaFile{
+		OrganizationId:  params.OrganizationId,
+		WhatsAppMediaId: params.WhatsAppMediaId,
+		Sha256:          contentHash,
+		MimeType:        mimeType,
+		SizeInBytes:     size,
+		FileName:        params.FileName,
+		StorageBackend:  service.Storage.Name(),
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	var sameContentMediaFile model.MediaFile
+	err = SELECT(table.MediaFile.AllColumns).
+		FROM(table.MediaFile).
+		WHERE(
+			table.MediaFile.OrganizationId.EQ(UUID(params.OrganizationId)).
+				AND(table.MediaFile.Sha256.EQ(String(contentHash))).
+				AND(table.MediaFile.StorageBackend.EQ(utils.EnumExpression(service.Storage.Name().String()))),
+		).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &sameContentMediaFile)
+
+	if err == nil {
+		mediaFile.StorageKey = sameContentMediaFile.StorageKey
+		mediaFile.ThumbnailStorageKey = sameContentMediaFile.ThumbnailStorageKey
+	} else if err.Error() == qrm.ErrNoRows.Error() {
+		mediaFile.StorageKey = fmt.Sprintf("%s/%s%s", params.OrganizationId.String(), contentHash, detectedMime.Extension())
+
+		if _, err := tempFile.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+
+		if err := service.Storage.Put(ctx, mediaFile.StorageKey, tempFile, size, mimeType); err != nil {
+			return nil, err
+		}
+
+		if category == ImageMedia {
+			mediaFile.ThumbnailStorageKey = service.storeThumbnail(ctx, tempFile, params.OrganizationId, contentHash)
+		}
+	} else {
+		return nil, err
+	}
+
+	var insertedMediaFile model.MediaFile
+	err = table.MediaFile.
+		INSERT(table.MediaFile.MutableColumns).
+		MODEL(mediaFile).
+		ON_CONFLICT(table.MediaFile.OrganizationId, table.MediaFile.WhatsAppMediaId).
+		DO_NOTHING().
+		RETURNING(table.MediaFile.AllColumns).
+		QueryContext(ctx, service.Db, &insertedMediaFile)
+
+	if err != nil {
+		if err.Error() == qrm.ErrNoRows.Error() && params.WhatsAppMediaId != nil {
+			// * the same whatsapp media has been stored concurrently
+			return service.GetMediaFileByWhatsAppMediaId(ctx, params.OrganizationId, *params.WhatsAppMediaId)
+		}
+		return nil, err
+	}
+
+	return &insertedMediaFile, nil
+}
+
+func (service *MediaService) storeThumbnail(ctx context.Context, content io.ReadSeeker, organizationId uuid.UUID, contentHash string) *string {
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return nil
+	}
+
+	thumbnail, err := createThumbnail(content)
+	if errors.Is(err, errThumbnailSourceTooLarge) {
+		service.Logger.Info("skipping the thumbnail of a too large image", "content_hash", contentHash)
+		return nil
+	}
+	if err != nil {
+		service.Logger.Error("error creating media thumbnail", "error", err.Error())
+		return nil
+	}
+
+	if thumbnail == nil {
+		// * the image is small enough to be its own thumbnail
+		return nil
+	}
+
+	thumbnailKey := fmt.Sprintf("%s/%s_thumbnail.jpg", organizationId.String(), contentHash)
+	if err := service.Storage.Put(ctx, thumbnailKey, bytes.NewReader(thumbnail), int64(len(thumbnail)), "image/jpeg"); err != nil {
+		service.Logger.Error("error storing media thumbnail", "error", err.Error())
+		return nil
+	}
+
+	return &thumbnailKey
+}
+
+// StoreWhatsAppMedia downloads the media from the (expiring) whatsapp media url and stores it
+func (service *MediaService) StoreWhatsAppMedia(ctx context.Context, organizationId uuid.UUID, whatsAppMediaId, mediaUrl, accessToken string, fileName *string) (*model.MediaFile, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, mediaUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+
+	response, err := service.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+		return nil, fmt.Errorf("%w: status %d: %s", errMediaDownloadHttpError, response.StatusCode, string(body))
+	}
+
+	return service.StoreMedia(ctx, StoreMediaParams{
+		OrganizationId:  organizationId,
+		Content:         response.Body,
+		FileName:        fileName,
+		WhatsAppMediaId: &whatsAppMediaId,
+	})
+}
+
+func (service *MediaService) GetMediaFileByWhatsAppMediaId(ctx context.Context, organizationId uuid.UUID, whatsAppMediaId string) (*model.MediaFile, error) {
+	var mediaFile model.MediaFile
+	err := SELECT(table.MediaFile.AllColumns).
+		FROM(table.MediaFile).
+		WHERE(
+			table.MediaFile.OrganizationId.EQ(UUID(organizationId)).
+				AND(table.MediaFile.WhatsAppMediaId.EQ(String(whatsAppMediaId))),
+		).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &mediaFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &mediaFile, nil
+}
+
+func (service *MediaService) GetMediaFileById(ctx context.Context, mediaFileId uuid.UUID) (*model.MediaFile, error) {
+	var mediaFile model.MediaFile
+	err := SELECT(table.MediaFile.AllColumns).
+		FROM(table.MediaFile).
+		WHERE(table.MediaFile.UniqueId.EQ(UUID(mediaFileId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &mediaFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &mediaFile, nil
+}
+
+// GetSignedUrl returns a short lived url to fetch the media file, directly from the storage backend when it supports presigned urls
+func (service *MediaService) GetSignedUrl(mediaFile model.MediaFile, isThumbnail bool) (string, error) {
+	return service.GetSignedUrlWithExpiry(mediaFile, isThumbnail, service.Config.SignedUrlExpiry)
+}
+
+// GetSignedUrlWithExpiry is GetSignedUrl with a custom expiry, presigned urls of the storage backend are capped by the backend
+func (service *MediaService) GetSignedUrlWithExpiry(mediaFile model.MediaFile, isThumbnail bool, expiry time.Duration) (string, error) {
+	storageKey := mediaFile.StorageKey
+	if isThumbnail && mediaFile.ThumbnailStorageKey != nil {
+		storageKey = *mediaFile.ThumbnailStorageKey
+	}
+
+	if mediaFile.StorageBackend == service.Storage.Name() {
+		presignedUrl, ok, err := service.Storage.PresignedUrl(storageKey, expiry)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return presignedUrl, nil
+		}
+	}
+
+	expiresAt := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expiresAt)
+	query.Set("thumbnail", strconv.FormatBool(isThumbnail))
+	query.Set("signature", service.sign(mediaFile.UniqueId.String(), isThumbnail, expiresAt))
+
+	return fmt.Sprintf("/api/media/%s?%s", mediaFile.UniqueId.String(), query.Encode()), nil
+}
+
+// VerifySignedUrl verifies the signature of a url generated by GetSignedUrl
+func (service *MediaService) VerifySignedUrl(mediaFileId string, isThumbnail bool, expiresAt, signature string) error {
+	expiresAtUnix, err := strconv.ParseInt(expiresAt, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAtUnix {
+		return ErrInvalidMediaSignature
+	}
+
+	expectedSignature := service.sign(mediaFileId, isThumbnail, expiresAt)
+	if !hmac.Equal([]byte(expectedSignature), []byte(signature)) {
+		return ErrInvalidMediaSignature
+	}
+
+	return nil
+}
+
+func (service *MediaService) sign(mediaFileId string, isThumbnail bool, expiresAt string) string {
+	mac := hmac.New(sha256.New, []byte(service.Config.UrlSigningKey))
+	mac.Write([]byte(mediaFileId + ":" + strconv.FormatBool(isThumbnail) + ":" + expiresAt))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// OpenMediaFile returns the content of the media file (or of its thumbnail) and its mime type
+func (service *MediaService) OpenMediaFile(ctx context.Context, mediaFile model.MediaFile, isThumbnail bool) (io.ReadCloser, string, error) {
+	if mediaFile.StorageBackend != service.Storage.Name() {
+		return nil, "", fmt.Errorf("media file is stored in the %s storage which is not configured", mediaFile.StorageBackend.String())
+	}
+
+	if isThumbnail && mediaFile.ThumbnailStorageKey != nil {
+		content, err := service.Storage.Get(ctx, *mediaFile.ThumbnailStorageKey)
+		return content, "image/jpeg", err
+	}
+
+	content, err := service.Storage.Get(ctx, mediaFile.StorageKey)
+	return content, mediaFile.MimeType, err
+}
+
+func resolveMediaType(detectedMime *mimetype.MIME) (string, MediaCategory, error) {
+	// * walk up the hierarchy, e.g. text/csv is accepted as its parent text/plain
+	for current := detectedMime; current != nil; current = current.Parent() {
+		mimeType, _, err := mime.ParseMediaType(current.String())
+		if err != nil {
+			continue
+		}
+
+		if category, ok := allowedMimeTypes[mimeType]; ok {
+			return mimeType, category, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("%w: %s", ErrUnsupportedMediaType, detectedMime.String())
+}
+
+// LinkWhatsAppMediaId sets the whatsapp media id of a media file uploaded by the organization
+func (service *MediaService) LinkWhatsAppMediaId(ctx context.Context, mediaFileId uuid.UUID, whatsAppMediaId string) (*model.MediaFile, error) {
+	var mediaFile model.MediaFile
+	err := table.MediaFile.
+		UPDATE(table.MediaFile.WhatsAppMediaId, table.MediaFile.UpdatedAt).
+		SET(String(whatsAppMediaId), TimestampzT(time.Now())).
+		WHERE(table.MediaFile.UniqueId.EQ(UUID(mediaFileId))).
+		RETURNING(table.MediaFile.AllColumns).
+		QueryContext(ctx, service.Db, &mediaFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &mediaFile, nil
+}
@@ -0,0 +1,121 @@
+package media_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wapikit/wapikit/.db-generated/model"
+)
+
+var ErrMediaObjectNotFound = errors.New("media object not found in the storage")
+
+// StorageBackend is the place where the media files are persisted
+type StorageBackend interface {
+	Name() model.MediaStorageBackendEnum
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// PresignedUrl returns a url to fetch the object directly from the backend,
+	// ok is false when the backend can not serve the objects itself and they must be served by the api server.
+	PresignedUrl(key string, expiry time.Duration) (url string, ok bool, err error)
+}
+
+// FilesystemStorage stores the media files in a directory on the local disk
+type FilesystemStorage struct {
+	RootPath string
+}
+
+func NewFilesystemStorage(rootPath string) (*FilesystemStorage, error) {
+	absolutePath, err := filepath.Abs(rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(absolutePath, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &FilesystemStorage{RootPath: absolutePath}, nil
+}
+
+func (storage *FilesystemStorage) Name() model.MediaStorageBackendEnum {
+	return model.MediaStorageBackendEnum_Filesystem
+}
+
+func (storage *FilesystemStorage) resolvePath(key string) (string, error) {
+	path := filepath.Join(storage.RootPath, filepath.FromSlash(key))
+	relativePath, err := filepath.Rel(storage.RootPath, path)
+	if err != nil || relativePath == "." || strings.HasPrefix(relativePath, "..") {
+		return "", fmt.Errorf("invalid media storage key: %s", key)
+	}
+	return path, nil
+}
+
+func (storage *FilesystemStorage) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	path, err := storage.resolvePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	// * write to a temporary file first, so a partially written file is never served
+	tempFile, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := io.Copy(tempFile, content); err != nil {
+		tempFile.Close()
+		return err
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), path)
+}
+
+func (storage *FilesystemStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := storage.resolvePath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrMediaObjectNotFound
+		}
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (storage *FilesystemStorage) Delete(ctx context.Context, key string) error {
+	path, err := storage.resolvePath(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (storage *FilesystemStorage) PresignedUrl(key string, expiry time.Duration) (string, bool, error) {
+	return "", false, nil
+}
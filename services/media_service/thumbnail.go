@@ -0,0 +1,95 @@
+package media_service
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+)
+
+const (
+	thumbnailMaxDimension = 320
+	thumbnailJpegQuality  = 80
+	// * the decoded image takes 4 to 8 bytes per pixel, a small file can declare a huge image and exhaust the memory
+	thumbnailMaxSourcePixels = 40_000_000
+)
+
+var errThumbnailSourceTooLarge = errors.New("the image is too large to create a thumbnail of")
+
+// createThumbnail scales down the image to fit in a thumbnailMaxDimension square and encodes it as a jpeg,
+// it returns nil when the image is already small enough to be used as its own thumbnail
+func createThumbnail(content io.ReadSeeker) ([]byte, error) {
+	config, _, err := image.DecodeConfig(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(config.Width)*int64(config.Height) > thumbnailMaxSourcePixels {
+		return nil, errThumbnailSourceTooLarge
+	}
+
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	sourceImage, _, err := image.Decode(content)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := sourceImage.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= thumbnailMaxDimension && height <= thumbnailMaxDimension {
+		return nil, nil
+	}
+
+	thumbnailWidth, thumbnailHeight := thumbnailMaxDimension, thumbnailMaxDimension
+	if width > height {
+		thumbnailHeight = max(1, height*thumbnailMaxDimension/width)
+	} else {
+		thumbnailWidth = max(1, width*thumbnailMaxDimension/height)
+	}
+
+	thumbnail := image.NewRGBA(image.Rect(0, 0, thumbnailWidth, thumbnailHeight))
+
+	// * box sampling, every pixel of the thumbnail is the average of the source pixels it covers
+	for y := 0; y < thumbnailHeight; y++ {
+		sourceStartY := bounds.Min.Y + y*height/thumbnailHeight
+		sourceEndY := max(sourceStartY+1, bounds.Min.Y+(y+1)*height/thumbnailHeight)
+
+		for x := 0; x < thumbnailWidth; x++ {
+			sourceStartX := bounds.Min.X + x*width/thumbnailWidth
+			sourceEndX := max(sourceStartX+1, bounds.Min.X+(x+1)*width/thumbnailWidth)
+
+			var red, green, blue, alpha, count uint64
+			for sourceY := sourceStartY; sourceY < sourceEndY; sourceY++ {
+				for sourceX := sourceStartX; sourceX < sourceEndX; sourceX++ {
+					r, g, b, a := sourceImage.At(sourceX, sourceY).RGBA()
+					red += uint64(r)
+					green += uint64(g)
+					blue += uint64(b)
+					alpha += uint64(a)
+					count++
+				}
+			}
+
+			thumbnail.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(red / count),
+				G: uint16(green / count),
+				B: uint16(blue / count),
+				A: uint16(alpha / count),
+			})
+		}
+	}
+
+	var encodedThumbnail bytes.Buffer
+	if err := jpeg.Encode(&encodedThumbnail, thumbnail, &jpeg.Options{Quality: thumbnailJpegQuality}); err != nil {
+		return nil, err
+	}
+
+	return encodedThumbnail.Bytes(), nil
+}
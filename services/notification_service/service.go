@@ -3,13 +3,17 @@ package notification_service
 import (
 	"bytes"
 	"database/sql"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"log/slog"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/smtp"
+	"net/textproto"
 	"strings"
 	"time"
 
@@ -87,6 +91,101 @@ func (ns *NotificationService) SendEmail(sendToEmail string, subject string, bod
 	return nil
 }
 
+type EmailAttachment struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+type EmailWithAttachmentsParams struct {
+	// SmtpConfig overrides the email config of the service, e.g. to send from the SMTP server of an organization
+	SmtpConfig  *EmailConfig
+	To          string
+	Subject     string
+	HtmlBody    string
+	Attachments []EmailAttachment
+}
+
+// SendEmailWithAttachments sends a html email with attachments as a multipart MIME message
+func (ns *NotificationService) SendEmailWithAttachments(params EmailWithAttachmentsParams) error {
+	smtpConfig := params.SmtpConfig
+	if smtpConfig == nil {
+		smtpConfig = ns.EmailConfig
+	}
+
+	if smtpConfig == nil || smtpConfig.Host == "" {
+		return fmt.Errorf("email is not configured")
+	}
+
+	var message bytes.Buffer
+	writer := multipart.NewWriter(&message)
+
+	fmt.Fprintf(&message, "From: %s\r\n", smtpConfig.Username)
+	fmt.Fprintf(&message, "To: %s\r\n", params.To)
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", params.Subject))
+	fmt.Fprintf(&message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", writer.Boundary())
+
+	bodyPart, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/html; charset=utf-8"},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return err
+	}
+	if err := writeBase64Lines(bodyPart, []byte(params.HtmlBody)); err != nil {
+		return err
+	}
+
+	for _, attachment := range params.Attachments {
+		attachmentPart, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {attachment.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})},
+		})
+		if err != nil {
+			return err
+		}
+		if err := writeBase64Lines(attachmentPart, attachment.Content); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	auth := smtp.PlainAuth("", smtpConfig.Username, smtpConfig.Password, smtpConfig.Host)
+	address := strings.Join([]string{smtpConfig.Host, smtpConfig.Port}, ":")
+
+	err = smtp.SendMail(
+		address,
+		auth,
+		smtpConfig.Username,
+		[]string{params.To},
+		message.Bytes(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// writeBase64Lines writes the content base64 encoded in lines of 76 characters as required by the MIME spec
+func writeBase64Lines(writer io.Writer, content []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(content)
+	for len(encoded) > 76 {
+		if _, err := io.WriteString(writer, encoded[:76]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err := io.WriteString(writer, encoded+"\r\n")
+	return err
+}
+
 func (ns *NotificationService) SendSlackNotification(params SlackNotificationParams) {
 	log.Printf("Sending slack alert for %s", params.Title)
 
@@ -94,8 +193,13 @@ func (ns *NotificationService) SendSlackNotification(params SlackNotificationPar
 		return
 	}
 
+	ns.SendSlackNotificationWithConfig(*ns.SlackConfig, params)
+}
+
+// SendSlackNotificationWithConfig posts the notification to the given slack webhook, e.g. the slack channel an organization configured
+func (ns *NotificationService) SendSlackNotificationWithConfig(slackConfig SlackConfig, params SlackNotificationParams) {
 	payload := SlackPayload{
-		Channel: ns.SlackConfig.SlackChannel,
+		Channel: slackConfig.SlackChannel,
 		Text:    fmt.Sprintf("*%s*\n\n%s", params.Title, params.Message),
 	}
 
@@ -105,7 +209,7 @@ func (ns *NotificationService) SendSlackNotification(params SlackNotificationPar
 		return
 	}
 
-	req, err := http.NewRequest("POST", ns.SlackConfig.SlackWebhookUrl, bytes.NewBuffer(bodyBytes))
+	req, err := http.NewRequest("POST", slackConfig.SlackWebhookUrl, bytes.NewBuffer(bodyBytes))
 	if err != nil {
 		log.Printf("Error creating Slack request: %v", err)
 		return
@@ -133,6 +237,7 @@ type InAppNotificationParams struct {
 	OrganizationMemberId *string
 	OrganizationId       *string
 	CtaUrl               *string
+	Type                 *string
 	IsBroadcast          bool // if broadcast, then this
 }
 
@@ -172,7 +277,7 @@ func (ns *NotificationService) SendInAppNotification(params InAppNotificationPar
 		CtaUrl:               params.CtaUrl,
 		Title:                params.Title,
 		Description:          params.Description,
-		Type:                 nil,
+		Type:                 params.Type,
 		OrganizationId:       orgUuid,
 		IsBroadcast:          params.IsBroadcast,
 		OrganizationMemberId: memberUuid,
@@ -193,6 +298,11 @@ func (ns *NotificationService) SendInAppNotification(params InAppNotificationPar
 		return
 	}
 
+	notificationType := ""
+	if insertedNotification.Type != nil {
+		notificationType = *insertedNotification.Type
+	}
+
 	event := event_service.NewNewNotificationEvent(
 		api_types.NotificationSchema{
 			CtaUrl:         insertedNotification.CtaUrl,
@@ -200,7 +310,7 @@ func (ns *NotificationService) SendInAppNotification(params InAppNotificationPar
 			CreatedAt:      insertedNotification.CreatedAt,
 			Description:    insertedNotification.Description,
 			Read:           false,
-			Type:           *insertedNotification.Type,
+			Type:           notificationType,
 			UniqueId:       insertedNotification.UniqueId.String(),
 			OrganizationId: params.OrganizationId,
 		},
@@ -0,0 +1,240 @@
+package pii_redactor
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// DetectorName names the PII a detector finds, it is the prefix of the placeholders, e.g. PHONE in <PHONE_1>
+type DetectorName string
+
+const (
+	DetectorEmail   DetectorName = "EMAIL"
+	DetectorPhone   DetectorName = "PHONE"
+	DetectorCard    DetectorName = "CARD"
+	DetectorSsn     DetectorName = "SSN"
+	DetectorIban    DetectorName = "IBAN"
+	DetectorAadhaar DetectorName = "AADHAAR"
+	DetectorPan     DetectorName = "PAN"
+)
+
+// Detector finds one kind of PII in a text, the matches of the pattern are only PII when they pass the validation, if any
+type Detector struct {
+	Name        DetectorName
+	Description string
+	Pattern     *regexp.Regexp
+	// Validate weeds out the matches of the pattern that are not PII, e.g. the card numbers failing the Luhn check
+	Validate func(match string) bool
+}
+
+var (
+	registryLock sync.RWMutex
+	registry     = []Detector{}
+)
+
+// RegisterDetector adds a detector to the registry, the detectors are applied in the order they were registered
+// and a detector registered again under the same name replaces the previous one
+func RegisterDetector(detector Detector) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	for index, registered := range registry {
+		if registered.Name == detector.Name {
+			registry[index] = detector
+			return
+		}
+	}
+	registry = append(registry, detector)
+}
+
+// RegisteredDetectors returns the detectors of the registry in the order they are applied
+func RegisteredDetectors() []Detector {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	return append([]Detector{}, registry...)
+}
+
+// RegisteredDetector returns the detector of the registry with the name
+func RegisteredDetector(name DetectorName) (Detector, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	for _, detector := range registry {
+		if detector.Name == name {
+			return detector, true
+		}
+	}
+	return Detector{}, false
+}
+
+var detectorNamePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]{0,29}$`)
+
+// NewCustomDetector compiles the regular expression of an organization into a detector, its name is upper cased
+// to read like the built in placeholders
+func NewCustomDetector(name, pattern string) (Detector, error) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !detectorNamePattern.MatchString(name) {
+		return Detector{}, fmt.Errorf("the name %q of a custom detector must start with a letter and only have letters, digits and underscores", name)
+	}
+	if _, isBuiltIn := RegisteredDetector(DetectorName(name)); isBuiltIn {
+		return Detector{}, fmt.Errorf("the name %q of a custom detector is already used by a built in detector", name)
+	}
+
+	compiledPattern, err := regexp.Compile(pattern)
+	if err != nil {
+		return Detector{}, fmt.Errorf("invalid pattern of the custom detector %q: %w", name, err)
+	}
+	if compiledPattern.MatchString("") {
+		return Detector{}, fmt.Errorf("the pattern of the custom detector %q must not match an empty text", name)
+	}
+
+	return Detector{
+		Name:        DetectorName(name),
+		Description: "Custom detector",
+		Pattern:     compiledPattern,
+	}, nil
+}
+
+func init() {
+	RegisterDetector(Detector{
+		Name:        DetectorEmail,
+		Description: "Email addresses",
+		Pattern:     regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`),
+	})
+	RegisterDetector(Detector{
+		Name:        DetectorIban,
+		Description: "International bank account numbers, validated with their mod 97 check digits",
+		Pattern:     regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]){11,30}\b`),
+		Validate:    isValidIban,
+	})
+	RegisterDetector(Detector{
+		Name:        DetectorCard,
+		Description: "Payment card numbers, validated with the Luhn checksum",
+		Pattern:     regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		Validate:    isValidCardNumber,
+	})
+	RegisterDetector(Detector{
+		Name:        DetectorAadhaar,
+		Description: "Indian Aadhaar numbers, validated with the Verhoeff checksum",
+		Pattern:     regexp.MustCompile(`\b[2-9]\d{3}[ -]?\d{4}[ -]?\d{4}\b`),
+		Validate:    isValidAadhaar,
+	})
+	RegisterDetector(Detector{
+		Name:        DetectorPan,
+		Description: "Indian permanent account numbers (PAN)",
+		Pattern:     regexp.MustCompile(`\b[A-Z]{3}[ABCFGHJLPT][A-Z]\d{4}[A-Z]\b`),
+	})
+	RegisterDetector(Detector{
+		Name:        DetectorSsn,
+		Description: "US social security numbers",
+		Pattern:     regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+	})
+	RegisterDetector(Detector{
+		Name:        DetectorPhone,
+		Description: "Phone numbers in the E.164 format and 10 digit national numbers",
+		Pattern:     regexp.MustCompile(`\+[1-9](?:[ -]?\d){6,14}\b|\(?\b\d{3}\)?[ .-]?\d{3}[ .-]?\d{4}\b`),
+	})
+}
+
+func digitsOf(text string) []int {
+	digits := make([]int, 0, len(text))
+	for _, character := range text {
+		if unicode.IsDigit(character) {
+			digits = append(digits, int(character-'0'))
+		}
+	}
+	return digits
+}
+
+// isValidCardNumber checks the Luhn checksum of the 13 to 19 digits of a card number
+func isValidCardNumber(match string) bool {
+	digits := digitsOf(match)
+	if len(digits) < 13 || len(digits) > 19 {
+		return false
+	}
+
+	sum := 0
+	for index := range digits {
+		digit := digits[len(digits)-1-index]
+		if index%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
+
+// isValidIban checks the mod 97 check digits of an IBAN, the letters count as the numbers 10 to 35
+func isValidIban(match string) bool {
+	iban := strings.ReplaceAll(match, " ", "")
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+
+	rearranged := iban[4:] + iban[:4]
+	numeric := strings.Builder{}
+	for _, character := range rearranged {
+		switch {
+		case character >= '0' && character <= '9':
+			numeric.WriteRune(character)
+		case character >= 'A' && character <= 'Z':
+			numeric.WriteString(fmt.Sprint(character - 'A' + 10))
+		default:
+			return false
+		}
+	}
+
+	number, isNumber := new(big.Int).SetString(numeric.String(), 10)
+	if !isNumber {
+		return false
+	}
+	return new(big.Int).Mod(number, big.NewInt(97)).Int64() == 1
+}
+
+var (
+	verhoeffMultiplication = [10][10]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 2, 3, 4, 0, 6, 7, 8, 9, 5},
+		{2, 3, 4, 0, 1, 7, 8, 9, 5, 6},
+		{3, 4, 0, 1, 2, 8, 9, 5, 6, 7},
+		{4, 0, 1, 2, 3, 9, 5, 6, 7, 8},
+		{5, 9, 8, 7, 6, 0, 4, 3, 2, 1},
+		{6, 5, 9, 8, 7, 1, 0, 4, 3, 2},
+		{7, 6, 5, 9, 8, 2, 1, 0, 4, 3},
+		{8, 7, 6, 5, 9, 3, 2, 1, 0, 4},
+		{9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+	}
+	verhoeffPermutation = [8][10]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{1, 5, 7, 6, 2, 8, 3, 0, 9, 4},
+		{5, 8, 0, 3, 7, 9, 6, 1, 4, 2},
+		{8, 9, 1, 6, 0, 4, 3, 5, 2, 7},
+		{9, 4, 5, 3, 1, 2, 6, 8, 7, 0},
+		{4, 2, 8, 6, 5, 7, 3, 9, 0, 1},
+		{2, 7, 9, 3, 8, 0, 6, 4, 1, 5},
+		{7, 0, 4, 6, 9, 1, 3, 2, 5, 8},
+	}
+)
+
+// isValidAadhaar checks the Verhoeff checksum of the 12 digits of an Aadhaar number
+func isValidAadhaar(match string) bool {
+	digits := digitsOf(match)
+	if len(digits) != 12 {
+		return false
+	}
+
+	checksum := 0
+	for index := range digits {
+		digit := digits[len(digits)-1-index]
+		checksum = verhoeffMultiplication[checksum][verhoeffPermutation[index%8][digit]]
+	}
+	return checksum == 0
+}
@@ -1,30 +1,232 @@
 package pii_redactor
 
 import (
+	"fmt"
 	"regexp"
-
-	"github.com/cockroachdb/redact"
+	"sort"
+	"strings"
+	"sync"
 )
 
+const redactedPlaceholder = "[REDACTED]"
+
+// maxPlaceholderLength is the length of the longest placeholder, <NAME_N> with a name of at most 30 characters
+const maxPlaceholderLength = 48
+
+var placeholderPattern = regexp.MustCompile(`<([A-Z][A-Z0-9_]*)_(\d+)>`)
+
+// PIIRedactor finds the PII in the texts with its detectors, either to redact it for good or to replace it
+// with placeholders that can be restored later
 type PIIRedactor struct {
-	patterns []*regexp.Regexp
+	detectors []Detector
 }
 
+// NewPIIRedactor returns a redactor with all the detectors of the registry
 func NewPIIRedactor() *PIIRedactor {
-	// Define regex patterns for common PII types
-	patterns := []*regexp.Regexp{
-		regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),                               // SSN
-		regexp.MustCompile(`\b\d{4} \d{4} \d{4} \d{4}\b`),                         // Credit Card
-		regexp.MustCompile(`\b\d{10}\b`),                                          // Phone Number
-		regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Z|a-z]{2,}\b`), // Email
+	return &PIIRedactor{detectors: RegisteredDetectors()}
+}
+
+// RedactorConfig is the PII redaction an organization configured
+type RedactorConfig struct {
+	// EnabledDetectors are the names of the registered detectors to apply, all of them when nil
+	EnabledDetectors []DetectorName
+	// CustomDetectors are the regular expressions of the organization, applied after the registered detectors
+	CustomDetectors []CustomDetectorConfig
+}
+
+// CustomDetectorConfig is a regular expression of an organization along with the name of its placeholders
+type CustomDetectorConfig struct {
+	Name    string
+	Pattern string
+}
+
+// NewConfiguredPIIRedactor returns a redactor with the enabled detectors of the registry followed by the custom ones
+func NewConfiguredPIIRedactor(config RedactorConfig) (*PIIRedactor, error) {
+	detectors := []Detector{}
+	if config.EnabledDetectors == nil {
+		detectors = RegisteredDetectors()
+	} else {
+		for _, name := range config.EnabledDetectors {
+			detector, exists := RegisteredDetector(name)
+			if !exists {
+				return nil, fmt.Errorf("unknown PII detector %q", name)
+			}
+			detectors = append(detectors, detector)
+		}
 	}
-	return &PIIRedactor{patterns: patterns}
+
+	customNames := map[DetectorName]bool{}
+	for _, customDetector := range config.CustomDetectors {
+		detector, err := NewCustomDetector(customDetector.Name, customDetector.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		if customNames[detector.Name] {
+			return nil, fmt.Errorf("the name %q is used by more than one custom detector", detector.Name)
+		}
+		customNames[detector.Name] = true
+		detectors = append(detectors, detector)
+	}
+
+	return &PIIRedactor{detectors: detectors}, nil
 }
 
+type piiMatch struct {
+	start    int
+	end      int
+	detector DetectorName
+}
+
+// findMatches returns the PII of the text in order, a match overlapping an earlier or a longer one is dropped
+func (r *PIIRedactor) findMatches(input string) []piiMatch {
+	matches := []piiMatch{}
+	for _, detector := range r.detectors {
+		for _, location := range detector.Pattern.FindAllStringIndex(input, -1) {
+			if location[0] == location[1] {
+				continue
+			}
+			if detector.Validate != nil && !detector.Validate(input[location[0]:location[1]]) {
+				continue
+			}
+			matches = append(matches, piiMatch{start: location[0], end: location[1], detector: detector.Name})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].start != matches[j].start {
+			return matches[i].start < matches[j].start
+		}
+		return matches[i].end > matches[j].end
+	})
+
+	nonOverlappingMatches := []piiMatch{}
+	end := 0
+	for _, match := range matches {
+		if match.start < end {
+			continue
+		}
+		nonOverlappingMatches = append(nonOverlappingMatches, match)
+		end = match.end
+	}
+	return nonOverlappingMatches
+}
+
+func (r *PIIRedactor) replaceMatches(input string, replacement func(match piiMatch) string) string {
+	matches := r.findMatches(input)
+	if len(matches) == 0 {
+		return input
+	}
+
+	output := strings.Builder{}
+	previousEnd := 0
+	for _, match := range matches {
+		output.WriteString(input[previousEnd:match.start])
+		output.WriteString(replacement(match))
+		previousEnd = match.end
+	}
+	output.WriteString(input[previousEnd:])
+	return output.String()
+}
+
+// Redact replaces the PII of the text for good
 func (r *PIIRedactor) Redact(input string) string {
-	redacted := redact.RedactableString(input)
-	for _, pattern := range r.patterns {
-		redacted = redact.RedactableString(pattern.ReplaceAllString(string(redacted), "[REDACTED]"))
+	return r.replaceMatches(input, func(match piiMatch) string {
+		return redactedPlaceholder
+	})
+}
+
+// NewRedaction starts a reversible redaction, the texts of a single AI call are redacted with the same redaction
+// so that a value gets the same placeholder in all of them
+func (r *PIIRedactor) NewRedaction() *Redaction {
+	return &Redaction{
+		redactor:            r,
+		placeholderToValue:  map[string]string{},
+		valueToPlaceholder:  map[string]string{},
+		countByDetectorName: map[DetectorName]int{},
+	}
+}
+
+// Redaction replaces the PII with numbered placeholders like <PHONE_1> and remembers the values, so that the placeholders
+// the model answers with can be restored before the answer is shown
+type Redaction struct {
+	redactor            *PIIRedactor
+	lock                sync.Mutex
+	placeholderToValue  map[string]string
+	valueToPlaceholder  map[string]string
+	countByDetectorName map[DetectorName]int
+}
+
+// Redact replaces the PII of the text with placeholders
+func (redaction *Redaction) Redact(input string) string {
+	redaction.lock.Lock()
+	defer redaction.lock.Unlock()
+
+	return redaction.redactor.replaceMatches(input, func(match piiMatch) string {
+		value := input[match.start:match.end]
+		if placeholder, exists := redaction.valueToPlaceholder[value]; exists {
+			return placeholder
+		}
+
+		redaction.countByDetectorName[match.detector]++
+		placeholder := fmt.Sprintf("<%s_%d>", match.detector, redaction.countByDetectorName[match.detector])
+		redaction.placeholderToValue[placeholder] = value
+		redaction.valueToPlaceholder[value] = placeholder
+		return placeholder
+	})
+}
+
+// Restore puts the values back in place of the placeholders of the redaction, the other placeholders are left as they are
+func (redaction *Redaction) Restore(input string) string {
+	redaction.lock.Lock()
+	defer redaction.lock.Unlock()
+
+	if len(redaction.placeholderToValue) == 0 {
+		return input
+	}
+
+	return placeholderPattern.ReplaceAllStringFunc(input, func(placeholder string) string {
+		if value, exists := redaction.placeholderToValue[placeholder]; exists {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// Count returns the number of distinct values the redaction replaced
+func (redaction *Redaction) Count() int {
+	redaction.lock.Lock()
+	defer redaction.lock.Unlock()
+
+	return len(redaction.placeholderToValue)
+}
+
+// NewStreamRestorer restores the placeholders of an answer streamed chunk by chunk
+func (redaction *Redaction) NewStreamRestorer() *StreamRestorer {
+	return &StreamRestorer{redaction: redaction}
+}
+
+// StreamRestorer holds back the end of a chunk that may be the beginning of a placeholder split across chunks
+type StreamRestorer struct {
+	redaction *Redaction
+	pending   string
+}
+
+// Write returns the restored text of the chunk that can be sent on
+func (restorer *StreamRestorer) Write(chunk string) string {
+	text := restorer.pending + chunk
+	restorer.pending = ""
+
+	if index := strings.LastIndex(text, "<"); index != -1 && !strings.Contains(text[index:], ">") && len(text)-index < maxPlaceholderLength {
+		restorer.pending = text[index:]
+		text = text[:index]
 	}
-	return string(redacted.Redact())
+
+	return restorer.redaction.Restore(text)
+}
+
+// Flush returns the restored text held back, once the stream ended
+func (restorer *StreamRestorer) Flush() string {
+	text := restorer.pending
+	restorer.pending = ""
+	return restorer.redaction.Restore(text)
 }
@@ -0,0 +1,95 @@
+package template_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/wapikit/wapikit/.db-generated/model"
+)
+
+// ! https://developers.facebook.com/docs/graph-api/guides/upload
+// * the media of a template header is not a media of the phone number, it is uploaded to the meta app with the resumable
+// * upload API and the template refers to it with the handle of the upload
+
+var ErrUnsupportedHeaderMedia = errors.New("the header of a template only supports jpeg and png images, mp4 videos and pdf documents")
+
+var templateHeaderMimeTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"video/mp4":       true,
+	"application/pdf": true,
+}
+
+// UploadHeaderMedia uploads a stored media file for the header of a template and returns the handle to set in the example of the header
+func (service *TemplateService) UploadHeaderMedia(ctx context.Context, businessAccount model.WhatsappBusinessAccount, mediaFile model.MediaFile) (string, error) {
+	if !templateHeaderMimeTypes[mediaFile.MimeType] {
+		return "", ErrUnsupportedHeaderMedia
+	}
+
+	appId, err := service.getAppId(ctx, businessAccount.AccessToken)
+	if err != nil {
+		return "", err
+	}
+
+	fileName := mediaFile.UniqueId.String()
+	if mediaFile.FileName != nil && *mediaFile.FileName != "" {
+		fileName = *mediaFile.FileName
+	}
+
+	query := url.Values{}
+	query.Set("file_name", fileName)
+	query.Set("file_length", strconv.FormatInt(mediaFile.SizeInBytes, 10))
+	query.Set("file_type", mediaFile.MimeType)
+
+	var uploadSession struct {
+		Id string `json:"id"`
+	}
+	err = service.graphRequest(ctx, businessAccount.AccessToken, http.MethodPost, fmt.Sprintf("%s/%s/uploads?%s", whatsAppGraphApiUrl, appId, query.Encode()), nil, &uploadSession)
+	if err != nil {
+		return "", err
+	}
+
+	content, _, err := service.MediaService.OpenMediaFile(ctx, mediaFile, false)
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s", whatsAppGraphApiUrl, uploadSession.Id), content)
+	if err != nil {
+		return "", err
+	}
+	request.ContentLength = mediaFile.SizeInBytes
+	// * the upload API only accepts the access token as an OAuth header
+	request.Header.Set("Authorization", "OAuth "+businessAccount.AccessToken)
+	request.Header.Set("file_offset", "0")
+
+	var uploadedFile struct {
+		Handle string `json:"h"`
+	}
+	if err := service.doGraphRequest(request, &uploadedFile); err != nil {
+		return "", err
+	}
+
+	return uploadedFile.Handle, nil
+}
+
+func (service *TemplateService) getAppId(ctx context.Context, accessToken string) (string, error) {
+	if appId, ok := service.appIds.Load(accessToken); ok {
+		return appId.(string), nil
+	}
+
+	var app struct {
+		Id string `json:"id"`
+	}
+	if err := service.graphRequest(ctx, accessToken, http.MethodGet, whatsAppGraphApiUrl+"/app", nil, &app); err != nil {
+		return "", err
+	}
+
+	service.appIds.Store(accessToken, app.Id)
+	return app.Id, nil
+}
@@ -0,0 +1,444 @@
+package template_service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	"github.com/wapikit/wapikit/services/business_account_service"
+	"github.com/wapikit/wapikit/services/media_service"
+	cache_service "github.com/wapikit/wapikit/services/redis_service"
+	"github.com/wapikit/wapikit/utils"
+)
+
+// ! https://developers.facebook.com/docs/whatsapp/business-management-api/message-templates
+// * the templates are managed with the graph API directly, the whatsapp answers are kept as is in the local table so that
+// * the campaigns and the template webhooks do not need to call whatsapp to know the status of a template
+
+const whatsAppGraphApiUrl = "https://graph.facebook.com/v21.0"
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+	ErrTemplateInUse    = errors.New("the template is used by a running or scheduled campaign, pause or cancel the campaign first")
+	// ErrTemplateNotSendable is returned for the campaigns whose template whatsapp does not allow to send, e.g. a paused or rejected template
+	ErrTemplateNotSendable = errors.New("the template of the campaign is not approved")
+)
+
+// GraphApiError is the error whatsapp answered a request with, e.g. a template name already in use
+type GraphApiError struct {
+	StatusCode int
+	Message    string
+}
+
+func (err *GraphApiError) Error() string {
+	return err.Message
+}
+
+// TemplateService keeps a local copy of the message templates of the business accounts of the organizations
+type TemplateService struct {
+	Logger                 *slog.Logger
+	Db                     *sql.DB
+	Redis                  *cache_service.RedisClient
+	BusinessAccountService *business_account_service.BusinessAccountService
+	MediaService           *media_service.MediaService
+	httpClient             *http.Client
+	// appIds caches the id of the meta app of the access token of a business account, the media of the headers are uploaded to the app
+	appIds sync.Map
+}
+
+func NewTemplateService(db *sql.DB, logger *slog.Logger, redis *cache_service.RedisClient, businessAccountService *business_account_service.BusinessAccountService, mediaService *media_service.MediaService) *TemplateService {
+	return &TemplateService{
+		Logger:                 logger,
+		Db:                     db,
+		Redis:                  redis,
+		BusinessAccountService: businessAccountService,
+		MediaService:           mediaService,
+		httpClient:             &http.Client{Timeout: 2 * time.Minute},
+	}
+}
+
+type TemplateWithBusinessAccount struct {
+	model.MessageTemplate
+	WhatsappBusinessAccount model.WhatsappBusinessAccount
+}
+
+var (
+	templateStatuses = map[string]model.MessageTemplateStatusEnum{
+		"APPROVED":         model.MessageTemplateStatusEnum_Approved,
+		"PENDING":          model.MessageTemplateStatusEnum_Pending,
+		"REJECTED":         model.MessageTemplateStatusEnum_Rejected,
+		"PAUSED":           model.MessageTemplateStatusEnum_Paused,
+		"DISABLED":         model.MessageTemplateStatusEnum_Disabled,
+		"IN_APPEAL":        model.MessageTemplateStatusEnum_InAppeal,
+		"PENDING_DELETION": model.MessageTemplateStatusEnum_PendingDeletion,
+		"DELETED":          model.MessageTemplateStatusEnum_Deleted,
+		"LIMIT_EXCEEDED":   model.MessageTemplateStatusEnum_LimitExceeded,
+		"ARCHIVED":         model.MessageTemplateStatusEnum_Archived,
+		// * a paused template whatsapp lifted the pause of after its quality recovered
+		"REINSTATED": model.MessageTemplateStatusEnum_Approved,
+	}
+	templateQualities = map[string]model.MessageTemplateQualityEnum{
+		"GREEN":   model.MessageTemplateQualityEnum_Green,
+		"YELLOW":  model.MessageTemplateQualityEnum_Yellow,
+		"RED":     model.MessageTemplateQualityEnum_Red,
+		"UNKNOWN": model.MessageTemplateQualityEnum_Unknown,
+	}
+	templateCategories = map[string]model.MessageTemplateCategoryEnum{
+		"MARKETING":      model.MessageTemplateCategoryEnum_Marketing,
+		"UTILITY":        model.MessageTemplateCategoryEnum_Utility,
+		"AUTHENTICATION": model.MessageTemplateCategoryEnum_Authentication,
+	}
+)
+
+// ParseTemplateStatus maps a status of whatsapp, e.g. IN_APPEAL, to the status of the local table
+func ParseTemplateStatus(status string) (model.MessageTemplateStatusEnum, bool) {
+	parsedStatus, ok := templateStatuses[strings.ToUpper(strings.TrimSpace(status))]
+	return parsedStatus, ok
+}
+
+// ParseTemplateQuality maps a quality score of whatsapp, e.g. YELLOW, to the quality of the local table
+func ParseTemplateQuality(quality string) model.MessageTemplateQualityEnum {
+	if parsedQuality, ok := templateQualities[strings.ToUpper(strings.TrimSpace(quality))]; ok {
+		return parsedQuality
+	}
+	return model.MessageTemplateQualityEnum_Unknown
+}
+
+func parseTemplateCategory(category string) model.MessageTemplateCategoryEnum {
+	if parsedCategory, ok := templateCategories[strings.ToUpper(strings.TrimSpace(category))]; ok {
+		return parsedCategory
+	}
+	return model.MessageTemplateCategoryEnum_Marketing
+}
+
+func whatsAppValue[T ~string](values map[string]T, value T) string {
+	for whatsAppValue, localValue := range values {
+		if localValue == value && whatsAppValue != "REINSTATED" {
+			return whatsAppValue
+		}
+	}
+	return ""
+}
+
+// IsTemplateSendable tells whether whatsapp accepts the messages of a template in this status
+func IsTemplateSendable(status model.MessageTemplateStatusEnum) bool {
+	return status == model.MessageTemplateStatusEnum_Approved
+}
+
+func ParseTemplateToApiSchema(template TemplateWithBusinessAccount) api_types.MessageTemplateSchema {
+	components := []api_types.WhatsAppBusinessHSMWhatsAppHSMComponent{}
+	if err := json.Unmarshal([]byte(template.Components), &components); err != nil {
+		components = []api_types.WhatsAppBusinessHSMWhatsAppHSMComponent{}
+	}
+
+	quality := api_types.MessageTemplateQualityRating(whatsAppValue(templateQualities, template.Quality))
+	businessAccountId := template.WhatsappBusinessAccount.AccountId
+
+	templateToReturn := api_types.MessageTemplateSchema{
+		Id:                template.TemplateId,
+		Name:              template.Name,
+		Language:          template.Language,
+		Category:          api_types.MessageTemplateCategory(whatsAppValue(templateCategories, template.Category)),
+		Status:            api_types.MessageTemplateStatus(whatsAppValue(templateStatuses, template.Status)),
+		StatusReason:      template.StatusReason,
+		QualityRating:     &quality,
+		Components:        &components,
+		BusinessAccountId: &businessAccountId,
+		LastSyncedAt:      template.LastSyncedAt,
+	}
+
+	if template.Status == model.MessageTemplateStatusEnum_Rejected {
+		templateToReturn.RejectedReason = template.StatusReason
+	}
+
+	return templateToReturn
+}
+
+// ResolveBusinessAccount returns the business account of the organization with the given whatsapp id, or the business account
+// of the default phone number of the organization when no id is given
+func (service *TemplateService) ResolveBusinessAccount(ctx context.Context, organizationId uuid.UUID, businessAccountId string) (*model.WhatsappBusinessAccount, error) {
+	if businessAccountId == "" {
+		phoneNumber, err := service.BusinessAccountService.GetDefaultPhoneNumber(ctx, organizationId)
+		if err == nil {
+			return &phoneNumber.WhatsappBusinessAccount, nil
+		}
+		if !errors.Is(err, business_account_service.ErrPhoneNumberNotFound) {
+			return nil, err
+		}
+	}
+
+	businessAccounts, err := service.BusinessAccountService.ListBusinessAccounts(ctx, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, businessAccount := range businessAccounts {
+		if businessAccountId == "" || businessAccount.AccountId == businessAccountId {
+			return &businessAccount, nil
+		}
+	}
+
+	return nil, business_account_service.ErrBusinessAccountNotFound
+}
+
+// GetTemplates returns the templates of the organization, the deleted templates are left out
+func (service *TemplateService) GetTemplates(ctx context.Context, organizationId uuid.UUID) ([]TemplateWithBusinessAccount, error) {
+	templates := []TemplateWithBusinessAccount{}
+
+	err := SELECT(table.MessageTemplate.AllColumns, table.WhatsappBusinessAccount.AllColumns).
+		FROM(
+			table.MessageTemplate.
+				LEFT_JOIN(table.WhatsappBusinessAccount, table.WhatsappBusinessAccount.UniqueId.EQ(table.MessageTemplate.WhatsappBusinessAccountId)),
+		).
+		WHERE(
+			table.MessageTemplate.OrganizationId.EQ(UUID(organizationId)).
+				AND(table.MessageTemplate.Status.NOT_EQ(utils.EnumExpression(model.MessageTemplateStatusEnum_Deleted.String()))),
+		).
+		ORDER_BY(table.MessageTemplate.Name.ASC(), table.MessageTemplate.Language.ASC()).
+		QueryContext(ctx, service.Db, &templates)
+
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// GetTemplate returns the template of the organization with the given whatsapp id
+func (service *TemplateService) GetTemplate(ctx context.Context, organizationId uuid.UUID, templateId string) (*TemplateWithBusinessAccount, error) {
+	var template TemplateWithBusinessAccount
+
+	err := SELECT(table.MessageTemplate.AllColumns, table.WhatsappBusinessAccount.AllColumns).
+		FROM(
+			table.MessageTemplate.
+				LEFT_JOIN(table.WhatsappBusinessAccount, table.WhatsappBusinessAccount.UniqueId.EQ(table.MessageTemplate.WhatsappBusinessAccountId)),
+		).
+		WHERE(
+			table.MessageTemplate.OrganizationId.EQ(UUID(organizationId)).
+				AND(table.MessageTemplate.TemplateId.EQ(String(templateId))),
+		).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &template)
+
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+// CheckTemplateSendable returns ErrTemplateNotSendable when the template is known to be in a status whatsapp does not send, the
+// templates not synced yet are let through, whatsapp rejects their messages anyway
+func (service *TemplateService) CheckTemplateSendable(ctx context.Context, organizationId uuid.UUID, templateId string) error {
+	template, err := service.GetTemplate(ctx, organizationId, templateId)
+	if err != nil {
+		if errors.Is(err, ErrTemplateNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if !IsTemplateSendable(template.Status) {
+		return fmt.Errorf("%w, the template %s is %s", ErrTemplateNotSendable, template.Name, strings.ToLower(template.Status.String()))
+	}
+
+	return nil
+}
+
+type CreateTemplateParams struct {
+	OrganizationId      uuid.UUID
+	BusinessAccount     model.WhatsappBusinessAccount
+	Name                string
+	Language            string
+	Category            api_types.MessageTemplateCategory
+	Components          []api_types.WhatsAppBusinessHSMWhatsAppHSMComponent
+	AllowCategoryChange bool
+}
+
+// CreateTemplate submits the template to whatsapp for review and stores it, whatsapp may answer with another category than
+// the one asked for when the category change is allowed
+func (service *TemplateService) CreateTemplate(ctx context.Context, params CreateTemplateParams) (*TemplateWithBusinessAccount, error) {
+	var response struct {
+		Id       string `json:"id"`
+		Status   string `json:"status"`
+		Category string `json:"category"`
+	}
+
+	err := service.graphRequest(ctx, params.BusinessAccount.AccessToken, http.MethodPost, fmt.Sprintf("%s/%s/message_templates", whatsAppGraphApiUrl, params.BusinessAccount.AccountId), map[string]interface{}{
+		"name":                  params.Name,
+		"language":              params.Language,
+		"category":              params.Category,
+		"components":            params.Components,
+		"allow_category_change": params.AllowCategoryChange,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	category := response.Category
+	if category == "" {
+		category = string(params.Category)
+	}
+
+	return service.upsertTemplate(ctx, params.BusinessAccount, whatsAppTemplate{
+		Id:         response.Id,
+		Name:       params.Name,
+		Language:   params.Language,
+		Category:   category,
+		Status:     response.Status,
+		Components: params.Components,
+	})
+}
+
+// UpdateTemplate edits the components and optionally the category of a template, the edited template is fetched back from
+// whatsapp as it goes through the review again
+func (service *TemplateService) UpdateTemplate(ctx context.Context, organizationId uuid.UUID, templateId string, category *api_types.MessageTemplateCategory, components []api_types.WhatsAppBusinessHSMWhatsAppHSMComponent) (*TemplateWithBusinessAccount, error) {
+	template, err := service.GetTemplate(ctx, organizationId, templateId)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := map[string]interface{}{
+		"components": components,
+	}
+	if category != nil {
+		payload["category"] = *category
+	}
+
+	err = service.graphRequest(ctx, template.WhatsappBusinessAccount.AccessToken, http.MethodPost, fmt.Sprintf("%s/%s", whatsAppGraphApiUrl, url.PathEscape(template.TemplateId)), payload, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.SyncTemplate(ctx, template.WhatsappBusinessAccount, template.TemplateId)
+}
+
+// DeleteTemplate deletes the template in whatsapp and in the local table
+func (service *TemplateService) DeleteTemplate(ctx context.Context, organizationId uuid.UUID, templateId string) error {
+	template, err := service.GetTemplate(ctx, organizationId, templateId)
+	if err != nil {
+		return err
+	}
+
+	var campaignsUsingTemplate []model.Campaign
+	err = SELECT(table.Campaign.UniqueId).
+		FROM(table.Campaign).
+		WHERE(
+			table.Campaign.OrganizationId.EQ(UUID(organizationId)).
+				AND(table.Campaign.MessageTemplateId.EQ(String(templateId))).
+				AND(table.Campaign.Status.IN(
+					utils.EnumExpression(model.CampaignStatusEnum_Running.String()),
+					utils.EnumExpression(model.CampaignStatusEnum_Scheduled.String()),
+				)),
+		).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &campaignsUsingTemplate)
+
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		return err
+	}
+	if len(campaignsUsingTemplate) > 0 {
+		return ErrTemplateInUse
+	}
+
+	// * the id deletes only this language of the template, the name alone would delete all of its languages
+	query := url.Values{}
+	query.Set("hsm_id", template.TemplateId)
+	query.Set("name", template.Name)
+
+	err = service.graphRequest(ctx, template.WhatsappBusinessAccount.AccessToken, http.MethodDelete, fmt.Sprintf("%s/%s/message_templates?%s", whatsAppGraphApiUrl, template.WhatsappBusinessAccount.AccountId, query.Encode()), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = table.MessageTemplate.
+		DELETE().
+		WHERE(table.MessageTemplate.UniqueId.EQ(UUID(template.UniqueId))).
+		ExecContext(ctx, service.Db)
+
+	return err
+}
+
+// graphRequest sends a request to the graph API and decodes its answer in dest, the errors answered by whatsapp are returned as a GraphApiError
+func (service *TemplateService) graphRequest(ctx context.Context, accessToken, method, requestUrl string, payload interface{}, dest interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		encodedPayload, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encodedPayload)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, requestUrl, body)
+	if err != nil {
+		return err
+	}
+
+	if payload != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	request.Header.Set("Authorization", "Bearer "+accessToken)
+
+	return service.doGraphRequest(request, dest)
+}
+
+func (service *TemplateService) doGraphRequest(request *http.Request, dest interface{}) error {
+	response, err := service.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, 10<<20))
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		var errorResponse struct {
+			Error struct {
+				Message      string `json:"message"`
+				ErrorUserMsg string `json:"error_user_msg"`
+			} `json:"error"`
+		}
+		message := fmt.Sprintf("whatsapp answered with status %d", response.StatusCode)
+		if err := json.Unmarshal(responseBody, &errorResponse); err == nil {
+			// * the user message tells what to fix in the template, e.g. a name already in use
+			if errorResponse.Error.ErrorUserMsg != "" {
+				message = errorResponse.Error.ErrorUserMsg
+			} else if errorResponse.Error.Message != "" {
+				message = errorResponse.Error.Message
+			}
+		}
+		return &GraphApiError{
+			StatusCode: response.StatusCode,
+			Message:    message,
+		}
+	}
+
+	if dest == nil {
+		return nil
+	}
+
+	return json.Unmarshal(responseBody, dest)
+}
@@ -0,0 +1,383 @@
+package template_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	. "github.com/go-jet/jet/v2/postgres"
+	"github.com/go-jet/jet/v2/qrm"
+	"github.com/google/uuid"
+	"github.com/wapikit/wapikit/.db-generated/model"
+	table "github.com/wapikit/wapikit/.db-generated/table"
+	"github.com/wapikit/wapikit/api/api_types"
+	"github.com/wapikit/wapikit/utils"
+)
+
+const (
+	templateSyncInterval = 6 * time.Hour
+	templateSyncLockKey  = "template_sync_lock"
+	templateFields       = "id,name,language,category,status,rejected_reason,quality_score,components"
+)
+
+// whatsAppTemplate is a template as the graph API returns it
+type whatsAppTemplate struct {
+	Id             string `json:"id"`
+	Name           string `json:"name"`
+	Language       string `json:"language"`
+	Category       string `json:"category"`
+	Status         string `json:"status"`
+	RejectedReason string `json:"rejected_reason"`
+	QualityScore   *struct {
+		Score string `json:"score"`
+	} `json:"quality_score"`
+	Components []api_types.WhatsAppBusinessHSMWhatsAppHSMComponent `json:"components"`
+}
+
+// TemplateStatusChange is a change of the status of a template, as the webhook of whatsapp tells it
+type TemplateStatusChange struct {
+	Template       TemplateWithBusinessAccount
+	PreviousStatus model.MessageTemplateStatusEnum
+}
+
+// RunScheduler syncs the templates of all the business accounts periodically, the webhooks keep the templates current in between
+// but they are missed while the server is down, it is a blocking function and must be executed in a go routine
+func (service *TemplateService) RunScheduler(ctx context.Context) {
+	ticker := time.NewTicker(templateSyncInterval)
+	defer ticker.Stop()
+
+	// * the templates are synced once at startup, a server restarted more often than the interval would never sync them otherwise
+	service.syncAllTemplatesWithLock(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			service.syncAllTemplatesWithLock(ctx)
+		}
+	}
+}
+
+// syncAllTemplatesWithLock syncs the templates unless another instance of the server is already syncing them
+func (service *TemplateService) syncAllTemplatesWithLock(ctx context.Context) {
+	// * only one instance of the server must sync the templates at a time
+	lock, err := service.Redis.AcquireLock(templateSyncLockKey, templateSyncInterval)
+	if err != nil {
+		return
+	}
+
+	service.syncAllTemplates(ctx)
+
+	if err := service.Redis.ReleaseLock(lock); err != nil {
+		service.Logger.Error("error releasing template sync lock", "error", err.Error())
+	}
+}
+
+func (service *TemplateService) syncAllTemplates(ctx context.Context) {
+	var businessAccounts []model.WhatsappBusinessAccount
+
+	err := SELECT(table.WhatsappBusinessAccount.AllColumns).
+		FROM(table.WhatsappBusinessAccount).
+		WHERE(
+			table.WhatsappBusinessAccount.AccessToken.NOT_EQ(String("")).
+				AND(table.WhatsappBusinessAccount.AccountId.NOT_EQ(String(""))),
+		).
+		QueryContext(ctx, service.Db, &businessAccounts)
+
+	if err != nil && !errors.Is(err, qrm.ErrNoRows) {
+		service.Logger.Error("error fetching business accounts to sync the templates of", "error", err.Error())
+		return
+	}
+
+	for _, businessAccount := range businessAccounts {
+		if _, err := service.SyncTemplates(ctx, businessAccount); err != nil {
+			service.Logger.Error("error syncing templates", "business_account_id", businessAccount.AccountId, "error", err.Error())
+		}
+	}
+}
+
+// SyncOrganizationTemplates syncs the templates of all the business accounts of the organization and returns the number of templates synced
+func (service *TemplateService) SyncOrganizationTemplates(ctx context.Context, organizationId uuid.UUID) (int, error) {
+	businessAccounts, err := service.BusinessAccountService.ListBusinessAccounts(ctx, organizationId)
+	if err != nil {
+		return 0, err
+	}
+
+	syncedCount := 0
+	for _, businessAccount := range businessAccounts {
+		if businessAccount.AccessToken == "" || businessAccount.AccountId == "" {
+			continue
+		}
+
+		count, err := service.SyncTemplates(ctx, businessAccount)
+		if err != nil {
+			return syncedCount, err
+		}
+		syncedCount += count
+	}
+
+	return syncedCount, nil
+}
+
+// SyncTemplates fetches all the templates of the business account from whatsapp and stores them, the templates deleted in whatsapp
+// are marked as deleted so that the campaigns using them are paused
+func (service *TemplateService) SyncTemplates(ctx context.Context, businessAccount model.WhatsappBusinessAccount) (int, error) {
+	query := url.Values{}
+	query.Set("fields", templateFields)
+	query.Set("limit", "100")
+	nextPageUrl := fmt.Sprintf("%s/%s/message_templates?%s", whatsAppGraphApiUrl, businessAccount.AccountId, query.Encode())
+
+	syncedTemplateIds := []Expression{}
+	for nextPageUrl != "" {
+		var response struct {
+			Data   []whatsAppTemplate `json:"data"`
+			Paging struct {
+				Next string `json:"next"`
+			} `json:"paging"`
+		}
+
+		if err := service.graphRequest(ctx, businessAccount.AccessToken, http.MethodGet, nextPageUrl, nil, &response); err != nil {
+			return 0, err
+		}
+
+		for _, template := range response.Data {
+			if _, err := service.upsertTemplate(ctx, businessAccount, template); err != nil {
+				return 0, err
+			}
+			syncedTemplateIds = append(syncedTemplateIds, String(template.Id))
+		}
+
+		nextPageUrl = response.Paging.Next
+	}
+
+	whereCondition := table.MessageTemplate.WhatsappBusinessAccountId.EQ(UUID(businessAccount.UniqueId)).
+		AND(table.MessageTemplate.Status.NOT_EQ(utils.EnumExpression(model.MessageTemplateStatusEnum_Deleted.String())))
+	if len(syncedTemplateIds) > 0 {
+		whereCondition = whereCondition.AND(table.MessageTemplate.TemplateId.NOT_IN(syncedTemplateIds...))
+	}
+
+	_, err := table.MessageTemplate.
+		UPDATE(table.MessageTemplate.Status, table.MessageTemplate.UpdatedAt, table.MessageTemplate.LastSyncedAt).
+		SET(utils.EnumExpression(model.MessageTemplateStatusEnum_Deleted.String()), time.Now(), time.Now()).
+		WHERE(whereCondition).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(syncedTemplateIds), nil
+}
+
+// SyncTemplate fetches a single template from whatsapp and stores it
+func (service *TemplateService) SyncTemplate(ctx context.Context, businessAccount model.WhatsappBusinessAccount, templateId string) (*TemplateWithBusinessAccount, error) {
+	var template whatsAppTemplate
+
+	err := service.graphRequest(ctx, businessAccount.AccessToken, http.MethodGet, fmt.Sprintf("%s/%s?fields=%s", whatsAppGraphApiUrl, url.PathEscape(templateId), url.QueryEscape(templateFields)), nil, &template)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.upsertTemplate(ctx, businessAccount, template)
+}
+
+func (service *TemplateService) upsertTemplate(ctx context.Context, businessAccount model.WhatsappBusinessAccount, template whatsAppTemplate) (*TemplateWithBusinessAccount, error) {
+	status, ok := ParseTemplateStatus(template.Status)
+	if !ok {
+		status = model.MessageTemplateStatusEnum_Pending
+	}
+
+	quality := model.MessageTemplateQualityEnum_Unknown
+	if template.QualityScore != nil {
+		quality = ParseTemplateQuality(template.QualityScore.Score)
+	}
+
+	var statusReason *string
+	if reason := strings.TrimSpace(template.RejectedReason); reason != "" && reason != "NONE" {
+		statusReason = &reason
+	}
+
+	components := template.Components
+	if components == nil {
+		components = []api_types.WhatsAppBusinessHSMWhatsAppHSMComponent{}
+	}
+	componentsJson, err := json.Marshal(components)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var upsertedTemplate model.MessageTemplate
+
+	err = table.MessageTemplate.
+		INSERT(
+			table.MessageTemplate.UpdatedAt,
+			table.MessageTemplate.OrganizationId,
+			table.MessageTemplate.WhatsappBusinessAccountId,
+			table.MessageTemplate.TemplateId,
+			table.MessageTemplate.Name,
+			table.MessageTemplate.Language,
+			table.MessageTemplate.Category,
+			table.MessageTemplate.Status,
+			table.MessageTemplate.Quality,
+			table.MessageTemplate.StatusReason,
+			table.MessageTemplate.Components,
+			table.MessageTemplate.LastSyncedAt,
+		).
+		MODEL(model.MessageTemplate{
+			UpdatedAt:                 now,
+			OrganizationId:            businessAccount.OrganizationId,
+			WhatsappBusinessAccountId: businessAccount.UniqueId,
+			TemplateId:                template.Id,
+			Name:                      template.Name,
+			Language:                  template.Language,
+			Category:                  parseTemplateCategory(template.Category),
+			Status:                    status,
+			Quality:                   quality,
+			StatusReason:              statusReason,
+			Components:                string(componentsJson),
+			LastSyncedAt:              &now,
+		}).
+		ON_CONFLICT(table.MessageTemplate.TemplateId).
+		DO_UPDATE(
+			SET(
+				table.MessageTemplate.Name.SET(table.MessageTemplate.EXCLUDED.Name),
+				table.MessageTemplate.Language.SET(table.MessageTemplate.EXCLUDED.Language),
+				table.MessageTemplate.Category.SET(table.MessageTemplate.EXCLUDED.Category),
+				table.MessageTemplate.Status.SET(table.MessageTemplate.EXCLUDED.Status),
+				table.MessageTemplate.StatusReason.SET(table.MessageTemplate.EXCLUDED.StatusReason),
+				table.MessageTemplate.Components.SET(table.MessageTemplate.EXCLUDED.Components),
+				table.MessageTemplate.UpdatedAt.SET(table.MessageTemplate.EXCLUDED.UpdatedAt),
+				table.MessageTemplate.LastSyncedAt.SET(table.MessageTemplate.EXCLUDED.LastSyncedAt),
+				// * the quality is only known once the template has been sent enough, an unknown quality does not erase the one the webhook told
+				table.MessageTemplate.Quality.SET(
+					StringExp(CASE().
+						WHEN(table.MessageTemplate.EXCLUDED.Quality.EQ(utils.EnumExpression(model.MessageTemplateQualityEnum_Unknown.String()))).
+						THEN(table.MessageTemplate.Quality).
+						ELSE(table.MessageTemplate.EXCLUDED.Quality)),
+				),
+			).
+				// * a template id can not be taken over by the business account of another organization
+				WHERE(table.MessageTemplate.OrganizationId.EQ(UUID(businessAccount.OrganizationId))),
+		).
+		RETURNING(table.MessageTemplate.AllColumns).
+		QueryContext(ctx, service.Db, &upsertedTemplate)
+
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, err
+	}
+
+	return &TemplateWithBusinessAccount{
+		MessageTemplate:         upsertedTemplate,
+		WhatsappBusinessAccount: businessAccount,
+	}, nil
+}
+
+// getTemplateOfBusinessAccount returns the template with the given whatsapp id, the template is fetched from whatsapp if it
+// is not known yet, e.g. a template created in the whatsapp manager
+func (service *TemplateService) getTemplateOfBusinessAccount(ctx context.Context, businessAccountId, templateId string) (*TemplateWithBusinessAccount, error) {
+	var template TemplateWithBusinessAccount
+
+	err := SELECT(table.MessageTemplate.AllColumns, table.WhatsappBusinessAccount.AllColumns).
+		FROM(
+			table.MessageTemplate.
+				INNER_JOIN(table.WhatsappBusinessAccount, table.WhatsappBusinessAccount.UniqueId.EQ(table.MessageTemplate.WhatsappBusinessAccountId)),
+		).
+		WHERE(
+			table.MessageTemplate.TemplateId.EQ(String(templateId)).
+				AND(table.WhatsappBusinessAccount.AccountId.EQ(String(businessAccountId))),
+		).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &template)
+
+	if err == nil {
+		return &template, nil
+	}
+
+	if !errors.Is(err, qrm.ErrNoRows) {
+		return nil, err
+	}
+
+	var businessAccount model.WhatsappBusinessAccount
+	err = SELECT(table.WhatsappBusinessAccount.AllColumns).
+		FROM(table.WhatsappBusinessAccount).
+		WHERE(table.WhatsappBusinessAccount.AccountId.EQ(String(businessAccountId))).
+		LIMIT(1).
+		QueryContext(ctx, service.Db, &businessAccount)
+
+	if err != nil {
+		if errors.Is(err, qrm.ErrNoRows) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, err
+	}
+
+	return service.SyncTemplate(ctx, businessAccount, templateId)
+}
+
+// UpdateTemplateStatus stores the status of a template whatsapp told with the template webhook, along with the reason of a rejection or a pause
+func (service *TemplateService) UpdateTemplateStatus(ctx context.Context, businessAccountId, templateId string, status model.MessageTemplateStatusEnum, reason string) (*TemplateStatusChange, error) {
+	template, err := service.getTemplateOfBusinessAccount(ctx, businessAccountId, templateId)
+	if err != nil {
+		return nil, err
+	}
+
+	statusChange := TemplateStatusChange{
+		Template:       *template,
+		PreviousStatus: template.Status,
+	}
+
+	var statusReason *string
+	if reason = strings.TrimSpace(reason); reason != "" && reason != "NONE" {
+		statusReason = &reason
+	}
+
+	var updatedTemplate model.MessageTemplate
+	err = table.MessageTemplate.
+		UPDATE(table.MessageTemplate.Status, table.MessageTemplate.StatusReason, table.MessageTemplate.UpdatedAt).
+		MODEL(model.MessageTemplate{
+			Status:       status,
+			StatusReason: statusReason,
+			UpdatedAt:    time.Now(),
+		}).
+		WHERE(table.MessageTemplate.UniqueId.EQ(UUID(template.UniqueId))).
+		RETURNING(table.MessageTemplate.AllColumns).
+		QueryContext(ctx, service.Db, &updatedTemplate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	statusChange.Template.MessageTemplate = updatedTemplate
+	return &statusChange, nil
+}
+
+// UpdateTemplateQuality stores the quality score of a template whatsapp told with the template quality webhook
+func (service *TemplateService) UpdateTemplateQuality(ctx context.Context, businessAccountId, templateId string, quality model.MessageTemplateQualityEnum) (*TemplateWithBusinessAccount, error) {
+	template, err := service.getTemplateOfBusinessAccount(ctx, businessAccountId, templateId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = table.MessageTemplate.
+		UPDATE(table.MessageTemplate.Quality, table.MessageTemplate.UpdatedAt).
+		SET(utils.EnumExpression(quality.String()), time.Now()).
+		WHERE(table.MessageTemplate.UniqueId.EQ(UUID(template.UniqueId))).
+		ExecContext(ctx, service.Db)
+
+	if err != nil {
+		return nil, err
+	}
+
+	template.Quality = quality
+	return template, nil
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress is returned by the public http client when the url points to an address not reachable on the internet
+var ErrPrivateAddress = errors.New("the url points to a private address")
+
+// maxPublicHttpRedirects is the number of redirects a public http client follows
+const maxPublicHttpRedirects = 5
+
+// nonPublicIpNetworks are the ranges the net package does not classify, the carrier grade NAT range and the "this network" range
+var nonPublicIpNetworks = []*net.IPNet{
+	mustParseCidr("100.64.0.0/10"),
+	mustParseCidr("0.0.0.0/8"),
+}
+
+func mustParseCidr(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// IsPublicIp tells if the ip is reachable on the internet, the loopback, private, carrier grade NAT, link local and multicast addresses are not
+func IsPublicIp(ip net.IP) bool {
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range nonPublicIpNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewPublicHttpClient returns a http client which only connects to public addresses, the address is checked at connection time
+// so that neither a dns answer nor a redirect can point the server at the internal network
+func NewPublicHttpClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if !IsPublicIp(net.ParseIP(host)) {
+				return ErrPrivateAddress
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: func(request *http.Request, via []*http.Request) error {
+			if len(via) >= maxPublicHttpRedirects {
+				return errors.New("too many redirects")
+			}
+			if request.URL.Scheme != "http" && request.URL.Scheme != "https" {
+				return errors.New("redirect to an unsupported url, only http and https urls are supported")
+			}
+			return nil
+		},
+	}
+}